@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/client/dashboard"
 )
 
 type RayJobSubmissionServiceServerOptions struct {
@@ -33,13 +35,14 @@ type RayJobSubmissionServiceServer struct {
 	log           logr.Logger
 	api.UnimplementedRayJobSubmissionServiceServer
 
-	dashboardClientFunc func() utils.RayDashboardClientInterface
+	// httpClient makes the requests to the Ray dashboards, which are reached through their head Services.
+	httpClient *http.Client
 }
 
 // Create RayJobSubmissionServiceServer
 func NewRayJobSubmissionServiceServer(clusterServer *ClusterServer, options *RayJobSubmissionServiceServerOptions) *RayJobSubmissionServiceServer {
 	zl := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
-	return &RayJobSubmissionServiceServer{clusterServer: clusterServer, options: options, log: zerologr.New(&zl).WithName("jobsubmissionservice"), dashboardClientFunc: utils.GetRayDashboardClientFunc(nil, false)}
+	return &RayJobSubmissionServiceServer{clusterServer: clusterServer, options: options, log: zerologr.New(&zl).WithName("jobsubmissionservice"), httpClient: &http.Client{Timeout: utils.DefaultDashboardClientTimeout}}
 }
 
 // Submit Ray job
@@ -50,12 +53,9 @@ func (s *RayJobSubmissionServiceServer) SubmitRayJob(ctx context.Context, req *a
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
 	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
-	request := &utils.RayJobRequest{Entrypoint: req.Jobsubmission.Entrypoint}
+	rayDashboardClient := dashboard.NewClient("http://"+*url, s.httpClient)
+	request := &dashboard.RayJobRequest{Entrypoint: req.Jobsubmission.Entrypoint}
 	if req.Jobsubmission.SubmissionId != "" {
 		request.SubmissionId = req.Jobsubmission.SubmissionId
 	}
@@ -90,7 +90,7 @@ func (s *RayJobSubmissionServiceServer) SubmitRayJob(ctx context.Context, req *a
 		}
 	}
 
-	sid, err := rayDashboardClient.SubmitJobReq(ctx, request, nil)
+	sid, err := rayDashboardClient.SubmitJob(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -105,18 +105,15 @@ func (s *RayJobSubmissionServiceServer) GetJobDetails(ctx context.Context, req *
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
 	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
+	rayDashboardClient := dashboard.NewClient("http://"+*url, s.httpClient)
 	nodeInfo, err := rayDashboardClient.GetJobInfo(ctx, req.Submissionid)
 	if err != nil {
+		if dashboard.IsNotFound(err) {
+			return nil, apierrors.NewNotFound(schema.GroupResource{Group: "RayJob", Resource: "JobSubmission"}, req.Submissionid)
+		}
 		return nil, err
 	}
-	if nodeInfo == nil {
-		return nil, apierrors.NewNotFound(schema.GroupResource{Group: "RayJob", Resource: "JobSubmission"}, req.Submissionid)
-	}
 	return convertNodeInfo(nodeInfo), nil
 }
 
@@ -128,19 +125,16 @@ func (s *RayJobSubmissionServiceServer) GetJobLog(ctx context.Context, req *api.
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
 	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
-	jlog, err := rayDashboardClient.GetJobLog(ctx, req.Submissionid)
+	rayDashboardClient := dashboard.NewClient("http://"+*url, s.httpClient)
+	jlog, err := rayDashboardClient.GetJobLogs(ctx, req.Submissionid)
 	if err != nil {
+		if dashboard.IsNotFound(err) {
+			return nil, apierrors.NewNotFound(schema.GroupResource{Group: "RayJob", Resource: "JobSubmission"}, req.Submissionid)
+		}
 		return nil, err
 	}
-	if jlog == nil {
-		return nil, apierrors.NewNotFound(schema.GroupResource{Group: "RayJob", Resource: "JobSubmission"}, req.Submissionid)
-	}
-	return &api.GetJobLogReply{Log: *jlog}, nil
+	return &api.GetJobLogReply{Log: jlog}, nil
 }
 
 // List jobs
@@ -151,17 +145,14 @@ func (s *RayJobSubmissionServiceServer) ListJobDetails(ctx context.Context, req
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
 	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
+	rayDashboardClient := dashboard.NewClient("http://"+*url, s.httpClient)
 	nodesInfo, err := rayDashboardClient.ListJobs(ctx)
 	if err != nil {
 		return nil, err
 	}
 	submissions := make([]*api.JobSubmissionInfo, 0)
-	for _, nodeInfo := range *nodesInfo {
+	for _, nodeInfo := range nodesInfo {
 		submissions = append(submissions, convertNodeInfo(&nodeInfo))
 	}
 	return &api.ListJobSubmissionInfo{Submissions: submissions}, nil
@@ -175,12 +166,9 @@ func (s *RayJobSubmissionServiceServer) StopRayJob(ctx context.Context, req *api
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
 	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
-	err = rayDashboardClient.StopJob(ctx, req.Submissionid)
+	rayDashboardClient := dashboard.NewClient("http://"+*url, s.httpClient)
+	_, err = rayDashboardClient.StopJob(ctx, req.Submissionid)
 	if err != nil {
 		return nil, err
 	}
@@ -195,11 +183,8 @@ func (s *RayJobSubmissionServiceServer) DeleteRayJob(ctx context.Context, req *a
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
 	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
+	rayDashboardClient := dashboard.NewClient("http://"+*url, s.httpClient)
 	err = rayDashboardClient.DeleteJob(ctx, req.Submissionid)
 	if err != nil {
 		return nil, err
@@ -221,7 +206,7 @@ func (s *RayJobSubmissionServiceServer) getRayClusterURL(ctx context.Context, re
 	return &url, nil
 }
 
-func convertNodeInfo(info *utils.RayJobInfo) *api.JobSubmissionInfo {
+func convertNodeInfo(info *dashboard.RayJobInfo) *api.JobSubmissionInfo {
 	jsi := api.JobSubmissionInfo{
 		Entrypoint:   info.Entrypoint,
 		JobId:        info.JobId,
@@ -90,7 +90,7 @@ func NewRayJob(apiJob *api.RayJob, computeTemplateMap map[string]*api.ComputeTem
 	}
 	if apiJob.EntrypointNumGpus > 0 {
 		// Entry point number of GPUs
-		rayJob.Spec.DeepCopy().EntrypointNumGpus = apiJob.EntrypointNumGpus
+		rayJob.Spec.EntrypointNumGpus = apiJob.EntrypointNumGpus
 	}
 	if apiJob.EntrypointResources != "" {
 		// Entry point resources
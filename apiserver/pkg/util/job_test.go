@@ -51,7 +51,9 @@ var apiJobExistingClusterSubmitter = &api.RayJob{
 		Cpu:    "400m",
 		Memory: "150Mi",
 	},
-	EntrypointNumCpus: 2,
+	EntrypointNumCpus:   2,
+	EntrypointNumGpus:   1,
+	EntrypointResources: `{"Custom_1": 1}`,
 }
 
 var apiJobExistingClusterSubmitterBadParams = &api.RayJob{
@@ -110,6 +112,8 @@ func TestBuildRayJob(t *testing.T) {
 	assert.NotNil(t, job.Spec.ClusterSelector)
 	assert.Nil(t, job.Spec.RayClusterSpec)
 	assert.Equal(t, float32(2), job.Spec.EntrypointNumCpus)
+	assert.Equal(t, float32(1), job.Spec.EntrypointNumGpus)
+	assert.Equal(t, `{"Custom_1": 1}`, job.Spec.EntrypointResources)
 	assert.NotNil(t, job.Spec.SubmitterPodTemplate)
 	assert.Equal(t, "ray-job-submitter", job.Spec.SubmitterPodTemplate.Spec.Containers[0].Name)
 	assert.Equal(t, "image", job.Spec.SubmitterPodTemplate.Spec.Containers[0].Image)
@@ -0,0 +1,145 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// rayResources are the KubeRay resources in an archive, in the order in which they are imported.
+var rayResources = []schema.GroupVersionResource{
+	{Group: "ray.io", Version: "v1", Resource: "rayclusters"},
+	{Group: "ray.io", Version: "v1", Resource: "rayjobs"},
+	{Group: "ray.io", Version: "v1", Resource: "rayservices"},
+}
+
+// exportedStatusFields are the status fields of each resource that are kept in an archive. They are not
+// restored on import because the KubeRay operator recalculates the status, but they tell users and the
+// import command which state the resource was in, e.g. whether a RayJob has already finished.
+var exportedStatusFields = map[string][]string{
+	"rayclusters": {"state"},
+	"rayjobs":     {"jobId", "jobStatus", "jobDeploymentStatus", "startTime", "endTime"},
+	"rayservices": {"serviceStatus"},
+}
+
+// lastAppliedConfigAnnotationKey is the annotation set by `kubectl apply`, which is not exported.
+const lastAppliedConfigAnnotationKey = "kubectl.kubernetes.io/last-applied-configuration"
+
+// archiveEntry is a resource stored in an archive at {resource}/{namespace}/{name}.yaml.
+type archiveEntry struct {
+	gvr    schema.GroupVersionResource
+	object *unstructured.Unstructured
+}
+
+func (entry archiveEntry) path() string {
+	return path.Join(entry.gvr.Resource, entry.object.GetNamespace(), entry.object.GetName()+".yaml")
+}
+
+// sanitize returns a copy of the object that only keeps the fields needed to recreate it in another
+// Kubernetes cluster: the name, namespace, labels, and annotations, the spec, and selected status fields.
+func sanitize(resource string, object *unstructured.Unstructured) *unstructured.Unstructured {
+	exported := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	exported.SetAPIVersion(object.GetAPIVersion())
+	exported.SetKind(object.GetKind())
+	exported.SetName(object.GetName())
+	exported.SetNamespace(object.GetNamespace())
+	exported.SetLabels(object.GetLabels())
+	annotations := object.GetAnnotations()
+	delete(annotations, lastAppliedConfigAnnotationKey)
+	exported.SetAnnotations(annotations)
+	if spec, ok := object.Object["spec"]; ok {
+		exported.Object["spec"] = spec
+	}
+
+	status, _ := object.Object["status"].(map[string]interface{})
+	selectedStatus := map[string]interface{}{}
+	for _, field := range exportedStatusFields[resource] {
+		if value, ok := status[field]; ok {
+			selectedStatus[field] = value
+		}
+	}
+	if len(selectedStatus) > 0 {
+		exported.Object["status"] = selectedStatus
+	}
+	return exported
+}
+
+// writeArchive writes the entries to a gzipped tarball.
+func writeArchive(w io.Writer, entries []archiveEntry) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, entry := range entries {
+		data, err := yaml.Marshal(entry.object.Object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", entry.path(), err)
+		}
+		header := &tar.Header{Name: entry.path(), Mode: 0o644, Size: int64(len(data))}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// readArchive reads the entries of a gzipped tarball written by writeArchive. The entries are
+// ordered by rayResources so that, e.g., RayClusters are imported before the RayJobs using them.
+func readArchive(r io.Reader) ([]archiveEntry, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gzipReader.Close()
+
+	gvrs := map[string]schema.GroupVersionResource{}
+	for _, gvr := range rayResources {
+		gvrs[gvr.Resource] = gvr
+	}
+	entriesByResource := map[string][]archiveEntry{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		resource := strings.Split(header.Name, "/")[0]
+		gvr, ok := gvrs[resource]
+		if !ok {
+			return nil, fmt.Errorf("unknown resource %q in archive entry %s", resource, header.Name)
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive entry %s: %w", header.Name, err)
+		}
+		object := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(data, &object.Object); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal archive entry %s: %w", header.Name, err)
+		}
+		entriesByResource[resource] = append(entriesByResource[resource], archiveEntry{gvr: gvr, object: object})
+	}
+
+	entries := []archiveEntry{}
+	for _, gvr := range rayResources {
+		entries = append(entries, entriesByResource[gvr.Resource]...)
+	}
+	return entries, nil
+}
@@ -0,0 +1,122 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+var listKinds = map[schema.GroupVersionResource]string{
+	rayResources[0]: "RayClusterList",
+	rayResources[1]: "RayJobList",
+	rayResources[2]: "RayServiceList",
+}
+
+func newRayObject(kind string, name string, status map[string]interface{}) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":            name,
+				"namespace":       "test",
+				"uid":             "fake-uid",
+				"resourceVersion": "1",
+				"annotations": map[string]interface{}{
+					lastAppliedConfigAnnotationKey: "{}",
+					"foo":                          "bar",
+				},
+			},
+			"spec": map[string]interface{}{"suspend": false},
+		},
+	}
+	if status != nil {
+		object.Object["status"] = status
+	}
+	return object
+}
+
+func TestSanitize(t *testing.T) {
+	object := newRayObject("RayJob", "rayjob", map[string]interface{}{
+		"jobId":               "rayjob-abc",
+		"jobDeploymentStatus": "Running",
+		"observedGeneration":  int64(1),
+	})
+
+	exported := sanitize("rayjobs", object)
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "ray.io/v1",
+		"kind":       "RayJob",
+		"metadata": map[string]interface{}{
+			"name":        "rayjob",
+			"namespace":   "test",
+			"annotations": map[string]interface{}{"foo": "bar"},
+		},
+		"spec": map[string]interface{}{"suspend": false},
+		"status": map[string]interface{}{
+			"jobId":               "rayjob-abc",
+			"jobDeploymentStatus": "Running",
+		},
+	}, exported.Object)
+}
+
+// Export the resources of a Kubernetes cluster and import them into another one.
+func TestExportImport(t *testing.T) {
+	ownedCluster := newRayObject("RayCluster", "rayjob-raycluster", nil)
+	ownedCluster.SetOwnerReferences([]v1.OwnerReference{{APIVersion: "ray.io/v1", Kind: "RayJob", Name: "running-job", UID: "fake-uid"}})
+	objects := []runtime.Object{
+		newRayObject("RayCluster", "raycluster", map[string]interface{}{"state": "ready"}),
+		ownedCluster,
+		newRayObject("RayJob", "running-job", map[string]interface{}{"jobDeploymentStatus": "Running"}),
+		newRayObject("RayJob", "finished-job", map[string]interface{}{"jobDeploymentStatus": "Complete"}),
+		newRayObject("RayService", "rayservice", nil),
+	}
+	archive := filepath.Join(t.TempDir(), "ray-resources.tar.gz")
+
+	sourceFactory := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer sourceFactory.Cleanup()
+	sourceFactory.FakeDynamicClient = fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objects...)
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	exportOptions := NewExportOptions(testStreams)
+	exportOptions.file = archive
+	*exportOptions.configFlags.Namespace = "test"
+	err := exportOptions.Run(context.Background(), sourceFactory)
+	assert.Nil(t, err)
+
+	targetFactory := cmdtesting.NewTestFactory()
+	defer targetFactory.Cleanup()
+	targetFactory.FakeDynamicClient = fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	testStreams, _, resBuf, _ := genericclioptions.NewTestIOStreams()
+	importOptions := NewImportOptions(testStreams)
+	importOptions.file = archive
+	*importOptions.configFlags.Namespace = "restored"
+	err = importOptions.Run(context.Background(), targetFactory)
+	assert.Nil(t, err)
+
+	expectedOutput := bytes.Buffer{}
+	expectedOutput.WriteString("rayclusters/test/raycluster.yaml imported\n")
+	expectedOutput.WriteString("rayjobs/test/finished-job.yaml skipped: the RayJob had already finished\n")
+	expectedOutput.WriteString("rayjobs/test/running-job.yaml imported\n")
+	expectedOutput.WriteString("rayservices/test/rayservice.yaml imported\n")
+	assert.Equal(t, expectedOutput.String(), resBuf.String())
+
+	for _, gvr := range rayResources {
+		list, err := targetFactory.FakeDynamicClient.Resource(gvr).Namespace("restored").List(context.Background(), v1.ListOptions{})
+		assert.Nil(t, err)
+		for _, item := range list.Items {
+			assert.NotContains(t, item.Object, "status")
+			assert.Empty(t, item.GetUID())
+		}
+		assert.Len(t, list.Items, 1)
+	}
+}
@@ -0,0 +1,131 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+type ExportOptions struct {
+	configFlags   *genericclioptions.ConfigFlags
+	ioStreams     *genericclioptions.IOStreams
+	file          string
+	AllNamespaces bool
+}
+
+func NewExportOptions(streams genericclioptions.IOStreams) *ExportOptions {
+	return &ExportOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+	}
+}
+
+func NewExportCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	options := NewExportOptions(streams)
+	// Initialize the factory for later use with the current config flag
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:   "export -f FILE",
+		Short: "Export RayClusters, RayJobs, and RayServices to an archive.",
+		Long: `Export the RayClusters, RayJobs, and RayServices in a namespace to a gzipped tarball that can be imported into another Kubernetes cluster with "kubectl ray import".
+The archive contains the spec and selected status fields of each resource. RayClusters managed by RayJobs or RayServices are not exported because they are recreated by their owners.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			// running cmd.Execute or cmd.ExecuteE sets the context, which will be done by root
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().StringVarP(&options.file, "file", "f", options.file, "Path of the archive to write, or - to write it to stdout.")
+	cmd.Flags().BoolVarP(&options.AllNamespaces, "all-namespaces", "A", options.AllNamespaces, "If present, export the resources in all namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *ExportOptions) Complete() error {
+	if *options.configFlags.Namespace == "" {
+		options.AllNamespaces = true
+	}
+	return nil
+}
+
+func (options *ExportOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.file == "" {
+		return fmt.Errorf("the archive must be specified with --file")
+	}
+	return nil
+}
+
+func (options *ExportOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	// Retrieves the dynamic client with factory.
+	dynamicClient, err := factory.DynamicClient()
+	if err != nil {
+		return fmt.Errorf("dynamic client failed to initialize: %w", err)
+	}
+
+	entries := []archiveEntry{}
+	for _, gvr := range rayResources {
+		var list *unstructured.UnstructuredList
+		if options.AllNamespaces {
+			list, err = dynamicClient.Resource(gvr).List(ctx, v1.ListOptions{})
+		} else {
+			list, err = dynamicClient.Resource(gvr).Namespace(*options.configFlags.Namespace).List(ctx, v1.ListOptions{})
+		}
+		if err != nil {
+			return fmt.Errorf("unable to retrieve %s: %w", gvr.Resource, err)
+		}
+		// Sort the resources so that the archive does not depend on the order returned by the API server.
+		sort.Slice(list.Items, func(i, j int) bool {
+			return path.Join(list.Items[i].GetNamespace(), list.Items[i].GetName()) < path.Join(list.Items[j].GetNamespace(), list.Items[j].GetName())
+		})
+		for i := range list.Items {
+			// Resources owned by other resources, e.g. the RayCluster of a RayJob, are recreated by their owners.
+			if len(list.Items[i].GetOwnerReferences()) > 0 {
+				continue
+			}
+			entries = append(entries, archiveEntry{gvr: gvr, object: sanitize(gvr.Resource, &list.Items[i])})
+		}
+	}
+
+	var out io.Writer = options.ioStreams.Out
+	if options.file != "-" {
+		file, err := os.Create(options.file)
+		if err != nil {
+			return fmt.Errorf("unable to create archive: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := writeArchive(out, entries); err != nil {
+		return fmt.Errorf("unable to write archive: %w", err)
+	}
+	if options.file != "-" {
+		for _, entry := range entries {
+			fmt.Fprintf(options.ioStreams.Out, "%s exported\n", entry.path())
+		}
+	}
+	return nil
+}
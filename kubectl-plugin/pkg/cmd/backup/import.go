@@ -0,0 +1,123 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+type ImportOptions struct {
+	configFlags         *genericclioptions.ConfigFlags
+	ioStreams           *genericclioptions.IOStreams
+	file                string
+	IncludeFinishedJobs bool
+}
+
+func NewImportOptions(streams genericclioptions.IOStreams) *ImportOptions {
+	return &ImportOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+	}
+}
+
+func NewImportCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	options := NewImportOptions(streams)
+	// Initialize the factory for later use with the current config flag
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:   "import -f FILE",
+		Short: "Import RayClusters, RayJobs, and RayServices from an archive.",
+		Long: `Create the RayClusters, RayJobs, and RayServices in an archive written by "kubectl ray export".
+Resources that already exist are skipped. The resources are created in their original namespaces unless --namespace is specified.
+RayJobs that had already finished when they were exported are skipped unless --include-finished-jobs is specified, because importing a RayJob runs it again.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			// running cmd.Execute or cmd.ExecuteE sets the context, which will be done by root
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().StringVarP(&options.file, "file", "f", options.file, "Path of the archive to read, or - to read it from stdin.")
+	cmd.Flags().BoolVar(&options.IncludeFinishedJobs, "include-finished-jobs", options.IncludeFinishedJobs, "If present, also import the RayJobs that had already finished when they were exported.")
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *ImportOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.file == "" {
+		return fmt.Errorf("the archive must be specified with --file")
+	}
+	return nil
+}
+
+func (options *ImportOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	// Retrieves the dynamic client with factory.
+	dynamicClient, err := factory.DynamicClient()
+	if err != nil {
+		return fmt.Errorf("dynamic client failed to initialize: %w", err)
+	}
+
+	var in io.Reader = options.ioStreams.In
+	if options.file != "-" {
+		file, err := os.Open(options.file)
+		if err != nil {
+			return fmt.Errorf("unable to open archive: %w", err)
+		}
+		defer file.Close()
+		in = file
+	}
+	entries, err := readArchive(in)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.gvr.Resource == "rayjobs" && !options.IncludeFinishedJobs && isFinishedRayJob(entry.object) {
+			fmt.Fprintf(options.ioStreams.Out, "%s skipped: the RayJob had already finished\n", entry.path())
+			continue
+		}
+
+		object := entry.object.DeepCopy()
+		// The KubeRay operator recalculates the status.
+		delete(object.Object, "status")
+		if namespace := *options.configFlags.Namespace; namespace != "" {
+			object.SetNamespace(namespace)
+		}
+
+		_, err := dynamicClient.Resource(entry.gvr).Namespace(object.GetNamespace()).Create(ctx, object, v1.CreateOptions{})
+		if k8serrors.IsAlreadyExists(err) {
+			fmt.Fprintf(options.ioStreams.Out, "%s skipped: already exists\n", entry.path())
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unable to import %s: %w", entry.path(), err)
+		}
+		fmt.Fprintf(options.ioStreams.Out, "%s imported\n", entry.path())
+	}
+	return nil
+}
+
+// isFinishedRayJob checks whether the exported RayJob had already finished.
+func isFinishedRayJob(object *unstructured.Unstructured) bool {
+	status, _, _ := unstructured.NestedString(object.Object, "status", "jobDeploymentStatus")
+	return status == "Complete" || status == "Failed"
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/backup"
 	cluster "github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/cluster"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
@@ -19,5 +20,7 @@ func NewRayCommand(streams genericclioptions.IOStreams) *cobra.Command {
 	}
 
 	cmd.AddCommand(cluster.NewClusterCommand(streams))
+	cmd.AddCommand(backup.NewExportCommand(streams))
+	cmd.AddCommand(backup.NewImportCommand(streams))
 	return cmd
 }
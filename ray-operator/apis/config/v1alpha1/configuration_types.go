@@ -3,8 +3,10 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
@@ -20,6 +22,10 @@ type Configuration struct {
 	// ProbeAddr is the address the probe endpoint binds to.
 	ProbeAddr string `json:"probeAddr,omitempty"`
 
+	// PprofBindAddress is the address the pprof endpoints bind to, e.g. `:6060`, so that the CPU, memory and goroutine
+	// profiles of the operator can be collected in-cluster. If empty, the pprof endpoints are disabled.
+	PprofBindAddress string `json:"pprofBindAddress,omitempty"`
+
 	// EnableLeaderElection enables leader election. Enabling this will ensure
 	// there is only one active instance of the operator.
 	EnableLeaderElection *bool `json:"enableLeaderElection,omitempty"`
@@ -28,10 +34,31 @@ type Configuration struct {
 	// resources live. Defaults to the pod namesapce if not set.
 	LeaderElectionNamespace string `json:"leaderElectionNamespace,omitempty"`
 
+	// LeaderElectionID is the name of the Lease used for leader election. Operators sharing the same ID and namespace
+	// are replicas of each other, of which only the leader reconciles. Defaults to `ray-operator-leader`.
+	LeaderElectionID string `json:"leaderElectionID,omitempty"`
+
+	// LeaderElectionLeaseDuration is how long the replicas wait before acquiring the leadership once the leader stopped
+	// renewing it. Defaults to 15s.
+	LeaderElectionLeaseDuration metav1.Duration `json:"leaderElectionLeaseDuration,omitempty"`
+
+	// LeaderElectionRenewDeadline is how long the leader retries renewing the leadership before giving it up. It must be
+	// less than the lease duration. Defaults to 10s.
+	LeaderElectionRenewDeadline metav1.Duration `json:"leaderElectionRenewDeadline,omitempty"`
+
+	// LeaderElectionRetryPeriod is how long the replicas wait between the attempts to acquire or renew the leadership. It
+	// must be less than the renew deadline. Defaults to 2s.
+	LeaderElectionRetryPeriod metav1.Duration `json:"leaderElectionRetryPeriod,omitempty"`
+
 	// WatchNamespace specifies a list of namespaces to watch for custom resources, separated by commas.
 	// If empty, all namespaces will be watched.
 	WatchNamespace string `json:"watchNamespace,omitempty"`
 
+	// WatchLabelSelector restricts the custom resources watched by the operator to the ones matching this label
+	// selector, e.g. `team=ml`, so that multiple operator instances can each manage a shard of the custom resources.
+	// The RayClusters created for RayJobs and RayServices inherit their labels. If empty, all of them are watched.
+	WatchLabelSelector string `json:"watchLabelSelector,omitempty"`
+
 	// LogFile is a path to a local file for synchronizing logs.
 	LogFile string `json:"logFile,omitempty"`
 
@@ -43,6 +70,19 @@ type Configuration struct {
 	// Defaults to `json` if empty.
 	LogStdoutEncoder string `json:"logStdoutEncoder,omitempty"`
 
+	// LogLevel is the minimum level of the logs, e.g. "debug", "info" or "error". The operator reloads it from the
+	// config file periodically, so that it can be changed without restarting the operator. Defaults to the level set
+	// by the `--zap-log-level` flag if empty.
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// LogStacktraceLevel is the minimum level of the logs with a stacktrace, e.g. "error" or "panic". Defaults to the
+	// level set by the `--zap-stacktrace-level` flag if empty.
+	LogStacktraceLevel string `json:"logStacktraceLevel,omitempty"`
+
+	// FeatureGates enables or disables the feature gates of the operator, e.g. `RayClusterStatusConditions: true`.
+	// It takes precedence over the `--feature-gates` flag.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
 	// HeadSidecarContainers includes specification for a sidecar container
 	// to inject into every Head pod.
 	HeadSidecarContainers []corev1.Container `json:"headSidecarContainers,omitempty"`
@@ -51,9 +91,64 @@ type Configuration struct {
 	// to inject into every Worker pod.
 	WorkerSidecarContainers []corev1.Container `json:"workerSidecarContainers,omitempty"`
 
+	// EnableAcceleratorTolerations adds tolerations for the taints of well-known accelerator nodes, such as
+	// "nvidia.com/gpu" and "google.com/tpu", to the Ray Pods requesting the corresponding resources.
+	EnableAcceleratorTolerations bool `json:"enableAcceleratorTolerations,omitempty"`
+
+	// AcceleratorResourceMapping maps extended resources, such as "google.com/tpu" or "habana.ai/gaudi", to Ray
+	// custom resources. The Ray nodes advertise the mapped resources in `ray start --resources` with the quantities
+	// of their Ray container's limits. Worker groups can override it with the `ray.io/accelerator-resources` annotation.
+	AcceleratorResourceMapping map[string]string `json:"acceleratorResourceMapping,omitempty"`
+
+	// EnvironmentTiers maps environment tiers, such as dev, staging and prod, to the defaults that the operator
+	// enforces on the RayClusters labeled with `ray.io/environment-tier: <tier>`. This allows stricter guardrails
+	// for production clusters without repeating them in every custom resource.
+	EnvironmentTiers map[string]EnvironmentTierPolicy `json:"environmentTiers,omitempty"`
+
+	// StuckPodTimeout is how long a worker Pod can stay terminating, or in the Unknown phase, before KubeRay
+	// force-deletes it and creates a replacement, e.g. when its node is gone. Disabled if zero.
+	StuckPodTimeout metav1.Duration `json:"stuckPodTimeout,omitempty"`
+
+	// EnablePrometheusMonitors creates Prometheus Operator PodMonitors scraping the Ray metrics of the head and worker
+	// Pods of each RayCluster, and the autoscaler and dashboard metrics of its head Pod.
+	EnablePrometheusMonitors bool `json:"enablePrometheusMonitors,omitempty"`
+
+	// PrometheusMonitorLabels are added to the labels of the PodMonitors, e.g. to match the podMonitorSelector of the
+	// Prometheus, such as `release: prometheus` for the kube-prometheus-stack Helm chart.
+	PrometheusMonitorLabels map[string]string `json:"prometheusMonitorLabels,omitempty"`
+
 	// ReconcileConcurrency is the max concurrency for each reconciler.
 	ReconcileConcurrency int `json:"reconcileConcurrency,omitempty"`
 
+	// RayClusterMaxConcurrentReconciles is the max concurrency of the RayCluster reconciler.
+	// Defaults to ReconcileConcurrency.
+	RayClusterMaxConcurrentReconciles int `json:"rayClusterMaxConcurrentReconciles,omitempty"`
+
+	// RayJobMaxConcurrentReconciles is the max concurrency of the RayJob reconciler.
+	// Defaults to ReconcileConcurrency.
+	RayJobMaxConcurrentReconciles int `json:"rayJobMaxConcurrentReconciles,omitempty"`
+
+	// RayServiceMaxConcurrentReconciles is the max concurrency of the RayService reconciler.
+	// Defaults to ReconcileConcurrency.
+	RayServiceMaxConcurrentReconciles int `json:"rayServiceMaxConcurrentReconciles,omitempty"`
+
+	// RateLimiterBaseDelay is the delay before the first retry of a custom resource whose reconciliation failed.
+	// The delay doubles on each consecutive failure up to RateLimiterMaxDelay. Defaults to 5ms if zero.
+	RateLimiterBaseDelay metav1.Duration `json:"rateLimiterBaseDelay,omitempty"`
+
+	// RateLimiterMaxDelay is the maximum delay before the retry of a custom resource whose reconciliation failed.
+	// Defaults to 1000s if zero.
+	RateLimiterMaxDelay metav1.Duration `json:"rateLimiterMaxDelay,omitempty"`
+
+	// SyncPeriod is the period after which all the watched resources are reconciled again, even if they did not
+	// change. Defaults to 10 hours if zero.
+	SyncPeriod metav1.Duration `json:"syncPeriod,omitempty"`
+
+	// GracefulShutdownTimeout is how long the operator waits for the in-flight reconciliations to finish when it stops,
+	// e.g. during an upgrade. It should be less than the termination grace period of the operator Pod. Defaults to
+	// 30s.
+	GracefulShutdownTimeout metav1.Duration `json:"gracefulShutdownTimeout,omitempty"`
+
 	// EnableBatchScheduler enables the batch scheduler. Currently this is supported
 	// by Volcano to support gang scheduling.
 	EnableBatchScheduler bool `json:"enableBatchScheduler,omitempty"`
@@ -64,12 +159,73 @@ type Configuration struct {
 	// connectivity to Pods.
 	UseKubernetesProxy bool `json:"useKubernetesProxy,omitempty"`
 
+	// DashboardClientTimeout bounds each request of the operator to the Ray dashboards, including its retries, so that
+	// an unreachable head Pod does not block the reconciliations. Defaults to 2s if zero.
+	DashboardClientTimeout metav1.Duration `json:"dashboardClientTimeout,omitempty"`
+
+	// DashboardClientMaxRetries is the number of times an idempotent request to a Ray dashboard is retried with an
+	// exponential backoff on connection errors and 502, 503 and 504 responses. Defaults to 2. Disabled if zero.
+	DashboardClientMaxRetries *int `json:"dashboardClientMaxRetries,omitempty"`
+
+	// DashboardClientCircuitBreakerThreshold is the number of consecutive failed requests to a Ray dashboard after which
+	// the requests to it fail fast for DashboardClientCircuitBreakerCooldown. Defaults to 5. Disabled if zero.
+	DashboardClientCircuitBreakerThreshold *int `json:"dashboardClientCircuitBreakerThreshold,omitempty"`
+
+	// DashboardClientCircuitBreakerCooldown is how long the requests to a Ray dashboard fail fast once its circuit
+	// breaker opens. Defaults to 30s if zero.
+	DashboardClientCircuitBreakerCooldown metav1.Duration `json:"dashboardClientCircuitBreakerCooldown,omitempty"`
+
 	// DeleteRayJobAfterJobFinishes deletes the RayJob CR itself if shutdownAfterJobFinishes is set to true.
 	DeleteRayJobAfterJobFinishes bool `json:"deleteRayJobAfterJobFinishes,omitempty"`
 }
 
+// EnvironmentTierPolicy is the set of defaults that the operator enforces on the RayClusters of an environment tier.
+// Settings in the RayCluster take precedence.
+type EnvironmentTierPolicy struct {
+	// ProbePeriodSeconds is the period of the liveness and readiness probes that KubeRay injects into Ray containers.
+	ProbePeriodSeconds *int32 `json:"probePeriodSeconds,omitempty"`
+
+	// LivenessProbeFailureThreshold is the failure threshold of the liveness probes that KubeRay injects.
+	LivenessProbeFailureThreshold *int32 `json:"livenessProbeFailureThreshold,omitempty"`
+
+	// ReadinessProbeFailureThreshold is the failure threshold of the readiness probes that KubeRay injects.
+	ReadinessProbeFailureThreshold *int32 `json:"readinessProbeFailureThreshold,omitempty"`
+
+	// SafeToEvict is the default `safeToEvict` of the head group and the worker groups.
+	SafeToEvict *bool `json:"safeToEvict,omitempty"`
+
+	// HeadPodDisruptionBudget creates a PodDisruptionBudget that protects the head Pod from voluntary disruptions,
+	// such as node drains.
+	HeadPodDisruptionBudget bool `json:"headPodDisruptionBudget,omitempty"`
+
+	// MaxPodsAddedPerMinute is the default `scalePolicy.maxPodsAddedPerMinute` of the worker groups without a scalePolicy.
+	MaxPodsAddedPerMinute *int32 `json:"maxPodsAddedPerMinute,omitempty"`
+
+	// MaxPodsRemovedPerMinute is the default `scalePolicy.maxPodsRemovedPerMinute` of the worker groups without a
+	// scalePolicy.
+	MaxPodsRemovedPerMinute *int32 `json:"maxPodsRemovedPerMinute,omitempty"`
+
+	// LogRotationMaxBytes is the size at which Ray rotates the log files of the Ray container, i.e. the
+	// RAY_ROTATION_MAX_BYTES environment variable, unless it is set in the Ray container.
+	LogRotationMaxBytes *int64 `json:"logRotationMaxBytes,omitempty"`
+
+	// LogRotationBackupCount is the number of rotated log files that Ray retains, i.e. the RAY_ROTATION_BACKUP_COUNT
+	// environment variable, unless it is set in the Ray container.
+	LogRotationBackupCount *int32 `json:"logRotationBackupCount,omitempty"`
+}
+
 func (config Configuration) GetDashboardClient(mgr manager.Manager) func() utils.RayDashboardClientInterface {
-	return utils.GetRayDashboardClientFunc(mgr, config.UseKubernetesProxy)
+	return utils.GetRayDashboardClientFunc(mgr, config.UseKubernetesProxy, config.DashboardClientOptions(), common.InstrumentDashboardTransport)
+}
+
+// DashboardClientOptions returns the options of the requests of the dashboard clients to the Ray dashboards.
+func (config Configuration) DashboardClientOptions() utils.DashboardClientOptions {
+	return utils.DashboardClientOptions{
+		Timeout:                 config.DashboardClientTimeout.Duration,
+		MaxRetries:              ptr.Deref(config.DashboardClientMaxRetries, utils.DefaultDashboardClientMaxRetries),
+		CircuitBreakerThreshold: ptr.Deref(config.DashboardClientCircuitBreakerThreshold, utils.DefaultDashboardClientCircuitBreakerThreshold),
+		CircuitBreakerCooldown:  config.DashboardClientCircuitBreakerCooldown.Duration,
+	}
 }
 
 func (config Configuration) GetHttpProxyClient(mgr manager.Manager) func() utils.RayHttpProxyClientInterface {
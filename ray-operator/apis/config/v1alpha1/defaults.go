@@ -9,6 +9,7 @@ const (
 	DefaultMetricsAddr          = ":8080"
 	DefaultProbeAddr            = ":8082"
 	DefaultEnableLeaderElection = true
+	DefaultLeaderElectionID     = "ray-operator-leader"
 	DefaultReconcileConcurrency = 1
 )
 
@@ -33,7 +34,23 @@ func SetDefaults_Configuration(cfg *Configuration) {
 		cfg.EnableLeaderElection = ptr.To(DefaultEnableLeaderElection)
 	}
 
+	if cfg.LeaderElectionID == "" {
+		cfg.LeaderElectionID = DefaultLeaderElectionID
+	}
+
 	if cfg.ReconcileConcurrency == 0 {
 		cfg.ReconcileConcurrency = DefaultReconcileConcurrency
 	}
+
+	if cfg.RayClusterMaxConcurrentReconciles == 0 {
+		cfg.RayClusterMaxConcurrentReconciles = cfg.ReconcileConcurrency
+	}
+
+	if cfg.RayJobMaxConcurrentReconciles == 0 {
+		cfg.RayJobMaxConcurrentReconciles = cfg.ReconcileConcurrency
+	}
+
+	if cfg.RayServiceMaxConcurrentReconciles == 0 {
+		cfg.RayServiceMaxConcurrentReconciles = cfg.ReconcileConcurrency
+	}
 }
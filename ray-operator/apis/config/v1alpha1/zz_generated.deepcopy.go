@@ -18,6 +18,16 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 		*out = new(bool)
 		**out = **in
 	}
+	out.LeaderElectionLeaseDuration = in.LeaderElectionLeaseDuration
+	out.LeaderElectionRenewDeadline = in.LeaderElectionRenewDeadline
+	out.LeaderElectionRetryPeriod = in.LeaderElectionRetryPeriod
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.HeadSidecarContainers != nil {
 		in, out := &in.HeadSidecarContainers, &out.HeadSidecarContainers
 		*out = make([]v1.Container, len(*in))
@@ -32,6 +42,44 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AcceleratorResourceMapping != nil {
+		in, out := &in.AcceleratorResourceMapping, &out.AcceleratorResourceMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnvironmentTiers != nil {
+		in, out := &in.EnvironmentTiers, &out.EnvironmentTiers
+		*out = make(map[string]EnvironmentTierPolicy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	out.StuckPodTimeout = in.StuckPodTimeout
+	if in.PrometheusMonitorLabels != nil {
+		in, out := &in.PrometheusMonitorLabels, &out.PrometheusMonitorLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.RateLimiterBaseDelay = in.RateLimiterBaseDelay
+	out.RateLimiterMaxDelay = in.RateLimiterMaxDelay
+	out.SyncPeriod = in.SyncPeriod
+	out.GracefulShutdownTimeout = in.GracefulShutdownTimeout
+	out.DashboardClientTimeout = in.DashboardClientTimeout
+	if in.DashboardClientMaxRetries != nil {
+		in, out := &in.DashboardClientMaxRetries, &out.DashboardClientMaxRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.DashboardClientCircuitBreakerThreshold != nil {
+		in, out := &in.DashboardClientCircuitBreakerThreshold, &out.DashboardClientCircuitBreakerThreshold
+		*out = new(int)
+		**out = **in
+	}
+	out.DashboardClientCircuitBreakerCooldown = in.DashboardClientCircuitBreakerCooldown
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configuration.
@@ -51,3 +99,58 @@ func (in *Configuration) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentTierPolicy) DeepCopyInto(out *EnvironmentTierPolicy) {
+	*out = *in
+	if in.ProbePeriodSeconds != nil {
+		in, out := &in.ProbePeriodSeconds, &out.ProbePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LivenessProbeFailureThreshold != nil {
+		in, out := &in.LivenessProbeFailureThreshold, &out.LivenessProbeFailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReadinessProbeFailureThreshold != nil {
+		in, out := &in.ReadinessProbeFailureThreshold, &out.ReadinessProbeFailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SafeToEvict != nil {
+		in, out := &in.SafeToEvict, &out.SafeToEvict
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxPodsAddedPerMinute != nil {
+		in, out := &in.MaxPodsAddedPerMinute, &out.MaxPodsAddedPerMinute
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPodsRemovedPerMinute != nil {
+		in, out := &in.MaxPodsRemovedPerMinute, &out.MaxPodsRemovedPerMinute
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LogRotationMaxBytes != nil {
+		in, out := &in.LogRotationMaxBytes, &out.LogRotationMaxBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LogRotationBackupCount != nil {
+		in, out := &in.LogRotationBackupCount, &out.LogRotationBackupCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentTierPolicy.
+func (in *EnvironmentTierPolicy) DeepCopy() *EnvironmentTierPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentTierPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,12 @@
+package v1
+
+// v1 is the hub version that the other versions of the KubeRay CRDs are converted to and from.
+
+// Hub marks RayCluster as a conversion hub.
+func (*RayCluster) Hub() {}
+
+// Hub marks RayJob as a conversion hub.
+func (*RayJob) Hub() {}
+
+// Hub marks RayService as a conversion hub.
+func (*RayService) Hub() {}
@@ -4,6 +4,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -14,18 +15,48 @@ type RayClusterSpec struct {
 	// Suspend indicates whether a RayCluster should be suspended.
 	// A suspended RayCluster will have head pods and worker pods deleted.
 	Suspend *bool `json:"suspend,omitempty"`
+	// IdleTimeoutSeconds is the number of seconds after which KubeRay terminates the RayCluster according to
+	// IdleTimeoutPolicy if no Ray job is pending or running and no Serve application is deployed on it. KubeRay polls the
+	// dashboard of the head Pod, e.g. so that abandoned development clusters do not hold resources. It is ignored for the
+	// RayClusters of RayJobs and RayServices.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
+	// IdleTimeoutPolicy is Suspend, the default, to suspend the RayCluster once it is idle for IdleTimeoutSeconds, or
+	// Delete to delete it.
+	// +optional
+	IdleTimeoutPolicy *IdleTimeoutPolicy `json:"idleTimeoutPolicy,omitempty"`
 	// AutoscalerOptions specifies optional configuration for the Ray autoscaler.
 	AutoscalerOptions      *AutoscalerOptions `json:"autoscalerOptions,omitempty"`
 	HeadServiceAnnotations map[string]string  `json:"headServiceAnnotations,omitempty"`
 	// EnableInTreeAutoscaling indicates whether operator should create in tree autoscaling configs
 	EnableInTreeAutoscaling *bool `json:"enableInTreeAutoscaling,omitempty"`
+	// ServiceMeshMode makes the Ray Pods compatible with the given service mesh.
+	// Currently, only "istio" is supported.
+	ServiceMeshMode *ServiceMeshMode `json:"serviceMeshMode,omitempty"`
+	// Logging specifies optional configuration for shipping the logs of the Ray Pods.
+	Logging *LoggingOptions `json:"logging,omitempty"`
+	// ServiceAccountTokens are projected service account tokens bound to the given audiences, e.g. for Vault or
+	// cloud APIs. They are mounted into the Ray containers and the autoscaler container of all Ray Pods.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ServiceAccountTokens []ServiceAccountToken `json:"serviceAccountTokens,omitempty"`
+	// DashboardClient configures how KubeRay connects to the Ray dashboard of the head Pod, e.g. when the dashboard
+	// serves HTTPS or is fronted by an authenticating proxy. It applies to the requests of the operator, not to the
+	// submitter Pods of RayJobs.
+	// +optional
+	DashboardClient *DashboardClientConfig `json:"dashboardClient,omitempty"`
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	// HeadGroupSpecs are the spec for the head pod
 	HeadGroupSpec HeadGroupSpec `json:"headGroupSpec"`
 	// RayVersion is used to determine the command for the Kubernetes Job managed by RayJob
 	RayVersion string `json:"rayVersion,omitempty"`
-	// WorkerGroupSpecs are the specs for the worker pods
+	// WorkerGroupSpecs are the specs for the worker pods. They are keyed by groupName, so that server-side apply
+	// merges the worker groups of different appliers and partial apply configurations.
+	// +listType=map
+	// +listMapKey=groupName
 	WorkerGroupSpecs []WorkerGroupSpec `json:"workerGroupSpecs,omitempty"`
 }
 
@@ -37,10 +68,56 @@ type HeadGroupSpec struct {
 	HeadService *corev1.Service `json:"headService,omitempty"`
 	// EnableIngress indicates whether operator should create ingress object for head service or not.
 	EnableIngress *bool `json:"enableIngress,omitempty"`
+	// SafeToEvict is the value of the `cluster-autoscaler.kubernetes.io/safe-to-evict` annotation of the head Pod.
+	// Defaults to false, which prevents the Kubernetes Cluster Autoscaler from evicting the head Pod when it scales
+	// down nodes. Set it to true to opt out. An annotation set in the Pod template takes precedence.
+	// +optional
+	SafeToEvict *bool `json:"safeToEvict,omitempty"`
+	// ShmSize is the size limit of the memory-backed emptyDir volume mounted at /dev/shm in the Ray container.
+	// Defaults to the memory request, or limit, of the Ray container.
+	// +optional
+	ShmSize *resource.Quantity `json:"shmSize,omitempty"`
+	// DisableShmVolume stops KubeRay from mounting a memory-backed emptyDir volume at /dev/shm in the Ray container,
+	// e.g. to use the /dev/shm of the container runtime or a hostPath volume instead.
+	// +optional
+	DisableShmVolume *bool `json:"disableShmVolume,omitempty"`
 	// RayStartParams are the params of the start command: node-manager-port, object-store-memory, ...
 	RayStartParams map[string]string `json:"rayStartParams"`
+	// RayStartParamsFrom merges the keys of a ConfigMap in the namespace of the RayCluster into RayStartParams, so that
+	// many RayClusters can be tuned in one place. RayStartParams take precedence. When the ConfigMap changes, the head
+	// Pod is recreated according to UpdateStrategy.
+	// +optional
+	RayStartParamsFrom *RayStartParamsSource `json:"rayStartParamsFrom,omitempty"`
 	// Template is the exact pod template used in K8s depoyments, statefulsets, etc.
 	Template corev1.PodTemplateSpec `json:"template"`
+	// UpdateStrategy indicates whether the head Pod is recreated when the Pod template changes. Defaults to Never,
+	// which only applies the changes to a new head Pod. A head Pod created by an earlier version of KubeRay is
+	// considered outdated by the Recreate strategy.
+	// +optional
+	UpdateStrategy *HeadUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// HeadUpdateStrategyType is the type of the update strategy of the head Pod.
+type HeadUpdateStrategyType string
+
+const (
+	// RecreateHeadUpdateStrategyType deletes the head Pod created from an outdated Pod template, so that it is
+	// recreated from the current one.
+	RecreateHeadUpdateStrategyType HeadUpdateStrategyType = "Recreate"
+	// NeverHeadUpdateStrategyType keeps the head Pod when the Pod template changes.
+	NeverHeadUpdateStrategyType HeadUpdateStrategyType = "Never"
+)
+
+// HeadUpdateStrategy indicates whether the head Pod is recreated when its Pod template changes.
+type HeadUpdateStrategy struct {
+	// Type is Recreate or Never. Defaults to Never.
+	// +kubebuilder:validation:Enum=Recreate;Never
+	// +optional
+	Type HeadUpdateStrategyType `json:"type,omitempty"`
+	// RecreateWorkers deletes all the worker Pods before the head Pod is recreated, so that they join the new head
+	// Pod from a clean state. It is only used if Type is Recreate.
+	// +optional
+	RecreateWorkers *bool `json:"recreateWorkers,omitempty"`
 }
 
 // WorkerGroupSpec are the specs for the worker pods
@@ -58,13 +135,225 @@ type WorkerGroupSpec struct {
 	MaxReplicas *int32 `json:"maxReplicas"`
 	// RayStartParams are the params of the start command: address, object-store-memory, ...
 	RayStartParams map[string]string `json:"rayStartParams"`
+	// RayStartParamsFrom merges the keys of a ConfigMap in the namespace of the RayCluster into RayStartParams, so that
+	// many RayClusters can be tuned in one place. RayStartParams take precedence. When the ConfigMap changes, the Pods
+	// of the group are replaced one at a time.
+	// +optional
+	RayStartParamsFrom *RayStartParamsSource `json:"rayStartParamsFrom,omitempty"`
 	// Template is a pod template for the worker
 	Template corev1.PodTemplateSpec `json:"template"`
 	// ScaleStrategy defines which pods to remove
 	ScaleStrategy ScaleStrategy `json:"scaleStrategy,omitempty"`
+	// ScheduledScaling overrides the minReplicas and maxReplicas of this group during recurring time windows, e.g. to
+	// pre-warm GPU workers before business hours and scale the group to zero at night. The first active window takes
+	// precedence.
+	// +optional
+	ScheduledScaling []ScheduledScalingWindow `json:"scheduledScaling,omitempty"`
+	// ScalePolicy limits how fast KubeRay adds and removes the Pods of this group, e.g. to protect a shared node pool
+	// from a runaway scale-up.
+	// +optional
+	ScalePolicy *ScalePolicy `json:"scalePolicy,omitempty"`
+	// WarmPoolSize is the number of standby Pods KubeRay keeps for this group, as long as the workers and standby
+	// Pods of the group do not exceed maxReplicas. Standby Pods request the resources of a worker and pull its images, but
+	// their containers only sleep. A standby Pod is deleted for each worker of the group waiting to be scheduled, so that
+	// new workers, e.g. when scaling up from zero, start on nodes that are already provisioned.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WarmPoolSize *int32 `json:"warmPoolSize,omitempty"`
+	// IdleTimeoutSeconds is the number of seconds to wait before scaling down an idle worker Pod of this group, so that
+	// expensive groups, e.g. GPU workers, can be scaled down sooner than others. It overrides
+	// AutoscalerOptions.IdleTimeoutSeconds. It is not read by the KubeRay operator but by the Ray autoscaler, and
+	// requires the autoscaler v2, i.e. AutoscalerOptions.Version v2.
+	// +optional
+	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
 	// NumOfHosts denotes the number of hosts to create per replica. The default value is 1.
+	// If it is larger than 1, the Pods of each replica are labeled with `ray.io/replica-index` and `ray.io/host-index`,
+	// share a headless service, and are created and deleted together.
 	// +kubebuilder:default:=1
 	NumOfHosts int32 `json:"numOfHosts,omitempty"`
+	// SafeToEvict is the value of the `cluster-autoscaler.kubernetes.io/safe-to-evict` annotation of the worker Pods.
+	// If unset, the annotation is not added. An annotation set in the Pod template takes precedence.
+	// +optional
+	SafeToEvict *bool `json:"safeToEvict,omitempty"`
+	// Spot indicates whether the worker Pods of this group run on spot or preemptible instances. If unset, KubeRay
+	// detects spot instances by well-known node labels. KubeRay replaces the worker Pods on spot instances that are
+	// being interrupted before Kubernetes notices that the instances are gone.
+	// +optional
+	Spot *bool `json:"spot,omitempty"`
+	// TopologySpread spreads the worker Pods of this group across topology domains, e.g. zones or hosts.
+	// +optional
+	TopologySpread *TopologySpreadOptions `json:"topologySpread,omitempty"`
+	// MaxPodAge is the maximum lifetime of the worker Pods of this group, e.g. 24h. Older Pods are deleted and replaced
+	// one at a time, so that long-running workers do not accumulate memory leaks or fragmentation. KubeRay drains the
+	// Ray node of a Pod for up to 2 minutes before deleting it, and recycles the next Pod only after all the Pods of the
+	// group are running and ready. For multi-host groups, whole replicas are replaced.
+	// +optional
+	MaxPodAge *metav1.Duration `json:"maxPodAge,omitempty"`
+	// GPU declares the GPUs of the Ray container of this group, including fractional shares of time-sliced GPUs and
+	// MIG devices. KubeRay sets both the device plugin resource requests and limits of the Ray container and the
+	// `--num-gpus` option of `ray start` from it, so that users do not have to keep them in sync. The Ray Autoscaler
+	// reads the resources of the group from its Pod template and rayStartParams, so it only sees these GPUs if the
+	// spec defaulting webhook (ENABLE_SPEC_DEFAULTING) writes them into the spec.
+	// +optional
+	GPU *GPUOptions `json:"gpu,omitempty"`
+	// ShmSize is the size limit of the memory-backed emptyDir volume mounted at /dev/shm in the Ray container.
+	// Defaults to the memory request, or limit, of the Ray container.
+	// +optional
+	ShmSize *resource.Quantity `json:"shmSize,omitempty"`
+	// DisableShmVolume stops KubeRay from mounting a memory-backed emptyDir volume at /dev/shm in the Ray container,
+	// e.g. to use the /dev/shm of the container runtime or a hostPath volume instead.
+	// +optional
+	DisableShmVolume *bool `json:"disableShmVolume,omitempty"`
+	// PlacementGroup makes KubeRay create a detached Ray placement group matching the shape of the worker Pods of
+	// this group once the head Pod is ready. The RayCluster only becomes ready after the placement groups of all
+	// worker groups are created, so that applications can rely on the reserved capacity.
+	// +optional
+	PlacementGroup *PlacementGroupOptions `json:"placementGroup,omitempty"`
+	// UpdateStrategy indicates how the worker Pods of this group are replaced when its Pod template changes. Defaults
+	// to OnDelete, which only applies the changes to new Pods. Worker Pods created by earlier versions of KubeRay are
+	// considered outdated by rolling updates.
+	// +optional
+	UpdateStrategy *WorkerGroupUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// WorkerGroupUpdateStrategyType is the type of the update strategy of a worker group.
+type WorkerGroupUpdateStrategyType string
+
+const (
+	// RollingUpdateWorkerGroupStrategyType gradually replaces the worker Pods created from an outdated Pod template.
+	RollingUpdateWorkerGroupStrategyType WorkerGroupUpdateStrategyType = "RollingUpdate"
+	// OnDeleteWorkerGroupStrategyType only applies the changes of the Pod template to new worker Pods.
+	OnDeleteWorkerGroupStrategyType WorkerGroupUpdateStrategyType = "OnDelete"
+)
+
+// WorkerGroupUpdateStrategy indicates how the worker Pods of a group are replaced when its Pod template changes.
+type WorkerGroupUpdateStrategy struct {
+	// Type is RollingUpdate or OnDelete. Defaults to OnDelete. Rolling updates are not supported for multi-host groups.
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+	// +optional
+	Type WorkerGroupUpdateStrategyType `json:"type,omitempty"`
+	// RollingUpdate configures the pace of the rolling update. It is only used if Type is RollingUpdate.
+	// +optional
+	RollingUpdate *RollingUpdateWorkerGroup `json:"rollingUpdate,omitempty"`
+}
+
+// RollingUpdateWorkerGroup configures the pace of the rolling update of a worker group.
+type RollingUpdateWorkerGroup struct {
+	// MaxUnavailable is the maximum number of worker Pods, or percentage of the desired replicas rounded down, that
+	// can be unavailable during the update. Defaults to 1.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MaxSurge is the maximum number of worker Pods, or percentage of the desired replicas rounded up, that can be
+	// created above the desired replicas during the update. Defaults to 0. MaxUnavailable and MaxSurge cannot both
+	// be 0.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// PlacementGroupStrategy is the strategy used by Ray to place the bundles of a placement group.
+type PlacementGroupStrategy string
+
+const (
+	PlacementGroupStrategyPack         PlacementGroupStrategy = "PACK"
+	PlacementGroupStrategySpread       PlacementGroupStrategy = "SPREAD"
+	PlacementGroupStrategyStrictPack   PlacementGroupStrategy = "STRICT_PACK"
+	PlacementGroupStrategyStrictSpread PlacementGroupStrategy = "STRICT_SPREAD"
+)
+
+// PlacementGroupOptions declares a Ray placement group whose bundles match the shape of the worker Pods of a group.
+type PlacementGroupOptions struct {
+	// Name is the name of the placement group in the `kuberay` Ray namespace. It must be unique in the RayCluster.
+	// Defaults to the group name.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Bundles is the number of bundles of the placement group. Each bundle reserves the CPUs and GPUs of one worker
+	// Pod of the group.
+	// +kubebuilder:validation:Minimum=1
+	Bundles int32 `json:"bundles"`
+	// Strategy is the placement strategy of the bundles. Defaults to STRICT_SPREAD, which places each bundle on a
+	// different Ray node.
+	// +kubebuilder:validation:Enum=PACK;SPREAD;STRICT_PACK;STRICT_SPREAD
+	// +optional
+	Strategy PlacementGroupStrategy `json:"strategy,omitempty"`
+}
+
+// RayStartParamsSource is a source of RayStartParams.
+type RayStartParamsSource struct {
+	// ConfigMapRef is the ConfigMap whose keys and values are the params of `ray start`. The ConfigMap must have the
+	// label `ray.io/ray-start-params: "true"`.
+	ConfigMapRef corev1.LocalObjectReference `json:"configMapRef"`
+}
+
+// DashboardClientConfig configures how KubeRay connects to the Ray dashboard of the head Pod. The Secrets are read
+// from the namespace of the RayCluster.
+type DashboardClientConfig struct {
+	// TLS makes KubeRay connect to the dashboard over HTTPS.
+	// +optional
+	TLS *DashboardTLSConfig `json:"tls,omitempty"`
+	// BearerTokenSecretRef selects the key of a Secret holding a token sent in the `Authorization: Bearer` header of
+	// the requests to the dashboard. It is not sent through the Kubernetes API server proxy, i.e. if the operator uses
+	// the Kubernetes proxy, as the API server authenticates KubeRay with that header.
+	// +optional
+	BearerTokenSecretRef *corev1.SecretKeySelector `json:"bearerTokenSecretRef,omitempty"`
+	// CookieSecretRef selects the key of a Secret holding the value of the `Cookie` header of the requests to the
+	// dashboard, e.g. the session cookie of an authenticating proxy.
+	// +optional
+	CookieSecretRef *corev1.SecretKeySelector `json:"cookieSecretRef,omitempty"`
+}
+
+// DashboardTLSConfig configures the HTTPS connections to the Ray dashboard.
+type DashboardTLSConfig struct {
+	// CASecretRef selects the key of a Secret holding the PEM-encoded CA certificates that the certificate of the
+	// dashboard is verified with. The system CAs of the operator are used if unset. It is not used through the
+	// Kubernetes API server proxy, as the API server connects to the dashboard.
+	// +optional
+	CASecretRef *corev1.SecretKeySelector `json:"caSecretRef,omitempty"`
+}
+
+// GPUOptions declares the GPUs of the Ray container. At most one of Shares and MIGProfile can be set.
+type GPUOptions struct {
+	// Count is the number of GPUs, or of MIG devices if MIGProfile is set. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Count *int32 `json:"count,omitempty"`
+	// Shares is the fraction of a time-sliced GPU used by the Ray container, e.g. 0.5. The device plugin exposes
+	// each physical GPU as ReplicasPerGPU replicas, so the Ray container requests Shares * ReplicasPerGPU replicas.
+	// `ray start` only accepts whole GPUs, so Ray sees the shares rounded down, i.e. no GPU for a fraction of a GPU,
+	// and the tasks use the shared GPU without requesting Ray GPUs.
+	// +optional
+	Shares *resource.Quantity `json:"shares,omitempty"`
+	// ReplicasPerGPU is the number of replicas of each physical GPU configured in the time-slicing
+	// configuration of the device plugin. It is required if Shares is set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReplicasPerGPU *int32 `json:"replicasPerGPU,omitempty"`
+	// MIGProfile is the profile of the MIG devices, e.g. 1g.5gb. The Ray container requests the
+	// `nvidia.com/mig-<profile>` resource of the mixed strategy of the NVIDIA device plugin.
+	// +optional
+	MIGProfile string `json:"migProfile,omitempty"`
+	// ResourceName is the device plugin resource of the GPUs. Defaults to nvidia.com/gpu, or to
+	// nvidia.com/mig-<profile> if MIGProfile is set.
+	// +optional
+	ResourceName string `json:"resourceName,omitempty"`
+}
+
+// TopologySpreadOptions are translated into a topology spread constraint of the worker Pods of a group.
+// A constraint with the same topology key in the Pod template takes precedence.
+type TopologySpreadOptions struct {
+	// TopologyKey is the node label whose values are the topology domains, e.g. topology.kubernetes.io/zone.
+	// +kubebuilder:default:=kubernetes.io/hostname
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// MaxSkew is the maximum difference between the numbers of worker Pods in any two topology domains.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+	// WhenUnsatisfiable indicates how to deal with a worker Pod that does not satisfy the spread constraint.
+	// Defaults to ScheduleAnyway, which only prefers spreading. Use DoNotSchedule to enforce it.
+	// +kubebuilder:validation:Enum=DoNotSchedule;ScheduleAnyway
+	// +optional
+	WhenUnsatisfiable *corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
 }
 
 // ScaleStrategy to remove workers
@@ -73,6 +362,47 @@ type ScaleStrategy struct {
 	WorkersToDelete []string `json:"workersToDelete,omitempty"`
 }
 
+// ScalePolicy limits the rate at which the Pods of a worker group are added and removed. The limits apply to the Pods
+// created and deleted to match the desired replicas, including the Pods in WorkersToDelete, but not to the
+// deletion of unhealthy or outdated Pods. The Ray autoscaler has no per-group rate limits, so KubeRay enforces
+// them on the replicas it requests, which are reached gradually. KubeRay keeps the Pods added and removed within
+// the window in memory, so the limits restart from zero when the operator restarts or another replica becomes the
+// leader.
+type ScalePolicy struct {
+	// MaxPodsAddedPerMinute is the maximum number of Pods of the group created in any one-minute window. Unlimited if
+	// unset.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPodsAddedPerMinute *int32 `json:"maxPodsAddedPerMinute,omitempty"`
+	// MaxPodsRemovedPerMinute is the maximum number of Pods of the group deleted in any one-minute window. Unlimited
+	// if unset.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPodsRemovedPerMinute *int32 `json:"maxPodsRemovedPerMinute,omitempty"`
+}
+
+// ScheduledScalingWindow overrides the minReplicas and maxReplicas of a worker group while it is active. KubeRay does
+// not update them in the RayCluster spec: it keeps the replicas of the group within the overridden bounds and reports
+// the bounds in the status of the group. The Ray autoscaler only reads the bounds of the spec, so scheduled scaling
+// is not supported when enableInTreeAutoscaling is true.
+type ScheduledScalingWindow struct {
+	// Schedule is a cron expression in the standard five-field format of the starts of the window, e.g. "0 8 * * 1-5".
+	Schedule string `json:"schedule"`
+	// TimeZone is the IANA name of the time zone of the schedule, e.g. "America/New_York". Defaults to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+	// Duration is how long the window stays active after each start, e.g. 10h.
+	Duration metav1.Duration `json:"duration"`
+	// MinReplicas is the minReplicas of the worker group while the window is active. Unchanged if unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the maxReplicas of the worker group while the window is active. Unchanged if unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+}
+
 // AutoscalerOptions specifies optional configuration for the Ray autoscaler.
 type AutoscalerOptions struct {
 	// Resources specifies optional resource request and limit overrides for the autoscaler container.
@@ -82,10 +412,23 @@ type AutoscalerOptions struct {
 	Image *string `json:"image,omitempty"`
 	// ImagePullPolicy optionally overrides the autoscaler container's image pull policy. This override is for provided for autoscaler testing and development.
 	ImagePullPolicy *corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Command overrides the entrypoint of the autoscaler container, e.g. for custom images that wrap the Ray autoscaler.
+	// Defaults to `/bin/bash -lc --`.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args overrides the arguments of the autoscaler container. Defaults to a `ray kuberay-autoscaler` command for the
+	// RayCluster, which can use the RAY_CLUSTER_NAME and RAY_CLUSTER_NAMESPACE environment variables of the container.
+	// +optional
+	Args []string `json:"args,omitempty"`
 	// SecurityContext defines the security options the container should be run with.
 	// If set, the fields of SecurityContext override the equivalent fields of PodSecurityContext.
 	// More info: https://kubernetes.io/docs/tasks/configure-pod-container/security-context/
 	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// ServiceAccountName is an existing ServiceAccount in the namespace of the RayCluster for the head Pod, which runs
+	// the autoscaler. If it is set, KubeRay does not create a ServiceAccount, Role, or RoleBinding for the autoscaler, so
+	// the ServiceAccount must be allowed to get and patch the RayCluster, and to get, list, watch, and patch its Pods.
+	// +optional
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
 	// IdleTimeoutSeconds is the number of seconds to wait before scaling down a worker pod which is not using Ray resources.
 	// Defaults to 60 (one minute). It is not read by the KubeRay operator but by the Ray autoscaler.
 	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
@@ -95,17 +438,142 @@ type AutoscalerOptions struct {
 	// Aggressive: An alias for Default; upscaling is not rate-limited.
 	// It is not read by the KubeRay operator but by the Ray autoscaler.
 	UpscalingMode *UpscalingMode `json:"upscalingMode,omitempty"`
+	// Version is the version of the Ray autoscaler, v1 or v2. Defaults to v1. The autoscaler v2 requires Ray 2.10 or
+	// later. For v2, KubeRay sets `RAY_enable_autoscaler_v2` in the Ray head and autoscaler containers, and the restart
+	// policy of the Ray Pods to Never unless it is set in their Pod templates, because the autoscaler v2 identifies each
+	// Ray node with its Pod. With the restart policy Never, a crashed autoscaler container is not restarted, and the
+	// RayCluster reports the AutoscalerUnhealthy condition, unless the NativeSidecarContainers feature gate runs the
+	// autoscaler as a native sidecar container, which is always restarted.
+	// +optional
+	Version *AutoscalerVersion `json:"version,omitempty"`
 	// Optional list of environment variables to set in the autoscaler container.
 	Env []corev1.EnvVar `json:"env,omitempty"`
 	// Optional list of sources to populate environment variables in the autoscaler container.
 	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
 	// Optional list of volumeMounts.  This is needed for enabling TLS for the autoscaler container.
+	// The volumes, e.g. holding the config files of custom autoscaler images, are declared in the head Pod template.
 	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
 }
 
+// ServiceAccountToken specifies a projected service account token. The token is mounted at
+// /var/run/secrets/tokens/{name}/token and is rotated by the kubelet before it expires.
+type ServiceAccountToken struct {
+	// Name identifies the token. It must be a DNS label.
+	// +kubebuilder:validation:MaxLength=50
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Audience is the intended audience of the token. The recipient of the token must identify itself
+	// with an identifier specified in the audience of the token.
+	Audience string `json:"audience"`
+	// ExpirationSeconds is the requested duration of validity of the token. Defaults to 1 hour.
+	// +kubebuilder:validation:Minimum=600
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// LoggingOptions specifies optional configuration for shipping the logs of the Ray Pods.
+type LoggingOptions struct {
+	// Sidecar injects a log-shipping sidecar container, such as Fluent Bit or Vector, into every Ray Pod.
+	Sidecar *LogSidecarOptions `json:"sidecar,omitempty"`
+	// Volume persists the Ray log directory /tmp/ray to a PersistentVolumeClaim instead of an emptyDir,
+	// so that the logs survive Pod restarts.
+	Volume *LogVolumeOptions `json:"volume,omitempty"`
+	// Archive uploads the Ray logs of each Pod to object storage when the Pod terminates.
+	Archive *LogArchiveOptions `json:"archive,omitempty"`
+}
+
+// LogVolumeOptions specifies the PersistentVolumeClaims storing the Ray log directory /tmp/ray.
+type LogVolumeOptions struct {
+	// ClaimName is the name of an existing PVC, typically ReadWriteMany, shared by all Ray Pods.
+	// Each Pod writes its logs to a sub-directory named after its group name and replica index.
+	// If empty, KubeRay generates a PVC for each head and worker replica and reuses it when the Pod is recreated.
+	ClaimName string `json:"claimName,omitempty"`
+	// StorageClassName is the storage class of the generated PVCs. Uses the default storage class if not set.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Size is the requested size of the generated PVCs. Defaults to 10Gi.
+	Size *resource.Quantity `json:"size,omitempty"`
+}
+
+// LogArchiveOptions specifies the object storage that the Ray logs are archived to. KubeRay injects a sidecar
+// container whose preStop hook uploads a tarball of /tmp/ray/session_latest/logs to the bucket when the Pod terminates.
+type LogArchiveOptions struct {
+	// Bucket is the S3 or GCS URI that the logs are uploaded to, e.g. s3://my-bucket/ray-logs.
+	// The logs of each Pod are uploaded to {bucket}/{RayCluster name}/{Pod name}.tar.gz.
+	// +kubebuilder:validation:Pattern=`^(s3|gs)://.+`
+	Bucket string `json:"bucket"`
+	// CredentialsSecretRef refers to an optional Secret whose keys are set as environment variables in the
+	// sidecar container, e.g. AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+	// Image optionally overrides the image of the sidecar container. The image must provide the `aws` CLI
+	// for S3 buckets or the `gsutil` CLI for GCS buckets.
+	Image *string `json:"image,omitempty"`
+}
+
+// LogSidecarOptions specifies the log-shipping sidecar container. The Ray log directory /tmp/ray is shared
+// with the sidecar so that the logs can be shipped elsewhere and persist after the Ray Pods are deleted.
+type LogSidecarOptions struct {
+	// Image is the image of the log-shipping sidecar container, e.g. fluent/fluent-bit or timberio/vector.
+	Image string `json:"image"`
+	// ConfigMapName is the name of an optional ConfigMap holding the config of the sidecar.
+	// It is mounted into the sidecar container at /etc/ray-log-sidecar.
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// Output is the destination of the logs, e.g. an S3 URI. It is passed to the sidecar container
+	// in the RAY_LOG_OUTPUT environment variable so that the sidecar config can refer to it.
+	Output string `json:"output,omitempty"`
+	// Resources specifies optional resource requests and limits for the sidecar container.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// Template variables that can be used in the env values and args of the containers in head and
+// worker Pod templates. KubeRay substitutes them with values unique to each Pod when creating it.
+const (
+	ClusterNameTemplateVariable  = "{{cluster.name}}"
+	NamespaceTemplateVariable    = "{{cluster.namespace}}"
+	GroupNameTemplateVariable    = "{{group.name}}"
+	ReplicaIndexTemplateVariable = "{{replica.index}}"
+)
+
+// ObjectStoreMemoryPercentAnnotationKey can be set on a head or worker Pod template to size the object store of the
+// Ray nodes as a percentage of the memory limit of the Ray container, e.g. "30". The object store is capped at the
+// size of /dev/shm. The annotation is ignored if `object-store-memory` is set in RayStartParams.
+const ObjectStoreMemoryPercentAnnotationKey = "ray.io/object-store-memory-percent"
+
+// RayStartParamsConfigMapLabelKey must be set to "true" on the ConfigMaps that RayStartParams are sourced from.
+// KubeRay only watches the ConfigMaps with this label.
+const RayStartParamsConfigMapLabelKey = "ray.io/ray-start-params"
+
 // +kubebuilder:validation:Enum=Default;Aggressive;Conservative
 type UpscalingMode string
 
+// +kubebuilder:validation:Enum=v1;v2
+type AutoscalerVersion string
+
+const (
+	AutoscalerVersionV1 AutoscalerVersion = "v1"
+	AutoscalerVersionV2 AutoscalerVersion = "v2"
+)
+
+// IdleTimeoutPolicy is what KubeRay does with a RayCluster that is idle for its IdleTimeoutSeconds.
+// +kubebuilder:validation:Enum=Suspend;Delete
+type IdleTimeoutPolicy string
+
+const (
+	// SuspendIdleTimeoutPolicy suspends the idle RayCluster, which deletes its Pods but keeps the RayCluster, so that
+	// it can be resumed.
+	SuspendIdleTimeoutPolicy IdleTimeoutPolicy = "Suspend"
+	// DeleteIdleTimeoutPolicy deletes the idle RayCluster.
+	DeleteIdleTimeoutPolicy IdleTimeoutPolicy = "Delete"
+)
+
+// +kubebuilder:validation:Enum=istio
+type ServiceMeshMode string
+
+const (
+	// IstioServiceMesh holds the Ray container until the Istio proxy is ready and keeps
+	// Ray's internal ports out of the sidecar's traffic interception.
+	IstioServiceMesh ServiceMeshMode = "istio"
+)
+
 // The overall state of the Ray cluster.
 type ClusterState string
 
@@ -141,7 +609,7 @@ type RayClusterStatus struct {
 	Endpoints map[string]string `json:"endpoints,omitempty"`
 	// Head info
 	Head HeadInfo `json:"head,omitempty"`
-	// Reason provides more information about current State
+	// Reason provides more information about current State, e.g. why the Pods of the RayCluster cannot be scheduled.
 	Reason string `json:"reason,omitempty"`
 
 	// Represents the latest available observations of a RayCluster's current state.
@@ -164,6 +632,57 @@ type RayClusterStatus struct {
 	// observedGeneration is the most recent generation observed for this RayCluster. It corresponds to the
 	// RayCluster's generation, which is updated on mutation by the API Server.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// GroupStatuses indicates the observed state of the head group and each worker group.
+	// +listType=map
+	// +listMapKey=groupName
+	GroupStatuses []GroupStatus `json:"groupStatuses,omitempty"`
+	// PlacementGroups are the names of the placement groups declared by the worker groups that KubeRay has created
+	// in the Ray cluster.
+	// +listType=set
+	PlacementGroups []string `json:"placementGroups,omitempty"`
+	// AutoscalerError is the last error reported by the Ray autoscaler, e.g. a failure to scale up a worker group.
+	// KubeRay reads it from the dashboard of the head Pod.
+	// +optional
+	AutoscalerError string `json:"autoscalerError,omitempty"`
+	// LastActivityTime is the last time KubeRay observed a pending or running Ray job or a Serve application on the
+	// RayCluster, or the time it started to track its activity. It is only set if IdleTimeoutSeconds is set.
+	// +optional
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+}
+
+// GroupStatus indicates the observed state of the Pods of a head or worker group.
+type GroupStatus struct {
+	// GroupName is the name of the worker group, or "headgroup" for the head group.
+	GroupName string `json:"groupName"`
+	// PodStartupDuration summarizes how long the running Pods of the group took to start their Ray containers
+	// after they were created. It is dominated by the time spent pulling images.
+	PodStartupDuration *PodStartupDuration `json:"podStartupDuration,omitempty"`
+	// Interruptions is the number of worker Pods of the group that KubeRay replaced because their spot or
+	// preemptible instances were interrupted.
+	Interruptions int32 `json:"interruptions,omitempty"`
+	// DesiredReplicas is the desired number of replicas of the worker group last observed by KubeRay, which the autoscaler
+	// changes when it scales the group. It is not set for the head group.
+	// +optional
+	DesiredReplicas *int32 `json:"desiredReplicas,omitempty"`
+	// MinReplicas and MaxReplicas are the bounds of the replicas of the worker group while an active scheduled scaling
+	// window overrides its minReplicas and maxReplicas. They are not set otherwise.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// RayStartParams are the effective RayStartParams of the group if they are sourced from a ConfigMap.
+	// +optional
+	RayStartParams map[string]string `json:"rayStartParams,omitempty"`
+}
+
+// PodStartupDuration summarizes the startup durations of a group of Pods.
+type PodStartupDuration struct {
+	// P50 is the median startup duration.
+	P50 metav1.Duration `json:"p50"`
+	// P90 is the 90th percentile startup duration.
+	P90 metav1.Duration `json:"p90"`
+	// Max is the longest startup duration.
+	Max metav1.Duration `json:"max"`
 }
 
 type RayClusterConditionType string
@@ -174,6 +693,17 @@ const (
 	RayClusterPodsProvisioning     = "RayClusterPodsProvisioning"
 	HeadPodNotFound                = "HeadPodNotFound"
 	HeadPodRunningAndReady         = "HeadPodRunningAndReady"
+	AllPodsSchedulable             = "AllPodsSchedulable"
+	// InsufficientResources, UntoleratedTaints and NodeSelectorMismatch are the most common reasons why the
+	// scheduler cannot place the Pods of a RayCluster. Unschedulable is used for the other reasons.
+	InsufficientResources = "InsufficientResources"
+	UntoleratedTaints     = "UntoleratedTaints"
+	NodeSelectorMismatch  = "NodeSelectorMismatch"
+	Unschedulable         = "Unschedulable"
+	// AutoscalerRunning and AutoscalerNotRunning are the reasons of the AutoscalerUnhealthy condition, unless the
+	// kubelet reports a more specific one, e.g. CrashLoopBackOff.
+	AutoscalerRunning    = "AutoscalerRunning"
+	AutoscalerNotRunning = "AutoscalerNotRunning"
 	// UnknownReason says that the reason for the condition is unknown.
 	UnknownReason = "Unknown"
 )
@@ -186,6 +716,12 @@ const (
 	HeadPodReady RayClusterConditionType = "HeadPodReady"
 	// RayClusterReplicaFailure is added in a RayCluster when one of its pods fails to be created or deleted.
 	RayClusterReplicaFailure RayClusterConditionType = "ReplicaFailure"
+	// RayClusterPodsUnschedulable indicates whether some Pods of the RayCluster cannot be scheduled. Its message
+	// aggregates the reasons reported by the scheduler.
+	RayClusterPodsUnschedulable RayClusterConditionType = "PodsUnschedulable"
+	// RayClusterAutoscalerUnhealthy indicates whether the autoscaler container of the head Pod is not running, e.g.
+	// because it keeps crashing or its liveness probe fails, in which case the worker groups are no longer scaled.
+	RayClusterAutoscalerUnhealthy RayClusterConditionType = "AutoscalerUnhealthy"
 )
 
 // HeadInfo gives info about head
@@ -213,6 +749,7 @@ const (
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="desired workers",type=integer,JSONPath=".status.desiredWorkerReplicas",priority=0
+// +kubebuilder:printcolumn:name="ready workers",type=integer,JSONPath=".status.readyWorkerReplicas",priority=1
 // +kubebuilder:printcolumn:name="available workers",type=integer,JSONPath=".status.availableWorkerReplicas",priority=0
 // +kubebuilder:printcolumn:name="cpus",type=string,JSONPath=".status.desiredCPU",priority=0
 // +kubebuilder:printcolumn:name="memory",type=string,JSONPath=".status.desiredMemory",priority=0
@@ -222,6 +759,8 @@ const (
 // +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp",priority=0
 // +kubebuilder:printcolumn:name="head pod IP",type="string",JSONPath=".status.head.podIP",priority=1
 // +kubebuilder:printcolumn:name="head service IP",type="string",JSONPath=".status.head.serviceIP",priority=1
+// +kubebuilder:selectablefield:JSONPath=".status.state"
+// +kubebuilder:selectablefield:JSONPath=".spec.suspend"
 // +genclient
 type RayCluster struct {
 	// Standard object metadata.
@@ -1,11 +1,22 @@
 package v1
 
 import (
+	"fmt"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -17,8 +28,76 @@ import (
 var (
 	rayclusterlog = logf.Log.WithName("raycluster-resource")
 	nameRegex, _  = regexp.Compile("^[a-z]([-a-z0-9]*[a-z0-9])?$")
+	// templateVariableRegex matches anything that looks like a KubeRay template variable, including misspelled ones.
+	templateVariableRegex = regexp.MustCompile(`\{\{\s*(cluster|group|replica)\.[^{}]*\}\}`)
+	templateVariables     = []string{ClusterNameTemplateVariable, NamespaceTemplateVariable, GroupNameTemplateVariable, ReplicaIndexTemplateVariable}
 )
 
+const (
+	// StrictRayStartParamsAnnotationKey enables the strict validation of RayStartParams if it is set to "true".
+	// In strict mode, every key of RayStartParams must be a `ray start` flag supported by the RayCluster's RayVersion.
+	StrictRayStartParamsAnnotationKey = "ray.io/strict-ray-start-params"
+	// AllowedRayStartParamsAnnotationKey is a comma-separated list of RayStartParams keys that are accepted in strict
+	// mode even though KubeRay does not know them, e.g. flags added in a Ray version newer than KubeRay.
+	AllowedRayStartParamsAnnotationKey = "ray.io/allowed-ray-start-params"
+)
+
+// headGroupName is the group name of the head Pods, which identifies the head group in the status of RayClusters.
+// It must match `utils.RayNodeHeadGroupLabelValue`.
+const headGroupName = "headgroup"
+
+// rayStartFlags maps the flags of `ray start` to the minimum Ray version supporting them.
+// An empty version means that all Ray versions supported by KubeRay, i.e. Ray 2.0.0 or newer, support the flag.
+var rayStartFlags = map[string]string{
+	"address":                      "",
+	"autoscaling-config":           "",
+	"block":                        "",
+	"dashboard-agent-grpc-port":    "",
+	"dashboard-agent-listen-port":  "",
+	"dashboard-grpc-port":          "",
+	"dashboard-host":               "",
+	"dashboard-port":               "",
+	"disable-usage-stats":          "",
+	"enable-object-reconstruction": "",
+	"gcs-server-port":              "",
+	"head":                         "",
+	"include-dashboard":            "",
+	"include-log-monitor":          "",
+	"labels":                       "2.8.0",
+	"log-color":                    "",
+	"log-style":                    "",
+	"max-worker-port":              "",
+	"memory":                       "",
+	"metrics-export-port":          "",
+	"min-worker-port":              "",
+	"no-monitor":                   "",
+	"no-redirect-output":           "",
+	"node-ip-address":              "",
+	"node-manager-port":            "",
+	"node-name":                    "2.1.0",
+	"num-cpus":                     "",
+	"num-gpus":                     "",
+	"object-manager-port":          "",
+	"object-spilling-directory":    "2.5.0",
+	"object-store-memory":          "",
+	"plasma-directory":             "",
+	"plasma-store-socket-name":     "",
+	"port":                         "",
+	"ray-client-server-port":       "",
+	"ray-debugger-external":        "",
+	"raylet-socket-name":           "",
+	"redis-password":               "",
+	"redis-shard-ports":            "",
+	"resources":                    "",
+	"runtime-env-agent-port":       "2.7.0",
+	"storage":                      "",
+	"system-config":                "",
+	"temp-dir":                     "",
+	"tracing-startup-hook":         "",
+	"verbose":                      "",
+	"worker-port-list":             "",
+}
+
 func (r *RayCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
@@ -33,13 +112,13 @@ var _ webhook.Validator = &RayCluster{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *RayCluster) ValidateCreate() (admission.Warnings, error) {
 	rayclusterlog.Info("validate create", "name", r.Name)
-	return nil, r.validateRayCluster()
+	return r.scaleFromZeroWarnings(), r.validateRayCluster()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *RayCluster) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
 	rayclusterlog.Info("validate update", "name", r.Name)
-	return nil, r.validateRayCluster()
+	return r.scaleFromZeroWarnings(), r.validateRayCluster()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -59,6 +138,22 @@ func (r *RayCluster) validateRayCluster() error {
 		allErrs = append(allErrs, err)
 	}
 
+	if err := r.validateTemplateVariables(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateObjectStoreMemoryPercent(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateShmOptions(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateRayStartParams(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -68,6 +163,29 @@ func (r *RayCluster) validateRayCluster() error {
 		r.Name, allErrs)
 }
 
+// scaleFromZeroWarnings warns about the worker groups that the autoscaler cannot scale up correctly from zero Pods.
+// Without a running Pod of a group, the autoscaler infers the resources of its Pods from the RayCluster spec only.
+func (r *RayCluster) scaleFromZeroWarnings() admission.Warnings {
+	if r.Spec.EnableInTreeAutoscaling == nil || !*r.Spec.EnableInTreeAutoscaling {
+		return nil
+	}
+	var warnings admission.Warnings
+	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		if (workerGroup.MinReplicas != nil && *workerGroup.MinReplicas > 0) || len(workerGroup.Template.Spec.Containers) == 0 {
+			continue
+		}
+		path := field.NewPath("spec").Child("workerGroupSpecs").Index(i)
+		resources := workerGroup.Template.Spec.Containers[0].Resources
+		if _, ok := workerGroup.RayStartParams["num-cpus"]; !ok && resources.Limits.Cpu().IsZero() && resources.Requests.Cpu().IsZero() {
+			warnings = append(warnings, fmt.Sprintf("%s: the autoscaler cannot scale this group up from zero Pods, set rayStartParams[num-cpus] or the CPU resources of the Ray container", path))
+		}
+		if _, ok := workerGroup.RayStartParams["num-gpus"]; workerGroup.GPU != nil && !ok {
+			warnings = append(warnings, fmt.Sprintf("%s: the autoscaler does not see the GPUs of gpu when it scales this group up from zero Pods, set rayStartParams[num-gpus]", path))
+		}
+	}
+	return warnings
+}
+
 func (r *RayCluster) validateName() *field.Error {
 	if !nameRegex.MatchString(r.Name) {
 		return field.Invalid(field.NewPath("metadata").Child("name"), r.Name, "name must consist of lower case alphanumeric characters or '-', start with an alphabetic character, and end with an alphanumeric character (e.g. 'my-name',  or 'abc-123', regex used for validation is '[a-z]([-a-z0-9]*[a-z0-9])?')")
@@ -77,13 +195,282 @@ func (r *RayCluster) validateName() *field.Error {
 
 func (r *RayCluster) validateWorkerGroups() *field.Error {
 	workerGroupNames := make(map[string]bool)
+	placementGroupNames := make(map[string]bool)
 
 	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
 		if _, ok := workerGroupNames[workerGroup.GroupName]; ok {
 			return field.Invalid(field.NewPath("spec").Child("workerGroupSpecs").Index(i), workerGroup, "worker group names must be unique")
 		}
 		workerGroupNames[workerGroup.GroupName] = true
+		if workerGroup.GroupName == headGroupName {
+			return field.Invalid(field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("groupName"), workerGroup.GroupName, fmt.Sprintf("%q is reserved for the head group", headGroupName))
+		}
+		if workerGroup.MaxPodAge != nil && workerGroup.MaxPodAge.Duration <= 0 {
+			return field.Invalid(field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("maxPodAge"), workerGroup.MaxPodAge.Duration.String(), "maxPodAge must be positive")
+		}
+		if workerGroup.IdleTimeoutSeconds != nil && *workerGroup.IdleTimeoutSeconds < 0 {
+			return field.Invalid(field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("idleTimeoutSeconds"), *workerGroup.IdleTimeoutSeconds, "idleTimeoutSeconds must be non-negative")
+		}
+		// The autoscaler v1 ignores the idle timeouts of the worker groups.
+		if workerGroup.IdleTimeoutSeconds != nil && (r.Spec.AutoscalerOptions == nil || r.Spec.AutoscalerOptions.Version == nil || *r.Spec.AutoscalerOptions.Version != AutoscalerVersionV2) {
+			return field.Invalid(field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("idleTimeoutSeconds"), *workerGroup.IdleTimeoutSeconds, "idleTimeoutSeconds requires autoscalerOptions.version v2")
+		}
+		// The autoscaler only reads the minReplicas and maxReplicas of the spec and would scale the group back.
+		if len(workerGroup.ScheduledScaling) > 0 && r.Spec.EnableInTreeAutoscaling != nil && *r.Spec.EnableInTreeAutoscaling {
+			return field.Invalid(field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("scheduledScaling"), workerGroup.GroupName, "scheduledScaling is not supported when enableInTreeAutoscaling is true")
+		}
+		for j, window := range workerGroup.ScheduledScaling {
+			if err := validateScheduledScalingWindow(window, field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("scheduledScaling").Index(j)); err != nil {
+				return err
+			}
+		}
+		if workerGroup.GPU != nil {
+			if err := validateGPUOptions(*workerGroup.GPU, field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("gpu")); err != nil {
+				return err
+			}
+		}
+		if workerGroup.PlacementGroup != nil {
+			placementGroupName := workerGroup.PlacementGroup.Name
+			if placementGroupName == "" {
+				placementGroupName = workerGroup.GroupName
+			}
+			if placementGroupNames[placementGroupName] {
+				return field.Invalid(field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("placementGroup", "name"), placementGroupName, "placement group names must be unique")
+			}
+			placementGroupNames[placementGroupName] = true
+		}
+		if workerGroup.UpdateStrategy != nil {
+			if err := validateWorkerGroupUpdateStrategy(workerGroup, field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("updateStrategy")); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateScheduledScalingWindow checks that the schedule and time zone of the window can be parsed.
+func validateScheduledScalingWindow(window ScheduledScalingWindow, path *field.Path) *field.Error {
+	if window.TimeZone != nil {
+		if _, err := time.LoadLocation(*window.TimeZone); err != nil {
+			return field.Invalid(path.Child("timeZone"), *window.TimeZone, fmt.Sprintf("invalid time zone: %v", err))
+		}
+	}
+	if strings.Contains(window.Schedule, "TZ=") {
+		return field.Invalid(path.Child("schedule"), window.Schedule, "schedule must not set a time zone, use timeZone instead")
+	}
+	if _, err := cron.ParseStandard(window.Schedule); err != nil {
+		return field.Invalid(path.Child("schedule"), window.Schedule, fmt.Sprintf("invalid cron schedule: %v", err))
+	}
+	if window.Duration.Duration <= 0 {
+		return field.Invalid(path.Child("duration"), window.Duration.Duration.String(), "duration must be positive")
+	}
+	return nil
+}
+
+// validateWorkerGroupUpdateStrategy checks that a rolling update of the worker group can make progress.
+func validateWorkerGroupUpdateStrategy(workerGroup WorkerGroupSpec, path *field.Path) *field.Error {
+	if workerGroup.UpdateStrategy.Type != RollingUpdateWorkerGroupStrategyType {
+		return nil
+	}
+	if workerGroup.NumOfHosts > 1 {
+		return field.Invalid(path.Child("type"), workerGroup.UpdateStrategy.Type, "rolling updates are not supported for worker groups with numOfHosts > 1")
+	}
+	rollingUpdate := workerGroup.UpdateStrategy.RollingUpdate
+	if rollingUpdate == nil {
+		return nil
+	}
+	// Scale percentages to 100 replicas, so that any positive percentage is positive.
+	maxUnavailable, maxSurge := 1, 0
+	if rollingUpdate.MaxUnavailable != nil {
+		value, err := intstr.GetScaledValueFromIntOrPercent(rollingUpdate.MaxUnavailable, 100, true)
+		if err != nil || value < 0 {
+			return field.Invalid(path.Child("rollingUpdate", "maxUnavailable"), rollingUpdate.MaxUnavailable.String(), "maxUnavailable must be a non-negative integer or percentage")
+		}
+		maxUnavailable = value
+	}
+	if rollingUpdate.MaxSurge != nil {
+		value, err := intstr.GetScaledValueFromIntOrPercent(rollingUpdate.MaxSurge, 100, true)
+		if err != nil || value < 0 {
+			return field.Invalid(path.Child("rollingUpdate", "maxSurge"), rollingUpdate.MaxSurge.String(), "maxSurge must be a non-negative integer or percentage")
+		}
+		maxSurge = value
+	}
+	if maxUnavailable == 0 && maxSurge == 0 {
+		return field.Invalid(path.Child("rollingUpdate"), rollingUpdate, "maxUnavailable and maxSurge cannot both be 0")
+	}
+	return nil
+}
+
+// validateGPUOptions checks that the GPU options translate into a whole number of device plugin resources.
+func validateGPUOptions(gpu GPUOptions, path *field.Path) *field.Error {
+	if gpu.Shares == nil {
+		if gpu.ReplicasPerGPU != nil {
+			return field.Invalid(path.Child("replicasPerGPU"), *gpu.ReplicasPerGPU, "replicasPerGPU can only be set with shares")
+		}
+		return nil
+	}
+	if gpu.MIGProfile != "" || gpu.Count != nil {
+		return field.Invalid(path.Child("shares"), gpu.Shares.String(), "shares cannot be set with migProfile or count")
+	}
+	if gpu.Shares.Sign() <= 0 || gpu.Shares.Cmp(resource.MustParse("1")) > 0 {
+		return field.Invalid(path.Child("shares"), gpu.Shares.String(), "shares must be greater than 0 and at most 1")
 	}
+	if gpu.ReplicasPerGPU == nil {
+		return field.Required(path.Child("replicasPerGPU"), "replicasPerGPU is required with shares")
+	}
+	if gpu.Shares.MilliValue()*int64(*gpu.ReplicasPerGPU)%1000 != 0 {
+		return field.Invalid(path.Child("shares"), gpu.Shares.String(), fmt.Sprintf("shares must be a multiple of 1/%d, the share of a replica of the GPU", *gpu.ReplicasPerGPU))
+	}
+	return nil
+}
 
+// validateShmOptions checks the /dev/shm settings of the head group and the worker groups.
+func (r *RayCluster) validateShmOptions() *field.Error {
+	if err := validateShmOptions(r.Spec.HeadGroupSpec.ShmSize, r.Spec.HeadGroupSpec.DisableShmVolume, field.NewPath("spec").Child("headGroupSpec")); err != nil {
+		return err
+	}
+	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		if err := validateShmOptions(workerGroup.ShmSize, workerGroup.DisableShmVolume, field.NewPath("spec").Child("workerGroupSpecs").Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateShmOptions(shmSize *resource.Quantity, disableShmVolume *bool, path *field.Path) *field.Error {
+	if shmSize == nil {
+		return nil
+	}
+	if disableShmVolume != nil && *disableShmVolume {
+		return field.Invalid(path.Child("shmSize"), shmSize.String(), "shmSize cannot be set with disableShmVolume")
+	}
+	if shmSize.Sign() <= 0 {
+		return field.Invalid(path.Child("shmSize"), shmSize.String(), "shmSize must be positive")
+	}
+	return nil
+}
+
+func (r *RayCluster) validateTemplateVariables() *field.Error {
+	if err := validatePodTemplateVariables(r.Spec.HeadGroupSpec.Template, field.NewPath("spec").Child("headGroupSpec", "template")); err != nil {
+		return err
+	}
+	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		if err := validatePodTemplateVariables(workerGroup.Template, field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("template")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePodTemplateVariables rejects unknown template variables in the env values and args of the containers.
+// Unknown variables are left as-is by KubeRay, so they are most likely typos.
+func validatePodTemplateVariables(template corev1.PodTemplateSpec, path *field.Path) *field.Error {
+	validate := func(value string, fieldPath *field.Path) *field.Error {
+		for _, variable := range templateVariableRegex.FindAllString(value, -1) {
+			if !slices.Contains(templateVariables, variable) {
+				return field.Invalid(fieldPath, value, fmt.Sprintf("unknown template variable %s, supported variables are: %s", variable, strings.Join(templateVariables, ", ")))
+			}
+		}
+		return nil
+	}
+
+	containerPaths := map[string][]corev1.Container{"initContainers": template.Spec.InitContainers, "containers": template.Spec.Containers}
+	for _, containersName := range []string{"initContainers", "containers"} {
+		for i, container := range containerPaths[containersName] {
+			containerPath := path.Child("spec", containersName).Index(i)
+			for j, arg := range container.Args {
+				if err := validate(arg, containerPath.Child("args").Index(j)); err != nil {
+					return err
+				}
+			}
+			for j, env := range container.Env {
+				if err := validate(env.Value, containerPath.Child("env").Index(j).Child("value")); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *RayCluster) validateObjectStoreMemoryPercent() *field.Error {
+	if err := validatePodTemplateObjectStoreMemoryPercent(r.Spec.HeadGroupSpec.Template, field.NewPath("spec").Child("headGroupSpec", "template")); err != nil {
+		return err
+	}
+	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		if err := validatePodTemplateObjectStoreMemoryPercent(workerGroup.Template, field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("template")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePodTemplateObjectStoreMemoryPercent checks that the object store memory percentage of the Pod template is an
+// integer between 1 and 100, and that the Ray container has a memory limit or request to derive the size from.
+func validatePodTemplateObjectStoreMemoryPercent(template corev1.PodTemplateSpec, path *field.Path) *field.Error {
+	value, ok := template.Annotations[ObjectStoreMemoryPercentAnnotationKey]
+	if !ok {
+		return nil
+	}
+	annotationPath := path.Child("metadata", "annotations").Key(ObjectStoreMemoryPercentAnnotationKey)
+	if percent, err := strconv.Atoi(value); err != nil || percent < 1 || percent > 100 {
+		return field.Invalid(annotationPath, value, "must be an integer between 1 and 100")
+	}
+	if len(template.Spec.Containers) == 0 {
+		return nil
+	}
+	resources := template.Spec.Containers[0].Resources
+	if _, ok := resources.Limits[corev1.ResourceMemory]; ok {
+		return nil
+	}
+	if _, ok := resources.Requests[corev1.ResourceMemory]; ok {
+		return nil
+	}
+	return field.Invalid(annotationPath, value, "the Ray container must have a memory limit or request")
+}
+
+// validateRayStartParams rejects RayStartParams keys that are not `ray start` flags in strict mode. Otherwise,
+// typos like `num-cpu` are only reported by `ray start` at runtime.
+func (r *RayCluster) validateRayStartParams() *field.Error {
+	if strings.ToLower(r.Annotations[StrictRayStartParamsAnnotationKey]) != "true" {
+		return nil
+	}
+	var allowedKeys []string
+	for _, key := range strings.Split(r.Annotations[AllowedRayStartParamsAnnotationKey], ",") {
+		allowedKeys = append(allowedKeys, strings.TrimSpace(key))
+	}
+	// If RayVersion cannot be parsed, the flags are not checked against it.
+	rayVersion, _ := semver.NewVersion(r.Spec.RayVersion)
+
+	validate := func(rayStartParams map[string]string, path *field.Path) *field.Error {
+		keys := make([]string, 0, len(rayStartParams))
+		for key := range rayStartParams {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if slices.Contains(allowedKeys, key) {
+				continue
+			}
+			minVersion, ok := rayStartFlags[key]
+			if !ok {
+				return field.Invalid(path.Key(key), key, fmt.Sprintf("unknown `ray start` flag, add it to the %s annotation if it is valid", AllowedRayStartParamsAnnotationKey))
+			}
+			if minVersion != "" && rayVersion != nil && rayVersion.LessThan(semver.MustParse(minVersion)) {
+				return field.Invalid(path.Key(key), key, fmt.Sprintf("`ray start` flag requires Ray %s or newer, but rayVersion is %s", minVersion, r.Spec.RayVersion))
+			}
+		}
+		return nil
+	}
+
+	if err := validate(r.Spec.HeadGroupSpec.RayStartParams, field.NewPath("spec").Child("headGroupSpec", "rayStartParams")); err != nil {
+		return err
+	}
+	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		if err := validate(workerGroup.RayStartParams, field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("rayStartParams")); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,109 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConcurrencyPolicy specifies how a RayCronJob treats the concurrent runs of its RayJobs.
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent allows the RayJobs of a RayCronJob to run concurrently.
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// ForbidConcurrent skips a scheduled run if the previous RayJob hasn't finished yet.
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// ReplaceConcurrent deletes the unfinished RayJobs and replaces them with the RayJob of the scheduled run.
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+// RayCronJobSpec defines the desired state of RayCronJob
+type RayCronJobSpec struct {
+	// Schedule is the schedule of the RayJobs in the Cron format, see https://en.wikipedia.org/wiki/Cron.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// TimeZone is the name of the time zone of the schedule in the IANA Time Zone database, e.g. "America/New_York".
+	// UTC is used if unset.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+	// StartingDeadlineSeconds is the deadline in seconds for starting the RayJob of a scheduled run if it is missed,
+	// e.g. because the operator was down. The missed runs are not counted as failed RayJobs.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+	// ConcurrencyPolicy specifies how to treat the concurrent runs of the RayJobs: "Allow" runs them concurrently,
+	// "Forbid" skips a run if the previous RayJob hasn't finished yet, and "Replace" deletes the unfinished RayJobs
+	// and replaces them with the RayJob of the run.
+	// +kubebuilder:default:=Allow
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// SuccessfulJobsHistoryLimit is the number of RayJobs that succeeded to keep. The older ones are deleted.
+	// +kubebuilder:default:=3
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+	// FailedJobsHistoryLimit is the number of RayJobs that failed to keep. The older ones are deleted.
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+	// RayJobTemplate is the template of the RayJobs created by the RayCronJob.
+	RayJobTemplate RayJobTemplateSpec `json:"rayJobTemplate"`
+}
+
+// RayJobTemplateSpec describes the RayJobs created by a RayCronJob.
+type RayJobTemplateSpec struct {
+	// Standard object's metadata of the RayJobs. Only the labels and annotations are used.
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec is the spec of the RayJobs.
+	Spec RayJobSpec `json:"spec"`
+}
+
+// RayCronJobStatus defines the observed state of RayCronJob
+type RayCronJobStatus struct {
+	// Active is the list of the RayJobs of the RayCronJob that haven't finished yet.
+	// +optional
+	Active []corev1.ObjectReference `json:"active,omitempty"`
+	// LastScheduleTime is the time of the last run scheduled for a RayJob.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastSuccessfulTime is the time when the last RayJob that succeeded finished.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// ObservedGeneration is the most recent generation observed for this RayCronJob. It corresponds to the
+	// RayCronJob's generation, which is updated on mutation by the API Server.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=all
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="schedule",type=string,JSONPath=".spec.schedule",priority=0
+// +kubebuilder:printcolumn:name="last schedule",type=date,JSONPath=".status.lastScheduleTime",priority=0
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp",priority=0
+// +genclient
+// RayCronJob is the Schema for the raycronjobs API
+type RayCronJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RayCronJobSpec   `json:"spec,omitempty"`
+	Status RayCronJobStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RayCronJobList contains a list of RayCronJob
+type RayCronJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RayCronJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RayCronJob{}, &RayCronJobList{})
+}
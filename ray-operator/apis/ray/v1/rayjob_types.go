@@ -42,6 +42,8 @@ const (
 	JobDeploymentStatusSuspending   JobDeploymentStatus = "Suspending"
 	JobDeploymentStatusSuspended    JobDeploymentStatus = "Suspended"
 	JobDeploymentStatusRetrying     JobDeploymentStatus = "Retrying"
+	JobDeploymentStatusWaiting      JobDeploymentStatus = "Waiting"
+	JobDeploymentStatusScheduled    JobDeploymentStatus = "Scheduled"
 )
 
 // JobFailedReason indicates the reason the RayJob changes its JobDeploymentStatus to 'Failed'
@@ -51,52 +53,182 @@ const (
 	SubmissionFailed JobFailedReason = "SubmissionFailed"
 	DeadlineExceeded JobFailedReason = "DeadlineExceeded"
 	AppFailed        JobFailedReason = "AppFailed"
+	// ClusterReadyTimeoutExceeded means that the RayCluster was not ready within `spec.clusterReadyTimeoutSeconds`.
+	ClusterReadyTimeoutExceeded JobFailedReason = "ClusterReadyTimeoutExceeded"
+)
+
+type RayJobConditionType string
+
+const (
+	// RayJobSuspended indicates whether the RayJob is suspended, i.e. its RayCluster and submitter Job were deleted
+	// because `spec.suspend` is true, e.g. until Kueue admits it.
+	RayJobSuspended RayJobConditionType = "Suspended"
+	// RayJobInitialized indicates whether the current attempt to run the Ray job started to create or select its
+	// RayCluster.
+	RayJobInitialized RayJobConditionType = "Initialized"
+	// RayJobClusterProvisioned indicates whether the RayCluster of the current attempt is ready.
+	RayJobClusterProvisioned RayJobConditionType = "ClusterProvisioned"
+	// RayJobSubmitted indicates whether the Ray job of the current attempt was submitted to the RayCluster.
+	RayJobSubmitted RayJobConditionType = "JobSubmitted"
+	// RayJobRunning indicates whether the Ray job of the current attempt is running.
+	RayJobRunning RayJobConditionType = "Running"
+	// RayJobComplete indicates whether the RayJob is complete, i.e. `Status.JobDeploymentStatus` is `Complete`.
+	RayJobComplete RayJobConditionType = "Complete"
+	// RayJobFailed indicates whether the RayJob failed, i.e. `Status.JobDeploymentStatus` is `Failed`.
+	RayJobFailed RayJobConditionType = "Failed"
+)
+
+// Custom Reason for RayJobCondition
+const (
+	RayJobSuspendedReason = "Suspended"
+	RayJobResumedReason   = "Resumed"
 )
 
 type JobSubmissionMode string
 
 const (
-	K8sJobMode JobSubmissionMode = "K8sJobMode" // Submit job via Kubernetes Job
-	HTTPMode   JobSubmissionMode = "HTTPMode"   // Submit job via HTTP request
+	K8sJobMode      JobSubmissionMode = "K8sJobMode"      // Submit job via Kubernetes Job
+	HTTPMode        JobSubmissionMode = "HTTPMode"        // Submit job via HTTP request
+	InteractiveMode JobSubmissionMode = "InteractiveMode" // Don't submit job in KubeRay. Instead, wait for user to submit job and provide job submission ID.
+	SidecarMode     JobSubmissionMode = "SidecarMode"     // Submit job via a container in the head Pod
 )
 
+// DeletionPolicy specifies the resources of a RayJob that are deleted once its Ray job finishes.
+// +kubebuilder:validation:Enum=DeleteCluster;DeleteWorkers;DeleteSelf;DeleteNone
+type DeletionPolicy string
+
+const (
+	DeleteCluster DeletionPolicy = "DeleteCluster" // Delete the RayCluster.
+	DeleteWorkers DeletionPolicy = "DeleteWorkers" // Delete the worker Pods of the RayCluster and keep its head Pod, e.g. to access the logs.
+	DeleteSelf    DeletionPolicy = "DeleteSelf"    // Delete the RayJob, and the RayCluster and the submitter Job it owns.
+	DeleteNone    DeletionPolicy = "DeleteNone"    // Delete nothing.
+)
+
+// RuntimeEnv is the runtime environment of a Ray job, see
+// https://docs.ray.io/en/latest/ray-core/handling-dependencies.html#api-reference.
+type RuntimeEnv struct {
+	// WorkingDir is the working directory of the Ray job, e.g. a directory of the Ray head or the URI of a zip file.
+	// +optional
+	WorkingDir string `json:"workingDir,omitempty"`
+	// Pip are the pip packages installed for the Ray job.
+	// +optional
+	Pip []string `json:"pip,omitempty"`
+	// EnvVars are the environment variables of the Ray job.
+	// +optional
+	EnvVars map[string]string `json:"envVars,omitempty"`
+	// EnvFrom are the Secrets and ConfigMaps in the namespace of the RayJob whose keys are injected into the
+	// environment variables of the Ray job when it is submitted, without being stored in the RayJob. When a key exists
+	// in multiple sources, the last source takes precedence, and envVars take precedence over all of them.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+}
+
 type SubmitterConfig struct {
 	// BackoffLimit of the submitter k8s job.
 	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
 }
 
+// LogStreamingOptions configures how the driver logs of the Ray job are streamed out of the RayCluster.
+type LogStreamingOptions struct {
+	// Follow creates a Kubernetes Job named after the RayJob that follows the driver logs of the Ray job with
+	// `ray job logs --follow`, so that `kubectl logs` shows them while the Ray job runs. The submitter Job already
+	// prints them in K8sJobMode, so it is only supported in HTTPMode and InteractiveMode.
+	// +optional
+	Follow bool `json:"follow,omitempty"`
+	// SinkURL is an HTTP(S) URL to which the driver logs of the Ray job are uploaded with a PUT request once the Ray
+	// job finished, e.g. a presigned S3 URL.
+	// +optional
+	SinkURL string `json:"sinkURL,omitempty"`
+}
+
+// RayClusterMetadata is the metadata of the RayCluster created by a RayJob.
+type RayClusterMetadata struct {
+	// Labels are added to the labels of the RayJob on the RayCluster, and override them.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are added to the annotations of the RayJob on the RayCluster, and override them.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RayJobStep is a step of a RayJob, submitted as a separate Ray job to the RayCluster of the RayJob.
+type RayJobStep struct {
+	// Name is the name of the step, unique in the RayJob. The step is submitted with the `<jobId>-<name>` jobId.
+	Name string `json:"name"`
+	// Entrypoint is the command of the step.
+	Entrypoint string `json:"entrypoint"`
+	// DependsOn are the names of the previous steps that must succeed before the step is submitted. If no step of the
+	// RayJob sets dependsOn, each step depends on the previous one, so that the steps run sequentially.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
 // RayJobSpec defines the desired state of RayJob
 type RayJobSpec struct {
 	// ActiveDeadlineSeconds is the duration in seconds that the RayJob may be active before
 	// KubeRay actively tries to terminate the RayJob; value must be positive integer.
+	// KubeRay stops the Ray job if it is running, and the RayJob fails with the DeadlineExceeded reason.
 	ActiveDeadlineSeconds *int32 `json:"activeDeadlineSeconds,omitempty"`
+	// ClusterReadyTimeoutSeconds is the duration in seconds that KubeRay waits for the RayCluster to be ready, e.g. for
+	// its Pods to be scheduled, before the RayJob fails with the ClusterReadyTimeoutExceeded reason and the RayCluster
+	// is deleted. If unset, KubeRay waits for the RayCluster indefinitely.
+	// +optional
+	ClusterReadyTimeoutSeconds *int32 `json:"clusterReadyTimeoutSeconds,omitempty"`
 	// Specifies the number of retries before marking this job failed.
-	// Each retry creates a new RayCluster.
+	// Each retry creates a new RayCluster, unless reuseRayClusterOnRetry is set.
 	// +kubebuilder:default:=0
 	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// ReuseRayClusterOnRetry specifies whether the retries of a failed Ray job run on the RayCluster of the failed
+	// attempt instead of a new RayCluster. The RayClusters selected by clusterSelector are always reused.
+	ReuseRayClusterOnRetry bool `json:"reuseRayClusterOnRetry,omitempty"`
 	// RayClusterSpec is the cluster template to run the job
 	RayClusterSpec *RayClusterSpec `json:"rayClusterSpec,omitempty"`
+	// RayClusterMetadata is the metadata of the RayCluster created by the RayJob. The RayCluster has the labels and
+	// annotations of the RayJob, merged with the ones of rayClusterMetadata, and the `ray.io/originated-from-cr-name`,
+	// `ray.io/originated-from-crd` and `app.kubernetes.io/created-by` labels, which cannot be overridden.
+	// +optional
+	RayClusterMetadata *RayClusterMetadata `json:"rayClusterMetadata,omitempty"`
 	// SubmitterPodTemplate is the template for the pod that will run `ray job submit`.
+	// If logStreaming.follow is set, it is the template for the pod that follows the driver logs of the Ray job.
 	SubmitterPodTemplate *corev1.PodTemplateSpec `json:"submitterPodTemplate,omitempty"`
 	// Metadata is data to store along with this job.
 	Metadata map[string]string `json:"metadata,omitempty"`
-	// clusterSelector is used to select running rayclusters by labels
+	// clusterSelector is used to select running rayclusters by labels, or by name with the `ray.io/cluster` key.
+	// The selected RayCluster is neither created nor deleted by the RayJob.
 	ClusterSelector map[string]string `json:"clusterSelector,omitempty"`
 	// Configurations of submitter k8s job.
 	SubmitterConfig *SubmitterConfig `json:"submitterConfig,omitempty"`
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
-	Entrypoint string `json:"entrypoint"`
+
+	// Entrypoint is the command of the Ray job. It must not be set with steps.
+	Entrypoint string `json:"entrypoint,omitempty"`
+	// Steps are run instead of the entrypoint on the same RayCluster, following their dependencies, e.g. for the stages
+	// of a pipeline. Each step is submitted as a separate Ray job once the steps it depends on succeeded, and the Ray job
+	// of the RayJob fails once a step failed and no other step is running. Steps require HTTPMode.
+	// +optional
+	Steps []RayJobStep `json:"steps,omitempty"`
 	// RuntimeEnvYAML represents the runtime environment configuration
 	// provided as a multi-line YAML string.
 	RuntimeEnvYAML string `json:"runtimeEnvYAML,omitempty"`
+	// RuntimeEnv is the runtime environment of the Ray job. It replaces runtimeEnvYAML, which must not be set with it.
+	// +optional
+	RuntimeEnv *RuntimeEnv `json:"runtimeEnv,omitempty"`
 	// If jobId is not set, a new jobId will be auto-generated.
+	// The retries of the Ray job are submitted with the `<jobId>-<attempt>` jobId.
 	JobId string `json:"jobId,omitempty"`
 	// SubmissionMode specifies how RayJob submits the Ray job to the RayCluster.
 	// In "K8sJobMode", the KubeRay operator creates a submitter Kubernetes Job to submit the Ray job.
 	// In "HTTPMode", the KubeRay operator sends a request to the RayCluster to create a Ray job.
+	// In "InteractiveMode", the KubeRay operator waits for users to submit the Ray job to the RayCluster, e.g. from a
+	// notebook, and to set the `ray.io/rayjob-submission-id` annotation of the RayJob to its submission ID.
+	// In "SidecarMode", the KubeRay operator adds a submitter container to the head Pod of the RayCluster, which submits
+	// the Ray job to the dashboard of the head Pod, so that no network traffic between Pods is needed to submit it.
 	// +kubebuilder:default:=K8sJobMode
 	SubmissionMode JobSubmissionMode `json:"submissionMode,omitempty"`
+	// LogStreaming streams the driver logs of the Ray job to a Kubernetes Job or to an HTTP(S) sink.
+	// +optional
+	LogStreaming *LogStreamingOptions `json:"logStreaming,omitempty"`
 	// EntrypointResources specifies the custom resources and quantities to reserve for the
 	// entrypoint command.
 	EntrypointResources string `json:"entrypointResources,omitempty"`
@@ -105,17 +237,27 @@ type RayJobSpec struct {
 	// EntrypointNumGpus specifies the number of gpus to reserve for the entrypoint command.
 	EntrypointNumGpus float32 `json:"entrypointNumGpus,omitempty"`
 	// TTLSecondsAfterFinished is the TTL to clean up RayCluster.
-	// It's only working when ShutdownAfterJobFinishes set to true.
+	// It's only working when ShutdownAfterJobFinishes set to true, or DeletionPolicy is set to delete resources.
 	// +kubebuilder:default:=0
 	TTLSecondsAfterFinished int32 `json:"ttlSecondsAfterFinished,omitempty"`
 	// ShutdownAfterJobFinishes will determine whether to delete the ray cluster once rayJob succeed or failed.
 	ShutdownAfterJobFinishes bool `json:"shutdownAfterJobFinishes,omitempty"`
+	// DeletionPolicy specifies the resources that are deleted once the Ray job finishes, after ttlSecondsAfterFinished.
+	// It replaces shutdownAfterJobFinishes, which must not be set with it, and requires the RayJobDeletionPolicy
+	// feature gate. If unset, the RayCluster is deleted if shutdownAfterJobFinishes is true.
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
 	// suspend specifies whether the RayJob controller should create a RayCluster instance
 	// If a job is applied with the suspend field set to true,
 	// the RayCluster will not be created and will wait for the transition to false.
-	// If the RayCluster is already created, it will be deleted.
+	// If the RayCluster is already created, the Ray job will be stopped and the RayCluster will be deleted.
 	// In case of transition to false a new RayCluster will be created.
+	// The Suspended condition of the status reports whether the RayJob is suspended, e.g. for Kueue.
 	Suspend bool `json:"suspend,omitempty"`
+	// Schedule re-runs the Ray job on a cron schedule in UTC, see https://en.wikipedia.org/wiki/Cron. Each run starts
+	// once the previous one finished and submits the entrypoint with a new jobId, on the RayCluster of the previous run
+	// if the deletionPolicy keeps it, or else on a new RayCluster. It requires the RayJobSchedule feature gate.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
 }
 
 // RayJobStatus defines the observed state of RayJob
@@ -129,26 +271,85 @@ type RayJobStatus struct {
 	JobDeploymentStatus JobDeploymentStatus `json:"jobDeploymentStatus,omitempty"`
 	Reason              JobFailedReason     `json:"reason,omitempty"`
 	Message             string              `json:"message,omitempty"`
+	// LogsTail is the end of the driver logs of the Ray job, fetched from the dashboard when the Ray job finished.
+	// It is truncated to the last 4 KiB.
+	LogsTail string `json:"logsTail,omitempty"`
+	// DriverExitCode is the exit code of the driver of the Ray job once it finished, if reported by the dashboard.
+	DriverExitCode *int32 `json:"driverExitCode,omitempty"`
+	// JobResult is the JSON result of the Ray job, read from its driver logs once it finished, so that it can be
+	// consumed without reading the logs. The entrypoint reports it by printing a line starting with
+	// `KUBERAY_JOB_RESULT:` followed by a JSON document of at most 4 KiB. The last such line is used.
+	JobResult string `json:"jobResult,omitempty"`
 	// StartTime is the time when JobDeploymentStatus transitioned from 'New' to 'Initializing'.
 	StartTime *metav1.Time `json:"startTime,omitempty"`
 	// EndTime is the time when JobDeploymentStatus transitioned to 'Complete' status.
 	// This occurs when the Ray job reaches a terminal state (SUCCEEDED, FAILED, STOPPED)
 	// or the submitter Job has failed.
 	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// LastScheduleTime is the time of the last run of the schedule of the RayJob.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastSuccessfulTime is the time when the Ray job last succeeded.
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
 	// Succeeded is the number of times this job succeeded.
+	// It is reset at each run of the schedule of the RayJob.
 	// +kubebuilder:default:=0
 	Succeeded *int32 `json:"succeeded,omitempty"`
 	// Failed is the number of times this job failed.
+	// It is reset at each run of the schedule of the RayJob.
 	// +kubebuilder:default:=0
 	Failed *int32 `json:"failed,omitempty"`
+	// Attempt is the number of the current attempt to run the Ray job, starting from 1.
+	// It is incremented each time a failed Ray job is retried.
+	Attempt int32 `json:"attempt,omitempty"`
+	// Attempts are the finished attempts to run the Ray job, in the order they were made.
+	// They are reset at each run of the schedule of the RayJob.
+	Attempts []RayJobAttempt `json:"attempts,omitempty"`
+	// Steps are the statuses of the steps of the current attempt to run the Ray job, in the order of the spec.
+	Steps []RayJobStepStatus `json:"steps,omitempty"`
 	// RayClusterStatus is the status of the RayCluster running the job.
 	RayClusterStatus RayClusterStatus `json:"rayClusterStatus,omitempty"`
 
+	// Represents the latest available observations of a RayJob's current state. The lastTransitionTime of the
+	// Initialized, ClusterProvisioned, JobSubmitted, Running, Complete and Failed conditions is the time when the
+	// current attempt to run the Ray job reached each stage.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
 	// observedGeneration is the most recent generation observed for this RayJob. It corresponds to the
 	// RayJob's generation, which is updated on mutation by the API Server.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// RayJobStepStatus is the status of a step of a RayJob.
+type RayJobStepStatus struct {
+	// StartTime is the time when the step was submitted.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// EndTime is the time when the Ray job of the step finished.
+	EndTime   *metav1.Time `json:"endTime,omitempty"`
+	Name      string       `json:"name"`
+	JobId     string       `json:"jobId,omitempty"`
+	JobStatus JobStatus    `json:"jobStatus,omitempty"`
+	// Message is the message of the Ray job of the step, or the reason why the step is not run.
+	Message string `json:"message,omitempty"`
+}
+
+// RayJobAttempt describes a finished attempt to run the Ray job of a RayJob.
+type RayJobAttempt struct {
+	// StartTime is the time when JobDeploymentStatus transitioned from 'New' to 'Initializing' for the attempt.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// EndTime is the time when the attempt succeeded or failed.
+	EndTime        *metav1.Time `json:"endTime,omitempty"`
+	JobId          string       `json:"jobId,omitempty"`
+	RayClusterName string       `json:"rayClusterName,omitempty"`
+	JobStatus      JobStatus    `json:"jobStatus,omitempty"`
+	// Reason is the reason why the attempt failed.
+	Reason  JobFailedReason `json:"reason,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:categories=all
 // +kubebuilder:subresource:status
@@ -159,6 +360,9 @@ type RayJobStatus struct {
 // +kubebuilder:printcolumn:name="start time",type=string,JSONPath=".status.startTime",priority=0
 // +kubebuilder:printcolumn:name="end time",type=string,JSONPath=".status.endTime",priority=0
 // +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp",priority=0
+// +kubebuilder:selectablefield:JSONPath=".status.jobStatus"
+// +kubebuilder:selectablefield:JSONPath=".status.jobDeploymentStatus"
+// +kubebuilder:selectablefield:JSONPath=".status.rayClusterName"
 // +genclient
 // RayJob is the Schema for the rayjobs API
 type RayJob struct {
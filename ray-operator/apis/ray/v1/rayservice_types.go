@@ -18,6 +18,7 @@ const (
 	Restarting                       ServiceStatus = "Restarting"
 	FailedToUpdateServingPodLabel    ServiceStatus = "FailedToUpdateServingPodLabel"
 	FailedToUpdateService            ServiceStatus = "FailedToUpdateService"
+	FailedToUpdateHTTPRoute          ServiceStatus = "FailedToUpdateHTTPRoute"
 )
 
 // These statuses should match Ray Serve's application statuses
@@ -49,14 +50,101 @@ var DeploymentStatusEnum = struct {
 	UNHEALTHY: "UNHEALTHY",
 }
 
+// RayServiceUpgradeType is the type of the upgrade strategy of a RayService.
+type RayServiceUpgradeType string
+
+const (
+	// NewClusterUpgradeType prepares a new RayCluster when a change of the RayCluster spec cannot be applied in place,
+	// and switches the traffic to it once its Serve applications are ready.
+	NewClusterUpgradeType RayServiceUpgradeType = "NewCluster"
+	// NewClusterWithIncrementalUpgradeType prepares a new RayCluster like NewClusterUpgradeType, but shifts the traffic
+	// to it gradually through a Gateway API HTTPRoute once its Serve applications are ready.
+	NewClusterWithIncrementalUpgradeType RayServiceUpgradeType = "NewClusterWithIncrementalUpgrade"
+	// NoneUpgradeType does not prepare a new RayCluster when the RayCluster spec changes. Only the changes that can be
+	// applied in place, e.g. to serveConfigV2 or new worker groups appended to the RayCluster spec, are applied.
+	NoneUpgradeType RayServiceUpgradeType = "None"
+)
+
+// RayServiceUpgradeStrategy indicates how a RayService is upgraded when its RayCluster spec changes.
+type RayServiceUpgradeStrategy struct {
+	// Type is NewCluster, NewClusterWithIncrementalUpgrade or None. Defaults to NewCluster, unless the
+	// ENABLE_ZERO_DOWNTIME environment variable of the KubeRay operator is false.
+	// +kubebuilder:validation:Enum=NewCluster;NewClusterWithIncrementalUpgrade;None
+	// +optional
+	Type RayServiceUpgradeType `json:"type,omitempty"`
+	// ClusterUpgradeOptions configures how the traffic is shifted to the pending RayCluster. It is required by
+	// the NewClusterWithIncrementalUpgrade upgrade strategy.
+	// +optional
+	ClusterUpgradeOptions *ClusterUpgradeOptions `json:"clusterUpgradeOptions,omitempty"`
+	// PendingClusterReadyTimeoutSeconds is the duration in seconds that KubeRay waits for the Serve applications of
+	// the pending RayCluster to be ready. Once it is exceeded, the pending RayCluster is deleted and a new one is
+	// prepared. If unset, KubeRay waits for the pending RayCluster indefinitely.
+	// +optional
+	PendingClusterReadyTimeoutSeconds *int32 `json:"pendingClusterReadyTimeoutSeconds,omitempty"`
+}
+
+// ClusterUpgradeOptions configures the traffic shifting of the NewClusterWithIncrementalUpgrade upgrade strategy.
+// KubeRay creates an HTTPRoute attached to the Gateway, which splits the traffic between the Serve services of the
+// active and pending RayClusters. The traffic is only shifted while the Serve applications of the pending RayCluster
+// are ready, and the pending RayCluster is promoted once it receives all the traffic.
+type ClusterUpgradeOptions struct {
+	// GatewayName is the name of the Gateway, in the namespace of the RayService, that the HTTPRoute is attached to.
+	GatewayName string `json:"gatewayName"`
+	// StepSizePercent is the percentage of the traffic shifted from the active to the pending RayCluster at each
+	// step. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	StepSizePercent *int32 `json:"stepSizePercent,omitempty"`
+	// IntervalSeconds is the duration in seconds between two steps. Defaults to 30 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+}
+
+// RayServiceRollback configures how a RayService rolls back to its previous RayCluster after an upgrade.
+type RayServiceRollback struct {
+	// KeepPreviousClusterSeconds is the duration in seconds that the previous RayCluster is kept, idle, after the new
+	// RayCluster is promoted, so that the RayService can roll back to it without preparing a RayCluster. If unset, the
+	// previous RayCluster is deleted 60 seconds after the promotion.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepPreviousClusterSeconds *int32 `json:"keepPreviousClusterSeconds,omitempty"`
+	// ToPreviousCluster routes the traffic back to the previous RayCluster while it is kept. As long as it is true, or
+	// the RayService has the `ray.io/rollback: "true"` annotation, KubeRay neither prepares a new RayCluster nor
+	// updates the Serve applications.
+	// +optional
+	ToPreviousCluster bool `json:"toPreviousCluster,omitempty"`
+}
+
 // RayServiceSpec defines the desired state of RayService
 type RayServiceSpec struct {
-	// Deprecated: This field is not used anymore. ref: https://github.com/ray-project/kuberay/issues/1685
+	// ServiceUnhealthySecondThreshold is the duration in seconds that KubeRay may fail to get the Serve application
+	// statuses of the active RayCluster, e.g. because its head Pod or dashboard is down, before it prepares a new
+	// RayCluster to replace it. If unset, the active RayCluster is never replaced for this reason.
+	// +optional
 	ServiceUnhealthySecondThreshold *int32 `json:"serviceUnhealthySecondThreshold,omitempty"`
-	// Deprecated: This field is not used anymore. ref: https://github.com/ray-project/kuberay/issues/1685
+	// DeploymentUnhealthySecondThreshold is the duration in seconds that a Serve application or deployment of the
+	// active RayCluster may be UNHEALTHY or DEPLOY_FAILED before KubeRay prepares a new RayCluster to replace it.
+	// If unset, the active RayCluster is never replaced for this reason.
+	// +optional
 	DeploymentUnhealthySecondThreshold *int32 `json:"deploymentUnhealthySecondThreshold,omitempty"`
+	// HealthCheckPeriodSeconds is how often in seconds KubeRay checks the Serve applications once they are running.
+	// Defaults to 2 seconds.
+	// +optional
+	HealthCheckPeriodSeconds *int32 `json:"healthCheckPeriodSeconds,omitempty"`
+	// UpgradeStrategy indicates whether a change of the RayCluster spec prepares a new RayCluster.
+	// +optional
+	UpgradeStrategy *RayServiceUpgradeStrategy `json:"upgradeStrategy,omitempty"`
+	// Rollback keeps the previous RayCluster after an upgrade so that the RayService can roll back to it.
+	// +optional
+	Rollback *RayServiceRollback `json:"rollback,omitempty"`
 	// ServeService is the Kubernetes service for head node and worker nodes who have healthy http proxy to serve traffics.
 	ServeService *corev1.Service `json:"serveService,omitempty"`
+	// ExcludeHeadPodFromServeSvc, if true, keeps the `ray.io/serve` label of the head Pod false, so that the Serve
+	// service only routes the traffic to the worker Pods whose Serve proxy is ready.
+	// +optional
+	ExcludeHeadPodFromServeSvc bool `json:"excludeHeadPodFromServeSvc,omitempty"`
 	// Important: Run "make" to regenerate code after modifying this file
 	// Defines the applications and deployments to deploy, should be a YAML multi-line scalar string.
 	ServeConfigV2  string         `json:"serveConfigV2,omitempty"`
@@ -72,6 +160,9 @@ type RayServiceStatuses struct {
 	ActiveServiceStatus RayServiceStatus `json:"activeServiceStatus,omitempty"`
 	// Pending Service Status indicates a RayCluster will be created or is being created.
 	PendingServiceStatus RayServiceStatus `json:"pendingServiceStatus,omitempty"`
+	// PreviousRayClusterName is the name of the RayCluster that was active before the last promotion, while it is
+	// kept for spec.rollback.keepPreviousClusterSeconds.
+	PreviousRayClusterName string `json:"previousRayClusterName,omitempty"`
 	// NumServeEndpoints indicates the number of Ray Pods that are actively serving or have been selected by the serve service.
 	// Ray Pods without a proxy actor or those that are unhealthy will not be counted.
 	NumServeEndpoints int32 `json:"numServeEndpoints,omitempty"`
@@ -85,6 +176,13 @@ type RayServiceStatus struct {
 	Applications     map[string]AppStatus `json:"applicationStatuses,omitempty"`
 	RayClusterName   string               `json:"rayClusterName,omitempty"`
 	RayClusterStatus RayClusterStatus     `json:"rayClusterStatus,omitempty"`
+	// TrafficRoutedPercent is the percentage of the traffic that the HTTPRoute of the RayService routes to the
+	// RayCluster. It is only set by the NewClusterWithIncrementalUpgrade upgrade strategy.
+	// +optional
+	TrafficRoutedPercent *int32 `json:"trafficRoutedPercent,omitempty"`
+	// LastTrafficMigratedTime is when TrafficRoutedPercent last changed.
+	// +optional
+	LastTrafficMigratedTime *metav1.Time `json:"lastTrafficMigratedTime,omitempty"`
 }
 
 type AppStatus struct {
@@ -113,6 +211,12 @@ type ServeDeploymentStatus struct {
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="service status",type=string,JSONPath=".status.serviceStatus"
 // +kubebuilder:printcolumn:name="num serve endpoints",type=string,JSONPath=".status.numServeEndpoints"
+// +kubebuilder:printcolumn:name="active cluster",type=string,JSONPath=".status.activeServiceStatus.rayClusterName",priority=1
+// +kubebuilder:printcolumn:name="pending cluster",type=string,JSONPath=".status.pendingServiceStatus.rayClusterName",priority=1
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:selectablefield:JSONPath=".status.serviceStatus"
+// +kubebuilder:selectablefield:JSONPath=".status.activeServiceStatus.rayClusterName"
+// +kubebuilder:selectablefield:JSONPath=".status.pendingServiceStatus.rayClusterName"
 // +genclient
 // RayService is the Schema for the rayservices API
 type RayService struct {
@@ -17,10 +17,13 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	//+kubebuilder:scaffold:imports
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -204,6 +207,567 @@ var _ = Describe("RayCluster validating webhook", func() {
 			Expect(err.Error()).To(ContainSubstring("worker group names must be unique"))
 		})
 	})
+
+	Context("when a worker group is named after the head group", func() {
+		It("should return error", func() {
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Namespace: "default",
+				},
+				Spec: RayClusterSpec{
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{},
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{
+						{
+							GroupName:      "headgroup",
+							RayStartParams: map[string]string{},
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is reserved for the head group"))
+		})
+	})
+
+	Context("when maxPodAge is not positive", func() {
+		It("should return error", func() {
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Namespace: "default",
+				},
+				Spec: RayClusterSpec{
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{},
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{
+						{
+							GroupName:      "group1",
+							RayStartParams: map[string]string{},
+							MaxPodAge:      &metav1.Duration{Duration: -time.Hour},
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("maxPodAge must be positive"))
+		})
+	})
+
+	Context("when idleTimeoutSeconds is negative", func() {
+		It("should return error", func() {
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Namespace: "default",
+				},
+				Spec: RayClusterSpec{
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{},
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{
+						{
+							GroupName:          "group1",
+							RayStartParams:     map[string]string{},
+							IdleTimeoutSeconds: ptr.To[int32](-1),
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("idleTimeoutSeconds must be non-negative"))
+		})
+	})
+
+	Context("when a worker group sets idleTimeoutSeconds without the autoscaler v2", func() {
+		It("should return error", func() {
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Namespace: "default",
+				},
+				Spec: RayClusterSpec{
+					EnableInTreeAutoscaling: ptr.To(true),
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{},
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{
+						{
+							GroupName:          "group1",
+							RayStartParams:     map[string]string{},
+							IdleTimeoutSeconds: ptr.To[int32](60),
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("idleTimeoutSeconds requires autoscalerOptions.version v2"))
+		})
+	})
+
+	Context("when a scheduled scaling window is invalid", func() {
+		newRayCluster := func(window ScheduledScalingWindow) RayCluster {
+			return RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Namespace: "default",
+				},
+				Spec: RayClusterSpec{
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{},
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{
+						{
+							GroupName:        "group1",
+							RayStartParams:   map[string]string{},
+							ScheduledScaling: []ScheduledScalingWindow{window},
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		It("should return error for an invalid schedule", func() {
+			rayCluster := newRayCluster(ScheduledScalingWindow{Schedule: "not a schedule", Duration: metav1.Duration{Duration: time.Hour}})
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid cron schedule"))
+		})
+
+		It("should return error for an invalid time zone", func() {
+			rayCluster := newRayCluster(ScheduledScalingWindow{Schedule: "0 8 * * *", TimeZone: ptr.To("Nowhere"), Duration: metav1.Duration{Duration: time.Hour}})
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid time zone"))
+		})
+
+		It("should return error when the RayCluster is autoscaled", func() {
+			rayCluster := newRayCluster(ScheduledScalingWindow{Schedule: "0 8 * * *", Duration: metav1.Duration{Duration: time.Hour}})
+			rayCluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("scheduledScaling is not supported when enableInTreeAutoscaling is true"))
+		})
+	})
+
+	Context("when placement group names are not unique", func() {
+		It("should return error", func() {
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Namespace: "default",
+				},
+				Spec: RayClusterSpec{
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{},
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{
+						{
+							GroupName:      "group1",
+							RayStartParams: map[string]string{},
+							PlacementGroup: &PlacementGroupOptions{Bundles: 1},
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{},
+								},
+							},
+						},
+						{
+							GroupName:      "group2",
+							RayStartParams: map[string]string{},
+							PlacementGroup: &PlacementGroupOptions{Name: "group1", Bundles: 1},
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("placement group names must be unique"))
+		})
+	})
+
+	Context("when maxUnavailable and maxSurge of a rolling update are both 0", func() {
+		It("should return error", func() {
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Namespace: "default",
+				},
+				Spec: RayClusterSpec{
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{},
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{
+						{
+							GroupName:      "group1",
+							RayStartParams: map[string]string{},
+							UpdateStrategy: &WorkerGroupUpdateStrategy{
+								Type: RollingUpdateWorkerGroupStrategyType,
+								RollingUpdate: &RollingUpdateWorkerGroup{
+									MaxUnavailable: ptr.To(intstr.FromString("0%")),
+									MaxSurge:       ptr.To(intstr.FromInt32(0)),
+								},
+							},
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("maxUnavailable and maxSurge cannot both be 0"))
+		})
+	})
+
+	Context("when GPU shares are not a whole number of GPU replicas", func() {
+		It("should return error", func() {
+			shares := resource.MustParse("0.5")
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Namespace: "default",
+				},
+				Spec: RayClusterSpec{
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{},
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{
+						{
+							GroupName:      "group1",
+							RayStartParams: map[string]string{},
+							GPU:            &GPUOptions{Shares: &shares, ReplicasPerGPU: ptr.To[int32](3)},
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("shares must be a multiple of 1/3"))
+		})
+	})
+
+	Context("when the object store memory percentage is out of range", func() {
+		It("should return error", func() {
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Namespace: "default",
+				},
+				Spec: RayClusterSpec{
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{},
+						Template: corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{ObjectStoreMemoryPercentAnnotationKey: "150"},
+							},
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  "ray-head",
+										Image: "rayproject/ray:2.9.0",
+										Resources: corev1.ResourceRequirements{
+											Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+										},
+									},
+								},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{},
+				},
+			}
+
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be an integer between 1 and 100"))
+		})
+
+		It("should also return the errors of the other fields", func() {
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Namespace:   "default",
+					Annotations: map[string]string{StrictRayStartParamsAnnotationKey: "true"},
+				},
+				Spec: RayClusterSpec{
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{"unknown-flag": "true"},
+						Template: corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{ObjectStoreMemoryPercentAnnotationKey: "150"},
+							},
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  "ray-head",
+										Image: "rayproject/ray:2.9.0",
+										Resources: corev1.ResourceRequirements{
+											Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+										},
+									},
+								},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{},
+				},
+			}
+
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be an integer between 1 and 100"))
+			Expect(err.Error()).To(ContainSubstring("unknown `ray start` flag"))
+		})
+	})
+
+	Context("when shmSize is set with disableShmVolume", func() {
+		It("should return error", func() {
+			shmSize := resource.MustParse("1Gi")
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Namespace: "default",
+				},
+				Spec: RayClusterSpec{
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams:   map[string]string{},
+						ShmSize:          &shmSize,
+						DisableShmVolume: ptr.To(true),
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{},
+				},
+			}
+
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("shmSize cannot be set with disableShmVolume"))
+		})
+	})
+
+	Context("when template variables are unknown", func() {
+		It("should return error", func() {
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+				},
+				Spec: RayClusterSpec{
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{"DEADBEEF": "DEADBEEF"},
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  "ray-head",
+										Image: "rayproject/ray:2.9.0",
+										Env:   []corev1.EnvVar{{Name: "CLUSTER_NAME", Value: "{{cluster.nme}}"}},
+									},
+								},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{},
+				},
+			}
+
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+
+			Expect(err.Error()).To(ContainSubstring("unknown template variable {{cluster.nme}}"))
+		})
+	})
+
+	Context("when RayStartParams are unknown in strict mode", func() {
+		var rayCluster RayCluster
+
+		BeforeEach(func() {
+			rayCluster = RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+					Annotations: map[string]string{StrictRayStartParamsAnnotationKey: "true"},
+				},
+				Spec: RayClusterSpec{
+					RayVersion: "2.9.0",
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{"num-cpu": "1"},
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{},
+				},
+			}
+		})
+
+		It("should return error", func() {
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+
+			Expect(err.Error()).To(ContainSubstring("spec.headGroupSpec.rayStartParams[num-cpu]"))
+		})
+
+		It("should accept allowlisted keys", func() {
+			rayCluster.Annotations[AllowedRayStartParamsAnnotationKey] = "foo, num-cpu"
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject flags newer than rayVersion", func() {
+			rayCluster.Spec.RayVersion = "2.6.0"
+			rayCluster.Spec.HeadGroupSpec.RayStartParams = map[string]string{"runtime-env-agent-port": "8079"}
+			err := k8sClient.Create(context.TODO(), &rayCluster)
+			Expect(err).To(HaveOccurred())
+
+			Expect(err.Error()).To(ContainSubstring("requires Ray 2.7.0 or newer"))
+		})
+	})
+
+	Context("when the autoscaler cannot scale a worker group up from zero Pods", func() {
+		It("should return warnings", func() {
+			rayCluster := RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      fmt.Sprintf("test-raycluster-%d", rand.IntnRange(1000, 9000)),
+				},
+				Spec: RayClusterSpec{
+					EnableInTreeAutoscaling: ptr.To(true),
+					HeadGroupSpec: HeadGroupSpec{
+						RayStartParams: map[string]string{},
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{},
+							},
+						},
+					},
+					WorkerGroupSpecs: []WorkerGroupSpec{
+						{
+							GroupName:      "gpu-group",
+							MinReplicas:    ptr.To[int32](0),
+							RayStartParams: map[string]string{},
+							GPU:            &GPUOptions{Count: ptr.To[int32](1)},
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{{Name: "ray-worker", Image: "rayproject/ray:2.9.0"}},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			warnings, err := rayCluster.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(2))
+			Expect(warnings[0]).To(ContainSubstring("rayStartParams[num-cpus]"))
+			Expect(warnings[1]).To(ContainSubstring("rayStartParams[num-gpus]"))
+
+			rayCluster.Spec.WorkerGroupSpecs[0].RayStartParams = map[string]string{"num-cpus": "4", "num-gpus": "1"}
+			warnings, err = rayCluster.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
 })
 
 var _ = AfterSuite(func() {
@@ -8,6 +8,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -54,11 +55,26 @@ func (in *AutoscalerOptions) DeepCopyInto(out *AutoscalerOptions) {
 		*out = new(corev1.PullPolicy)
 		**out = **in
 	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
 		*out = new(corev1.SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ServiceAccountName != nil {
+		in, out := &in.ServiceAccountName, &out.ServiceAccountName
+		*out = new(string)
+		**out = **in
+	}
 	if in.IdleTimeoutSeconds != nil {
 		in, out := &in.IdleTimeoutSeconds, &out.IdleTimeoutSeconds
 		*out = new(int32)
@@ -69,6 +85,11 @@ func (in *AutoscalerOptions) DeepCopyInto(out *AutoscalerOptions) {
 		*out = new(UpscalingMode)
 		**out = **in
 	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(AutoscalerVersion)
+		**out = **in
+	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]corev1.EnvVar, len(*in))
@@ -102,6 +123,153 @@ func (in *AutoscalerOptions) DeepCopy() *AutoscalerOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeOptions) DeepCopyInto(out *ClusterUpgradeOptions) {
+	*out = *in
+	if in.StepSizePercent != nil {
+		in, out := &in.StepSizePercent, &out.StepSizePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeOptions.
+func (in *ClusterUpgradeOptions) DeepCopy() *ClusterUpgradeOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardClientConfig) DeepCopyInto(out *DashboardClientConfig) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DashboardTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BearerTokenSecretRef != nil {
+		in, out := &in.BearerTokenSecretRef, &out.BearerTokenSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CookieSecretRef != nil {
+		in, out := &in.CookieSecretRef, &out.CookieSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardClientConfig.
+func (in *DashboardClientConfig) DeepCopy() *DashboardClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardTLSConfig) DeepCopyInto(out *DashboardTLSConfig) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardTLSConfig.
+func (in *DashboardTLSConfig) DeepCopy() *DashboardTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUOptions) DeepCopyInto(out *GPUOptions) {
+	*out = *in
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Shares != nil {
+		in, out := &in.Shares, &out.Shares
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ReplicasPerGPU != nil {
+		in, out := &in.ReplicasPerGPU, &out.ReplicasPerGPU
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUOptions.
+func (in *GPUOptions) DeepCopy() *GPUOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupStatus) DeepCopyInto(out *GroupStatus) {
+	*out = *in
+	if in.PodStartupDuration != nil {
+		in, out := &in.PodStartupDuration, &out.PodStartupDuration
+		*out = new(PodStartupDuration)
+		**out = **in
+	}
+	if in.DesiredReplicas != nil {
+		in, out := &in.DesiredReplicas, &out.DesiredReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RayStartParams != nil {
+		in, out := &in.RayStartParams, &out.RayStartParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupStatus.
+func (in *GroupStatus) DeepCopy() *GroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HeadGroupSpec) DeepCopyInto(out *HeadGroupSpec) {
 	*out = *in
@@ -115,6 +283,21 @@ func (in *HeadGroupSpec) DeepCopyInto(out *HeadGroupSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SafeToEvict != nil {
+		in, out := &in.SafeToEvict, &out.SafeToEvict
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ShmSize != nil {
+		in, out := &in.ShmSize, &out.ShmSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DisableShmVolume != nil {
+		in, out := &in.DisableShmVolume, &out.DisableShmVolume
+		*out = new(bool)
+		**out = **in
+	}
 	if in.RayStartParams != nil {
 		in, out := &in.RayStartParams, &out.RayStartParams
 		*out = make(map[string]string, len(*in))
@@ -122,7 +305,17 @@ func (in *HeadGroupSpec) DeepCopyInto(out *HeadGroupSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RayStartParamsFrom != nil {
+		in, out := &in.RayStartParamsFrom, &out.RayStartParamsFrom
+		*out = new(RayStartParamsSource)
+		**out = **in
+	}
 	in.Template.DeepCopyInto(&out.Template)
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(HeadUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadGroupSpec.
@@ -135,6 +328,26 @@ func (in *HeadGroupSpec) DeepCopy() *HeadGroupSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeadUpdateStrategy) DeepCopyInto(out *HeadUpdateStrategy) {
+	*out = *in
+	if in.RecreateWorkers != nil {
+		in, out := &in.RecreateWorkers, &out.RecreateWorkers
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadUpdateStrategy.
+func (in *HeadUpdateStrategy) DeepCopy() *HeadUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(HeadUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HeadInfo) DeepCopyInto(out *HeadInfo) {
 	*out = *in
@@ -150,6 +363,154 @@ func (in *HeadInfo) DeepCopy() *HeadInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogArchiveOptions) DeepCopyInto(out *LogArchiveOptions) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogArchiveOptions.
+func (in *LogArchiveOptions) DeepCopy() *LogArchiveOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LogArchiveOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSidecarOptions) DeepCopyInto(out *LogSidecarOptions) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSidecarOptions.
+func (in *LogSidecarOptions) DeepCopy() *LogSidecarOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSidecarOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogStreamingOptions) DeepCopyInto(out *LogStreamingOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStreamingOptions.
+func (in *LogStreamingOptions) DeepCopy() *LogStreamingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LogStreamingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogVolumeOptions) DeepCopyInto(out *LogVolumeOptions) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogVolumeOptions.
+func (in *LogVolumeOptions) DeepCopy() *LogVolumeOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LogVolumeOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggingOptions) DeepCopyInto(out *LoggingOptions) {
+	*out = *in
+	if in.Sidecar != nil {
+		in, out := &in.Sidecar, &out.Sidecar
+		*out = new(LogSidecarOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(LogVolumeOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(LogArchiveOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingOptions.
+func (in *LoggingOptions) DeepCopy() *LoggingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementGroupOptions) DeepCopyInto(out *PlacementGroupOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementGroupOptions.
+func (in *PlacementGroupOptions) DeepCopy() *PlacementGroupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementGroupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodStartupDuration) DeepCopyInto(out *PodStartupDuration) {
+	*out = *in
+	out.P50 = in.P50
+	out.P90 = in.P90
+	out.Max = in.Max
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodStartupDuration.
+func (in *PodStartupDuration) DeepCopy() *PodStartupDuration {
+	if in == nil {
+		return nil
+	}
+	out := new(PodStartupDuration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayCluster) DeepCopyInto(out *RayCluster) {
 	*out = *in
@@ -209,6 +570,35 @@ func (in *RayClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayClusterMetadata) DeepCopyInto(out *RayClusterMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterMetadata.
+func (in *RayClusterMetadata) DeepCopy() *RayClusterMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(RayClusterMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayClusterSpec) DeepCopyInto(out *RayClusterSpec) {
 	*out = *in
@@ -217,6 +607,16 @@ func (in *RayClusterSpec) DeepCopyInto(out *RayClusterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IdleTimeoutSeconds != nil {
+		in, out := &in.IdleTimeoutSeconds, &out.IdleTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleTimeoutPolicy != nil {
+		in, out := &in.IdleTimeoutPolicy, &out.IdleTimeoutPolicy
+		*out = new(IdleTimeoutPolicy)
+		**out = **in
+	}
 	if in.AutoscalerOptions != nil {
 		in, out := &in.AutoscalerOptions, &out.AutoscalerOptions
 		*out = new(AutoscalerOptions)
@@ -234,6 +634,28 @@ func (in *RayClusterSpec) DeepCopyInto(out *RayClusterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ServiceMeshMode != nil {
+		in, out := &in.ServiceMeshMode, &out.ServiceMeshMode
+		*out = new(ServiceMeshMode)
+		**out = **in
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(LoggingOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountTokens != nil {
+		in, out := &in.ServiceAccountTokens, &out.ServiceAccountTokens
+		*out = make([]ServiceAccountToken, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DashboardClient != nil {
+		in, out := &in.DashboardClient, &out.DashboardClient
+		*out = new(DashboardClientConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	in.HeadGroupSpec.DeepCopyInto(&out.HeadGroupSpec)
 	if in.WorkerGroupSpecs != nil {
 		in, out := &in.WorkerGroupSpecs, &out.WorkerGroupSpecs
@@ -287,6 +709,22 @@ func (in *RayClusterStatus) DeepCopyInto(out *RayClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GroupStatuses != nil {
+		in, out := &in.GroupStatuses, &out.GroupStatuses
+		*out = make([]GroupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlacementGroups != nil {
+		in, out := &in.PlacementGroups, &out.PlacementGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastActivityTime != nil {
+		in, out := &in.LastActivityTime, &out.LastActivityTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterStatus.
@@ -299,6 +737,129 @@ func (in *RayClusterStatus) DeepCopy() *RayClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayCronJob) DeepCopyInto(out *RayCronJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayCronJob.
+func (in *RayCronJob) DeepCopy() *RayCronJob {
+	if in == nil {
+		return nil
+	}
+	out := new(RayCronJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayCronJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayCronJobList) DeepCopyInto(out *RayCronJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RayCronJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayCronJobList.
+func (in *RayCronJobList) DeepCopy() *RayCronJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(RayCronJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayCronJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayCronJobSpec) DeepCopyInto(out *RayCronJobSpec) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	in.RayJobTemplate.DeepCopyInto(&out.RayJobTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayCronJobSpec.
+func (in *RayCronJobSpec) DeepCopy() *RayCronJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RayCronJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayCronJobStatus) DeepCopyInto(out *RayCronJobStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]corev1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayCronJobStatus.
+func (in *RayCronJobStatus) DeepCopy() *RayCronJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RayCronJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayJob) DeepCopyInto(out *RayJob) {
 	*out = *in
@@ -326,6 +887,29 @@ func (in *RayJob) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayJobAttempt) DeepCopyInto(out *RayJobAttempt) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobAttempt.
+func (in *RayJobAttempt) DeepCopy() *RayJobAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(RayJobAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayJobList) DeepCopyInto(out *RayJobList) {
 	*out = *in
@@ -366,6 +950,11 @@ func (in *RayJobSpec) DeepCopyInto(out *RayJobSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ClusterReadyTimeoutSeconds != nil {
+		in, out := &in.ClusterReadyTimeoutSeconds, &out.ClusterReadyTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 	if in.BackoffLimit != nil {
 		in, out := &in.BackoffLimit, &out.BackoffLimit
 		*out = new(int32)
@@ -376,6 +965,11 @@ func (in *RayJobSpec) DeepCopyInto(out *RayJobSpec) {
 		*out = new(RayClusterSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RayClusterMetadata != nil {
+		in, out := &in.RayClusterMetadata, &out.RayClusterMetadata
+		*out = new(RayClusterMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SubmitterPodTemplate != nil {
 		in, out := &in.SubmitterPodTemplate, &out.SubmitterPodTemplate
 		*out = new(corev1.PodTemplateSpec)
@@ -395,25 +989,135 @@ func (in *RayJobSpec) DeepCopyInto(out *RayJobSpec) {
 			(*out)[key] = val
 		}
 	}
-	if in.SubmitterConfig != nil {
-		in, out := &in.SubmitterConfig, &out.SubmitterConfig
-		*out = new(SubmitterConfig)
-		(*in).DeepCopyInto(*out)
+	if in.SubmitterConfig != nil {
+		in, out := &in.SubmitterConfig, &out.SubmitterConfig
+		*out = new(SubmitterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]RayJobStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeEnv != nil {
+		in, out := &in.RuntimeEnv, &out.RuntimeEnv
+		*out = new(RuntimeEnv)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogStreaming != nil {
+		in, out := &in.LogStreaming, &out.LogStreaming
+		*out = new(LogStreamingOptions)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobSpec.
+func (in *RayJobSpec) DeepCopy() *RayJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RayJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayJobStatus) DeepCopyInto(out *RayJobStatus) {
+	*out = *in
+	if in.DriverExitCode != nil {
+		in, out := &in.DriverExitCode, &out.DriverExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Succeeded != nil {
+		in, out := &in.Succeeded, &out.Succeeded
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make([]RayJobAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]RayJobStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.RayClusterStatus.DeepCopyInto(&out.RayClusterStatus)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobStatus.
+func (in *RayJobStatus) DeepCopy() *RayJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RayJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayJobStep) DeepCopyInto(out *RayJobStep) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobSpec.
-func (in *RayJobSpec) DeepCopy() *RayJobSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobStep.
+func (in *RayJobStep) DeepCopy() *RayJobStep {
 	if in == nil {
 		return nil
 	}
-	out := new(RayJobSpec)
+	out := new(RayJobStep)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayJobStatus) DeepCopyInto(out *RayJobStatus) {
+func (in *RayJobStepStatus) DeepCopyInto(out *RayJobStepStatus) {
 	*out = *in
 	if in.StartTime != nil {
 		in, out := &in.StartTime, &out.StartTime
@@ -423,25 +1127,31 @@ func (in *RayJobStatus) DeepCopyInto(out *RayJobStatus) {
 		in, out := &in.EndTime, &out.EndTime
 		*out = (*in).DeepCopy()
 	}
-	if in.Succeeded != nil {
-		in, out := &in.Succeeded, &out.Succeeded
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Failed != nil {
-		in, out := &in.Failed, &out.Failed
-		*out = new(int32)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobStepStatus.
+func (in *RayJobStepStatus) DeepCopy() *RayJobStepStatus {
+	if in == nil {
+		return nil
 	}
-	in.RayClusterStatus.DeepCopyInto(&out.RayClusterStatus)
+	out := new(RayJobStepStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobStatus.
-func (in *RayJobStatus) DeepCopy() *RayJobStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayJobTemplateSpec) DeepCopyInto(out *RayJobTemplateSpec) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobTemplateSpec.
+func (in *RayJobTemplateSpec) DeepCopy() *RayJobTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RayJobStatus)
+	out := new(RayJobTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -505,6 +1215,26 @@ func (in *RayServiceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayServiceRollback) DeepCopyInto(out *RayServiceRollback) {
+	*out = *in
+	if in.KeepPreviousClusterSeconds != nil {
+		in, out := &in.KeepPreviousClusterSeconds, &out.KeepPreviousClusterSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceRollback.
+func (in *RayServiceRollback) DeepCopy() *RayServiceRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(RayServiceRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayServiceSpec) DeepCopyInto(out *RayServiceSpec) {
 	*out = *in
@@ -518,6 +1248,21 @@ func (in *RayServiceSpec) DeepCopyInto(out *RayServiceSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.HealthCheckPeriodSeconds != nil {
+		in, out := &in.HealthCheckPeriodSeconds, &out.HealthCheckPeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(RayServiceUpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(RayServiceRollback)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ServeService != nil {
 		in, out := &in.ServeService, &out.ServeService
 		*out = new(corev1.Service)
@@ -547,6 +1292,15 @@ func (in *RayServiceStatus) DeepCopyInto(out *RayServiceStatus) {
 		}
 	}
 	in.RayClusterStatus.DeepCopyInto(&out.RayClusterStatus)
+	if in.TrafficRoutedPercent != nil {
+		in, out := &in.TrafficRoutedPercent, &out.TrafficRoutedPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastTrafficMigratedTime != nil {
+		in, out := &in.LastTrafficMigratedTime, &out.LastTrafficMigratedTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceStatus.
@@ -580,6 +1334,131 @@ func (in *RayServiceStatuses) DeepCopy() *RayServiceStatuses {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayServiceUpgradeStrategy) DeepCopyInto(out *RayServiceUpgradeStrategy) {
+	*out = *in
+	if in.ClusterUpgradeOptions != nil {
+		in, out := &in.ClusterUpgradeOptions, &out.ClusterUpgradeOptions
+		*out = new(ClusterUpgradeOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingClusterReadyTimeoutSeconds != nil {
+		in, out := &in.PendingClusterReadyTimeoutSeconds, &out.PendingClusterReadyTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceUpgradeStrategy.
+func (in *RayServiceUpgradeStrategy) DeepCopy() *RayServiceUpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RayServiceUpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayStartParamsSource) DeepCopyInto(out *RayStartParamsSource) {
+	*out = *in
+	out.ConfigMapRef = in.ConfigMapRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayStartParamsSource.
+func (in *RayStartParamsSource) DeepCopy() *RayStartParamsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RayStartParamsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateWorkerGroup) DeepCopyInto(out *RollingUpdateWorkerGroup) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateWorkerGroup.
+func (in *RollingUpdateWorkerGroup) DeepCopy() *RollingUpdateWorkerGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateWorkerGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeEnv) DeepCopyInto(out *RuntimeEnv) {
+	*out = *in
+	if in.Pip != nil {
+		in, out := &in.Pip, &out.Pip
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvVars != nil {
+		in, out := &in.EnvVars, &out.EnvVars
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeEnv.
+func (in *RuntimeEnv) DeepCopy() *RuntimeEnv {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeEnv)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalePolicy) DeepCopyInto(out *ScalePolicy) {
+	*out = *in
+	if in.MaxPodsAddedPerMinute != nil {
+		in, out := &in.MaxPodsAddedPerMinute, &out.MaxPodsAddedPerMinute
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPodsRemovedPerMinute != nil {
+		in, out := &in.MaxPodsRemovedPerMinute, &out.MaxPodsRemovedPerMinute
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalePolicy.
+func (in *ScalePolicy) DeepCopy() *ScalePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleStrategy) DeepCopyInto(out *ScaleStrategy) {
 	*out = *in
@@ -600,6 +1479,37 @@ func (in *ScaleStrategy) DeepCopy() *ScaleStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledScalingWindow) DeepCopyInto(out *ScheduledScalingWindow) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	out.Duration = in.Duration
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledScalingWindow.
+func (in *ScheduledScalingWindow) DeepCopy() *ScheduledScalingWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledScalingWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServeDeploymentStatus) DeepCopyInto(out *ServeDeploymentStatus) {
 	*out = *in
@@ -619,6 +1529,26 @@ func (in *ServeDeploymentStatus) DeepCopy() *ServeDeploymentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountToken) DeepCopyInto(out *ServiceAccountToken) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountToken.
+func (in *ServiceAccountToken) DeepCopy() *ServiceAccountToken {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubmitterConfig) DeepCopyInto(out *SubmitterConfig) {
 	*out = *in
@@ -639,6 +1569,31 @@ func (in *SubmitterConfig) DeepCopy() *SubmitterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpreadOptions) DeepCopyInto(out *TopologySpreadOptions) {
+	*out = *in
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WhenUnsatisfiable != nil {
+		in, out := &in.WhenUnsatisfiable, &out.WhenUnsatisfiable
+		*out = new(corev1.UnsatisfiableConstraintAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpreadOptions.
+func (in *TopologySpreadOptions) DeepCopy() *TopologySpreadOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpreadOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerGroupSpec) DeepCopyInto(out *WorkerGroupSpec) {
 	*out = *in
@@ -664,8 +1619,80 @@ func (in *WorkerGroupSpec) DeepCopyInto(out *WorkerGroupSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RayStartParamsFrom != nil {
+		in, out := &in.RayStartParamsFrom, &out.RayStartParamsFrom
+		*out = new(RayStartParamsSource)
+		**out = **in
+	}
 	in.Template.DeepCopyInto(&out.Template)
 	in.ScaleStrategy.DeepCopyInto(&out.ScaleStrategy)
+	if in.ScheduledScaling != nil {
+		in, out := &in.ScheduledScaling, &out.ScheduledScaling
+		*out = make([]ScheduledScalingWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScalePolicy != nil {
+		in, out := &in.ScalePolicy, &out.ScalePolicy
+		*out = new(ScalePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WarmPoolSize != nil {
+		in, out := &in.WarmPoolSize, &out.WarmPoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleTimeoutSeconds != nil {
+		in, out := &in.IdleTimeoutSeconds, &out.IdleTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SafeToEvict != nil {
+		in, out := &in.SafeToEvict, &out.SafeToEvict
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Spot != nil {
+		in, out := &in.Spot, &out.Spot
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TopologySpread != nil {
+		in, out := &in.TopologySpread, &out.TopologySpread
+		*out = new(TopologySpreadOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxPodAge != nil {
+		in, out := &in.MaxPodAge, &out.MaxPodAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(GPUOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShmSize != nil {
+		in, out := &in.ShmSize, &out.ShmSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DisableShmVolume != nil {
+		in, out := &in.DisableShmVolume, &out.DisableShmVolume
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PlacementGroup != nil {
+		in, out := &in.PlacementGroup, &out.PlacementGroup
+		*out = new(PlacementGroupOptions)
+		**out = **in
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(WorkerGroupUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerGroupSpec.
@@ -677,3 +1704,23 @@ func (in *WorkerGroupSpec) DeepCopy() *WorkerGroupSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerGroupUpdateStrategy) DeepCopyInto(out *WorkerGroupUpdateStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(RollingUpdateWorkerGroup)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerGroupUpdateStrategy.
+func (in *WorkerGroupUpdateStrategy) DeepCopy() *WorkerGroupUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerGroupUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
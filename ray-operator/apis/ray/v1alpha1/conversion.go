@@ -0,0 +1,247 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConversionDataAnnotationKey stores the fields of the spec and status of the v1 object that a v1alpha1 object was
+// converted from that v1alpha1 does not have, so that they survive a round trip through v1alpha1. Only those fields are
+// stored, so that the annotation stays well below the size limit of the annotations.
+const ConversionDataAnnotationKey = "ray.io/conversion-data"
+
+// The fields of v1alpha1 are a subset of the fields of v1 with the same JSON names, so the objects are converted
+// through their JSON representation.
+
+// ConvertTo converts this RayCluster to the hub version (v1).
+func (r *RayCluster) ConvertTo(dst conversion.Hub) error {
+	return convertToHub(r, dst, &RayCluster{})
+}
+
+// ConvertFrom converts from the hub version (v1) to this version.
+func (r *RayCluster) ConvertFrom(src conversion.Hub) error {
+	return convertFromHub(src, r)
+}
+
+// ConvertTo converts this RayJob to the hub version (v1).
+func (r *RayJob) ConvertTo(dst conversion.Hub) error {
+	return convertToHub(r, dst, &RayJob{})
+}
+
+// ConvertFrom converts from the hub version (v1) to this version.
+func (r *RayJob) ConvertFrom(src conversion.Hub) error {
+	return convertFromHub(src, r)
+}
+
+// ConvertTo converts this RayService to the hub version (v1).
+func (r *RayService) ConvertTo(dst conversion.Hub) error {
+	return convertToHub(r, dst, &RayService{})
+}
+
+// ConvertFrom converts from the hub version (v1) to this version.
+func (r *RayService) ConvertFrom(src conversion.Hub) error {
+	return convertFromHub(src, r)
+}
+
+// convertToHub converts src to dst and restores the fields of the v1 object stored in the ConversionDataAnnotationKey
+// annotation that v1alpha1 does not have. spoke is an empty object of the type of src.
+func convertToHub(src metav1.Object, dst conversion.Hub, spoke interface{}) error {
+	obj, err := toMap(src)
+	if err != nil {
+		return err
+	}
+	if data, ok := src.GetAnnotations()[ConversionDataAnnotationKey]; ok {
+		stored := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(data), &stored); err != nil {
+			return fmt.Errorf("failed to decode the %s annotation: %w", ConversionDataAnnotationKey, err)
+		}
+		// Decoding the stored fields into v1alpha1 drops the ones that v1alpha1 does not have.
+		if err := json.Unmarshal([]byte(data), spoke); err != nil {
+			return fmt.Errorf("failed to decode the %s annotation: %w", ConversionDataAnnotationKey, err)
+		}
+		known, err := toMap(spoke)
+		if err != nil {
+			return err
+		}
+		restoreFields(obj, stored, known)
+
+		metadata, _ := obj["metadata"].(map[string]interface{})
+		annotations, _ := metadata["annotations"].(map[string]interface{})
+		delete(annotations, ConversionDataAnnotationKey)
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+		}
+	}
+	return fromMap(obj, dst)
+}
+
+// convertFromHub converts src to dst and stores the fields of the spec and status of src that dst does not have in the
+// ConversionDataAnnotationKey annotation of dst.
+func convertFromHub(src conversion.Hub, dst metav1.Object) error {
+	obj, err := toMap(src)
+	if err != nil {
+		return err
+	}
+	stored := map[string]interface{}{"spec": obj["spec"], "status": obj["status"]}
+	if err := fromMap(obj, dst); err != nil {
+		return err
+	}
+	converted, err := toMap(dst)
+	if err != nil {
+		return err
+	}
+	unknown := unknownFields(stored, map[string]interface{}{"spec": converted["spec"], "status": converted["status"]})
+	if unknown == nil {
+		return nil
+	}
+	data, err := json.Marshal(unknown)
+	if err != nil {
+		return err
+	}
+	annotations := dst.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ConversionDataAnnotationKey] = string(data)
+	dst.SetAnnotations(annotations)
+	return nil
+}
+
+// unknownFields returns the fields of obj that are missing from known, the same object decoded into v1alpha1, or nil
+// if there are none. The items of lists are kept in place, with their groupName or name, so that restoreFields matches
+// them with the items of the converted object.
+func unknownFields(obj, known map[string]interface{}) map[string]interface{} {
+	unknown := map[string]interface{}{}
+	for key, value := range obj {
+		knownValue, ok := known[key]
+		if !ok {
+			unknown[key] = value
+			continue
+		}
+		switch value := value.(type) {
+		case map[string]interface{}:
+			if knownMap, ok := knownValue.(map[string]interface{}); ok {
+				if fields := unknownFields(value, knownMap); fields != nil {
+					unknown[key] = fields
+				}
+			}
+		case []interface{}:
+			knownList, ok := knownValue.([]interface{})
+			if !ok || len(knownList) != len(value) {
+				continue
+			}
+			items := make([]interface{}, len(value))
+			hasUnknownFields := false
+			for i, item := range value {
+				fields := map[string]interface{}{}
+				itemMap, itemOk := item.(map[string]interface{})
+				knownMap, knownOk := knownList[i].(map[string]interface{})
+				if itemOk && knownOk {
+					if itemFields := unknownFields(itemMap, knownMap); itemFields != nil {
+						fields, hasUnknownFields = itemFields, true
+					}
+					for _, nameKey := range []string{"groupName", "name"} {
+						if name, ok := itemMap[nameKey]; ok {
+							fields[nameKey] = name
+						}
+					}
+				}
+				items[i] = fields
+			}
+			if hasUnknownFields {
+				unknown[key] = items
+			}
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	return unknown
+}
+
+// restoreFields copies the fields of stored that are missing from known, the same fields decoded into v1alpha1, to obj.
+// Only the fields that v1alpha1 does not have are restored, so the changes made through v1alpha1 are kept, and the
+// fields of objects that were removed through v1alpha1 are not restored.
+func restoreFields(obj, stored, known map[string]interface{}) {
+	for key, storedValue := range stored {
+		knownValue, ok := known[key]
+		if !ok {
+			if _, exists := obj[key]; !exists {
+				obj[key] = storedValue
+			}
+			continue
+		}
+		switch storedValue := storedValue.(type) {
+		case map[string]interface{}:
+			objMap, objOk := obj[key].(map[string]interface{})
+			knownMap, knownOk := knownValue.(map[string]interface{})
+			if objOk && knownOk {
+				restoreFields(objMap, storedValue, knownMap)
+			}
+		case []interface{}:
+			objList, objOk := obj[key].([]interface{})
+			knownList, knownOk := knownValue.([]interface{})
+			if !objOk || !knownOk || len(knownList) != len(storedValue) {
+				continue
+			}
+			for i, storedItem := range storedValue {
+				storedMap, storedOk := storedItem.(map[string]interface{})
+				knownMap, knownOk := knownList[i].(map[string]interface{})
+				if !storedOk || !knownOk {
+					continue
+				}
+				if objMap := matchingItem(objList, storedMap, i); objMap != nil {
+					restoreFields(objMap, storedMap, knownMap)
+				}
+			}
+		}
+	}
+}
+
+// matchingItem returns the item of items that corresponds to the stored item at index i: the one with the same
+// groupName or name, e.g. the same worker group or container, or the one at the same index for unnamed items.
+func matchingItem(items []interface{}, stored map[string]interface{}, i int) map[string]interface{} {
+	for _, key := range []string{"groupName", "name"} {
+		name, ok := stored[key]
+		if !ok {
+			continue
+		}
+		for _, item := range items {
+			if item, ok := item.(map[string]interface{}); ok && item[key] == name {
+				return item
+			}
+		}
+		return nil
+	}
+	if i < len(items) {
+		item, _ := items[i].(map[string]interface{})
+		return item
+	}
+	return nil
+}
+
+func toMap(obj interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// fromMap decodes obj into dst, keeping the apiVersion and kind of dst.
+func fromMap(obj map[string]interface{}, dst interface{}) error {
+	delete(obj, "apiVersion")
+	delete(obj, "kind")
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
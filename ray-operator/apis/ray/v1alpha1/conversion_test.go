@@ -0,0 +1,153 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func newHubRayCluster() *rayv1.RayCluster {
+	return &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "raycluster-sample",
+			Namespace:   "default",
+			Annotations: map[string]string{"key": "value"},
+		},
+		Spec: rayv1.RayClusterSpec{
+			RayVersion: "2.9.0",
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				RayStartParams: map[string]string{"num-cpus": "1"},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.9.0"}},
+					},
+				},
+			},
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{
+					GroupName:    "small-group",
+					Replicas:     ptr.To[int32](1),
+					MinReplicas:  ptr.To[int32](0),
+					MaxReplicas:  ptr.To[int32](5),
+					WarmPoolSize: ptr.To[int32](2),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "ray-worker", Image: "rayproject/ray:2.9.0"}},
+						},
+					},
+				},
+				{
+					GroupName:    "large-group",
+					WarmPoolSize: ptr.To[int32](1),
+				},
+			},
+		},
+		Status: rayv1.RayClusterStatus{
+			State: rayv1.Ready,
+		},
+	}
+}
+
+// assertEqualJSON compares the JSON representations of the objects, since decoding the zero resource.Quantity values
+// of the status does not produce the zero value.
+func assertEqualJSON(t *testing.T, expected, actual interface{}) {
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
+
+func TestRayClusterConversion(t *testing.T) {
+	t.Run("convert to v1alpha1 and back without losing the v1 fields", func(t *testing.T) {
+		hub := newHubRayCluster()
+		spoke := &RayCluster{}
+		require.NoError(t, spoke.ConvertFrom(hub))
+		assert.Equal(t, "raycluster-sample", spoke.Name)
+		assert.Equal(t, "2.9.0", spoke.Spec.RayVersion)
+		assert.Equal(t, int32(5), *spoke.Spec.WorkerGroupSpecs[0].MaxReplicas)
+		assert.Equal(t, Ready, spoke.Status.State)
+		assert.Contains(t, spoke.Annotations, ConversionDataAnnotationKey)
+		// Only the fields that v1alpha1 does not have are stored.
+		assert.Contains(t, spoke.Annotations[ConversionDataAnnotationKey], `"warmPoolSize":2`)
+		assert.NotContains(t, spoke.Annotations[ConversionDataAnnotationKey], "rayVersion")
+		assert.NotContains(t, spoke.Annotations[ConversionDataAnnotationKey], "rayproject/ray")
+
+		converted := &rayv1.RayCluster{}
+		require.NoError(t, spoke.ConvertTo(converted))
+		assertEqualJSON(t, hub, converted)
+	})
+
+	t.Run("keep the changes made through v1alpha1", func(t *testing.T) {
+		spoke := &RayCluster{}
+		require.NoError(t, spoke.ConvertFrom(newHubRayCluster()))
+		// Remove the first worker group and update the second one.
+		spoke.Spec.WorkerGroupSpecs = spoke.Spec.WorkerGroupSpecs[1:]
+		spoke.Spec.WorkerGroupSpecs[0].MaxReplicas = ptr.To[int32](3)
+		delete(spoke.Spec.HeadGroupSpec.RayStartParams, "num-cpus")
+
+		converted := &rayv1.RayCluster{}
+		require.NoError(t, spoke.ConvertTo(converted))
+		require.Len(t, converted.Spec.WorkerGroupSpecs, 1)
+		assert.Equal(t, "large-group", converted.Spec.WorkerGroupSpecs[0].GroupName)
+		assert.Equal(t, int32(3), *converted.Spec.WorkerGroupSpecs[0].MaxReplicas)
+		assert.Equal(t, int32(1), *converted.Spec.WorkerGroupSpecs[0].WarmPoolSize)
+		assert.Empty(t, converted.Spec.HeadGroupSpec.RayStartParams)
+		assert.Equal(t, map[string]string{"key": "value"}, converted.Annotations)
+	})
+
+	t.Run("convert v1alpha1 objects never converted from v1", func(t *testing.T) {
+		spoke := &RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "raycluster-sample", Namespace: "default"},
+			Spec: RayClusterSpec{
+				WorkerGroupSpecs: []WorkerGroupSpec{{GroupName: "small-group", Replicas: ptr.To[int32](1)}},
+			},
+		}
+		converted := &rayv1.RayCluster{}
+		require.NoError(t, spoke.ConvertTo(converted))
+		assert.Equal(t, "raycluster-sample", converted.Name)
+		assert.Empty(t, converted.Annotations)
+		assert.Equal(t, int32(1), *converted.Spec.WorkerGroupSpecs[0].Replicas)
+		assert.Nil(t, converted.Spec.WorkerGroupSpecs[0].WarmPoolSize)
+	})
+}
+
+func TestRayJobConversion(t *testing.T) {
+	hub := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayjob-sample", Namespace: "default"},
+		Spec: rayv1.RayJobSpec{
+			Entrypoint:            "python /home/ray/samples/sample_code.py",
+			ActiveDeadlineSeconds: ptr.To[int32](60),
+			RayClusterSpec:        &newHubRayCluster().Spec,
+		},
+	}
+	spoke := &RayJob{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+	assert.Equal(t, hub.Spec.Entrypoint, spoke.Spec.Entrypoint)
+
+	converted := &rayv1.RayJob{}
+	require.NoError(t, spoke.ConvertTo(converted))
+	assertEqualJSON(t, hub, converted)
+}
+
+func TestRayServiceConversion(t *testing.T) {
+	hub := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice-sample", Namespace: "default"},
+		Spec: rayv1.RayServiceSpec{
+			RayClusterSpec: newHubRayCluster().Spec,
+		},
+	}
+	spoke := &RayService{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+
+	converted := &rayv1.RayService{}
+	require.NoError(t, spoke.ConvertTo(converted))
+	assertEqualJSON(t, hub, converted)
+}
@@ -2,6 +2,7 @@ package schedulerinterface
 
 import (
 	"context"
+	"errors"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -11,6 +12,10 @@ import (
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
 
+// ErrNotAdmitted is wrapped by the errors of DoBatchSchedulingOnSubmission when the batch scheduler has not admitted the
+// RayCluster, or has evicted it, e.g. to preempt it. The Pods of the RayCluster are deleted until it is admitted.
+var ErrNotAdmitted = errors.New("the RayCluster is not admitted by the batch scheduler")
+
 // BatchScheduler manages submitting RayCluster pods to a third-party scheduler.
 type BatchScheduler interface {
 	// Name corresponds to the schedulerName in Kubernetes:
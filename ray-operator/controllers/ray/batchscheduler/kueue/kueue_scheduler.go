@@ -0,0 +1,307 @@
+package kueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	QueueNameLabelKey = "kueue.x-k8s.io/queue-name"
+	// WorkloadAdmittedCondition is the condition of the status of a Workload that Kueue sets to true once the Workload
+	// is admitted to a ClusterQueue, and to false if it is evicted, e.g. because it is preempted.
+	WorkloadAdmittedCondition = "Admitted"
+	// Kueue rejects the Workloads with more pod sets.
+	maxPodSets = 8
+)
+
+var (
+	// WorkloadGVR is the resource of the Kueue Workloads. KubeRay reads and writes them as unstructured objects, so
+	// that it does not depend on the Go module of Kueue.
+	WorkloadGVR = schema.GroupVersionResource{Group: "kueue.x-k8s.io", Version: "v1beta1", Resource: "workloads"}
+	// ResourceFlavorGVR is the resource of the Kueue ResourceFlavors, which are cluster-scoped.
+	ResourceFlavorGVR = schema.GroupVersionResource{Group: "kueue.x-k8s.io", Version: "v1beta1", Resource: "resourceflavors"}
+)
+
+// KueueBatchScheduler admits the RayClusters to Kueue natively: it creates a Kueue Workload with a pod set for the
+// head group and for each worker group of the RayCluster, and reports the RayCluster as not admitted until Kueue
+// admits the Workload, so that its Pods are only created within the quota of the ClusterQueue. The Pods of each group
+// get the node labels and tolerations of the ResourceFlavors assigned to its pod set, so that they run on the nodes
+// the quota was reserved for. It must not be used along with the RayJob and RayCluster integrations of Kueue, which
+// would suspend the same RayClusters.
+//
+// The Workload only counts the minReplicas of the worker groups of an autoscaled RayCluster, so the Ray autoscaler can
+// grow the groups beyond the quota admitted by Kueue.
+type KueueBatchScheduler struct {
+	dynamicClient dynamic.Interface
+
+	// podSetFlavors are the node labels and tolerations of the pod sets of the admitted Workloads, by RayCluster and
+	// group name.
+	podSetFlavors map[types.NamespacedName]map[string]podSetFlavor
+	mu            sync.Mutex
+}
+
+// podSetFlavor is the scheduling constraints of the ResourceFlavors assigned to a pod set.
+type podSetFlavor struct {
+	nodeLabels  map[string]string
+	tolerations []corev1.Toleration
+}
+
+type KueueBatchSchedulerFactory struct{}
+
+func GetPluginName() string {
+	return "kueue"
+}
+
+func (k *KueueBatchScheduler) Name() string {
+	return GetPluginName()
+}
+
+func (k *KueueBatchScheduler) DoBatchSchedulingOnSubmission(ctx context.Context, app *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx).WithName(GetPluginName())
+	workloadName := getAppWorkloadName(app)
+	workload, err := k.dynamicClient.Resource(WorkloadGVR).Namespace(app.Namespace).Get(ctx, workloadName, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if workload, err = createWorkload(ctx, app, workloadName); err != nil {
+			return err
+		}
+		// The pod sets of a Workload are immutable, so the Workload keeps the replicas of the RayCluster at creation.
+		if workload, err = k.dynamicClient.Resource(WorkloadGVR).Namespace(app.Namespace).Create(ctx, workload, metav1.CreateOptions{}); err != nil {
+			logger.Error(err, "Workload CREATE error!", "workload", workloadName)
+			return err
+		}
+		logger.Info("Created the Kueue Workload of the RayCluster", "workload", workloadName)
+	}
+	key := types.NamespacedName{Namespace: app.Namespace, Name: app.Name}
+	if !isWorkloadAdmitted(workload) {
+		k.setPodSetFlavors(key, nil)
+		return fmt.Errorf("%w: the Kueue Workload %s is not admitted", schedulerinterface.ErrNotAdmitted, workloadName)
+	}
+	podSetFlavors, err := k.getPodSetFlavors(ctx, workload)
+	if err != nil {
+		return err
+	}
+	k.setPodSetFlavors(key, podSetFlavors)
+	return nil
+}
+
+func (k *KueueBatchScheduler) setPodSetFlavors(key types.NamespacedName, podSetFlavors map[string]podSetFlavor) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if podSetFlavors == nil {
+		delete(k.podSetFlavors, key)
+		return
+	}
+	if k.podSetFlavors == nil {
+		k.podSetFlavors = map[types.NamespacedName]map[string]podSetFlavor{}
+	}
+	k.podSetFlavors[key] = podSetFlavors
+}
+
+// getPodSetFlavors returns the node labels and tolerations of the ResourceFlavors that Kueue assigned to each pod set
+// of the admitted Workload in its status.admission.podSetAssignments.
+func (k *KueueBatchScheduler) getPodSetFlavors(ctx context.Context, workload *unstructured.Unstructured) (map[string]podSetFlavor, error) {
+	podSetAssignments, _, err := unstructured.NestedSlice(workload.Object, "status", "admission", "podSetAssignments")
+	if err != nil {
+		return nil, err
+	}
+	resourceFlavors := map[string]podSetFlavor{}
+	podSetFlavors := map[string]podSetFlavor{}
+	for _, podSetAssignment := range podSetAssignments {
+		podSetAssignment, ok := podSetAssignment.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		podSetName, _, _ := unstructured.NestedString(podSetAssignment, "name")
+		flavorsByResource, _, _ := unstructured.NestedStringMap(podSetAssignment, "flavors")
+		// The same ResourceFlavor is usually assigned to several resources of the pod set.
+		flavorNames := sets.New[string]()
+		for _, flavorName := range flavorsByResource {
+			flavorNames.Insert(flavorName)
+		}
+		podSet := podSetFlavor{nodeLabels: map[string]string{}}
+		for _, flavorName := range sets.List(flavorNames) {
+			flavor, ok := resourceFlavors[flavorName]
+			if !ok {
+				if flavor, err = k.getResourceFlavor(ctx, flavorName); err != nil {
+					return nil, err
+				}
+				resourceFlavors[flavorName] = flavor
+			}
+			for key, value := range flavor.nodeLabels {
+				podSet.nodeLabels[key] = value
+			}
+			podSet.tolerations = append(podSet.tolerations, flavor.tolerations...)
+		}
+		podSetFlavors[podSetName] = podSet
+	}
+	return podSetFlavors, nil
+}
+
+// getResourceFlavor returns the node labels and tolerations of the ResourceFlavor.
+func (k *KueueBatchScheduler) getResourceFlavor(ctx context.Context, name string) (podSetFlavor, error) {
+	resourceFlavor, err := k.dynamicClient.Resource(ResourceFlavorGVR).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return podSetFlavor{}, err
+	}
+	nodeLabels, _, err := unstructured.NestedStringMap(resourceFlavor.Object, "spec", "nodeLabels")
+	if err != nil {
+		return podSetFlavor{}, err
+	}
+	unstructuredTolerations, _, err := unstructured.NestedSlice(resourceFlavor.Object, "spec", "tolerations")
+	if err != nil {
+		return podSetFlavor{}, err
+	}
+	tolerations := make([]corev1.Toleration, 0, len(unstructuredTolerations))
+	for _, unstructuredToleration := range unstructuredTolerations {
+		unstructuredToleration, ok := unstructuredToleration.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		toleration := corev1.Toleration{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredToleration, &toleration); err != nil {
+			return podSetFlavor{}, err
+		}
+		tolerations = append(tolerations, toleration)
+	}
+	return podSetFlavor{nodeLabels: nodeLabels, tolerations: tolerations}, nil
+}
+
+func getAppWorkloadName(app *rayv1.RayCluster) string {
+	return fmt.Sprintf("ray-%s-workload", app.Name)
+}
+
+// createWorkload returns the Workload of the RayCluster. Kueue admits it if the quota of the ClusterQueue fits the
+// resource requests of the templates of its pod sets multiplied by their counts: the desired replicas of the worker
+// groups, or their minimum replicas if autoscaling is enabled.
+func createWorkload(ctx context.Context, app *rayv1.RayCluster, workloadName string) (*unstructured.Unstructured, error) {
+	if len(app.Spec.WorkerGroupSpecs)+1 > maxPodSets {
+		return nil, fmt.Errorf("a Kueue Workload has at most %d pod sets, but the RayCluster %s has %d worker groups", maxPodSets, app.Name, len(app.Spec.WorkerGroupSpecs))
+	}
+	autoscaling := app.Spec.EnableInTreeAutoscaling != nil && *app.Spec.EnableInTreeAutoscaling
+
+	headPodSet, err := newPodSet(utils.RayNodeHeadGroupLabelValue, 1, app.Spec.HeadGroupSpec.Template)
+	if err != nil {
+		return nil, err
+	}
+	podSets := []interface{}{headPodSet}
+	for _, worker := range app.Spec.WorkerGroupSpecs {
+		replicas := utils.GetWorkerGroupDesiredReplicas(ctx, worker)
+		if autoscaling {
+			replicas = *worker.MinReplicas
+		}
+		// Each replica of a multi-host worker group has NumOfHosts Pods, which is 0 if the CRD does not have it.
+		numOfHosts := max(worker.NumOfHosts, 1)
+		podSet, err := newPodSet(worker.GroupName, int64(replicas*numOfHosts), worker.Template)
+		if err != nil {
+			return nil, err
+		}
+		podSets = append(podSets, podSet)
+	}
+
+	spec := map[string]interface{}{
+		"podSets": podSets,
+	}
+	if queue, ok := app.ObjectMeta.Labels[QueueNameLabelKey]; ok {
+		spec["queueName"] = queue
+	}
+	if priorityClassName, ok := app.ObjectMeta.Labels[utils.RayPriorityClassName]; ok {
+		spec["priorityClassName"] = priorityClassName
+		spec["priorityClassSource"] = "scheduling.k8s.io/priorityclass"
+	}
+
+	workload := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	workload.SetAPIVersion(WorkloadGVR.GroupVersion().String())
+	workload.SetKind("Workload")
+	workload.SetName(workloadName)
+	workload.SetNamespace(app.Namespace)
+	// The Workload is deleted along with the RayCluster, which releases its quota.
+	workload.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(app, rayv1.SchemeGroupVersion.WithKind("RayCluster")),
+	})
+	return workload, nil
+}
+
+func newPodSet(name string, count int64, template corev1.PodTemplateSpec) (map[string]interface{}, error) {
+	unstructuredTemplate, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&template)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"name":     name,
+		"count":    count,
+		"template": unstructuredTemplate,
+	}, nil
+}
+
+// isWorkloadAdmitted returns whether the Admitted condition of the Workload is true.
+func isWorkloadAdmitted(workload *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(workload.Object, "status", "conditions")
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]interface{})
+		if ok && condition["type"] == WorkloadAdmittedCondition {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
+
+func (k *KueueBatchScheduler) AddMetadataToPod(app *rayv1.RayCluster, groupName string, pod *corev1.Pod) {
+	if priorityClassName, ok := app.ObjectMeta.Labels[utils.RayPriorityClassName]; ok {
+		pod.Spec.PriorityClassName = priorityClassName
+	}
+
+	k.mu.Lock()
+	podSet, ok := k.podSetFlavors[types.NamespacedName{Namespace: app.Namespace, Name: app.Name}][groupName]
+	k.mu.Unlock()
+	if !ok {
+		return
+	}
+	if len(podSet.nodeLabels) > 0 && pod.Spec.NodeSelector == nil {
+		pod.Spec.NodeSelector = map[string]string{}
+	}
+	for key, value := range podSet.nodeLabels {
+		pod.Spec.NodeSelector[key] = value
+	}
+	pod.Spec.Tolerations = append(pod.Spec.Tolerations, podSet.tolerations...)
+}
+
+func (kf *KueueBatchSchedulerFactory) New(config *rest.Config) (schedulerinterface.BatchScheduler, error) {
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize dynamic client with error %w", err)
+	}
+	return &KueueBatchScheduler{
+		dynamicClient: dynamicClient,
+	}, nil
+}
+
+func (kf *KueueBatchSchedulerFactory) AddToScheme(_ *runtime.Scheme) {
+	// The Workloads are unstructured objects, so no extra scheme needs to be registered.
+}
+
+func (kf *KueueBatchSchedulerFactory) ConfigureReconciler(b *builder.Builder) *builder.Builder {
+	// The Workloads are not watched, as the Kueue CRDs may not be installed when another batch scheduler is used.
+	// The RayClusters waiting for admission are requeued instead.
+	return b
+}
@@ -0,0 +1,197 @@
+package kueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func newRayCluster() *rayv1.RayCluster {
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "ray",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		},
+	}
+	return &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raycluster-sample",
+			Namespace: "default",
+			Labels: map[string]string{
+				utils.RaySchedulerName:     GetPluginName(),
+				QueueNameLabelKey:          "user-queue",
+				utils.RayPriorityClassName: "high-priority",
+			},
+		},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{Spec: podSpec},
+			},
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{
+					GroupName:   "small-group",
+					Template:    corev1.PodTemplateSpec{Spec: podSpec},
+					Replicas:    ptr.To[int32](2),
+					MinReplicas: ptr.To[int32](1),
+					MaxReplicas: ptr.To[int32](4),
+					NumOfHosts:  2,
+				},
+			},
+		},
+	}
+}
+
+func getPodSetCounts(t *testing.T, workload *unstructured.Unstructured) map[string]int64 {
+	podSets, found, err := unstructured.NestedSlice(workload.Object, "spec", "podSets")
+	require.NoError(t, err)
+	require.True(t, found)
+	counts := map[string]int64{}
+	for _, podSet := range podSets {
+		podSet := podSet.(map[string]interface{})
+		counts[podSet["name"].(string)] = podSet["count"].(int64)
+	}
+	return counts
+}
+
+func TestCreateWorkload(t *testing.T) {
+	ctx := context.Background()
+	cluster := newRayCluster()
+
+	workload, err := createWorkload(ctx, cluster, getAppWorkloadName(cluster))
+	require.NoError(t, err)
+	assert.Equal(t, "ray-raycluster-sample-workload", workload.GetName())
+	assert.Equal(t, "Workload", workload.GetKind())
+	require.Len(t, workload.GetOwnerReferences(), 1)
+	assert.Equal(t, cluster.Name, workload.GetOwnerReferences()[0].Name)
+
+	queueName, _, _ := unstructured.NestedString(workload.Object, "spec", "queueName")
+	assert.Equal(t, "user-queue", queueName)
+	priorityClassName, _, _ := unstructured.NestedString(workload.Object, "spec", "priorityClassName")
+	assert.Equal(t, "high-priority", priorityClassName)
+
+	// The worker group has 2 replicas of 2 hosts.
+	assert.Equal(t, map[string]int64{utils.RayNodeHeadGroupLabelValue: 1, "small-group": 4}, getPodSetCounts(t, workload))
+
+	// The minimum replicas are requested if autoscaling is enabled.
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	workload, err = createWorkload(ctx, cluster, getAppWorkloadName(cluster))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{utils.RayNodeHeadGroupLabelValue: 1, "small-group": 2}, getPodSetCounts(t, workload))
+
+	// Kueue does not support more than 8 pod sets.
+	for i := 0; i < maxPodSets; i++ {
+		cluster.Spec.WorkerGroupSpecs = append(cluster.Spec.WorkerGroupSpecs, cluster.Spec.WorkerGroupSpecs[0])
+	}
+	_, err = createWorkload(ctx, cluster, getAppWorkloadName(cluster))
+	require.Error(t, err)
+}
+
+func TestDoBatchSchedulingOnSubmission(t *testing.T) {
+	ctx := context.Background()
+	cluster := newRayCluster()
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	scheduler := &KueueBatchScheduler{dynamicClient: dynamicClient}
+
+	// The Workload is created, and the RayCluster is not admitted until Kueue admits it.
+	err := scheduler.DoBatchSchedulingOnSubmission(ctx, cluster)
+	require.True(t, errors.Is(err, schedulerinterface.ErrNotAdmitted))
+	workloads := dynamicClient.Resource(WorkloadGVR).Namespace(cluster.Namespace)
+	workload, err := workloads.Get(ctx, getAppWorkloadName(cluster), metav1.GetOptions{})
+	require.NoError(t, err)
+
+	setAdmitted := func(status metav1.ConditionStatus) {
+		err := unstructured.SetNestedSlice(workload.Object, []interface{}{
+			map[string]interface{}{"type": WorkloadAdmittedCondition, "status": string(status)},
+		}, "status", "conditions")
+		require.NoError(t, err)
+		workload, err = workloads.Update(ctx, workload, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+
+	setAdmitted(metav1.ConditionTrue)
+	require.NoError(t, scheduler.DoBatchSchedulingOnSubmission(ctx, cluster))
+
+	// The RayCluster is not admitted anymore once Kueue evicts its Workload.
+	setAdmitted(metav1.ConditionFalse)
+	err = scheduler.DoBatchSchedulingOnSubmission(ctx, cluster)
+	require.True(t, errors.Is(err, schedulerinterface.ErrNotAdmitted))
+}
+
+func TestAddMetadataToPod(t *testing.T) {
+	ctx := context.Background()
+	cluster := newRayCluster()
+	resourceFlavor := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"nodeLabels": map[string]interface{}{"cloud.provider.com/accelerator": "nvidia-a100"},
+			"tolerations": []interface{}{
+				map[string]interface{}{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoSchedule"},
+			},
+		},
+	}}
+	resourceFlavor.SetAPIVersion(ResourceFlavorGVR.GroupVersion().String())
+	resourceFlavor.SetKind("ResourceFlavor")
+	resourceFlavor.SetName("a100")
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), resourceFlavor)
+	scheduler := &KueueBatchScheduler{dynamicClient: dynamicClient}
+
+	// The Pods only get the priority class until the Workload is admitted.
+	pod := &corev1.Pod{}
+	scheduler.AddMetadataToPod(cluster, "small-group", pod)
+	assert.Equal(t, "high-priority", pod.Spec.PriorityClassName)
+	assert.Nil(t, pod.Spec.NodeSelector)
+
+	err := scheduler.DoBatchSchedulingOnSubmission(ctx, cluster)
+	require.True(t, errors.Is(err, schedulerinterface.ErrNotAdmitted))
+	workloads := dynamicClient.Resource(WorkloadGVR).Namespace(cluster.Namespace)
+	workload, err := workloads.Get(ctx, getAppWorkloadName(cluster), metav1.GetOptions{})
+	require.NoError(t, err)
+	err = unstructured.SetNestedField(workload.Object, map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": WorkloadAdmittedCondition, "status": string(metav1.ConditionTrue)},
+		},
+		"admission": map[string]interface{}{
+			"podSetAssignments": []interface{}{
+				map[string]interface{}{"name": utils.RayNodeHeadGroupLabelValue, "flavors": map[string]interface{}{}},
+				map[string]interface{}{"name": "small-group", "flavors": map[string]interface{}{"cpu": "a100", "nvidia.com/gpu": "a100"}},
+			},
+		},
+	}, "status")
+	require.NoError(t, err)
+	_, err = workloads.Update(ctx, workload, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, scheduler.DoBatchSchedulingOnSubmission(ctx, cluster))
+
+	// The Pods of the group get the node labels and tolerations of the ResourceFlavor assigned to its pod set.
+	pod = &corev1.Pod{Spec: corev1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/os": "linux"}}}
+	scheduler.AddMetadataToPod(cluster, "small-group", pod)
+	assert.Equal(t, map[string]string{"kubernetes.io/os": "linux", "cloud.provider.com/accelerator": "nvidia-a100"}, pod.Spec.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{
+		{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	}, pod.Spec.Tolerations)
+
+	headPod := &corev1.Pod{}
+	scheduler.AddMetadataToPod(cluster, utils.RayNodeHeadGroupLabelValue, headPod)
+	assert.Empty(t, headPod.Spec.NodeSelector)
+	assert.Empty(t, headPod.Spec.Tolerations)
+}
@@ -10,6 +10,7 @@ import (
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/kueue"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/volcano"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/yunikorn"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
@@ -17,6 +18,7 @@ import (
 
 var schedulerContainers = map[string]schedulerinterface.BatchSchedulerFactory{
 	schedulerinterface.GetDefaultPluginName(): &schedulerinterface.DefaultBatchSchedulerFactory{},
+	kueue.GetPluginName():                     &kueue.KueueBatchSchedulerFactory{},
 	volcano.GetPluginName():                   &volcano.VolcanoBatchSchedulerFactory{},
 	yunikorn.GetPluginName():                  &yunikorn.YuniKornSchedulerFactory{},
 }
@@ -11,7 +11,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 
-	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	volcanov1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
@@ -21,7 +20,7 @@ import (
 
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	ctrl "sigs.k8s.io/controller-runtime"
 
 	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
@@ -35,7 +34,6 @@ const (
 type VolcanoBatchScheduler struct {
 	extensionClient apiextensionsclient.Interface
 	volcanoClient   volcanoclient.Interface
-	log             logr.Logger
 }
 
 type VolcanoBatchSchedulerFactory struct{}
@@ -59,30 +57,31 @@ func (v *VolcanoBatchScheduler) DoBatchSchedulingOnSubmission(ctx context.Contex
 		totalResource = utils.CalculateMinResources(app)
 	}
 
-	return v.syncPodGroup(app, minMember, totalResource)
+	return v.syncPodGroup(ctx, app, minMember, totalResource)
 }
 
 func getAppPodGroupName(app *rayv1.RayCluster) string {
 	return fmt.Sprintf("ray-%s-pg", app.Name)
 }
 
-func (v *VolcanoBatchScheduler) syncPodGroup(app *rayv1.RayCluster, size int32, totalResource corev1.ResourceList) error {
+func (v *VolcanoBatchScheduler) syncPodGroup(ctx context.Context, app *rayv1.RayCluster, size int32, totalResource corev1.ResourceList) error {
+	logger := ctrl.LoggerFrom(ctx).WithName(GetPluginName())
 	podGroupName := getAppPodGroupName(app)
-	if pg, err := v.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Get(context.TODO(), podGroupName, metav1.GetOptions{}); err != nil {
+	if pg, err := v.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Get(ctx, podGroupName, metav1.GetOptions{}); err != nil {
 		if !errors.IsNotFound(err) {
 			return err
 		}
 
 		podGroup := createPodGroup(app, podGroupName, size, totalResource)
 		if _, err := v.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Create(
-			context.TODO(), &podGroup, metav1.CreateOptions{},
+			ctx, &podGroup, metav1.CreateOptions{},
 		); err != nil {
 			if errors.IsAlreadyExists(err) {
-				v.log.Info("pod group already exists, no need to create")
+				logger.Info("pod group already exists, no need to create")
 				return nil
 			}
 
-			v.log.Error(err, "Pod group CREATE error!", "PodGroup.Error", err)
+			logger.Error(err, "Pod group CREATE error!", "podGroup", podGroupName)
 			return err
 		}
 	} else {
@@ -90,9 +89,9 @@ func (v *VolcanoBatchScheduler) syncPodGroup(app *rayv1.RayCluster, size int32,
 			pg.Spec.MinMember = size
 			pg.Spec.MinResources = &totalResource
 			if _, err := v.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Update(
-				context.TODO(), pg, metav1.UpdateOptions{},
+				ctx, pg, metav1.UpdateOptions{},
 			); err != nil {
-				v.log.Error(err, "Pod group UPDATE error!", "podGroup", podGroupName)
+				logger.Error(err, "Pod group UPDATE error!", "podGroup", podGroupName)
 				return err
 			}
 		}
@@ -173,7 +172,6 @@ func (vf *VolcanoBatchSchedulerFactory) New(config *rest.Config) (schedulerinter
 	return &VolcanoBatchScheduler{
 		extensionClient: extClient,
 		volcanoClient:   vkClient,
-		log:             logf.Log.WithName("volcano"),
 	}, nil
 }
 
@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -51,6 +52,52 @@ func RayClusterHeadServiceListOptions(instance *rayv1.RayCluster) []client.ListO
 	}
 }
 
+const (
+	// PodRayClusterIndexField indexes the Pods by the name of their RayCluster.
+	PodRayClusterIndexField = "rayCluster"
+	// PodRayNodeTypeIndexField indexes the Pods by the name of their RayCluster and their node type, e.g.
+	// `raycluster/worker`.
+	PodRayNodeTypeIndexField = "rayCluster.nodeType"
+	// PodRayNodeGroupIndexField indexes the Pods by the name of their RayCluster and their group, e.g.
+	// `raycluster/small-group`.
+	PodRayNodeGroupIndexField = "rayCluster.group"
+)
+
+// PodIndexers returns the functions indexing the Pods by the labels the controllers select them with.
+func PodIndexers() map[string]client.IndexerFunc {
+	return map[string]client.IndexerFunc{
+		PodRayClusterIndexField:   podLabelsIndexer(utils.RayClusterLabelKey),
+		PodRayNodeTypeIndexField:  podLabelsIndexer(utils.RayClusterLabelKey, utils.RayNodeTypeLabelKey),
+		PodRayNodeGroupIndexField: podLabelsIndexer(utils.RayClusterLabelKey, utils.RayNodeGroupLabelKey),
+	}
+}
+
+// IndexPodFields registers the indexes of PodIndexers in the cache of the manager.
+func IndexPodFields(ctx context.Context, indexer client.FieldIndexer) error {
+	for field, extractValue := range PodIndexers() {
+		if err := indexer.IndexField(ctx, &corev1.Pod{}, field, extractValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// podLabelsIndexer indexes the objects by the values of the labels joined with "/". The objects missing any of the
+// labels are not indexed.
+func podLabelsIndexer(keys ...string) client.IndexerFunc {
+	return func(obj client.Object) []string {
+		values := make([]string, 0, len(keys))
+		for _, key := range keys {
+			value, ok := obj.GetLabels()[key]
+			if !ok {
+				return nil
+			}
+			values = append(values, value)
+		}
+		return []string{strings.Join(values, "/")}
+	}
+}
+
 type AssociationOption interface {
 	client.ListOption
 	client.DeleteAllOfOption
@@ -65,6 +112,29 @@ func (list AssociationOptions) ToListOptions() (options []client.ListOption) {
 	return options
 }
 
+// ToCachedPodListOptions returns the list options of the association of Pods, which also select the Pods with the
+// indexes of PodIndexers when possible, so that the cache does not scan all the Pods of the namespace. The options can
+// only be used with a client reading from the cache of the manager, as the API server does not know the indexes.
+func (list AssociationOptions) ToCachedPodListOptions() []client.ListOption {
+	options := list.ToListOptions()
+	listOptions := client.ListOptions{}
+	listOptions.ApplyOptions(options)
+	if listOptions.LabelSelector == nil {
+		return options
+	}
+	cluster, ok := listOptions.LabelSelector.RequiresExactMatch(utils.RayClusterLabelKey)
+	if !ok {
+		return options
+	}
+	if group, ok := listOptions.LabelSelector.RequiresExactMatch(utils.RayNodeGroupLabelKey); ok {
+		return append(options, client.MatchingFields{PodRayNodeGroupIndexField: cluster + "/" + group})
+	}
+	if nodeType, ok := listOptions.LabelSelector.RequiresExactMatch(utils.RayNodeTypeLabelKey); ok {
+		return append(options, client.MatchingFields{PodRayNodeTypeIndexField: cluster + "/" + nodeType})
+	}
+	return append(options, client.MatchingFields{PodRayClusterIndexField: cluster})
+}
+
 func (list AssociationOptions) ToDeleteOptions() (options []client.DeleteAllOfOption) {
 	for _, option := range list {
 		options = append(options, option.(client.DeleteAllOfOption))
@@ -110,6 +180,17 @@ func RayClusterGroupPodsAssociationOptions(instance *rayv1.RayCluster, group str
 	}
 }
 
+// RayClusterWarmPoolPodsAssociationOptions selects the standby Pods of the warm pools of the worker groups of the
+// RayCluster, which RayClusterAllPodsAssociationOptions does not select.
+func RayClusterWarmPoolPodsAssociationOptions(instance *rayv1.RayCluster) AssociationOptions {
+	return AssociationOptions{
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels{
+			utils.RayWarmPoolLabelKey: instance.Name,
+		},
+	}
+}
+
 func RayClusterAllPodsAssociationOptions(instance *rayv1.RayCluster) AssociationOptions {
 	return AssociationOptions{
 		client.InNamespace(instance.Namespace),
@@ -150,6 +231,10 @@ func RayServicePendingRayClusterNamespacedName(rayService *rayv1.RayService) typ
 	return types.NamespacedName{Name: rayService.Status.PendingServiceStatus.RayClusterName, Namespace: rayService.Namespace}
 }
 
+func RayServicePreviousRayClusterNamespacedName(rayService *rayv1.RayService) types.NamespacedName {
+	return types.NamespacedName{Name: rayService.Status.PreviousRayClusterName, Namespace: rayService.Namespace}
+}
+
 // RayJobK8sJobNamespacedName is the only place to associate the RayJob with the submitter Kubernetes Job.
 func RayJobK8sJobNamespacedName(rayJob *rayv1.RayJob) types.NamespacedName {
 	return types.NamespacedName{
@@ -166,12 +251,13 @@ func RayJobRayClusterNamespacedName(rayJob *rayv1.RayJob) types.NamespacedName {
 }
 
 // GetRayClusterHeadPod gets a *corev1.Pod from a *rayv1.RayCluster. Note that it returns (nil, nil) in the case of no head pod exists.
+// The reader must read from the cache of the manager, which indexes the Pods.
 func GetRayClusterHeadPod(ctx context.Context, reader client.Reader, instance *rayv1.RayCluster) (*corev1.Pod, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
 	runtimePods := corev1.PodList{}
 	filterLabels := RayClusterHeadPodsAssociationOptions(instance)
-	if err := reader.List(ctx, &runtimePods, filterLabels.ToListOptions()...); err != nil {
+	if err := reader.List(ctx, &runtimePods, filterLabels.ToCachedPodListOptions()...); err != nil {
 		return nil, err
 	}
 	if len(runtimePods.Items) == 0 {
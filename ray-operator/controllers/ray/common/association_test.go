@@ -284,12 +284,55 @@ func TestGetRayClusterHeadPod(t *testing.T) {
 		},
 	}
 
-	// Initialize a fake client with newScheme and runtimeObjects.
+	// Initialize a fake client with newScheme and runtimeObjects, indexing the Pods like the cache of the manager.
 	runtimeObjects := []runtime.Object{headPod}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	builder := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...)
+	for field, extractValue := range PodIndexers() {
+		builder = builder.WithIndex(&corev1.Pod{}, field, extractValue)
+	}
+	fakeClient := builder.Build()
 	ctx := context.TODO()
 
 	ret, err := GetRayClusterHeadPod(ctx, fakeClient, &cluster)
 	assert.Nil(t, err)
 	assert.Equal(t, ret, headPod)
 }
+
+func TestToCachedPodListOptions(t *testing.T) {
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+
+	for _, test := range []struct {
+		name           string
+		options        AssociationOptions
+		expectedFields client.MatchingFields
+	}{
+		{"all Pods", RayClusterAllPodsAssociationOptions(cluster), client.MatchingFields{PodRayClusterIndexField: "raycluster"}},
+		{"head Pods", RayClusterHeadPodsAssociationOptions(cluster), client.MatchingFields{PodRayNodeTypeIndexField: "raycluster/head"}},
+		{"group Pods", RayClusterGroupPodsAssociationOptions(cluster, "small-group"), client.MatchingFields{PodRayNodeGroupIndexField: "raycluster/small-group"}},
+		{"warm pool Pods", RayClusterWarmPoolPodsAssociationOptions(cluster), nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			options := test.options.ToCachedPodListOptions()
+			if test.expectedFields == nil {
+				assert.Equal(t, test.options.ToListOptions(), options)
+				return
+			}
+			assert.Equal(t, append(test.options.ToListOptions(), test.expectedFields), options)
+		})
+	}
+}
+
+func TestPodIndexers(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+		utils.RayClusterLabelKey:   "raycluster",
+		utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+		utils.RayNodeGroupLabelKey: "small-group",
+	}}}
+	indexers := PodIndexers()
+	assert.Equal(t, []string{"raycluster"}, indexers[PodRayClusterIndexField](pod))
+	assert.Equal(t, []string{"raycluster/worker"}, indexers[PodRayNodeTypeIndexField](pod))
+	assert.Equal(t, []string{"raycluster/small-group"}, indexers[PodRayNodeGroupIndexField](pod))
+
+	// The Pods which are not part of a RayCluster are not indexed.
+	assert.Nil(t, indexers[PodRayClusterIndexField](&corev1.Pod{}))
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	// WorkerDrainTimeout is how long KubeRay lets the Ray node of a worker Pod drain before it deletes the Pod.
+	WorkerDrainTimeout = 2 * time.Minute
+	drainNodeIPsEnvVar = "KUBERAY_DRAIN_NODE_IPS"
+	// drainNodeReasonPreemption is the DRAIN_NODE_REASON_PREEMPTION value of the DrainNodeReason enum of Ray, which
+	// stops scheduling new work on the node and lets the running work finish until the deadline.
+	drainNodeReasonPreemption = 2
+)
+
+// drainNodesScript drains the alive Ray nodes whose IPs are listed in the KUBERAY_DRAIN_NODE_IPS environment variable
+// through the GCS, with a deadline of WorkerDrainTimeout. It must not contain single quotes, because it is passed to
+// `python -c '...'`.
+var drainNodesScript = fmt.Sprintf(`
+import json
+import os
+import time
+
+import ray
+
+ray.init()
+gcs_client = ray._private.worker.global_worker.gcs_client
+node_ips = set(json.loads(os.environ["%s"]))
+deadline_timestamp_ms = int((time.time() + %d) * 1000)
+for node in ray.nodes():
+    if node["Alive"] and node["NodeManagerAddress"] in node_ips:
+        gcs_client.drain_node(node["NodeID"], %d, "Drained by KubeRay before deleting the Pod", deadline_timestamp_ms)
+`, drainNodeIPsEnvVar, int(WorkerDrainTimeout.Seconds()), drainNodeReasonPreemption)
+
+// BuildDrainNodesJobRequest builds the Ray job draining the Ray nodes of the worker Pods. Its submission ID is derived
+// from the Pods, so that the nodes of the same Pods are drained only once.
+func BuildDrainNodesJobRequest(cluster *rayv1.RayCluster, pods []corev1.Pod) (*utils.RayJobRequest, error) {
+	nodeIPs := make([]string, 0, len(pods))
+	uids := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		nodeIPs = append(nodeIPs, pod.Status.PodIP)
+		uids = append(uids, string(pod.UID))
+	}
+	sort.Strings(nodeIPs)
+	sort.Strings(uids)
+	data, err := json.Marshal(nodeIPs)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := utils.GenerateJsonHash(uids)
+	if err != nil {
+		return nil, err
+	}
+	return &utils.RayJobRequest{
+		Entrypoint:   fmt.Sprintf("python -c '%s'", drainNodesScript),
+		SubmissionId: fmt.Sprintf("%s-drain-%s", cluster.Name, strings.ToLower(hash[:8])),
+		RuntimeEnv: utils.RuntimeEnvType{
+			"env_vars": map[string]string{drainNodeIPsEnvVar: string(data)},
+		},
+		Metadata: map[string]string{utils.RayClusterLabelKey: cluster.Name},
+	}, nil
+}
@@ -0,0 +1,37 @@
+package common
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildDrainNodesJobRequest(t *testing.T) {
+	cluster := instance.DeepCopy()
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{UID: "uid-b"}, Status: corev1.PodStatus{PodIP: "10.0.0.2"}},
+		{ObjectMeta: metav1.ObjectMeta{UID: "uid-a"}, Status: corev1.PodStatus{PodIP: "10.0.0.1"}},
+	}
+	request, err := BuildDrainNodesJobRequest(cluster, pods)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(request.SubmissionId, cluster.Name+"-drain-"))
+	assert.True(t, strings.HasPrefix(request.Entrypoint, "python -c '"))
+	assert.Equal(t, 2, strings.Count(request.Entrypoint, "'"))
+
+	envVars := request.RuntimeEnv["env_vars"].(map[string]string)
+	var nodeIPs []string
+	assert.NoError(t, json.Unmarshal([]byte(envVars[drainNodeIPsEnvVar]), &nodeIPs))
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, nodeIPs)
+
+	// The submission ID does not depend on the order of the Pods, but changes with them.
+	reversed, err := BuildDrainNodesJobRequest(cluster, []corev1.Pod{pods[1], pods[0]})
+	assert.NoError(t, err)
+	assert.Equal(t, request.SubmissionId, reversed.SubmissionId)
+	other, err := BuildDrainNodesJobRequest(cluster, pods[:1])
+	assert.NoError(t, err)
+	assert.NotEqual(t, request.SubmissionId, other.SubmissionId)
+}
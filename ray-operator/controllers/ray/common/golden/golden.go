@@ -0,0 +1,191 @@
+// Package golden compares the Pods that KubeRay builds for representative RayClusters with golden YAML files.
+//
+// The golden files in testdata are the Pods that the RayCluster controller builds with the default operator
+// configuration, e.g. ENABLE_PROBES_INJECTION and ENABLE_INIT_CONTAINER_INJECTION are unset. They are regenerated with
+//
+//	go test ./controllers/ray/ -run TestGoldenPods -update
+//
+// Forks and integrators that customize how Pods are built can run the suite against their own build function to
+// detect behavioral drift from upstream KubeRay:
+//
+//	func TestPodsMatchUpstream(t *testing.T) {
+//		golden.Run(t, "path/to/kuberay/ray-operator/controllers/ray/common/golden/testdata", myBuildPods, false)
+//	}
+package golden
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// Case is a representative RayCluster whose Pods are compared with the golden file named after the case.
+type Case struct {
+	Name    string
+	Cluster *rayv1.RayCluster
+}
+
+// BuildFunc builds the head Pod and one Pod of each worker group of the RayCluster, in this order.
+type BuildFunc func(ctx context.Context, cluster *rayv1.RayCluster) []corev1.Pod
+
+// Cases returns the representative RayClusters of the suite.
+func Cases() []Case {
+	autoscaler := newCluster("autoscaler-enabled")
+	autoscaler.Spec.EnableInTreeAutoscaling = ptr.To(true)
+
+	gpu := newCluster("gpu")
+	gpu.Spec.WorkerGroupSpecs = append(gpu.Spec.WorkerGroupSpecs,
+		newWorkerGroup("gpu-group", corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}),
+		newWorkerGroup("shared-gpu-group", nil))
+	gpu.Spec.WorkerGroupSpecs[2].GPU = &rayv1.GPUOptions{Shares: ptr.To(resource.MustParse("0.5")), ReplicasPerGPU: ptr.To[int32](2)}
+
+	tls := newCluster("tls")
+	addTLS(&tls.Spec.HeadGroupSpec.Template)
+	addTLS(&tls.Spec.WorkerGroupSpecs[0].Template)
+
+	gcsFT := newCluster("gcs-ft")
+	gcsFT.Annotations = map[string]string{utils.RayFTEnabledAnnotationKey: "true"}
+	gcsFT.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Env = []corev1.EnvVar{
+		{Name: "RAY_REDIS_ADDRESS", Value: "redis:6379"},
+	}
+
+	return []Case{
+		{Name: "autoscaler-disabled", Cluster: newCluster("autoscaler-disabled")},
+		{Name: "autoscaler-enabled", Cluster: autoscaler},
+		{Name: "gpu", Cluster: gpu},
+		{Name: "tls", Cluster: tls},
+		{Name: "gcs-ft", Cluster: gcsFT},
+	}
+}
+
+// Run builds the Pods of each case with build and compares them with the golden file `<case>.golden` in dir. If update
+// is true, the golden file is written instead. A missing golden file fails the case unless update is true.
+func Run(t *testing.T, dir string, build BuildFunc, update bool) {
+	t.Helper()
+	for _, c := range Cases() {
+		t.Run(c.Name, func(t *testing.T) {
+			actual, err := Marshal(build(context.Background(), c.Cluster))
+			if err != nil {
+				t.Fatalf("failed to marshal the Pods: %v", err)
+			}
+			path := filepath.Join(dir, c.Name+".golden")
+			if update {
+				if err := os.MkdirAll(dir, 0o755); err != nil {
+					t.Fatalf("failed to create the golden file directory: %v", err)
+				}
+				if err := os.WriteFile(path, actual, 0o644); err != nil {
+					t.Fatalf("failed to write the golden file: %v", err)
+				}
+				t.Logf("wrote the golden file %s", path)
+				return
+			}
+			expected, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read the golden file, run the test with -update to create it: %v", err)
+			}
+			assert.Equal(t, string(expected), string(actual), "the Pods differ from the golden file %s", path)
+		})
+	}
+}
+
+// Marshal serializes the Pods as a YAML stream.
+func Marshal(pods []corev1.Pod) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, pod := range pods {
+		data, err := yaml.Marshal(pod)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+func newCluster(name string) *rayv1.RayCluster {
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	}
+	return &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: rayv1.RayClusterSpec{
+			RayVersion: "2.9.0",
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				RayStartParams: map[string]string{"dashboard-host": "0.0.0.0"},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:      "ray-head",
+								Image:     "rayproject/ray:2.9.0",
+								Resources: corev1.ResourceRequirements{Requests: resources, Limits: resources},
+							},
+						},
+					},
+				},
+			},
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{newWorkerGroup("cpu-group", nil)},
+		},
+	}
+}
+
+func newWorkerGroup(name string, limits corev1.ResourceList) rayv1.WorkerGroupSpec {
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	}
+	allLimits := resources.DeepCopy()
+	for resourceName, quantity := range limits {
+		allLimits[resourceName] = quantity
+	}
+	return rayv1.WorkerGroupSpec{
+		GroupName:      name,
+		Replicas:       ptr.To[int32](1),
+		MinReplicas:    ptr.To[int32](0),
+		MaxReplicas:    ptr.To[int32](10),
+		RayStartParams: map[string]string{},
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:      "ray-worker",
+						Image:     "rayproject/ray:2.9.0",
+						Resources: corev1.ResourceRequirements{Requests: resources, Limits: allLimits},
+					},
+				},
+			},
+		},
+	}
+}
+
+// addTLS configures the Ray container to use the TLS certificates in the ray-tls secret.
+func addTLS(template *corev1.PodTemplateSpec) {
+	container := &template.Spec.Containers[utils.RayContainerIndex]
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "RAY_USE_TLS", Value: "1"},
+		corev1.EnvVar{Name: "RAY_TLS_SERVER_CERT", Value: "/etc/ray/tls/tls.crt"},
+		corev1.EnvVar{Name: "RAY_TLS_SERVER_KEY", Value: "/etc/ray/tls/tls.key"},
+		corev1.EnvVar{Name: "RAY_TLS_CA_CERT", Value: "/etc/ray/tls/ca.crt"},
+	)
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: "ray-tls", MountPath: "/etc/ray/tls", ReadOnly: true})
+	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+		Name:         "ray-tls",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "ray-tls"}},
+	})
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// HTTPRouteGVK is the GroupVersionKind of the Gateway API HTTPRoutes. KubeRay does not depend on the Gateway API, so
+// the HTTPRoutes are unstructured.
+var HTTPRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+// HTTPRouteBackend is a Serve service of a RayCluster of a RayService, and the percentage of the traffic routed to it.
+type HTTPRouteBackend struct {
+	ServiceName string
+	Port        int32
+	Weight      int32
+}
+
+// HTTPRouteName returns the name of the HTTPRoute of the RayService.
+func HTTPRouteName(rayService *rayv1.RayService) string {
+	return utils.CheckName(rayService.Name + "-httproute")
+}
+
+// BuildHTTPRouteForRayService returns the HTTPRoute attached to the Gateway of the ClusterUpgradeOptions of the
+// RayService, which splits the traffic of the RayService between the given backends according to their weights.
+func BuildHTTPRouteForRayService(rayService *rayv1.RayService, backends []HTTPRouteBackend) *unstructured.Unstructured {
+	backendRefs := make([]interface{}, 0, len(backends))
+	for _, backend := range backends {
+		backendRefs = append(backendRefs, map[string]interface{}{
+			"kind":   "Service",
+			"name":   backend.ServiceName,
+			"port":   int64(backend.Port),
+			"weight": int64(backend.Weight),
+		})
+	}
+
+	gatewayName := ""
+	if upgradeStrategy := rayService.Spec.UpgradeStrategy; upgradeStrategy != nil && upgradeStrategy.ClusterUpgradeOptions != nil {
+		gatewayName = upgradeStrategy.ClusterUpgradeOptions.GatewayName
+	}
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(HTTPRouteGVK)
+	route.SetName(HTTPRouteName(rayService))
+	route.SetNamespace(rayService.Namespace)
+	route.SetLabels(map[string]string{
+		utils.RayOriginatedFromCRNameLabelKey: rayService.Name,
+		utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD),
+		utils.KubernetesCreatedByLabelKey:     utils.ComponentName,
+	})
+	route.Object["spec"] = map[string]interface{}{
+		"parentRefs": []interface{}{
+			map[string]interface{}{
+				"name":      gatewayName,
+				"namespace": rayService.Namespace,
+			},
+		},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": backendRefs,
+			},
+		},
+	}
+	return route
+}
+
+// HTTPRouteOwnedFields returns the fields of the HTTPRoute that KubeRay sets: the name of its Gateway, and the Serve
+// services, ports and weights of its backends. The API server defaults the other fields of the spec, e.g. the group
+// and kind of the references and the path match of the rules, so only these fields are compared to the desired ones.
+func HTTPRouteOwnedFields(route *unstructured.Unstructured) (string, []HTTPRouteBackend) {
+	gatewayName := ""
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	if len(parentRefs) > 0 {
+		if parentRef, ok := parentRefs[0].(map[string]interface{}); ok {
+			gatewayName, _, _ = unstructured.NestedString(parentRef, "name")
+		}
+	}
+
+	backends := []HTTPRouteBackend{}
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	for _, rule := range rules {
+		rule, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		backendRefs, _, _ := unstructured.NestedSlice(rule, "backendRefs")
+		for _, backendRef := range backendRefs {
+			backendRef, ok := backendRef.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(backendRef, "name")
+			port, _, _ := unstructured.NestedInt64(backendRef, "port")
+			weight, _, _ := unstructured.NestedInt64(backendRef, "weight")
+			backends = append(backends, HTTPRouteBackend{ServiceName: name, Port: int32(port), Weight: int32(weight)})
+		}
+	}
+	return gatewayName, backends
+}
@@ -0,0 +1,88 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildHTTPRouteForRayService(t *testing.T) {
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Spec: rayv1.RayServiceSpec{
+			UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{
+				Type:                  rayv1.NewClusterWithIncrementalUpgradeType,
+				ClusterUpgradeOptions: &rayv1.ClusterUpgradeOptions{GatewayName: "gateway"},
+			},
+		},
+	}
+	route := BuildHTTPRouteForRayService(rayService, []HTTPRouteBackend{
+		{ServiceName: "rayservice-raycluster-abcde-serve-svc", Port: 8000, Weight: 70},
+		{ServiceName: "rayservice-raycluster-fghij-serve-svc", Port: 8000, Weight: 30},
+	})
+
+	assert.Equal(t, HTTPRouteGVK, route.GroupVersionKind())
+	assert.Equal(t, "rayservice-httproute", route.GetName())
+	assert.Equal(t, "default", route.GetNamespace())
+	assert.Equal(t, "rayservice", route.GetLabels()[utils.RayOriginatedFromCRNameLabelKey])
+
+	parentRefs, _, err := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "gateway", "namespace": "default"}}, parentRefs)
+
+	rules, _, err := unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Nil(t, err)
+	assert.Len(t, rules, 1)
+	backendRefs := rules[0].(map[string]interface{})["backendRefs"].([]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"kind": "Service", "name": "rayservice-raycluster-abcde-serve-svc", "port": int64(8000), "weight": int64(70)},
+		map[string]interface{}{"kind": "Service", "name": "rayservice-raycluster-fghij-serve-svc", "port": int64(8000), "weight": int64(30)},
+	}, backendRefs)
+}
+
+func TestHTTPRouteOwnedFields(t *testing.T) {
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Spec: rayv1.RayServiceSpec{
+			UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{
+				Type:                  rayv1.NewClusterWithIncrementalUpgradeType,
+				ClusterUpgradeOptions: &rayv1.ClusterUpgradeOptions{GatewayName: "gateway"},
+			},
+		},
+	}
+	backends := []HTTPRouteBackend{
+		{ServiceName: "rayservice-raycluster-abcde-serve-svc", Port: 8000, Weight: 70},
+		{ServiceName: "rayservice-raycluster-fghij-serve-svc", Port: 8000, Weight: 30},
+	}
+	route := BuildHTTPRouteForRayService(rayService, backends)
+
+	// The fields defaulted by the API server are ignored.
+	route.Object["spec"] = map[string]interface{}{
+		"parentRefs": []interface{}{
+			map[string]interface{}{"group": "gateway.networking.k8s.io", "kind": "Gateway", "name": "gateway", "namespace": "default"},
+		},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{"group": "", "kind": "Service", "name": "rayservice-raycluster-abcde-serve-svc", "port": int64(8000), "weight": int64(70)},
+					map[string]interface{}{"group": "", "kind": "Service", "name": "rayservice-raycluster-fghij-serve-svc", "port": int64(8000), "weight": int64(30)},
+				},
+				"matches": []interface{}{
+					map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/"}},
+				},
+			},
+		},
+	}
+	gatewayName, ownedBackends := HTTPRouteOwnedFields(route)
+	assert.Equal(t, "gateway", gatewayName)
+	assert.Equal(t, backends, ownedBackends)
+
+	gatewayName, ownedBackends = HTTPRouteOwnedFields(&unstructured.Unstructured{Object: map[string]interface{}{}})
+	assert.Equal(t, "", gatewayName)
+	assert.Empty(t, ownedBackends)
+}
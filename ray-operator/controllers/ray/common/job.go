@@ -17,6 +17,17 @@ import (
 
 // GetRuntimeEnvJson returns the JSON string of the runtime environment for the Ray job.
 func getRuntimeEnvJson(rayJobInstance *rayv1.RayJob) (string, error) {
+	if rayJobInstance.Spec.RuntimeEnv != nil {
+		runtimeEnv, err := utils.GetRuntimeEnv(rayJobInstance)
+		if err != nil || len(runtimeEnv) == 0 {
+			return "", err
+		}
+		jsonData, err := json.Marshal(runtimeEnv)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonData), nil
+	}
 	runtimeEnvYAML := rayJobInstance.Spec.RuntimeEnvYAML
 
 	if len(runtimeEnvYAML) > 0 {
@@ -32,6 +43,17 @@ func getRuntimeEnvJson(rayJobInstance *rayv1.RayJob) (string, error) {
 	return "", nil
 }
 
+// HasRuntimeEnvFrom returns whether the runtime environment of the Ray job references Secrets or ConfigMaps.
+func HasRuntimeEnvFrom(rayJob *rayv1.RayJob) bool {
+	return rayJob.Spec.RuntimeEnv != nil && len(rayJob.Spec.RuntimeEnv.EnvFrom) != 0
+}
+
+// RayJobRuntimeEnvSecretName returns the name of the Secret that stores the runtime environment of the Ray job in
+// K8sJobMode and SidecarMode if it references Secrets or ConfigMaps.
+func RayJobRuntimeEnvSecretName(rayJob *rayv1.RayJob) string {
+	return rayJob.Name + "-runtime-env"
+}
+
 // GetBaseRayJobCommand returns the first part of the Ray Job command up to and including the address, e.g. "ray job submit --address http://..."
 func GetBaseRayJobCommand(address string) []string {
 	// add http:// if needed
@@ -41,6 +63,82 @@ func GetBaseRayJobCommand(address string) []string {
 	return []string{"ray", "job", "submit", "--address", address}
 }
 
+// GetSidecarSubmitterContainer returns the container that submits the Ray job in SidecarMode. It is added to the head Pod
+// and waits for the dashboard of the head Pod to submit the Ray job, unless it already exists, e.g. when the container
+// restarts. It then prints the driver logs and keeps running, so that it is not restarted once the Ray job finished.
+// The container only exits with an error if the Ray job cannot be submitted.
+func GetSidecarSubmitterContainer(rayJobInstance *rayv1.RayJob) (corev1.Container, error) {
+	headContainer := rayJobInstance.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex]
+	dashboardPort := utils.FindContainerPort(&headContainer, utils.DashboardPortName, utils.DefaultDashboardPort)
+	localRayJob := rayJobInstance.DeepCopy()
+	localRayJob.Status.DashboardURL = fmt.Sprintf("127.0.0.1:%d", dashboardPort)
+	submitCommand, err := GetK8sJobCommand(localRayJob)
+	if err != nil {
+		return corev1.Container{}, err
+	}
+	// The first 5 arguments are "ray job submit --address <address>". The driver logs are followed separately, so that
+	// the exit code of the container does not depend on the one of the Ray job.
+	submitCommand = append(submitCommand[:5:5], append([]string{"--no-wait"}, submitCommand[5:]...)...)
+	address := shellQuote(GetBaseRayJobCommand(localRayJob.Status.DashboardURL)[4])
+	jobId := shellQuote(rayJobInstance.Status.JobId)
+	script := strings.Join([]string{
+		fmt.Sprintf("until ray job list --address %s > /dev/null 2>&1; do echo 'Waiting for the dashboard of the head Pod'; sleep 2; done", address),
+		fmt.Sprintf("if ! ray job status --address %s %s > /dev/null 2>&1; then %s || exit 1; fi", address, jobId, shellJoin(submitCommand)),
+		fmt.Sprintf("ray job logs --address %s --follow %s", address, jobId),
+		"exec sleep infinity",
+	}, "\n")
+
+	submitterTemplate := GetDefaultSubmitterTemplate(&rayv1.RayCluster{Spec: *rayJobInstance.Spec.RayClusterSpec})
+	container := submitterTemplate.Spec.Containers[utils.RayContainerIndex]
+	container.Command = []string{"/bin/bash", "-c", script}
+	container.Env = []corev1.EnvVar{
+		{Name: "PYTHONUNBUFFERED", Value: "1"},
+		{Name: utils.RAY_DASHBOARD_ADDRESS, Value: localRayJob.Status.DashboardURL},
+		{Name: utils.RAY_JOB_SUBMISSION_ID, Value: rayJobInstance.Status.JobId},
+	}
+	if HasRuntimeEnvFrom(rayJobInstance) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      utils.RayJobRuntimeEnvVolumeName,
+			MountPath: utils.RayJobRuntimeEnvMountPath,
+			ReadOnly:  true,
+		})
+	}
+	return container, nil
+}
+
+// shellJoin joins the arguments into a shell command line, quoting each of them.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote quotes the argument for a POSIX shell.
+func shellQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// GetK8sJobLogsCommand returns the command that follows the driver logs of the Ray job, e.g.
+// "ray job logs --address http://... --follow <jobId>".
+func GetK8sJobLogsCommand(rayJobInstance *rayv1.RayJob) []string {
+	address := rayJobInstance.Status.DashboardURL
+	if !strings.HasPrefix(address, "http://") {
+		address = "http://" + address
+	}
+	return []string{"ray", "job", "logs", "--address", address, "--follow", rayJobInstance.Status.JobId}
+}
+
+// IsRayJobLogsFollowed returns whether a Kubernetes Job follows the driver logs of the Ray job submitted by the
+// KubeRay operator or by users.
+func IsRayJobLogsFollowed(rayJob *rayv1.RayJob) bool {
+	if rayJob.Spec.SubmissionMode != rayv1.HTTPMode && rayJob.Spec.SubmissionMode != rayv1.InteractiveMode {
+		return false
+	}
+	return rayJob.Spec.LogStreaming != nil && rayJob.Spec.LogStreaming.Follow
+}
+
 // GetMetadataJson returns the JSON string of the metadata for the Ray job.
 func GetMetadataJson(metadata map[string]string, rayVersion string) (string, error) {
 	// Check that the Ray version is at least 2.6.0.
@@ -74,12 +172,18 @@ func GetK8sJobCommand(rayJobInstance *rayv1.RayJob) ([]string, error) {
 
 	k8sJobCommand := GetBaseRayJobCommand(address)
 
-	runtimeEnvJson, err := getRuntimeEnvJson(rayJobInstance)
-	if err != nil {
-		return nil, err
-	}
-	if len(runtimeEnvJson) > 0 {
-		k8sJobCommand = append(k8sJobCommand, "--runtime-env-json", runtimeEnvJson)
+	// The runtime environment that references Secrets or ConfigMaps is read from the Secret of the RayJob mounted in
+	// the submitter Pod, so that their values are not stored in the submitter Job.
+	if HasRuntimeEnvFrom(rayJobInstance) {
+		k8sJobCommand = append(k8sJobCommand, "--runtime-env", utils.RayJobRuntimeEnvMountPath+"/"+utils.RayJobRuntimeEnvSecretKey)
+	} else {
+		runtimeEnvJson, err := getRuntimeEnvJson(rayJobInstance)
+		if err != nil {
+			return nil, err
+		}
+		if len(runtimeEnvJson) > 0 {
+			k8sJobCommand = append(k8sJobCommand, "--runtime-env-json", runtimeEnvJson)
+		}
 	}
 
 	if len(metadata) > 0 {
@@ -124,7 +228,7 @@ func GetDefaultSubmitterTemplate(rayClusterInstance *rayv1.RayCluster) corev1.Po
 		Spec: corev1.PodSpec{
 			Containers: []corev1.Container{
 				{
-					Name: "ray-job-submitter",
+					Name: utils.SubmitterContainerName,
 					// Use the image of the Ray head to be defensive against version mismatch issues
 					Image: rayClusterInstance.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Image,
 					Resources: corev1.ResourceRequirements{
@@ -62,6 +62,27 @@ pip: ["python-multipart==0.0.6"]
 	assert.Equal(t, expectedMap, actualMap)
 }
 
+func TestGetRuntimeEnvJsonFromRuntimeEnv(t *testing.T) {
+	rayJobWithRuntimeEnv := &rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RuntimeEnv: &rayv1.RuntimeEnv{
+				WorkingDir: "./",
+				Pip:        []string{"python-multipart==0.0.6"},
+				EnvVars:    map[string]string{"counter_name": "test_counter"},
+			},
+		},
+	}
+	expected := `{"env_vars":{"counter_name":"test_counter"},"pip":["python-multipart==0.0.6"],"working_dir":"./"}`
+	jsonOutput, err := getRuntimeEnvJson(rayJobWithRuntimeEnv)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, jsonOutput)
+
+	// The runtime environment without any field is not submitted.
+	jsonOutput, err = getRuntimeEnvJson(&rayv1.RayJob{Spec: rayv1.RayJobSpec{RuntimeEnv: &rayv1.RuntimeEnv{}}})
+	assert.NoError(t, err)
+	assert.Empty(t, jsonOutput)
+}
+
 func TestGetBaseRayJobCommand(t *testing.T) {
 	expected := []string{"ray", "job", "submit", "--address", "http://127.0.0.1:8265"}
 	command := GetBaseRayJobCommand(testRayJob.Status.DashboardURL)
@@ -149,6 +170,86 @@ pip: ["python-multipart==0.0.6"]
 	}
 }
 
+func TestGetK8sJobCommandWithRuntimeEnvFrom(t *testing.T) {
+	rayJob := &rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RuntimeEnv: &rayv1.RuntimeEnv{
+				EnvFrom: []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "test-secret"}}},
+				},
+			},
+			Entrypoint: "echo hello",
+		},
+		Status: rayv1.RayJobStatus{
+			DashboardURL: "http://127.0.0.1:8265",
+			JobId:        "testJobId",
+		},
+	}
+	// The runtime environment is read from the mounted Secret so that the values of the Secret are not in the command.
+	expected := []string{
+		"ray", "job", "submit", "--address", "http://127.0.0.1:8265",
+		"--runtime-env", "/tmp/ray-job-runtime-env/runtime_env.yaml",
+		"--submission-id", "testJobId",
+		"--",
+		"echo", "hello",
+	}
+	command, err := GetK8sJobCommand(rayJob)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, command)
+}
+
+func TestGetK8sJobLogsCommand(t *testing.T) {
+	rayJob := &rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			SubmissionMode: rayv1.HTTPMode,
+			LogStreaming:   &rayv1.LogStreamingOptions{Follow: true},
+		},
+		Status: rayv1.RayJobStatus{
+			DashboardURL: "127.0.0.1:8265",
+			JobId:        "testJobId",
+		},
+	}
+	assert.True(t, IsRayJobLogsFollowed(rayJob))
+	expected := []string{"ray", "job", "logs", "--address", "http://127.0.0.1:8265", "--follow", "testJobId"}
+	assert.Equal(t, expected, GetK8sJobLogsCommand(rayJob))
+
+	// The submitter Job already prints the driver logs in K8sJobMode.
+	rayJob.Spec.SubmissionMode = rayv1.K8sJobMode
+	assert.False(t, IsRayJobLogsFollowed(rayJob))
+}
+
+func TestGetSidecarSubmitterContainer(t *testing.T) {
+	rayJob := &rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			SubmissionMode: rayv1.SidecarMode,
+			Entrypoint:     "python -c 'print(1)'",
+			RayClusterSpec: &rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.9.0"}},
+						},
+					},
+				},
+			},
+		},
+		Status: rayv1.RayJobStatus{
+			DashboardURL: "test-raycluster-head-svc.default.svc.cluster.local:8265",
+			JobId:        "testJobId",
+		},
+	}
+	container, err := GetSidecarSubmitterContainer(rayJob)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.SubmitterContainerName, container.Name)
+	assert.Equal(t, "rayproject/ray:2.9.0", container.Image)
+	assert.Equal(t, []string{"/bin/bash", "-c"}, container.Command[:2])
+	// The Ray job is submitted to the dashboard of the head Pod, with the entrypoint quoted for the shell.
+	script := container.Command[2]
+	assert.Contains(t, script, `'ray' 'job' 'submit' '--address' 'http://127.0.0.1:8265' '--no-wait' '--submission-id' 'testJobId' '--' 'python' '-c' 'print(1)'`)
+	assert.Contains(t, script, "ray job logs --address 'http://127.0.0.1:8265' --follow 'testJobId'")
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
+
 func TestMetadataRaisesErrorBeforeRay26(t *testing.T) {
 	rayJob := &rayv1.RayJob{
 		Spec: rayv1.RayJobSpec{
@@ -1,9 +1,29 @@
 package common
 
 import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// The kinds of the custom resources reconciled by the operator.
+const (
+	KindRayCluster = "RayCluster"
+	KindRayJob     = "RayJob"
+	KindRayService = "RayService"
+	KindRayCronJob = "RayCronJob"
 )
 
 // Define all the prometheus counters for all clusters
@@ -36,6 +56,85 @@ var (
 		},
 		[]string{"namespace"},
 	)
+	podStartupDurationSeconds = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ray_operator_pod_startup_duration_seconds",
+			Help: "Time from Pod creation until the Ray container is running, per RayCluster group and quantile",
+		},
+		[]string{"namespace", "cluster", "group", "quantile"},
+	)
+	clusterDesiredWorkers = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kuberay_cluster_desired_workers",
+			Help: "Number of worker Pods desired by the RayCluster",
+		},
+		[]string{"namespace", "cluster"},
+	)
+	clusterReadyWorkers = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kuberay_cluster_ready_workers",
+			Help: "Number of ready worker Pods of the RayCluster",
+		},
+		[]string{"namespace", "cluster"},
+	)
+	clusterProvisionDurationSeconds = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kuberay_cluster_provision_duration_seconds",
+			Help: "Time from the creation of the RayCluster until all its Pods were ready for the first time",
+		},
+		[]string{"namespace", "cluster"},
+	)
+	jobDurationSeconds = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kuberay_job_duration_seconds",
+			Help: "Time from the start of the RayJob until it completed, or until now if it has not completed",
+		},
+		[]string{"namespace", "job", "job_deployment_status"},
+	)
+	serviceServeHealthy = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kuberay_service_serve_healthy",
+			Help: "Whether the RayService is running and all the Serve applications of its active RayCluster are running",
+		},
+		[]string{"namespace", "service"},
+	)
+	reconcileDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ray_operator_reconcile_duration_seconds",
+			Help:    "Duration of the reconciliations of the custom resources, per kind and result",
+			Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"kind", "namespace", "name", "result"},
+	)
+	reconcileErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ray_operator_reconcile_errors_total",
+			Help: "Counts the failed reconciliations of the custom resources, per kind and reason",
+		},
+		[]string{"kind", "namespace", "name", "reason"},
+	)
+	podsCreatedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ray_operator_pods_created_total",
+			Help: "Counts the Pods created for the RayClusters",
+		},
+		[]string{"namespace", "cluster"},
+	)
+	podsDeletedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ray_operator_pods_deleted_total",
+			Help: "Counts the Pods deleted for the RayClusters",
+		},
+		[]string{"namespace", "cluster"},
+	)
+	dashboardRequestDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ray_operator_dashboard_request_duration_seconds",
+			Help:    "Duration of the requests to the Ray dashboard of the RayClusters, per API, method and status code",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"namespace", "cluster", "api", "method", "code"},
+	)
 )
 
 func init() {
@@ -43,7 +142,18 @@ func init() {
 	metrics.Registry.MustRegister(clustersCreatedCount,
 		clustersDeletedCount,
 		clustersSuccessfulCount,
-		clustersFailedCount)
+		clustersFailedCount,
+		podStartupDurationSeconds,
+		clusterDesiredWorkers,
+		clusterReadyWorkers,
+		clusterProvisionDurationSeconds,
+		jobDurationSeconds,
+		serviceServeHealthy,
+		reconcileDurationSeconds,
+		reconcileErrorsTotal,
+		podsCreatedTotal,
+		podsDeletedTotal,
+		dashboardRequestDurationSeconds)
 }
 
 func CreatedClustersCounterInc(namespace string) {
@@ -62,3 +172,214 @@ func SuccessfulClustersCounterInc(namespace string) {
 func FailedClustersCounterInc(namespace string) {
 	clustersFailedCount.WithLabelValues(namespace).Inc()
 }
+
+// SetPodStartupDurationGauges exports the Pod startup durations of each group of the RayCluster.
+// Series of groups that were removed or have no running Pods are deleted.
+func SetPodStartupDurationGauges(namespace, cluster string, groupStatuses []rayv1.GroupStatus) {
+	DeletePodStartupDurationGauges(namespace, cluster)
+	for _, groupStatus := range groupStatuses {
+		if groupStatus.PodStartupDuration == nil {
+			continue
+		}
+		for quantile, duration := range map[string]metav1.Duration{
+			"0.5": groupStatus.PodStartupDuration.P50,
+			"0.9": groupStatus.PodStartupDuration.P90,
+			"1":   groupStatus.PodStartupDuration.Max,
+		} {
+			podStartupDurationSeconds.WithLabelValues(namespace, cluster, groupStatus.GroupName, quantile).Set(duration.Seconds())
+		}
+	}
+}
+
+// DeletePodStartupDurationGauges deletes the Pod startup duration series of a deleted RayCluster.
+func DeletePodStartupDurationGauges(namespace, cluster string) {
+	podStartupDurationSeconds.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "cluster": cluster})
+}
+
+// SetRayClusterGauges exports the desired and ready workers of the RayCluster, and how long it took to be provisioned
+// once it is.
+func SetRayClusterGauges(cluster *rayv1.RayCluster) {
+	clusterDesiredWorkers.WithLabelValues(cluster.Namespace, cluster.Name).Set(float64(cluster.Status.DesiredWorkerReplicas))
+	clusterReadyWorkers.WithLabelValues(cluster.Namespace, cluster.Name).Set(float64(cluster.Status.ReadyWorkerReplicas))
+	if provisioned := meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterProvisioned)); provisioned != nil && provisioned.Status == metav1.ConditionTrue {
+		clusterProvisionDurationSeconds.WithLabelValues(cluster.Namespace, cluster.Name).
+			Set(provisioned.LastTransitionTime.Sub(cluster.CreationTimestamp.Time).Seconds())
+	}
+}
+
+// DeleteRayClusterMetrics deletes the series of a deleted RayCluster.
+func DeleteRayClusterMetrics(namespace, cluster string) {
+	DeletePodStartupDurationGauges(namespace, cluster)
+	labels := prometheus.Labels{"namespace": namespace, "cluster": cluster}
+	for _, gauge := range []*prometheus.GaugeVec{clusterDesiredWorkers, clusterReadyWorkers, clusterProvisionDurationSeconds} {
+		gauge.DeletePartialMatch(labels)
+	}
+	podsCreatedTotal.DeletePartialMatch(labels)
+	podsDeletedTotal.DeletePartialMatch(labels)
+	dashboardRequestDurationSeconds.DeletePartialMatch(labels)
+	deleteReconcileMetrics(KindRayCluster, namespace, cluster)
+}
+
+// SetRayJobGauges exports how long the RayJob ran, once it started. The series of the previous deployment status of
+// the RayJob is deleted.
+func SetRayJobGauges(job *rayv1.RayJob, now time.Time) {
+	DeleteRayJobGauges(job.Namespace, job.Name)
+	if job.Status.StartTime == nil {
+		return
+	}
+	end := now
+	if job.Status.EndTime != nil {
+		end = job.Status.EndTime.Time
+	}
+	jobDurationSeconds.WithLabelValues(job.Namespace, job.Name, string(job.Status.JobDeploymentStatus)).
+		Set(end.Sub(job.Status.StartTime.Time).Seconds())
+}
+
+// DeleteRayJobGauges deletes the duration series of the RayJob.
+func DeleteRayJobGauges(namespace, job string) {
+	jobDurationSeconds.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "job": job})
+}
+
+// DeleteRayJobMetrics deletes the series of a deleted RayJob.
+func DeleteRayJobMetrics(namespace, job string) {
+	DeleteRayJobGauges(namespace, job)
+	deleteReconcileMetrics(KindRayJob, namespace, job)
+}
+
+// SetRayServiceGauges exports whether the RayService is running and all the Serve applications of its active
+// RayCluster are running.
+func SetRayServiceGauges(service *rayv1.RayService) {
+	healthy := service.Status.ServiceStatus == rayv1.Running && len(service.Status.ActiveServiceStatus.Applications) > 0
+	for _, app := range service.Status.ActiveServiceStatus.Applications {
+		if app.Status != rayv1.ApplicationStatusEnum.RUNNING {
+			healthy = false
+		}
+	}
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+	serviceServeHealthy.WithLabelValues(service.Namespace, service.Name).Set(value)
+}
+
+// DeleteRayServiceMetrics deletes the series of a deleted RayService.
+func DeleteRayServiceMetrics(namespace, service string) {
+	serviceServeHealthy.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "service": service})
+	deleteReconcileMetrics(KindRayService, namespace, service)
+}
+
+// ReconcileObserver records the duration and the error of a reconciliation of a custom resource.
+type ReconcileObserver struct {
+	kind    string
+	name    types.NamespacedName
+	start   time.Time
+	deleted bool
+}
+
+// ObserveReconcile starts observing a reconciliation of the custom resource. The returned observer's Done is meant to
+// be deferred at the start of the reconciliation with the named error result.
+func ObserveReconcile(kind string, name types.NamespacedName) *ReconcileObserver {
+	return &ReconcileObserver{kind: kind, name: name, start: time.Now()}
+}
+
+// Deleted marks the custom resource as not found, so that Done deletes all its series instead of recreating them.
+func (o *ReconcileObserver) Deleted() {
+	o.deleted = true
+}
+
+// Done records the duration of the reconciliation, and its error if *err is not nil, or deletes the series of the
+// custom resource if it was deleted.
+func (o *ReconcileObserver) Done(err *error) {
+	if o.deleted {
+		switch o.kind {
+		case KindRayCluster:
+			DeleteRayClusterMetrics(o.name.Namespace, o.name.Name)
+		case KindRayJob:
+			DeleteRayJobMetrics(o.name.Namespace, o.name.Name)
+		case KindRayService:
+			DeleteRayServiceMetrics(o.name.Namespace, o.name.Name)
+		case KindRayCronJob:
+			deleteReconcileMetrics(KindRayCronJob, o.name.Namespace, o.name.Name)
+		}
+		return
+	}
+	result := "success"
+	if err != nil && *err != nil {
+		result = "error"
+		reconcileErrorsTotal.WithLabelValues(o.kind, o.name.Namespace, o.name.Name, ErrorReason(*err)).Inc()
+	}
+	reconcileDurationSeconds.WithLabelValues(o.kind, o.name.Namespace, o.name.Name, result).Observe(time.Since(o.start).Seconds())
+}
+
+func deleteReconcileMetrics(kind, namespace, name string) {
+	labels := prometheus.Labels{"kind": kind, "namespace": namespace, "name": name}
+	reconcileDurationSeconds.DeletePartialMatch(labels)
+	reconcileErrorsTotal.DeletePartialMatch(labels)
+}
+
+// ErrorReason returns the reason of a reconciliation error: the reason of the error if it has one, e.g. the
+// utils.ErrFailedCreateWorkerPod marker, else the reason of the Kubernetes API error, else Unknown.
+func ErrorReason(err error) string {
+	var reasoned interface{ Reason() string }
+	if errors.As(err, &reasoned) && reasoned.Reason() != "" {
+		return reasoned.Reason()
+	}
+	if reason := apierrors.ReasonForError(err); reason != "" {
+		return string(reason)
+	}
+	return "Unknown"
+}
+
+// PodCreatedCounterInc counts a Pod created for the RayCluster.
+func PodCreatedCounterInc(namespace, cluster string) {
+	podsCreatedTotal.WithLabelValues(namespace, cluster).Inc()
+}
+
+// PodDeletedCounterInc counts a Pod deleted for the RayCluster.
+func PodDeletedCounterInc(namespace, cluster string) {
+	podsDeletedTotal.WithLabelValues(namespace, cluster).Inc()
+}
+
+// InstrumentDashboardTransport returns a RoundTripper recording the duration of the requests to the Ray dashboard of
+// the RayCluster made through next, or through http.DefaultTransport at the time of the request if next is nil, like
+// an http.Client without a Transport.
+func InstrumentDashboardTransport(rayCluster *rayv1.RayCluster, next http.RoundTripper) http.RoundTripper {
+	transport := &dashboardTransport{next: next}
+	if rayCluster != nil {
+		transport.namespace, transport.cluster = rayCluster.Namespace, rayCluster.Name
+	}
+	return transport
+}
+
+type dashboardTransport struct {
+	next      http.RoundTripper
+	namespace string
+	cluster   string
+}
+
+func (t *dashboardTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	dashboardRequestDurationSeconds.WithLabelValues(t.namespace, t.cluster, dashboardAPI(req.URL.Path), req.Method, code).
+		Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// dashboardAPI returns the API of the Ray dashboard a request path belongs to, so that the IDs in the paths, e.g. of
+// the Ray jobs, do not end up in the labels. The paths may be prefixed, e.g. by the Kubernetes API server proxy.
+func dashboardAPI(path string) string {
+	for _, api := range []string{"serve", "jobs", "cluster_status"} {
+		if strings.Contains(path, "/api/"+api) {
+			return api
+		}
+	}
+	return "other"
+}
@@ -0,0 +1,195 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestSetPodStartupDurationGauges(t *testing.T) {
+	startupDuration := &rayv1.PodStartupDuration{
+		P50: metav1.Duration{Duration: 10 * time.Second},
+		P90: metav1.Duration{Duration: 20 * time.Second},
+		Max: metav1.Duration{Duration: 30 * time.Second},
+	}
+	SetPodStartupDurationGauges("default", "raycluster", []rayv1.GroupStatus{
+		{GroupName: "group1", PodStartupDuration: startupDuration},
+		{GroupName: "group2", PodStartupDuration: startupDuration},
+	})
+	assert.Equal(t, 6, testutil.CollectAndCount(podStartupDurationSeconds))
+	assert.Equal(t, float64(20), testutil.ToFloat64(podStartupDurationSeconds.WithLabelValues("default", "raycluster", "group1", "0.9")))
+
+	// The series of removed groups are deleted.
+	SetPodStartupDurationGauges("default", "raycluster", []rayv1.GroupStatus{{GroupName: "group1", PodStartupDuration: startupDuration}})
+	assert.Equal(t, 3, testutil.CollectAndCount(podStartupDurationSeconds))
+
+	// All the series are deleted with the RayCluster.
+	DeleteRayClusterMetrics("default", "raycluster")
+	assert.Equal(t, 0, testutil.CollectAndCount(podStartupDurationSeconds))
+}
+
+func TestSetRayClusterGauges(t *testing.T) {
+	created := time.Now().Add(-time.Minute)
+	cluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default", CreationTimestamp: metav1.NewTime(created)},
+		Status: rayv1.RayClusterStatus{
+			DesiredWorkerReplicas: 3,
+			ReadyWorkerReplicas:   2,
+		},
+	}
+
+	SetRayClusterGauges(cluster)
+	assert.Equal(t, float64(3), testutil.ToFloat64(clusterDesiredWorkers.WithLabelValues("default", "raycluster")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(clusterReadyWorkers.WithLabelValues("default", "raycluster")))
+	assert.Equal(t, 0, testutil.CollectAndCount(clusterProvisionDurationSeconds))
+
+	cluster.Status.Conditions = []metav1.Condition{{
+		Type:               string(rayv1.RayClusterProvisioned),
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(created.Add(30 * time.Second)),
+	}}
+	SetRayClusterGauges(cluster)
+	assert.Equal(t, float64(30), testutil.ToFloat64(clusterProvisionDurationSeconds.WithLabelValues("default", "raycluster")))
+
+	DeleteRayClusterMetrics("default", "raycluster")
+	assert.Equal(t, 0, testutil.CollectAndCount(clusterDesiredWorkers))
+	assert.Equal(t, 0, testutil.CollectAndCount(clusterProvisionDurationSeconds))
+}
+
+func TestSetRayJobGauges(t *testing.T) {
+	now := time.Now()
+	job := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayjob", Namespace: "default"},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+			StartTime:           &metav1.Time{Time: now.Add(-time.Minute)},
+		},
+	}
+
+	SetRayJobGauges(job, now)
+	assert.Equal(t, float64(60), testutil.ToFloat64(jobDurationSeconds.WithLabelValues("default", "rayjob", "Running")))
+
+	// The series of the previous deployment status is replaced.
+	job.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusComplete
+	job.Status.EndTime = &metav1.Time{Time: now.Add(-30 * time.Second)}
+	SetRayJobGauges(job, now)
+	assert.Equal(t, 1, testutil.CollectAndCount(jobDurationSeconds))
+	assert.Equal(t, float64(30), testutil.ToFloat64(jobDurationSeconds.WithLabelValues("default", "rayjob", "Complete")))
+
+	DeleteRayJobGauges("default", "rayjob")
+	assert.Equal(t, 0, testutil.CollectAndCount(jobDurationSeconds))
+}
+
+func TestSetRayServiceGauges(t *testing.T) {
+	service := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Status: rayv1.RayServiceStatuses{
+			ServiceStatus: rayv1.Running,
+			ActiveServiceStatus: rayv1.RayServiceStatus{
+				Applications: map[string]rayv1.AppStatus{
+					"app1": {Status: rayv1.ApplicationStatusEnum.RUNNING},
+					"app2": {Status: rayv1.ApplicationStatusEnum.RUNNING},
+				},
+			},
+		},
+	}
+
+	SetRayServiceGauges(service)
+	assert.Equal(t, float64(1), testutil.ToFloat64(serviceServeHealthy.WithLabelValues("default", "rayservice")))
+
+	service.Status.ActiveServiceStatus.Applications["app2"] = rayv1.AppStatus{Status: rayv1.ApplicationStatusEnum.DEPLOY_FAILED}
+	SetRayServiceGauges(service)
+	assert.Equal(t, float64(0), testutil.ToFloat64(serviceServeHealthy.WithLabelValues("default", "rayservice")))
+
+	DeleteRayServiceMetrics("default", "rayservice")
+	assert.Equal(t, 0, testutil.CollectAndCount(serviceServeHealthy))
+}
+
+type reasonedError struct{}
+
+func (reasonedError) Error() string  { return "failed" }
+func (reasonedError) Reason() string { return "FailedCreateWorkerPod" }
+
+func TestErrorReason(t *testing.T) {
+	assert.Equal(t, "FailedCreateWorkerPod", ErrorReason(fmt.Errorf("wrapped: %w", reasonedError{})))
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "pods"}, "pod", errors.New("conflict"))
+	assert.Equal(t, "Conflict", ErrorReason(conflict))
+	assert.Equal(t, "Unknown", ErrorReason(errors.New("failed")))
+}
+
+func TestObserveReconcile(t *testing.T) {
+	name := types.NamespacedName{Namespace: "test-observe-reconcile", Name: "raycluster"}
+
+	var err error
+	ObserveReconcile(KindRayCluster, name).Done(&err)
+	err = reasonedError{}
+	ObserveReconcile(KindRayCluster, name).Done(&err)
+
+	// Delete reports whether the series was observed.
+	assert.True(t, reconcileDurationSeconds.DeleteLabelValues(KindRayCluster, name.Namespace, name.Name, "success"))
+	assert.True(t, reconcileDurationSeconds.DeleteLabelValues(KindRayCluster, name.Namespace, name.Name, "error"))
+	assert.Equal(t, float64(1), testutil.ToFloat64(reconcileErrorsTotal.WithLabelValues(KindRayCluster, name.Namespace, name.Name, "FailedCreateWorkerPod")))
+}
+
+func TestObserveReconcile_Deleted(t *testing.T) {
+	name := types.NamespacedName{Namespace: "test-observe-reconcile-deleted", Name: "raycluster"}
+	err := error(reasonedError{})
+	ObserveReconcile(KindRayCluster, name).Done(&err)
+	PodCreatedCounterInc(name.Namespace, name.Name)
+
+	// The series of a deleted custom resource are deleted rather than recreated by the reconciliation that found it
+	// deleted.
+	observer := ObserveReconcile(KindRayCluster, name)
+	observer.Deleted()
+	err = nil
+	observer.Done(&err)
+	assert.False(t, reconcileDurationSeconds.DeleteLabelValues(KindRayCluster, name.Namespace, name.Name, "error"))
+	assert.False(t, reconcileDurationSeconds.DeleteLabelValues(KindRayCluster, name.Namespace, name.Name, "success"))
+	assert.False(t, reconcileErrorsTotal.DeleteLabelValues(KindRayCluster, name.Namespace, name.Name, "FailedCreateWorkerPod"))
+	assert.False(t, podsCreatedTotal.DeleteLabelValues(name.Namespace, name.Name))
+}
+
+func TestPodCounters(t *testing.T) {
+	namespace := "test-pod-counters"
+	PodCreatedCounterInc(namespace, "raycluster")
+	PodCreatedCounterInc(namespace, "raycluster")
+	PodDeletedCounterInc(namespace, "raycluster")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(podsCreatedTotal.WithLabelValues(namespace, "raycluster")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(podsDeletedTotal.WithLabelValues(namespace, "raycluster")))
+}
+
+func TestInstrumentDashboardTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	rayCluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "test-dashboard-transport"}}
+	client := &http.Client{Transport: InstrumentDashboardTransport(rayCluster, nil)}
+	resp, err := client.Get(server.URL + "/api/jobs/raysubmit_123")
+	assert.Nil(t, err)
+	resp.Body.Close()
+
+	// The ID of the Ray job does not end up in the labels.
+	assert.True(t, dashboardRequestDurationSeconds.DeleteLabelValues(rayCluster.Namespace, rayCluster.Name, "jobs", http.MethodGet, "404"))
+}
+
+func TestDashboardAPI(t *testing.T) {
+	assert.Equal(t, "serve", dashboardAPI("/api/serve/applications/"))
+	assert.Equal(t, "jobs", dashboardAPI("/api/v1/namespaces/default/services/raycluster-head-svc:dashboard/proxy/api/jobs/"))
+	assert.Equal(t, "cluster_status", dashboardAPI("/api/cluster_status"))
+	assert.Equal(t, "other", dashboardAPI("/api/version"))
+}
@@ -0,0 +1,95 @@
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// PodMonitorGVK is the GroupVersionKind of the PodMonitors of the Prometheus Operator. KubeRay does not depend on the
+// Prometheus Operator API, so the PodMonitors are unstructured.
+var PodMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+
+// PodMonitorName returns the name of the PodMonitor scraping the Pods of the given type of the RayCluster.
+func PodMonitorName(cluster *rayv1.RayCluster, nodeType rayv1.RayNodeType) string {
+	return utils.CheckName(cluster.Name + "-" + string(nodeType) + "-monitor")
+}
+
+// BuildPodMonitors returns the PodMonitors scraping the Ray metrics of the head and worker Pods of the RayCluster, and
+// the autoscaler and dashboard metrics of the head Pod. The given labels are added to the ones identifying the
+// RayCluster, e.g. so that the PodMonitors match the podMonitorSelector of the Prometheus.
+func BuildPodMonitors(cluster *rayv1.RayCluster, labels map[string]string) []*unstructured.Unstructured {
+	return []*unstructured.Unstructured{
+		buildPodMonitor(cluster, rayv1.HeadNode, labels, utils.MetricsPortName, utils.AutoscalerMetricsPortName, utils.DashboardMetricsPortName),
+		buildPodMonitor(cluster, rayv1.WorkerNode, labels, utils.MetricsPortName),
+	}
+}
+
+func buildPodMonitor(cluster *rayv1.RayCluster, nodeType rayv1.RayNodeType, labels map[string]string, ports ...string) *unstructured.Unstructured {
+	endpoints := make([]interface{}, 0, len(ports))
+	for _, port := range ports {
+		endpoints = append(endpoints, map[string]interface{}{
+			"port": port,
+			// Identify the RayCluster of the metrics like the Ray documentation does.
+			"relabelings": []interface{}{
+				map[string]interface{}{
+					"sourceLabels": []interface{}{"__meta_kubernetes_pod_label_ray_io_cluster"},
+					"targetLabel":  "ray_io_cluster",
+				},
+			},
+		})
+	}
+
+	monitor := &unstructured.Unstructured{}
+	monitor.SetGroupVersionKind(PodMonitorGVK)
+	monitor.SetName(PodMonitorName(cluster, nodeType))
+	monitor.SetNamespace(cluster.Namespace)
+	monitorLabels := map[string]string{}
+	for k, v := range labels {
+		monitorLabels[k] = v
+	}
+	monitorLabels[utils.RayClusterLabelKey] = cluster.Name
+	monitorLabels[utils.KubernetesApplicationNameLabelKey] = utils.ApplicationName
+	monitorLabels[utils.KubernetesCreatedByLabelKey] = utils.ComponentName
+	monitor.SetLabels(monitorLabels)
+	monitor.Object["spec"] = map[string]interface{}{
+		"jobLabel": utils.RayClusterLabelKey,
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				utils.RayClusterLabelKey:  cluster.Name,
+				utils.RayNodeTypeLabelKey: string(nodeType),
+			},
+		},
+		"podMetricsEndpoints": endpoints,
+	}
+	return monitor
+}
+
+// AddMonitoringPorts declares the autoscaler and dashboard metrics ports on the Ray container of the head Pod unless
+// they are already declared, as the PodMonitors can only scrape named container ports.
+func AddMonitoringPorts(pod *corev1.Pod) {
+	container := &pod.Spec.Containers[utils.RayContainerIndex]
+	for _, port := range []corev1.ContainerPort{
+		{Name: utils.AutoscalerMetricsPortName, ContainerPort: utils.DefaultAutoscalerMetricsPort, Protocol: corev1.ProtocolTCP},
+		{Name: utils.DashboardMetricsPortName, ContainerPort: utils.DefaultDashboardMetricsPort, Protocol: corev1.ProtocolTCP},
+	} {
+		if !hasContainerPort(pod, port.Name, port.ContainerPort) {
+			container.Ports = append(container.Ports, port)
+		}
+	}
+}
+
+// hasContainerPort returns whether a container of the Pod already declares the port, by name or number.
+func hasContainerPort(pod *corev1.Pod, name string, port int32) bool {
+	for _, container := range pod.Spec.Containers {
+		for _, p := range container.Ports {
+			if p.Name == name || p.ContainerPort == port {
+				return true
+			}
+		}
+	}
+	return false
+}
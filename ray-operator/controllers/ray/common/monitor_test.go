@@ -0,0 +1,65 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildPodMonitors(t *testing.T) {
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	monitors := BuildPodMonitors(cluster, map[string]string{"release": "prometheus", utils.RayClusterLabelKey: "overridden"})
+	assert.Len(t, monitors, 2)
+
+	for i, test := range []struct {
+		name     string
+		nodeType string
+		ports    []string
+	}{
+		{"raycluster-head-monitor", "head", []string{"metrics", "as-metrics", "dash-metrics"}},
+		{"raycluster-worker-monitor", "worker", []string{"metrics"}},
+	} {
+		monitor := monitors[i]
+		assert.Equal(t, PodMonitorGVK, monitor.GroupVersionKind())
+		assert.Equal(t, test.name, monitor.GetName())
+		assert.Equal(t, "default", monitor.GetNamespace())
+		assert.Equal(t, "prometheus", monitor.GetLabels()["release"])
+		assert.Equal(t, "raycluster", monitor.GetLabels()[utils.RayClusterLabelKey])
+
+		selector, _, err := unstructured.NestedStringMap(monitor.Object, "spec", "selector", "matchLabels")
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{utils.RayClusterLabelKey: "raycluster", utils.RayNodeTypeLabelKey: test.nodeType}, selector)
+
+		endpoints, _, err := unstructured.NestedSlice(monitor.Object, "spec", "podMetricsEndpoints")
+		assert.Nil(t, err)
+		ports := []string{}
+		for _, endpoint := range endpoints {
+			ports = append(ports, endpoint.(map[string]interface{})["port"].(string))
+		}
+		assert.Equal(t, test.ports, ports)
+	}
+}
+
+func TestAddMonitoringPorts(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Name:  "ray-head",
+		Ports: []corev1.ContainerPort{{Name: utils.MetricsPortName, ContainerPort: utils.DefaultMetricsPort}},
+	}}}}
+
+	AddMonitoringPorts(pod)
+	assert.Equal(t, []corev1.ContainerPort{
+		{Name: utils.MetricsPortName, ContainerPort: utils.DefaultMetricsPort},
+		{Name: utils.AutoscalerMetricsPortName, ContainerPort: utils.DefaultAutoscalerMetricsPort, Protocol: corev1.ProtocolTCP},
+		{Name: utils.DashboardMetricsPortName, ContainerPort: utils.DefaultDashboardMetricsPort, Protocol: corev1.ProtocolTCP},
+	}, pod.Spec.Containers[0].Ports)
+
+	// The ports already declared are not declared again.
+	AddMonitoringPorts(pod)
+	assert.Len(t, pod.Spec.Containers[0].Ports, 3)
+}
@@ -0,0 +1,42 @@
+package common
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// HeadPodDisruptionBudgetName returns the name of the PodDisruptionBudget protecting the head Pod of the RayCluster.
+func HeadPodDisruptionBudgetName(cluster *rayv1.RayCluster) string {
+	return utils.CheckName(cluster.Name + "-head-pdb")
+}
+
+// BuildHeadPodDisruptionBudget returns a PodDisruptionBudget that prevents voluntary disruptions, such as node drains,
+// from evicting the head Pod. Losing the head Pod restarts all the Ray jobs of the cluster unless GCS fault tolerance
+// is enabled.
+func BuildHeadPodDisruptionBudget(cluster *rayv1.RayCluster) *policyv1.PodDisruptionBudget {
+	minAvailable := intstr.FromInt32(1)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      HeadPodDisruptionBudgetName(cluster),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:                cluster.Name,
+				utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+				utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+			},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					utils.RayClusterLabelKey:  cluster.Name,
+					utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+				},
+			},
+		},
+	}
+}
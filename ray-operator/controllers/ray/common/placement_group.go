@@ -0,0 +1,154 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	// PlacementGroupNamespace is the Ray namespace of the placement groups created by KubeRay. Applications look
+	// them up with `ray.util.get_placement_group(name)` after connecting to this namespace.
+	PlacementGroupNamespace = "kuberay"
+	// placementGroupReadyTimeoutSeconds is how long the bootstrap job waits for each placement group to be ready.
+	placementGroupReadyTimeoutSeconds = 600
+	placementGroupsEnvVar             = "KUBERAY_PLACEMENT_GROUPS"
+)
+
+// placementGroupBootstrapScript creates the placement groups declared in the KUBERAY_PLACEMENT_GROUPS environment
+// variable unless they already exist, and waits until they are ready. It must not contain single quotes, because it
+// is passed to `python -c '...'`.
+var placementGroupBootstrapScript = fmt.Sprintf(`
+import json
+import os
+
+import ray
+from ray.util.placement_group import get_placement_group, placement_group
+
+ray.init(namespace="%s")
+for spec in json.loads(os.environ["%s"]):
+    try:
+        pg = get_placement_group(spec["name"])
+    except ValueError:
+        pg = placement_group(spec["bundles"], strategy=spec["strategy"], name=spec["name"], lifetime="detached")
+    ray.get(pg.ready(), timeout=%d)
+`, PlacementGroupNamespace, placementGroupsEnvVar, placementGroupReadyTimeoutSeconds)
+
+// PlacementGroupSpec is the definition of a Ray placement group passed to the bootstrap job.
+type PlacementGroupSpec struct {
+	Name     string               `json:"name"`
+	Strategy string               `json:"strategy"`
+	Bundles  []map[string]float64 `json:"bundles"`
+}
+
+// BuildPlacementGroupSpecs returns the placement groups declared by the worker groups of the RayCluster.
+func BuildPlacementGroupSpecs(cluster *rayv1.RayCluster) []PlacementGroupSpec {
+	var specs []PlacementGroupSpec
+	for _, worker := range cluster.Spec.WorkerGroupSpecs {
+		if worker.PlacementGroup == nil {
+			continue
+		}
+		spec := PlacementGroupSpec{
+			Name:     worker.PlacementGroup.Name,
+			Strategy: string(worker.PlacementGroup.Strategy),
+		}
+		if spec.Name == "" {
+			spec.Name = worker.GroupName
+		}
+		if spec.Strategy == "" {
+			spec.Strategy = string(rayv1.PlacementGroupStrategyStrictSpread)
+		}
+		bundle := placementGroupBundle(worker)
+		for i := int32(0); i < worker.PlacementGroup.Bundles; i++ {
+			spec.Bundles = append(spec.Bundles, bundle)
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// placementGroupBundle returns the Ray resources of one worker Pod of the group, computed like the `--num-cpus` and
+// `--num-gpus` options of `ray start`.
+func placementGroupBundle(worker rayv1.WorkerGroupSpec) map[string]float64 {
+	rayStartParams := AddGPUNumGPUs(worker.RayStartParams, worker.GPU)
+	var limits corev1.ResourceList
+	if len(worker.Template.Spec.Containers) > utils.RayContainerIndex {
+		limits = worker.Template.Spec.Containers[utils.RayContainerIndex].Resources.Limits
+	}
+
+	bundle := make(map[string]float64)
+	if cpus, err := strconv.ParseFloat(rayStartParams["num-cpus"], 64); err == nil {
+		bundle["CPU"] = cpus
+	} else if cpu := limits[corev1.ResourceCPU]; !cpu.IsZero() {
+		bundle["CPU"] = float64(cpu.Value())
+	}
+	if gpus, err := strconv.ParseFloat(rayStartParams["num-gpus"], 64); err == nil {
+		bundle["GPU"] = gpus
+	} else {
+		for resourceName, quantity := range limits {
+			if strings.HasSuffix(string(resourceName), "gpu") && !quantity.IsZero() {
+				bundle["GPU"] = float64(quantity.Value())
+				break
+			}
+		}
+	}
+	for resourceName, value := range bundle {
+		if value == 0 {
+			delete(bundle, resourceName)
+		}
+	}
+	return bundle
+}
+
+// PlacementGroupNames returns the sorted names of the placement groups.
+func PlacementGroupNames(specs []PlacementGroupSpec) []string {
+	names := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		names = append(names, spec.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PlacementGroupsCreated checks whether all the placement groups declared by the worker groups of the RayCluster
+// have been created.
+func PlacementGroupsCreated(cluster *rayv1.RayCluster) bool {
+	created := make(map[string]bool, len(cluster.Status.PlacementGroups))
+	for _, name := range cluster.Status.PlacementGroups {
+		created[name] = true
+	}
+	for _, name := range PlacementGroupNames(BuildPlacementGroupSpecs(cluster)) {
+		if !created[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildPlacementGroupBootstrapJobRequest builds the Ray job creating the placement groups. Its submission ID is
+// derived from the placement groups, so that a new job is submitted when they change.
+func BuildPlacementGroupBootstrapJobRequest(cluster *rayv1.RayCluster, specs []PlacementGroupSpec) (*utils.RayJobRequest, error) {
+	data, err := json.Marshal(specs)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := utils.GenerateJsonHash(specs)
+	if err != nil {
+		return nil, err
+	}
+	return &utils.RayJobRequest{
+		Entrypoint:   fmt.Sprintf("python -c '%s'", placementGroupBootstrapScript),
+		SubmissionId: fmt.Sprintf("%s-placement-groups-%s", cluster.Name, strings.ToLower(hash[:8])),
+		RuntimeEnv: utils.RuntimeEnvType{
+			"env_vars": map[string]string{placementGroupsEnvVar: string(data)},
+		},
+		Metadata: map[string]string{utils.RayClusterLabelKey: cluster.Name},
+	}, nil
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestBuildPlacementGroupSpecs(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs[0].PlacementGroup = &rayv1.PlacementGroupOptions{Bundles: 2}
+	gpuGroup := *cluster.Spec.WorkerGroupSpecs[0].DeepCopy()
+	gpuGroup.GroupName = "gpu-group"
+	gpuGroup.RayStartParams = map[string]string{"num-cpus": "4"}
+	gpuGroup.Template.Spec.Containers[0].Resources.Limits = corev1.ResourceList{
+		corev1.ResourceCPU:                    resource.MustParse("8"),
+		corev1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+	}
+	gpuGroup.PlacementGroup = &rayv1.PlacementGroupOptions{Name: "training", Bundles: 1, Strategy: rayv1.PlacementGroupStrategyPack}
+	noPlacementGroup := *cluster.Spec.WorkerGroupSpecs[0].DeepCopy()
+	noPlacementGroup.GroupName = "no-placement-group"
+	noPlacementGroup.PlacementGroup = nil
+	cluster.Spec.WorkerGroupSpecs = append(cluster.Spec.WorkerGroupSpecs, gpuGroup, noPlacementGroup)
+
+	// The worker group sets `num-cpus` and requests 3 GPUs in its limits.
+	workerBundle := map[string]float64{"CPU": 1, "GPU": 3}
+	assert.Equal(t, []PlacementGroupSpec{
+		{
+			Name:     cluster.Spec.WorkerGroupSpecs[0].GroupName,
+			Strategy: string(rayv1.PlacementGroupStrategyStrictSpread),
+			Bundles:  []map[string]float64{workerBundle, workerBundle},
+		},
+		{
+			Name:     "training",
+			Strategy: string(rayv1.PlacementGroupStrategyPack),
+			Bundles:  []map[string]float64{{"CPU": 4, "GPU": 1}},
+		},
+	}, BuildPlacementGroupSpecs(cluster))
+
+	// The number of GPUs is taken from the GPU options of the group.
+	gpuGroup.Template.Spec.Containers[0].Resources.Limits = nil
+	gpuGroup.GPU = &rayv1.GPUOptions{Count: ptr.To[int32](2)}
+	assert.Equal(t, map[string]float64{"CPU": 4, "GPU": 2}, placementGroupBundle(gpuGroup))
+}
+
+func TestPlacementGroupsCreated(t *testing.T) {
+	cluster := instance.DeepCopy()
+	assert.True(t, PlacementGroupsCreated(cluster))
+
+	cluster.Spec.WorkerGroupSpecs[0].PlacementGroup = &rayv1.PlacementGroupOptions{Bundles: 1}
+	assert.False(t, PlacementGroupsCreated(cluster))
+
+	cluster.Status.PlacementGroups = []string{cluster.Spec.WorkerGroupSpecs[0].GroupName}
+	assert.True(t, PlacementGroupsCreated(cluster))
+}
+
+func TestBuildPlacementGroupBootstrapJobRequest(t *testing.T) {
+	cluster := instance.DeepCopy()
+	specs := []PlacementGroupSpec{{Name: "pg", Strategy: "PACK", Bundles: []map[string]float64{{"CPU": 1}}}}
+	request, err := BuildPlacementGroupBootstrapJobRequest(cluster, specs)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(request.SubmissionId, cluster.Name+"-placement-groups-"))
+	assert.True(t, strings.HasPrefix(request.Entrypoint, "python -c '"))
+	assert.Equal(t, 2, strings.Count(request.Entrypoint, "'"))
+
+	envVars := request.RuntimeEnv["env_vars"].(map[string]string)
+	var actual []PlacementGroupSpec
+	assert.NoError(t, json.Unmarshal([]byte(envVars[placementGroupsEnvVar]), &actual))
+	assert.Equal(t, specs, actual)
+
+	// The submission ID changes with the placement groups.
+	specs[0].Bundles = append(specs[0].Bundles, map[string]float64{"CPU": 1})
+	otherRequest, err := BuildPlacementGroupBootstrapJobRequest(cluster, specs)
+	assert.NoError(t, err)
+	assert.NotEqual(t, request.SubmissionId, otherRequest.SubmissionId)
+}
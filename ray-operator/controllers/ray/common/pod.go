@@ -3,8 +3,13 @@ package common
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -16,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -25,8 +31,18 @@ const (
 	RayLogVolumeName            = "ray-logs"
 	RayLogVolumeMountPath       = "/tmp/ray"
 	AutoscalerContainerName     = "autoscaler"
+	LogSidecarContainerName     = "ray-log-sidecar"
+	LogSidecarConfigVolumeName  = "ray-log-sidecar-config"
+	LogSidecarConfigMountPath   = "/etc/ray-log-sidecar"
+	LogArchiverContainerName    = "ray-log-archiver"
 	RayHeadContainer            = "ray-head"
 	ObjectStoreMemoryKey        = "object-store-memory"
+	// Projected service account tokens are mounted at {ServiceAccountTokenMountPath}{name}/token.
+	ServiceAccountTokenVolumePrefix = "sa-token-"
+	ServiceAccountTokenMountPath    = "/var/run/secrets/tokens/"
+	// Default images of the log-archiving sidecar for S3 and GCS buckets.
+	DefaultLogArchiverS3Image  = "amazon/aws-cli:2.15.0"
+	DefaultLogArchiverGCSImage = "gcr.io/google.com/cloudsdktool/google-cloud-cli:489.0.0-slim"
 	// TODO (davidxia): should be a const in upstream ray-project/ray
 	AllowSlowStorageEnvVar = "RAY_OBJECT_STORE_ALLOW_SLOW_STORAGE"
 	// If set to true, kuberay auto injects an init container waiting for ray GCS.
@@ -87,12 +103,33 @@ func initTemplateAnnotations(instance rayv1.RayCluster, podTemplate *corev1.PodT
 	}
 }
 
+// setSafeToEvictAnnotation sets the safe-to-evict annotation of the Kubernetes Cluster Autoscaler
+// unless users already set it in the Pod template.
+func setSafeToEvictAnnotation(podTemplate *corev1.PodTemplateSpec, safeToEvict bool) {
+	if _, ok := podTemplate.Annotations[utils.SafeToEvictAnnotationKey]; ok {
+		return
+	}
+	podTemplate.Annotations[utils.SafeToEvictAnnotationKey] = strconv.FormatBool(safeToEvict)
+}
+
+// setShmAnnotations records the /dev/shm settings of a group in the Pod template for BuildPod.
+func setShmAnnotations(podTemplate *corev1.PodTemplateSpec, shmSize *resource.Quantity, disableShmVolume *bool) {
+	if shmSize != nil {
+		podTemplate.Annotations[utils.RayShmSizeAnnotationKey] = shmSize.String()
+	}
+	if disableShmVolume != nil && *disableShmVolume {
+		podTemplate.Annotations[utils.RayDisableShmVolumeAnnotationKey] = "true"
+	}
+}
+
 // DefaultHeadPodTemplate sets the config values
 func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, headSpec rayv1.HeadGroupSpec, podName string, headPort string) corev1.PodTemplateSpec {
 	// TODO (Dmitri) The argument headPort is essentially unused;
 	// headPort is passed into setMissingRayStartParams but unused there for the head pod.
 	// To mitigate this awkwardness and reduce code redundancy, unify head and worker pod configuration logic.
-	podTemplate := headSpec.Template
+	// Deep copy the template, so that defaulting the containers, volumes, and metadata below never mutates the
+	// slices and maps shared with the RayCluster spec.
+	podTemplate := *headSpec.Template.DeepCopy()
 	podTemplate.GenerateName = podName
 	// Pods created by RayCluster should be restricted to the namespace of the RayCluster.
 	// This ensures privilege of KubeRay users are contained within the namespace of the RayCluster.
@@ -105,6 +142,10 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 	headSpec.RayStartParams = setMissingRayStartParams(ctx, headSpec.RayStartParams, rayv1.HeadNode, headPort, "")
 
 	initTemplateAnnotations(instance, &podTemplate)
+	// The head Pod runs the GCS and the autoscaler, so the Kubernetes Cluster Autoscaler
+	// should not evict it unless users opt out.
+	setSafeToEvictAnnotation(&podTemplate, headSpec.SafeToEvict != nil && *headSpec.SafeToEvict)
+	setShmAnnotations(&podTemplate, headSpec.ShmSize, headSpec.DisableShmVolume)
 
 	// if in-tree autoscaling is enabled, then autoscaler container should be injected into head pod.
 	if instance.Spec.EnableInTreeAutoscaling != nil && *instance.Spec.EnableInTreeAutoscaling {
@@ -113,15 +154,32 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 		headSpec.RayStartParams["no-monitor"] = "true"
 		// set custom service account with proper roles bound.
 		// utils.CheckName clips the name to match the behavior of reconcileAutoscalerServiceAccount
-		podTemplate.Spec.ServiceAccountName = utils.CheckName(utils.GetHeadGroupServiceAccountName(&instance))
+		if instance.Spec.AutoscalerOptions != nil && instance.Spec.AutoscalerOptions.ServiceAccountName != nil {
+			podTemplate.Spec.ServiceAccountName = *instance.Spec.AutoscalerOptions.ServiceAccountName
+		} else {
+			podTemplate.Spec.ServiceAccountName = utils.CheckName(utils.GetHeadGroupServiceAccountName(&instance))
+		}
 		// Use the same image as Ray head container by default.
 		autoscalerImage := podTemplate.Spec.Containers[utils.RayContainerIndex].Image
 		// inject autoscaler container into head pod
-		autoscalerContainer := BuildAutoscalerContainer(autoscalerImage)
+		autoscalingV2 := utils.IsAutoscalingV2Enabled(&instance.Spec)
+		autoscalerContainer := BuildAutoscalerContainer(autoscalerImage, autoscalingV2)
 		// Merge the user overrides from autoscalerOptions into the autoscaler container config.
 		mergeAutoscalerOverrides(&autoscalerContainer, instance.Spec.AutoscalerOptions)
 		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, autoscalerContainer)
+		if autoscalingV2 {
+			setAutoscalerV2EnvVar(&podTemplate.Spec.Containers[utils.RayContainerIndex])
+			setAutoscalerV2RestartPolicy(&podTemplate)
+		}
+	}
+
+	if instance.Spec.Logging != nil && instance.Spec.Logging.Sidecar != nil {
+		addLogSidecar(&podTemplate, *instance.Spec.Logging.Sidecar)
+	}
+	if instance.Spec.Logging != nil && instance.Spec.Logging.Archive != nil {
+		addLogArchiver(&podTemplate, *instance.Spec.Logging.Archive)
 	}
+	addServiceAccountTokens(&podTemplate, instance.Spec.ServiceAccountTokens)
 
 	// If the metrics port does not exist in the Ray container, add a default one for Prometheus.
 	isMetricsPortExists := utils.FindContainerPort(&podTemplate.Spec.Containers[utils.RayContainerIndex], utils.MetricsPortName, -1) != -1
@@ -133,9 +191,456 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 		podTemplate.Spec.Containers[utils.RayContainerIndex].Ports = append(podTemplate.Spec.Containers[utils.RayContainerIndex].Ports, metricsPort)
 	}
 
+	if isServiceMeshModeEnabled(instance, rayv1.IstioServiceMesh) {
+		configureIstio(&podTemplate, headSpec.RayStartParams, headPort)
+	}
+
 	return podTemplate
 }
 
+func isServiceMeshModeEnabled(instance rayv1.RayCluster, mode rayv1.ServiceMeshMode) bool {
+	return instance.Spec.ServiceMeshMode != nil && *instance.Spec.ServiceMeshMode == mode
+}
+
+// configureIstio makes a Ray Pod template compatible with Istio sidecar injection. It must be called once all the
+// containers and their ports are in the Pod template.
+//
+// (1) The proxy is started before the application containers via `holdApplicationUntilProxyStarts`.
+// (2) Ray's internal gRPC ports are excluded from the sidecar's traffic interception because Ray nodes
+// talk to each other directly with Pod IPs, which Istio cannot route with mTLS. Ray picks some of these
+// ports randomly, so BuildPod pins them in the `ray start` command, see istioRayStartParams.
+// (3) Ray workers listen on a range of ports, which cannot be excluded as Istio only accepts lists of ports.
+// Instead, only the ports declared by the containers, e.g. the dashboard and the Serve ports, are intercepted.
+// (4) The `ray.io/service-mesh-mode` annotation tells BuildPod to hold `ray start` until the proxy is ready.
+//
+// Workers already reach the head through the FQDN of the head service, which resolves to the Pod IP of the head
+// with or without the sidecar. Routing the head service itself through the mesh is not supported.
+//
+// Annotations set by users are never overwritten.
+func configureIstio(podTemplate *corev1.PodTemplateSpec, rayStartParams map[string]string, headPort string) {
+	rayStartParams = istioRayStartParams(rayStartParams)
+	internalPorts := []string{headPort}
+	for _, key := range istioPinnedRayStartParams {
+		internalPorts = append(internalPorts, rayStartParams[key])
+	}
+
+	var interceptedPorts []string
+	for _, container := range podTemplate.Spec.Containers {
+		for _, port := range container.Ports {
+			if p := strconv.Itoa(int(port.ContainerPort)); !slices.Contains(internalPorts, p) && !slices.Contains(interceptedPorts, p) {
+				interceptedPorts = append(interceptedPorts, p)
+			}
+		}
+	}
+
+	annotations := map[string]string{
+		utils.RayServiceMeshModeAnnotationKey:        string(rayv1.IstioServiceMesh),
+		utils.IstioProxyConfigAnnotationKey:          `{"holdApplicationUntilProxyStarts": true}`,
+		utils.IstioIncludeInboundPortsAnnotationKey:  strings.Join(interceptedPorts, ","),
+		utils.IstioExcludeInboundPortsAnnotationKey:  strings.Join(internalPorts, ","),
+		utils.IstioExcludeOutboundPortsAnnotationKey: strings.Join(internalPorts, ","),
+	}
+	for k, v := range annotations {
+		if _, ok := podTemplate.Annotations[k]; !ok {
+			podTemplate.Annotations[k] = v
+		}
+	}
+}
+
+// istioPinnedRayStartParams are the RayStartParams of the ports that Ray picks randomly by default.
+var istioPinnedRayStartParams = []string{"object-manager-port", "node-manager-port", "dashboard-agent-grpc-port"}
+
+// istioRayStartParams returns a copy of the RayStartParams with the ports of istioPinnedRayStartParams pinned to their
+// defaults unless users set them. The RayStartParams of the spec are left unchanged.
+func istioRayStartParams(rayStartParams map[string]string) map[string]string {
+	defaultPorts := map[string]int{
+		"object-manager-port":       utils.DefaultObjectManagerPort,
+		"node-manager-port":         utils.DefaultNodeManagerPort,
+		"dashboard-agent-grpc-port": utils.DefaultDashboardAgentGrpcPort,
+	}
+	params := maps.Clone(rayStartParams)
+	if params == nil {
+		params = map[string]string{}
+	}
+	for _, key := range istioPinnedRayStartParams {
+		if _, ok := params[key]; !ok {
+			params[key] = strconv.Itoa(defaultPorts[key])
+		}
+	}
+	return params
+}
+
+// generateWaitForIstioProxyCommand returns a command that blocks until the Istio proxy reports ready.
+func generateWaitForIstioProxyCommand() string {
+	return fmt.Sprintf(
+		"until %s > /dev/null 2>&1; do echo \"Waiting for the Istio proxy to be ready.\"; sleep 1; done",
+		generateHTTPGetCommand(2, utils.DefaultIstioProxyReadinessPort, utils.IstioProxyReadinessPath))
+}
+
+// generateHTTPGetCommand returns a command that prints the response body of `http://localhost:<port>/<path>` and fails
+// if the request fails. Distroless Ray images ship neither wget nor curl, so the command falls back to the Python
+// interpreter that Ray itself requires.
+func generateHTTPGetCommand(timeoutSeconds int, port int, path string) string {
+	url := fmt.Sprintf("http://localhost:%d/%s", port, path)
+	return fmt.Sprintf(
+		`{ if command -v wget > /dev/null 2>&1; then wget -T %d -q -O- %s; `+
+			`else python3 -c "import sys, urllib.request; sys.stdout.write(urllib.request.urlopen('%s', timeout=%d).read().decode())"; fi; }`,
+		timeoutSeconds, url, url, timeoutSeconds)
+}
+
+// httpHealthCheckScript is the Python script run by the probes of the Ray container. It succeeds if the response bodies
+// of all the URLs passed as arguments contain "success", which is what the Ray health check endpoints return.
+const httpHealthCheckScript = `import sys, urllib.request
+for url in sys.argv[1:]:
+    if b"success" not in urllib.request.urlopen(url, timeout=%d).read():
+        sys.exit(1)`
+
+// generateHTTPHealthProbeCommand returns the exec probe command checking the health endpoints at
+// `http://localhost:<port>/<path>`. It runs the Python interpreter that Ray itself requires without a shell, so that it
+// also works in distroless and minimal Ray images, which ship neither bash, wget, nor grep.
+func generateHTTPHealthProbeCommand(timeoutSeconds int, endpoints ...string) []string {
+	command := []string{"python3", "-c", fmt.Sprintf(httpHealthCheckScript, timeoutSeconds)}
+	for _, endpoint := range endpoints {
+		command = append(command, "http://localhost:"+endpoint)
+	}
+	return command
+}
+
+// addLogSidecar injects a log-shipping sidecar container into the Pod template. BuildPod shares the Ray
+// log directory between the Ray container and the sidecar.
+func addLogSidecar(podTemplate *corev1.PodTemplateSpec, sidecar rayv1.LogSidecarOptions) {
+	container := corev1.Container{
+		Name:  LogSidecarContainerName,
+		Image: sidecar.Image,
+	}
+	if sidecar.Output != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: utils.RAY_LOG_OUTPUT, Value: sidecar.Output})
+	}
+	if sidecar.Resources != nil {
+		container.Resources = *sidecar.Resources.DeepCopy()
+	}
+	if sidecar.ConfigMapName != "" {
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{
+			Name: LogSidecarConfigVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: sidecar.ConfigMapName},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      LogSidecarConfigVolumeName,
+			MountPath: LogSidecarConfigMountPath,
+			ReadOnly:  true,
+		})
+	}
+	podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, container)
+}
+
+// addServiceAccountTokens mounts the projected service account tokens into the Ray container and,
+// if present, the autoscaler container.
+func addServiceAccountTokens(podTemplate *corev1.PodTemplateSpec, tokens []rayv1.ServiceAccountToken) {
+	for _, token := range tokens {
+		volumeName := ServiceAccountTokenVolumePrefix + token.Name
+		if !checkIfVolumeExists(&corev1.Pod{Spec: podTemplate.Spec}, volumeName) {
+			podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								Audience:          token.Audience,
+								ExpirationSeconds: token.ExpirationSeconds,
+								Path:              "token",
+							},
+						}},
+					},
+				},
+			})
+		}
+		for i := range podTemplate.Spec.Containers {
+			container := &podTemplate.Spec.Containers[i]
+			if i != utils.RayContainerIndex && container.Name != AutoscalerContainerName {
+				continue
+			}
+			if !checkIfVolumeMounted(container, ServiceAccountTokenMountPath+token.Name) {
+				container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+					Name:      volumeName,
+					MountPath: ServiceAccountTokenMountPath + token.Name,
+					ReadOnly:  true,
+				})
+			}
+		}
+	}
+}
+
+// addLogArchiver injects a sidecar container into the Pod template that uploads the Ray logs to object storage
+// in its preStop hook. The container itself only waits to be terminated. BuildPod shares the Ray log directory
+// between the Ray container and the sidecar.
+func addLogArchiver(podTemplate *corev1.PodTemplateSpec, archive rayv1.LogArchiveOptions) {
+	image, uploadCmd := DefaultLogArchiverS3Image, "aws s3 cp"
+	if strings.HasPrefix(archive.Bucket, "gs://") {
+		image, uploadCmd = DefaultLogArchiverGCSImage, "gsutil cp"
+	}
+	if archive.Image != nil {
+		image = *archive.Image
+	}
+	archiveCmd := fmt.Sprintf(
+		"tar -czf /tmp/ray-logs.tar.gz -C %s/session_latest logs && %s /tmp/ray-logs.tar.gz %s/$%s/$%s.tar.gz",
+		RayLogVolumeMountPath, uploadCmd, strings.TrimSuffix(archive.Bucket, "/"), utils.RAY_CLUSTER_NAME, utils.POD_NAME)
+
+	container := corev1.Container{
+		Name:    LogArchiverContainerName,
+		Image:   image,
+		Command: []string{"/bin/sh", "-c", "--"},
+		Args:    []string{"trap 'exit 0' TERM; sleep infinity & wait"},
+		Env: []corev1.EnvVar{
+			{
+				Name: utils.RAY_CLUSTER_NAME,
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: fmt.Sprintf("metadata.labels['%s']", utils.RayClusterLabelKey),
+					},
+				},
+			},
+			{
+				Name: utils.POD_NAME,
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+				},
+			},
+		},
+		Lifecycle: &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", archiveCmd}},
+			},
+		},
+	}
+	if archive.CredentialsSecretRef != nil {
+		container.EnvFrom = append(container.EnvFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: *archive.CredentialsSecretRef},
+		})
+	}
+	podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, container)
+}
+
+// acceleratorResourceNames are well-known accelerator resources. Cloud providers and device plugins
+// usually taint the nodes offering them with a taint whose key is the resource name.
+var acceleratorResourceNames = []corev1.ResourceName{
+	"amd.com/gpu",
+	"aws.amazon.com/neuron",
+	"google.com/tpu",
+	"nvidia.com/gpu",
+}
+
+// AddAcceleratorTolerations adds a toleration for the taint of each well-known accelerator requested by
+// the containers of the Pod template, unless the Pod template already tolerates it. Otherwise, the Pods
+// stay Pending forever on clusters that taint their accelerator nodes.
+func AddAcceleratorTolerations(podTemplate *corev1.PodTemplateSpec) {
+	requested := make(map[corev1.ResourceName]bool)
+	for _, container := range podTemplate.Spec.Containers {
+		for _, resources := range []corev1.ResourceList{container.Resources.Requests, container.Resources.Limits} {
+			for name, quantity := range resources {
+				if !quantity.IsZero() {
+					requested[name] = true
+				}
+			}
+		}
+	}
+
+	// The tolerations may still be shared with the RayCluster's Pod template, so a new slice is built.
+	tolerations := append([]corev1.Toleration{}, podTemplate.Spec.Tolerations...)
+	for _, name := range acceleratorResourceNames {
+		if requested[name] && !isTaintTolerated(tolerations, string(name)) {
+			tolerations = append(tolerations, corev1.Toleration{
+				Key:      string(name),
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			})
+		}
+	}
+	if len(tolerations) > len(podTemplate.Spec.Tolerations) {
+		podTemplate.Spec.Tolerations = tolerations
+	}
+}
+
+func isTaintTolerated(tolerations []corev1.Toleration, taintKey string) bool {
+	for _, toleration := range tolerations {
+		// An empty key with the Exists operator tolerates everything.
+		if toleration.Key == taintKey || (toleration.Key == "" && toleration.Operator == corev1.TolerationOpExists) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	defaultGPUResourceName = "nvidia.com/gpu"
+	migResourcePrefix      = "nvidia.com/mig-"
+	// noSetCUDAVisibleDevicesEnvName stops Ray from hiding the GPUs from the tasks that do not request Ray GPUs.
+	noSetCUDAVisibleDevicesEnvName = "RAY_EXPERIMENTAL_NOSET_CUDA_VISIBLE_DEVICES"
+)
+
+// gpuResource returns the device plugin resource requested by the Ray container for the GPU options, its quantity,
+// and the number of GPUs that Ray sees.
+func gpuResource(gpu rayv1.GPUOptions) (corev1.ResourceName, resource.Quantity, int64) {
+	resourceName := gpu.ResourceName
+	if resourceName == "" {
+		resourceName = defaultGPUResourceName
+		if gpu.MIGProfile != "" {
+			resourceName = migResourcePrefix + gpu.MIGProfile
+		}
+	}
+	if gpu.Shares != nil {
+		// The webhook guarantees that the shares amount to a whole number of replicas of the time-sliced GPU. `ray start`
+		// only accepts whole GPUs, so Ray sees the shares rounded down, i.e. no GPU for a fraction of a GPU, instead
+		// of more GPU capacity than the Ray container has.
+		replicas := gpu.Shares.MilliValue() * int64(ptr.Deref(gpu.ReplicasPerGPU, 1)) / 1000
+		return corev1.ResourceName(resourceName), *resource.NewQuantity(replicas, resource.DecimalSI), gpu.Shares.MilliValue() / 1000
+	}
+	count := int64(ptr.Deref(gpu.Count, 1))
+	return corev1.ResourceName(resourceName), *resource.NewQuantity(count, resource.DecimalSI), count
+}
+
+// AddGPUResources sets the requests and limits of the device plugin resource of the GPU options in the Ray container.
+// If Ray sees no GPU, i.e. for a fraction of a time-sliced GPU, Ray is told not to hide the shared GPU from the tasks.
+func AddGPUResources(podTemplate *corev1.PodTemplateSpec, gpu rayv1.GPUOptions) {
+	resourceName, quantity, numGPUs := gpuResource(gpu)
+	container := &podTemplate.Spec.Containers[utils.RayContainerIndex]
+	if numGPUs == 0 && !utils.EnvVarExists(noSetCUDAVisibleDevicesEnvName, container.Env) {
+		container.Env = append(container.Env, corev1.EnvVar{Name: noSetCUDAVisibleDevicesEnvName, Value: "1"})
+	}
+	// The resource lists may still be shared with the RayCluster's Pod template, so new maps are built.
+	container.Resources.Limits = container.Resources.Limits.DeepCopy()
+	if container.Resources.Limits == nil {
+		container.Resources.Limits = corev1.ResourceList{}
+	}
+	container.Resources.Limits[resourceName] = quantity
+	container.Resources.Requests = container.Resources.Requests.DeepCopy()
+	if container.Resources.Requests == nil {
+		container.Resources.Requests = corev1.ResourceList{}
+	}
+	container.Resources.Requests[resourceName] = quantity
+}
+
+// AddGPUNumGPUs returns a copy of rayStartParams whose `num-gpus` parameter is the number of GPUs that Ray sees for
+// the GPU options. For example, a Ray container using half of a time-sliced GPU requests 2 of its 4 replicas, but
+// Ray sees no GPU. `num-gpus` already set in rayStartParams wins.
+func AddGPUNumGPUs(rayStartParams map[string]string, gpu *rayv1.GPUOptions) map[string]string {
+	if gpu == nil {
+		return rayStartParams
+	}
+	if _, ok := rayStartParams["num-gpus"]; ok {
+		return rayStartParams
+	}
+	_, _, numGPUs := gpuResource(*gpu)
+	params := make(map[string]string, len(rayStartParams)+1)
+	for k, v := range rayStartParams {
+		params[k] = v
+	}
+	params["num-gpus"] = strconv.FormatInt(numGPUs, 10)
+	return params
+}
+
+// reservedRayResourceNames are the Ray resources set by dedicated `ray start` options, which cannot be passed in `--resources`.
+var reservedRayResourceNames = []string{"CPU", "GPU", "memory", "object_store_memory"}
+
+// ParseAcceleratorResourceMapping parses a comma-separated list of `<extended resource>=<Ray resource>` pairs,
+// e.g. "google.com/tpu=TPU,habana.ai/gaudi=HPU".
+func ParseAcceleratorResourceMapping(s string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		resourceName, rayResourceName, found := strings.Cut(pair, "=")
+		resourceName, rayResourceName = strings.TrimSpace(resourceName), strings.TrimSpace(rayResourceName)
+		if !found || resourceName == "" || rayResourceName == "" {
+			return nil, fmt.Errorf("invalid accelerator resource mapping %q: expected <extended resource>=<Ray resource>", pair)
+		}
+		if utils.Contains(reservedRayResourceNames, rayResourceName) {
+			return nil, fmt.Errorf("invalid accelerator resource mapping %q: %s is a reserved Ray resource", pair, rayResourceName)
+		}
+		mapping[resourceName] = rayResourceName
+	}
+	return mapping, nil
+}
+
+// AddAcceleratorResources returns a copy of rayStartParams whose `resources` parameter advertises the extended
+// resources in the limits of the Ray container as Ray custom resources, e.g. `google.com/tpu: 4` as `{"TPU": 4}`.
+// The mapping from extended resources to Ray resources is the operator-level mapping, overridden per key by the
+// `ray.io/accelerator-resources` annotation of the Pod template. Ray resources already set in rayStartParams win.
+func AddAcceleratorResources(ctx context.Context, podTemplate corev1.PodTemplateSpec, rayStartParams map[string]string, mapping map[string]string) map[string]string {
+	log := ctrl.LoggerFrom(ctx)
+	if value, ok := podTemplate.Annotations[utils.RayAcceleratorResourcesAnnotationKey]; ok {
+		groupMapping, err := ParseAcceleratorResourceMapping(value)
+		if err != nil {
+			log.Error(err, "Ignoring the annotation", "annotation", utils.RayAcceleratorResourcesAnnotationKey)
+		} else {
+			merged := make(map[string]string, len(mapping)+len(groupMapping))
+			for k, v := range mapping {
+				merged[k] = v
+			}
+			for k, v := range groupMapping {
+				merged[k] = v
+			}
+			mapping = merged
+		}
+	}
+	if len(mapping) == 0 || len(podTemplate.Spec.Containers) == 0 {
+		return rayStartParams
+	}
+
+	customResources := make(map[string]float64)
+	if value, ok := rayStartParams["resources"]; ok {
+		var err error
+		if customResources, err = parseRayCustomResources(value); err != nil {
+			log.Error(err, "Failed to parse the resources in rayStartParams; accelerator resources are not added", "resources", value)
+			return rayStartParams
+		}
+	}
+	added := false
+	for resourceName, quantity := range podTemplate.Spec.Containers[utils.RayContainerIndex].Resources.Limits {
+		rayResourceName, ok := mapping[string(resourceName)]
+		if !ok || quantity.IsZero() {
+			continue
+		}
+		if _, ok := customResources[rayResourceName]; ok {
+			continue
+		}
+		customResources[rayResourceName] = float64(quantity.Value())
+		added = true
+	}
+	if !added {
+		return rayStartParams
+	}
+
+	params := make(map[string]string, len(rayStartParams)+1)
+	for k, v := range rayStartParams {
+		params[k] = v
+	}
+	// json.Marshal sorts the keys, so the generated command is stable across reconciliations.
+	encoded, _ := json.Marshal(customResources)
+	params["resources"] = fmt.Sprintf(`'"%s"'`, strings.ReplaceAll(string(encoded), `"`, `\"`))
+	return params
+}
+
+// parseRayCustomResources parses the `resources` rayStartParam. Users usually quote the JSON dictionary for the shell,
+// e.g. '"{\"Custom1\": 1}"' or '{"Custom1": 1}'.
+func parseRayCustomResources(value string) (map[string]float64, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "'"), "'")
+	if strings.HasPrefix(value, `"`) {
+		value = strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`), `\"`, `"`)
+	}
+	customResources := make(map[string]float64)
+	if err := json.Unmarshal([]byte(value), &customResources); err != nil {
+		return nil, err
+	}
+	return customResources, nil
+}
+
 func getEnableInitContainerInjection() bool {
 	if s := os.Getenv(EnableInitContainerInjectionEnvKey); strings.ToLower(s) == "false" {
 		return false
@@ -143,6 +648,45 @@ func getEnableInitContainerInjection() bool {
 	return true
 }
 
+// envVarReferenceRegex matches references to other environment variables, e.g. `$(MY_POD_IP)`.
+var envVarReferenceRegex = regexp.MustCompile(`\$\(([A-Za-z_][A-Za-z0-9_.-]*)\)`)
+
+// getInitContainerEnv returns the environment variables of the Ray container that are passed to the init container.
+// If ENABLE_MINIMAL_INIT_CONTAINER_ENV is true, only the TLS-related variables, the ones listed in
+// INIT_CONTAINER_ENV_ALLOWLIST, and the variables they reference are passed.
+func getInitContainerEnv(env []corev1.EnvVar) []corev1.EnvVar {
+	if s := os.Getenv(utils.ENABLE_MINIMAL_INIT_CONTAINER_ENV); strings.ToLower(s) != "true" {
+		return env
+	}
+
+	required := map[string]bool{}
+	for _, name := range strings.Split(os.Getenv(utils.INIT_CONTAINER_ENV_ALLOWLIST), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			required[name] = true
+		}
+	}
+	// A variable can only reference the variables defined before it, so a single backward pass
+	// marks all variables that the required ones depend on.
+	for i := len(env) - 1; i >= 0; i-- {
+		name := env[i].Name
+		if !required[name] && name != "RAY_USE_TLS" && !strings.HasPrefix(name, "RAY_TLS_") {
+			continue
+		}
+		required[name] = true
+		for _, match := range envVarReferenceRegex.FindAllStringSubmatch(env[i].Value, -1) {
+			required[match[1]] = true
+		}
+	}
+
+	minimalEnv := []corev1.EnvVar{}
+	for _, envVar := range env {
+		if required[envVar.Name] {
+			minimalEnv = append(minimalEnv, envVar)
+		}
+	}
+	return minimalEnv
+}
+
 func getEnableProbesInjection() bool {
 	if s := os.Getenv(utils.ENABLE_PROBES_INJECTION); strings.ToLower(s) == "false" {
 		return false
@@ -150,9 +694,43 @@ func getEnableProbesInjection() bool {
 	return true
 }
 
+// addTopologySpreadConstraint spreads the worker Pods of a group across the topology domains given by the options,
+// unless the Pod template already has a topology spread constraint with the same topology key.
+func addTopologySpreadConstraint(podTemplate *corev1.PodTemplateSpec, clusterName string, groupName string, options rayv1.TopologySpreadOptions) {
+	topologyKey := options.TopologyKey
+	if topologyKey == "" {
+		topologyKey = corev1.LabelHostname
+	}
+	for _, constraint := range podTemplate.Spec.TopologySpreadConstraints {
+		if constraint.TopologyKey == topologyKey {
+			return
+		}
+	}
+
+	constraint := corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       topologyKey,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				utils.RayClusterLabelKey:   clusterName,
+				utils.RayNodeGroupLabelKey: groupName,
+			},
+		},
+	}
+	if options.MaxSkew != nil {
+		constraint.MaxSkew = *options.MaxSkew
+	}
+	if options.WhenUnsatisfiable != nil {
+		constraint.WhenUnsatisfiable = *options.WhenUnsatisfiable
+	}
+	podTemplate.Spec.TopologySpreadConstraints = append(podTemplate.Spec.TopologySpreadConstraints, constraint)
+}
+
 // DefaultWorkerPodTemplate sets the config values
 func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, workerSpec rayv1.WorkerGroupSpec, podName string, fqdnRayIP string, headPort string) corev1.PodTemplateSpec {
-	podTemplate := workerSpec.Template
+	// Deep copy the template for the same reason as in DefaultHeadPodTemplate.
+	podTemplate := *workerSpec.Template.DeepCopy()
 	podTemplate.GenerateName = podName
 	// Pods created by RayCluster should be restricted to the namespace of the RayCluster.
 	// This ensures privilege of KubeRay users are contained within the namespace of the RayCluster.
@@ -160,7 +738,9 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 
 	// The Ray worker should only start once the GCS server is ready.
 	// only inject init container only when ENABLE_INIT_CONTAINER_INJECTION is true
-	enableInitContainerInjection := getEnableInitContainerInjection()
+	// In Istio mode, init containers run before the sidecar proxy starts, so they cannot reach the GCS server.
+	// The Ray container waits for the proxy instead, and `ray start` retries the connection to the GCS server.
+	enableInitContainerInjection := getEnableInitContainerInjection() && !isServiceMeshModeEnabled(instance, rayv1.IstioServiceMesh)
 
 	if enableInitContainerInjection {
 		// Do not modify `deepCopyRayContainer` anywhere.
@@ -195,7 +775,7 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 			// This init container requires certain environment variables to establish a secure connection with the Ray head using TLS authentication.
 			// Additionally, some of these environment variables may reference files stored in volumes, so we need to include both the `Env` and `VolumeMounts` fields here.
 			// For more details, please refer to: https://docs.ray.io/en/latest/ray-core/configure.html#tls-authentication.
-			Env:          deepCopyRayContainer.Env,
+			Env:          getInitContainerEnv(deepCopyRayContainer.Env),
 			VolumeMounts: deepCopyRayContainer.VolumeMounts,
 			// If users specify a ResourceQuota for the namespace, the init container needs to specify resources explicitly.
 			// GKE's Autopilot does not support GPU-using init containers, so we explicitly specify the resources for the
@@ -225,6 +805,27 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 	workerSpec.RayStartParams = setMissingRayStartParams(ctx, workerSpec.RayStartParams, rayv1.WorkerNode, headPort, fqdnRayIP)
 
 	initTemplateAnnotations(instance, &podTemplate)
+	if workerSpec.SafeToEvict != nil {
+		setSafeToEvictAnnotation(&podTemplate, *workerSpec.SafeToEvict)
+	}
+	setShmAnnotations(&podTemplate, workerSpec.ShmSize, workerSpec.DisableShmVolume)
+	if workerSpec.TopologySpread != nil {
+		addTopologySpreadConstraint(&podTemplate, instance.Name, workerSpec.GroupName, *workerSpec.TopologySpread)
+	}
+
+	if instance.Spec.Logging != nil && instance.Spec.Logging.Sidecar != nil {
+		addLogSidecar(&podTemplate, *instance.Spec.Logging.Sidecar)
+	}
+	if instance.Spec.Logging != nil && instance.Spec.Logging.Archive != nil {
+		addLogArchiver(&podTemplate, *instance.Spec.Logging.Archive)
+	}
+	addServiceAccountTokens(&podTemplate, instance.Spec.ServiceAccountTokens)
+	if workerSpec.GPU != nil {
+		AddGPUResources(&podTemplate, *workerSpec.GPU)
+	}
+	if utils.IsAutoscalingV2Enabled(&instance.Spec) {
+		setAutoscalerV2RestartPolicy(&podTemplate)
+	}
 
 	// If the metrics port does not exist in the Ray container, add a default one for Prometheus.
 	isMetricsPortExists := utils.FindContainerPort(&podTemplate.Spec.Containers[utils.RayContainerIndex], utils.MetricsPortName, -1) != -1
@@ -236,31 +837,46 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 		podTemplate.Spec.Containers[utils.RayContainerIndex].Ports = append(podTemplate.Spec.Containers[utils.RayContainerIndex].Ports, metricsPort)
 	}
 
+	if isServiceMeshModeEnabled(instance, rayv1.IstioServiceMesh) {
+		configureIstio(&podTemplate, workerSpec.RayStartParams, headPort)
+	}
+
 	return podTemplate
 }
 
+// initAutoscalerLivenessProbe injects a liveness probe into the autoscaler container unless users define one. The
+// probe uses the log file of the autoscaler as a heartbeat, as the autoscaler does not serve a health endpoint.
+func initAutoscalerLivenessProbe(autoscalerContainer *corev1.Container) {
+	if autoscalerContainer.LivenessProbe != nil {
+		return
+	}
+	heartbeatScript := fmt.Sprintf(
+		`import os, sys, time; sys.exit(time.time() - os.path.getmtime("%s/%s") > %d)`,
+		RayLogVolumeMountPath, utils.RayAutoscalerLogPath, utils.AutoscalerHeartbeatTimeoutSeconds)
+	autoscalerContainer.LivenessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"python3", "-c", heartbeatScript}},
+		},
+		InitialDelaySeconds: utils.DefaultAutoscalerLivenessProbeInitialDelaySeconds,
+		TimeoutSeconds:      utils.DefaultAutoscalerLivenessProbeTimeoutSeconds,
+		PeriodSeconds:       utils.DefaultAutoscalerLivenessProbePeriodSeconds,
+		SuccessThreshold:    utils.DefaultLivenessProbeSuccessThreshold,
+		FailureThreshold:    utils.DefaultAutoscalerLivenessProbeFailureThreshold,
+	}
+}
+
 func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType rayv1.RayNodeType, creatorCRDType utils.CRDType) {
-	rayAgentRayletHealthCommand := fmt.Sprintf(
-		utils.BaseWgetHealthCommand,
-		utils.DefaultReadinessProbeTimeoutSeconds,
-		utils.DefaultDashboardAgentListenPort,
-		utils.RayAgentRayletHealthPath,
-	)
-	rayDashboardGCSHealthCommand := fmt.Sprintf(
-		utils.BaseWgetHealthCommand,
-		utils.DefaultReadinessProbeFailureThreshold,
-		utils.DefaultDashboardPort,
-		utils.RayDashboardGCSHealthPath,
-	)
+	rayAgentRayletHealthEndpoint := fmt.Sprintf("%d/%s", utils.DefaultDashboardAgentListenPort, utils.RayAgentRayletHealthPath)
+	rayDashboardGCSHealthEndpoint := fmt.Sprintf("%d/%s", utils.DefaultDashboardPort, utils.RayDashboardGCSHealthPath)
 
 	// Generally, the liveness and readiness probes perform the same checks.
 	// For head node => Check GCS and Raylet status.
 	// For worker node => Check Raylet status.
-	commands := []string{}
+	endpoints := []string{}
 	if rayNodeType == rayv1.HeadNode {
-		commands = append(commands, rayAgentRayletHealthCommand, rayDashboardGCSHealthCommand)
+		endpoints = append(endpoints, rayAgentRayletHealthEndpoint, rayDashboardGCSHealthEndpoint)
 	} else {
-		commands = append(commands, rayAgentRayletHealthCommand)
+		endpoints = append(endpoints, rayAgentRayletHealthEndpoint)
 	}
 
 	if rayContainer.LivenessProbe == nil {
@@ -271,7 +887,7 @@ func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType r
 			SuccessThreshold:    utils.DefaultLivenessProbeSuccessThreshold,
 			FailureThreshold:    utils.DefaultLivenessProbeFailureThreshold,
 		}
-		rayContainer.LivenessProbe.Exec = &corev1.ExecAction{Command: []string{"bash", "-c", strings.Join(commands, " && ")}}
+		rayContainer.LivenessProbe.Exec = &corev1.ExecAction{Command: generateHTTPHealthProbeCommand(utils.DefaultLivenessProbeTimeoutSeconds, endpoints...)}
 	}
 
 	if rayContainer.ReadinessProbe == nil {
@@ -282,21 +898,17 @@ func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType r
 			SuccessThreshold:    utils.DefaultReadinessProbeSuccessThreshold,
 			FailureThreshold:    utils.DefaultReadinessProbeFailureThreshold,
 		}
-		rayContainer.ReadinessProbe.Exec = &corev1.ExecAction{Command: []string{"bash", "-c", strings.Join(commands, " && ")}}
+		rayContainer.ReadinessProbe.Exec = &corev1.ExecAction{Command: generateHTTPHealthProbeCommand(utils.DefaultReadinessProbeTimeoutSeconds, endpoints...)}
 
 		// For worker Pods serving traffic, we need to add an additional HTTP proxy health check for the readiness probe.
 		// Note: head Pod checks the HTTP proxy's health at every rayservice controller reconcile instaed of using readiness probe.
 		// See https://github.com/ray-project/kuberay/pull/1808 for reasons.
 		if creatorCRDType == utils.RayServiceCRD && rayNodeType == rayv1.WorkerNode {
 			rayContainer.ReadinessProbe.FailureThreshold = utils.ServeReadinessProbeFailureThreshold
-			rayServeProxyHealthCommand := fmt.Sprintf(
-				utils.BaseWgetHealthCommand,
-				utils.DefaultReadinessProbeInitialDelaySeconds,
-				utils.FindContainerPort(rayContainer, utils.ServingPortName, utils.DefaultServingPort),
-				utils.RayServeProxyHealthPath,
-			)
-			commands = append(commands, rayServeProxyHealthCommand)
-			rayContainer.ReadinessProbe.Exec = &corev1.ExecAction{Command: []string{"bash", "-c", strings.Join(commands, " && ")}}
+			rayServeProxyHealthEndpoint := fmt.Sprintf("%d/%s",
+				utils.FindContainerPort(rayContainer, utils.ServingPortName, utils.DefaultServingPort), utils.RayServeProxyHealthPath)
+			endpoints = append(endpoints, rayServeProxyHealthEndpoint)
+			rayContainer.ReadinessProbe.Exec = &corev1.ExecAction{Command: generateHTTPHealthProbeCommand(utils.DefaultReadinessProbeTimeoutSeconds, endpoints...)}
 		}
 	}
 }
@@ -305,10 +917,10 @@ func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType r
 func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNodeType rayv1.RayNodeType, rayStartParams map[string]string, headPort string, enableRayAutoscaler *bool, creatorCRDType utils.CRDType, fqdnRayIP string) (aPod corev1.Pod) {
 	log := ctrl.LoggerFrom(ctx)
 
-	// For Worker Pod: Traffic readiness is determined by the readiness probe.
-	// Therefore, the RayClusterServingServiceLabelKey label is not utilized and should always be set to true.
-	// For Head Pod: Traffic readiness is determined by the value of the RayClusterServingServiceLabelKey label.
-	// Initially, set the label to false and let the rayservice controller to manage its value.
+	// Traffic readiness is determined by the value of the RayClusterServingServiceLabelKey label, which the Serve
+	// service selects, and by the readiness probe. For Worker Pod: the readiness probe checks the Serve proxy, so set the
+	// label to true and let the rayservice controller set it to false once the Serve proxy is unhealthy or the Pod is
+	// drained. For Head Pod: initially, set the label to false and let the rayservice controller manage its value.
 	if creatorCRDType == utils.RayServiceCRD {
 		podTemplateSpec.Labels[utils.RayClusterServingServiceLabelKey] = utils.EnableRayClusterServingServiceTrue
 		if rayNodeType == rayv1.HeadNode {
@@ -326,7 +938,9 @@ func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNo
 	}
 
 	// Add /dev/shm volumeMount for the object store to avoid performance degradation.
-	addEmptyDir(ctx, &pod.Spec.Containers[utils.RayContainerIndex], &pod, SharedMemoryVolumeName, SharedMemoryVolumeMountPath, corev1.StorageMediumMemory)
+	if podTemplateSpec.Annotations[utils.RayDisableShmVolumeAnnotationKey] != "true" {
+		addSharedMemoryVolume(ctx, &pod, podTemplateSpec.Annotations[utils.RayShmSizeAnnotationKey])
+	}
 	if rayNodeType == rayv1.HeadNode && enableRayAutoscaler != nil && *enableRayAutoscaler {
 		// The Ray autoscaler writes logs which are read by the Ray head.
 		// We need a shared log volume to enable this information flow.
@@ -336,6 +950,13 @@ func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNo
 		addEmptyDir(ctx, &pod.Spec.Containers[utils.RayContainerIndex], &pod, RayLogVolumeName, RayLogVolumeMountPath, corev1.StorageMediumDefault)
 		addEmptyDir(ctx, &pod.Spec.Containers[autoscalerContainerIndex], &pod, RayLogVolumeName, RayLogVolumeMountPath, corev1.StorageMediumDefault)
 	}
+	// The log-shipping and log-archiving sidecars read the logs written by the Ray container.
+	for _, containerName := range []string{LogSidecarContainerName, LogArchiverContainerName} {
+		if containerIndex := getContainerIndexByName(pod, containerName); containerIndex != -1 {
+			addEmptyDir(ctx, &pod.Spec.Containers[utils.RayContainerIndex], &pod, RayLogVolumeName, RayLogVolumeMountPath, corev1.StorageMediumDefault)
+			addEmptyDir(ctx, &pod.Spec.Containers[containerIndex], &pod, RayLogVolumeName, RayLogVolumeMountPath, corev1.StorageMediumDefault)
+		}
+	}
 
 	var cmd, args string
 	if len(pod.Spec.Containers[utils.RayContainerIndex].Command) > 0 {
@@ -345,6 +966,13 @@ func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNo
 		cmd += convertCmdToString(pod.Spec.Containers[utils.RayContainerIndex].Args)
 	}
 
+	if value, ok := podTemplateSpec.Annotations[rayv1.ObjectStoreMemoryPercentAnnotationKey]; ok {
+		rayStartParams = setObjectStoreMemoryFromPercent(ctx, pod, rayStartParams, value)
+	}
+	if podTemplateSpec.Annotations[utils.RayServiceMeshModeAnnotationKey] == string(rayv1.IstioServiceMesh) {
+		rayStartParams = istioRayStartParams(rayStartParams)
+	}
+
 	// Increase the open file descriptor limit of the `ray start` process and its child processes to 65536.
 	ulimitCmd := "ulimit -n 65536"
 	// Generate the `ray start` command.
@@ -359,6 +987,10 @@ func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNo
 	// TODO (kevin85421): Consider removing the check for the "ray start" string in the future.
 	if !isOverwriteRayContainerCmd && !strings.Contains(cmd, "ray start") {
 		generatedCmd := fmt.Sprintf("%s; %s", ulimitCmd, rayStartCmd)
+		if podTemplateSpec.Annotations[utils.RayServiceMeshModeAnnotationKey] == string(rayv1.IstioServiceMesh) {
+			// Ray nodes cannot reach each other until the sidecar proxy is ready.
+			generatedCmd = fmt.Sprintf("%s; %s", generateWaitForIstioProxyCommand(), generatedCmd)
+		}
 		log.Info("BuildPod", "rayNodeType", rayNodeType, "generatedCmd", generatedCmd)
 		// replacing the old command
 		pod.Spec.Containers[utils.RayContainerIndex].Command = []string{"/bin/bash", "-lc", "--"}
@@ -377,6 +1009,7 @@ func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNo
 		setInitContainerEnvVars(&pod.Spec.InitContainers[index], fqdnRayIP)
 	}
 	setContainerEnvVars(&pod, rayNodeType, rayStartParams, fqdnRayIP, headPort, rayStartCmd, creatorCRDType)
+	substituteTemplateVariables(&pod)
 
 	// Inject probes into the Ray containers if the user has not explicitly disabled them.
 	// The feature flag `ENABLE_PROBES_INJECTION` will be removed if this feature is stable enough.
@@ -387,13 +1020,56 @@ func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNo
 		// play a crucial role in KubeRay health checks. Without them, certain failures,
 		// such as the Raylet process crashing, may go undetected.
 		initLivenessAndReadinessProbe(&pod.Spec.Containers[utils.RayContainerIndex], rayNodeType, creatorCRDType)
+		// Restart the autoscaler if it is wedged, as the worker groups are no longer scaled otherwise.
+		if containerIndex := getContainerIndexByName(pod, AutoscalerContainerName); containerIndex != -1 {
+			initAutoscalerLivenessProbe(&pod.Spec.Containers[containerIndex])
+		}
 	}
 
 	return pod
 }
 
-// BuildAutoscalerContainer builds a Ray autoscaler container which can be appended to the head pod.
-func BuildAutoscalerContainer(autoscalerImage string) corev1.Container {
+// substituteTemplateVariables replaces the template variables in the env values and args of all containers
+// with values unique to the Pod. The head Pod always uses "0" as its replica index.
+func substituteTemplateVariables(pod *corev1.Pod) {
+	replicaIndex := "0"
+	if index, ok := pod.Labels[utils.RayWorkerReplicaIndexKey]; ok {
+		replicaIndex = index
+	}
+	replacer := strings.NewReplacer(
+		rayv1.ClusterNameTemplateVariable, pod.Labels[utils.RayClusterLabelKey],
+		rayv1.NamespaceTemplateVariable, pod.Namespace,
+		rayv1.GroupNameTemplateVariable, pod.Labels[utils.RayNodeGroupLabelKey],
+		rayv1.ReplicaIndexTemplateVariable, replicaIndex,
+	)
+
+	// The slices may still be shared with the RayCluster's Pod template, so they are copied instead of modified in place.
+	substitute := func(containers []corev1.Container) {
+		for i := range containers {
+			args := make([]string, 0, len(containers[i].Args))
+			for _, arg := range containers[i].Args {
+				args = append(args, replacer.Replace(arg))
+			}
+			if len(args) > 0 {
+				containers[i].Args = args
+			}
+			env := make([]corev1.EnvVar, 0, len(containers[i].Env))
+			for _, envVar := range containers[i].Env {
+				envVar.Value = replacer.Replace(envVar.Value)
+				env = append(env, envVar)
+			}
+			if len(env) > 0 {
+				containers[i].Env = env
+			}
+		}
+	}
+	substitute(pod.Spec.InitContainers)
+	substitute(pod.Spec.Containers)
+}
+
+// BuildAutoscalerContainer builds a Ray autoscaler container which can be appended to the head pod. If autoscalingV2
+// is true, `ray kuberay-autoscaler` runs the autoscaler v2.
+func BuildAutoscalerContainer(autoscalerImage string, autoscalingV2 bool) corev1.Container {
 	container := corev1.Container{
 		Name:            AutoscalerContainerName,
 		Image:           autoscalerImage,
@@ -447,9 +1123,29 @@ func BuildAutoscalerContainer(autoscalerImage string) corev1.Container {
 			},
 		},
 	}
+	if autoscalingV2 {
+		setAutoscalerV2EnvVar(&container)
+	}
 	return container
 }
 
+// setAutoscalerV2EnvVar enables the autoscaler v2 in the container unless users already set RAY_enable_autoscaler_v2.
+func setAutoscalerV2EnvVar(container *corev1.Container) {
+	if !utils.EnvVarExists(utils.RAY_ENABLE_AUTOSCALER_V2, container.Env) {
+		container.Env = append(container.Env, corev1.EnvVar{Name: utils.RAY_ENABLE_AUTOSCALER_V2, Value: "1"})
+	}
+}
+
+// setAutoscalerV2RestartPolicy stops Kubernetes from restarting the containers of a Ray Pod with the autoscaler v2,
+// which identifies each Ray node with its Pod, so that a failed Ray node is replaced by a new Pod. A restart policy set
+// by users takes precedence. The autoscaler container is not restarted either, unless UseNativeSidecars makes it a
+// native sidecar container.
+func setAutoscalerV2RestartPolicy(podTemplate *corev1.PodTemplateSpec) {
+	if podTemplate.Spec.RestartPolicy == "" {
+		podTemplate.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+}
+
 // Merge the user overrides from autoscalerOptions into the autoscaler container config.
 func mergeAutoscalerOverrides(autoscalerContainer *corev1.Container, autoscalerOptions *rayv1.AutoscalerOptions) {
 	if autoscalerOptions != nil {
@@ -462,6 +1158,12 @@ func mergeAutoscalerOverrides(autoscalerContainer *corev1.Container, autoscalerO
 		if autoscalerOptions.ImagePullPolicy != nil {
 			autoscalerContainer.ImagePullPolicy = *autoscalerOptions.ImagePullPolicy
 		}
+		if len(autoscalerOptions.Command) > 0 {
+			autoscalerContainer.Command = autoscalerOptions.Command
+		}
+		if len(autoscalerOptions.Args) > 0 {
+			autoscalerContainer.Args = autoscalerOptions.Args
+		}
 		if len(autoscalerOptions.Env) > 0 {
 			autoscalerContainer.Env = append(autoscalerContainer.Env, autoscalerOptions.Env...)
 		}
@@ -497,6 +1199,37 @@ func getAutoscalerContainerIndex(pod corev1.Pod) (autoscalerContainerIndex int)
 	panic("Autoscaler container not found!")
 }
 
+// getContainerIndexByName returns the index of the container with the given name, or -1 if there is none.
+func getContainerIndexByName(pod corev1.Pod, containerName string) int {
+	for i, container := range pod.Spec.Containers {
+		if container.Name == containerName {
+			return i
+		}
+	}
+	return -1
+}
+
+// UseNativeSidecars moves the autoscaler and log sidecars injected by KubeRay from the containers to the init containers
+// with `restartPolicy: Always`. Kubernetes starts these native sidecars before the Ray container and terminates them
+// after it, so the log sidecars ship the logs written by the Ray container while it shuts down.
+func UseNativeSidecars(pod *corev1.Pod) {
+	sidecarNames := []string{AutoscalerContainerName, LogSidecarContainerName, LogArchiverContainerName}
+	var sidecars, containers []corev1.Container
+	for i, container := range pod.Spec.Containers {
+		if i != utils.RayContainerIndex && utils.Contains(sidecarNames, container.Name) {
+			container.RestartPolicy = ptr.To(corev1.ContainerRestartPolicyAlways)
+			sidecars = append(sidecars, container)
+		} else {
+			containers = append(containers, container)
+		}
+	}
+	if len(sidecars) == 0 {
+		return
+	}
+	pod.Spec.Containers = containers
+	pod.Spec.InitContainers = append(sidecars, pod.Spec.InitContainers...)
+}
+
 // labelPod returns the labels for selecting the resources
 // belonging to the given RayCluster CR name.
 func labelPod(rayNodeType rayv1.RayNodeType, rayClusterName string, groupName string, labels map[string]string) (ret map[string]string) {
@@ -770,7 +1503,14 @@ func convertParamMap(rayStartParams map[string]string) (s string) {
 	// specialParameterOptions' arguments can be true or false.
 	// For example, --log-color can be auto | false | true.
 	specialParameterOptions := []string{"log-color", "include-dashboard"}
-	for option, argument := range rayStartParams {
+	// The flags are sorted so that the command of the same Pod template does not change across reconciliations.
+	options := make([]string, 0, len(rayStartParams))
+	for option := range rayStartParams {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+	for _, option := range options {
+		argument := rayStartParams[option]
 		if utils.Contains([]string{"true", "false"}, strings.ToLower(argument)) && !utils.Contains(specialParameterOptions, option) {
 			// booleanOptions: do not require any argument. Essentially represent boolean on-off switches.
 			if strings.ToLower(argument) == "true" {
@@ -784,6 +1524,88 @@ func convertParamMap(rayStartParams map[string]string) (s string) {
 	return flags.String()
 }
 
+// setObjectStoreMemoryFromPercent returns a copy of rayStartParams whose `object-store-memory` is the given percentage
+// of the memory limit, or request, of the Ray container. The object store is capped at the size limit of the /dev/shm
+// volume of the Ray container, because Ray falls back to a much slower disk-backed object store if /dev/shm is too
+// small. `object-store-memory` already set in rayStartParams wins.
+func setObjectStoreMemoryFromPercent(ctx context.Context, pod corev1.Pod, rayStartParams map[string]string, value string) map[string]string {
+	log := ctrl.LoggerFrom(ctx)
+	if _, ok := rayStartParams[ObjectStoreMemoryKey]; ok {
+		return rayStartParams
+	}
+	percent, err := strconv.Atoi(value)
+	if err != nil || percent < 1 || percent > 100 {
+		log.Info("Ignoring invalid object store memory percentage", "annotation", rayv1.ObjectStoreMemoryPercentAnnotationKey, "value", value)
+		return rayStartParams
+	}
+	rayContainer := pod.Spec.Containers[utils.RayContainerIndex]
+	memory, ok := rayContainer.Resources.Limits[corev1.ResourceMemory]
+	if !ok {
+		memory, ok = rayContainer.Resources.Requests[corev1.ResourceMemory]
+	}
+	if !ok {
+		log.Info("Ignoring the object store memory percentage because the Ray container has no memory limit or request")
+		return rayStartParams
+	}
+
+	objectStoreMemory := memory.Value() * int64(percent) / 100
+	if shmSize := getSharedMemorySizeLimit(pod, rayContainer); shmSize != nil && objectStoreMemory > shmSize.Value() {
+		log.Info("The object store does not fit in /dev/shm, capping it at the size of /dev/shm",
+			"objectStoreMemory", objectStoreMemory, "sharedMemorySize", shmSize.Value())
+		objectStoreMemory = shmSize.Value()
+	}
+
+	params := make(map[string]string, len(rayStartParams)+1)
+	for k, v := range rayStartParams {
+		params[k] = v
+	}
+	params[ObjectStoreMemoryKey] = strconv.FormatInt(objectStoreMemory, 10)
+	return params
+}
+
+// getSharedMemorySizeLimit returns the size limit of the emptyDir volume mounted at /dev/shm in the container, or nil
+// if the volume is not an emptyDir volume or has no size limit.
+func getSharedMemorySizeLimit(pod corev1.Pod, container corev1.Container) *resource.Quantity {
+	for _, volumeMount := range container.VolumeMounts {
+		if volumeMount.MountPath != SharedMemoryVolumeMountPath {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.Name == volumeMount.Name && volume.EmptyDir != nil {
+				return volume.EmptyDir.SizeLimit
+			}
+		}
+	}
+	return nil
+}
+
+// addSharedMemoryVolume mounts a memory-backed emptyDir volume at /dev/shm in the Ray container. The size limit of the
+// volume is shmSize if it is a valid quantity, and the memory request, or limit, of the Ray container otherwise.
+func addSharedMemoryVolume(ctx context.Context, pod *corev1.Pod, shmSize string) {
+	log := ctrl.LoggerFrom(ctx)
+	rayContainer := &pod.Spec.Containers[utils.RayContainerIndex]
+	if checkIfVolumeMounted(rayContainer, SharedMemoryVolumeMountPath) {
+		log.Info("volume already mounted", "volume", SharedMemoryVolumeName, "path", SharedMemoryVolumeMountPath)
+		return
+	}
+	// A volume named like the /dev/shm volume in the Pod template is mounted as is.
+	userVolume := checkIfVolumeExists(pod, SharedMemoryVolumeName)
+	addEmptyDir(ctx, rayContainer, pod, SharedMemoryVolumeName, SharedMemoryVolumeMountPath, corev1.StorageMediumMemory)
+	if shmSize == "" || userVolume {
+		return
+	}
+	sizeLimit, err := resource.ParseQuantity(shmSize)
+	if err != nil {
+		log.Info("Ignoring invalid /dev/shm size", "annotation", utils.RayShmSizeAnnotationKey, "value", shmSize)
+		return
+	}
+	for i := range pod.Spec.Volumes {
+		if pod.Spec.Volumes[i].Name == SharedMemoryVolumeName {
+			pod.Spec.Volumes[i].EmptyDir.SizeLimit = &sizeLimit
+		}
+	}
+}
+
 // addEmptyDir adds an emptyDir volume to the pod and a corresponding volume mount to the container
 // Used for a /dev/shm memory mount for object store and for a /tmp/ray disk mount for autoscaler logs.
 func addEmptyDir(ctx context.Context, container *corev1.Container, pod *corev1.Pod, volumeName string, volumeMountPath string, storageMedium corev1.StorageMedium) {
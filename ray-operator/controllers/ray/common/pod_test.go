@@ -14,6 +14,7 @@ import (
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
@@ -215,6 +216,22 @@ var autoscalerContainer = corev1.Container{
 			Name:      "ray-logs",
 		},
 	},
+	LivenessProbe: &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{
+					"python3",
+					"-c",
+					`import os, sys, time; sys.exit(time.time() - os.path.getmtime("/tmp/ray/session_latest/logs/monitor.log") > 60)`,
+				},
+			},
+		},
+		InitialDelaySeconds: 30,
+		TimeoutSeconds:      5,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	},
 }
 
 var trueFlag = true
@@ -568,6 +585,432 @@ func TestBuildPod_WithGcsFtEnabled(t *testing.T) {
 	checkContainerEnv(t, rayContainer, utils.RAY_GCS_RPC_SERVER_RECONNECT_TIMEOUT_S, "120")
 }
 
+func TestBuildPod_WithObjectStoreMemoryPercent(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Template.Annotations = map[string]string{
+		rayv1.ObjectStoreMemoryPercentAnnotationKey: "30",
+	}
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.GetCRDType(""), "")
+
+	// 30% of the 1Gi memory limit of the Ray container.
+	rayContainer := pod.Spec.Containers[utils.RayContainerIndex]
+	assert.Contains(t, rayContainer.Args[0], "--object-store-memory=322122547")
+	// The RayStartParams of the head group are not mutated.
+	assert.NotContains(t, cluster.Spec.HeadGroupSpec.RayStartParams, ObjectStoreMemoryKey)
+
+	// The object store is capped at the size of a user-provided /dev/shm volume.
+	shmSizeLimit := resource.MustParse("100Mi")
+	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	podTemplateSpec.Spec.Volumes = append(podTemplateSpec.Spec.Volumes, corev1.Volume{
+		Name: "shm",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory, SizeLimit: &shmSizeLimit},
+		},
+	})
+	podTemplateSpec.Spec.Containers[utils.RayContainerIndex].VolumeMounts = append(podTemplateSpec.Spec.Containers[utils.RayContainerIndex].VolumeMounts,
+		corev1.VolumeMount{Name: "shm", MountPath: SharedMemoryVolumeMountPath})
+	// BuildPod writes the `ray start` command into the containers of the Pod template, so each Pod is built from a copy.
+	pod = BuildPod(ctx, *podTemplateSpec.DeepCopy(), rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.GetCRDType(""), "")
+	rayContainer = pod.Spec.Containers[utils.RayContainerIndex]
+	assert.Contains(t, rayContainer.Args[0], "--object-store-memory=104857600")
+
+	// `object-store-memory` set in RayStartParams wins.
+	params := map[string]string{ObjectStoreMemoryKey: "200000000"}
+	pod = BuildPod(ctx, *podTemplateSpec.DeepCopy(), rayv1.HeadNode, params, "6379", nil, utils.GetCRDType(""), "")
+	rayContainer = pod.Spec.Containers[utils.RayContainerIndex]
+	assert.Contains(t, rayContainer.Args[0], "--object-store-memory=200000000")
+}
+
+func TestBuildPod_WithShmOptions(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	shmSize := resource.MustParse("2Gi")
+	cluster.Spec.HeadGroupSpec.ShmSize = &shmSize
+	cluster.Spec.WorkerGroupSpecs[0].DisableShmVolume = ptr.To(true)
+
+	// The /dev/shm volume of the head Pod is sized to ShmSize instead of the memory of the Ray container.
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.GetCRDType(""), "")
+	assert.True(t, checkIfVolumeMounted(&pod.Spec.Containers[utils.RayContainerIndex], SharedMemoryVolumeMountPath))
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == SharedMemoryVolumeName {
+			assert.Equal(t, shmSize.String(), volume.EmptyDir.SizeLimit.String())
+		}
+	}
+
+	// The worker Pods do not get a /dev/shm volume.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podName = cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	pod = BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, "6379", nil, utils.GetCRDType(""), fqdnRayIP)
+	assert.False(t, checkIfVolumeMounted(&pod.Spec.Containers[utils.RayContainerIndex], SharedMemoryVolumeMountPath))
+	assert.False(t, checkIfVolumeExists(&pod, SharedMemoryVolumeName))
+}
+
+func TestUseNativeSidecars(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = &trueFlag
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	podTemplateSpec.Spec.InitContainers = []corev1.Container{{Name: "init"}}
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", &trueFlag, utils.GetCRDType(""), "")
+	rayContainer := pod.Spec.Containers[utils.RayContainerIndex]
+
+	UseNativeSidecars(&pod)
+	assert.Equal(t, []corev1.Container{rayContainer}, pod.Spec.Containers)
+	assert.Len(t, pod.Spec.InitContainers, 2)
+	assert.Equal(t, AutoscalerContainerName, pod.Spec.InitContainers[0].Name)
+	assert.Equal(t, ptr.To(corev1.ContainerRestartPolicyAlways), pod.Spec.InitContainers[0].RestartPolicy)
+	assert.Equal(t, "init", pod.Spec.InitContainers[1].Name)
+	assert.Nil(t, pod.Spec.InitContainers[1].RestartPolicy)
+
+	// The Pod is left unchanged when it has no sidecars.
+	UseNativeSidecars(&pod)
+	assert.Equal(t, []corev1.Container{rayContainer}, pod.Spec.Containers)
+	assert.Len(t, pod.Spec.InitContainers, 2)
+}
+
+func TestBuildPod_WithIstioServiceMesh(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	cluster.Spec.ServiceMeshMode = ptr.To(rayv1.IstioServiceMesh)
+	// Annotations set by users should not be overwritten.
+	cluster.Spec.WorkerGroupSpecs[0].Template.Annotations = map[string]string{
+		utils.IstioProxyConfigAnnotationKey: "user-defined",
+	}
+
+	// Build a head Pod.
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.GetCRDType(""), "")
+
+	assert.Equal(t, string(rayv1.IstioServiceMesh), pod.Annotations[utils.RayServiceMeshModeAnnotationKey])
+	assert.Equal(t, `{"holdApplicationUntilProxyStarts": true}`, pod.Annotations[utils.IstioProxyConfigAnnotationKey])
+	assert.Equal(t, "6379,8076,8077,8078", pod.Annotations[utils.IstioExcludeInboundPortsAnnotationKey])
+	assert.Equal(t, "6379,8076,8077,8078", pod.Annotations[utils.IstioExcludeOutboundPortsAnnotationKey])
+	// Only the declared ports are intercepted, so that the worker port range is not.
+	assert.Equal(t, "8080", pod.Annotations[utils.IstioIncludeInboundPortsAnnotationKey])
+	assert.Contains(t, pod.Spec.Containers[utils.RayContainerIndex].Args[0], "--node-manager-port=8077")
+	// The pinned ports are not written into the RayStartParams of the spec.
+	assert.NotContains(t, cluster.Spec.HeadGroupSpec.RayStartParams, "node-manager-port")
+	assert.True(t, strings.HasPrefix(pod.Spec.Containers[utils.RayContainerIndex].Args[0], generateWaitForIstioProxyCommand()))
+
+	// Build a worker Pod.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.RayStartParams["object-manager-port"] = "9000"
+	podName = cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	pod = BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, "6379", nil, utils.GetCRDType(""), fqdnRayIP)
+
+	assert.Equal(t, "user-defined", pod.Annotations[utils.IstioProxyConfigAnnotationKey])
+	assert.Equal(t, "6379,9000,8077,8078", pod.Annotations[utils.IstioExcludeInboundPortsAnnotationKey])
+	// The init container cannot reach the GCS server before the sidecar proxy starts.
+	assert.Empty(t, pod.Spec.InitContainers)
+	assert.True(t, strings.HasPrefix(pod.Spec.Containers[utils.RayContainerIndex].Args[0], generateWaitForIstioProxyCommand()))
+	assert.Contains(t, pod.Spec.Containers[utils.RayContainerIndex].Args[0], "--address=raycluster-sample-head-svc.default.svc.cluster.local:6379")
+}
+
+func TestDefaultPodTemplate_SafeToEvict(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	headPodName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	workerPodName := cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+
+	// The head Pod is not safe to evict by default, and the annotation is not added to worker Pods.
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, *cluster.Spec.HeadGroupSpec.DeepCopy(), headPodName, "6379")
+	assert.Equal(t, "false", podTemplateSpec.Annotations[utils.SafeToEvictAnnotationKey])
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, *worker.DeepCopy(), workerPodName, fqdnRayIP, "6379")
+	assert.NotContains(t, podTemplateSpec.Annotations, utils.SafeToEvictAnnotationKey)
+
+	// Users can opt out for the head Pod and opt in for worker Pods.
+	headSpec := cluster.Spec.HeadGroupSpec.DeepCopy()
+	headSpec.SafeToEvict = ptr.To(true)
+	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster, *headSpec, headPodName, "6379")
+	assert.Equal(t, "true", podTemplateSpec.Annotations[utils.SafeToEvictAnnotationKey])
+	workerSpec := worker.DeepCopy()
+	workerSpec.SafeToEvict = ptr.To(false)
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, *workerSpec, workerPodName, fqdnRayIP, "6379")
+	assert.Equal(t, "false", podTemplateSpec.Annotations[utils.SafeToEvictAnnotationKey])
+
+	// Annotations set by users should not be overwritten.
+	headSpec = cluster.Spec.HeadGroupSpec.DeepCopy()
+	headSpec.Template.Annotations = map[string]string{utils.SafeToEvictAnnotationKey: "true"}
+	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster, *headSpec, headPodName, "6379")
+	assert.Equal(t, "true", podTemplateSpec.Annotations[utils.SafeToEvictAnnotationKey])
+}
+
+func TestDefaultWorkerPodTemplate_WithTopologySpread(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podName := cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+
+	// Spread across hosts by default.
+	workerSpec := worker.DeepCopy()
+	workerSpec.TopologySpread = &rayv1.TopologySpreadOptions{}
+	podTemplateSpec := DefaultWorkerPodTemplate(ctx, *cluster, *workerSpec, podName, fqdnRayIP, "6379")
+	assert.Equal(t, []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       corev1.LabelHostname,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				utils.RayClusterLabelKey:   "raycluster-sample",
+				utils.RayNodeGroupLabelKey: "small-group",
+			},
+		},
+	}}, podTemplateSpec.Spec.TopologySpreadConstraints)
+
+	// Spread across zones strictly.
+	workerSpec = worker.DeepCopy()
+	workerSpec.TopologySpread = &rayv1.TopologySpreadOptions{
+		TopologyKey:       corev1.LabelTopologyZone,
+		MaxSkew:           ptr.To[int32](2),
+		WhenUnsatisfiable: ptr.To(corev1.DoNotSchedule),
+	}
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, *workerSpec, podName, fqdnRayIP, "6379")
+	assert.Len(t, podTemplateSpec.Spec.TopologySpreadConstraints, 1)
+	constraint := podTemplateSpec.Spec.TopologySpreadConstraints[0]
+	assert.Equal(t, corev1.LabelTopologyZone, constraint.TopologyKey)
+	assert.Equal(t, int32(2), constraint.MaxSkew)
+	assert.Equal(t, corev1.DoNotSchedule, constraint.WhenUnsatisfiable)
+
+	// Constraints set by users should not be overwritten.
+	workerSpec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		{MaxSkew: 5, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.ScheduleAnyway},
+	}
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, *workerSpec, podName, fqdnRayIP, "6379")
+	assert.Len(t, podTemplateSpec.Spec.TopologySpreadConstraints, 1)
+	assert.Equal(t, int32(5), podTemplateSpec.Spec.TopologySpreadConstraints[0].MaxSkew)
+}
+
+func TestDefaultWorkerPodTemplate_WithGPU(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podName := cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+	shares := resource.MustParse("0.5")
+
+	tests := map[string]struct {
+		gpu          rayv1.GPUOptions
+		resourceName corev1.ResourceName
+		quantity     string
+		numGPUs      string
+	}{
+		"whole GPUs": {
+			gpu:          rayv1.GPUOptions{Count: ptr.To[int32](2)},
+			resourceName: "nvidia.com/gpu",
+			quantity:     "2",
+			numGPUs:      "2",
+		},
+		"MIG devices": {
+			gpu:          rayv1.GPUOptions{MIGProfile: "1g.5gb"},
+			resourceName: "nvidia.com/mig-1g.5gb",
+			quantity:     "1",
+			numGPUs:      "1",
+		},
+		"half of a time-sliced GPU": {
+			gpu:          rayv1.GPUOptions{Shares: &shares, ReplicasPerGPU: ptr.To[int32](4), ResourceName: "nvidia.com/gpu.shared"},
+			resourceName: "nvidia.com/gpu.shared",
+			quantity:     "2",
+			// `ray start` only accepts whole GPUs, so Ray does not see the shared GPU.
+			numGPUs: "0",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			workerSpec := worker.DeepCopy()
+			workerSpec.GPU = &tc.gpu
+			podTemplateSpec := DefaultWorkerPodTemplate(ctx, *cluster, *workerSpec, podName, fqdnRayIP, "6379")
+			resources := podTemplateSpec.Spec.Containers[utils.RayContainerIndex].Resources
+			limit, request := resources.Limits[tc.resourceName], resources.Requests[tc.resourceName]
+			assert.Equal(t, tc.quantity, limit.String())
+			assert.Equal(t, tc.quantity, request.String())
+			// The resources of the RayCluster's Pod template must not be modified.
+			assert.True(t, equality.Semantic.DeepEqual(worker.Template, workerSpec.Template))
+
+			rayStartParams := AddGPUNumGPUs(workerSpec.RayStartParams, workerSpec.GPU)
+			assert.Equal(t, tc.numGPUs, rayStartParams["num-gpus"])
+			// Ray does not hide the GPU it does not see from the tasks.
+			assert.Equal(t, tc.numGPUs == "0", utils.EnvVarExists("RAY_EXPERIMENTAL_NOSET_CUDA_VISIBLE_DEVICES", podTemplateSpec.Spec.Containers[utils.RayContainerIndex].Env))
+		})
+	}
+
+	// `num-gpus` set by users wins.
+	rayStartParams := AddGPUNumGPUs(map[string]string{"num-gpus": "4"}, &rayv1.GPUOptions{Count: ptr.To[int32](1)})
+	assert.Equal(t, "4", rayStartParams["num-gpus"])
+}
+
+func TestBuildPod_WithTemplateVariables(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	templateEnv := corev1.EnvVar{Name: "TEMPLATE_ENV", Value: "{{cluster.name}}/{{cluster.namespace}}/{{group.name}}-{{replica.index}}"}
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Env = append(
+		cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Env, templateEnv)
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].Env = append(
+		cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].Env, templateEnv)
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers = append(cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers, corev1.Container{
+		Name: "sidecar",
+		Args: []string{"--peer={{cluster.name}}-{{group.name}}-{{replica.index}}"},
+	})
+
+	// Build a head Pod. The replica index of the head Pod is always 0.
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.GetCRDType(""), "")
+	checkContainerEnv(t, pod.Spec.Containers[utils.RayContainerIndex], "TEMPLATE_ENV", "raycluster-sample/default/headgroup-0")
+
+	// Build a worker Pod.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podName = cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	podTemplateSpec.Labels[utils.RayWorkerReplicaIndexKey] = "3"
+	pod = BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, "6379", nil, utils.GetCRDType(""), fqdnRayIP)
+	checkContainerEnv(t, pod.Spec.Containers[utils.RayContainerIndex], "TEMPLATE_ENV", "raycluster-sample/default/small-group-3")
+	assert.Equal(t, []string{"--peer=raycluster-sample-small-group-3"}, pod.Spec.Containers[1].Args)
+
+	// The RayCluster's Pod template should not be modified.
+	assert.Equal(t, templateEnv, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].Env[1])
+	assert.Equal(t, "--peer={{cluster.name}}-{{group.name}}-{{replica.index}}", cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[1].Args[0])
+}
+
+func TestBuildPod_WithLogSidecar(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	cluster.Spec.Logging = &rayv1.LoggingOptions{
+		Sidecar: &rayv1.LogSidecarOptions{
+			Image:         "fluent/fluent-bit:3.0",
+			ConfigMapName: "fluent-bit-config",
+			Output:        "s3://ray-logs/raycluster-sample",
+		},
+	}
+
+	checkLogSidecar := func(pod corev1.Pod) {
+		sidecarIndex := getContainerIndexByName(pod, LogSidecarContainerName)
+		assert.NotEqual(t, -1, sidecarIndex)
+		sidecar := pod.Spec.Containers[sidecarIndex]
+		assert.Equal(t, "fluent/fluent-bit:3.0", sidecar.Image)
+		checkContainerEnv(t, sidecar, utils.RAY_LOG_OUTPUT, "s3://ray-logs/raycluster-sample")
+		assert.True(t, checkIfVolumeMounted(&sidecar, LogSidecarConfigMountPath))
+		assert.True(t, checkIfVolumeMounted(&sidecar, RayLogVolumeMountPath))
+		assert.True(t, checkIfVolumeMounted(&pod.Spec.Containers[utils.RayContainerIndex], RayLogVolumeMountPath))
+		assert.True(t, checkIfVolumeExists(&pod, LogSidecarConfigVolumeName))
+		assert.True(t, checkIfVolumeExists(&pod, RayLogVolumeName))
+	}
+
+	// Build a head Pod.
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.GetCRDType(""), "")
+	checkLogSidecar(pod)
+
+	// Build a worker Pod.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podName = cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	pod = BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, "6379", nil, utils.GetCRDType(""), fqdnRayIP)
+	checkLogSidecar(pod)
+}
+
+func TestBuildPod_WithLogArchive(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	cluster.Spec.Logging = &rayv1.LoggingOptions{
+		Archive: &rayv1.LogArchiveOptions{
+			Bucket:               "s3://my-bucket/ray-logs/",
+			CredentialsSecretRef: &corev1.LocalObjectReference{Name: "aws-credentials"},
+		},
+	}
+
+	// Build a worker Pod archiving logs to S3.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podName := cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec := DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, "6379", nil, utils.GetCRDType(""), fqdnRayIP)
+
+	archiverIndex := getContainerIndexByName(pod, LogArchiverContainerName)
+	assert.NotEqual(t, -1, archiverIndex)
+	archiver := pod.Spec.Containers[archiverIndex]
+	assert.Equal(t, DefaultLogArchiverS3Image, archiver.Image)
+	preStopCmd := strings.Join(archiver.Lifecycle.PreStop.Exec.Command, " ")
+	assert.Contains(t, preStopCmd, "aws s3 cp /tmp/ray-logs.tar.gz s3://my-bucket/ray-logs/$RAY_CLUSTER_NAME/$POD_NAME.tar.gz")
+	assert.Equal(t, "aws-credentials", archiver.EnvFrom[0].SecretRef.Name)
+	assert.True(t, checkIfVolumeMounted(&archiver, RayLogVolumeMountPath))
+	assert.True(t, checkIfVolumeMounted(&pod.Spec.Containers[utils.RayContainerIndex], RayLogVolumeMountPath))
+
+	// Build a head Pod archiving logs to GCS.
+	cluster.Spec.Logging.Archive = &rayv1.LogArchiveOptions{Bucket: "gs://my-bucket"}
+	podName = strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod = BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.GetCRDType(""), "")
+
+	archiverIndex = getContainerIndexByName(pod, LogArchiverContainerName)
+	assert.NotEqual(t, -1, archiverIndex)
+	archiver = pod.Spec.Containers[archiverIndex]
+	assert.Equal(t, DefaultLogArchiverGCSImage, archiver.Image)
+	assert.Contains(t, strings.Join(archiver.Lifecycle.PreStop.Exec.Command, " "), "gsutil cp /tmp/ray-logs.tar.gz gs://my-bucket/$RAY_CLUSTER_NAME/$POD_NAME.tar.gz")
+	assert.Empty(t, archiver.EnvFrom)
+}
+
+func TestBuildPod_WithServiceAccountTokens(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = &trueFlag
+	cluster.Spec.ServiceAccountTokens = []rayv1.ServiceAccountToken{
+		{Name: "vault", Audience: "vault", ExpirationSeconds: ptr.To[int64](600)},
+	}
+
+	// Build a head Pod. The token is mounted into both the Ray container and the autoscaler container.
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", &trueFlag, utils.GetCRDType(""), "")
+
+	assert.True(t, checkIfVolumeExists(&pod, "sa-token-vault"))
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == "sa-token-vault" {
+			projection := volume.Projected.Sources[0].ServiceAccountToken
+			assert.Equal(t, "vault", projection.Audience)
+			assert.Equal(t, int64(600), *projection.ExpirationSeconds)
+			assert.Equal(t, "token", projection.Path)
+		}
+	}
+	assert.True(t, checkIfVolumeMounted(&pod.Spec.Containers[utils.RayContainerIndex], "/var/run/secrets/tokens/vault"))
+	autoscalerIndex := getContainerIndexByName(pod, AutoscalerContainerName)
+	assert.NotEqual(t, -1, autoscalerIndex)
+	assert.True(t, checkIfVolumeMounted(&pod.Spec.Containers[autoscalerIndex], "/var/run/secrets/tokens/vault"))
+	// The RayCluster spec is not mutated.
+	headTemplate := cluster.Spec.HeadGroupSpec.Template
+	assert.False(t, checkIfVolumeExists(&corev1.Pod{Spec: headTemplate.Spec}, "sa-token-vault"))
+	assert.False(t, checkIfVolumeMounted(&headTemplate.Spec.Containers[utils.RayContainerIndex], "/var/run/secrets/tokens/vault"))
+
+	// Build a worker Pod.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podName = cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	pod = BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, "6379", nil, utils.GetCRDType(""), fqdnRayIP)
+
+	assert.True(t, checkIfVolumeExists(&pod, "sa-token-vault"))
+	assert.True(t, checkIfVolumeMounted(&pod.Spec.Containers[utils.RayContainerIndex], "/var/run/secrets/tokens/vault"))
+	assert.False(t, checkIfVolumeExists(&corev1.Pod{Spec: worker.Template.Spec}, "sa-token-vault"))
+	assert.False(t, checkIfVolumeMounted(&worker.Template.Spec.Containers[utils.RayContainerIndex], "/var/run/secrets/tokens/vault"))
+}
+
 // Check that autoscaler container overrides work as expected.
 func TestBuildPodWithAutoscalerOptions(t *testing.T) {
 	ctx := context.Background()
@@ -590,6 +1033,8 @@ func TestBuildPodWithAutoscalerOptions(t *testing.T) {
 			corev1.ResourceMemory: testMemoryLimit,
 		},
 	}
+	customCommand := []string{"/usr/local/bin/autoscaler-wrapper"}
+	customArgs := []string{"--config", "/etc/autoscaler/config.yaml"}
 	customEnv := []corev1.EnvVar{{Name: "fooEnv", Value: "fooValue"}}
 	customEnvFrom := []corev1.EnvFromSource{{Prefix: "Pre"}}
 	customVolumeMounts := []corev1.VolumeMount{
@@ -625,6 +1070,8 @@ func TestBuildPodWithAutoscalerOptions(t *testing.T) {
 		IdleTimeoutSeconds: &customTimeout,
 		Image:              &customAutoscalerImage,
 		ImagePullPolicy:    &customPullPolicy,
+		Command:            customCommand,
+		Args:               customArgs,
 		Resources:          &customResources,
 		Env:                customEnv,
 		EnvFrom:            customEnvFrom,
@@ -636,6 +1083,8 @@ func TestBuildPodWithAutoscalerOptions(t *testing.T) {
 	expectedContainer := *autoscalerContainer.DeepCopy()
 	expectedContainer.Image = customAutoscalerImage
 	expectedContainer.ImagePullPolicy = customPullPolicy
+	expectedContainer.Command = customCommand
+	expectedContainer.Args = customArgs
 	expectedContainer.Resources = customResources
 	expectedContainer.EnvFrom = customEnvFrom
 	expectedContainer.Env = append(expectedContainer.Env, customEnv...)
@@ -710,6 +1159,48 @@ func TestHeadPodTemplate_AutoscalerImage(t *testing.T) {
 	assert.Equal(t, customAutoscalerImage, podTemplateSpec.Spec.Containers[autoscalerContainerIndex].Image)
 }
 
+func TestPodTemplate_WithAutoscalerV2(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := instance.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = &trueFlag
+	cluster.Spec.AutoscalerOptions = &rayv1.AutoscalerOptions{Version: ptr.To(rayv1.AutoscalerVersionV2)}
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+
+	// Both the Ray head container and the autoscaler container run the autoscaler v2, and the Ray Pods are not restarted.
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod := corev1.Pod{Spec: podTemplateSpec.Spec}
+	env, ok := utils.EnvVarByName(utils.RAY_ENABLE_AUTOSCALER_V2, podTemplateSpec.Spec.Containers[utils.RayContainerIndex].Env)
+	assert.True(t, ok)
+	assert.Equal(t, "1", env.Value)
+	env, ok = utils.EnvVarByName(utils.RAY_ENABLE_AUTOSCALER_V2, pod.Spec.Containers[getAutoscalerContainerIndex(pod)].Env)
+	assert.True(t, ok)
+	assert.Equal(t, "1", env.Value)
+	assert.Equal(t, corev1.RestartPolicyNever, podTemplateSpec.Spec.RestartPolicy)
+
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, *worker.DeepCopy(), podName, fqdnRayIP, "6379")
+	assert.Equal(t, corev1.RestartPolicyNever, podTemplateSpec.Spec.RestartPolicy)
+
+	// The values set by users take precedence.
+	cluster.Spec.HeadGroupSpec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Env = append(
+		cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Env,
+		corev1.EnvVar{Name: utils.RAY_ENABLE_AUTOSCALER_V2, Value: "0"})
+	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	env, _ = utils.EnvVarByName(utils.RAY_ENABLE_AUTOSCALER_V2, podTemplateSpec.Spec.Containers[utils.RayContainerIndex].Env)
+	assert.Equal(t, "0", env.Value)
+	assert.Equal(t, corev1.RestartPolicyOnFailure, podTemplateSpec.Spec.RestartPolicy)
+
+	// The autoscaler v1 does not change the restart policy.
+	cluster = instance.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = &trueFlag
+	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	assert.False(t, utils.EnvVarExists(utils.RAY_ENABLE_AUTOSCALER_V2, podTemplateSpec.Spec.Containers[utils.RayContainerIndex].Env))
+	assert.Equal(t, cluster.Spec.HeadGroupSpec.Template.Spec.RestartPolicy, podTemplateSpec.Spec.RestartPolicy)
+}
+
 // If no service account is specified in the RayCluster,
 // the head pod's service account should be an empty string.
 func TestHeadPodTemplate_WithNoServiceAccount(t *testing.T) {
@@ -757,6 +1248,18 @@ func TestHeadPodTemplate_WithServiceAccount(t *testing.T) {
 	}
 }
 
+// If an existing service account is specified in AutoscalerOptions, the head pod uses it.
+func TestHeadPodTemplate_WithAutoscalerServiceAccount(t *testing.T) {
+	cluster := instance.DeepCopy()
+	serviceAccount := "autoscaler-service-account"
+	cluster.Spec.HeadGroupSpec.Template.Spec.ServiceAccountName = "head-service-account"
+	cluster.Spec.EnableInTreeAutoscaling = &trueFlag
+	cluster.Spec.AutoscalerOptions = &rayv1.AutoscalerOptions{ServiceAccountName: &serviceAccount}
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	pod := DefaultHeadPodTemplate(context.Background(), *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	assert.Equal(t, serviceAccount, pod.Spec.ServiceAccountName)
+}
+
 func splitAndSort(s string) []string {
 	strs := strings.Split(s, " ")
 	result := make([]string, 0, len(strs))
@@ -884,6 +1387,34 @@ func TestDefaultInitContainer(t *testing.T) {
 	assert.NotEmpty(t, rayContainer.Resources, "The test only makes sense if the Ray container has resource limit/request.")
 }
 
+func TestDefaultInitContainer_MinimalEnv(t *testing.T) {
+	t.Setenv(utils.ENABLE_MINIMAL_INIT_CONTAINER_ENV, "true")
+	t.Setenv(utils.INIT_CONTAINER_ENV_ALLOWLIST, "HTTP_PROXY, ")
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.Template.Spec.Containers[utils.RayContainerIndex].Env = []corev1.EnvVar{
+		{Name: "CERT_DIR", Value: "/etc/ray/tls"},
+		{Name: "UNRELATED", Value: "value"},
+		{Name: "RAY_USE_TLS", Value: "1"},
+		{Name: "RAY_TLS_CA_CERT", Value: "$(CERT_DIR)/ca.crt"},
+		{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+	}
+	podName := cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+
+	podTemplateSpec := DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	healthCheckContainer := podTemplateSpec.Spec.InitContainers[len(podTemplateSpec.Spec.InitContainers)-1]
+	envNames := []string{}
+	for _, env := range healthCheckContainer.Env {
+		envNames = append(envNames, env.Name)
+	}
+	// The variables referenced by TLS-related variables are kept as well.
+	assert.Equal(t, []string{"CERT_DIR", "RAY_USE_TLS", "RAY_TLS_CA_CERT", "HTTP_PROXY"}, envNames)
+	// The environment of the Ray container is not modified.
+	assert.Len(t, podTemplateSpec.Spec.Containers[utils.RayContainerIndex].Env, 5)
+}
+
 func TestDefaultInitContainerImagePullPolicy(t *testing.T) {
 	ctx := context.Background()
 
@@ -1139,3 +1670,159 @@ func TestInitLivenessAndReadinessProbe(t *testing.T) {
 	assert.False(t, strings.Contains(strings.Join(rayContainer.LivenessProbe.Exec.Command, " "), utils.RayServeProxyHealthPath))
 	assert.True(t, strings.Contains(strings.Join(rayContainer.ReadinessProbe.Exec.Command, " "), utils.RayServeProxyHealthPath))
 }
+
+func TestInitAutoscalerLivenessProbe(t *testing.T) {
+	// KubeRay injects a heartbeat probe reading the log file of the autoscaler.
+	container := corev1.Container{Name: AutoscalerContainerName}
+	initAutoscalerLivenessProbe(&container)
+	assert.NotNil(t, container.LivenessProbe.Exec)
+	assert.Contains(t, strings.Join(container.LivenessProbe.Exec.Command, " "), "/tmp/ray/"+utils.RayAutoscalerLogPath)
+
+	// A probe defined by users is not overridden.
+	httpGetProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: "/metrics", Port: intstr.FromInt(44217)},
+		},
+	}
+	container.LivenessProbe = httpGetProbe
+	initAutoscalerLivenessProbe(&container)
+	assert.Equal(t, httpGetProbe, container.LivenessProbe)
+
+	// The probe is not injected if probes injection is disabled.
+	os.Setenv(utils.ENABLE_PROBES_INJECTION, "false")
+	defer os.Unsetenv(utils.ENABLE_PROBES_INJECTION)
+	cluster := instance.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = &trueFlag
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(context.Background(), *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod := BuildPod(context.Background(), podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", &trueFlag, utils.GetCRDType(""), "")
+	assert.Nil(t, pod.Spec.Containers[getAutoscalerContainerIndex(pod)].LivenessProbe)
+}
+
+func TestGenerateHTTPHealthProbeCommand(t *testing.T) {
+	command := generateHTTPHealthProbeCommand(2, "52365/api/local_raylet_healthz", "8265/api/gcs_healthz")
+	// The probe runs Python directly, so that it also works in distroless Ray images without a shell or wget.
+	assert.Equal(t, "python3", command[0])
+	assert.Equal(t, "-c", command[1])
+	assert.Contains(t, command[2], "urllib.request.urlopen(url, timeout=2)")
+	assert.Equal(t, []string{"http://localhost:52365/api/local_raylet_healthz", "http://localhost:8265/api/gcs_healthz"}, command[3:])
+}
+
+func TestAddAcceleratorTolerations(t *testing.T) {
+	gpuToleration := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	tpuToleration := corev1.Toleration{Key: "google.com/tpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	userToleration := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpEqual, Value: "a100", Effect: corev1.TaintEffectNoSchedule}
+
+	tests := map[string]struct {
+		limits      corev1.ResourceList
+		tolerations []corev1.Toleration
+		expected    []corev1.Toleration
+	}{
+		"no accelerators requested": {
+			limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			expected: nil,
+		},
+		"zero GPUs requested": {
+			limits:   corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("0")},
+			expected: nil,
+		},
+		"GPUs and TPUs requested": {
+			limits:   corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1"), "google.com/tpu": resource.MustParse("4")},
+			expected: []corev1.Toleration{tpuToleration, gpuToleration},
+		},
+		"the taint is already tolerated by the user": {
+			limits:      corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+			tolerations: []corev1.Toleration{userToleration},
+			expected:    []corev1.Toleration{userToleration},
+		},
+		"all taints are already tolerated by the user": {
+			limits:      corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+			tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			expected:    []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers:  []corev1.Container{{Name: "ray-worker", Resources: corev1.ResourceRequirements{Limits: tc.limits}}},
+					Tolerations: tc.tolerations,
+				},
+			}
+			AddAcceleratorTolerations(&podTemplate)
+			assert.Equal(t, tc.expected, podTemplate.Spec.Tolerations)
+		})
+	}
+}
+
+func TestParseAcceleratorResourceMapping(t *testing.T) {
+	mapping, err := ParseAcceleratorResourceMapping(" google.com/tpu=TPU, habana.ai/gaudi = HPU,")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"google.com/tpu": "TPU", "habana.ai/gaudi": "HPU"}, mapping)
+
+	mapping, err = ParseAcceleratorResourceMapping("")
+	assert.Nil(t, err)
+	assert.Empty(t, mapping)
+
+	_, err = ParseAcceleratorResourceMapping("google.com/tpu")
+	assert.NotNil(t, err)
+	_, err = ParseAcceleratorResourceMapping("amd.com/gpu=GPU")
+	assert.NotNil(t, err)
+}
+
+func TestAddAcceleratorResources(t *testing.T) {
+	mapping := map[string]string{"google.com/tpu": "TPU", "amd.com/gpu": "AMD_GPU"}
+
+	tests := map[string]struct {
+		limits         corev1.ResourceList
+		annotations    map[string]string
+		rayStartParams map[string]string
+		expected       map[string]string
+	}{
+		"no mapped resources requested": {
+			limits:         corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+			rayStartParams: map[string]string{},
+			expected:       map[string]string{},
+		},
+		"mapped resources requested": {
+			limits:         corev1.ResourceList{"google.com/tpu": resource.MustParse("4"), "amd.com/gpu": resource.MustParse("0")},
+			rayStartParams: map[string]string{},
+			expected:       map[string]string{"resources": `'"{\"TPU\":4}"'`},
+		},
+		"merge into the user's resources": {
+			limits:         corev1.ResourceList{"google.com/tpu": resource.MustParse("4"), "amd.com/gpu": resource.MustParse("2")},
+			rayStartParams: map[string]string{"resources": `'"{\"Custom1\": 1, \"TPU\": 8}"'`},
+			expected:       map[string]string{"resources": `'"{\"AMD_GPU\":2,\"Custom1\":1,\"TPU\":8}"'`},
+		},
+		"the annotation overrides the mapping": {
+			limits:         corev1.ResourceList{"google.com/tpu": resource.MustParse("4"), "habana.ai/gaudi": resource.MustParse("8")},
+			annotations:    map[string]string{utils.RayAcceleratorResourcesAnnotationKey: "google.com/tpu=TPU-v5e,habana.ai/gaudi=HPU"},
+			rayStartParams: map[string]string{},
+			expected:       map[string]string{"resources": `'"{\"HPU\":8,\"TPU-v5e\":4}"'`},
+		},
+		"invalid resources are left unchanged": {
+			limits:         corev1.ResourceList{"google.com/tpu": resource.MustParse("4")},
+			rayStartParams: map[string]string{"resources": "Custom1=1"},
+			expected:       map[string]string{"resources": "Custom1=1"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "ray-worker", Resources: corev1.ResourceRequirements{Limits: tc.limits}}},
+				},
+			}
+			original := make(map[string]string, len(tc.rayStartParams))
+			for k, v := range tc.rayStartParams {
+				original[k] = v
+			}
+			assert.Equal(t, tc.expected, AddAcceleratorResources(context.Background(), podTemplate, tc.rayStartParams, mapping))
+			// The rayStartParams of the RayCluster must not be modified.
+			assert.Equal(t, original, tc.rayStartParams)
+		})
+	}
+}
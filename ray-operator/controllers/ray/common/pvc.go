@@ -0,0 +1,94 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// DefaultLogVolumeSize is the size of the generated log PVCs if `spec.logging.volume.size` is not set.
+var DefaultLogVolumeSize = resource.MustParse("10Gi")
+
+// getReplicaIndex returns the replica index of a Ray Pod. The head Pod has the replica index 0.
+func getReplicaIndex(pod corev1.Pod) string {
+	if index, ok := pod.Labels[utils.RayWorkerReplicaIndexKey]; ok {
+		return index
+	}
+	return "0"
+}
+
+// getReplicaID returns "<group name>-<replica index>", which identifies the replica of a Ray Pod across Pod recreations.
+func getReplicaID(pod corev1.Pod) string {
+	return fmt.Sprintf("%s-%s", pod.Labels[utils.RayNodeGroupLabelKey], getReplicaIndex(pod))
+}
+
+// GetLogVolumeClaimName returns the name of the PVC generated to store the logs of the given Ray Pod.
+// The name only depends on the replica of the Pod, so a recreated Pod reuses the logs of its predecessor.
+func GetLogVolumeClaimName(pod corev1.Pod) string {
+	return fmt.Sprintf("%s-%s-%s", pod.Labels[utils.RayClusterLabelKey], getReplicaID(pod), RayLogVolumeName)
+}
+
+// SetLogVolume replaces the emptyDir volume of the Ray log directory with a PVC. If a shared PVC is
+// configured, each Pod mounts its own sub-directory of the volume.
+func SetLogVolume(ctx context.Context, pod *corev1.Pod, options rayv1.LogVolumeOptions) {
+	claimName, subPath := GetLogVolumeClaimName(*pod), ""
+	if options.ClaimName != "" {
+		claimName, subPath = options.ClaimName, getReplicaID(*pod)
+	}
+	volumeSource := corev1.VolumeSource{
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+	}
+
+	if !checkIfVolumeExists(pod, RayLogVolumeName) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: RayLogVolumeName})
+	}
+	for i := range pod.Spec.Volumes {
+		if pod.Spec.Volumes[i].Name == RayLogVolumeName {
+			pod.Spec.Volumes[i].VolumeSource = volumeSource
+		}
+	}
+	// The volume exists at this point, so addEmptyDir only mounts it if the Ray container does not mount /tmp/ray yet.
+	addEmptyDir(ctx, &pod.Spec.Containers[utils.RayContainerIndex], pod, RayLogVolumeName, RayLogVolumeMountPath, corev1.StorageMediumDefault)
+
+	for i := range pod.Spec.Containers {
+		for j := range pod.Spec.Containers[i].VolumeMounts {
+			if pod.Spec.Containers[i].VolumeMounts[j].Name == RayLogVolumeName {
+				pod.Spec.Containers[i].VolumeMounts[j].SubPath = subPath
+			}
+		}
+	}
+}
+
+// BuildLogVolumeClaim builds the PVC storing the logs of the given Ray Pod. The PVC is labeled with the group and the
+// replica index of the Pod, so that it can be deleted once the group no longer has the replica.
+func BuildLogVolumeClaim(cluster *rayv1.RayCluster, pod corev1.Pod, options rayv1.LogVolumeOptions) *corev1.PersistentVolumeClaim {
+	size := DefaultLogVolumeSize
+	if options.Size != nil {
+		size = *options.Size
+	}
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetLogVolumeClaimName(pod),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:          cluster.Name,
+				utils.RayNodeGroupLabelKey:        pod.Labels[utils.RayNodeGroupLabelKey],
+				utils.RayWorkerReplicaIndexKey:    getReplicaIndex(pod),
+				utils.KubernetesCreatedByLabelKey: utils.ComponentName,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: options.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+}
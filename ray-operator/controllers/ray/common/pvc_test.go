@@ -0,0 +1,92 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func buildTestWorkerPod(t *testing.T) corev1.Pod {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podName := cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec := DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	podTemplateSpec.Labels[utils.RayWorkerReplicaIndexKey] = "2"
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, "6379", nil, utils.GetCRDType(""), fqdnRayIP)
+	assert.False(t, checkIfVolumeExists(&pod, RayLogVolumeName))
+	return pod
+}
+
+func getRayLogVolume(pod corev1.Pod) *corev1.Volume {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == RayLogVolumeName {
+			return &volume
+		}
+	}
+	return nil
+}
+
+func TestSetLogVolume_GeneratedClaim(t *testing.T) {
+	pod := buildTestWorkerPod(t)
+	SetLogVolume(context.Background(), &pod, rayv1.LogVolumeOptions{})
+
+	assert.Equal(t, "raycluster-sample-small-group-2-ray-logs", GetLogVolumeClaimName(pod))
+	volume := getRayLogVolume(pod)
+	assert.NotNil(t, volume)
+	assert.Equal(t, "raycluster-sample-small-group-2-ray-logs", volume.PersistentVolumeClaim.ClaimName)
+	assert.Nil(t, volume.EmptyDir)
+	rayContainer := pod.Spec.Containers[utils.RayContainerIndex]
+	assert.True(t, checkIfVolumeMounted(&rayContainer, RayLogVolumeMountPath))
+	for _, volumeMount := range rayContainer.VolumeMounts {
+		if volumeMount.Name == RayLogVolumeName {
+			assert.Empty(t, volumeMount.SubPath)
+		}
+	}
+}
+
+func TestSetLogVolume_SharedClaim(t *testing.T) {
+	pod := buildTestWorkerPod(t)
+	// The emptyDir added by BuildPod, e.g. for a log-shipping sidecar, is replaced.
+	addEmptyDir(context.Background(), &pod.Spec.Containers[utils.RayContainerIndex], &pod, RayLogVolumeName, RayLogVolumeMountPath, corev1.StorageMediumDefault)
+	SetLogVolume(context.Background(), &pod, rayv1.LogVolumeOptions{ClaimName: "shared-ray-logs"})
+
+	volume := getRayLogVolume(pod)
+	assert.NotNil(t, volume)
+	assert.Equal(t, "shared-ray-logs", volume.PersistentVolumeClaim.ClaimName)
+	assert.Nil(t, volume.EmptyDir)
+	numMounts := 0
+	for _, volumeMount := range pod.Spec.Containers[utils.RayContainerIndex].VolumeMounts {
+		if volumeMount.Name == RayLogVolumeName {
+			numMounts++
+			assert.Equal(t, "small-group-2", volumeMount.SubPath)
+		}
+	}
+	assert.Equal(t, 1, numMounts)
+}
+
+func TestBuildLogVolumeClaim(t *testing.T) {
+	pod := buildTestWorkerPod(t)
+
+	pvc := BuildLogVolumeClaim(instance.DeepCopy(), pod, rayv1.LogVolumeOptions{})
+	assert.Equal(t, "raycluster-sample-small-group-2-ray-logs", pvc.Name)
+	assert.Equal(t, "default", pvc.Namespace)
+	assert.Equal(t, "raycluster-sample", pvc.Labels[utils.RayClusterLabelKey])
+	assert.Nil(t, pvc.Spec.StorageClassName)
+	assert.True(t, DefaultLogVolumeSize.Equal(pvc.Spec.Resources.Requests[corev1.ResourceStorage]))
+
+	pvc = BuildLogVolumeClaim(instance.DeepCopy(), pod, rayv1.LogVolumeOptions{
+		StorageClassName: ptr.To("standard-rwo"),
+		Size:             ptr.To(resource.MustParse("1Gi")),
+	})
+	assert.Equal(t, "standard-rwo", *pvc.Spec.StorageClassName)
+	assert.True(t, resource.MustParse("1Gi").Equal(pvc.Spec.Resources.Requests[corev1.ResourceStorage]))
+}
@@ -26,7 +26,8 @@ func BuildServiceAccount(cluster *rayv1.RayCluster) (*corev1.ServiceAccount, err
 	return sa, nil
 }
 
-// BuildRole creates a new Role for an RayCluster resource.
+// BuildRole creates a new Role for the autoscaler of a RayCluster, which only allows it to read and patch the Pods in the
+// namespace and the RayCluster itself.
 func BuildRole(cluster *rayv1.RayCluster) (*rbacv1.Role, error) {
 	role := &rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
@@ -45,9 +46,10 @@ func BuildRole(cluster *rayv1.RayCluster) (*rbacv1.Role, error) {
 				Verbs:     []string{"get", "list", "watch", "patch"},
 			},
 			{
-				APIGroups: []string{"ray.io"},
-				Resources: []string{"rayclusters"},
-				Verbs:     []string{"get", "patch"},
+				APIGroups:     []string{"ray.io"},
+				Resources:     []string{"rayclusters"},
+				ResourceNames: []string{cluster.Name},
+				Verbs:         []string{"get", "patch"},
 			},
 		},
 	}
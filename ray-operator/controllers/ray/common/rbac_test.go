@@ -84,3 +84,23 @@ func TestBuildRoleBindingSubjectAndRoleRefName(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildRole(t *testing.T) {
+	cluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raycluster-sample",
+			Namespace: "default",
+		},
+	}
+	role, err := BuildRole(cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, cluster.Namespace, role.Namespace)
+	for _, rule := range role.Rules {
+		// The autoscaler can only access its own RayCluster.
+		if reflect.DeepEqual(rule.Resources, []string{"rayclusters"}) {
+			assert.Equal(t, []string{cluster.Name}, rule.ResourceNames)
+		}
+		assert.NotContains(t, rule.Verbs, "delete")
+		assert.NotContains(t, rule.Verbs, "create")
+	}
+}
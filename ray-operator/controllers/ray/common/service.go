@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -172,6 +173,27 @@ func BuildServeServiceForRayService(ctx context.Context, rayService rayv1.RaySer
 	return BuildServeService(ctx, rayService, rayCluster, true)
 }
 
+// BuildServeServiceForRayServiceCluster builds the Serve service of one of the RayClusters of the RayService, which
+// the HTTPRoute of the RayService routes a part of the traffic to during incremental upgrades.
+func BuildServeServiceForRayServiceCluster(ctx context.Context, rayService rayv1.RayService, rayCluster rayv1.RayCluster) (*corev1.Service, error) {
+	serveService, err := BuildServeServiceForRayService(ctx, rayService, rayCluster)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateServeServiceName(rayCluster.Name),
+			Namespace: rayCluster.Namespace,
+			Labels:    serveService.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: serveService.Spec.Selector,
+			Ports:    serveService.Spec.Ports,
+			Type:     corev1.ServiceTypeClusterIP,
+		},
+	}, nil
+}
+
 // BuildServeServiceForRayCluster builds the serve service for Ray cluster.
 func BuildServeServiceForRayCluster(ctx context.Context, rayCluster rayv1.RayCluster) (*corev1.Service, error) {
 	return BuildServeService(ctx, rayv1.RayService{}, rayCluster, false)
@@ -316,6 +338,36 @@ func BuildHeadlessServiceForRayCluster(rayCluster rayv1.RayCluster) (*corev1.Ser
 	return headlessService, nil
 }
 
+// MultiHostReplicaServiceName returns the name of the headless service of a replica of a multi-host worker group.
+func MultiHostReplicaServiceName(clusterName string, groupName string, replicaIndex int) string {
+	return utils.CheckName(strings.ToLower(fmt.Sprintf("%s-%s-%d", clusterName, groupName, replicaIndex)))
+}
+
+// BuildHeadlessServiceForMultiHostReplica builds the headless service that selects the Pods of a replica of a multi-host
+// worker group. The Pods use the service as their subdomain, so the hosts of a replica can resolve each other by hostname
+// even before they are ready.
+func BuildHeadlessServiceForMultiHostReplica(rayCluster rayv1.RayCluster, groupName string, replicaIndex int) *corev1.Service {
+	labels := map[string]string{
+		utils.RayClusterLabelKey:   rayCluster.Name,
+		utils.RayNodeGroupLabelKey: groupName,
+		utils.RayReplicaIndexKey:   strconv.Itoa(replicaIndex),
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MultiHostReplicaServiceName(rayCluster.Name, groupName, replicaIndex),
+			Namespace: rayCluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                "None",
+			Selector:                 labels,
+			Type:                     corev1.ServiceTypeClusterIP,
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
+
 func setServiceTypeForUserProvidedService(ctx context.Context, service *corev1.Service, defaultType corev1.ServiceType) {
 	log := ctrl.LoggerFrom(ctx)
 	// If the user has not specified a service type, use the default service type
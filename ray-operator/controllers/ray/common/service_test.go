@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
@@ -450,6 +451,28 @@ func TestBuildServiceForHeadPodPortsOrder(t *testing.T) {
 	}
 }
 
+func TestBuildHeadlessServiceForMultiHostReplica(t *testing.T) {
+	svc := BuildHeadlessServiceForMultiHostReplica(*instanceWithWrongSvc, "tpu-group", 2)
+
+	assert.Equal(t, "raycluster-sample-tpu-group-2", svc.Name)
+	assert.Equal(t, instanceWithWrongSvc.Namespace, svc.Namespace)
+	assert.Equal(t, "None", svc.Spec.ClusterIP)
+	assert.True(t, svc.Spec.PublishNotReadyAddresses)
+	expectedSelector := map[string]string{
+		utils.RayClusterLabelKey:   instanceWithWrongSvc.Name,
+		utils.RayNodeGroupLabelKey: "tpu-group",
+		utils.RayReplicaIndexKey:   "2",
+	}
+	assert.Equal(t, expectedSelector, svc.Spec.Selector)
+	// The per-replica services must not be mistaken for the headless worker service of the RayCluster.
+	assert.NotContains(t, svc.Labels, utils.RayClusterHeadlessServiceLabelKey)
+
+	// The name is truncated from the front so that the replica index is preserved.
+	name := MultiHostReplicaServiceName(strings.Repeat("a", 40), "tpu-group", 12)
+	assert.LessOrEqual(t, len(name), 50)
+	assert.True(t, strings.HasSuffix(name, "-tpu-group-12"))
+}
+
 func TestBuildServeServiceForRayService(t *testing.T) {
 	svc, err := BuildServeServiceForRayService(context.Background(), *serviceInstance, *instanceWithWrongSvc)
 	assert.Nil(t, err)
@@ -482,6 +505,18 @@ func TestBuildServeServiceForRayService(t *testing.T) {
 	validateNameAndNamespaceForUserSpecifiedService(svc, serviceInstance.ObjectMeta.Namespace, expectedName, t)
 }
 
+func TestBuildServeServiceForRayServiceCluster(t *testing.T) {
+	svc, err := BuildServeServiceForRayServiceCluster(context.Background(), *serviceInstance, *instanceWithWrongSvc)
+	assert.Nil(t, err)
+
+	assert.Equal(t, instanceWithWrongSvc.Name, svc.Spec.Selector[utils.RayClusterLabelKey])
+	assert.Equal(t, utils.EnableRayClusterServingServiceTrue, svc.Spec.Selector[utils.RayClusterServingServiceLabelKey])
+	assert.Equal(t, serviceInstance.Name, svc.Labels[utils.RayOriginatedFromCRNameLabelKey])
+	assert.Equal(t, corev1.ServiceTypeClusterIP, svc.Spec.Type)
+	assert.Equal(t, fmt.Sprintf("%s-%s-%s", instanceWithWrongSvc.Name, "serve", "svc"), svc.Name)
+	assert.Equal(t, instanceWithWrongSvc.Namespace, svc.Namespace)
+}
+
 func TestBuildServeServiceForRayCluster(t *testing.T) {
 	svc, err := BuildServeServiceForRayCluster(context.Background(), *instanceForServeSvc)
 	assert.Nil(t, err)
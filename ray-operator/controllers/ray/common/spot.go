@@ -0,0 +1,61 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NodeNotReadyGracePeriod is how long a spot node must stay not ready before its instance is considered interrupted,
+// so that transient kubelet heartbeat failures do not delete the worker Pods on it.
+var NodeNotReadyGracePeriod = 60 * time.Second
+
+// spotNodeLabels are the node labels set by cloud providers and node provisioners on spot or preemptible instances.
+var spotNodeLabels = map[string]string{
+	"cloud.google.com/gke-spot":             "true",
+	"cloud.google.com/gke-preemptible":      "true",
+	"eks.amazonaws.com/capacityType":        "SPOT",
+	"karpenter.sh/capacity-type":            "spot",
+	"kubernetes.azure.com/scalesetpriority": "spot",
+	"node.kubernetes.io/lifecycle":          "spot",
+}
+
+// interruptionTaintKeys are the node taints that indicate the instance of the node is about to be or has been shut down.
+var interruptionTaintKeys = []string{
+	// Set by the cloud node lifecycle controller when the instance of the node is shut down.
+	"node.cloudprovider.kubernetes.io/shutdown",
+	// Set by the GKE node termination handler when a preemption notice is received.
+	"cloud.google.com/impending-node-termination",
+	// Set by the AWS node termination handler when a spot interruption notice is received.
+	"aws-node-termination-handler/spot-itn",
+}
+
+// IsSpotNode checks whether the node runs on a spot or preemptible instance based on well-known node labels.
+func IsSpotNode(node *corev1.Node) bool {
+	for key, value := range spotNodeLabels {
+		if node.Labels[key] == value {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNodeInterrupted checks whether the instance of the node is being interrupted, i.e. the node is tainted by a
+// termination handler or has not been ready for NodeNotReadyGracePeriod. It returns the reason if so.
+func IsNodeInterrupted(node *corev1.Node, now time.Time) (bool, string) {
+	for _, taint := range node.Spec.Taints {
+		for _, key := range interruptionTaintKeys {
+			if taint.Key == key {
+				return true, fmt.Sprintf("Node %s has the taint %s", node.Name, key)
+			}
+		}
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue &&
+			!condition.LastTransitionTime.IsZero() && now.Sub(condition.LastTransitionTime.Time) >= NodeNotReadyGracePeriod {
+			return true, fmt.Sprintf("Node %s has not been ready for %s: %s", node.Name, NodeNotReadyGracePeriod, condition.Reason)
+		}
+	}
+	return false, ""
+}
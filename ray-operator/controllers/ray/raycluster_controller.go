@@ -2,59 +2,94 @@ package ray
 
 import (
 	"context"
+	"encoding/json"
 	errstd "errors"
 	"fmt"
+	"math"
 	"os"
 	"reflect"
 	"runtime"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/ptr"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler"
+	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
+	"github.com/ray-project/kuberay/ray-operator/pkg/tracing"
 
 	batchv1 "k8s.io/api/batch/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	"k8s.io/client-go/tools/record"
 
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 
-	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
+	"github.com/robfig/cron/v3"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/version"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	controller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 type reconcileFunc func(context.Context, *rayv1.RayCluster) error
 
+// requeueAfterError is returned by a reconcileFunc that cannot finish its work yet, e.g. because it waits for the
+// informer cache. The RayCluster is requeued after the given duration, and the error is not reported as a failure.
+type requeueAfterError struct {
+	after  time.Duration
+	reason string
+}
+
+func (e *requeueAfterError) Error() string {
+	return fmt.Sprintf("requeue after %s: %s", e.after, e.reason)
+}
+
 var (
 	DefaultRequeueDuration = 2 * time.Second
 	EnableBatchScheduler   bool
 
+	// The maximum period between two polls of the dashboard of a RayCluster with an idle timeout
+	idleTimeoutPollPeriod = time.Minute
+	// The period between two checks of the admission of a RayCluster waiting for the batch scheduler to admit it
+	admissionPollPeriod = 5 * time.Second
+
 	// Definition of a index field for pod name
 	podUIDIndexField = "metadata.uid"
+	// Definition of a index field for the ConfigMaps that the RayStartParams of RayClusters are sourced from
+	rayStartParamsConfigMapIndexField = "spec.rayStartParamsFrom.configMapRef.name"
 )
 
 // getDiscoveryClient returns a discovery client for the current reconciler
@@ -96,6 +131,37 @@ func getClusterType(ctx context.Context) bool {
 	return false
 }
 
+// supportsNativeSidecars checks whether the Kubernetes API server supports native sidecar containers, i.e. init
+// containers with `restartPolicy: Always`, which were introduced in Kubernetes 1.28.
+func supportsNativeSidecars(ctx context.Context) bool {
+	logger := ctrl.LoggerFrom(ctx)
+	config, err := ctrl.GetConfig()
+	if err != nil || config == nil {
+		logger.Info("Cannot retrieve config, not using native sidecar containers")
+		return false
+	}
+	dclient, err := getDiscoveryClient(config)
+	if err != nil || dclient == nil {
+		logger.Info("Cannot retrieve a DiscoveryClient, not using native sidecar containers")
+		return false
+	}
+	serverVersion, err := dclient.ServerVersion()
+	if err != nil {
+		logger.Info("Error while querying the server version, not using native sidecar containers", "error", err)
+		return false
+	}
+	parsedVersion, err := version.ParseGeneric(serverVersion.GitVersion)
+	if err != nil {
+		logger.Info("Cannot parse the server version, not using native sidecar containers", "version", serverVersion.GitVersion)
+		return false
+	}
+	if !parsedVersion.AtLeast(version.MajorMinor(1, 28)) {
+		logger.Info("Native sidecar containers require Kubernetes 1.28 or later", "version", serverVersion.GitVersion)
+		return false
+	}
+	return true
+}
+
 // NewReconciler returns a new reconcile.Reconciler
 func NewReconciler(ctx context.Context, mgr manager.Manager, options RayClusterReconcilerOptions) *RayClusterReconciler {
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &corev1.Pod{}, podUIDIndexField, func(rawObj client.Object) []string {
@@ -104,17 +170,36 @@ func NewReconciler(ctx context.Context, mgr manager.Manager, options RayClusterR
 	}); err != nil {
 		panic(err)
 	}
+	// The Pods are also listed by the RayService controller, e.g. to get the head Pods of the RayClusters.
+	if err := common.IndexPodFields(ctx, mgr.GetFieldIndexer()); err != nil {
+		panic(err)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &rayv1.RayCluster{}, rayStartParamsConfigMapIndexField, func(rawObj client.Object) []string {
+		return getRayStartParamsConfigMapNames(rawObj.(*rayv1.RayCluster))
+	}); err != nil {
+		panic(err)
+	}
 	isOpenShift := getClusterType(ctx)
+	nativeSidecars := features.Enabled(features.NativeSidecarContainers) && supportsNativeSidecars(ctx)
 
 	return &RayClusterReconciler{
 		Client:            mgr.GetClient(),
+		APIReader:         mgr.GetAPIReader(),
 		Scheme:            mgr.GetScheme(),
 		Recorder:          mgr.GetEventRecorderFor("raycluster-controller"),
 		BatchSchedulerMgr: batchscheduler.NewSchedulerManager(mgr.GetConfig()),
 		IsOpenShift:       isOpenShift,
 
-		headSidecarContainers:   options.HeadSidecarContainers,
-		workerSidecarContainers: options.WorkerSidecarContainers,
+		headSidecarContainers:        options.HeadSidecarContainers,
+		workerSidecarContainers:      options.WorkerSidecarContainers,
+		enableAcceleratorTolerations: options.EnableAcceleratorTolerations,
+		acceleratorResourceMapping:   options.AcceleratorResourceMapping,
+		environmentTiers:             options.EnvironmentTiers,
+		nativeSidecars:               nativeSidecars,
+		dashboardClientFunc:          options.DashboardClientFunc,
+		stuckPodTimeout:              options.StuckPodTimeout,
+		enablePrometheusMonitors:     options.EnablePrometheusMonitors,
+		prometheusMonitorLabels:      options.PrometheusMonitorLabels,
 	}
 }
 
@@ -123,12 +208,39 @@ var _ reconcile.Reconciler = &RayClusterReconciler{}
 // RayClusterReconciler reconciles a RayCluster object
 type RayClusterReconciler struct {
 	client.Client
+	// APIReader reads the objects that the manager does not cache from the API server, e.g. the generated log PVCs.
+	APIReader         client.Reader
 	Scheme            *k8sruntime.Scheme
 	Recorder          record.EventRecorder
 	BatchSchedulerMgr *batchscheduler.SchedulerManager
 
-	headSidecarContainers   []corev1.Container
-	workerSidecarContainers []corev1.Container
+	headSidecarContainers        []corev1.Container
+	workerSidecarContainers      []corev1.Container
+	enableAcceleratorTolerations bool
+	acceleratorResourceMapping   map[string]string
+	environmentTiers             map[string]configapi.EnvironmentTierPolicy
+	// nativeSidecars runs the autoscaler and log sidecars as native sidecar containers.
+	nativeSidecars      bool
+	dashboardClientFunc func() utils.RayDashboardClientInterface
+	// stuckPodTimeout is how long a worker Pod can stay terminating, or in the Unknown phase, before it is
+	// force-deleted. Disabled if zero.
+	stuckPodTimeout time.Duration
+	// enablePrometheusMonitors creates the PodMonitors scraping the metrics of the RayClusters, with the
+	// prometheusMonitorLabels.
+	enablePrometheusMonitors bool
+	prometheusMonitorLabels  map[string]string
+
+	// multiHostReplicaCreations maps the multiHostReplicaKey of each multi-host replica being created to the time its
+	// creation started, until the informer cache observes all the Pods of the replica.
+	multiHostReplicaCreations sync.Map
+	// podExpectations maps the NamespacedName of each RayCluster to the *podExpectations of its Pods.
+	podExpectations sync.Map
+	// scaleHistories maps the NamespacedName of each RayCluster to the *scaleHistory of its worker groups.
+	scaleHistories sync.Map
+	// podCreationBackoffs maps the NamespacedName of each RayCluster to the *podCreationBackoff of its head and groups.
+	podCreationBackoffs sync.Map
+	// podSizeChecks maps the NamespacedName of each RayCluster to the *podSizeCheck of its head and groups.
+	podSizeChecks sync.Map
 
 	IsOpenShift bool
 }
@@ -136,6 +248,25 @@ type RayClusterReconciler struct {
 type RayClusterReconcilerOptions struct {
 	HeadSidecarContainers   []corev1.Container
 	WorkerSidecarContainers []corev1.Container
+	// EnableAcceleratorTolerations adds tolerations for the taints of well-known accelerator nodes
+	// to the Pods requesting the corresponding resources.
+	EnableAcceleratorTolerations bool
+	// AcceleratorResourceMapping maps extended resources, such as "google.com/tpu", to the Ray custom
+	// resources advertised by the Ray nodes requesting them.
+	AcceleratorResourceMapping map[string]string
+	// EnvironmentTiers maps the values of the `ray.io/environment-tier` label of RayClusters to the defaults
+	// enforced on them.
+	EnvironmentTiers map[string]configapi.EnvironmentTierPolicy
+	// DashboardClientFunc creates the clients of the Ray dashboards, which are used to create the placement groups
+	// declared by the worker groups.
+	DashboardClientFunc func() utils.RayDashboardClientInterface
+	// StuckPodTimeout is how long a worker Pod can stay terminating, or in the Unknown phase, before it is
+	// force-deleted. Disabled if zero.
+	StuckPodTimeout time.Duration
+	// EnablePrometheusMonitors creates Prometheus Operator PodMonitors scraping the metrics of the RayClusters, with
+	// the PrometheusMonitorLabels.
+	EnablePrometheusMonitors bool
+	PrometheusMonitorLabels  map[string]string
 }
 
 // Reconcile reads that state of the cluster for a RayCluster object and makes changes based on it
@@ -145,11 +276,15 @@ type RayClusterReconcilerOptions struct {
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=list;create;delete
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;create;update;patch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
@@ -157,13 +292,17 @@ type RayClusterReconcilerOptions struct {
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;create;delete
 
 // [WARNING]: There MUST be a newline after kubebuilder markers.
 
 // Reconcile used to bridge the desired state with the current state
-func (r *RayClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (r *RayClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
 	logger := ctrl.LoggerFrom(ctx)
-	var err error
+	observer := common.ObserveReconcile(common.KindRayCluster, request.NamespacedName)
+	defer observer.Done(&err)
+	ctx, span := tracing.StartReconcile(ctx, common.KindRayCluster, request.NamespacedName)
+	defer tracing.End(span, &err)
 
 	// Try to fetch the RayCluster instance
 	instance := &rayv1.RayCluster{}
@@ -174,6 +313,11 @@ func (r *RayClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	// No match found
 	if errors.IsNotFound(err) {
 		logger.Info("Read request instance not found error!")
+		r.podExpectations.Delete(request.NamespacedName)
+		r.scaleHistories.Delete(request.NamespacedName)
+		r.podCreationBackoffs.Delete(request.NamespacedName)
+		r.podSizeChecks.Delete(request.NamespacedName)
+		observer.Deleted()
 	} else {
 		logger.Error(err, "Read request instance error!")
 	}
@@ -183,7 +327,7 @@ func (r *RayClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 
 func (r *RayClusterReconciler) deleteAllPods(ctx context.Context, filters common.AssociationOptions) (pods corev1.PodList, err error) {
 	logger := ctrl.LoggerFrom(ctx)
-	if err = r.List(ctx, &pods, filters.ToListOptions()...); err != nil {
+	if err = r.List(ctx, &pods, filters.ToCachedPodListOptions()...); err != nil {
 		return pods, err
 	}
 	active := 0
@@ -303,7 +447,16 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		return ctrl.Result{}, nil
 	}
 
+	requeueAfter, terminated, err := r.reconcileIdleTimeout(ctx, instance)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if terminated {
+		return ctrl.Result{}, nil
+	}
+
 	reconcileFuncs := []reconcileFunc{
+		r.reconcileScheduledScaling,
 		r.reconcileAutoscalerServiceAccount,
 		r.reconcileAutoscalerRole,
 		r.reconcileAutoscalerRoleBinding,
@@ -311,12 +464,28 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		r.reconcileHeadService,
 		r.reconcileHeadlessService,
 		r.reconcileServeService,
+		r.reconcileHeadPodDisruptionBudget,
+		r.reconcilePrometheusMonitors,
+		r.reconcileWorkerGroups,
 		r.reconcilePods,
+		r.reconcileLogVolumeClaims,
+		r.reconcileWarmPool,
+		r.reconcilePlacementGroups,
+		r.reconcileAutoscalerStatus,
 	}
 
 	for _, fn := range reconcileFuncs {
 		if reconcileErr = fn(ctx, instance); reconcileErr != nil {
 			funcName := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+			var requeueErr *requeueAfterError
+			if errstd.As(reconcileErr, &requeueErr) {
+				logger.Info("Requeue the RayCluster", "function name", funcName, "reason", requeueErr.reason, "after", requeueErr.after)
+				if requeueAfter == 0 || requeueErr.after < requeueAfter {
+					requeueAfter = requeueErr.after
+				}
+				reconcileErr = nil
+				continue
+			}
 			logger.Error(reconcileErr, "Error reconcile resources", "function name", funcName)
 			break
 		}
@@ -332,7 +501,6 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 	}
 
 	// Return error based on order.
-	var err error
 	if reconcileErr != nil {
 		err = reconcileErr
 	} else if calculateErr != nil {
@@ -340,6 +508,11 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 	} else {
 		err = updateErr
 	}
+	if err != nil && err == updateErr && errors.IsConflict(err) {
+		// The RayCluster was modified since it was read, so its status is recomputed from the latest RayCluster.
+		logger.Info("The RayCluster was modified while reconciling it; requeue it to recompute its status", "cluster name", request.Name)
+		return ctrl.Result{Requeue: true}, nil
+	}
 	if err != nil {
 		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
 	}
@@ -352,8 +525,15 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		logger.Info(fmt.Sprintf("Environment variable %s is not set, using default value of %d seconds", utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV, utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS), "cluster name", request.Name)
 		requeueAfterSeconds = utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS
 	}
-	logger.Info("Unconditional requeue after", "cluster name", request.Name, "seconds", requeueAfterSeconds)
-	return ctrl.Result{RequeueAfter: time.Duration(requeueAfterSeconds) * time.Second}, nil
+	if defaultRequeueAfter := time.Duration(requeueAfterSeconds) * time.Second; requeueAfter == 0 || defaultRequeueAfter < requeueAfter {
+		requeueAfter = defaultRequeueAfter
+	}
+	// Requeue earlier to start or end the scheduled scaling windows on time.
+	if next := nextScheduledScalingTime(instance, time.Now()); !next.IsZero() && time.Until(next) < requeueAfter {
+		requeueAfter = max(time.Until(next), time.Second)
+	}
+	logger.Info("Unconditional requeue after", "cluster name", request.Name, "seconds", requeueAfter.Seconds())
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // Checks whether the old and new RayClusterStatus are inconsistent by comparing different fields. If the only
@@ -399,6 +579,22 @@ func (r *RayClusterReconciler) inconsistentRayClusterStatus(ctx context.Context,
 		logger.Info("inconsistentRayClusterStatus", "old conditions", oldStatus.Conditions, "new conditions", newStatus.Conditions)
 		return true
 	}
+	if !reflect.DeepEqual(oldStatus.GroupStatuses, newStatus.GroupStatuses) {
+		logger.Info("inconsistentRayClusterStatus", "old groupStatuses", oldStatus.GroupStatuses, "new groupStatuses", newStatus.GroupStatuses)
+		return true
+	}
+	if !reflect.DeepEqual(oldStatus.PlacementGroups, newStatus.PlacementGroups) {
+		logger.Info("inconsistentRayClusterStatus", "old placementGroups", oldStatus.PlacementGroups, "new placementGroups", newStatus.PlacementGroups)
+		return true
+	}
+	if oldStatus.AutoscalerError != newStatus.AutoscalerError {
+		logger.Info("inconsistentRayClusterStatus", "old autoscalerError", oldStatus.AutoscalerError, "new autoscalerError", newStatus.AutoscalerError)
+		return true
+	}
+	if !reflect.DeepEqual(oldStatus.LastActivityTime, newStatus.LastActivityTime) {
+		logger.Info("inconsistentRayClusterStatus", "old lastActivityTime", oldStatus.LastActivityTime, "new lastActivityTime", newStatus.LastActivityTime)
+		return true
+	}
 	return false
 }
 
@@ -599,223 +795,1944 @@ func (r *RayClusterReconciler) reconcileHeadlessService(ctx context.Context, ins
 	return nil
 }
 
-func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv1.RayCluster) error {
+// reconcileHeadPodDisruptionBudget creates the PodDisruptionBudget of the head Pod if the environment tier of the
+// RayCluster requires it, and deletes it otherwise.
+func (r *RayClusterReconciler) reconcileHeadPodDisruptionBudget(ctx context.Context, instance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
+	tier := r.getEnvironmentTierPolicy(ctx, instance)
+	desired := tier != nil && tier.HeadPodDisruptionBudget
 
-	// if RayCluster is suspended, delete all pods and skip reconcile
-	if instance.Spec.Suspend != nil && *instance.Spec.Suspend {
-		if _, err := r.deleteAllPods(ctx, common.RayClusterAllPodsAssociationOptions(instance)); err != nil {
-			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeletePod),
-				"Failed deleting Pods due to suspension for RayCluster %s/%s, %v",
-				instance.Namespace, instance.Name, err)
-			return errstd.Join(utils.ErrFailedDeleteAllPods, err)
-		}
+	// Read the PodDisruptionBudget from the API server so that KubeRay does not cache all PodDisruptionBudgets.
+	pdb := &policyv1.PodDisruptionBudget{}
+	err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: common.HeadPodDisruptionBudgetName(instance)}, pdb)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
 
-		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedPod),
-			"Deleted Pods for RayCluster %s/%s due to suspension",
-			instance.Namespace, instance.Name)
+	if !desired {
+		// Only delete the PodDisruptionBudget if it was created by KubeRay for this RayCluster.
+		if exists && metav1.IsControlledBy(pdb, instance) {
+			if err := r.Delete(ctx, pdb); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			logger.Info("Deleted the PodDisruptionBudget of the head Pod", "PodDisruptionBudget", pdb.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedPodDisruptionBudget), "Deleted PodDisruptionBudget %s/%s", pdb.Namespace, pdb.Name)
+		}
+		return nil
+	}
+	if exists {
 		return nil
 	}
 
-	// check if all the pods exist
-	headPods := corev1.PodList{}
-	if err := r.List(ctx, &headPods, common.RayClusterHeadPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+	pdb = common.BuildHeadPodDisruptionBudget(instance)
+	if err := controllerutil.SetControllerReference(instance, pdb, r.Scheme); err != nil {
 		return err
 	}
-	if EnableBatchScheduler {
-		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(instance); err == nil {
-			if err := scheduler.DoBatchSchedulingOnSubmission(ctx, instance); err != nil {
-				return err
-			}
-		} else {
-			return err
+	if err := r.Create(ctx, pdb); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
 		}
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreatePodDisruptionBudget), "Failed creating PodDisruptionBudget %s/%s, %v", pdb.Namespace, pdb.Name, err)
+		return err
 	}
+	logger.Info("Created the PodDisruptionBudget of the head Pod", "PodDisruptionBudget", pdb.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedPodDisruptionBudget), "Created PodDisruptionBudget %s/%s", pdb.Namespace, pdb.Name)
+	return nil
+}
 
-	// Reconcile head Pod
-	if len(headPods.Items) == 1 {
-		headPod := headPods.Items[0]
-		logger.Info("reconcilePods", "Found 1 head Pod", headPod.Name, "Pod status", headPod.Status.Phase,
-			"Pod status reason", headPod.Status.Reason,
-			"Pod restart policy", headPod.Spec.RestartPolicy,
-			"Ray container terminated status", getRayContainerStateTerminated(headPod))
-
-		shouldDelete, reason := shouldDeletePod(headPod, rayv1.HeadNode)
-		logger.Info("reconcilePods", "head Pod", headPod.Name, "shouldDelete", shouldDelete, "reason", reason)
-		if shouldDelete {
-			if err := r.Delete(ctx, &headPod); err != nil {
-				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteHeadPod),
-					"Failed deleting head Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v, %v",
-					headPod.Namespace, headPod.Name, headPod.Status.Phase, headPod.Spec.RestartPolicy, getRayContainerStateTerminated(headPod), err)
-				return errstd.Join(utils.ErrFailedDeleteHeadPod, err)
+// reconcilePrometheusMonitors creates the PodMonitors scraping the metrics of the RayCluster if they are enabled, and
+// updates their spec and labels if they changed, e.g. with the labels configured for the operator. The labels added by
+// others are kept. The PodMonitors are deleted with the RayCluster.
+func (r *RayClusterReconciler) reconcilePrometheusMonitors(ctx context.Context, instance *rayv1.RayCluster) error {
+	if !r.enablePrometheusMonitors {
+		return nil
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	for _, monitor := range common.BuildPodMonitors(instance, r.prometheusMonitorLabels) {
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(common.PodMonitorGVK)
+		err := r.Get(ctx, types.NamespacedName{Namespace: monitor.GetNamespace(), Name: monitor.GetName()}, existing)
+		if err == nil {
+			if err := r.updatePrometheusMonitor(ctx, instance, existing, monitor); err != nil {
+				return err
 			}
-			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedHeadPod),
-				"Deleted head Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v",
-				headPod.Namespace, headPod.Name, headPod.Status.Phase, headPod.Spec.RestartPolicy, getRayContainerStateTerminated(headPod))
-			return fmt.Errorf(reason)
+			continue
 		}
-	} else if len(headPods.Items) == 0 {
-		// Create head Pod if it does not exist.
-		logger.Info("reconcilePods", "Found 0 head Pods; creating a head Pod for the RayCluster.", instance.Name)
-		common.CreatedClustersCounterInc(instance.Namespace)
-		if err := r.createHeadPod(ctx, *instance); err != nil {
-			common.FailedClustersCounterInc(instance.Namespace)
-			return errstd.Join(utils.ErrFailedCreateHeadPod, err)
+		if meta.IsNoMatchError(err) {
+			// Do not fail the reconciliation of the RayCluster if the Prometheus Operator is not installed.
+			logger.Info("The PodMonitor CRD is not installed. Skip creating the PodMonitors.", "error", err)
+			return nil
 		}
-		common.SuccessfulClustersCounterInc(instance.Namespace)
-	} else if len(headPods.Items) > 1 {
-		logger.Info("reconcilePods", fmt.Sprintf("Found %d head Pods; deleting extra head Pods.", len(headPods.Items)), instance.Name)
-		// TODO (kevin85421): In-place update may not be a good idea.
-		itemLength := len(headPods.Items)
-		for index := 0; index < itemLength; index++ {
-			if headPods.Items[index].Status.Phase == corev1.PodRunning || headPods.Items[index].Status.Phase == corev1.PodPending {
-				// Remove the healthy pod at index i from the list of pods to delete
-				headPods.Items[index] = headPods.Items[len(headPods.Items)-1] // replace last element with the healthy head.
-				headPods.Items = headPods.Items[:len(headPods.Items)-1]       // Truncate slice.
-				itemLength--
-			}
+		if !errors.IsNotFound(err) {
+			return err
 		}
-		// delete all the extra head pod pods
-		for _, extraHeadPodToDelete := range headPods.Items {
-			if err := r.Delete(ctx, &extraHeadPodToDelete); err != nil {
-				return errstd.Join(utils.ErrFailedDeleteHeadPod, err)
+		if err := controllerutil.SetControllerReference(instance, monitor, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, monitor); err != nil {
+			if errors.IsAlreadyExists(err) {
+				continue
 			}
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreatePodMonitor), "Failed creating PodMonitor %s/%s, %v", monitor.GetNamespace(), monitor.GetName(), err)
+			return err
 		}
+		logger.Info("Created the PodMonitor", "PodMonitor", monitor.GetName())
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedPodMonitor), "Created PodMonitor %s/%s", monitor.GetNamespace(), monitor.GetName())
 	}
+	return nil
+}
 
-	// Reconcile worker pods now
-	for _, worker := range instance.Spec.WorkerGroupSpecs {
-		// workerReplicas will store the target number of pods for this worker group.
-		var workerReplicas int32 = utils.GetWorkerGroupDesiredReplicas(ctx, worker)
-		logger.Info("reconcilePods", "desired workerReplicas (always adhering to minReplicas/maxReplica)", workerReplicas, "worker group", worker.GroupName, "maxReplicas", worker.MaxReplicas, "minReplicas", worker.MinReplicas, "replicas", worker.Replicas)
+// updatePrometheusMonitor updates the spec and the labels of the existing PodMonitor to the desired ones, if they differ.
+func (r *RayClusterReconciler) updatePrometheusMonitor(ctx context.Context, instance *rayv1.RayCluster, existing, desired *unstructured.Unstructured) error {
+	labels := existing.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labelsChanged := false
+	for k, v := range desired.GetLabels() {
+		if labels[k] != v {
+			labels[k] = v
+			labelsChanged = true
+		}
+	}
+	if !labelsChanged && equality.Semantic.DeepEqual(existing.Object["spec"], desired.Object["spec"]) {
+		return nil
+	}
+	existing.SetLabels(labels)
+	existing.Object["spec"] = desired.Object["spec"]
+	if err := r.Update(ctx, existing); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToUpdatePodMonitor), "Failed updating PodMonitor %s/%s, %v", existing.GetNamespace(), existing.GetName(), err)
+		return err
+	}
+	ctrl.LoggerFrom(ctx).Info("Updated the PodMonitor", "PodMonitor", existing.GetName())
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.UpdatedPodMonitor), "Updated PodMonitor %s/%s", existing.GetNamespace(), existing.GetName())
+	return nil
+}
 
-		workerPods := corev1.PodList{}
-		if err := r.List(ctx, &workerPods, common.RayClusterGroupPodsAssociationOptions(instance, worker.GroupName).ToListOptions()...); err != nil {
+// reconcilePlacementGroups creates the placement groups declared by the worker groups once the head Pod is ready, by
+// submitting a Ray job through the dashboard API, and records them in the status of the RayCluster when the job
+// succeeds. The job is checked in every reconciliation, so that the placement groups are created again when the head
+// Pod is recreated without GCS fault tolerance.
+func (r *RayClusterReconciler) reconcilePlacementGroups(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	specs := common.BuildPlacementGroupSpecs(instance)
+	if len(specs) == 0 || r.dashboardClientFunc == nil {
+		instance.Status.PlacementGroups = nil
+		return nil
+	}
+	if instance.Spec.Suspend != nil && *instance.Spec.Suspend {
+		instance.Status.PlacementGroups = nil
+		return nil
+	}
+
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		return err
+	}
+	if headPod == nil || !utils.IsRunningAndReady(headPod) {
+		logger.Info("Waiting for the head Pod to be ready before creating the placement groups")
+		instance.Status.PlacementGroups = nil
+		return nil
+	}
+
+	request, err := common.BuildPlacementGroupBootstrapJobRequest(instance, specs)
+	if err != nil {
+		return err
+	}
+	dashboardURL, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		return err
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, dashboardURL, instance); err != nil {
+		return err
+	}
+
+	jobInfo, err := rayDashboardClient.GetJobInfo(ctx, request.SubmissionId)
+	if err != nil {
+		if !errors.IsBadRequest(err) {
+			return err
+		}
+		// The job does not exist yet, or the head Pod was recreated.
+		instance.Status.PlacementGroups = nil
+		if _, err := rayDashboardClient.SubmitJobReq(ctx, request, &request.SubmissionId); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreatePlacementGroups),
+				"Failed to submit the Ray job %s creating the placement groups %v, %v", request.SubmissionId, common.PlacementGroupNames(specs), err)
 			return err
 		}
+		logger.Info("Submitted the Ray job creating the placement groups", "submissionId", request.SubmissionId)
+		return nil
+	}
 
-		// Delete unhealthy worker Pods.
-		deletedWorkers := make(map[string]struct{})
-		deleted := struct{}{}
-		numDeletedUnhealthyWorkerPods := 0
-		for _, workerPod := range workerPods.Items {
-			shouldDelete, reason := shouldDeletePod(workerPod, rayv1.WorkerNode)
-			logger.Info("reconcilePods", "worker Pod", workerPod.Name, "shouldDelete", shouldDelete, "reason", reason)
-			if shouldDelete {
-				numDeletedUnhealthyWorkerPods++
-				deletedWorkers[workerPod.Name] = deleted
-				if err := r.Delete(ctx, &workerPod); err != nil {
-					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod),
-						"Failed deleting worker Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v, %v",
-						workerPod.Namespace, workerPod.Name, workerPod.Status.Phase, workerPod.Spec.RestartPolicy, getRayContainerStateTerminated(workerPod), err)
-					return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
-				}
-				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod),
-					"Deleted worker Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v",
-					workerPod.Namespace, workerPod.Name, workerPod.Status.Phase, workerPod.Spec.RestartPolicy, getRayContainerStateTerminated(workerPod))
-			}
+	switch jobInfo.JobStatus {
+	case rayv1.JobStatusSucceeded:
+		names := common.PlacementGroupNames(specs)
+		if !reflect.DeepEqual(instance.Status.PlacementGroups, names) {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedPlacementGroups),
+				"Created the placement groups %v", names)
+		}
+		instance.Status.PlacementGroups = names
+	case rayv1.JobStatusFailed, rayv1.JobStatusStopped:
+		// Delete the job, so that it is submitted again in the next reconciliation.
+		instance.Status.PlacementGroups = nil
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreatePlacementGroups),
+			"The Ray job %s creating the placement groups %v is %s, %s", request.SubmissionId, common.PlacementGroupNames(specs), jobInfo.JobStatus, jobInfo.Message)
+		if err := rayDashboardClient.DeleteJob(ctx, request.SubmissionId); err != nil {
+			return err
 		}
+	default:
+		instance.Status.PlacementGroups = nil
+	}
+	return nil
+}
 
-		// If we delete unhealthy Pods, we will not create new Pods in this reconciliation.
-		if numDeletedUnhealthyWorkerPods > 0 {
-			return fmt.Errorf("Delete %d unhealthy worker Pods", numDeletedUnhealthyWorkerPods)
+// reconcileAutoscalerStatus reads the state of the autoscaler from the dashboard of the head Pod and records its last
+// error in the status of the RayCluster, so that users can see why the worker groups are not scaled as expected.
+// Failures to reach the dashboard are not returned, as they should not block the reconciliation of the RayCluster.
+func (r *RayClusterReconciler) reconcileAutoscalerStatus(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling || r.dashboardClientFunc == nil {
+		instance.Status.AutoscalerError = ""
+		return nil
+	}
+
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		logger.Info("Failed to get the head Pod to read the autoscaler state", "error", err.Error())
+		return nil
+	}
+	if headPod == nil || !utils.IsRunningAndReady(headPod) {
+		return nil
+	}
+
+	dashboardURL, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		logger.Info("Failed to fetch the dashboard URL to read the autoscaler state", "error", err.Error())
+		return nil
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, dashboardURL, instance); err != nil {
+		logger.Info("Failed to initialize the dashboard client to read the autoscaler state", "error", err.Error())
+		return nil
+	}
+	clusterStatus, err := rayDashboardClient.GetClusterStatus(ctx)
+	if err != nil {
+		logger.Info("Failed to read the autoscaler state from the dashboard", "error", err.Error())
+		return nil
+	}
+
+	if clusterStatus.AutoscalingError != "" && clusterStatus.AutoscalingError != instance.Status.AutoscalerError {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.AutoscalerFailed),
+			"The autoscaler failed: %s", clusterStatus.AutoscalingError)
+	}
+	instance.Status.AutoscalerError = clusterStatus.AutoscalingError
+	return nil
+}
+
+// reconcileIdleTimeout tracks the last time the RayCluster had a pending or running Ray job or a Serve application, and
+// suspends or deletes it according to its IdleTimeoutPolicy once it is idle for IdleTimeoutSeconds. It returns when to
+// poll the dashboard again, and whether the RayCluster was terminated, in which case the reconciliation stops.
+func (r *RayClusterReconciler) reconcileIdleTimeout(ctx context.Context, instance *rayv1.RayCluster) (time.Duration, bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	suspended := instance.Spec.Suspend != nil && *instance.Spec.Suspend
+	if instance.Spec.IdleTimeoutSeconds == nil || r.dashboardClientFunc == nil || suspended ||
+		getCreatorCRDType(*instance) != utils.RayClusterCRD {
+		instance.Status.LastActivityTime = nil
+		return 0, false, nil
+	}
+
+	active, err := r.isRayClusterActive(ctx, instance)
+	if err != nil {
+		logger.Info("Failed to read the activity of the RayCluster from the dashboard", "error", err.Error())
+		return idleTimeoutPollPeriod, false, nil
+	}
+	now := metav1.Now()
+	if active || instance.Status.LastActivityTime == nil {
+		instance.Status.LastActivityTime = &now
+	}
+
+	timeout := time.Duration(*instance.Spec.IdleTimeoutSeconds) * time.Second
+	idle := now.Sub(instance.Status.LastActivityTime.Time)
+	if idle < timeout {
+		return min(timeout-idle, idleTimeoutPollPeriod), false, nil
+	}
+
+	policy := ptr.Deref(instance.Spec.IdleTimeoutPolicy, rayv1.SuspendIdleTimeoutPolicy)
+	logger.Info("The RayCluster is idle for longer than its idle timeout", "idle", idle, "policy", policy)
+	switch policy {
+	case rayv1.DeleteIdleTimeoutPolicy:
+		if err := r.Delete(ctx, instance, client.Preconditions{UID: ptr.To(instance.UID)}); err != nil {
+			return 0, false, client.IgnoreNotFound(err)
 		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.IdleTimeoutExpired),
+			"Deleted the RayCluster after it was idle for %s", idle.Round(time.Second))
+	default:
+		suspendedInstance := instance.DeepCopy()
+		suspendedInstance.Spec.Suspend = ptr.To(true)
+		if err := r.Patch(ctx, suspendedInstance, client.MergeFrom(instance)); err != nil {
+			return 0, false, err
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.IdleTimeoutExpired),
+			"Suspended the RayCluster after it was idle for %s", idle.Round(time.Second))
+	}
+	return 0, true, nil
+}
 
-		// Always remove the specified WorkersToDelete - regardless of the value of Replicas.
-		// Essentially WorkersToDelete has to be deleted to meet the expectations of the Autoscaler.
-		logger.Info("reconcilePods", "removing the pods in the scaleStrategy of", worker.GroupName)
-		for _, podsToDelete := range worker.ScaleStrategy.WorkersToDelete {
-			pod := corev1.Pod{}
-			pod.Name = podsToDelete
-			pod.Namespace = utils.GetNamespace(instance.ObjectMeta)
-			logger.Info("Deleting pod", "namespace", pod.Namespace, "name", pod.Name)
-			if err := r.Delete(ctx, &pod); err != nil {
-				if !errors.IsNotFound(err) {
-					logger.Info("reconcilePods", "Fail to delete Pod", pod.Name, "error", err)
-					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting pod %s/%s, %v", pod.Namespace, pod.Name, err)
-					return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
-				}
-				logger.Info("reconcilePods", "The worker Pod has already been deleted", pod.Name)
-			} else {
-				deletedWorkers[pod.Name] = deleted
-				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod), "Deleted pod %s/%s", pod.Namespace, pod.Name)
+// isRayClusterActive checks whether a Ray job is pending or running or a Serve application is deployed on the
+// RayCluster. A RayCluster whose head Pod is not ready yet is considered active, so that it is not terminated while
+// it starts.
+func (r *RayClusterReconciler) isRayClusterActive(ctx context.Context, instance *rayv1.RayCluster) (bool, error) {
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		return false, err
+	}
+	if headPod == nil || !utils.IsRunningAndReady(headPod) {
+		return true, nil
+	}
+
+	dashboardURL, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		return false, err
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, dashboardURL, instance); err != nil {
+		return false, err
+	}
+	jobs, err := rayDashboardClient.ListJobs(ctx)
+	if err != nil {
+		return false, err
+	}
+	if jobs != nil {
+		for _, job := range *jobs {
+			if !rayv1.IsJobTerminal(job.JobStatus) {
+				return true, nil
+			}
+		}
+	}
+	// Serve is not started on RayClusters that never deployed an application, so a failure to read the applications
+	// is not returned.
+	serveApps, err := rayDashboardClient.GetMultiApplicationStatus(ctx)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Info("Failed to read the Serve applications from the dashboard", "error", err.Error())
+		return false, nil
+	}
+	return len(serveApps) > 0, nil
+}
+
+// reconcileScheduledScaling overrides the minReplicas and maxReplicas of the worker groups in memory with the ones of
+// their active scheduled scaling windows, so that the replicas that reconcilePods creates stay within them. The spec is
+// not updated; the overridden bounds are reported in the statuses of the groups instead. An invalid window is reported
+// and ignored, so that it never blocks the reconciliation of the Pods. The windows of an autoscaled RayCluster are
+// ignored as well, because the Ray autoscaler only reads the bounds of the spec and would scale the groups back.
+func (r *RayClusterReconciler) reconcileScheduledScaling(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if utils.IsAutoscalingEnabled(&instance.Spec) {
+		for _, worker := range instance.Spec.WorkerGroupSpecs {
+			if len(worker.ScheduledScaling) == 0 {
+				continue
+			}
+			logger.Info("reconcileScheduledScaling", "ignoring the scheduledScaling of autoscaled worker group", worker.GroupName)
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToApplyScheduledScaling),
+				"Ignored scheduledScaling of worker group %s because enableInTreeAutoscaling is true", worker.GroupName)
+		}
+		return nil
+	}
+
+	previousStatuses := map[string]rayv1.GroupStatus{}
+	for _, groupStatus := range instance.Status.GroupStatuses {
+		previousStatuses[groupStatus.GroupName] = groupStatus
+	}
+
+	now := time.Now()
+	for i := range instance.Spec.WorkerGroupSpecs {
+		worker := &instance.Spec.WorkerGroupSpecs[i]
+		previous := previousStatuses[worker.GroupName]
+		window, err := activeScheduledScalingWindow(worker.ScheduledScaling, now)
+		if err != nil {
+			logger.Info("reconcileScheduledScaling", "ignoring the invalid scheduledScaling of worker group", worker.GroupName, "error", err)
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToApplyScheduledScaling),
+				"Invalid scheduledScaling of worker group %s: %v", worker.GroupName, err)
+			continue
+		}
+
+		if window == nil {
+			if previous.MinReplicas != nil || previous.MaxReplicas != nil {
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.AppliedScheduledScaling),
+					"Restored minReplicas %d and maxReplicas %d of worker group %s at the end of its scheduled scaling window",
+					ptr.Deref(worker.MinReplicas, 0), ptr.Deref(worker.MaxReplicas, 0), worker.GroupName)
+			}
+			continue
+		}
+
+		if window.MinReplicas != nil {
+			worker.MinReplicas = ptr.To(*window.MinReplicas)
+		}
+		if window.MaxReplicas != nil {
+			worker.MaxReplicas = ptr.To(*window.MaxReplicas)
+		}
+		if !ptr.Equal(previous.MinReplicas, worker.MinReplicas) || !ptr.Equal(previous.MaxReplicas, worker.MaxReplicas) {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.AppliedScheduledScaling),
+				"Set minReplicas %d and maxReplicas %d of worker group %s for the scheduled scaling window %q",
+				ptr.Deref(worker.MinReplicas, 0), ptr.Deref(worker.MaxReplicas, 0), worker.GroupName, window.Schedule)
+		}
+	}
+	return nil
+}
+
+// setScheduledScalingReplicas records the minReplicas and maxReplicas of the worker groups overridden by their active
+// scheduled scaling windows in the statuses of the groups.
+func setScheduledScalingReplicas(instance *rayv1.RayCluster, groupStatuses []rayv1.GroupStatus, now time.Time) {
+	if utils.IsAutoscalingEnabled(&instance.Spec) {
+		return
+	}
+	workers := map[string]rayv1.WorkerGroupSpec{}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		workers[worker.GroupName] = worker
+	}
+	for i := range groupStatuses {
+		worker, ok := workers[groupStatuses[i].GroupName]
+		if !ok {
+			continue
+		}
+		if window, err := activeScheduledScalingWindow(worker.ScheduledScaling, now); err == nil && window != nil {
+			groupStatuses[i].MinReplicas = ptr.To(ptr.Deref(worker.MinReplicas, 0))
+			groupStatuses[i].MaxReplicas = ptr.To(ptr.Deref(worker.MaxReplicas, 0))
+		}
+	}
+}
+
+// parseScheduledScalingSchedule parses the cron schedule of a window in its time zone, UTC by default.
+func parseScheduledScalingSchedule(window rayv1.ScheduledScalingWindow) (cron.Schedule, error) {
+	return parseCronSchedule(window.Schedule, window.TimeZone)
+}
+
+// parseCronSchedule parses a cron schedule in the given time zone, UTC if nil.
+func parseCronSchedule(schedule string, timeZone *string) (cron.Schedule, error) {
+	location := "UTC"
+	if timeZone != nil {
+		location = *timeZone
+	}
+	if _, err := time.LoadLocation(location); err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", location, err)
+	}
+	if strings.Contains(schedule, "TZ=") {
+		return nil, fmt.Errorf("schedule %q must not set a time zone, use timeZone instead", schedule)
+	}
+	parsed, err := cron.ParseStandard(fmt.Sprintf("CRON_TZ=%s %s", location, schedule))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	return parsed, nil
+}
+
+// activeScheduledScalingWindow returns the first of the windows active at the given time, or nil if none is. A window
+// is active if one of its starts is within its duration before the given time.
+func activeScheduledScalingWindow(windows []rayv1.ScheduledScalingWindow, now time.Time) (*rayv1.ScheduledScalingWindow, error) {
+	for i := range windows {
+		schedule, err := parseScheduledScalingSchedule(windows[i])
+		if err != nil {
+			return nil, err
+		}
+		if !schedule.Next(now.Add(-windows[i].Duration.Duration)).After(now) {
+			return &windows[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// nextScheduledScalingTime returns the next time after the given one at which a scheduled scaling window of the
+// RayCluster starts or ends, or the zero time if the RayCluster has no valid window or is autoscaled.
+func nextScheduledScalingTime(instance *rayv1.RayCluster, now time.Time) time.Time {
+	var next time.Time
+	if utils.IsAutoscalingEnabled(&instance.Spec) {
+		return next
+	}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		for _, window := range worker.ScheduledScaling {
+			schedule, err := parseScheduledScalingSchedule(window)
+			if err != nil {
+				continue
+			}
+			candidate := schedule.Next(now)
+			if start := schedule.Next(now.Add(-window.Duration.Duration)); !start.After(now) {
+				candidate = start.Add(window.Duration.Duration)
+			}
+			if !candidate.IsZero() && (next.IsZero() || candidate.Before(next)) {
+				next = candidate
+			}
+		}
+	}
+	return next
+}
+
+// reconcileWorkerGroups records the worker groups added to and removed from the RayCluster, and deletes the Pods of
+// the removed groups, which reconcilePods no longer manages. The Pods are deleted gracefully, so that their Ray nodes
+// can shut down within the termination grace period of the Pods.
+func (r *RayClusterReconciler) reconcileWorkerGroups(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	groupNames := make(map[string]bool, len(instance.Spec.WorkerGroupSpecs))
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		groupNames[worker.GroupName] = true
+	}
+
+	// The group statuses list the worker groups of the previous reconciliation. A new RayCluster has none, and its
+	// worker groups are not reported as added.
+	if len(instance.Status.GroupStatuses) > 0 {
+		knownGroupNames := make(map[string]bool, len(instance.Status.GroupStatuses))
+		for _, groupStatus := range instance.Status.GroupStatuses {
+			if groupStatus.GroupName == utils.RayNodeHeadGroupLabelValue {
+				continue
+			}
+			knownGroupNames[groupStatus.GroupName] = true
+			if !groupNames[groupStatus.GroupName] {
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.GroupRemoved),
+					"Removed worker group %s from RayCluster %s/%s", groupStatus.GroupName, instance.Namespace, instance.Name)
+			}
+		}
+		for _, worker := range instance.Spec.WorkerGroupSpecs {
+			if !knownGroupNames[worker.GroupName] {
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.GroupAdded),
+					"Added worker group %s to RayCluster %s/%s", worker.GroupName, instance.Namespace, instance.Name)
+			}
+		}
+	}
+
+	workerPods := corev1.PodList{}
+	if err := r.List(ctx, &workerPods, common.RayClusterWorkerPodsAssociationOptions(instance).ToCachedPodListOptions()...); err != nil {
+		return err
+	}
+	var removedPods []corev1.Pod
+	for _, pod := range workerPods.Items {
+		if groupNames[pod.Labels[utils.RayNodeGroupLabelKey]] || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		removedPods = append(removedPods, pod)
+	}
+	// Drain the Ray nodes of the Pods of the removed worker groups before deleting them.
+	podsToDelete, drainAfter := r.drainWorkerPods(ctx, instance, removedPods, time.Now())
+	for i := range podsToDelete {
+		pod := &podsToDelete[i]
+		groupName := pod.Labels[utils.RayNodeGroupLabelKey]
+		logger.Info("reconcileWorkerGroups", "deleting Pod of removed worker group", groupName, "Pod", pod.Name)
+		if err := r.deletePod(ctx, instance, groupName, pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod),
+				"Failed deleting Pod %s/%s of removed worker group %s, %v", pod.Namespace, pod.Name, groupName, err)
+			return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod),
+			"Deleted Pod %s/%s of removed worker group %s", pod.Namespace, pod.Name, groupName)
+	}
+
+	// Forget the scale history of the removed worker groups, so that a group added again with the same name starts
+	// afresh.
+	history := r.getScaleHistory(instance)
+	for groupName := range history.added {
+		if !groupNames[groupName] {
+			delete(history.added, groupName)
+		}
+	}
+	for groupName := range history.removed {
+		if !groupNames[groupName] {
+			delete(history.removed, groupName)
+		}
+	}
+
+	// Requeue the RayCluster to delete the Pods of the removed worker groups once their Ray nodes are drained.
+	if drainAfter > 0 {
+		return &requeueAfterError{after: drainAfter, reason: "draining the Ray nodes of the Pods of removed worker groups"}
+	}
+	return nil
+}
+
+func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	// if RayCluster is suspended, delete all pods and skip reconcile
+	if instance.Spec.Suspend != nil && *instance.Spec.Suspend {
+		if _, err := r.deleteAllPods(ctx, common.RayClusterAllPodsAssociationOptions(instance)); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeletePod),
+				"Failed deleting Pods due to suspension for RayCluster %s/%s, %v",
+				instance.Namespace, instance.Name, err)
+			return errstd.Join(utils.ErrFailedDeleteAllPods, err)
+		}
+
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedPod),
+			"Deleted Pods for RayCluster %s/%s due to suspension",
+			instance.Namespace, instance.Name)
+		return nil
+	}
+
+	// check if all the pods exist
+	headPods := corev1.PodList{}
+	if err := r.List(ctx, &headPods, common.RayClusterHeadPodsAssociationOptions(instance).ToCachedPodListOptions()...); err != nil {
+		return err
+	}
+	if EnableBatchScheduler {
+		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(instance); err == nil {
+			if err := scheduler.DoBatchSchedulingOnSubmission(ctx, instance); err != nil {
+				if !errstd.Is(err, schedulerinterface.ErrNotAdmitted) {
+					return err
+				}
+				// Only the admitted RayClusters have Pods, so that the Pods of a RayCluster evicted by the batch
+				// scheduler are deleted to release its quota.
+				if _, err := r.deleteAllPods(ctx, common.RayClusterAllPodsAssociationOptions(instance)); err != nil {
+					return errstd.Join(utils.ErrFailedDeleteAllPods, err)
+				}
+				return &requeueAfterError{after: admissionPollPeriod, reason: err.Error()}
+			}
+		} else {
+			return err
+		}
+	}
+
+	// Reconcile head Pod
+	// waitingGroups are the groups whose Pods are not reconciled until the informer cache observes earlier changes.
+	var waitingGroups []string
+	if !r.podExpectationsSatisfied(instance, utils.RayNodeHeadGroupLabelValue, headPods.Items) {
+		logger.Info("reconcilePods", "waiting for the informer cache to observe the head Pod created or deleted earlier", instance.Name)
+		waitingGroups = append(waitingGroups, utils.RayNodeHeadGroupLabelValue)
+	} else if len(headPods.Items) == 1 {
+		headPod := headPods.Items[0]
+		logger.Info("reconcilePods", "Found 1 head Pod", headPod.Name, "Pod status", headPod.Status.Phase,
+			"Pod status reason", headPod.Status.Reason,
+			"Pod restart policy", headPod.Spec.RestartPolicy,
+			"Ray container terminated status", getRayContainerStateTerminated(headPod))
+
+		shouldDelete, reason := shouldDeletePod(headPod, rayv1.HeadNode)
+		_, rayStartParamsHash, err := r.getRayStartParams(ctx, instance, instance.Spec.HeadGroupSpec.RayStartParams, instance.Spec.HeadGroupSpec.RayStartParamsFrom)
+		if err != nil {
+			return err
+		}
+		if !shouldDelete && r.isHeadPodTemplateOutdated(instance, headPod, rayStartParamsHash) {
+			shouldDelete = true
+			reason = fmt.Sprintf("The head Pod %s was created from an outdated Pod template", headPod.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.ReplacedOutdatedPod), "%s", reason)
+			// Delete the workers first, so that they do not try to reconnect to the old head Pod.
+			if ptr.Deref(instance.Spec.HeadGroupSpec.UpdateStrategy.RecreateWorkers, false) {
+				if _, err := r.deleteAllPods(ctx, common.RayClusterWorkerPodsAssociationOptions(instance)); err != nil {
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod),
+						"Failed deleting the worker Pods before recreating the head Pod %s/%s, %v", headPod.Namespace, headPod.Name, err)
+					return errstd.Join(utils.ErrFailedDeleteAllPods, err)
+				}
+			}
+		}
+		logger.Info("reconcilePods", "head Pod", headPod.Name, "shouldDelete", shouldDelete, "reason", reason)
+		if shouldDelete {
+			if err := r.deletePod(ctx, instance, utils.RayNodeHeadGroupLabelValue, &headPod); err != nil {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteHeadPod),
+					"Failed deleting head Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v, %v",
+					headPod.Namespace, headPod.Name, headPod.Status.Phase, headPod.Spec.RestartPolicy, getRayContainerStateTerminated(headPod), err)
+				return errstd.Join(utils.ErrFailedDeleteHeadPod, err)
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedHeadPod),
+				"Deleted head Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v",
+				headPod.Namespace, headPod.Name, headPod.Status.Phase, headPod.Spec.RestartPolicy, getRayContainerStateTerminated(headPod))
+			return fmt.Errorf(reason)
+		}
+	} else if len(headPods.Items) == 0 {
+		// Create head Pod if it does not exist.
+		logger.Info("reconcilePods", "Found 0 head Pods; creating a head Pod for the RayCluster.", instance.Name)
+		common.CreatedClustersCounterInc(instance.Namespace)
+		if err := r.createHeadPod(ctx, *instance); err != nil {
+			var backoffErr *requeueAfterError
+			if errstd.As(err, &backoffErr) {
+				return backoffErr
+			}
+			common.FailedClustersCounterInc(instance.Namespace)
+			return errstd.Join(utils.ErrFailedCreateHeadPod, err)
+		}
+		common.SuccessfulClustersCounterInc(instance.Namespace)
+	} else if len(headPods.Items) > 1 {
+		logger.Info("reconcilePods", fmt.Sprintf("Found %d head Pods; deleting extra head Pods.", len(headPods.Items)), instance.Name)
+		// TODO (kevin85421): In-place update may not be a good idea.
+		itemLength := len(headPods.Items)
+		for index := 0; index < itemLength; index++ {
+			if headPods.Items[index].Status.Phase == corev1.PodRunning || headPods.Items[index].Status.Phase == corev1.PodPending {
+				// Remove the healthy pod at index i from the list of pods to delete
+				headPods.Items[index] = headPods.Items[len(headPods.Items)-1] // replace last element with the healthy head.
+				headPods.Items = headPods.Items[:len(headPods.Items)-1]       // Truncate slice.
+				itemLength--
+			}
+		}
+		// delete all the extra head pod pods
+		for _, extraHeadPodToDelete := range headPods.Items {
+			if err := r.deletePod(ctx, instance, utils.RayNodeHeadGroupLabelValue, &extraHeadPodToDelete); err != nil {
+				return errstd.Join(utils.ErrFailedDeleteHeadPod, err)
+			}
+		}
+	}
+
+	// Reconcile worker pods now
+	scaleHistory := r.getScaleHistory(instance)
+	tier := r.getEnvironmentTierPolicy(ctx, instance)
+	var rateLimitedGroups []string
+	// rateLimitedWaits are how long the rate-limited groups wait until their scale policy windows free up.
+	var rateLimitedWaits []time.Duration
+	// drainingGroups are the groups whose recycled Pods are deleted once their Ray nodes are drained, in drainWaits.
+	var drainingGroups []string
+	var drainWaits []time.Duration
+	// backoffGroups are the groups whose Pods are created once the backoff after a failed creation expires, in backoffWaits.
+	var backoffGroups []string
+	var backoffWaits []time.Duration
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		// workerReplicas will store the target number of pods for this worker group.
+		var workerReplicas int32 = utils.GetWorkerGroupDesiredReplicas(ctx, worker)
+		logger.Info("reconcilePods", "desired workerReplicas (always adhering to minReplicas/maxReplica)", workerReplicas, "worker group", worker.GroupName, "maxReplicas", worker.MaxReplicas, "minReplicas", worker.MinReplicas, "replicas", worker.Replicas)
+
+		workerPods := corev1.PodList{}
+		if err := r.List(ctx, &workerPods, common.RayClusterGroupPodsAssociationOptions(instance, worker.GroupName).ToCachedPodListOptions()...); err != nil {
+			return err
+		}
+		if !r.podExpectationsSatisfied(instance, worker.GroupName, workerPods.Items) {
+			// The Pod events observing the earlier creations and deletions trigger the next reconciliation.
+			logger.Info("reconcilePods", "waiting for the informer cache to observe the Pods created or deleted earlier in worker group", worker.GroupName)
+			waitingGroups = append(waitingGroups, worker.GroupName)
+			continue
+		}
+
+		// Delete unhealthy worker Pods.
+		deletedWorkers := make(map[string]struct{})
+		deleted := struct{}{}
+		numDeletedUnhealthyWorkerPods := 0
+		for _, workerPod := range workerPods.Items {
+			shouldDelete, reason := shouldDeletePod(workerPod, rayv1.WorkerNode)
+			// Replace worker Pods on interrupted spot instances proactively instead of waiting for Kubernetes to
+			// notice that their nodes are gone.
+			interrupted := false
+			if !shouldDelete {
+				interrupted, reason = r.isWorkerPodInterrupted(ctx, worker, workerPod)
+				shouldDelete = interrupted
+			}
+			logger.Info("reconcilePods", "worker Pod", workerPod.Name, "shouldDelete", shouldDelete, "reason", reason)
+			if shouldDelete {
+				numDeletedUnhealthyWorkerPods++
+				deletedWorkers[workerPod.Name] = deleted
+				if interrupted {
+					if err := r.deletePod(ctx, instance, worker.GroupName, &workerPod); err != nil {
+						r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod),
+							"Failed deleting interrupted worker Pod %s/%s; %s, %v", workerPod.Namespace, workerPod.Name, reason, err)
+						return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+					}
+					incrementGroupInterruptions(&instance.Status, worker.GroupName)
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.InterruptedWorkerPod),
+						"Deleted interrupted worker Pod %s/%s; %s", workerPod.Namespace, workerPod.Name, reason)
+					continue
+				}
+				if err := r.deletePod(ctx, instance, worker.GroupName, &workerPod); err != nil {
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod),
+						"Failed deleting worker Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v, %v",
+						workerPod.Namespace, workerPod.Name, workerPod.Status.Phase, workerPod.Spec.RestartPolicy, getRayContainerStateTerminated(workerPod), err)
+					return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+				}
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod),
+					"Deleted worker Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v",
+					workerPod.Namespace, workerPod.Name, workerPod.Status.Phase, workerPod.Spec.RestartPolicy, getRayContainerStateTerminated(workerPod))
+			}
+		}
+
+		// The replicas of multi-host groups are atomic, so the other hosts of the replicas of unhealthy Pods are deleted too.
+		if numDeletedUnhealthyWorkerPods > 0 && worker.NumOfHosts > 1 {
+			if err := r.deleteMultiHostReplicasOfPods(ctx, instance, workerPods.Items, deletedWorkers); err != nil {
+				return err
+			}
+		}
+
+		// If we delete unhealthy Pods, we will not create new Pods in this reconciliation.
+		if numDeletedUnhealthyWorkerPods > 0 {
+			return fmt.Errorf("Delete %d unhealthy worker Pods", numDeletedUnhealthyWorkerPods)
+		}
+
+		// Force-delete the worker Pods stuck terminating or in the Unknown phase, e.g. because their node is gone, so
+		// that they are replaced below instead of leaving the group under-provisioned until the node comes back.
+		if r.stuckPodTimeout > 0 {
+			numForceDeletedWorkerPods := 0
+			now := time.Now()
+			for i := range workerPods.Items {
+				pod := &workerPods.Items[i]
+				stuck, reason := isPodStuck(*pod, r.stuckPodTimeout, now)
+				if !stuck {
+					continue
+				}
+				logger.Info("reconcilePods", "force-deleting stuck worker Pod", pod.Name, "Worker group", worker.GroupName, "reason", reason)
+				if err := r.deletePod(ctx, instance, worker.GroupName, pod, client.GracePeriodSeconds(0)); err != nil && !errors.IsNotFound(err) {
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed force-deleting Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+					return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+				}
+				numForceDeletedWorkerPods++
+				deletedWorkers[pod.Name] = deleted
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.ForceDeletedWorkerPod),
+					"Force-deleted worker Pod %s/%s; %s", pod.Namespace, pod.Name, reason)
+			}
+			if numForceDeletedWorkerPods > 0 && worker.NumOfHosts > 1 {
+				if err := r.deleteMultiHostReplicasOfPods(ctx, instance, workerPods.Items, deletedWorkers); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Always remove the specified WorkersToDelete - regardless of the value of Replicas.
+		// Essentially WorkersToDelete has to be deleted to meet the expectations of the Autoscaler.
+		logger.Info("reconcilePods", "removing the pods in the scaleStrategy of", worker.GroupName)
+		maxPodsAdded, maxPodsRemoved := scalePolicyLimits(worker, tier)
+		removalBudget := scaleBudget(scaleHistory.removed, worker.GroupName, maxPodsRemoved, time.Now())
+		for _, podsToDelete := range worker.ScaleStrategy.WorkersToDelete {
+			if removalBudget <= 0 {
+				// The Pods that are already gone do not count against the ScalePolicy.
+				if slices.ContainsFunc(workerPods.Items, func(pod corev1.Pod) bool { return pod.Name == podsToDelete }) {
+					logger.Info("reconcilePods", "scalePolicy limits the Pods removed from worker group", worker.GroupName, "Pod", podsToDelete)
+					rateLimitedGroups = append(rateLimitedGroups, worker.GroupName)
+					rateLimitedWaits = append(rateLimitedWaits, scaleBudgetResetAfter(scaleHistory.removed, worker.GroupName, time.Now()))
+				}
+				continue
+			}
+			pod := corev1.Pod{}
+			pod.Name = podsToDelete
+			pod.Namespace = utils.GetNamespace(instance.ObjectMeta)
+			logger.Info("Deleting pod", "namespace", pod.Namespace, "name", pod.Name)
+			if err := r.deletePod(ctx, instance, worker.GroupName, &pod); err != nil {
+				if !errors.IsNotFound(err) {
+					logger.Info("reconcilePods", "Fail to delete Pod", pod.Name, "error", err)
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting pod %s/%s, %v", pod.Namespace, pod.Name, err)
+					return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+				}
+				logger.Info("reconcilePods", "The worker Pod has already been deleted", pod.Name)
+			} else {
+				deletedWorkers[pod.Name] = deleted
+				removalBudget--
+				scaleHistory.removed[worker.GroupName] = append(scaleHistory.removed[worker.GroupName], time.Now())
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod), "Deleted pod %s/%s", pod.Namespace, pod.Name)
+			}
+		}
+		worker.ScaleStrategy.WorkersToDelete = []string{}
+
+		// Recycle the oldest worker Pod that exceeds the maximum Pod age of the group.
+		// Its Ray node is drained before the Pod is deleted.
+		if recycledPod := getWorkerPodToRecycle(worker, workerPods.Items, deletedWorkers, time.Now()); recycledPod != nil {
+			logger.Info("reconcilePods", "recycling worker Pod", recycledPod.Name, "Worker group", worker.GroupName, "maxPodAge", worker.MaxPodAge.Duration)
+			podsToDelete, drainAfter := r.drainWorkerPods(ctx, instance, []corev1.Pod{*recycledPod}, time.Now())
+			if drainAfter > 0 {
+				drainingGroups = append(drainingGroups, worker.GroupName)
+				drainWaits = append(drainWaits, drainAfter)
+			}
+			for i := range podsToDelete {
+				pod := &podsToDelete[i]
+				if err := r.deletePod(ctx, instance, worker.GroupName, pod); err != nil && !errors.IsNotFound(err) {
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+					return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+				}
+				deletedWorkers[pod.Name] = deleted
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.RecycledWorkerPod),
+					"Deleted worker Pod %s/%s because it is older than the maxPodAge %s of worker group %s", pod.Namespace, pod.Name, worker.MaxPodAge.Duration, worker.GroupName)
+			}
+		}
+
+		// Replace the worker Pods created with outdated RayStartParams one at a time.
+		_, rayStartParamsHash, err := r.getRayStartParams(ctx, instance, worker.RayStartParams, worker.RayStartParamsFrom)
+		if err != nil {
+			return err
+		}
+		if pod := getOutdatedWorkerPod(workerPods.Items, deletedWorkers, rayStartParamsHash); pod != nil {
+			logger.Info("reconcilePods", "replacing worker Pod with outdated RayStartParams", pod.Name, "Worker group", worker.GroupName)
+			if err := r.deletePod(ctx, instance, worker.GroupName, pod); err != nil && !errors.IsNotFound(err) {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+				return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+			}
+			deletedWorkers[pod.Name] = deleted
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.ReplacedOutdatedPod),
+				"Deleted worker Pod %s/%s because it was created with outdated RayStartParams of worker group %s", pod.Namespace, pod.Name, worker.GroupName)
+		}
+
+		// Replace the worker Pods created from an outdated Pod template according to the update strategy of the group.
+		updating, numSurgePods := false, 0
+		if isRollingUpdate(worker) && worker.NumOfHosts <= 1 {
+			var podsToUpdate []*corev1.Pod
+			podsToUpdate, numSurgePods, updating = getRollingUpdatePlan(worker.UpdateStrategy.RollingUpdate, workerReplicas, workerPods.Items, deletedWorkers, utils.GeneratePodTemplateHash(worker.Template))
+			for _, pod := range podsToUpdate {
+				logger.Info("reconcilePods", "replacing worker Pod with outdated Pod template", pod.Name, "Worker group", worker.GroupName)
+				if err := r.deletePod(ctx, instance, worker.GroupName, pod); err != nil && !errors.IsNotFound(err) {
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+					return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+				}
+				deletedWorkers[pod.Name] = deleted
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.ReplacedOutdatedPod),
+					"Deleted worker Pod %s/%s because it was created from an outdated Pod template of worker group %s", pod.Namespace, pod.Name, worker.GroupName)
+			}
+		}
+
+		runningPods := corev1.PodList{}
+		for _, pod := range workerPods.Items {
+			if _, ok := deletedWorkers[pod.Name]; !ok {
+				runningPods.Items = append(runningPods.Items, pod)
+			}
+		}
+		// A replica can contain multiple hosts, so we need to calculate this based on the number of hosts per replica.
+		// If the user doesn't install the CRD with `NumOfHosts`, the zero value of `NumOfHosts`, which is 0, will be used.
+		// Hence, all workers will be deleted. Here, we set `NumOfHosts` to max(1, `NumOfHosts`) to avoid this situation.
+		if worker.NumOfHosts <= 0 {
+			worker.NumOfHosts = 1
+		}
+		if worker.NumOfHosts > 1 {
+			if err := r.reconcileMultiHostReplicas(ctx, instance, worker, workerReplicas, workerPods.Items, deletedWorkers); err != nil {
+				var backoffErr *requeueAfterError
+				if !errstd.As(err, &backoffErr) {
+					return err
+				}
+				backoffGroups = append(backoffGroups, worker.GroupName)
+				backoffWaits = append(backoffWaits, backoffErr.after)
+			}
+			continue
+		}
+		// During a rolling update, up to maxSurge Pods are created above the desired replicas.
+		numExpectedPods := workerReplicas*worker.NumOfHosts + int32(numSurgePods)
+		diff := numExpectedPods - int32(len(runningPods.Items))
+
+		logger.Info("reconcilePods", "workerReplicas", workerReplicas, "NumOfHosts", worker.NumOfHosts, "runningPods", len(runningPods.Items), "diff", diff)
+
+		if diff > 0 {
+			if budget := scaleBudget(scaleHistory.added, worker.GroupName, maxPodsAdded, time.Now()); diff > budget {
+				logger.Info("reconcilePods", "scalePolicy limits the Pods added to worker group", worker.GroupName, "diff", diff, "maxPodsAddedPerMinute", *maxPodsAdded)
+				rateLimitedGroups = append(rateLimitedGroups, worker.GroupName)
+				rateLimitedWaits = append(rateLimitedWaits, scaleBudgetResetAfter(scaleHistory.added, worker.GroupName, time.Now()))
+				diff = budget
+			}
+			// pods need to be added
+			logger.Info("reconcilePods", "Number workers to add", diff, "Worker group", worker.GroupName)
+			// create all workers of this group
+			replicaIndices := getAvailableReplicaIndices(runningPods.Items, int(diff))
+			var i int32
+			for i = 0; i < diff; i++ {
+				logger.Info("reconcilePods", "creating worker for group", worker.GroupName, fmt.Sprintf("index %d", i), fmt.Sprintf("in total %d", diff))
+				if err := r.createWorkerPod(ctx, *instance, *worker.DeepCopy(), replicaIndices[i], nil); err != nil {
+					var backoffErr *requeueAfterError
+					if !errstd.As(err, &backoffErr) {
+						return errstd.Join(utils.ErrFailedCreateWorkerPod, err)
+					}
+					backoffGroups = append(backoffGroups, worker.GroupName)
+					backoffWaits = append(backoffWaits, backoffErr.after)
+					break
+				}
+				scaleHistory.added[worker.GroupName] = append(scaleHistory.added[worker.GroupName], time.Now())
+			}
+		} else if diff == 0 {
+			logger.Info("reconcilePods", "all workers already exist for group", worker.GroupName)
+			continue
+		} else if updating {
+			// The rolling update deletes the surplus outdated Pods once the new Pods are available.
+			logger.Info("reconcilePods", "waiting for the rolling update of worker group", worker.GroupName)
+		} else {
+			// diff < 0 indicates the need to delete some Pods to match the desired number of replicas.
+			if isRandomPodDeleteEnabled(instance) {
+				// diff < 0 means that we need to delete some Pods to meet the desired number of replicas.
+				randomlyRemovedWorkers := -diff
+				if budget := scaleBudget(scaleHistory.removed, worker.GroupName, maxPodsRemoved, time.Now()); randomlyRemovedWorkers > budget {
+					logger.Info("reconcilePods", "scalePolicy limits the Pods removed from worker group", worker.GroupName, "maxPodsRemovedPerMinute", *maxPodsRemoved)
+					rateLimitedGroups = append(rateLimitedGroups, worker.GroupName)
+					rateLimitedWaits = append(rateLimitedWaits, scaleBudgetResetAfter(scaleHistory.removed, worker.GroupName, time.Now()))
+					randomlyRemovedWorkers = budget
+				}
+				logger.Info("reconcilePods", "Number workers to delete randomly", randomlyRemovedWorkers, "Worker group", worker.GroupName)
+				for i := 0; i < int(randomlyRemovedWorkers); i++ {
+					randomPodToDelete := runningPods.Items[i]
+					logger.Info("Randomly deleting Pod", "progress", fmt.Sprintf("%d / %d", i+1, randomlyRemovedWorkers), "with name", randomPodToDelete.Name)
+					if err := r.deletePod(ctx, instance, worker.GroupName, &randomPodToDelete); err != nil {
+						if !errors.IsNotFound(err) {
+							r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting Pod %s/%s, %v", randomPodToDelete.Namespace, randomPodToDelete.Name, err)
+							return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+						}
+						logger.Info("reconcilePods", "The worker Pod has already been deleted", randomPodToDelete.Name)
+					}
+					scaleHistory.removed[worker.GroupName] = append(scaleHistory.removed[worker.GroupName], time.Now())
+					r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod), "Deleted Pod %s/%s", randomPodToDelete.Namespace, randomPodToDelete.Name)
+				}
+			} else {
+				logger.Info(fmt.Sprintf("Random Pod deletion is disabled for cluster %s. The only decision-maker for Pod deletions is Autoscaler.", instance.Name))
+			}
+		}
+	}
+
+	if err := r.deleteMultiHostReplicaServicesOfRemovedGroups(ctx, instance); err != nil {
+		return err
+	}
+
+	var requeueAfter time.Duration
+	var requeueReasons []string
+	// Requeue the RayCluster to add or remove the remaining Pods once the scale policy window of a group frees up.
+	if len(rateLimitedGroups) > 0 {
+		slices.Sort(rateLimitedGroups)
+		requeueAfter = slices.Min(rateLimitedWaits)
+		requeueReasons = append(requeueReasons, fmt.Sprintf("the scalePolicy of worker groups %v limits the Pods added or removed", slices.Compact(rateLimitedGroups)))
+	}
+	// Requeue the RayCluster to delete the recycled Pods once their Ray nodes are drained.
+	if len(drainingGroups) > 0 {
+		if after := slices.Min(drainWaits); requeueAfter == 0 || after < requeueAfter {
+			requeueAfter = after
+		}
+		requeueReasons = append(requeueReasons, fmt.Sprintf("draining the Ray nodes of the recycled Pods of groups %v", drainingGroups))
+	}
+	// Requeue the RayCluster to retry creating the Pods of the groups once their backoff expires.
+	if len(backoffGroups) > 0 {
+		if after := slices.Min(backoffWaits); requeueAfter == 0 || after < requeueAfter {
+			requeueAfter = after
+		}
+		requeueReasons = append(requeueReasons, fmt.Sprintf("backing off from creating the Pods of groups %v", backoffGroups))
+	}
+	// Requeue the RayCluster in case the informer cache misses the events of the Pods that the groups wait for.
+	if len(waitingGroups) > 0 {
+		if requeueAfter == 0 || DefaultRequeueDuration < requeueAfter {
+			requeueAfter = DefaultRequeueDuration
+		}
+		requeueReasons = append(requeueReasons, fmt.Sprintf("waiting for the informer cache to observe the Pods of groups %v", waitingGroups))
+	}
+	if len(requeueReasons) > 0 {
+		return &requeueAfterError{after: requeueAfter, reason: strings.Join(requeueReasons, "; ")}
+	}
+	return nil
+}
+
+// isHeadPodTemplateOutdated checks whether the head Pod should be recreated because it was created from an outdated Pod
+// template, or with outdated RayStartParams sourced from a ConfigMap, whose hash is rayStartParamsHash.
+func (r *RayClusterReconciler) isHeadPodTemplateOutdated(instance *rayv1.RayCluster, headPod corev1.Pod, rayStartParamsHash string) bool {
+	strategy := instance.Spec.HeadGroupSpec.UpdateStrategy
+	if strategy == nil || strategy.Type != rayv1.RecreateHeadUpdateStrategyType {
+		return false
+	}
+	return headPod.Labels[utils.RayPodTemplateHashLabelKey] != r.headPodTemplateHash(instance) ||
+		headPod.Annotations[utils.RayStartParamsHashAnnotationKey] != rayStartParamsHash
+}
+
+// headPodTemplateHash returns the hash of the Pod template of the head group. The monitoring ports that KubeRay declares on
+// the head Pod when the PodMonitors are enabled are part of the hash, so that the RecreateHead update strategy recreates
+// the head Pods created without them, which the PodMonitors cannot scrape.
+func (r *RayClusterReconciler) headPodTemplateHash(instance *rayv1.RayCluster) string {
+	template := instance.Spec.HeadGroupSpec.Template.DeepCopy()
+	if r.enablePrometheusMonitors && len(template.Spec.Containers) > utils.RayContainerIndex {
+		pod := corev1.Pod{Spec: template.Spec}
+		common.AddMonitoringPorts(&pod)
+		template.Spec = pod.Spec
+	}
+	return utils.GeneratePodTemplateHash(*template)
+}
+
+// isRollingUpdate checks whether the worker group replaces the Pods created from an outdated Pod template gradually.
+func isRollingUpdate(worker rayv1.WorkerGroupSpec) bool {
+	return worker.UpdateStrategy != nil && worker.UpdateStrategy.Type == rayv1.RollingUpdateWorkerGroupStrategyType
+}
+
+// getRollingUpdateLimits resolves the maximum numbers of unavailable and surge Pods of a rolling update of a worker
+// group with the given number of desired replicas.
+func getRollingUpdateLimits(rollingUpdate *rayv1.RollingUpdateWorkerGroup, replicas int32) (maxUnavailable int, maxSurge int) {
+	maxUnavailable = 1
+	if rollingUpdate != nil {
+		if rollingUpdate.MaxUnavailable != nil {
+			maxUnavailable, _ = intstr.GetScaledValueFromIntOrPercent(rollingUpdate.MaxUnavailable, int(replicas), false)
+		}
+		if rollingUpdate.MaxSurge != nil {
+			maxSurge, _ = intstr.GetScaledValueFromIntOrPercent(rollingUpdate.MaxSurge, int(replicas), true)
+		}
+	}
+	// A percentage can be rounded down to 0 for small groups. Replace one Pod at a time so that the update progresses.
+	if maxUnavailable <= 0 && maxSurge <= 0 {
+		maxUnavailable = 1
+	}
+	return maxUnavailable, maxSurge
+}
+
+// getRollingUpdatePlan plans the next step of the rolling update of a single-host worker group. It returns the Pods
+// created from an outdated Pod template to delete now, the number of Pods to create above the desired replicas, and
+// whether any Pod is outdated. Like Deployments, the number of Pods never exceeds replicas + maxSurge, and the number of
+// available Pods never falls below replicas - maxUnavailable because of the update.
+func getRollingUpdatePlan(rollingUpdate *rayv1.RollingUpdateWorkerGroup, replicas int32, pods []corev1.Pod, deletedPods map[string]struct{}, hash string) ([]*corev1.Pod, int, bool) {
+	var outdated []*corev1.Pod
+	numPods, numAvailable := 0, 0
+	for i := range pods {
+		pod := &pods[i]
+		if _, deleted := deletedPods[pod.Name]; deleted || pod.DeletionTimestamp != nil {
+			continue
+		}
+		numPods++
+		if utils.IsRunningAndReady(pod) {
+			numAvailable++
+		}
+		if pod.Labels[utils.RayPodTemplateHashLabelKey] != hash {
+			outdated = append(outdated, pod)
+		}
+	}
+	if len(outdated) == 0 {
+		return nil, 0, false
+	}
+
+	maxUnavailable, maxSurge := getRollingUpdateLimits(rollingUpdate, replicas)
+	// Pods above the desired replicas are only created to replace outdated Pods.
+	numSurgePods := min(int(replicas)+maxSurge, int(replicas)+len(outdated)) - max(numPods, int(replicas))
+	if numSurgePods < 0 {
+		numSurgePods = 0
+	}
+
+	// Delete the outdated Pods that are not available first, because deleting them does not reduce the availability.
+	sort.SliceStable(outdated, func(i, j int) bool {
+		return !utils.IsRunningAndReady(outdated[i]) && utils.IsRunningAndReady(outdated[j])
+	})
+	minAvailable := int(replicas) - maxUnavailable
+	podBudget, availableBudget := numPods-minAvailable, numAvailable-minAvailable
+	var podsToDelete []*corev1.Pod
+	for _, pod := range outdated {
+		if podBudget <= 0 {
+			break
+		}
+		if utils.IsRunningAndReady(pod) {
+			if availableBudget <= 0 {
+				break
+			}
+			availableBudget--
+		}
+		podBudget--
+		podsToDelete = append(podsToDelete, pod)
+	}
+	return podsToDelete, numSurgePods, true
+}
+
+// getOutdatedWorkerPod returns a worker Pod whose RayStartParams hash differs from the given one, or nil if no Pod
+// should be replaced now. Like the recycling of old Pods, Pods are replaced one at a time.
+func getOutdatedWorkerPod(pods []corev1.Pod, deletedPods map[string]struct{}, hash string) *corev1.Pod {
+	var outdated *corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if _, deleted := deletedPods[pod.Name]; deleted || pod.DeletionTimestamp != nil || !utils.IsRunningAndReady(pod) {
+			return nil
+		}
+		if outdated == nil && pod.Annotations[utils.RayStartParamsHashAnnotationKey] != hash {
+			outdated = pod
+		}
+	}
+	return outdated
+}
+
+// getWorkerPodToRecycle returns the oldest worker Pod that is older than the maxPodAge of the worker group, or nil if no
+// Pod should be recycled now. Pods are recycled one at a time: nothing is recycled while any Pod of the group is not
+// running and ready, e.g. because the replacement of the previously recycled Pod is still starting.
+func getWorkerPodToRecycle(worker rayv1.WorkerGroupSpec, pods []corev1.Pod, deletedPods map[string]struct{}, now time.Time) *corev1.Pod {
+	if worker.MaxPodAge == nil || worker.MaxPodAge.Duration <= 0 {
+		return nil
+	}
+	var oldest *corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		// The Pod whose Ray node is draining is recycled first, even if it is no longer ready once its node is drained.
+		if _, draining := pod.Annotations[utils.RayDrainDeadlineAnnotationKey]; draining && pod.DeletionTimestamp == nil {
+			if _, deleted := deletedPods[pod.Name]; !deleted {
+				return pod
+			}
+		}
+	}
+	for i := range pods {
+		pod := &pods[i]
+		if _, deleted := deletedPods[pod.Name]; deleted || pod.DeletionTimestamp != nil || !utils.IsRunningAndReady(pod) {
+			return nil
+		}
+		if now.Sub(pod.CreationTimestamp.Time) < worker.MaxPodAge.Duration {
+			continue
+		}
+		if oldest == nil || pod.CreationTimestamp.Before(&oldest.CreationTimestamp) {
+			oldest = pod
+		}
+	}
+	return oldest
+}
+
+// isPodStuck returns whether the Pod has been terminating, or in the Unknown phase because its node stopped reporting
+// its status, for longer than the timeout, and the reason. Such Pods are typically on a node that is gone, and are not
+// removed until the node comes back or the Pod is force-deleted.
+func isPodStuck(pod corev1.Pod, timeout time.Duration, now time.Time) (bool, string) {
+	if pod.DeletionTimestamp != nil && now.Sub(pod.DeletionTimestamp.Time) > timeout {
+		return true, fmt.Sprintf("the Pod has been terminating since %s, longer than %s", pod.DeletionTimestamp.UTC().Format(time.RFC3339), timeout)
+	}
+	if pod.Status.Phase == corev1.PodUnknown {
+		// The Ready condition turns False or Unknown when the node stops reporting the status of the Pod. While it is
+		// still True, its LastTransitionTime is when the Pod became ready, not when its phase became Unknown.
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady && cond.Status != corev1.ConditionTrue && now.Sub(cond.LastTransitionTime.Time) > timeout {
+				return true, fmt.Sprintf("the Pod has been in the %s phase since %s, longer than %s", corev1.PodUnknown, cond.LastTransitionTime.UTC().Format(time.RFC3339), timeout)
+			}
+		}
+	}
+	return false, ""
+}
+
+// isRandomPodDeleteEnabled returns whether KubeRay may delete worker Pods of its own choosing to match the desired
+// number of replicas. Randomly deleting Pods is certainly not ideal. So, if autoscaling is enabled for the cluster, we
+// disable random Pod deletion, making Autoscaler the sole decision-maker for Pod deletions.
+func isRandomPodDeleteEnabled(instance *rayv1.RayCluster) bool {
+	// Case 1: If Autoscaler is disabled, we will always enable random Pod deletion no matter the value of the feature flag.
+	// Case 2: If Autoscaler is enabled, we will respect the value of the feature flag. If the feature flag environment variable
+	// is not set, we will disable random Pod deletion by default.
+	enableInTreeAutoscaling := (instance.Spec.EnableInTreeAutoscaling != nil) && (*instance.Spec.EnableInTreeAutoscaling)
+	if !enableInTreeAutoscaling {
+		return true
+	}
+	// TODO (kevin85421): `ENABLE_RANDOM_POD_DELETE` is a feature flag for KubeRay v0.6.0. If users want to use
+	// the old behavior, they can set the environment variable `ENABLE_RANDOM_POD_DELETE` to `true`. When the
+	// default behavior is stable enough, we can remove this feature flag.
+	return strings.ToLower(os.Getenv(utils.ENABLE_RANDOM_POD_DELETE)) == "true"
+}
+
+// reconcileWarmPool keeps the standby Pods of the worker groups with a warmPoolSize, and deletes the ones of the other
+// worker groups and of suspended RayClusters.
+func (r *RayClusterReconciler) reconcileWarmPool(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	standbyPods := corev1.PodList{}
+	if err := r.List(ctx, &standbyPods, common.RayClusterWarmPoolPodsAssociationOptions(instance).ToCachedPodListOptions()...); err != nil {
+		return err
+	}
+	podsByGroup := map[string][]corev1.Pod{}
+	for _, pod := range standbyPods.Items {
+		group := pod.Labels[utils.RayNodeGroupLabelKey]
+		podsByGroup[group] = append(podsByGroup[group], pod)
+	}
+
+	suspended := instance.Spec.Suspend != nil && *instance.Spec.Suspend
+	var podsToDelete []corev1.Pod
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		pods := podsByGroup[worker.GroupName]
+		delete(podsByGroup, worker.GroupName)
+		if !r.podExpectationsSatisfied(instance, warmPoolExpectationsGroup(worker.GroupName), pods) {
+			logger.Info("reconcileWarmPool", "waiting for the informer cache to observe the standby Pods of worker group", worker.GroupName)
+			continue
+		}
+
+		desired, releasing := int64(0), false
+		if worker.WarmPoolSize != nil && !suspended {
+			var err error
+			if desired, releasing, err = r.desiredWarmPoolSize(ctx, instance, worker); err != nil {
+				return err
+			}
+		}
+
+		// Standby Pods that exited are replaced.
+		var activePods []corev1.Pod
+		for _, pod := range pods {
+			if !pod.DeletionTimestamp.IsZero() {
+				continue
+			}
+			if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+				podsToDelete = append(podsToDelete, pod)
+				continue
+			}
+			activePods = append(activePods, pod)
+		}
+
+		diff := desired - int64(len(activePods))
+		if diff > 0 {
+			logger.Info("reconcileWarmPool", "creating standby Pods of worker group", worker.GroupName, "number", diff)
+			for i := int64(0); i < diff; i++ {
+				if err := r.createWarmPoolPod(ctx, *instance, worker); err != nil {
+					return err
+				}
+			}
+		} else if diff < 0 {
+			// Standby Pods released for workers waiting to be scheduled should free their nodes, so the scheduled ones
+			// go first. Otherwise, the ones that are not scheduled yet go first.
+			sort.SliceStable(activePods, func(i, j int) bool {
+				return (activePods[i].Spec.NodeName != "") == releasing && (activePods[j].Spec.NodeName != "") != releasing
+			})
+			podsToDelete = append(podsToDelete, activePods[:-diff]...)
+		}
+	}
+	// The remaining standby Pods belong to worker groups removed from the RayCluster.
+	for _, pods := range podsByGroup {
+		for _, pod := range pods {
+			if pod.DeletionTimestamp.IsZero() {
+				podsToDelete = append(podsToDelete, pod)
+			}
+		}
+	}
+
+	for i := range podsToDelete {
+		pod := &podsToDelete[i]
+		if err := r.Delete(ctx, pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWarmPoolPod),
+				"Failed deleting standby Pod %s/%s; Pod status: %s; Pod reason: %s; Pod message: %s, %v", pod.Namespace, pod.Name, pod.Status.Phase, pod.Status.Reason, pod.Status.Message, err)
+			return err
+		}
+		r.expectPodDeletion(instance, warmPoolExpectationsGroup(pod.Labels[utils.RayNodeGroupLabelKey]), pod.Name)
+		common.PodDeletedCounterInc(instance.Namespace, instance.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWarmPoolPod),
+			"Deleted standby Pod %s/%s; Pod status: %s", pod.Namespace, pod.Name, pod.Status.Phase)
+	}
+	return nil
+}
+
+// desiredWarmPoolSize returns the number of standby Pods of the worker group, and whether some of them are released
+// for workers of the group waiting to be scheduled. Multi-host replicas count for numOfHosts Pods.
+func (r *RayClusterReconciler) desiredWarmPoolSize(ctx context.Context, instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec) (int64, bool, error) {
+	workerPods := corev1.PodList{}
+	if err := r.List(ctx, &workerPods, common.RayClusterGroupPodsAssociationOptions(instance, worker.GroupName).ToCachedPodListOptions()...); err != nil {
+		return 0, false, err
+	}
+	unscheduled := int64(0)
+	for _, pod := range workerPods.Items {
+		if pod.DeletionTimestamp.IsZero() && pod.Spec.NodeName == "" {
+			unscheduled++
+		}
+	}
+
+	hosts := int64(max(worker.NumOfHosts, 1))
+	maxReplicas := int64(ptr.Deref(worker.MaxReplicas, math.MaxInt32))
+	replicas := int64(utils.GetWorkerGroupDesiredReplicas(ctx, worker))
+	desired := min(int64(*worker.WarmPoolSize), (maxReplicas-replicas)*hosts)
+	return max(desired-unscheduled, 0), unscheduled > 0, nil
+}
+
+// warmPoolExpectationsGroup is the group of the podExpectations of the standby Pods of the worker group. It cannot
+// clash with the name of a group, which is a label value.
+func warmPoolExpectationsGroup(group string) string {
+	return group + "/" + utils.RayWarmPoolLabelKey
+}
+
+func (r *RayClusterReconciler) createWarmPoolPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	pod := r.buildWarmPoolPod(ctx, instance, worker)
+	if err := r.Create(ctx, &pod); err != nil {
+		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateWarmPoolPod),
+			"Failed to create standby Pod of worker group %s, %v", worker.GroupName, err)
+		return err
+	}
+	r.expectPodCreation(&instance, warmPoolExpectationsGroup(worker.GroupName), pod.Name)
+	common.PodCreatedCounterInc(instance.Namespace, instance.Name)
+	logger.Info("Created standby Pod for RayCluster", "name", pod.Name, "group", worker.GroupName)
+	r.Recorder.Eventf(&instance, corev1.EventTypeNormal, string(utils.CreatedWarmPoolPod),
+		"Created standby Pod %s/%s of worker group %s", pod.Namespace, pod.Name, worker.GroupName)
+	return nil
+}
+
+// podExpectationsTimeout is how long KubeRay waits for the informer cache to observe the Pods it created or deleted
+// before it makes new decisions about the Pods of their group regardless, e.g. because a created Pod was deleted by
+// someone else before the informer cache observed it.
+const podExpectationsTimeout = 30 * time.Second
+
+// podExpectations tracks the Pods of a RayCluster that KubeRay created or deleted but the informer cache has not
+// observed yet. Decisions based on a Pod list missing them would create or delete the same Pods again.
+type podExpectations struct {
+	uid types.UID
+	// created and deleted map the names of the Pods to their groups.
+	created  map[string]string
+	deleted  map[string]string
+	deadline time.Time
+}
+
+// getPodExpectations returns the podExpectations of the RayCluster, discarding the ones of a deleted RayCluster with
+// the same name.
+func (r *RayClusterReconciler) getPodExpectations(instance *rayv1.RayCluster) *podExpectations {
+	key := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	if value, ok := r.podExpectations.Load(key); ok && value.(*podExpectations).uid == instance.UID {
+		return value.(*podExpectations)
+	}
+	expectations := &podExpectations{uid: instance.UID, created: map[string]string{}, deleted: map[string]string{}}
+	r.podExpectations.Store(key, expectations)
+	return expectations
+}
+
+func (r *RayClusterReconciler) expectPodCreation(instance *rayv1.RayCluster, group string, name string) {
+	expectations := r.getPodExpectations(instance)
+	expectations.created[name] = group
+	expectations.deadline = time.Now().Add(podExpectationsTimeout)
+}
+
+func (r *RayClusterReconciler) expectPodDeletion(instance *rayv1.RayCluster, group string, name string) {
+	expectations := r.getPodExpectations(instance)
+	expectations.deleted[name] = group
+	expectations.deadline = time.Now().Add(podExpectationsTimeout)
+}
+
+// podExpectationsSatisfied returns whether the given Pods of the group, listed from the informer cache, include all the
+// Pods of the group created earlier and reflect all the Pods of the group deleted earlier.
+func (r *RayClusterReconciler) podExpectationsSatisfied(instance *rayv1.RayCluster, group string, pods []corev1.Pod) bool {
+	expectations := r.getPodExpectations(instance)
+	if time.Now().After(expectations.deadline) {
+		expectations.created = map[string]string{}
+		expectations.deleted = map[string]string{}
+		return true
+	}
+	listed := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		listed[pods[i].Name] = &pods[i]
+	}
+	satisfied := true
+	for name, g := range expectations.created {
+		if g != group {
+			continue
+		}
+		if _, ok := listed[name]; ok {
+			delete(expectations.created, name)
+		} else {
+			satisfied = false
+		}
+	}
+	for name, g := range expectations.deleted {
+		if g != group {
+			continue
+		}
+		if pod, ok := listed[name]; !ok || pod.DeletionTimestamp != nil {
+			delete(expectations.deleted, name)
+		} else {
+			satisfied = false
+		}
+	}
+	return satisfied
+}
+
+// scalePolicyLimits returns the maximum number of Pods that can be added and removed per minute in the worker group.
+// The environment tier of the RayCluster provides the limits of the worker groups without a ScalePolicy.
+func scalePolicyLimits(worker rayv1.WorkerGroupSpec, tier *configapi.EnvironmentTierPolicy) (maxPodsAdded, maxPodsRemoved *int32) {
+	if worker.ScalePolicy != nil {
+		return worker.ScalePolicy.MaxPodsAddedPerMinute, worker.ScalePolicy.MaxPodsRemovedPerMinute
+	}
+	if tier != nil {
+		return tier.MaxPodsAddedPerMinute, tier.MaxPodsRemovedPerMinute
+	}
+	return nil, nil
+}
+
+// scalePolicyWindow is the period over which the ScalePolicy of a worker group limits the Pods added and removed.
+const scalePolicyWindow = time.Minute
+
+// scaleHistory records when KubeRay added and removed the Pods of the worker groups of a RayCluster to match their
+// desired replicas, so that their ScalePolicy can be enforced across reconciliations. The history is kept in memory
+// only, so it resets when the operator restarts or another replica becomes the leader.
+type scaleHistory struct {
+	uid types.UID
+	// added and removed map the names of the worker groups to the times their Pods were created or deleted.
+	added   map[string][]time.Time
+	removed map[string][]time.Time
+}
+
+// getScaleHistory returns the scaleHistory of the RayCluster, discarding the one of a deleted RayCluster with the same
+// name.
+func (r *RayClusterReconciler) getScaleHistory(instance *rayv1.RayCluster) *scaleHistory {
+	key := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	if value, ok := r.scaleHistories.Load(key); ok && value.(*scaleHistory).uid == instance.UID {
+		return value.(*scaleHistory)
+	}
+	history := &scaleHistory{uid: instance.UID, added: map[string][]time.Time{}, removed: map[string][]time.Time{}}
+	r.scaleHistories.Store(key, history)
+	return history
+}
+
+// scaleBudget returns how many more Pods of the group can be added, or removed, within the scale policy window under
+// the given limit, and forgets the Pods added or removed before the window. It returns math.MaxInt32 if there is no
+// limit.
+func scaleBudget(history map[string][]time.Time, group string, limit *int32, now time.Time) int32 {
+	recent := []time.Time{}
+	for _, t := range history[group] {
+		if now.Sub(t) < scalePolicyWindow {
+			recent = append(recent, t)
+		}
+	}
+	history[group] = recent
+	if limit == nil {
+		return math.MaxInt32
+	}
+	return max(*limit-int32(len(recent)), 0)
+}
+
+// scaleBudgetResetAfter returns how long until the oldest Pod of the group added, or removed, within the scale policy
+// window leaves it, so that the budget of the group grows again.
+func scaleBudgetResetAfter(history map[string][]time.Time, group string, now time.Time) time.Duration {
+	if len(history[group]) == 0 {
+		return scalePolicyWindow
+	}
+	return max(scalePolicyWindow-now.Sub(slices.MinFunc(history[group], func(a, b time.Time) int { return a.Compare(b) })), time.Second)
+}
+
+// podCreationBackoffMaxDelay is the maximum delay before KubeRay retries to create the Pods of a group after a failure.
+const podCreationBackoffMaxDelay = 5 * time.Minute
+
+// podCreationBackoffKey identifies the head Pod or a worker group of a RayCluster in its podCreationBackoff. The head
+// Pod is keyed by its node type only, so that it never shares the backoff of a worker group named like the head group.
+type podCreationBackoffKey struct {
+	nodeType rayv1.RayNodeType
+	group    string
+}
+
+func (k podCreationBackoffKey) String() string {
+	if k.nodeType == rayv1.HeadNode {
+		return "the head Pod"
+	}
+	return fmt.Sprintf("the Pods of group %s", k.group)
+}
+
+// podCreationBackoff records the consecutive failures to create the Pods of the head and the groups of a RayCluster, so
+// that KubeRay backs off exponentially instead of retrying, e.g. against an exhausted quota, at every reconciliation.
+type podCreationBackoff struct {
+	uid types.UID
+	// failures and retryAfter map the head and the groups to their consecutive failures and the time before which
+	// KubeRay does not retry.
+	failures   map[podCreationBackoffKey]int
+	retryAfter map[podCreationBackoffKey]time.Time
+}
+
+// getPodCreationBackoff returns the podCreationBackoff of the RayCluster, discarding the one of a deleted RayCluster
+// with the same name.
+func (r *RayClusterReconciler) getPodCreationBackoff(instance *rayv1.RayCluster) *podCreationBackoff {
+	key := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	if value, ok := r.podCreationBackoffs.Load(key); ok && value.(*podCreationBackoff).uid == instance.UID {
+		return value.(*podCreationBackoff)
+	}
+	backoff := &podCreationBackoff{uid: instance.UID, failures: map[podCreationBackoffKey]int{}, retryAfter: map[podCreationBackoffKey]time.Time{}}
+	r.podCreationBackoffs.Store(key, backoff)
+	return backoff
+}
+
+// checkPodCreationBackoff returns a requeueAfterError if KubeRay is backing off from creating the Pods of the head, or
+// of the group for a worker, so that the RayCluster is requeued when the backoff expires instead of failing and
+// compounding the backoff of the rate limiter of the controller.
+func (r *RayClusterReconciler) checkPodCreationBackoff(instance *rayv1.RayCluster, key podCreationBackoffKey) error {
+	backoff := r.getPodCreationBackoff(instance)
+	if retryAfter, ok := backoff.retryAfter[key]; ok && time.Now().Before(retryAfter) {
+		return &requeueAfterError{
+			after: time.Until(retryAfter),
+			reason: fmt.Sprintf("backing off from creating %s after %d consecutive failures until %s",
+				key, backoff.failures[key], retryAfter.Format(time.RFC3339)),
+		}
+	}
+	return nil
+}
+
+// recordPodCreation records whether KubeRay succeeded to create a Pod of the head, or of the group for a worker. The
+// delay before the next retry starts at DefaultRequeueDuration and doubles on each consecutive failure up to
+// podCreationBackoffMaxDelay.
+func (r *RayClusterReconciler) recordPodCreation(instance *rayv1.RayCluster, key podCreationBackoffKey, err error) {
+	backoff := r.getPodCreationBackoff(instance)
+	if err == nil {
+		delete(backoff.failures, key)
+		delete(backoff.retryAfter, key)
+		return
+	}
+	backoff.failures[key]++
+	delay := podCreationBackoffMaxDelay
+	if shift := backoff.failures[key] - 1; shift < 16 {
+		delay = min(DefaultRequeueDuration<<shift, podCreationBackoffMaxDelay)
+	}
+	backoff.retryAfter[key] = time.Now().Add(delay)
+}
+
+// deletePod deletes the Pod if it is still the Pod that KubeRay listed, so that a Pod recreated with the same name is
+// not deleted based on the state of its predecessor, and expects the informer cache to observe the deletion. The
+// ResourceVersion is not a precondition because the kubelet updates the status of the Pods continuously.
+func (r *RayClusterReconciler) deletePod(ctx context.Context, instance *rayv1.RayCluster, group string, pod *corev1.Pod, opts ...client.DeleteOption) error {
+	if pod.UID != "" {
+		opts = append(opts, client.Preconditions{UID: ptr.To(pod.UID)})
+	}
+	if err := r.Delete(ctx, pod, opts...); err != nil {
+		return err
+	}
+	r.expectPodDeletion(instance, group, pod.Name)
+	common.PodDeletedCounterInc(instance.Namespace, instance.Name)
+	return nil
+}
+
+// drainWorkerPods drains the Ray nodes of the worker Pods before KubeRay deletes them, so that the work running on them
+// can finish instead of failing, and returns the Pods that can be deleted now and how long until the earliest drain
+// deadline of the others. The nodes are drained by a Ray job submitted through the dashboard, like the placement groups,
+// and a Pod can be deleted once the deadline in its RayDrainDeadlineAnnotationKey annotation has passed or it is no
+// longer ready. The Pods are deleted without draining if their nodes cannot be drained, e.g. because the head Pod is not
+// ready, so that a failure to drain never blocks their deletion.
+func (r *RayClusterReconciler) drainWorkerPods(ctx context.Context, instance *rayv1.RayCluster, pods []corev1.Pod, now time.Time) ([]corev1.Pod, time.Duration) {
+	logger := ctrl.LoggerFrom(ctx)
+	var drained, undrained []corev1.Pod
+	var after time.Duration
+	for _, pod := range pods {
+		if !utils.IsRunningAndReady(&pod) || pod.Status.PodIP == "" {
+			drained = append(drained, pod)
+			continue
+		}
+		value, ok := pod.Annotations[utils.RayDrainDeadlineAnnotationKey]
+		if !ok {
+			undrained = append(undrained, pod)
+			continue
+		}
+		deadline, err := time.Parse(time.RFC3339, value)
+		if err != nil || !now.Before(deadline) {
+			drained = append(drained, pod)
+			continue
+		}
+		if after == 0 || deadline.Sub(now) < after {
+			after = deadline.Sub(now)
+		}
+	}
+	if len(undrained) == 0 {
+		return drained, after
+	}
+
+	if err := r.submitDrainNodesJob(ctx, instance, undrained); err != nil {
+		logger.Info("drainWorkerPods", "deleting the Pods without draining their Ray nodes", len(undrained), "error", err)
+		return append(drained, undrained...), after
+	}
+	deadline := now.Add(common.WorkerDrainTimeout)
+	for _, pod := range undrained {
+		// The Pod is copied so that the annotations of the Pod in the informer cache are not modified.
+		drainingPod := pod.DeepCopy()
+		if drainingPod.Annotations == nil {
+			drainingPod.Annotations = map[string]string{}
+		}
+		drainingPod.Annotations[utils.RayDrainDeadlineAnnotationKey] = deadline.UTC().Format(time.RFC3339)
+		if err := r.Patch(ctx, drainingPod, client.MergeFrom(&pod)); err != nil {
+			logger.Info("drainWorkerPods", "deleting the Pod whose drain deadline cannot be recorded", pod.Name, "error", err)
+			drained = append(drained, pod)
+			continue
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DrainingWorkerPod),
+			"Draining the Ray node of Pod %s/%s before deleting it at %s", pod.Namespace, pod.Name, deadline.UTC().Format(time.RFC3339))
+		if after == 0 || common.WorkerDrainTimeout < after {
+			after = common.WorkerDrainTimeout
+		}
+	}
+	return drained, after
+}
+
+// submitDrainNodesJob submits the Ray job draining the Ray nodes of the worker Pods through the dashboard of the head
+// Pod, unless it was already submitted.
+func (r *RayClusterReconciler) submitDrainNodesJob(ctx context.Context, instance *rayv1.RayCluster, pods []corev1.Pod) error {
+	if r.dashboardClientFunc == nil {
+		return fmt.Errorf("no dashboard client is configured")
+	}
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		return err
+	}
+	if headPod == nil || !utils.IsRunningAndReady(headPod) {
+		return fmt.Errorf("the head Pod is not ready")
+	}
+
+	request, err := common.BuildDrainNodesJobRequest(instance, pods)
+	if err != nil {
+		return err
+	}
+	dashboardURL, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		return err
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, dashboardURL, instance); err != nil {
+		return err
+	}
+	if _, err := rayDashboardClient.GetJobInfo(ctx, request.SubmissionId); err == nil {
+		return nil
+	} else if !errors.IsBadRequest(err) {
+		return err
+	}
+	_, err = rayDashboardClient.SubmitJobReq(ctx, request, &request.SubmissionId)
+	return err
+}
+
+// multiHostReplicaCreationGracePeriod is how long a newly created multi-host replica may miss hosts in the informer
+// cache before KubeRay considers it broken and deletes it.
+const multiHostReplicaCreationGracePeriod = 30 * time.Second
+
+// multiHostReplicaKey identifies a replica of a multi-host worker group across reconciliations.
+type multiHostReplicaKey struct {
+	cluster types.UID
+	group   string
+	replica int
+}
+
+// multiHostIndices is the position of a worker Pod within a multi-host worker group.
+type multiHostIndices struct {
+	replica int
+	host    int
+}
+
+// groupPodsByMultiHostReplica groups the worker Pods of a multi-host worker group by their replica index and host index.
+// Pods without valid indices, e.g. Pods created before the group became multi-host, and Pods that duplicate the host of
+// another Pod in the same replica are returned separately because they cannot be part of any replica.
+func groupPodsByMultiHostReplica(pods []corev1.Pod, numOfHosts int32) (map[int]map[int]corev1.Pod, []corev1.Pod) {
+	replicas := make(map[int]map[int]corev1.Pod)
+	var unassigned []corev1.Pod
+	for _, pod := range pods {
+		replicaIndex, err := strconv.Atoi(pod.Labels[utils.RayReplicaIndexKey])
+		if err != nil || replicaIndex < 0 {
+			unassigned = append(unassigned, pod)
+			continue
+		}
+		hostIndex, err := strconv.Atoi(pod.Labels[utils.RayHostIndexKey])
+		if err != nil || hostIndex < 0 || hostIndex >= int(numOfHosts) {
+			unassigned = append(unassigned, pod)
+			continue
+		}
+		if _, ok := replicas[replicaIndex]; !ok {
+			replicas[replicaIndex] = make(map[int]corev1.Pod)
+		}
+		if _, ok := replicas[replicaIndex][hostIndex]; ok {
+			unassigned = append(unassigned, pod)
+			continue
+		}
+		replicas[replicaIndex][hostIndex] = pod
+	}
+	return replicas, unassigned
+}
+
+// reconcileMultiHostReplicas reconciles the worker Pods of a worker group whose replicas span multiple hosts
+// (numOfHosts > 1), such as TPU Pod slices. The Pods of a replica are labeled with the replica index and their host
+// index, and a headless service per replica lets its hosts resolve each other.
+//
+// Replicas are atomic: the Pods of a replica are created and deleted together. If a host Pod of a replica fails or is
+// deleted, e.g. by Autoscaler in WorkersToDelete, the other hosts are deleted as well and a new replica replaces it.
+//
+// deletedPods are the names of the Pods deleted in this reconciliation, e.g. the ones in WorkersToDelete.
+func (r *RayClusterReconciler) reconcileMultiHostReplicas(ctx context.Context, instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec, workerReplicas int32, pods []corev1.Pod, deletedPods map[string]struct{}) error {
+	logger := ctrl.LoggerFrom(ctx)
+	replicaKey := func(index int) multiHostReplicaKey {
+		return multiHostReplicaKey{cluster: instance.UID, group: worker.GroupName, replica: index}
+	}
+
+	// Deleted and terminating Pods are not part of any replica. Their replicas are broken, and their replica indices
+	// are not reused until they are gone, so that new Pods do not share hostnames with them.
+	reservedIndices := make(map[int]bool)
+	activePods := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if _, deleted := deletedPods[pod.Name]; deleted || pod.DeletionTimestamp != nil {
+			if index, err := strconv.Atoi(pod.Labels[utils.RayReplicaIndexKey]); err == nil {
+				reservedIndices[index] = true
+			}
+			continue
+		}
+		activePods = append(activePods, pod)
+	}
+	replicas, unassigned := groupPodsByMultiHostReplica(activePods, worker.NumOfHosts)
+
+	podsToDelete := unassigned
+	if len(unassigned) > 0 && instance.Spec.EnableInTreeAutoscaling != nil && *instance.Spec.EnableInTreeAutoscaling {
+		// Autoscaler is the only decision-maker for the deletion of running Pods, so the Pods without valid indices are
+		// left to it instead of being deleted behind its back.
+		logger.Info("reconcilePods", "skipping the deletion of Pods without valid multi-host indices in worker group", worker.GroupName, "Pods", len(unassigned))
+		podsToDelete = nil
+	}
+	deleteReplica := func(index int) {
+		for _, pod := range replicas[index] {
+			podsToDelete = append(podsToDelete, pod)
+		}
+		delete(replicas, index)
+		reservedIndices[index] = true
+		r.multiHostReplicaCreations.Delete(replicaKey(index))
+	}
+	for index, hosts := range replicas {
+		if len(hosts) == int(worker.NumOfHosts) {
+			r.multiHostReplicaCreations.Delete(replicaKey(index))
+			continue
+		}
+		createdAt, ok := r.multiHostReplicaCreations.Load(replicaKey(index))
+		if !reservedIndices[index] && ok && time.Since(createdAt.(time.Time)) < multiHostReplicaCreationGracePeriod {
+			// The informer cache has not observed all the Pods of the recently created replica yet.
+			continue
+		}
+		logger.Info("reconcilePods", "deleting incomplete multi-host replica", index, "Worker group", worker.GroupName, "hosts", len(hosts), "NumOfHosts", worker.NumOfHosts)
+		deleteReplica(index)
+	}
+
+	if surplus := len(replicas) - int(workerReplicas); surplus > 0 {
+		// Prefer deleting the replicas whose creation is still in progress, and then the ones with the largest indices.
+		candidates := make([]int, 0, len(replicas))
+		for index := range replicas {
+			candidates = append(candidates, index)
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			iComplete := len(replicas[candidates[i]]) == int(worker.NumOfHosts)
+			jComplete := len(replicas[candidates[j]]) == int(worker.NumOfHosts)
+			if iComplete != jComplete {
+				return !iComplete
+			}
+			return candidates[i] > candidates[j]
+		})
+		if isRandomPodDeleteEnabled(instance) {
+			for _, index := range candidates[:surplus] {
+				logger.Info("reconcilePods", "deleting multi-host replica", index, "Worker group", worker.GroupName)
+				deleteReplica(index)
+			}
+		} else {
+			logger.Info(fmt.Sprintf("Random Pod deletion is disabled for cluster %s. The only decision-maker for Pod deletions is Autoscaler.", instance.Name))
+		}
+	}
+	for _, pod := range podsToDelete {
+		if err := r.deletePod(ctx, instance, worker.GroupName, &pod); err != nil {
+			if !errors.IsNotFound(err) {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+				return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+			}
+			logger.Info("reconcilePods", "The worker Pod has already been deleted", pod.Name)
+			continue
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod), "Deleted Pod %s/%s", pod.Namespace, pod.Name)
+	}
+
+	// Create new replicas with the smallest unused indices.
+	var remainingPods []corev1.Pod
+	for _, hosts := range replicas {
+		for _, pod := range hosts {
+			remainingPods = append(remainingPods, pod)
+		}
+	}
+	var newReplicas []int
+	for index := 0; len(replicas) < int(workerReplicas); index++ {
+		if _, ok := replicas[index]; ok || reservedIndices[index] {
+			continue
+		}
+		replicas[index] = nil
+		newReplicas = append(newReplicas, index)
+	}
+
+	logger.Info("reconcilePods", "workerReplicas", workerReplicas, "NumOfHosts", worker.NumOfHosts, "runningPods", len(remainingPods), "newReplicas", newReplicas)
+	podIndices := getAvailableReplicaIndices(remainingPods, len(newReplicas)*int(worker.NumOfHosts))
+	for i, index := range newReplicas {
+		if err := r.createMultiHostReplicaService(ctx, instance, worker.GroupName, index); err != nil {
+			return err
+		}
+		r.multiHostReplicaCreations.Store(replicaKey(index), time.Now())
+		for host := 0; host < int(worker.NumOfHosts); host++ {
+			podIndex := podIndices[i*int(worker.NumOfHosts)+host]
+			if err := r.createWorkerPod(ctx, *instance, *worker.DeepCopy(), podIndex, &multiHostIndices{replica: index, host: host}); err != nil {
+				var backoffErr *requeueAfterError
+				if errstd.As(err, &backoffErr) {
+					return backoffErr
+				}
+				return errstd.Join(utils.ErrFailedCreateWorkerPod, err)
 			}
 		}
-		worker.ScaleStrategy.WorkersToDelete = []string{}
-
-		runningPods := corev1.PodList{}
-		for _, pod := range workerPods.Items {
-			if _, ok := deletedWorkers[pod.Name]; !ok {
-				runningPods.Items = append(runningPods.Items, pod)
-			}
+	}
+
+	return r.deleteStaleMultiHostReplicaServices(ctx, instance, worker.GroupName, replicas)
+}
+
+// deleteMultiHostReplicasOfPods deletes the remaining Pods of the multi-host replicas that the deleted Pods belong to, and
+// adds them to deletedPods.
+func (r *RayClusterReconciler) deleteMultiHostReplicasOfPods(ctx context.Context, instance *rayv1.RayCluster, pods []corev1.Pod, deletedPods map[string]struct{}) error {
+	brokenReplicas := make(map[string]bool)
+	for _, pod := range pods {
+		if _, ok := deletedPods[pod.Name]; ok && pod.Labels[utils.RayReplicaIndexKey] != "" {
+			brokenReplicas[pod.Labels[utils.RayReplicaIndexKey]] = true
+		}
+	}
+	for _, pod := range pods {
+		if _, ok := deletedPods[pod.Name]; ok || pod.DeletionTimestamp != nil || !brokenReplicas[pod.Labels[utils.RayReplicaIndexKey]] {
+			continue
+		}
+		if err := r.deletePod(ctx, instance, pod.Labels[utils.RayNodeGroupLabelKey], &pod); err != nil && !errors.IsNotFound(err) {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+			return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+		}
+		deletedPods[pod.Name] = struct{}{}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod),
+			"Deleted Pod %s/%s because another host of its multi-host replica %s was deleted", pod.Namespace, pod.Name, pod.Labels[utils.RayReplicaIndexKey])
+	}
+	return nil
+}
+
+// createMultiHostReplicaService creates the headless service of a replica of a multi-host worker group if it does not exist.
+func (r *RayClusterReconciler) createMultiHostReplicaService(ctx context.Context, instance *rayv1.RayCluster, groupName string, replicaIndex int) error {
+	svc := common.BuildHeadlessServiceForMultiHostReplica(*instance, groupName, replicaIndex)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(svc), &corev1.Service{}); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	return r.createService(ctx, svc, instance)
+}
+
+// deleteStaleMultiHostReplicaServices deletes the headless services of the replicas of a multi-host worker group that no
+// longer exist.
+func (r *RayClusterReconciler) deleteStaleMultiHostReplicaServices(ctx context.Context, instance *rayv1.RayCluster, groupName string, replicas map[int]map[int]corev1.Pod) error {
+	logger := ctrl.LoggerFrom(ctx)
+	services := corev1.ServiceList{}
+	if err := r.List(ctx, &services, common.RayClusterGroupPodsAssociationOptions(instance, groupName).ToListOptions()...); err != nil {
+		return err
+	}
+	for _, svc := range services.Items {
+		replicaIndex, err := strconv.Atoi(svc.Labels[utils.RayReplicaIndexKey])
+		if err != nil {
+			continue
+		}
+		if _, ok := replicas[replicaIndex]; ok {
+			continue
 		}
-		// A replica can contain multiple hosts, so we need to calculate this based on the number of hosts per replica.
-		// If the user doesn't install the CRD with `NumOfHosts`, the zero value of `NumOfHosts`, which is 0, will be used.
-		// Hence, all workers will be deleted. Here, we set `NumOfHosts` to max(1, `NumOfHosts`) to avoid this situation.
-		if worker.NumOfHosts <= 0 {
-			worker.NumOfHosts = 1
+		if err := r.Delete(ctx, &svc); err != nil && !errors.IsNotFound(err) {
+			return err
 		}
-		numExpectedPods := workerReplicas * worker.NumOfHosts
-		diff := numExpectedPods - int32(len(runningPods.Items))
-
-		logger.Info("reconcilePods", "workerReplicas", workerReplicas, "NumOfHosts", worker.NumOfHosts, "runningPods", len(runningPods.Items), "diff", diff)
+		logger.Info("Deleted the headless service of a removed multi-host replica", "name", svc.Name)
+	}
+	return nil
+}
 
-		if diff > 0 {
-			// pods need to be added
-			logger.Info("reconcilePods", "Number workers to add", diff, "Worker group", worker.GroupName)
-			// create all workers of this group
-			var i int32
-			for i = 0; i < diff; i++ {
-				logger.Info("reconcilePods", "creating worker for group", worker.GroupName, fmt.Sprintf("index %d", i), fmt.Sprintf("in total %d", diff))
-				if err := r.createWorkerPod(ctx, *instance, *worker.DeepCopy()); err != nil {
-					return errstd.Join(utils.ErrFailedCreateWorkerPod, err)
-				}
-			}
-		} else if diff == 0 {
-			logger.Info("reconcilePods", "all workers already exist for group", worker.GroupName)
+// deleteMultiHostReplicaServicesOfRemovedGroups deletes the headless services of the replicas of the worker groups that
+// were removed from the RayCluster or are no longer multi-host. The services of the remaining multi-host groups are
+// cleaned up by deleteStaleMultiHostReplicaServices.
+func (r *RayClusterReconciler) deleteMultiHostReplicaServicesOfRemovedGroups(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	multiHostGroups := make(map[string]bool)
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if worker.NumOfHosts > 1 {
+			multiHostGroups[worker.GroupName] = true
+		}
+	}
+	services := corev1.ServiceList{}
+	if err := r.List(ctx, &services, common.RayClusterAllPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return err
+	}
+	for _, svc := range services.Items {
+		if _, ok := svc.Labels[utils.RayReplicaIndexKey]; !ok || multiHostGroups[svc.Labels[utils.RayNodeGroupLabelKey]] {
 			continue
-		} else {
-			// diff < 0 indicates the need to delete some Pods to match the desired number of replicas. However,
-			// randomly deleting Pods is certainly not ideal. So, if autoscaling is enabled for the cluster, we
-			// will disable random Pod deletion, making Autoscaler the sole decision-maker for Pod deletions.
-			enableInTreeAutoscaling := (instance.Spec.EnableInTreeAutoscaling != nil) && (*instance.Spec.EnableInTreeAutoscaling)
-
-			// TODO (kevin85421): `enableRandomPodDelete` is a feature flag for KubeRay v0.6.0. If users want to use
-			// the old behavior, they can set the environment variable `ENABLE_RANDOM_POD_DELETE` to `true`. When the
-			// default behavior is stable enough, we can remove this feature flag.
-			enableRandomPodDelete := false
-			if enableInTreeAutoscaling {
-				if s := os.Getenv(utils.ENABLE_RANDOM_POD_DELETE); strings.ToLower(s) == "true" {
-					enableRandomPodDelete = true
-				}
-			}
-			// Case 1: If Autoscaler is disabled, we will always enable random Pod deletion no matter the value of the feature flag.
-			// Case 2: If Autoscaler is enabled, we will respect the value of the feature flag. If the feature flag environment variable
-			// is not set, we will disable random Pod deletion by default.
-			if !enableInTreeAutoscaling || enableRandomPodDelete {
-				// diff < 0 means that we need to delete some Pods to meet the desired number of replicas.
-				randomlyRemovedWorkers := -diff
-				logger.Info("reconcilePods", "Number workers to delete randomly", randomlyRemovedWorkers, "Worker group", worker.GroupName)
-				for i := 0; i < int(randomlyRemovedWorkers); i++ {
-					randomPodToDelete := runningPods.Items[i]
-					logger.Info("Randomly deleting Pod", "progress", fmt.Sprintf("%d / %d", i+1, randomlyRemovedWorkers), "with name", randomPodToDelete.Name)
-					if err := r.Delete(ctx, &randomPodToDelete); err != nil {
-						if !errors.IsNotFound(err) {
-							r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting Pod %s/%s, %v", randomPodToDelete.Namespace, randomPodToDelete.Name, err)
-							return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
-						}
-						logger.Info("reconcilePods", "The worker Pod has already been deleted", randomPodToDelete.Name)
-					}
-					r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod), "Deleted Pod %s/%s", randomPodToDelete.Namespace, randomPodToDelete.Name)
-				}
-			} else {
-				logger.Info(fmt.Sprintf("Random Pod deletion is disabled for cluster %s. The only decision-maker for Pod deletions is Autoscaler.", instance.Name))
-			}
 		}
+		if err := r.Delete(ctx, &svc); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		logger.Info("Deleted the headless service of a replica of a removed multi-host worker group", "name", svc.Name)
 	}
 	return nil
 }
 
+// isWorkerPodInterrupted checks whether the worker Pod runs on a spot or preemptible instance that is being
+// interrupted. Spot instances are detected by the `spot` field of the worker group, or by well-known node labels
+// if the field is not set. The node is read with the APIReader, so that the manager does not cache all the nodes, which
+// a namespaced operator is not allowed to watch anyway.
+func (r *RayClusterReconciler) isWorkerPodInterrupted(ctx context.Context, worker rayv1.WorkerGroupSpec, pod corev1.Pod) (bool, string) {
+	logger := ctrl.LoggerFrom(ctx)
+	if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil || (worker.Spot != nil && !*worker.Spot) {
+		return false, ""
+	}
+
+	node := &corev1.Node{}
+	if err := r.APIReader.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		if errors.IsNotFound(err) && worker.Spot != nil {
+			// The node of an interrupted instance may already be deleted.
+			return true, fmt.Sprintf("Node %s of the spot worker Pod %s does not exist", pod.Spec.NodeName, pod.Name)
+		}
+		if !errors.IsNotFound(err) {
+			logger.Info("Failed to get the node of the worker Pod to check for spot interruptions", "Pod", pod.Name, "node", pod.Spec.NodeName, "error", err)
+		}
+		return false, ""
+	}
+	if worker.Spot == nil && !common.IsSpotNode(node) {
+		return false, ""
+	}
+	return common.IsNodeInterrupted(node, time.Now())
+}
+
+// incrementGroupInterruptions increments the number of interruptions of the group in the RayCluster status.
+func incrementGroupInterruptions(status *rayv1.RayClusterStatus, groupName string) {
+	for i := range status.GroupStatuses {
+		if status.GroupStatuses[i].GroupName == groupName {
+			status.GroupStatuses[i].Interruptions++
+			return
+		}
+	}
+	status.GroupStatuses = append(status.GroupStatuses, rayv1.GroupStatus{GroupName: groupName, Interruptions: 1})
+}
+
 // shouldDeletePod returns whether the Pod should be deleted and the reason
 //
 // @param pod: The Pod to be checked.
@@ -955,8 +2872,19 @@ func (r *RayClusterReconciler) createService(ctx context.Context, svc *corev1.Se
 func (r *RayClusterReconciler) createHeadPod(ctx context.Context, instance rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
 
+	rayStartParams, hash, err := r.getRayStartParams(ctx, &instance, instance.Spec.HeadGroupSpec.RayStartParams, instance.Spec.HeadGroupSpec.RayStartParamsFrom)
+	if err != nil {
+		return err
+	}
+	instance.Spec.HeadGroupSpec.RayStartParams = rayStartParams
+
 	// build the pod then create it
 	pod := r.buildHeadPod(ctx, instance)
+	setRayStartParamsHash(&pod, hash)
+	r.warnIfPodTooLarge(ctx, &instance, pod)
+	if err := r.createLogVolumeClaim(ctx, instance, pod); err != nil {
+		return err
+	}
 	if EnableBatchScheduler {
 		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(&instance); err == nil {
 			scheduler.AddMetadataToPod(&instance, utils.RayNodeHeadGroupLabelValue, &pod)
@@ -965,20 +2893,286 @@ func (r *RayClusterReconciler) createHeadPod(ctx context.Context, instance rayv1
 		}
 	}
 
-	if err := r.Create(ctx, &pod); err != nil {
+	backoffKey := podCreationBackoffKey{nodeType: rayv1.HeadNode}
+	if err := r.checkPodCreationBackoff(&instance, backoffKey); err != nil {
+		return err
+	}
+	createCtx, span := tracing.Start(ctx, "CreatePod", attribute.String("kuberay.group", utils.RayNodeHeadGroupLabelValue))
+	err = r.Create(createCtx, &pod)
+	tracing.End(span, &err)
+	r.recordPodCreation(&instance, backoffKey, err)
+	if err != nil {
 		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateHeadPod), "Failed to create head Pod %s/%s, %v", pod.Namespace, pod.Name, err)
 		return err
 	}
+	r.expectPodCreation(&instance, utils.RayNodeHeadGroupLabelValue, pod.Name)
+	common.PodCreatedCounterInc(instance.Namespace, instance.Name)
 	logger.Info("Created head Pod for RayCluster", "name", pod.Name)
 	r.Recorder.Eventf(&instance, corev1.EventTypeNormal, string(utils.CreatedHeadPod), "Created head Pod %s/%s", pod.Namespace, pod.Name)
 	return nil
 }
 
-func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec) error {
+// getRayStartParams returns the RayStartParams of a group merged over the keys of the ConfigMap they are sourced from,
+// and the hash of the keys of the ConfigMap, which is empty if the RayStartParams are not sourced from a ConfigMap.
+func (r *RayClusterReconciler) getRayStartParams(ctx context.Context, instance *rayv1.RayCluster, rayStartParams map[string]string, from *rayv1.RayStartParamsSource) (map[string]string, string, error) {
+	if from == nil {
+		return rayStartParams, "", nil
+	}
+	configMap := &corev1.ConfigMap{}
+	// The informer cache only holds the ConfigMaps with the RayStartParamsConfigMapLabelKey label.
+	if err := r.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: from.ConfigMapRef.Name}, configMap); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToGetRayStartParams),
+			"Failed to get the RayStartParams from ConfigMap %s/%s with the label %s=true, %v", instance.Namespace, from.ConfigMapRef.Name, rayv1.RayStartParamsConfigMapLabelKey, err)
+		return nil, "", err
+	}
+	hash, err := utils.GenerateJsonHash(configMap.Data)
+	if err != nil {
+		return nil, "", err
+	}
+	merged := make(map[string]string, len(configMap.Data)+len(rayStartParams))
+	for k, v := range configMap.Data {
+		merged[k] = v
+	}
+	for k, v := range rayStartParams {
+		merged[k] = v
+	}
+	return merged, hash, nil
+}
+
+// setEffectiveRayStartParams records the RayStartParams of the groups that source them from a ConfigMap in the
+// statuses of the groups. The statuses of the groups whose ConfigMap cannot be read have no RayStartParams.
+func (r *RayClusterReconciler) setEffectiveRayStartParams(ctx context.Context, instance *rayv1.RayCluster, groupStatuses []rayv1.GroupStatus) {
+	for i := range groupStatuses {
+		rayStartParams, from := instance.Spec.HeadGroupSpec.RayStartParams, instance.Spec.HeadGroupSpec.RayStartParamsFrom
+		if groupStatuses[i].GroupName != utils.RayNodeHeadGroupLabelValue {
+			from = nil
+			for _, worker := range instance.Spec.WorkerGroupSpecs {
+				if worker.GroupName == groupStatuses[i].GroupName {
+					rayStartParams, from = worker.RayStartParams, worker.RayStartParamsFrom
+				}
+			}
+		}
+		if from == nil {
+			continue
+		}
+		effective, _, err := r.getRayStartParams(ctx, instance, rayStartParams, from)
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Info("Failed to get the effective RayStartParams", "group", groupStatuses[i].GroupName, "error", err)
+			continue
+		}
+		groupStatuses[i].RayStartParams = effective
+	}
+}
+
+// setRayStartParamsHash records the hash of the ConfigMap keys merged into the RayStartParams of the Pod.
+func setRayStartParamsHash(pod *corev1.Pod, hash string) {
+	if hash == "" {
+		return
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[utils.RayStartParamsHashAnnotationKey] = hash
+}
+
+// getRayStartParamsConfigMapNames returns the names of the ConfigMaps that the groups of the RayCluster source their
+// RayStartParams from.
+func getRayStartParamsConfigMapNames(instance *rayv1.RayCluster) []string {
+	var names []string
+	if from := instance.Spec.HeadGroupSpec.RayStartParamsFrom; from != nil {
+		names = append(names, from.ConfigMapRef.Name)
+	}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if worker.RayStartParamsFrom != nil && !slices.Contains(names, worker.RayStartParamsFrom.ConfigMapRef.Name) {
+			names = append(names, worker.RayStartParamsFrom.ConfigMapRef.Name)
+		}
+	}
+	return names
+}
+
+// rayClustersForConfigMap maps a ConfigMap to the RayClusters that source RayStartParams from it, so that their Pods
+// are replaced when it changes.
+func (r *RayClusterReconciler) rayClustersForConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	rayClusters := rayv1.RayClusterList{}
+	if err := r.List(ctx, &rayClusters, client.InNamespace(obj.GetNamespace()), client.MatchingFields{rayStartParamsConfigMapIndexField: obj.GetName()}); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list the RayClusters sourcing RayStartParams from ConfigMap", "ConfigMap", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(rayClusters.Items))
+	for _, rayCluster := range rayClusters.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rayCluster)})
+	}
+	return requests
+}
+
+// podSizeWarningThresholdBytes is the serialized size of a Pod above which KubeRay warns that the Pod approaches
+// the default request size limit of etcd (1.5 MiB).
+const podSizeWarningThresholdBytes = 1024 * 1024
+
+// podSizeCheck records the Pod templates of the head and worker groups of a RayCluster whose size was checked. It is
+// kept in memory only, so the Pod templates are checked again when the operator restarts.
+type podSizeCheck struct {
+	uid types.UID
+	// templateHashes maps the names of the head and worker groups to the hash of the Pod template last checked.
+	templateHashes map[string]string
+}
+
+// getPodSizeCheck returns the podSizeCheck of the RayCluster, discarding the one of a deleted RayCluster with the same
+// name.
+func (r *RayClusterReconciler) getPodSizeCheck(instance *rayv1.RayCluster) *podSizeCheck {
+	key := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	if value, ok := r.podSizeChecks.Load(key); ok && value.(*podSizeCheck).uid == instance.UID {
+		return value.(*podSizeCheck)
+	}
+	check := &podSizeCheck{uid: instance.UID, templateHashes: map[string]string{}}
+	r.podSizeChecks.Store(key, check)
+	return check
+}
+
+// warnIfPodTooLarge emits a warning event if the serialized size of the Pod approaches the size limit of etcd.
+// Large Pods are usually caused by large environments, which are duplicated into the injected init container unless
+// ENABLE_MINIMAL_INIT_CONTAINER_ENV is set. The Pods of a group have about the same size, so only the first Pod built
+// from each Pod template of the group is checked.
+func (r *RayClusterReconciler) warnIfPodTooLarge(ctx context.Context, instance *rayv1.RayCluster, pod corev1.Pod) {
+	logger := ctrl.LoggerFrom(ctx)
+	check := r.getPodSizeCheck(instance)
+	groupName := pod.Labels[utils.RayNodeGroupLabelKey]
+	templateHash := pod.Labels[utils.RayPodTemplateHashLabelKey]
+	if checkedHash, ok := check.templateHashes[groupName]; ok && checkedHash == templateHash {
+		return
+	}
+	check.templateHashes[groupName] = templateHash
+
+	data, err := json.Marshal(pod)
+	if err != nil {
+		logger.Error(err, "Failed to serialize Pod to calculate its size", "generateName", pod.GenerateName)
+		return
+	}
+	if len(data) < podSizeWarningThresholdBytes {
+		return
+	}
+	logger.Info("Pod approaches the size limit of etcd", "generateName", pod.GenerateName, "bytes", len(data))
+	r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.LargePod),
+		"Pod %s/%s is %d bytes and approaches the size limit of etcd; consider setting %s on the KubeRay operator or reducing the environment variables of the Ray container",
+		pod.Namespace, pod.GenerateName, len(data), utils.ENABLE_MINIMAL_INIT_CONTAINER_ENV)
+}
+
+// createLogVolumeClaim creates the PVC storing the logs of the given Ray Pod if KubeRay is asked to generate one.
+// The PVC is owned by the RayCluster, so it is reused by the Pods recreated for the same replica.
+func (r *RayClusterReconciler) createLogVolumeClaim(ctx context.Context, instance rayv1.RayCluster, pod corev1.Pod) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if instance.Spec.Logging == nil || instance.Spec.Logging.Volume == nil || instance.Spec.Logging.Volume.ClaimName != "" {
+		return nil
+	}
+
+	pvc := common.BuildLogVolumeClaim(&instance, pod, *instance.Spec.Logging.Volume)
+	if err := controllerutil.SetControllerReference(&instance, pvc, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, pvc); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreatePersistentVolumeClaim), "Failed to create PersistentVolumeClaim %s/%s, %v", pvc.Namespace, pvc.Name, err)
+		return err
+	}
+	logger.Info("Created PersistentVolumeClaim for the Ray logs", "name", pvc.Name)
+	r.Recorder.Eventf(&instance, corev1.EventTypeNormal, string(utils.CreatedPersistentVolumeClaim), "Created PersistentVolumeClaim %s/%s", pvc.Namespace, pvc.Name)
+	return nil
+}
+
+// reconcileLogVolumeClaims deletes the generated log PVCs of the replicas that the groups of the RayCluster no longer
+// have, e.g. after a scale-down or the removal of a worker group. The PVCs of the replicas whose Pods are only being
+// recreated are kept, so that the new Pods reuse the logs of their predecessors. The PVCs are listed with the APIReader,
+// so that the manager does not cache all the PVCs of the cluster.
+func (r *RayClusterReconciler) reconcileLogVolumeClaims(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if instance.Spec.Logging == nil || instance.Spec.Logging.Volume == nil || instance.Spec.Logging.Volume.ClaimName != "" {
+		return nil
+	}
+	// The replicas of a suspended RayCluster come back when it is resumed.
+	if instance.Spec.Suspend != nil && *instance.Spec.Suspend {
+		return nil
+	}
+
+	claims := corev1.PersistentVolumeClaimList{}
+	if err := r.APIReader.List(ctx, &claims, client.InNamespace(instance.Namespace), client.MatchingLabels{
+		utils.RayClusterLabelKey:          instance.Name,
+		utils.KubernetesCreatedByLabelKey: utils.ComponentName,
+	}); err != nil {
+		return err
+	}
+	if len(claims.Items) == 0 {
+		return nil
+	}
+	pods := corev1.PodList{}
+	if err := r.List(ctx, &pods, common.RayClusterAllPodsAssociationOptions(instance).ToCachedPodListOptions()...); err != nil {
+		return err
+	}
+	mountedClaims := make(map[string]bool, len(pods.Items))
+	for _, pod := range pods.Items {
+		mountedClaims[common.GetLogVolumeClaimName(pod)] = true
+	}
+	// The replica indices of the Pods of a group are in [0, desired number of Pods) once the group is scaled.
+	desiredPods := map[string]int{utils.RayNodeHeadGroupLabelValue: 1}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		desiredPods[worker.GroupName] = int(utils.GetWorkerGroupDesiredReplicas(ctx, worker) * max(worker.NumOfHosts, 1))
+	}
+
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		index, err := strconv.Atoi(claim.Labels[utils.RayWorkerReplicaIndexKey])
+		if err != nil || mountedClaims[claim.Name] {
+			continue
+		}
+		if desired, ok := desiredPods[claim.Labels[utils.RayNodeGroupLabelKey]]; ok && index < desired {
+			continue
+		}
+		if err := r.Delete(ctx, claim); client.IgnoreNotFound(err) != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeletePersistentVolumeClaim),
+				"Failed to delete PersistentVolumeClaim %s/%s, %v", claim.Namespace, claim.Name, err)
+			return err
+		}
+		logger.Info("Deleted the PersistentVolumeClaim of a removed replica", "name", claim.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedPersistentVolumeClaim),
+			"Deleted PersistentVolumeClaim %s/%s", claim.Namespace, claim.Name)
+	}
+	return nil
+}
+
+// getAvailableReplicaIndices returns the n smallest replica indices that are not used by any of the given Pods.
+func getAvailableReplicaIndices(pods []corev1.Pod, n int) []int {
+	used := make(map[int]bool, len(pods))
+	for _, pod := range pods {
+		if index, err := strconv.Atoi(pod.Labels[utils.RayWorkerReplicaIndexKey]); err == nil {
+			used[index] = true
+		}
+	}
+	indices := make([]int, 0, n)
+	for index := 0; len(indices) < n; index++ {
+		if !used[index] {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec, replicaIndex int, multiHost *multiHostIndices) error {
 	logger := ctrl.LoggerFrom(ctx)
 
+	rayStartParams, hash, err := r.getRayStartParams(ctx, &instance, worker.RayStartParams, worker.RayStartParamsFrom)
+	if err != nil {
+		return err
+	}
+	worker.RayStartParams = rayStartParams
+
 	// build the pod then create it
-	pod := r.buildWorkerPod(ctx, instance, worker)
+	pod := r.buildWorkerPod(ctx, instance, worker, replicaIndex, multiHost)
+	setRayStartParamsHash(&pod, hash)
+	r.warnIfPodTooLarge(ctx, &instance, pod)
+	if err := r.createLogVolumeClaim(ctx, instance, pod); err != nil {
+		return err
+	}
 	if EnableBatchScheduler {
 		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(&instance); err == nil {
 			scheduler.AddMetadataToPod(&instance, worker.GroupName, &pod)
@@ -987,10 +3181,20 @@ func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance ray
 		}
 	}
 
-	if err := r.Create(ctx, &pod); err != nil {
+	backoffKey := podCreationBackoffKey{nodeType: rayv1.WorkerNode, group: worker.GroupName}
+	if err := r.checkPodCreationBackoff(&instance, backoffKey); err != nil {
+		return err
+	}
+	createCtx, span := tracing.Start(ctx, "CreatePod", attribute.String("kuberay.group", worker.GroupName))
+	err = r.Create(createCtx, &pod)
+	tracing.End(span, &err)
+	r.recordPodCreation(&instance, backoffKey, err)
+	if err != nil {
 		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateWorkerPod), "Failed to create worker Pod %s/%s, %v", pod.Namespace, pod.Name, err)
 		return err
 	}
+	r.expectPodCreation(&instance, worker.GroupName, pod.Name)
+	common.PodCreatedCounterInc(instance.Namespace, instance.Name)
 	logger.Info("Created worker Pod for RayCluster", "name", pod.Name)
 	r.Recorder.Eventf(&instance, corev1.EventTypeNormal, string(utils.CreatedWorkerPod), "Created worker Pod %s/%s", pod.Namespace, pod.Name)
 	return nil
@@ -999,18 +3203,43 @@ func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance ray
 // Build head instance pod(s).
 func (r *RayClusterReconciler) buildHeadPod(ctx context.Context, instance rayv1.RayCluster) corev1.Pod {
 	logger := ctrl.LoggerFrom(ctx)
+	// Hash the Pod template before any defaulting, so that the hash matches the one the update strategy compares with.
+	templateHash := r.headPodTemplateHash(&instance)
 	podName := utils.PodGenerateName(instance.Name, rayv1.HeadNode)
 	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, instance, instance.Namespace) // Fully Qualified Domain Name
 	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
 	headPort := common.GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
 	autoscalingEnabled := instance.Spec.EnableInTreeAutoscaling
-	podConf := common.DefaultHeadPodTemplate(ctx, instance, instance.Spec.HeadGroupSpec, podName, headPort)
+	tier := r.getEnvironmentTierPolicy(ctx, &instance)
+	headSpec := instance.Spec.HeadGroupSpec
+	if tier != nil && headSpec.SafeToEvict == nil {
+		headSpec.SafeToEvict = tier.SafeToEvict
+	}
+	podConf := common.DefaultHeadPodTemplate(ctx, instance, headSpec, podName, headPort)
 	if len(r.headSidecarContainers) > 0 {
 		podConf.Spec.Containers = append(podConf.Spec.Containers, r.headSidecarContainers...)
 	}
+	if r.enableAcceleratorTolerations {
+		common.AddAcceleratorTolerations(&podConf)
+	}
+	podConf.Labels[utils.RayPodTemplateHashLabelKey] = templateHash
 	logger.Info("head pod labels", "labels", podConf.Labels)
 	creatorCRDType := getCreatorCRDType(instance)
-	pod := common.BuildPod(ctx, podConf, rayv1.HeadNode, instance.Spec.HeadGroupSpec.RayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
+	rayStartParams := common.AddAcceleratorResources(ctx, podConf, instance.Spec.HeadGroupSpec.RayStartParams, r.acceleratorResourceMapping)
+	pod := common.BuildPod(ctx, podConf, rayv1.HeadNode, rayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
+	if tier != nil {
+		applyEnvironmentTierProbes(&pod, headSpec.Template, *tier)
+		applyEnvironmentTierLogRotation(&pod, *tier)
+	}
+	if instance.Spec.Logging != nil && instance.Spec.Logging.Volume != nil {
+		common.SetLogVolume(ctx, &pod, *instance.Spec.Logging.Volume)
+	}
+	if r.nativeSidecars {
+		common.UseNativeSidecars(&pod)
+	}
+	if r.enablePrometheusMonitors {
+		common.AddMonitoringPorts(&pod)
+	}
 	// Set raycluster instance as the owner and controller
 	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
 		logger.Error(err, "Failed to set controller reference for raycluster pod")
@@ -1019,25 +3248,106 @@ func (r *RayClusterReconciler) buildHeadPod(ctx context.Context, instance rayv1.
 	return pod
 }
 
+// getEnvironmentTierPolicy returns the policy of the environment tier of the RayCluster, or nil if the RayCluster does
+// not have an environment tier.
+func (r *RayClusterReconciler) getEnvironmentTierPolicy(ctx context.Context, instance *rayv1.RayCluster) *configapi.EnvironmentTierPolicy {
+	tierName, ok := instance.Labels[utils.RayEnvironmentTierLabelKey]
+	if !ok {
+		return nil
+	}
+	tier, ok := r.environmentTiers[tierName]
+	if !ok {
+		ctrl.LoggerFrom(ctx).Info("Unknown environment tier, no defaults are enforced", "tier", tierName)
+		return nil
+	}
+	return &tier
+}
+
+// applyEnvironmentTierProbes applies the probe settings of the environment tier to the probes that KubeRay injected
+// into the Ray container. Probes defined in the Pod template are left unchanged.
+func applyEnvironmentTierProbes(pod *corev1.Pod, template corev1.PodTemplateSpec, tier configapi.EnvironmentTierPolicy) {
+	templateContainer := template.Spec.Containers[utils.RayContainerIndex]
+	rayContainer := &pod.Spec.Containers[utils.RayContainerIndex]
+	if templateContainer.LivenessProbe == nil && rayContainer.LivenessProbe != nil {
+		if tier.ProbePeriodSeconds != nil {
+			rayContainer.LivenessProbe.PeriodSeconds = *tier.ProbePeriodSeconds
+		}
+		if tier.LivenessProbeFailureThreshold != nil {
+			rayContainer.LivenessProbe.FailureThreshold = *tier.LivenessProbeFailureThreshold
+		}
+	}
+	if templateContainer.ReadinessProbe == nil && rayContainer.ReadinessProbe != nil {
+		if tier.ProbePeriodSeconds != nil {
+			rayContainer.ReadinessProbe.PeriodSeconds = *tier.ProbePeriodSeconds
+		}
+		if tier.ReadinessProbeFailureThreshold != nil {
+			rayContainer.ReadinessProbe.FailureThreshold = *tier.ReadinessProbeFailureThreshold
+		}
+	}
+}
+
+// applyEnvironmentTierLogRotation sets the log rotation of the environment tier on the Ray container, unless the Ray
+// container already sets it.
+func applyEnvironmentTierLogRotation(pod *corev1.Pod, tier configapi.EnvironmentTierPolicy) {
+	rayContainer := &pod.Spec.Containers[utils.RayContainerIndex]
+	if tier.LogRotationMaxBytes != nil && !utils.EnvVarExists(utils.RAY_ROTATION_MAX_BYTES, rayContainer.Env) {
+		rayContainer.Env = append(rayContainer.Env, corev1.EnvVar{Name: utils.RAY_ROTATION_MAX_BYTES, Value: strconv.FormatInt(*tier.LogRotationMaxBytes, 10)})
+	}
+	if tier.LogRotationBackupCount != nil && !utils.EnvVarExists(utils.RAY_ROTATION_BACKUP_COUNT, rayContainer.Env) {
+		rayContainer.Env = append(rayContainer.Env, corev1.EnvVar{Name: utils.RAY_ROTATION_BACKUP_COUNT, Value: strconv.Itoa(int(*tier.LogRotationBackupCount))})
+	}
+}
+
 func getCreatorCRDType(instance rayv1.RayCluster) utils.CRDType {
 	return utils.GetCRDType(instance.Labels[utils.RayOriginatedFromCRDLabelKey])
 }
 
 // Build worker instance pods.
-func (r *RayClusterReconciler) buildWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec) corev1.Pod {
+// multiHost is the position of the Pod within a multi-host worker group, or nil if the group is not multi-host.
+func (r *RayClusterReconciler) buildWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec, replicaIndex int, multiHost *multiHostIndices) corev1.Pod {
 	logger := ctrl.LoggerFrom(ctx)
+	// Hash the Pod template before any defaulting, so that the hash matches the one the rolling updates compare with.
+	templateHash := utils.GeneratePodTemplateHash(*worker.Template.DeepCopy())
 	podName := utils.PodGenerateName(fmt.Sprintf("%s-%s", instance.Name, worker.GroupName), rayv1.WorkerNode)
 	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, instance, instance.Namespace) // Fully Qualified Domain Name
 
 	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
 	headPort := common.GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
 	autoscalingEnabled := instance.Spec.EnableInTreeAutoscaling
+	tier := r.getEnvironmentTierPolicy(ctx, &instance)
+	if tier != nil && worker.SafeToEvict == nil {
+		worker.SafeToEvict = tier.SafeToEvict
+	}
 	podTemplateSpec := common.DefaultWorkerPodTemplate(ctx, instance, worker, podName, fqdnRayIP, headPort)
+	podTemplateSpec.Labels[utils.RayWorkerReplicaIndexKey] = strconv.Itoa(replicaIndex)
+	podTemplateSpec.Labels[utils.RayPodTemplateHashLabelKey] = templateHash
+	if multiHost != nil {
+		podTemplateSpec.Labels[utils.RayReplicaIndexKey] = strconv.Itoa(multiHost.replica)
+		podTemplateSpec.Labels[utils.RayHostIndexKey] = strconv.Itoa(multiHost.host)
+		// The hosts of a replica are resolvable as {hostname}.{subdomain}.{namespace}.svc through the headless service of the replica.
+		podTemplateSpec.Spec.Subdomain = common.MultiHostReplicaServiceName(instance.Name, worker.GroupName, multiHost.replica)
+		podTemplateSpec.Spec.Hostname = fmt.Sprintf("%s-%d", podTemplateSpec.Spec.Subdomain, multiHost.host)
+	}
 	if len(r.workerSidecarContainers) > 0 {
 		podTemplateSpec.Spec.Containers = append(podTemplateSpec.Spec.Containers, r.workerSidecarContainers...)
 	}
+	if r.enableAcceleratorTolerations {
+		common.AddAcceleratorTolerations(&podTemplateSpec)
+	}
 	creatorCRDType := getCreatorCRDType(instance)
-	pod := common.BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
+	rayStartParams := common.AddGPUNumGPUs(worker.RayStartParams, worker.GPU)
+	rayStartParams = common.AddAcceleratorResources(ctx, podTemplateSpec, rayStartParams, r.acceleratorResourceMapping)
+	pod := common.BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, rayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
+	if tier != nil {
+		applyEnvironmentTierProbes(&pod, worker.Template, *tier)
+		applyEnvironmentTierLogRotation(&pod, *tier)
+	}
+	if instance.Spec.Logging != nil && instance.Spec.Logging.Volume != nil {
+		common.SetLogVolume(ctx, &pod, *instance.Spec.Logging.Volume)
+	}
+	if r.nativeSidecars {
+		common.UseNativeSidecars(&pod)
+	}
 	// Set raycluster instance as the owner and controller
 	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
 		logger.Error(err, "Failed to set controller reference for raycluster pod")
@@ -1046,6 +3356,39 @@ func (r *RayClusterReconciler) buildWorkerPod(ctx context.Context, instance rayv
 	return pod
 }
 
+// buildWarmPoolPod builds a standby Pod of the warm pool of the worker group from a worker Pod. It keeps the
+// scheduling constraints, images and resources of the worker, but its containers only sleep until the Pod is deleted.
+// It does not carry the labels of Ray Pods, so that it is neither counted as a worker nor selected by the Services.
+func (r *RayClusterReconciler) buildWarmPoolPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec) corev1.Pod {
+	pod := r.buildWorkerPod(ctx, instance, worker, 0, nil)
+	pod.GenerateName = utils.PodGenerateName(fmt.Sprintf("%s-%s-warm", instance.Name, worker.GroupName), rayv1.WorkerNode)
+	for _, key := range []string{
+		utils.RayClusterLabelKey, utils.RayNodeTypeLabelKey, utils.RayNodeLabelKey, utils.RayIDLabelKey,
+		utils.RayWorkerReplicaIndexKey, utils.RayClusterServingServiceLabelKey,
+	} {
+		delete(pod.Labels, key)
+	}
+	pod.Labels[utils.RayWarmPoolLabelKey] = instance.Name
+	pod.Labels[utils.RayNodeGroupLabelKey] = worker.GroupName
+
+	// The init containers and volumes may wait for or depend on the rest of the RayCluster, e.g. the GCS server.
+	pod.Spec.InitContainers = nil
+	pod.Spec.Volumes = nil
+	pod.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		container.Command = []string{"/bin/sh", "-c", "trap 'exit 0' TERM; sleep infinity & wait"}
+		container.Args = nil
+		container.Ports = nil
+		container.VolumeMounts = nil
+		container.LivenessProbe = nil
+		container.ReadinessProbe = nil
+		container.StartupProbe = nil
+		container.Lifecycle = nil
+	}
+	return pod
+}
+
 func (r *RayClusterReconciler) buildRedisCleanupJob(ctx context.Context, instance rayv1.RayCluster) batchv1.Job {
 	logger := ctrl.LoggerFrom(ctx)
 
@@ -1123,8 +3466,8 @@ func (r *RayClusterReconciler) buildRedisCleanupJob(ctx context.Context, instanc
 	return redisCleanupJob
 }
 
-// SetupWithManager builds the reconciler.
-func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int) error {
+// SetupWithManager builds the reconciler. A nil rateLimiter uses the default one of controller-runtime.
+func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int, rateLimiter ratelimiter.RateLimiter) error {
 	b := ctrl.NewControllerManagedBy(mgr).
 		For(&rayv1.RayCluster{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
@@ -1132,7 +3475,8 @@ func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 			predicate.AnnotationChangedPredicate{},
 		))).
 		Owns(&corev1.Pod{}).
-		Owns(&corev1.Service{})
+		Owns(&corev1.Service{}).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.rayClustersForConfigMap))
 
 	if EnableBatchScheduler {
 		b = batchscheduler.ConfigureReconciler(b)
@@ -1141,15 +3485,10 @@ func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 	return b.
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: reconcileConcurrency,
-			LogConstructor: func(request *reconcile.Request) logr.Logger {
-				logger := ctrl.Log.WithName("controllers").WithName("RayCluster")
-				if request != nil {
-					logger = logger.WithValues("RayCluster", request.NamespacedName)
-				}
-				return logger
-			},
+			RateLimiter:             rateLimiter,
+			LogConstructor:          utils.LogConstructor(utils.RayClusterCRD),
 		}).
-		Complete(r)
+		Complete(utils.WithoutShutdownCancel(r))
 }
 
 func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *rayv1.RayCluster, reconcileErr error) (*rayv1.RayCluster, error) {
@@ -1182,8 +3521,7 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 	newInstance.Status.ObservedGeneration = newInstance.ObjectMeta.Generation
 
 	runtimePods := corev1.PodList{}
-	filterLabels := client.MatchingLabels{utils.RayClusterLabelKey: newInstance.Name}
-	if err := r.List(ctx, &runtimePods, client.InNamespace(newInstance.Namespace), filterLabels); err != nil {
+	if err := r.List(ctx, &runtimePods, common.RayClusterAllPodsAssociationOptions(newInstance).ToCachedPodListOptions()...); err != nil {
 		return nil, err
 	}
 
@@ -1192,6 +3530,11 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 	newInstance.Status.DesiredWorkerReplicas = utils.CalculateDesiredReplicas(ctx, newInstance)
 	newInstance.Status.MinWorkerReplicas = utils.CalculateMinReplicas(newInstance)
 	newInstance.Status.MaxWorkerReplicas = utils.CalculateMaxReplicas(newInstance)
+	newInstance.Status.GroupStatuses = utils.CalculateGroupStatuses(ctx, newInstance, runtimePods)
+	r.setEffectiveRayStartParams(ctx, newInstance, newInstance.Status.GroupStatuses)
+	setScheduledScalingReplicas(newInstance, newInstance.Status.GroupStatuses, time.Now())
+	r.recordScaledWorkerGroups(instance, newInstance.Status.GroupStatuses)
+	common.SetPodStartupDurationGauges(newInstance.Namespace, newInstance.Name, newInstance.Status.GroupStatuses)
 
 	totalResources := utils.CalculateDesiredResources(newInstance)
 	newInstance.Status.DesiredCPU = totalResources[corev1.ResourceCPU]
@@ -1199,12 +3542,26 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 	newInstance.Status.DesiredGPU = sumGPUs(totalResources)
 	newInstance.Status.DesiredTPU = totalResources[corev1.ResourceName("google.com/tpu")]
 
-	if utils.CheckAllPodsRunning(ctx, runtimePods) {
+	// The RayCluster is only ready once the placement groups declared by its worker groups are created.
+	if utils.CheckAllPodsRunning(ctx, runtimePods) && common.PlacementGroupsCreated(newInstance) {
 		newInstance.Status.State = rayv1.Ready //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
 	}
 
+	// Surface why Pods cannot be scheduled, so that users do not need to inspect the Pods to find out why the
+	// RayCluster does not become ready.
+	unschedulableCondition := utils.FindPodsUnschedulableCondition(runtimePods.Items)
+	newInstance.Status.Reason = ""
+	if unschedulableCondition.Status == metav1.ConditionTrue {
+		newInstance.Status.Reason = unschedulableCondition.Message
+		if instance.Status.Reason != newInstance.Status.Reason {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, string(utils.UnschedulablePods), unschedulableCondition.Message)
+		}
+	}
+
 	// Check if the head node is running and ready by checking the head pod's status.
 	if features.Enabled(features.RayClusterStatusConditions) {
+		meta.SetStatusCondition(&newInstance.Status.Conditions, unschedulableCondition)
+
 		headPod, err := common.GetRayClusterHeadPod(ctx, r, newInstance)
 		if err != nil {
 			return nil, err
@@ -1222,6 +3579,15 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 			meta.SetStatusCondition(&newInstance.Status.Conditions, headPodReadyCondition)
 		}
 
+		// Surface a wedged or crashing autoscaler, which would otherwise silently stop scaling the worker groups.
+		autoscalingEnabled := newInstance.Spec.EnableInTreeAutoscaling != nil && *newInstance.Spec.EnableInTreeAutoscaling
+		if autoscalingEnabled && headPod != nil {
+			autoscalerUnhealthyCondition := utils.FindAutoscalerUnhealthyCondition(headPod, common.AutoscalerContainerName)
+			meta.SetStatusCondition(&newInstance.Status.Conditions, autoscalerUnhealthyCondition)
+		} else {
+			meta.RemoveStatusCondition(&newInstance.Status.Conditions, string(rayv1.RayClusterAutoscalerUnhealthy))
+		}
+
 		if !meta.IsStatusConditionTrue(newInstance.Status.Conditions, string(rayv1.RayClusterProvisioned)) {
 			// RayClusterProvisioned indicates whether all Ray Pods are ready when the RayCluster is first created.
 			// Note RayClusterProvisioned StatusCondition will not be updated after all Ray Pods are ready for the first time.
@@ -1265,6 +3631,7 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 		}
 		newInstance.Status.StateTransitionTimes[newInstance.Status.State] = &timeNow //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
 	}
+	common.SetRayClusterGauges(newInstance)
 
 	return newInstance, nil
 }
@@ -1356,9 +3723,41 @@ func (r *RayClusterReconciler) updateHeadInfo(ctx context.Context, instance *ray
 	return nil
 }
 
+// recordScaledWorkerGroups fires an event for each worker group whose desired replicas changed since the last
+// reconciliation, e.g. because the autoscaler scaled it, so that users can see why the number of Pods changed.
+func (r *RayClusterReconciler) recordScaledWorkerGroups(instance *rayv1.RayCluster, groupStatuses []rayv1.GroupStatus) {
+	oldDesiredReplicas := map[string]int32{}
+	for _, groupStatus := range instance.Status.GroupStatuses {
+		if groupStatus.DesiredReplicas != nil {
+			oldDesiredReplicas[groupStatus.GroupName] = *groupStatus.DesiredReplicas
+		}
+	}
+	for _, groupStatus := range groupStatuses {
+		oldReplicas, ok := oldDesiredReplicas[groupStatus.GroupName]
+		if !ok || groupStatus.DesiredReplicas == nil || oldReplicas == *groupStatus.DesiredReplicas {
+			continue
+		}
+		direction := "up"
+		if *groupStatus.DesiredReplicas < oldReplicas {
+			direction = "down"
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.ScaledWorkerGroup),
+			"Scaled %s worker group %s from %d to %d replicas", direction, groupStatus.GroupName, oldReplicas, *groupStatus.DesiredReplicas)
+	}
+}
+
+// isAutoscalerRBACManaged returns whether KubeRay creates the ServiceAccount, Role, and RoleBinding of the autoscaler,
+// i.e. in-tree autoscaling is enabled without an existing ServiceAccount in AutoscalerOptions.
+func isAutoscalerRBACManaged(instance *rayv1.RayCluster) bool {
+	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling {
+		return false
+	}
+	return instance.Spec.AutoscalerOptions == nil || instance.Spec.AutoscalerOptions.ServiceAccountName == nil
+}
+
 func (r *RayClusterReconciler) reconcileAutoscalerServiceAccount(ctx context.Context, instance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
-	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling {
+	if !isAutoscalerRBACManaged(instance) {
 		return nil
 	}
 
@@ -1414,7 +3813,7 @@ func (r *RayClusterReconciler) reconcileAutoscalerServiceAccount(ctx context.Con
 
 func (r *RayClusterReconciler) reconcileAutoscalerRole(ctx context.Context, instance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
-	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling {
+	if !isAutoscalerRBACManaged(instance) {
 		return nil
 	}
 
@@ -1451,12 +3850,24 @@ func (r *RayClusterReconciler) reconcileAutoscalerRole(ctx context.Context, inst
 		return nil
 	}
 
+	// Narrow down the rules of the roles created by earlier versions of KubeRay. Roles created by users are left alone.
+	desiredRole, err := common.BuildRole(instance)
+	if err != nil {
+		return err
+	}
+	if metav1.IsControlledBy(role, instance) && !reflect.DeepEqual(role.Rules, desiredRole.Rules) {
+		role.Rules = desiredRole.Rules
+		if err := r.Update(ctx, role); err != nil {
+			return err
+		}
+		logger.Info("Updated the rules of the role for Ray Autoscaler", "name", role.Name)
+	}
 	return nil
 }
 
 func (r *RayClusterReconciler) reconcileAutoscalerRoleBinding(ctx context.Context, instance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
-	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling {
+	if !isAutoscalerRBACManaged(instance) {
 		return nil
 	}
 
@@ -1502,7 +3913,7 @@ func (r *RayClusterReconciler) updateRayClusterStatus(ctx context.Context, origi
 		return nil
 	}
 	logger.Info("updateRayClusterStatus", "name", originalRayClusterInstance.Name, "old status", originalRayClusterInstance.Status, "new status", newInstance.Status)
-	err := r.Status().Update(ctx, newInstance)
+	err := utils.PatchStatus(ctx, r.Client, newInstance)
 	if err != nil {
 		logger.Info("Error updating status", "name", originalRayClusterInstance.Name, "error", err, "RayCluster", newInstance)
 	}
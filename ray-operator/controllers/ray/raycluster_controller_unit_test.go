@@ -18,13 +18,20 @@ package ray
 import (
 	"context"
 	"errors"
+	"flag"
+	"fmt"
+	"math"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common/golden"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/scheme"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
@@ -34,11 +41,13 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
@@ -76,6 +85,15 @@ var (
 	workersToDelete         []string
 )
 
+// newFakeClientBuilder returns a builder of fake clients indexing the Pods like the cache of the manager does.
+func newFakeClientBuilder() *clientFake.ClientBuilder {
+	builder := clientFake.NewClientBuilder()
+	for field, extractValue := range common.PodIndexers() {
+		builder = builder.WithIndex(&corev1.Pod{}, field, extractValue)
+	}
+	return builder
+}
+
 func setupTest(t *testing.T) {
 	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
 	namespaceStr = "default"
@@ -464,7 +482,7 @@ func TestReconcile_RemoveWorkersToDelete_RandomDelete(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			// Initialize a fake client with newScheme and runtimeObjects.
-			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 			ctx := context.Background()
 			podList := corev1.PodList{}
 			err := fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
@@ -568,7 +586,7 @@ func TestReconcile_RemoveWorkersToDelete_NoRandomDelete(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			// Initialize a fake client with newScheme and runtimeObjects.
-			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 			ctx := context.Background()
 			podList := corev1.PodList{}
 			err := fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
@@ -625,7 +643,7 @@ func TestReconcile_RandomDelete_OK(t *testing.T) {
 	var localExpectReplicaNum int32 = 2
 	testRayCluster.Spec.WorkerGroupSpecs[0].Replicas = &localExpectReplicaNum
 
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 	ctx := context.Background()
 
 	podList := corev1.PodList{}
@@ -682,7 +700,7 @@ func TestReconcile_PodDeleted_Diff0_OK(t *testing.T) {
 	oldNumWorkerPods := len(testPods) - numHeadPods
 
 	// Initialize a fake client with newScheme and runtimeObjects.
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 	ctx := context.Background()
 
 	// Get the pod list from the fake client.
@@ -741,7 +759,7 @@ func TestReconcile_PodDeleted_DiffLess0_OK(t *testing.T) {
 	oldNumWorkerPods := len(testPods) - numHeadPods
 
 	// Initialize a fake client with newScheme and runtimeObjects.
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 
 	// Get the pod list from the fake client.
 	podList := corev1.PodList{}
@@ -800,7 +818,7 @@ func TestReconcile_Diff0_WorkersToDelete_OK(t *testing.T) {
 	oldNumWorkerPods := len(testPods) - numHeadPods
 
 	// Initialize a fake client with newScheme and runtimeObjects.
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 	ctx := context.Background()
 
 	// Get the pod list from the fake client.
@@ -872,7 +890,7 @@ func TestReconcile_PodCrash_DiffLess0_OK(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			// Initialize a fake client with newScheme and runtimeObjects.
-			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 			ctx := context.Background()
 
 			// Get the pod list from the fake client.
@@ -939,7 +957,7 @@ func TestReconcile_PodEvicted_DiffLess0_OK(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			fakeClient := clientFake.NewClientBuilder().
+			fakeClient := newFakeClientBuilder().
 				WithRuntimeObjects(testPods...).
 				Build()
 			ctx := context.Background()
@@ -1008,7 +1026,7 @@ func TestReconcileHeadService(t *testing.T) {
 
 	// Initialize a fake client with newScheme and runtimeObjects.
 	runtimeObjects := []runtime.Object{cluster}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	ctx := context.TODO()
 	headServiceSelector := labels.SelectorFromSet(map[string]string{
 		utils.RayClusterLabelKey:  cluster.Name,
@@ -1050,7 +1068,7 @@ func TestReconcileHeadService(t *testing.T) {
 
 	// Case 3: Two head services exist. This case only happens when users manually create a head service.
 	runtimeObjects = []runtime.Object{headService1, headService2}
-	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient = newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	serviceList = corev1.ServiceList{}
 	err = fakeClient.List(ctx, &serviceList, &client.ListOptions{
 		LabelSelector: headServiceSelector,
@@ -1081,7 +1099,7 @@ func TestReconcileHeadlessService(t *testing.T) {
 
 	// Initialize a fake client with newScheme and runtimeObjects.
 	runtimeObjects := []runtime.Object{cluster}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	ctx := context.TODO()
 
 	// Initialize RayCluster reconciler.
@@ -1149,7 +1167,7 @@ func getNotFailedPodItemNum(podList corev1.PodList) int {
 func TestReconcile_AutoscalerServiceAccount(t *testing.T) {
 	setupTest(t)
 
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 	ctx := context.Background()
 	saNamespacedName := types.NamespacedName{
 		Name:      utils.GetHeadGroupServiceAccountName(testRayCluster),
@@ -1189,7 +1207,7 @@ func TestReconcile_Autoscaler_ServiceAccountName(t *testing.T) {
 
 	// Case 1: There is no ServiceAccount "my-sa" in the Kubernetes cluster
 	runtimeObjects := []runtime.Object{}
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(runtimeObjects...).Build()
 	ctx := context.Background()
 
 	// Initialize the reconciler
@@ -1210,7 +1228,7 @@ func TestReconcile_Autoscaler_ServiceAccountName(t *testing.T) {
 
 	// Case 2: There is a ServiceAccount "my-sa" in the Kubernetes cluster
 	runtimeObjects = []runtime.Object{&myServiceAccount}
-	fakeClient = clientFake.NewClientBuilder().WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient = newFakeClientBuilder().WithRuntimeObjects(runtimeObjects...).Build()
 
 	// Initialize the reconciler
 	testRayClusterReconciler = &RayClusterReconciler{
@@ -1226,7 +1244,7 @@ func TestReconcile_Autoscaler_ServiceAccountName(t *testing.T) {
 func TestReconcile_AutoscalerRoleBinding(t *testing.T) {
 	setupTest(t)
 
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 	ctx := context.Background()
 
 	rbNamespacedName := types.NamespacedName{
@@ -1252,13 +1270,70 @@ func TestReconcile_AutoscalerRoleBinding(t *testing.T) {
 	assert.Nil(t, err, "Fail to get autoscaler RoleBinding after reconciliation")
 }
 
+func TestReconcile_AutoscalerExistingServiceAccount(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.AutoscalerOptions = &rayv1.AutoscalerOptions{ServiceAccountName: ptr.To("my-sa")}
+	fakeClient := newFakeClientBuilder().Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+
+	// KubeRay neither checks nor creates the ServiceAccount, Role, and RoleBinding of the autoscaler.
+	assert.Nil(t, testRayClusterReconciler.reconcileAutoscalerServiceAccount(ctx, cluster))
+	assert.Nil(t, testRayClusterReconciler.reconcileAutoscalerRole(ctx, cluster))
+	assert.Nil(t, testRayClusterReconciler.reconcileAutoscalerRoleBinding(ctx, cluster))
+
+	saList := corev1.ServiceAccountList{}
+	assert.Nil(t, fakeClient.List(ctx, &saList))
+	assert.Empty(t, saList.Items)
+	roleList := rbacv1.RoleList{}
+	assert.Nil(t, fakeClient.List(ctx, &roleList))
+	assert.Empty(t, roleList.Items)
+	rbList := rbacv1.RoleBindingList{}
+	assert.Nil(t, fakeClient.List(ctx, &rbList))
+	assert.Empty(t, rbList.Items)
+}
+
+func TestReconcile_AutoscalerRoleRules(t *testing.T) {
+	setupTest(t)
+
+	// A role created by an earlier version of KubeRay allows the autoscaler to patch all the RayClusters in the namespace.
+	role, err := common.BuildRole(testRayCluster)
+	assert.Nil(t, err)
+	role.Rules[1].ResourceNames = nil
+	assert.Nil(t, controllerutil.SetControllerReference(testRayCluster, role, scheme.Scheme))
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(role).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+
+	err = testRayClusterReconciler.reconcileAutoscalerRole(ctx, testRayCluster)
+	assert.Nil(t, err, "Fail to reconcile autoscaler Role")
+
+	desiredRole, err := common.BuildRole(testRayCluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, common.RayClusterAutoscalerRoleNamespacedName(testRayCluster), role)
+	assert.Nil(t, err)
+	assert.Equal(t, desiredRole.Rules, role.Rules)
+}
+
 func TestReconcile_UpdateClusterReason(t *testing.T) {
 	setupTest(t)
 
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
 
-	fakeClient := clientFake.NewClientBuilder().
+	fakeClient := newFakeClientBuilder().
 		WithScheme(newScheme).
 		WithObjects(testRayCluster).
 		WithStatusSubresource(testRayCluster).
@@ -1294,7 +1369,7 @@ func TestReconcile_UpdateClusterReason(t *testing.T) {
 func TestUpdateEndpoints(t *testing.T) {
 	setupTest(t)
 
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testServices...).Build()
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testServices...).Build()
 	ctx := context.Background()
 	testRayClusterReconciler := &RayClusterReconciler{
 		Client:   fakeClient,
@@ -1363,7 +1438,7 @@ func TestGetHeadPodIPAndNameFromGetRayClusterHeadPod(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(tc.pods...).Build()
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(tc.pods...).Build()
 
 			ip, name := "", ""
 			headPod, err := common.GetRayClusterHeadPod(context.TODO(), fakeClient, testRayCluster)
@@ -1433,7 +1508,7 @@ func TestGetHeadServiceIPAndName(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(tc.services...).Build()
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(tc.services...).Build()
 
 			testRayClusterReconciler := &RayClusterReconciler{
 				Client:   fakeClient,
@@ -1463,7 +1538,7 @@ func TestGetHeadServiceIPAndNameOnHeadlessService(t *testing.T) {
 	}
 	assert.Equal(t, headService.Spec.ClusterIP, corev1.ClusterIPNone, "BuildServiceForHeadPod returned unexpected ClusterIP")
 
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(headService).WithRuntimeObjects(testPods...).Build()
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(headService).WithRuntimeObjects(testPods...).Build()
 
 	testRayClusterReconciler := &RayClusterReconciler{
 		Client:   fakeClient,
@@ -1503,7 +1578,7 @@ func TestUpdateStatusObservedGeneration(t *testing.T) {
 	testRayCluster.Status.ObservedGeneration = -1
 
 	// Initialize a fake client with newScheme and runtimeObjects.
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	ctx := context.Background()
 
 	// Verify the initial values of `Generation` and `ObservedGeneration`.
@@ -1538,7 +1613,7 @@ func TestReconcile_UpdateClusterState(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
 
-	fakeClient := clientFake.NewClientBuilder().
+	fakeClient := newFakeClientBuilder().
 		WithScheme(newScheme).
 		WithObjects(testRayCluster).
 		WithStatusSubresource(testRayCluster).
@@ -1574,7 +1649,7 @@ func TestReconcile_UpdateClusterState(t *testing.T) {
 func TestInconsistentRayClusterStatus(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects().Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects().Build()
 	r := &RayClusterReconciler{
 		Client:   fakeClient,
 		Recorder: &record.FakeRecorder{},
@@ -1707,7 +1782,7 @@ func TestCalculateStatus(t *testing.T) {
 	runtimeObjects := []runtime.Object{headPod, headService}
 
 	// Initialize a fake client with newScheme and runtimeObjects.
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	ctx := context.Background()
 
 	// Initialize a RayCluster reconciler.
@@ -1746,7 +1821,7 @@ func TestCalculateStatus(t *testing.T) {
 		},
 	}
 	runtimeObjects = []runtime.Object{headPod, headService}
-	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient = newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	r.Client = fakeClient
 	newInstance, _ = r.calculateStatus(ctx, testRayCluster, nil)
 	assert.True(t, meta.IsStatusConditionPresentAndEqual(newInstance.Status.Conditions, string(rayv1.HeadPodReady), metav1.ConditionFalse))
@@ -1754,7 +1829,7 @@ func TestCalculateStatus(t *testing.T) {
 	// Test CheckRayHeadRunningAndReady with head pod not running
 	headPod.Status.Phase = corev1.PodFailed
 	runtimeObjects = []runtime.Object{headPod, headService}
-	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient = newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	r.Client = fakeClient
 	newInstance, _ = r.calculateStatus(ctx, testRayCluster, nil)
 	assert.True(t, meta.IsStatusConditionPresentAndEqual(newInstance.Status.Conditions, string(rayv1.HeadPodReady), metav1.ConditionFalse))
@@ -1763,6 +1838,30 @@ func TestCalculateStatus(t *testing.T) {
 	newInstance, err = r.calculateStatus(ctx, testRayCluster, errors.Join(utils.ErrFailedCreateHeadPod, errors.New("invalid")))
 	assert.Nil(t, err)
 	assert.True(t, meta.IsStatusConditionPresentAndEqual(newInstance.Status.Conditions, string(rayv1.RayClusterReplicaFailure), metav1.ConditionTrue))
+	assert.True(t, meta.IsStatusConditionPresentAndEqual(newInstance.Status.Conditions, string(rayv1.RayClusterPodsUnschedulable), metav1.ConditionFalse))
+	assert.Empty(t, newInstance.Status.Reason)
+
+	// Test the reasons why the head Pod cannot be scheduled
+	headPod.Status.Phase = corev1.PodPending
+	headPod.Status.Conditions = []corev1.PodCondition{
+		{
+			Type:    corev1.PodScheduled,
+			Status:  corev1.ConditionFalse,
+			Reason:  corev1.PodReasonUnschedulable,
+			Message: "0/1 nodes are available: 1 Insufficient nvidia.com/gpu. preemption: 0/1 nodes are available: 1 No preemption victims found for incoming pod.",
+		},
+	}
+	runtimeObjects = []runtime.Object{headPod, headService}
+	fakeClient = newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	r.Client = fakeClient
+	newInstance, err = r.calculateStatus(ctx, testRayCluster, nil)
+	assert.Nil(t, err)
+	condition := meta.FindStatusCondition(newInstance.Status.Conditions, string(rayv1.RayClusterPodsUnschedulable))
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, rayv1.InsufficientResources, condition.Reason)
+	}
+	assert.Equal(t, "1 Pod is unschedulable: Insufficient nvidia.com/gpu (1 Pod)", newInstance.Status.Reason)
 }
 
 func TestRayClusterProvisionedCondition(t *testing.T) {
@@ -1817,7 +1916,7 @@ func TestRayClusterProvisionedCondition(t *testing.T) {
 	}
 
 	runtimeObjects := append([]runtime.Object{headPod, workerPod}, testServices...)
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	ctx := context.Background()
 	r := &RayClusterReconciler{
 		Client:   fakeClient,
@@ -1894,7 +1993,7 @@ func TestStateTransitionTimes_NoStateChange(t *testing.T) {
 	runtimeObjects := []runtime.Object{headPod, headService}
 
 	// Initialize a fake client with newScheme and runtimeObjects.
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	ctx := context.Background()
 
 	// Initialize a RayCluster reconciler.
@@ -1937,7 +2036,7 @@ func Test_TerminatedWorkers_NoAutoscaler(t *testing.T) {
 	oldNumWorkerPods := len(testPods) - numHeadPods
 
 	// Initialize a fake client with newScheme and runtimeObjects.
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 	ctx := context.Background()
 
 	// Get the pod list from the fake client.
@@ -2053,7 +2152,7 @@ func Test_TerminatedHead_RestartPolicy(t *testing.T) {
 	runtimeObjects := testPods[0:1]
 	cluster := testRayCluster.DeepCopy()
 	cluster.Spec.WorkerGroupSpecs = nil
-	fakeClient := clientFake.NewClientBuilder().
+	fakeClient := newFakeClientBuilder().
 		WithScheme(newScheme).
 		WithRuntimeObjects(runtimeObjects...).
 		Build()
@@ -2140,7 +2239,7 @@ func Test_RunningPods_RayContainerTerminated(t *testing.T) {
 	runtimeObjects := testPods[0:1]
 	cluster := testRayCluster.DeepCopy()
 	cluster.Spec.WorkerGroupSpecs = nil
-	fakeClient := clientFake.NewClientBuilder().
+	fakeClient := newFakeClientBuilder().
 		WithScheme(newScheme).
 		WithRuntimeObjects(runtimeObjects...).
 		WithStatusSubresource(cluster).
@@ -2329,6 +2428,7 @@ func Test_RedisCleanupFeatureFlag(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
 	_ = corev1.AddToScheme(newScheme)
+	_ = policyv1.AddToScheme(newScheme)
 
 	// Prepare a RayCluster with the GCS FT enabled and Autoscaling disabled.
 	gcsFTEnabledCluster := testRayCluster.DeepCopy()
@@ -2369,7 +2469,7 @@ func Test_RedisCleanupFeatureFlag(t *testing.T) {
 			}
 
 			cluster := gcsFTEnabledCluster.DeepCopy()
-			fakeClient := clientFake.NewClientBuilder().
+			fakeClient := newFakeClientBuilder().
 				WithScheme(newScheme).
 				WithObjects(cluster).
 				WithStatusSubresource(cluster).
@@ -2377,9 +2477,10 @@ func Test_RedisCleanupFeatureFlag(t *testing.T) {
 
 			// Initialize the reconciler
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   newScheme,
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				Recorder:  &record.FakeRecorder{},
+				Scheme:    newScheme,
 			}
 
 			rayClusterList := rayv1.RayClusterList{}
@@ -2513,7 +2614,7 @@ func Test_RedisCleanup(t *testing.T) {
 			}
 			ctx := context.Background()
 
-			fakeClient := clientFake.NewClientBuilder().
+			fakeClient := newFakeClientBuilder().
 				WithScheme(newScheme).
 				WithRuntimeObjects(runtimeObjects...).
 				WithStatusSubresource(cluster).
@@ -2599,7 +2700,7 @@ func TestReconcile_Replicas_Optional(t *testing.T) {
 	assert.Equal(t, 1, len(testRayCluster.Spec.WorkerGroupSpecs), "This test assumes only one worker group.")
 
 	// Disable autoscaling so that the random Pod deletion is enabled.
-	testRayCluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	testRayCluster.Spec.EnableInTreeAutoscaling = nil
 	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
 
 	tests := map[string]struct {
@@ -2646,7 +2747,7 @@ func TestReconcile_Replicas_Optional(t *testing.T) {
 			oldNumWorkerPods := len(testPods) - numHeadPods
 
 			// Initialize a fake client with newScheme and runtimeObjects.
-			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 			ctx := context.Background()
 
 			// Get the pod list from the fake client.
@@ -2687,7 +2788,7 @@ func TestReconcile_Multihost_Replicas(t *testing.T) {
 
 	// Disable autoscaling so that the random Pod deletion is enabled.
 	// Set `NumOfHosts` to 4 to specify multi-host group
-	testRayCluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	testRayCluster.Spec.EnableInTreeAutoscaling = nil
 	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
 	testRayCluster.Spec.WorkerGroupSpecs[0].NumOfHosts = 4
 
@@ -2738,7 +2839,7 @@ func TestReconcile_Multihost_Replicas(t *testing.T) {
 			oldNumWorkerPods := len(testPods) - numHeadPods
 
 			// Initialize a fake client with newScheme and runtimeObjects.
-			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods...).Build()
 			ctx := context.Background()
 
 			// Get the pod list from the fake client.
@@ -2779,7 +2880,7 @@ func TestReconcile_NumOfHosts(t *testing.T) {
 
 	// Disable autoscaling so that the random Pod deletion is enabled.
 	// Set `Replicas` to 1 and clear `WorkersToDelete`
-	testRayCluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	testRayCluster.Spec.EnableInTreeAutoscaling = nil
 	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
 	testRayCluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](1)
 
@@ -2806,7 +2907,7 @@ func TestReconcile_NumOfHosts(t *testing.T) {
 
 			// Initialize a fake client with newScheme and runtimeObjects.
 			// The fake client will start with 1 head pod and 0 worker pods.
-			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0]).Build()
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods[0]).Build()
 			ctx := context.Background()
 
 			// Get the pod list from the fake client.
@@ -2841,6 +2942,307 @@ func TestReconcile_NumOfHosts(t *testing.T) {
 	}
 }
 
+func TestReconcile_MultiHostReplicas(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](2)
+	cluster.Spec.WorkerGroupSpecs[0].NumOfHosts = 2
+
+	// The fake client will start with 1 head Pod and 0 worker Pods.
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods[0]).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+
+	listWorkerPods := func() map[multiHostIndices]corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+		assert.Nil(t, err, "Fail to get pod list")
+		pods := make(map[multiHostIndices]corev1.Pod)
+		for _, pod := range podList.Items {
+			replica, err := strconv.Atoi(pod.Labels[utils.RayReplicaIndexKey])
+			assert.Nil(t, err)
+			host, err := strconv.Atoi(pod.Labels[utils.RayHostIndexKey])
+			assert.Nil(t, err)
+			pods[multiHostIndices{replica: replica, host: host}] = pod
+		}
+		return pods
+	}
+	listReplicaServices := func() []string {
+		svcList := corev1.ServiceList{}
+		err := fakeClient.List(ctx, &svcList, common.RayClusterGroupPodsAssociationOptions(cluster, groupNameStr).ToListOptions()...)
+		assert.Nil(t, err, "Fail to get service list")
+		names := []string{}
+		for _, svc := range svcList.Items {
+			names = append(names, svc.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	// Each replica consists of 2 hosts, and each replica has its own headless service.
+	err := testRayClusterReconciler.reconcilePods(ctx, cluster)
+	assert.Nil(t, err, "Fail to reconcile Pods")
+	pods := listWorkerPods()
+	assert.Len(t, pods, 4)
+	for replica := 0; replica < 2; replica++ {
+		svcName := common.MultiHostReplicaServiceName(cluster.Name, groupNameStr, replica)
+		for host := 0; host < 2; host++ {
+			pod, ok := pods[multiHostIndices{replica: replica, host: host}]
+			assert.True(t, ok, "Missing host %d of replica %d", host, replica)
+			assert.Equal(t, svcName, pod.Spec.Subdomain)
+			assert.Equal(t, fmt.Sprintf("%s-%d", svcName, host), pod.Spec.Hostname)
+		}
+	}
+	assert.Equal(t, []string{
+		common.MultiHostReplicaServiceName(cluster.Name, groupNameStr, 0),
+		common.MultiHostReplicaServiceName(cluster.Name, groupNameStr, 1),
+	}, listReplicaServices())
+
+	// Autoscaler scales down the group by deleting one host of replica 0. Although random Pod deletion is disabled,
+	// the other host of the incomplete replica and its headless service are deleted.
+	deletedPod := pods[multiHostIndices{replica: 0, host: 1}]
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](1)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{deletedPod.Name}
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	assert.Nil(t, err, "Fail to reconcile Pods")
+	pods = listWorkerPods()
+	assert.Len(t, pods, 2)
+	for host := 0; host < 2; host++ {
+		_, ok := pods[multiHostIndices{replica: 1, host: host}]
+		assert.True(t, ok, "Missing host %d of replica 1", host)
+	}
+	assert.Equal(t, []string{common.MultiHostReplicaServiceName(cluster.Name, groupNameStr, 1)}, listReplicaServices())
+
+	// Scaling up reuses the smallest unused replica index.
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](2)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	assert.Nil(t, err, "Fail to reconcile Pods")
+	pods = listWorkerPods()
+	assert.Len(t, pods, 4)
+	_, ok := pods[multiHostIndices{replica: 0, host: 0}]
+	assert.True(t, ok, "Replica 0 should be recreated")
+
+	// The headless services of the replicas are deleted once the group is no longer multi-host.
+	cluster.Spec.WorkerGroupSpecs[0].NumOfHosts = 1
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	assert.Nil(t, err, "Fail to reconcile Pods")
+	assert.Empty(t, listReplicaServices())
+}
+
+func TestReconcile_MultiHostReplicas_Atomic(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](2)
+	cluster.Spec.WorkerGroupSpecs[0].NumOfHosts = 2
+
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods[0]).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+	listWorkerPods := func() []corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+		assert.Nil(t, err, "Fail to get pod list")
+		return podList.Items
+	}
+
+	err := testRayClusterReconciler.reconcilePods(ctx, cluster)
+	assert.Nil(t, err, "Fail to reconcile Pods")
+	pods := listWorkerPods()
+	assert.Len(t, pods, 4)
+
+	// A host of replica 0 fails, so both hosts of replica 0 are deleted.
+	oldReplicaPods := map[string]bool{}
+	for _, pod := range pods {
+		if pod.Labels[utils.RayReplicaIndexKey] != "0" {
+			continue
+		}
+		oldReplicaPods[pod.Name] = true
+		if pod.Labels[utils.RayHostIndexKey] == "0" {
+			pod.Status.Phase = corev1.PodFailed
+			err = fakeClient.Status().Update(ctx, &pod)
+			assert.Nil(t, err, "Fail to update Pod status")
+		}
+	}
+	assert.Len(t, oldReplicaPods, 2)
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	assert.NotNil(t, err, "Deleting unhealthy Pods should requeue the reconciliation")
+	pods = listWorkerPods()
+	assert.Len(t, pods, 2)
+	for _, pod := range pods {
+		assert.Equal(t, "1", pod.Labels[utils.RayReplicaIndexKey])
+	}
+
+	// The replica is recreated as a whole.
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	assert.Nil(t, err, "Fail to reconcile Pods")
+	pods = listWorkerPods()
+	assert.Len(t, pods, 4)
+	for _, pod := range pods {
+		assert.False(t, oldReplicaPods[pod.Name], "Pod %s of the failed replica should be deleted", pod.Name)
+	}
+}
+
+func TestReconcileMultiHostReplicas_IncompleteReplica(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.UID = "test-uid"
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.NumOfHosts = 2
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "host-0",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   cluster.Name,
+				utils.RayNodeGroupLabelKey: worker.GroupName,
+				utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+				utils.RayReplicaIndexKey:   "0",
+				utils.RayHostIndexKey:      "0",
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		creationStartedAt *time.Time
+		expectDeleted     bool
+	}{
+		"replica not created by this controller": {
+			creationStartedAt: nil,
+			expectDeleted:     true,
+		},
+		"replica created recently": {
+			// The other host may not be observed by the informer cache yet.
+			creationStartedAt: ptr.To(time.Now()),
+			expectDeleted:     false,
+		},
+		"replica created before the grace period": {
+			creationStartedAt: ptr.To(time.Now().Add(-2 * multiHostReplicaCreationGracePeriod)),
+			expectDeleted:     true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(pod.DeepCopy()).Build()
+			testRayClusterReconciler := &RayClusterReconciler{
+				Client:   fakeClient,
+				Recorder: &record.FakeRecorder{},
+				Scheme:   scheme.Scheme,
+			}
+			key := multiHostReplicaKey{cluster: cluster.UID, group: worker.GroupName, replica: 0}
+			if tc.creationStartedAt != nil {
+				testRayClusterReconciler.multiHostReplicaCreations.Store(key, *tc.creationStartedAt)
+			}
+
+			err := testRayClusterReconciler.reconcileMultiHostReplicas(ctx, cluster, worker, 1, []corev1.Pod{pod}, map[string]struct{}{})
+			assert.Nil(t, err)
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(&pod), &corev1.Pod{})
+			assert.Equal(t, tc.expectDeleted, k8serrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestReconcileMultiHostReplicas_UnassignedPod(t *testing.T) {
+	setupTest(t)
+
+	worker := testRayCluster.Spec.WorkerGroupSpecs[0]
+	worker.NumOfHosts = 2
+	// The Pod was created before the group became multi-host, so it has no replica index.
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "single-host",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   testRayCluster.Name,
+				utils.RayNodeGroupLabelKey: worker.GroupName,
+				utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		enableInTreeAutoscaling *bool
+		expectDeleted           bool
+	}{
+		"autoscaling disabled": {
+			enableInTreeAutoscaling: nil,
+			expectDeleted:           true,
+		},
+		"autoscaling enabled": {
+			// Autoscaler decides which Pods to delete.
+			enableInTreeAutoscaling: ptr.To(true),
+			expectDeleted:           false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cluster := testRayCluster.DeepCopy()
+			cluster.Spec.EnableInTreeAutoscaling = tc.enableInTreeAutoscaling
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(pod.DeepCopy()).Build()
+			testRayClusterReconciler := &RayClusterReconciler{
+				Client:   fakeClient,
+				Recorder: &record.FakeRecorder{},
+				Scheme:   scheme.Scheme,
+			}
+
+			err := testRayClusterReconciler.reconcileMultiHostReplicas(ctx, cluster, worker, 0, []corev1.Pod{pod}, map[string]struct{}{})
+			assert.Nil(t, err)
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(&pod), &corev1.Pod{})
+			assert.Equal(t, tc.expectDeleted, k8serrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestGroupPodsByMultiHostReplica(t *testing.T) {
+	podWithIndices := func(name string, replica string, host string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{utils.RayReplicaIndexKey: replica, utils.RayHostIndexKey: host},
+			},
+		}
+	}
+
+	replicas, unassigned := groupPodsByMultiHostReplica([]corev1.Pod{
+		podWithIndices("a", "0", "0"),
+		podWithIndices("b", "0", "1"),
+		podWithIndices("c", "1", "1"),
+		podWithIndices("duplicate", "0", "1"),
+		podWithIndices("out-of-range", "1", "2"),
+		podWithIndices("no-labels", "", ""),
+	}, 2)
+
+	assert.Len(t, replicas, 2)
+	assert.Equal(t, "a", replicas[0][0].Name)
+	assert.Equal(t, "b", replicas[0][1].Name)
+	assert.Len(t, replicas[1], 1)
+	assert.Equal(t, "c", replicas[1][1].Name)
+	unassignedNames := []string{}
+	for _, pod := range unassigned {
+		unassignedNames = append(unassignedNames, pod.Name)
+	}
+	assert.Equal(t, []string{"duplicate", "out-of-range", "no-labels"}, unassignedNames)
+}
+
 func TestSumGPUs(t *testing.T) {
 	nvidiaGPUResourceName := corev1.ResourceName("nvidia.com/gpu")
 	googleTPUResourceName := corev1.ResourceName("google.com/tpu")
@@ -2882,6 +3284,185 @@ func TestSumGPUs(t *testing.T) {
 	}
 }
 
+func TestGetAvailableReplicaIndices(t *testing.T) {
+	podWithIndex := func(index string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{utils.RayWorkerReplicaIndexKey: index},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		pods     []corev1.Pod
+		n        int
+		expected []int
+	}{
+		"no existing Pods": {
+			nil,
+			3,
+			[]int{0, 1, 2},
+		},
+		"reuse the smallest unused indices": {
+			[]corev1.Pod{podWithIndex("0"), podWithIndex("2"), podWithIndex("4")},
+			3,
+			[]int{1, 3, 5},
+		},
+		"Pods without a valid index are ignored": {
+			[]corev1.Pod{podWithIndex(""), podWithIndex("foo"), podWithIndex("1")},
+			2,
+			[]int{0, 2},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getAvailableReplicaIndices(tc.pods, tc.n))
+		})
+	}
+}
+
+func TestGetWorkerPodToRecycle(t *testing.T) {
+	now := time.Now()
+	newPod := func(name string, age time.Duration, ready bool) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return pod
+	}
+	worker := rayv1.WorkerGroupSpec{MaxPodAge: &metav1.Duration{Duration: 24 * time.Hour}}
+
+	tests := map[string]struct {
+		worker      rayv1.WorkerGroupSpec
+		pods        []corev1.Pod
+		deletedPods map[string]struct{}
+		expected    string
+	}{
+		"maxPodAge is not set": {
+			worker: rayv1.WorkerGroupSpec{},
+			pods:   []corev1.Pod{newPod("old", 48*time.Hour, true)},
+		},
+		"no Pod is older than maxPodAge": {
+			worker: worker,
+			pods:   []corev1.Pod{newPod("young", time.Hour, true)},
+		},
+		"recycle the oldest Pod": {
+			worker:   worker,
+			pods:     []corev1.Pod{newPod("old", 25*time.Hour, true), newPod("oldest", 48*time.Hour, true), newPod("young", time.Hour, true)},
+			expected: "oldest",
+		},
+		"wait for the other Pods to be ready": {
+			worker: worker,
+			pods:   []corev1.Pod{newPod("old", 48*time.Hour, true), newPod("replacement", time.Minute, false)},
+		},
+		"wait for the Pods deleted in this reconciliation": {
+			worker:      worker,
+			pods:        []corev1.Pod{newPod("old", 48*time.Hour, true), newPod("deleted", 48*time.Hour, true)},
+			deletedPods: map[string]struct{}{"deleted": {}},
+		},
+		"recycle the draining Pod even if it is no longer ready": {
+			worker: worker,
+			pods: func() []corev1.Pod {
+				draining := newPod("draining", 25*time.Hour, false)
+				draining.Annotations = map[string]string{utils.RayDrainDeadlineAnnotationKey: now.Format(time.RFC3339)}
+				return []corev1.Pod{newPod("oldest", 48*time.Hour, true), draining}
+			}(),
+			expected: "draining",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			pod := getWorkerPodToRecycle(tc.worker, tc.pods, tc.deletedPods, now)
+			if tc.expected == "" {
+				assert.Nil(t, pod)
+				return
+			}
+			if assert.NotNil(t, pod) {
+				assert.Equal(t, tc.expected, pod.Name)
+			}
+		})
+	}
+}
+
+func TestIsWorkerPodInterrupted(t *testing.T) {
+	spotLabels := map[string]string{"cloud.google.com/gke-spot": "true"}
+	newNode := func(name string, labels map[string]string, ready corev1.ConditionStatus, taints ...corev1.Taint) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       corev1.NodeSpec{Taints: taints},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{
+					Type:               corev1.NodeReady,
+					Status:             ready,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * common.NodeNotReadyGracePeriod)),
+				}},
+			},
+		}
+	}
+	// The node only became not ready recently, e.g. because of a missed kubelet heartbeat.
+	recentlyNotReady := newNode("spot-recently-not-ready", spotLabels, corev1.ConditionUnknown)
+	recentlyNotReady.Status.Conditions[0].LastTransitionTime = metav1.Now()
+	nodes := []runtime.Object{
+		recentlyNotReady,
+		newNode("spot-ready", spotLabels, corev1.ConditionTrue),
+		newNode("spot-not-ready", spotLabels, corev1.ConditionUnknown),
+		newNode("spot-preempted", spotLabels, corev1.ConditionTrue, corev1.Taint{Key: "cloud.google.com/impending-node-termination", Effect: corev1.TaintEffectNoSchedule}),
+		newNode("on-demand-not-ready", nil, corev1.ConditionFalse),
+	}
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(nodes...).Build()
+	r := &RayClusterReconciler{Client: fakeClient, APIReader: fakeClient, Recorder: &record.FakeRecorder{}, Scheme: scheme.Scheme}
+
+	tests := map[string]struct {
+		spot        *bool
+		nodeName    string
+		interrupted bool
+	}{
+		"Pod is not scheduled":                          {nil, "", false},
+		"spot node is ready":                            {nil, "spot-ready", false},
+		"spot node is not ready":                        {nil, "spot-not-ready", true},
+		"spot node only became not ready recently":      {nil, "spot-recently-not-ready", false},
+		"spot node is tainted by a termination handler": {nil, "spot-preempted", true},
+		"on-demand node is not ready":                   {nil, "on-demand-not-ready", false},
+		"worker group is marked as spot":                {ptr.To(true), "on-demand-not-ready", true},
+		"worker group is marked as not spot":            {ptr.To(false), "spot-not-ready", false},
+		"node of a spot worker group is deleted":        {ptr.To(true), "deleted", true},
+		"node is deleted":                               {nil, "deleted", false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			worker := rayv1.WorkerGroupSpec{GroupName: "small-group", Spot: tc.spot}
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: namespaceStr},
+				Spec:       corev1.PodSpec{NodeName: tc.nodeName},
+			}
+			interrupted, _ := r.isWorkerPodInterrupted(context.Background(), worker, pod)
+			assert.Equal(t, tc.interrupted, interrupted)
+		})
+	}
+}
+
+func TestIncrementGroupInterruptions(t *testing.T) {
+	status := rayv1.RayClusterStatus{
+		GroupStatuses: []rayv1.GroupStatus{{GroupName: utils.RayNodeHeadGroupLabelValue}, {GroupName: "small-group", Interruptions: 1}},
+	}
+	incrementGroupInterruptions(&status, "small-group")
+	incrementGroupInterruptions(&status, "new-group")
+	assert.Equal(t, []rayv1.GroupStatus{
+		{GroupName: utils.RayNodeHeadGroupLabelValue},
+		{GroupName: "small-group", Interruptions: 2},
+		{GroupName: "new-group", Interruptions: 1},
+	}, status.GroupStatuses)
+}
+
 func TestDeleteAllPods(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(newScheme)
@@ -2913,7 +3494,7 @@ func TestDeleteAllPods(t *testing.T) {
 		},
 	}
 
-	fakeClient := clientFake.NewClientBuilder().
+	fakeClient := newFakeClientBuilder().
 		WithScheme(newScheme).
 		WithRuntimeObjects(p1, p2, p3).
 		Build()
@@ -3004,7 +3585,7 @@ func TestEvents_FailedPodCreation(t *testing.T) {
 			// We create a fake client with an interceptor for Create() in order to simulate a failure for pod creation.
 			// We return utils.ErrFailedCreateWorkerPod here because we deleted a worker pod in the previous step, so
 			// an attempt to reconcile that will take place.
-			fakeClient := clientFake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			fakeClient := newFakeClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
 				Create: func(_ context.Context, _ client.WithWatch, _ client.Object, _ ...client.CreateOption) error {
 					return test.errInject
 				},
@@ -3052,3 +3633,1387 @@ func TestEvents_FailedPodCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileHeadPodDisruptionBudget(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Labels = map[string]string{utils.RayEnvironmentTierLabelKey: "prod"}
+	fakeClient := newFakeClientBuilder().Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		environmentTiers: map[string]configapi.EnvironmentTierPolicy{
+			"dev":  {},
+			"prod": {HeadPodDisruptionBudget: true},
+		},
+	}
+	pdbKey := types.NamespacedName{Namespace: cluster.Namespace, Name: common.HeadPodDisruptionBudgetName(cluster)}
+
+	// The prod tier protects the head Pod with a PodDisruptionBudget.
+	err := testRayClusterReconciler.reconcileHeadPodDisruptionBudget(ctx, cluster)
+	assert.Nil(t, err)
+	pdb := &policyv1.PodDisruptionBudget{}
+	err = fakeClient.Get(ctx, pdbKey, pdb)
+	assert.Nil(t, err)
+	assert.Equal(t, string(rayv1.HeadNode), pdb.Spec.Selector.MatchLabels[utils.RayNodeTypeLabelKey])
+	assert.True(t, metav1.IsControlledBy(pdb, cluster))
+
+	// The PodDisruptionBudget is deleted when the RayCluster moves to a tier that does not require it.
+	cluster.Labels[utils.RayEnvironmentTierLabelKey] = "dev"
+	err = testRayClusterReconciler.reconcileHeadPodDisruptionBudget(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, pdbKey, pdb)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestReconcileLogVolumeClaims(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.Logging = &rayv1.LoggingOptions{Volume: &rayv1.LogVolumeOptions{}}
+	newPod := func(groupName string, replicaIndex int) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", groupName, replicaIndex),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:       cluster.Name,
+				utils.RayNodeTypeLabelKey:      string(rayv1.WorkerNode),
+				utils.RayNodeGroupLabelKey:     groupName,
+				utils.RayWorkerReplicaIndexKey: strconv.Itoa(replicaIndex),
+			},
+		}}
+	}
+	var objects []runtime.Object
+	for i := 0; i < int(expectReplicaNum)+2; i++ {
+		objects = append(objects, common.BuildLogVolumeClaim(cluster, newPod(groupNameStr, i), *cluster.Spec.Logging.Volume))
+	}
+	objects = append(objects, common.BuildLogVolumeClaim(cluster, newPod("removed-group", 0), *cluster.Spec.Logging.Volume))
+	// The Pod of the last replica still runs, e.g. because it is being scaled down.
+	lastPod := newPod(groupNameStr, int(expectReplicaNum)+1)
+	objects = append(objects, &lastPod)
+
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(objects...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+	}
+	err := testRayClusterReconciler.reconcileLogVolumeClaims(ctx, cluster)
+	assert.Nil(t, err)
+
+	claims := corev1.PersistentVolumeClaimList{}
+	err = fakeClient.List(ctx, &claims)
+	assert.Nil(t, err)
+	claimNames := []string{}
+	for _, claim := range claims.Items {
+		claimNames = append(claimNames, claim.Name)
+	}
+	// The PVCs of the desired replicas and of the running Pod are kept.
+	expectedClaimNames := []string{common.GetLogVolumeClaimName(lastPod)}
+	for i := 0; i < int(expectReplicaNum); i++ {
+		expectedClaimNames = append(expectedClaimNames, common.GetLogVolumeClaimName(newPod(groupNameStr, i)))
+	}
+	assert.ElementsMatch(t, expectedClaimNames, claimNames)
+}
+
+func TestReconcilePrometheusMonitors(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	fakeClient := newFakeClientBuilder().Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+	getMonitor := func(nodeType rayv1.RayNodeType) (*unstructured.Unstructured, error) {
+		monitor := &unstructured.Unstructured{}
+		monitor.SetGroupVersionKind(common.PodMonitorGVK)
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: common.PodMonitorName(cluster, nodeType)}, monitor)
+		return monitor, err
+	}
+
+	// The PodMonitors are only created if they are enabled.
+	err := testRayClusterReconciler.reconcilePrometheusMonitors(ctx, cluster)
+	assert.Nil(t, err)
+	_, err = getMonitor(rayv1.HeadNode)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	testRayClusterReconciler.enablePrometheusMonitors = true
+	testRayClusterReconciler.prometheusMonitorLabels = map[string]string{"release": "prometheus"}
+	err = testRayClusterReconciler.reconcilePrometheusMonitors(ctx, cluster)
+	assert.Nil(t, err)
+	for _, nodeType := range []rayv1.RayNodeType{rayv1.HeadNode, rayv1.WorkerNode} {
+		monitor, err := getMonitor(nodeType)
+		assert.Nil(t, err)
+		assert.Equal(t, "prometheus", monitor.GetLabels()["release"])
+		assert.True(t, metav1.IsControlledBy(monitor, cluster))
+	}
+
+	// The PodMonitors are updated to the labels configured for the operator and their spec, keeping the labels added by
+	// others.
+	monitor, err := getMonitor(rayv1.HeadNode)
+	assert.Nil(t, err)
+	monitor.SetLabels(map[string]string{"team": "ray"})
+	monitor.Object["spec"] = map[string]interface{}{"jobLabel": "outdated"}
+	assert.Nil(t, fakeClient.Update(ctx, monitor))
+	testRayClusterReconciler.prometheusMonitorLabels = map[string]string{"release": "kube-prometheus"}
+	err = testRayClusterReconciler.reconcilePrometheusMonitors(ctx, cluster)
+	assert.Nil(t, err)
+	monitor, err = getMonitor(rayv1.HeadNode)
+	assert.Nil(t, err)
+	assert.Equal(t, "kube-prometheus", monitor.GetLabels()["release"])
+	assert.Equal(t, "ray", monitor.GetLabels()["team"])
+	assert.Equal(t, utils.RayClusterLabelKey, monitor.Object["spec"].(map[string]interface{})["jobLabel"])
+
+	// The PodMonitors that are up to date are left as is.
+	resourceVersion := monitor.GetResourceVersion()
+	err = testRayClusterReconciler.reconcilePrometheusMonitors(ctx, cluster)
+	assert.Nil(t, err)
+	monitor, err = getMonitor(rayv1.HeadNode)
+	assert.Nil(t, err)
+	assert.Equal(t, resourceVersion, monitor.GetResourceVersion())
+
+	// The head Pod declares the ports of the autoscaler and dashboard metrics.
+	pod := testRayClusterReconciler.buildHeadPod(ctx, *cluster)
+	assert.NotEqual(t, -1, utils.FindContainerPort(&pod.Spec.Containers[utils.RayContainerIndex], utils.AutoscalerMetricsPortName, -1))
+	assert.NotEqual(t, -1, utils.FindContainerPort(&pod.Spec.Containers[utils.RayContainerIndex], utils.DashboardMetricsPortName, -1))
+
+	// A head Pod created before the PodMonitors were enabled is recreated by the RecreateHead update strategy, so that it
+	// declares the ports.
+	cluster.Spec.HeadGroupSpec.UpdateStrategy = &rayv1.HeadUpdateStrategy{Type: rayv1.RecreateHeadUpdateStrategyType}
+	assert.False(t, testRayClusterReconciler.isHeadPodTemplateOutdated(cluster, pod, ""))
+	testRayClusterReconciler.enablePrometheusMonitors = false
+	previousPod := testRayClusterReconciler.buildHeadPod(ctx, *cluster)
+	testRayClusterReconciler.enablePrometheusMonitors = true
+	assert.True(t, testRayClusterReconciler.isHeadPodTemplateOutdated(cluster, previousPod, ""))
+}
+
+func TestBuildWorkerPod_WithEnvironmentTier(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Labels = map[string]string{utils.RayEnvironmentTierLabelKey: "prod"}
+	testRayClusterReconciler := &RayClusterReconciler{
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+		environmentTiers: map[string]configapi.EnvironmentTierPolicy{
+			"prod": {
+				ProbePeriodSeconds:            ptr.To[int32](10),
+				LivenessProbeFailureThreshold: ptr.To[int32](3),
+				SafeToEvict:                   ptr.To(false),
+				LogRotationMaxBytes:           ptr.To[int64](1024),
+				LogRotationBackupCount:        ptr.To[int32](2),
+			},
+		},
+	}
+
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	pod := testRayClusterReconciler.buildWorkerPod(context.Background(), *cluster, worker, 0, nil)
+	rayContainer := pod.Spec.Containers[utils.RayContainerIndex]
+	assert.Equal(t, int32(10), rayContainer.LivenessProbe.PeriodSeconds)
+	assert.Equal(t, int32(3), rayContainer.LivenessProbe.FailureThreshold)
+	assert.Equal(t, int32(10), rayContainer.ReadinessProbe.PeriodSeconds)
+	assert.Equal(t, int32(utils.DefaultReadinessProbeFailureThreshold), rayContainer.ReadinessProbe.FailureThreshold)
+	assert.Equal(t, "false", pod.Annotations[utils.SafeToEvictAnnotationKey])
+	assert.Contains(t, rayContainer.Env, corev1.EnvVar{Name: utils.RAY_ROTATION_MAX_BYTES, Value: "1024"})
+	assert.Contains(t, rayContainer.Env, corev1.EnvVar{Name: utils.RAY_ROTATION_BACKUP_COUNT, Value: "2"})
+
+	// Settings in the RayCluster take precedence over the defaults of the tier.
+	worker.SafeToEvict = ptr.To(true)
+	worker.Template.Spec.Containers[utils.RayContainerIndex].LivenessProbe = &corev1.Probe{PeriodSeconds: 30}
+	worker.Template.Spec.Containers[utils.RayContainerIndex].Env = []corev1.EnvVar{{Name: utils.RAY_ROTATION_BACKUP_COUNT, Value: "5"}}
+	pod = testRayClusterReconciler.buildWorkerPod(context.Background(), *cluster, worker, 0, nil)
+	assert.Equal(t, int32(30), pod.Spec.Containers[utils.RayContainerIndex].LivenessProbe.PeriodSeconds)
+	assert.Equal(t, "true", pod.Annotations[utils.SafeToEvictAnnotationKey])
+	assert.Contains(t, pod.Spec.Containers[utils.RayContainerIndex].Env, corev1.EnvVar{Name: utils.RAY_ROTATION_BACKUP_COUNT, Value: "5"})
+	assert.NotContains(t, pod.Spec.Containers[utils.RayContainerIndex].Env, corev1.EnvVar{Name: utils.RAY_ROTATION_BACKUP_COUNT, Value: "2"})
+}
+
+func TestScalePolicyLimits(t *testing.T) {
+	tier := &configapi.EnvironmentTierPolicy{MaxPodsAddedPerMinute: ptr.To[int32](5), MaxPodsRemovedPerMinute: ptr.To[int32](2)}
+
+	// The environment tier provides the limits of the worker groups without a ScalePolicy.
+	maxPodsAdded, maxPodsRemoved := scalePolicyLimits(rayv1.WorkerGroupSpec{}, tier)
+	assert.Equal(t, int32(5), *maxPodsAdded)
+	assert.Equal(t, int32(2), *maxPodsRemoved)
+
+	// The ScalePolicy of the worker group takes precedence over the environment tier.
+	worker := rayv1.WorkerGroupSpec{ScalePolicy: &rayv1.ScalePolicy{MaxPodsAddedPerMinute: ptr.To[int32](1)}}
+	maxPodsAdded, maxPodsRemoved = scalePolicyLimits(worker, tier)
+	assert.Equal(t, int32(1), *maxPodsAdded)
+	assert.Nil(t, maxPodsRemoved)
+
+	maxPodsAdded, maxPodsRemoved = scalePolicyLimits(rayv1.WorkerGroupSpec{}, nil)
+	assert.Nil(t, maxPodsAdded)
+	assert.Nil(t, maxPodsRemoved)
+}
+
+func TestPodExpectations(t *testing.T) {
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default", UID: "uid"}}
+	r := &RayClusterReconciler{}
+	pod := func(name string, deleting bool) corev1.Pod {
+		pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if deleting {
+			pod.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		}
+		return pod
+	}
+
+	r.expectPodCreation(cluster, "group", "created")
+	r.expectPodDeletion(cluster, "group", "deleted")
+	assert.True(t, r.podExpectationsSatisfied(cluster, "other-group", nil))
+	assert.False(t, r.podExpectationsSatisfied(cluster, "group", []corev1.Pod{pod("deleted", false)}))
+	// The creation is observed, but the deletion is not.
+	assert.False(t, r.podExpectationsSatisfied(cluster, "group", []corev1.Pod{pod("created", false), pod("deleted", false)}))
+	assert.True(t, r.podExpectationsSatisfied(cluster, "group", []corev1.Pod{pod("created", false), pod("deleted", true)}))
+	// Observed expectations are forgotten.
+	assert.True(t, r.podExpectationsSatisfied(cluster, "group", nil))
+
+	// A RayCluster recreated with the same name does not inherit the expectations.
+	r.expectPodCreation(cluster, "group", "created")
+	recreated := cluster.DeepCopy()
+	recreated.UID = "new-uid"
+	assert.True(t, r.podExpectationsSatisfied(recreated, "group", nil))
+
+	// Expectations that are not observed in time are given up.
+	r.expectPodCreation(cluster, "group", "created")
+	r.getPodExpectations(cluster).deadline = time.Now().Add(-time.Second)
+	assert.True(t, r.podExpectationsSatisfied(cluster, "group", nil))
+}
+
+func TestPodCreationBackoff(t *testing.T) {
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default", UID: "uid"}}
+	r := &RayClusterReconciler{}
+	failure := errors.New("exceeded quota")
+	group := podCreationBackoffKey{nodeType: rayv1.WorkerNode, group: "group"}
+
+	assert.Nil(t, r.checkPodCreationBackoff(cluster, group))
+	r.recordPodCreation(cluster, group, failure)
+	// KubeRay requeues the RayCluster when the backoff expires instead of failing the reconciliation.
+	var requeueErr *requeueAfterError
+	if assert.ErrorAs(t, r.checkPodCreationBackoff(cluster, group), &requeueErr) {
+		assert.InDelta(t, requeueErr.after.Seconds(), DefaultRequeueDuration.Seconds(), 1)
+	}
+	assert.Nil(t, r.checkPodCreationBackoff(cluster, podCreationBackoffKey{nodeType: rayv1.WorkerNode, group: "other-group"}))
+
+	// The head Pod does not share the backoff of a worker group named like the head group, and vice versa.
+	headGroup := podCreationBackoffKey{nodeType: rayv1.WorkerNode, group: utils.RayNodeHeadGroupLabelValue}
+	r.recordPodCreation(cluster, headGroup, failure)
+	assert.Nil(t, r.checkPodCreationBackoff(cluster, podCreationBackoffKey{nodeType: rayv1.HeadNode}))
+	r.recordPodCreation(cluster, headGroup, nil)
+	r.recordPodCreation(cluster, podCreationBackoffKey{nodeType: rayv1.HeadNode}, failure)
+	assert.Nil(t, r.checkPodCreationBackoff(cluster, headGroup))
+	assert.NotNil(t, r.checkPodCreationBackoff(cluster, podCreationBackoffKey{nodeType: rayv1.HeadNode}))
+
+	// The delay doubles on each consecutive failure up to the maximum.
+	backoff := r.getPodCreationBackoff(cluster)
+	r.recordPodCreation(cluster, group, failure)
+	assert.InDelta(t, time.Until(backoff.retryAfter[group]).Seconds(), (2 * DefaultRequeueDuration).Seconds(), 1)
+	for i := 0; i < 100; i++ {
+		r.recordPodCreation(cluster, group, failure)
+	}
+	assert.InDelta(t, time.Until(backoff.retryAfter[group]).Seconds(), podCreationBackoffMaxDelay.Seconds(), 1)
+
+	// A successful creation resets the backoff.
+	r.recordPodCreation(cluster, group, nil)
+	assert.Nil(t, r.checkPodCreationBackoff(cluster, group))
+
+	// A RayCluster recreated with the same name does not inherit the backoff.
+	r.recordPodCreation(cluster, group, failure)
+	recreated := cluster.DeepCopy()
+	recreated.UID = "new-uid"
+	assert.Nil(t, r.checkPodCreationBackoff(recreated, group))
+}
+
+func TestReconcilePods_StaleCache(t *testing.T) {
+	setupTest(t)
+	testRayCluster.Spec.EnableInTreeAutoscaling = nil
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	ctx := context.Background()
+
+	fakeClient := newFakeClientBuilder().Build()
+	r := &RayClusterReconciler{Client: fakeClient, Recorder: &record.FakeRecorder{}, Scheme: scheme.Scheme}
+	err := r.reconcilePods(ctx, testRayCluster)
+	assert.Nil(t, err)
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err)
+	assert.Equal(t, 1+int(expectReplicaNum), len(podList.Items))
+
+	// The next reconciliation sees a cache that has not observed the created Pods yet, and must not create them again.
+	staleClient := newFakeClientBuilder().Build()
+	r.Client = staleClient
+	err = r.reconcilePods(ctx, testRayCluster)
+	var requeueErr *requeueAfterError
+	assert.ErrorAs(t, err, &requeueErr)
+	err = staleClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err)
+	assert.Empty(t, podList.Items)
+}
+
+func TestGetRayStartParams(t *testing.T) {
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ray-start-params", Namespace: "default"},
+		Data:       map[string]string{"num-cpus": "2", "object-store-memory": "1000000000"},
+	}
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(configMap).Build()
+	r := &RayClusterReconciler{Client: fakeClient, Recorder: &record.FakeRecorder{}, Scheme: scheme.Scheme}
+	ctx := context.Background()
+	from := &rayv1.RayStartParamsSource{ConfigMapRef: corev1.LocalObjectReference{Name: "ray-start-params"}}
+
+	// Without a ConfigMap, the RayStartParams are used as is.
+	params, hash, err := r.getRayStartParams(ctx, cluster, map[string]string{"num-cpus": "1"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"num-cpus": "1"}, params)
+	assert.Empty(t, hash)
+
+	// The RayStartParams of the group take precedence over the keys of the ConfigMap.
+	params, hash, err = r.getRayStartParams(ctx, cluster, map[string]string{"num-cpus": "1"}, from)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"num-cpus": "1", "object-store-memory": "1000000000"}, params)
+	assert.NotEmpty(t, hash)
+
+	// The hash changes with the ConfigMap.
+	configMap.Data["object-store-memory"] = "2000000000"
+	err = fakeClient.Update(ctx, configMap)
+	assert.Nil(t, err)
+	_, newHash, err := r.getRayStartParams(ctx, cluster, map[string]string{"num-cpus": "1"}, from)
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash, newHash)
+
+	// The status of the group shows the effective RayStartParams.
+	cluster.Spec.WorkerGroupSpecs = []rayv1.WorkerGroupSpec{{GroupName: "group", RayStartParams: map[string]string{"num-cpus": "1"}, RayStartParamsFrom: from}}
+	groupStatuses := []rayv1.GroupStatus{{GroupName: utils.RayNodeHeadGroupLabelValue}, {GroupName: "group"}}
+	r.setEffectiveRayStartParams(ctx, cluster, groupStatuses)
+	assert.Nil(t, groupStatuses[0].RayStartParams)
+	assert.Equal(t, map[string]string{"num-cpus": "1", "object-store-memory": "2000000000"}, groupStatuses[1].RayStartParams)
+
+	from.ConfigMapRef.Name = "missing"
+	_, _, err = r.getRayStartParams(ctx, cluster, map[string]string{}, from)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestGetOutdatedWorkerPod(t *testing.T) {
+	newPod := func(name string, hash string, ready bool) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if hash != "" {
+			pod.Annotations[utils.RayStartParamsHashAnnotationKey] = hash
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return pod
+	}
+
+	tests := map[string]struct {
+		pods        []corev1.Pod
+		deletedPods map[string]struct{}
+		hash        string
+		expected    string
+	}{
+		"no Pod is outdated": {
+			pods: []corev1.Pod{newPod("a", "new", true), newPod("b", "new", true)},
+			hash: "new",
+		},
+		"replace an outdated Pod": {
+			pods:     []corev1.Pod{newPod("a", "new", true), newPod("b", "old", true)},
+			hash:     "new",
+			expected: "b",
+		},
+		"the group no longer sources RayStartParams from a ConfigMap": {
+			pods:     []corev1.Pod{newPod("a", "old", true)},
+			expected: "a",
+		},
+		"wait for the other Pods to be ready": {
+			pods: []corev1.Pod{newPod("a", "old", true), newPod("replacement", "new", false)},
+			hash: "new",
+		},
+		"wait for the Pods deleted in this reconciliation": {
+			pods:        []corev1.Pod{newPod("a", "old", true), newPod("deleted", "old", true)},
+			deletedPods: map[string]struct{}{"deleted": {}},
+			hash:        "new",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			pod := getOutdatedWorkerPod(tc.pods, tc.deletedPods, tc.hash)
+			if tc.expected == "" {
+				assert.Nil(t, pod)
+				return
+			}
+			if assert.NotNil(t, pod) {
+				assert.Equal(t, tc.expected, pod.Name)
+			}
+		})
+	}
+}
+
+func TestIsHeadPodTemplateOutdated(t *testing.T) {
+	setupTest(t)
+
+	r := &RayClusterReconciler{}
+	cluster := testRayCluster.DeepCopy()
+	headPod := testPods[0].(*corev1.Pod).DeepCopy()
+	assert.False(t, r.isHeadPodTemplateOutdated(cluster, *headPod, ""))
+
+	cluster.Spec.HeadGroupSpec.UpdateStrategy = &rayv1.HeadUpdateStrategy{Type: rayv1.NeverHeadUpdateStrategyType}
+	assert.False(t, r.isHeadPodTemplateOutdated(cluster, *headPod, ""))
+	assert.False(t, r.isHeadPodTemplateOutdated(cluster, *headPod, "new"))
+
+	// The head Pod was not created from the current Pod template.
+	cluster.Spec.HeadGroupSpec.UpdateStrategy.Type = rayv1.RecreateHeadUpdateStrategyType
+	assert.True(t, r.isHeadPodTemplateOutdated(cluster, *headPod, ""))
+
+	headPod.Labels[utils.RayPodTemplateHashLabelKey] = utils.GeneratePodTemplateHash(cluster.Spec.HeadGroupSpec.Template)
+	assert.False(t, r.isHeadPodTemplateOutdated(cluster, *headPod, ""))
+
+	// The head Pod was created with outdated RayStartParams sourced from a ConfigMap.
+	assert.True(t, r.isHeadPodTemplateOutdated(cluster, *headPod, "new"))
+	headPod.Annotations = map[string]string{utils.RayStartParamsHashAnnotationKey: "new"}
+	assert.False(t, r.isHeadPodTemplateOutdated(cluster, *headPod, "new"))
+
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Image = "rayproject/ray:nightly"
+	assert.True(t, r.isHeadPodTemplateOutdated(cluster, *headPod, "new"))
+}
+
+func TestBuildHeadPod_PodTemplateHash(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	cluster.Spec.HeadGroupSpec.UpdateStrategy = &rayv1.HeadUpdateStrategy{Type: rayv1.RecreateHeadUpdateStrategyType}
+	r := &RayClusterReconciler{Recorder: &record.FakeRecorder{}, Scheme: scheme.Scheme}
+
+	// A head Pod built from the current Pod template is not recreated, also after it is built again.
+	for i := 0; i < 2; i++ {
+		headPod := r.buildHeadPod(context.Background(), *cluster)
+		assert.False(t, r.isHeadPodTemplateOutdated(cluster, headPod, ""))
+	}
+}
+
+func TestReconcile_RecreateOutdatedHeadPod(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.HeadGroupSpec.UpdateStrategy = &rayv1.HeadUpdateStrategy{
+		Type:            rayv1.RecreateHeadUpdateStrategyType,
+		RecreateWorkers: ptr.To(true),
+	}
+	headPod := testPods[0].(*corev1.Pod).DeepCopy()
+	workerPod := testPods[1].(*corev1.Pod).DeepCopy()
+	workerPod.Labels[utils.RayNodeTypeLabelKey] = string(rayv1.WorkerNode)
+
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(headPod, workerPod).Build()
+	r := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+
+	// The head Pod is recreated after the worker Pods are deleted.
+	err := r.reconcilePods(ctx, cluster)
+	assert.ErrorContains(t, err, "outdated Pod template")
+	podList := corev1.PodList{}
+	assert.NoError(t, fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr)))
+	assert.Empty(t, podList.Items)
+}
+
+func TestGetRollingUpdatePlan(t *testing.T) {
+	newPod := func(name string, hash string, ready bool) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{utils.RayPodTemplateHashLabelKey: hash}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return pod
+	}
+
+	tests := map[string]struct {
+		rollingUpdate    *rayv1.RollingUpdateWorkerGroup
+		deletedPods      map[string]struct{}
+		pods             []corev1.Pod
+		expectedDeleted  []string
+		replicas         int32
+		expectedSurge    int
+		expectedUpdating bool
+	}{
+		"no Pod is outdated": {
+			replicas: 3,
+			pods:     []corev1.Pod{newPod("a", "new", true), newPod("b", "new", true), newPod("c", "new", true)},
+		},
+		"replace one Pod at a time by default": {
+			replicas:         3,
+			pods:             []corev1.Pod{newPod("a", "old", true), newPod("b", "old", true), newPod("c", "old", true)},
+			expectedDeleted:  []string{"a"},
+			expectedUpdating: true,
+		},
+		"create a surge Pod before deleting an outdated Pod": {
+			rollingUpdate: &rayv1.RollingUpdateWorkerGroup{
+				MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+				MaxSurge:       ptr.To(intstr.FromInt32(1)),
+			},
+			replicas:         3,
+			pods:             []corev1.Pod{newPod("a", "old", true), newPod("b", "old", true), newPod("c", "old", true)},
+			expectedSurge:    1,
+			expectedUpdating: true,
+		},
+		"delete an outdated Pod once the surge Pod is available": {
+			rollingUpdate: &rayv1.RollingUpdateWorkerGroup{
+				MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+				MaxSurge:       ptr.To(intstr.FromInt32(1)),
+			},
+			replicas:         3,
+			pods:             []corev1.Pod{newPod("a", "old", true), newPod("b", "old", true), newPod("c", "old", true), newPod("d", "new", true)},
+			expectedDeleted:  []string{"a"},
+			expectedUpdating: true,
+		},
+		"delete the outdated Pods that are not available first": {
+			replicas:         3,
+			pods:             []corev1.Pod{newPod("a", "old", true), newPod("b", "old", false), newPod("c", "new", true)},
+			expectedDeleted:  []string{"b"},
+			expectedUpdating: true,
+		},
+		"round down a percentage of unavailable Pods": {
+			rollingUpdate:    &rayv1.RollingUpdateWorkerGroup{MaxUnavailable: ptr.To(intstr.FromString("60%"))},
+			replicas:         4,
+			pods:             []corev1.Pod{newPod("a", "old", true), newPod("b", "old", true), newPod("c", "old", true), newPod("d", "old", true)},
+			expectedDeleted:  []string{"a", "b"},
+			expectedUpdating: true,
+		},
+		"wait for the Pods deleted in this reconciliation": {
+			replicas:         3,
+			deletedPods:      map[string]struct{}{"a": {}},
+			pods:             []corev1.Pod{newPod("a", "old", true), newPod("b", "old", true), newPod("c", "old", true)},
+			expectedUpdating: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			pods, surge, updating := getRollingUpdatePlan(tc.rollingUpdate, tc.replicas, tc.pods, tc.deletedPods, "new")
+			var deleted []string
+			for _, pod := range pods {
+				deleted = append(deleted, pod.Name)
+			}
+			assert.Equal(t, tc.expectedDeleted, deleted)
+			assert.Equal(t, tc.expectedSurge, surge)
+			assert.Equal(t, tc.expectedUpdating, updating)
+		})
+	}
+}
+
+func TestBuildWorkerPod_PodTemplateHash(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.UpdateStrategy = &rayv1.WorkerGroupUpdateStrategy{Type: rayv1.RollingUpdateWorkerGroupStrategyType}
+	r := &RayClusterReconciler{Recorder: &record.FakeRecorder{}, Scheme: scheme.Scheme}
+
+	// A Pod built from the current Pod template is not replaced by a rolling update, also after it is built again.
+	for i := 0; i < 2; i++ {
+		pod := r.buildWorkerPod(context.Background(), *cluster, worker, i, nil)
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		podsToUpdate, _, updating := getRollingUpdatePlan(worker.UpdateStrategy.RollingUpdate, 1, []corev1.Pod{pod}, nil, utils.GeneratePodTemplateHash(worker.Template))
+		assert.Empty(t, podsToUpdate)
+		assert.False(t, updating)
+	}
+}
+
+func TestReconcilePlacementGroups(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs[0].PlacementGroup = &rayv1.PlacementGroupOptions{Bundles: 2}
+	headPod := testPods[0].(*corev1.Pod).DeepCopy()
+	headPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	headService, err := common.BuildServiceForHeadPod(ctx, *cluster, nil, nil)
+	assert.NoError(t, err)
+
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(headPod, headService).Build()
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	r := &RayClusterReconciler{
+		Client:              fakeClient,
+		Recorder:            &record.FakeRecorder{},
+		Scheme:              scheme.Scheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface { return dashboardClient },
+	}
+	mockJobInfo := func(jobInfo *utils.RayJobInfo, err error) {
+		getJobInfo := func(context.Context, string) (*utils.RayJobInfo, error) {
+			return jobInfo, err
+		}
+		dashboardClient.GetJobInfoMock.Store(&getJobInfo)
+	}
+
+	// The bootstrap job is submitted if it does not exist.
+	mockJobInfo(nil, k8serrors.NewBadRequest("Job does not exist on the cluster"))
+	assert.NoError(t, r.reconcilePlacementGroups(ctx, cluster))
+	assert.Nil(t, cluster.Status.PlacementGroups)
+	assert.False(t, common.PlacementGroupsCreated(cluster))
+
+	// The placement groups are recorded once the bootstrap job succeeds.
+	mockJobInfo(&utils.RayJobInfo{JobStatus: rayv1.JobStatusSucceeded}, nil)
+	assert.NoError(t, r.reconcilePlacementGroups(ctx, cluster))
+	assert.Equal(t, []string{groupNameStr}, cluster.Status.PlacementGroups)
+	assert.True(t, common.PlacementGroupsCreated(cluster))
+
+	// The placement groups are no longer recorded if the bootstrap job fails, e.g. after the head Pod is recreated.
+	mockJobInfo(&utils.RayJobInfo{JobStatus: rayv1.JobStatusFailed}, nil)
+	assert.NoError(t, r.reconcilePlacementGroups(ctx, cluster))
+	assert.Nil(t, cluster.Status.PlacementGroups)
+
+	// The placement groups are not created before the head Pod is ready.
+	headPod.Status.Conditions = nil
+	r.Client = newFakeClientBuilder().WithRuntimeObjects(headPod, headService).Build()
+	mockJobInfo(&utils.RayJobInfo{JobStatus: rayv1.JobStatusSucceeded}, nil)
+	assert.NoError(t, r.reconcilePlacementGroups(ctx, cluster))
+	assert.Nil(t, cluster.Status.PlacementGroups)
+}
+
+func TestDrainWorkerPods(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	cluster := testRayCluster.DeepCopy()
+	headPod := testPods[0].(*corev1.Pod).DeepCopy()
+	headPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	workerPod := testPods[1].(*corev1.Pod).DeepCopy()
+	workerPod.Status.PodIP = "10.0.0.1"
+	workerPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	headService, err := common.BuildServiceForHeadPod(ctx, *cluster, nil, nil)
+	assert.NoError(t, err)
+
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(headPod, workerPod, headService).Build()
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	getJobInfo := func(context.Context, string) (*utils.RayJobInfo, error) {
+		return nil, k8serrors.NewBadRequest("Job does not exist on the cluster")
+	}
+	dashboardClient.GetJobInfoMock.Store(&getJobInfo)
+	r := &RayClusterReconciler{
+		Client:              fakeClient,
+		Recorder:            &record.FakeRecorder{},
+		Scheme:              scheme.Scheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface { return dashboardClient },
+	}
+
+	// The Ray node of the Pod is drained first, and its drain deadline is recorded on the Pod.
+	now := time.Now()
+	podsToDelete, after := r.drainWorkerPods(ctx, cluster, []corev1.Pod{*workerPod}, now)
+	assert.Empty(t, podsToDelete)
+	assert.Equal(t, common.WorkerDrainTimeout, after)
+	assert.Empty(t, workerPod.Annotations[utils.RayDrainDeadlineAnnotationKey])
+	drainingPod := corev1.Pod{}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(workerPod), &drainingPod)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, drainingPod.Annotations[utils.RayDrainDeadlineAnnotationKey])
+
+	// The Pod is deleted once its drain deadline has passed.
+	podsToDelete, after = r.drainWorkerPods(ctx, cluster, []corev1.Pod{drainingPod}, now.Add(time.Minute))
+	assert.Empty(t, podsToDelete)
+	assert.Greater(t, after, time.Duration(0))
+	podsToDelete, after = r.drainWorkerPods(ctx, cluster, []corev1.Pod{drainingPod}, now.Add(common.WorkerDrainTimeout+time.Second))
+	assert.Len(t, podsToDelete, 1)
+	assert.Zero(t, after)
+
+	// The Pod is deleted without draining if the dashboard cannot drain its Ray node.
+	r.dashboardClientFunc = nil
+	podsToDelete, after = r.drainWorkerPods(ctx, cluster, []corev1.Pod{*workerPod}, now)
+	assert.Len(t, podsToDelete, 1)
+	assert.Zero(t, after)
+}
+
+func TestIsPodStuck(t *testing.T) {
+	now := time.Now()
+	timeout := 10 * time.Minute
+	newPod := func(phase corev1.PodPhase, deletedAgo, readyTransitionAgo time.Duration) corev1.Pod {
+		pod := corev1.Pod{
+			Status: corev1.PodStatus{
+				Phase:      phase,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionUnknown, LastTransitionTime: metav1.NewTime(now.Add(-readyTransitionAgo))}},
+			},
+		}
+		if deletedAgo > 0 {
+			pod.DeletionTimestamp = &metav1.Time{Time: now.Add(-deletedAgo)}
+		}
+		return pod
+	}
+
+	tests := map[string]struct {
+		pod      corev1.Pod
+		expected bool
+	}{
+		"running Pod": {
+			pod: newPod(corev1.PodRunning, 0, time.Hour),
+		},
+		"Pod terminating for less than the timeout": {
+			pod: newPod(corev1.PodRunning, time.Minute, time.Hour),
+		},
+		"Pod terminating for longer than the timeout": {
+			pod:      newPod(corev1.PodRunning, time.Hour, time.Hour),
+			expected: true,
+		},
+		"Pod in the Unknown phase for less than the timeout": {
+			pod: newPod(corev1.PodUnknown, 0, time.Minute),
+		},
+		"Pod in the Unknown phase for longer than the timeout": {
+			pod:      newPod(corev1.PodUnknown, 0, time.Hour),
+			expected: true,
+		},
+		"Pod in the Unknown phase that is still ready": {
+			pod: func() corev1.Pod {
+				pod := newPod(corev1.PodUnknown, 0, time.Hour)
+				pod.Status.Conditions[0].Status = corev1.ConditionTrue
+				return pod
+			}(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stuck, reason := isPodStuck(tc.pod, timeout, now)
+			assert.Equal(t, tc.expected, stuck)
+			assert.Equal(t, tc.expected, reason != "")
+		})
+	}
+}
+
+func TestReconcile_ForceDeleteStuckWorkerPods(t *testing.T) {
+	setupTest(t)
+
+	var replicas int32 = 2
+	testRayCluster.Spec.WorkerGroupSpecs[0].Replicas = &replicas
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+
+	// The node of pod1 is gone, so the Pod has been in the Unknown phase for an hour.
+	stuckPod := testPods[1].(*corev1.Pod).DeepCopy()
+	stuckPod.Status.Phase = corev1.PodUnknown
+	stuckPod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodReady, Status: corev1.ConditionUnknown, LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour))},
+	}
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods[0], stuckPod, testPods[2]).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:          fakeClient,
+		Recorder:        &record.FakeRecorder{},
+		Scheme:          scheme.Scheme,
+		stuckPodTimeout: 10 * time.Minute,
+	}
+
+	err := testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	assert.Nil(t, err, "Fail to reconcile Pods")
+
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, &client.ListOptions{
+		LabelSelector: workerSelector,
+		Namespace:     namespaceStr,
+	})
+	assert.Nil(t, err, "Fail to get pod list after reconcile")
+	// The stuck Pod is force-deleted and replaced.
+	assert.Equal(t, int(replicas), len(podList.Items))
+	for _, pod := range podList.Items {
+		assert.NotEqual(t, stuckPod.Name, pod.Name)
+	}
+}
+
+func TestReconcileAutoscalerStatus(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	headPod := testPods[0].(*corev1.Pod).DeepCopy()
+	headPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	headService, err := common.BuildServiceForHeadPod(ctx, *cluster, nil, nil)
+	assert.NoError(t, err)
+
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(headPod, headService).Build()
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	recorder := record.NewFakeRecorder(10)
+	r := &RayClusterReconciler{
+		Client:              fakeClient,
+		Recorder:            recorder,
+		Scheme:              scheme.Scheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface { return dashboardClient },
+	}
+
+	// The error of the autoscaler is recorded in the status, and an event is fired only when it changes.
+	autoscalingError := "Failed to launch 1 node(s) of type small-group"
+	dashboardClient.SetClusterStatus(utils.ClusterStatus{AutoscalingError: autoscalingError})
+	assert.NoError(t, r.reconcileAutoscalerStatus(ctx, cluster))
+	assert.Equal(t, autoscalingError, cluster.Status.AutoscalerError)
+	assert.NoError(t, r.reconcileAutoscalerStatus(ctx, cluster))
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, autoscalingError)
+
+	// The error is cleared once the autoscaler recovers.
+	dashboardClient.SetClusterStatus(utils.ClusterStatus{})
+	assert.NoError(t, r.reconcileAutoscalerStatus(ctx, cluster))
+	assert.Empty(t, cluster.Status.AutoscalerError)
+	assert.Len(t, recorder.Events, 0)
+
+	// Failures to read the state of the autoscaler do not fail the reconciliation.
+	cluster.Status.AutoscalerError = autoscalingError
+	r.Client = newFakeClientBuilder().WithRuntimeObjects(headPod, headService).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(_ context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+			return errors.New("list failed")
+		},
+	}).Build()
+	assert.NoError(t, r.reconcileAutoscalerStatus(ctx, cluster))
+	assert.Equal(t, autoscalingError, cluster.Status.AutoscalerError)
+	r.Client = fakeClient
+
+	// The error is cleared when autoscaling is disabled.
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	assert.NoError(t, r.reconcileAutoscalerStatus(ctx, cluster))
+	assert.Empty(t, cluster.Status.AutoscalerError)
+}
+
+func TestReconcileIdleTimeout(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.IdleTimeoutSeconds = ptr.To[int32](600)
+	headPod := testPods[0].(*corev1.Pod).DeepCopy()
+	headPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	headService, err := common.BuildServiceForHeadPod(ctx, *cluster, nil, nil)
+	assert.NoError(t, err)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster.DeepCopy(), headPod, headService).Build()
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	recorder := record.NewFakeRecorder(10)
+	r := &RayClusterReconciler{
+		Client:              fakeClient,
+		Recorder:            recorder,
+		Scheme:              newScheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface { return dashboardClient },
+	}
+
+	// The activity is tracked from the first reconciliation, and the dashboard is polled until the timeout expires.
+	requeueAfter, terminated, err := r.reconcileIdleTimeout(ctx, cluster)
+	assert.NoError(t, err)
+	assert.False(t, terminated)
+	assert.NotNil(t, cluster.Status.LastActivityTime)
+	assert.Equal(t, idleTimeoutPollPeriod, requeueAfter)
+
+	// A running Ray job or a Serve application keeps the RayCluster active.
+	idleSince := metav1.NewTime(time.Now().Add(-time.Hour))
+	getJobInfo := func(_ context.Context, _ string) (*utils.RayJobInfo, error) {
+		return &utils.RayJobInfo{JobStatus: rayv1.JobStatusRunning}, nil
+	}
+	dashboardClient.GetJobInfoMock.Store(&getJobInfo)
+	cluster.Status.LastActivityTime = &idleSince
+	_, terminated, err = r.reconcileIdleTimeout(ctx, cluster)
+	assert.NoError(t, err)
+	assert.False(t, terminated)
+	assert.True(t, cluster.Status.LastActivityTime.After(idleSince.Time))
+	dashboardClient.GetJobInfoMock.Store(nil)
+
+	dashboardClient.SetMultiApplicationStatuses(map[string]*utils.ServeApplicationStatus{"app": {Status: rayv1.ApplicationStatusEnum.RUNNING}})
+	cluster.Status.LastActivityTime = &idleSince
+	_, terminated, err = r.reconcileIdleTimeout(ctx, cluster)
+	assert.NoError(t, err)
+	assert.False(t, terminated)
+	assert.True(t, cluster.Status.LastActivityTime.After(idleSince.Time))
+	dashboardClient.SetMultiApplicationStatuses(nil)
+
+	// An idle RayCluster is suspended by default.
+	cluster.Status.LastActivityTime = &idleSince
+	_, terminated, err = r.reconcileIdleTimeout(ctx, cluster)
+	assert.NoError(t, err)
+	assert.True(t, terminated)
+	assert.Contains(t, <-recorder.Events, "Suspended the RayCluster")
+	suspendedCluster := &rayv1.RayCluster{}
+	assert.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(cluster), suspendedCluster))
+	assert.True(t, ptr.Deref(suspendedCluster.Spec.Suspend, false))
+
+	// The activity is not tracked while the RayCluster is suspended.
+	_, terminated, err = r.reconcileIdleTimeout(ctx, suspendedCluster)
+	assert.NoError(t, err)
+	assert.False(t, terminated)
+	assert.Nil(t, suspendedCluster.Status.LastActivityTime)
+
+	// An idle RayCluster is deleted with the Delete policy.
+	cluster = suspendedCluster
+	cluster.Spec.Suspend = nil
+	cluster.Spec.IdleTimeoutPolicy = ptr.To(rayv1.DeleteIdleTimeoutPolicy)
+	cluster.Status.LastActivityTime = &idleSince
+	_, terminated, err = r.reconcileIdleTimeout(ctx, cluster)
+	assert.NoError(t, err)
+	assert.True(t, terminated)
+	assert.Contains(t, <-recorder.Events, "Deleted the RayCluster")
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(cluster), &rayv1.RayCluster{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestRecordScaledWorkerGroups(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Status.GroupStatuses = []rayv1.GroupStatus{
+		{GroupName: utils.RayNodeHeadGroupLabelValue},
+		{GroupName: "up", DesiredReplicas: ptr.To[int32](1)},
+		{GroupName: "down", DesiredReplicas: ptr.To[int32](3)},
+		{GroupName: "unchanged", DesiredReplicas: ptr.To[int32](2)},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &RayClusterReconciler{Recorder: recorder}
+
+	r.recordScaledWorkerGroups(cluster, []rayv1.GroupStatus{
+		{GroupName: utils.RayNodeHeadGroupLabelValue},
+		{GroupName: "up", DesiredReplicas: ptr.To[int32](4)},
+		{GroupName: "down", DesiredReplicas: ptr.To[int32](0)},
+		{GroupName: "unchanged", DesiredReplicas: ptr.To[int32](2)},
+		// No event is fired for a worker group that is newly added.
+		{GroupName: "new", DesiredReplicas: ptr.To[int32](1)},
+	})
+	assert.Len(t, recorder.Events, 2)
+	assert.Equal(t, "Normal ScaledWorkerGroup Scaled up worker group up from 1 to 4 replicas", <-recorder.Events)
+	assert.Equal(t, "Normal ScaledWorkerGroup Scaled down worker group down from 3 to 0 replicas", <-recorder.Events)
+}
+
+func TestReconcile_ScalePolicy(t *testing.T) {
+	setupTest(t)
+
+	testRayCluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](5)
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScalePolicy = &rayv1.ScalePolicy{
+		MaxPodsAddedPerMinute:   ptr.To[int32](2),
+		MaxPodsRemovedPerMinute: ptr.To[int32](1),
+	}
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods[0]).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+	listWorkerPods := func() []corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+		assert.Nil(t, err, "Fail to get pod list")
+		return podList.Items
+	}
+	forgetScaleHistory := func() {
+		history := testRayClusterReconciler.getScaleHistory(testRayCluster)
+		for group, times := range history.added {
+			for i := range times {
+				times[i] = times[i].Add(-scalePolicyWindow)
+			}
+			history.added[group] = times
+		}
+		for group, times := range history.removed {
+			for i := range times {
+				times[i] = times[i].Add(-scalePolicyWindow)
+			}
+			history.removed[group] = times
+		}
+	}
+
+	// Only 2 Pods are added per minute, and the RayCluster is requeued to add the others once the window frees up.
+	err := testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	var requeueErr *requeueAfterError
+	if assert.ErrorAs(t, err, &requeueErr) {
+		assert.Contains(t, requeueErr.reason, "scalePolicy")
+		assert.LessOrEqual(t, requeueErr.after, scalePolicyWindow)
+	}
+	assert.Len(t, listWorkerPods(), 2)
+	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	assert.ErrorContains(t, err, "scalePolicy")
+	assert.Len(t, listWorkerPods(), 2)
+
+	// More Pods are added once the earlier ones fall out of the window.
+	forgetScaleHistory()
+	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	assert.ErrorContains(t, err, "scalePolicy")
+	assert.Len(t, listWorkerPods(), 4)
+	forgetScaleHistory()
+	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Len(t, listWorkerPods(), 5)
+
+	// Only 1 of the Pods in WorkersToDelete is removed per minute.
+	workerPods := listWorkerPods()
+	testRayCluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](3)
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{workerPods[0].Name, workerPods[1].Name}
+	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	assert.ErrorContains(t, err, "scalePolicy")
+	assert.Len(t, listWorkerPods(), 4)
+	forgetScaleHistory()
+	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Len(t, listWorkerPods(), 3)
+}
+
+func TestScaleBudget(t *testing.T) {
+	now := time.Now()
+	history := map[string][]time.Time{
+		"group": {now.Add(-2 * time.Minute), now.Add(-30 * time.Second), now.Add(-10 * time.Second)},
+	}
+
+	// The Pods added or removed before the window are forgotten.
+	assert.Equal(t, int32(1), scaleBudget(history, "group", ptr.To[int32](3), now))
+	assert.Len(t, history["group"], 2)
+	assert.Equal(t, int32(0), scaleBudget(history, "group", ptr.To[int32](1), now))
+	assert.Equal(t, int32(math.MaxInt32), scaleBudget(history, "group", nil, now))
+	assert.Equal(t, int32(2), scaleBudget(history, "other-group", ptr.To[int32](2), now))
+}
+
+func TestScaleBudgetResetAfter(t *testing.T) {
+	now := time.Now()
+	history := map[string][]time.Time{
+		"group": {now.Add(-10 * time.Second), now.Add(-30 * time.Second)},
+	}
+
+	// The budget grows again when the oldest Pod added or removed leaves the window.
+	assert.Equal(t, 30*time.Second, scaleBudgetResetAfter(history, "group", now))
+	assert.Equal(t, scalePolicyWindow, scaleBudgetResetAfter(history, "other-group", now))
+}
+
+func TestReconcileScheduledScaling(t *testing.T) {
+	setupTest(t)
+
+	testRayCluster.Spec.EnableInTreeAutoscaling = nil
+	testRayCluster.Spec.WorkerGroupSpecs[0].MinReplicas = ptr.To[int32](1)
+	testRayCluster.Spec.WorkerGroupSpecs[0].MaxReplicas = ptr.To[int32](10)
+	// The window starts every minute and lasts for an hour, so it is always active.
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScheduledScaling = []rayv1.ScheduledScalingWindow{
+		{
+			Schedule:    "* * * * *",
+			Duration:    metav1.Duration{Duration: time.Hour},
+			MinReplicas: ptr.To[int32](4),
+		},
+	}
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(testRayCluster).Build()
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(10)
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: recorder,
+		Scheme:   scheme.Scheme,
+	}
+
+	// The minReplicas of the window is applied in memory, without updating the spec.
+	cluster := testRayCluster.DeepCopy()
+	err := testRayClusterReconciler.reconcileScheduledScaling(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), *cluster.Spec.WorkerGroupSpecs[0].MinReplicas)
+	assert.Equal(t, int32(10), *cluster.Spec.WorkerGroupSpecs[0].MaxReplicas)
+	assert.Contains(t, <-recorder.Events, "Set minReplicas 4 and maxReplicas 10 of worker group small-group")
+	storedCluster := rayv1.RayCluster{}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(testRayCluster), &storedCluster)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), *storedCluster.Spec.WorkerGroupSpecs[0].MinReplicas)
+
+	// The overridden bounds are reported in the status of the group.
+	groupStatuses := []rayv1.GroupStatus{{GroupName: utils.RayNodeHeadGroupLabelValue}, {GroupName: "small-group"}}
+	setScheduledScalingReplicas(cluster, groupStatuses, time.Now())
+	assert.Nil(t, groupStatuses[0].MinReplicas)
+	assert.Equal(t, ptr.To[int32](4), groupStatuses[1].MinReplicas)
+	assert.Equal(t, ptr.To[int32](10), groupStatuses[1].MaxReplicas)
+
+	// No event is fired while the window stays active.
+	cluster = testRayCluster.DeepCopy()
+	cluster.Status.GroupStatuses = groupStatuses
+	err = testRayClusterReconciler.reconcileScheduledScaling(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Empty(t, recorder.Events)
+
+	// The minReplicas of the spec applies again once the window is gone.
+	cluster = testRayCluster.DeepCopy()
+	cluster.Status.GroupStatuses = groupStatuses
+	cluster.Spec.WorkerGroupSpecs[0].ScheduledScaling = nil
+	err = testRayClusterReconciler.reconcileScheduledScaling(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), *cluster.Spec.WorkerGroupSpecs[0].MinReplicas)
+	assert.Contains(t, <-recorder.Events, "Restored minReplicas 1 and maxReplicas 10 of worker group small-group")
+
+	// An invalid schedule is reported and ignored, without blocking the reconciliation.
+	cluster = testRayCluster.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs[0].ScheduledScaling = []rayv1.ScheduledScalingWindow{
+		{Schedule: "not a schedule", Duration: metav1.Duration{Duration: time.Hour}},
+	}
+	err = testRayClusterReconciler.reconcileScheduledScaling(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), *cluster.Spec.WorkerGroupSpecs[0].MinReplicas)
+	assert.Contains(t, <-recorder.Events, "Invalid scheduledScaling of worker group small-group")
+
+	// The windows of an autoscaled RayCluster are reported and ignored, as the autoscaler only reads the spec.
+	cluster = testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	err = testRayClusterReconciler.reconcileScheduledScaling(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), *cluster.Spec.WorkerGroupSpecs[0].MinReplicas)
+	assert.Contains(t, <-recorder.Events, "Ignored scheduledScaling of worker group small-group")
+	groupStatuses = []rayv1.GroupStatus{{GroupName: "small-group"}}
+	setScheduledScalingReplicas(cluster, groupStatuses, time.Now())
+	assert.Nil(t, groupStatuses[0].MinReplicas)
+	assert.True(t, nextScheduledScalingTime(cluster, time.Now()).IsZero())
+}
+
+func TestActiveScheduledScalingWindow(t *testing.T) {
+	windows := []rayv1.ScheduledScalingWindow{
+		{
+			// Business hours in New York on weekdays.
+			Schedule:    "0 8 * * 1-5",
+			TimeZone:    ptr.To("America/New_York"),
+			Duration:    metav1.Duration{Duration: 10 * time.Hour},
+			MinReplicas: ptr.To[int32](4),
+		},
+		{
+			// Nights in UTC.
+			Schedule:    "0 0 * * *",
+			Duration:    metav1.Duration{Duration: 6 * time.Hour},
+			MaxReplicas: ptr.To[int32](0),
+		},
+	}
+
+	tests := map[string]struct {
+		now      time.Time
+		expected *int
+	}{
+		"Monday 9:00 in New York": {
+			now:      time.Date(2024, 6, 3, 13, 0, 0, 0, time.UTC),
+			expected: ptr.To(0),
+		},
+		"Monday 18:00 in New York": {
+			now:      time.Date(2024, 6, 3, 22, 0, 0, 0, time.UTC),
+			expected: nil,
+		},
+		"Saturday 9:00 in New York": {
+			now:      time.Date(2024, 6, 8, 13, 0, 0, 0, time.UTC),
+			expected: nil,
+		},
+		"Sunday 3:00 in UTC": {
+			now:      time.Date(2024, 6, 9, 3, 0, 0, 0, time.UTC),
+			expected: ptr.To(1),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			window, err := activeScheduledScalingWindow(windows, tc.now)
+			assert.Nil(t, err)
+			if tc.expected == nil {
+				assert.Nil(t, window)
+			} else {
+				assert.Equal(t, &windows[*tc.expected], window)
+			}
+		})
+	}
+
+	_, err := activeScheduledScalingWindow([]rayv1.ScheduledScalingWindow{{Schedule: "0 8 * * *", TimeZone: ptr.To("Nowhere")}}, time.Now())
+	assert.NotNil(t, err)
+}
+
+func TestNextScheduledScalingTime(t *testing.T) {
+	cluster := &rayv1.RayCluster{
+		Spec: rayv1.RayClusterSpec{
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{
+					ScheduledScaling: []rayv1.ScheduledScalingWindow{
+						{Schedule: "0 8 * * *", Duration: metav1.Duration{Duration: 10 * time.Hour}},
+					},
+				},
+			},
+		},
+	}
+
+	// The window starts at 8:00 and ends at 18:00.
+	now := time.Date(2024, 6, 3, 7, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC), nextScheduledScalingTime(cluster, now).UTC())
+	now = time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 6, 3, 18, 0, 0, 0, time.UTC), nextScheduledScalingTime(cluster, now).UTC())
+
+	assert.True(t, nextScheduledScalingTime(&rayv1.RayCluster{}, now).IsZero())
+}
+
+func TestReconcileWarmPool(t *testing.T) {
+	setupTest(t)
+
+	testRayCluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](3)
+	testRayCluster.Spec.WorkerGroupSpecs[0].MaxReplicas = ptr.To[int32](5)
+	testRayCluster.Spec.WorkerGroupSpecs[0].WarmPoolSize = ptr.To[int32](2)
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(testPods[0]).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+	listStandbyPods := func() []corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, common.RayClusterWarmPoolPodsAssociationOptions(testRayCluster).ToListOptions()...)
+		assert.Nil(t, err, "Fail to get pod list")
+		return podList.Items
+	}
+
+	// The standby Pods only sleep and are not counted as Ray Pods.
+	err := testRayClusterReconciler.reconcileWarmPool(ctx, testRayCluster)
+	assert.Nil(t, err)
+	standbyPods := listStandbyPods()
+	assert.Len(t, standbyPods, 2)
+	for _, pod := range standbyPods {
+		assert.Equal(t, groupNameStr, pod.Labels[utils.RayNodeGroupLabelKey])
+		assert.NotContains(t, pod.Labels, utils.RayClusterLabelKey)
+		assert.NotContains(t, pod.Labels, utils.RayNodeTypeLabelKey)
+		assert.Empty(t, pod.Spec.InitContainers)
+		assert.Nil(t, pod.Spec.Containers[utils.RayContainerIndex].ReadinessProbe)
+		assert.Contains(t, strings.Join(pod.Spec.Containers[utils.RayContainerIndex].Command, " "), "sleep infinity")
+	}
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, common.RayClusterAllPodsAssociationOptions(testRayCluster).ToListOptions()...)
+	assert.Nil(t, err)
+	assert.Len(t, podList.Items, 1)
+
+	// The workers and standby Pods of the group do not exceed maxReplicas.
+	testRayCluster.Spec.WorkerGroupSpecs[0].MaxReplicas = ptr.To[int32](4)
+	err = testRayClusterReconciler.reconcileWarmPool(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Len(t, listStandbyPods(), 1)
+
+	// A standby Pod is released for each worker waiting to be scheduled.
+	workerPod := testPods[1].(*corev1.Pod).DeepCopy()
+	workerPod.Spec.NodeName = ""
+	err = fakeClient.Create(ctx, workerPod)
+	assert.Nil(t, err)
+	err = testRayClusterReconciler.reconcileWarmPool(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Empty(t, listStandbyPods())
+	err = fakeClient.Delete(ctx, workerPod)
+	assert.Nil(t, err)
+
+	// The standby Pods are deleted when the RayCluster is suspended.
+	err = testRayClusterReconciler.reconcileWarmPool(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Len(t, listStandbyPods(), 1)
+	testRayCluster.Spec.Suspend = ptr.To(true)
+	err = testRayClusterReconciler.reconcileWarmPool(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Empty(t, listStandbyPods())
+}
+
+func TestReconcileWorkerGroups(t *testing.T) {
+	setupTest(t)
+
+	newWorkerGroup := testRayCluster.Spec.WorkerGroupSpecs[0].DeepCopy()
+	newWorkerGroup.GroupName = "new-group"
+	testRayCluster.Spec.WorkerGroupSpecs = append(testRayCluster.Spec.WorkerGroupSpecs, *newWorkerGroup)
+	testRayCluster.Status.GroupStatuses = []rayv1.GroupStatus{
+		{GroupName: utils.RayNodeHeadGroupLabelValue},
+		{GroupName: groupNameStr},
+		{GroupName: "old-group"},
+	}
+	workerPod := testPods[1].(*corev1.Pod).DeepCopy()
+	workerPod.Labels[utils.RayNodeTypeLabelKey] = string(rayv1.WorkerNode)
+	orphanedPod := workerPod.DeepCopy()
+	orphanedPod.Name = "old-group-pod"
+	orphanedPod.Labels[utils.RayNodeGroupLabelKey] = "old-group"
+
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(workerPod, orphanedPod).Build()
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(10)
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: recorder,
+		Scheme:   scheme.Scheme,
+	}
+
+	err := testRayClusterReconciler.reconcileWorkerGroups(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Contains(t, <-recorder.Events, "Removed worker group old-group")
+	assert.Contains(t, <-recorder.Events, "Added worker group new-group")
+	assert.Contains(t, <-recorder.Events, "Deleted Pod default/old-group-pod of removed worker group old-group")
+
+	// Only the Pod of the removed worker group is deleted.
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, common.RayClusterWorkerPodsAssociationOptions(testRayCluster).ToListOptions()...)
+	assert.Nil(t, err)
+	assert.Len(t, podList.Items, 1)
+	assert.Equal(t, workerPod.Name, podList.Items[0].Name)
+
+	// The worker groups of a new RayCluster are not reported as added.
+	testRayCluster.Status.GroupStatuses = nil
+	err = testRayClusterReconciler.reconcileWorkerGroups(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestWarnIfPodTooLarge(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(10)
+	r := &RayClusterReconciler{Recorder: recorder}
+	newPod := func(groupName, templateHash string, envSize int) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "raycluster-sample-" + groupName + "-",
+				Namespace:    "default",
+				Labels: map[string]string{
+					utils.RayNodeGroupLabelKey:       groupName,
+					utils.RayPodTemplateHashLabelKey: templateHash,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "ray-worker",
+					Env:  []corev1.EnvVar{{Name: "LARGE", Value: strings.Repeat("x", envSize)}},
+				}},
+			},
+		}
+	}
+
+	// The first Pod of a group approaching the size limit of etcd is reported.
+	r.warnIfPodTooLarge(ctx, testRayCluster, newPod("small-group", "hash1", podSizeWarningThresholdBytes))
+	assert.Contains(t, <-recorder.Events, "approaches the size limit of etcd")
+
+	// The other Pods built from the same Pod template are not checked again.
+	r.warnIfPodTooLarge(ctx, testRayCluster, newPod("small-group", "hash1", podSizeWarningThresholdBytes))
+	assert.Empty(t, recorder.Events)
+
+	// The Pods of another group, or of a new Pod template of the group, are checked.
+	r.warnIfPodTooLarge(ctx, testRayCluster, newPod("large-group", "hash1", 0))
+	assert.Empty(t, recorder.Events)
+	r.warnIfPodTooLarge(ctx, testRayCluster, newPod("small-group", "hash2", podSizeWarningThresholdBytes))
+	assert.Contains(t, <-recorder.Events, "approaches the size limit of etcd")
+}
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the Pods in common/golden/testdata")
+
+func TestGoldenPods(t *testing.T) {
+	r := &RayClusterReconciler{Recorder: &record.FakeRecorder{}, Scheme: scheme.Scheme}
+	golden.Run(t, "common/golden/testdata", func(ctx context.Context, cluster *rayv1.RayCluster) []corev1.Pod {
+		pods := []corev1.Pod{r.buildHeadPod(ctx, *cluster)}
+		for _, worker := range cluster.Spec.WorkerGroupSpecs {
+			pods = append(pods, r.buildWorkerPod(ctx, *cluster, worker, 0, nil))
+		}
+		return pods
+	}, *updateGolden)
+}
@@ -0,0 +1,346 @@
+package ray
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/tracing"
+)
+
+const (
+	RayCronJobDefaultRequeueDuration = 3 * time.Second
+	// The default number of RayJobs that succeeded and failed that a RayCronJob keeps, like the defaults of its CRD.
+	defaultSuccessfulJobsHistoryLimit = 3
+	defaultFailedJobsHistoryLimit     = 1
+)
+
+// RayCronJobReconciler reconciles a RayCronJob object
+type RayCronJobReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// NewRayCronJobReconciler returns a new reconcile.Reconciler
+func NewRayCronJobReconciler(mgr manager.Manager) *RayCronJobReconciler {
+	return &RayCronJobReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("raycronjob-controller"),
+	}
+}
+
+// +kubebuilder:rbac:groups=ray.io,resources=raycronjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ray.io,resources=raycronjobs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ray.io,resources=raycronjobs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ray.io,resources=rayjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+
+// [WARNING]: There MUST be a newline after kubebuilder markers.
+// Reconcile creates the RayJobs of the runs of a RayCronJob that are due, deletes the finished RayJobs beyond its
+// history limits, and requeues the RayCronJob at the time of its next run.
+func (r *RayCronJobReconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
+	logger := ctrl.LoggerFrom(ctx)
+	observer := common.ObserveReconcile(common.KindRayCronJob, request.NamespacedName)
+	defer observer.Done(&err)
+	ctx, span := tracing.StartReconcile(ctx, common.KindRayCronJob, request.NamespacedName)
+	defer tracing.End(span, &err)
+
+	rayCronJobInstance := &rayv1.RayCronJob{}
+	if err := r.Get(ctx, request.NamespacedName, rayCronJobInstance); err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request. Stop reconciliation.
+			logger.Info("RayCronJob resource not found. Ignoring since object must be deleted", "name", request.NamespacedName)
+			observer.Deleted()
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		logger.Error(err, "Failed to get RayCronJob")
+		return ctrl.Result{RequeueAfter: RayCronJobDefaultRequeueDuration}, err
+	}
+
+	if !rayCronJobInstance.ObjectMeta.DeletionTimestamp.IsZero() {
+		// The RayJobs of the RayCronJob are garbage collected with it.
+		logger.Info("RayCronJob is being deleted", "DeletionTimestamp", rayCronJobInstance.ObjectMeta.DeletionTimestamp)
+		return ctrl.Result{}, nil
+	}
+
+	requeueAfter, err := r.reconcileRayCronJob(ctx, rayCronJobInstance, time.Now())
+	if err != nil {
+		return ctrl.Result{RequeueAfter: RayCronJobDefaultRequeueDuration}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileRayCronJob reconciles the RayCronJob at the given time, and returns the duration until its next run, or 0
+// if its schedule is invalid.
+func (r *RayCronJobReconciler) reconcileRayCronJob(ctx context.Context, rayCronJob *rayv1.RayCronJob, now time.Time) (time.Duration, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	originalStatus := rayCronJob.Status.DeepCopy()
+
+	schedule, err := parseCronSchedule(rayCronJob.Spec.Schedule, rayCronJob.Spec.TimeZone)
+	if err != nil {
+		// The RayCronJob is reconciled again once its spec is updated.
+		logger.Error(err, "The RayCronJob spec is invalid")
+		r.Recorder.Eventf(rayCronJob, corev1.EventTypeWarning, string(utils.InvalidRayCronJobSpec),
+			"Failed to parse the schedule of RayCronJob %s/%s: %v", rayCronJob.Namespace, rayCronJob.Name, err)
+		return 0, nil
+	}
+
+	rayJobs, err := r.listRayJobs(ctx, rayCronJob)
+	if err != nil {
+		return 0, err
+	}
+	var activeRayJobs, succeededRayJobs, failedRayJobs []rayv1.RayJob
+	for _, rayJob := range rayJobs {
+		switch {
+		case !isRayJobFinished(&rayJob):
+			activeRayJobs = append(activeRayJobs, rayJob)
+		case rayJob.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusComplete && rayJob.Status.JobStatus == rayv1.JobStatusSucceeded:
+			succeededRayJobs = append(succeededRayJobs, rayJob)
+			finishedTime := getRayJobFinishedTime(&rayJob)
+			if rayCronJob.Status.LastSuccessfulTime == nil || finishedTime.After(rayCronJob.Status.LastSuccessfulTime.Time) {
+				rayCronJob.Status.LastSuccessfulTime = &metav1.Time{Time: finishedTime}
+			}
+		default:
+			failedRayJobs = append(failedRayJobs, rayJob)
+		}
+	}
+
+	if err := r.deleteRayJobsBeyondHistoryLimit(ctx, rayCronJob, succeededRayJobs, ptr.Deref(rayCronJob.Spec.SuccessfulJobsHistoryLimit, defaultSuccessfulJobsHistoryLimit)); err != nil {
+		return 0, err
+	}
+	if err := r.deleteRayJobsBeyondHistoryLimit(ctx, rayCronJob, failedRayJobs, ptr.Deref(rayCronJob.Spec.FailedJobsHistoryLimit, defaultFailedJobsHistoryLimit)); err != nil {
+		return 0, err
+	}
+
+	scheduledTime, nextTime := getMostRecentScheduleTime(rayCronJob, schedule, now)
+	if scheduledTime != nil {
+		if activeRayJobs, err = r.runScheduledRayJob(ctx, rayCronJob, activeRayJobs, *scheduledTime); err != nil {
+			return 0, err
+		}
+	}
+
+	rayCronJob.Status.Active = nil
+	for _, rayJob := range activeRayJobs {
+		rayCronJob.Status.Active = append(rayCronJob.Status.Active, corev1.ObjectReference{
+			APIVersion: rayv1.GroupVersion.String(),
+			Kind:       "RayJob",
+			Namespace:  rayJob.Namespace,
+			Name:       rayJob.Name,
+			UID:        rayJob.UID,
+		})
+	}
+	rayCronJob.Status.ObservedGeneration = rayCronJob.Generation
+	if !reflect.DeepEqual(originalStatus, &rayCronJob.Status) {
+		if err := utils.PatchStatus(ctx, r.Client, rayCronJob); err != nil {
+			logger.Info("Failed to update RayCronJob status", "error", err)
+			return 0, err
+		}
+	}
+	return nextTime.Sub(now), nil
+}
+
+// runScheduledRayJob creates the RayJob of the run of the RayCronJob scheduled at the given time according to its
+// concurrency policy, and returns the active RayJobs.
+func (r *RayCronJobReconciler) runScheduledRayJob(ctx context.Context, rayCronJob *rayv1.RayCronJob, activeRayJobs []rayv1.RayJob, scheduledTime time.Time) ([]rayv1.RayJob, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	rayJob, err := r.constructRayJobForRayCronJob(rayCronJob, scheduledTime)
+	if err != nil {
+		return activeRayJobs, err
+	}
+	for _, activeRayJob := range activeRayJobs {
+		// The RayJob was created by a reconciliation that failed to update the status.
+		if activeRayJob.Name == rayJob.Name {
+			rayCronJob.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+			return activeRayJobs, nil
+		}
+	}
+
+	switch rayCronJob.Spec.ConcurrencyPolicy {
+	case rayv1.ForbidConcurrent:
+		if len(activeRayJobs) > 0 {
+			// The last schedule time is not updated, so that the run starts once the active RayJobs finish, unless
+			// its startingDeadlineSeconds passes.
+			logger.Info("Skip the run of the RayCronJob, as the previous RayJob hasn't finished yet", "scheduledTime", scheduledTime)
+			r.Recorder.Eventf(rayCronJob, corev1.EventTypeNormal, string(utils.SkippedRayCronJobRun),
+				"Skipped the run scheduled at %s, as the previous RayJob hasn't finished yet", scheduledTime.Format(time.RFC3339))
+			return activeRayJobs, nil
+		}
+	case rayv1.ReplaceConcurrent:
+		for i := range activeRayJobs {
+			if err := r.deleteRayJob(ctx, rayCronJob, &activeRayJobs[i]); err != nil {
+				return activeRayJobs, err
+			}
+		}
+		activeRayJobs = nil
+	}
+
+	if err := r.Create(ctx, rayJob); err != nil {
+		if errors.IsAlreadyExists(err) {
+			// The RayJob of the run already finished.
+			rayCronJob.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+			return activeRayJobs, nil
+		}
+		r.Recorder.Eventf(rayCronJob, corev1.EventTypeWarning, string(utils.FailedToCreateRayJob),
+			"Failed to create RayJob %s/%s: %v", rayJob.Namespace, rayJob.Name, err)
+		return activeRayJobs, err
+	}
+	logger.Info("Created the RayJob of the RayCronJob", "RayJob", rayJob.Name, "scheduledTime", scheduledTime)
+	r.Recorder.Eventf(rayCronJob, corev1.EventTypeNormal, string(utils.CreatedRayJob),
+		"Created RayJob %s/%s", rayJob.Namespace, rayJob.Name)
+	rayCronJob.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+	return append(activeRayJobs, *rayJob), nil
+}
+
+// constructRayJobForRayCronJob returns the RayJob of the run of the RayCronJob scheduled at the given time. Its name is
+// derived from the time, so that each run creates a single RayJob.
+func (r *RayCronJobReconciler) constructRayJobForRayCronJob(rayCronJob *rayv1.RayCronJob, scheduledTime time.Time) (*rayv1.RayJob, error) {
+	template := rayCronJob.Spec.RayJobTemplate
+	labels := make(map[string]string, len(template.Labels)+2)
+	for key, value := range template.Labels {
+		labels[key] = value
+	}
+	labels[utils.RayOriginatedFromCRNameLabelKey] = rayCronJob.Name
+	labels[utils.RayOriginatedFromCRDLabelKey] = utils.RayOriginatedFromCRDLabelValue(utils.RayCronJobCRD)
+	annotations := make(map[string]string, len(template.Annotations)+1)
+	for key, value := range template.Annotations {
+		annotations[key] = value
+	}
+	annotations[utils.RayCronJobScheduledTimestampAnnotationKey] = scheduledTime.Format(time.RFC3339)
+
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%d", rayCronJob.Name, scheduledTime.Unix()/60),
+			Namespace:   rayCronJob.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	if err := ctrl.SetControllerReference(rayCronJob, rayJob, r.Scheme); err != nil {
+		return nil, err
+	}
+	return rayJob, nil
+}
+
+// listRayJobs returns the RayJobs created by the RayCronJob.
+func (r *RayCronJobReconciler) listRayJobs(ctx context.Context, rayCronJob *rayv1.RayCronJob) ([]rayv1.RayJob, error) {
+	rayJobList := rayv1.RayJobList{}
+	if err := r.List(ctx, &rayJobList, client.InNamespace(rayCronJob.Namespace), client.MatchingLabels{
+		utils.RayOriginatedFromCRNameLabelKey: rayCronJob.Name,
+		utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayCronJobCRD),
+	}); err != nil {
+		return nil, err
+	}
+	var rayJobs []rayv1.RayJob
+	for _, rayJob := range rayJobList.Items {
+		if metav1.IsControlledBy(&rayJob, rayCronJob) {
+			rayJobs = append(rayJobs, rayJob)
+		}
+	}
+	return rayJobs, nil
+}
+
+// deleteRayJobsBeyondHistoryLimit deletes the oldest of the finished RayJobs until at most limit RayJobs are left.
+func (r *RayCronJobReconciler) deleteRayJobsBeyondHistoryLimit(ctx context.Context, rayCronJob *rayv1.RayCronJob, rayJobs []rayv1.RayJob, limit int32) error {
+	if len(rayJobs) <= int(limit) {
+		return nil
+	}
+	sort.Slice(rayJobs, func(i, j int) bool {
+		return getRayJobFinishedTime(&rayJobs[i]).Before(getRayJobFinishedTime(&rayJobs[j]))
+	})
+	for i := 0; i < len(rayJobs)-int(limit); i++ {
+		if err := r.deleteRayJob(ctx, rayCronJob, &rayJobs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RayCronJobReconciler) deleteRayJob(ctx context.Context, rayCronJob *rayv1.RayCronJob, rayJob *rayv1.RayJob) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if err := r.Delete(ctx, rayJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		r.Recorder.Eventf(rayCronJob, corev1.EventTypeWarning, string(utils.FailedToDeleteRayJob),
+			"Failed to delete RayJob %s/%s: %v", rayJob.Namespace, rayJob.Name, err)
+		return err
+	}
+	logger.Info("Deleted the RayJob of the RayCronJob", "RayJob", rayJob.Name)
+	r.Recorder.Eventf(rayCronJob, corev1.EventTypeNormal, string(utils.DeletedRayJob),
+		"Deleted RayJob %s/%s", rayJob.Namespace, rayJob.Name)
+	return nil
+}
+
+// getMostRecentScheduleTime returns the time of the most recent run of the RayCronJob that is due and wasn't scheduled
+// yet, or nil if there is none, and the time of its next run. The runs missed before startingDeadlineSeconds are
+// ignored.
+func getMostRecentScheduleTime(rayCronJob *rayv1.RayCronJob, schedule cron.Schedule, now time.Time) (*time.Time, time.Time) {
+	earliestTime := rayCronJob.CreationTimestamp.Time
+	if rayCronJob.Status.LastScheduleTime != nil {
+		earliestTime = rayCronJob.Status.LastScheduleTime.Time
+	}
+	if rayCronJob.Spec.StartingDeadlineSeconds != nil {
+		deadline := now.Add(-time.Duration(*rayCronJob.Spec.StartingDeadlineSeconds) * time.Second)
+		if deadline.After(earliestTime) {
+			earliestTime = deadline
+		}
+	}
+	return getScheduleTimesSince(schedule, earliestTime, now)
+}
+
+// getScheduleTimesSince returns the time of the most recent run of the schedule after earliestTime that is due, or nil
+// if there is none, and the time of its next run.
+func getScheduleTimesSince(schedule cron.Schedule, earliestTime time.Time, now time.Time) (*time.Time, time.Time) {
+	var mostRecentTime *time.Time
+	nextTime := schedule.Next(earliestTime)
+	for !nextTime.After(now) {
+		scheduledTime := nextTime
+		mostRecentTime = &scheduledTime
+		nextTime = schedule.Next(nextTime)
+	}
+	return mostRecentTime, nextTime
+}
+
+func isRayJobFinished(rayJob *rayv1.RayJob) bool {
+	return rayJob.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusComplete || rayJob.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusFailed
+}
+
+// getRayJobFinishedTime returns the end time of a finished RayJob, or its creation time if it has none.
+func getRayJobFinishedTime(rayJob *rayv1.RayJob) time.Time {
+	if rayJob.Status.EndTime != nil {
+		return rayJob.Status.EndTime.Time
+	}
+	return rayJob.CreationTimestamp.Time
+}
+
+// SetupWithManager sets up the controller with the Manager. A nil rateLimiter uses the default one of controller-runtime.
+func (r *RayCronJobReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int, rateLimiter ratelimiter.RateLimiter) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rayv1.RayCronJob{}).
+		Owns(&rayv1.RayJob{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: reconcileConcurrency,
+			RateLimiter:             rateLimiter,
+			LogConstructor:          utils.LogConstructor(utils.RayCronJobCRD),
+		}).
+		Complete(utils.WithoutShutdownCancel(r))
+}
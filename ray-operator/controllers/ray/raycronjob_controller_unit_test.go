@@ -0,0 +1,250 @@
+package ray
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+var rayCronJobCreationTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newRayCronJob(concurrencyPolicy rayv1.ConcurrencyPolicy) *rayv1.RayCronJob {
+	return &rayv1.RayCronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-raycronjob",
+			Namespace:         "default",
+			UID:               "test-raycronjob-uid",
+			CreationTimestamp: metav1.Time{Time: rayCronJobCreationTime},
+		},
+		Spec: rayv1.RayCronJobSpec{
+			Schedule:          "*/5 * * * *",
+			ConcurrencyPolicy: concurrencyPolicy,
+			RayJobTemplate: rayv1.RayJobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "etl"},
+				},
+				Spec: rayv1.RayJobSpec{
+					Entrypoint: "python etl.py",
+				},
+			},
+		},
+	}
+}
+
+// newRayCronJobRayJob returns a RayJob of the RayCronJob for the run scheduled at the given time.
+func newRayCronJobRayJob(t *testing.T, scheme *runtime.Scheme, rayCronJob *rayv1.RayCronJob, scheduledTime time.Time, status rayv1.RayJobStatus) *rayv1.RayJob {
+	r := &RayCronJobReconciler{Scheme: scheme}
+	rayJob, err := r.constructRayJobForRayCronJob(rayCronJob, scheduledTime)
+	require.NoError(t, err)
+	rayJob.Status = status
+	return rayJob
+}
+
+func listRayCronJobRayJobs(t *testing.T, c client.Client) map[string]rayv1.RayJob {
+	rayJobs := rayv1.RayJobList{}
+	require.NoError(t, c.List(context.Background(), &rayJobs))
+	rayJobsByName := map[string]rayv1.RayJob{}
+	for _, rayJob := range rayJobs.Items {
+		rayJobsByName[rayJob.Name] = rayJob
+	}
+	return rayJobsByName
+}
+
+func TestGetMostRecentScheduleTime(t *testing.T) {
+	schedule, err := parseCronSchedule("*/5 * * * *", nil)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		lastScheduleTime        *time.Time
+		startingDeadlineSeconds *int64
+		now                     time.Time
+		expectedScheduleTime    *time.Time
+		expectedNextTime        time.Time
+	}{
+		"no run is due": {
+			now:              rayCronJobCreationTime.Add(3 * time.Minute),
+			expectedNextTime: rayCronJobCreationTime.Add(5 * time.Minute),
+		},
+		"the most recent of the missed runs is due": {
+			now:                  rayCronJobCreationTime.Add(12 * time.Minute),
+			expectedScheduleTime: ptr.To(rayCronJobCreationTime.Add(10 * time.Minute)),
+			expectedNextTime:     rayCronJobCreationTime.Add(15 * time.Minute),
+		},
+		"the last scheduled run is not due again": {
+			lastScheduleTime: ptr.To(rayCronJobCreationTime.Add(10 * time.Minute)),
+			now:              rayCronJobCreationTime.Add(12 * time.Minute),
+			expectedNextTime: rayCronJobCreationTime.Add(15 * time.Minute),
+		},
+		"the runs missed before the starting deadline are not due": {
+			startingDeadlineSeconds: ptr.To[int64](60),
+			now:                     rayCronJobCreationTime.Add(12 * time.Minute),
+			expectedNextTime:        rayCronJobCreationTime.Add(15 * time.Minute),
+		},
+		"the runs missed within the starting deadline are due": {
+			startingDeadlineSeconds: ptr.To[int64](180),
+			now:                     rayCronJobCreationTime.Add(12 * time.Minute),
+			expectedScheduleTime:    ptr.To(rayCronJobCreationTime.Add(10 * time.Minute)),
+			expectedNextTime:        rayCronJobCreationTime.Add(15 * time.Minute),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rayCronJob := newRayCronJob(rayv1.AllowConcurrent)
+			rayCronJob.Spec.StartingDeadlineSeconds = tc.startingDeadlineSeconds
+			if tc.lastScheduleTime != nil {
+				rayCronJob.Status.LastScheduleTime = &metav1.Time{Time: *tc.lastScheduleTime}
+			}
+			scheduleTime, nextTime := getMostRecentScheduleTime(rayCronJob, schedule, tc.now)
+			if tc.expectedScheduleTime == nil {
+				assert.Nil(t, scheduleTime)
+			} else {
+				require.NotNil(t, scheduleTime)
+				assert.True(t, tc.expectedScheduleTime.Equal(*scheduleTime))
+			}
+			assert.True(t, tc.expectedNextTime.Equal(nextTime))
+		})
+	}
+}
+
+func TestReconcileRayCronJob(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	ctx := context.Background()
+	firstRun := rayCronJobCreationTime.Add(5 * time.Minute)
+	secondRun := rayCronJobCreationTime.Add(10 * time.Minute)
+	now := secondRun.Add(time.Minute)
+	running := rayv1.RayJobStatus{JobDeploymentStatus: rayv1.JobDeploymentStatusRunning}
+
+	tests := map[string]struct {
+		concurrencyPolicy  rayv1.ConcurrencyPolicy
+		expectedRayJobRuns []time.Time
+	}{
+		"Allow runs the RayJobs concurrently": {
+			concurrencyPolicy:  rayv1.AllowConcurrent,
+			expectedRayJobRuns: []time.Time{firstRun, secondRun},
+		},
+		"Forbid skips the run while a RayJob is running": {
+			concurrencyPolicy:  rayv1.ForbidConcurrent,
+			expectedRayJobRuns: []time.Time{firstRun},
+		},
+		"Replace deletes the running RayJob": {
+			concurrencyPolicy:  rayv1.ReplaceConcurrent,
+			expectedRayJobRuns: []time.Time{secondRun},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rayCronJob := newRayCronJob(tc.concurrencyPolicy)
+			rayCronJob.Status.LastScheduleTime = &metav1.Time{Time: firstRun}
+			rayJob := newRayCronJobRayJob(t, newScheme, rayCronJob, firstRun, running)
+			fakeClient := newFakeClientBuilder().
+				WithScheme(newScheme).
+				WithRuntimeObjects(rayCronJob, rayJob).
+				WithStatusSubresource(rayCronJob).Build()
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rayCronJob), rayCronJob))
+			r := &RayCronJobReconciler{Client: fakeClient, Scheme: newScheme, Recorder: record.NewFakeRecorder(10)}
+
+			requeueAfter, err := r.reconcileRayCronJob(ctx, rayCronJob, now)
+			require.NoError(t, err)
+			assert.Equal(t, 4*time.Minute, requeueAfter)
+
+			rayJobs := listRayCronJobRayJobs(t, fakeClient)
+			require.Len(t, rayJobs, len(tc.expectedRayJobRuns))
+			for _, run := range tc.expectedRayJobRuns {
+				rayJob, ok := rayJobs[fmt.Sprintf("%s-%d", rayCronJob.Name, run.Unix()/60)]
+				require.True(t, ok)
+				assert.Equal(t, "etl", rayJob.Labels["app"])
+				assert.Equal(t, rayCronJob.Name, rayJob.Labels[utils.RayOriginatedFromCRNameLabelKey])
+				assert.Equal(t, run.Format(time.RFC3339), rayJob.Annotations[utils.RayCronJobScheduledTimestampAnnotationKey])
+				assert.True(t, metav1.IsControlledBy(&rayJob, rayCronJob))
+				assert.Equal(t, "python etl.py", rayJob.Spec.Entrypoint)
+			}
+
+			updatedRayCronJob := &rayv1.RayCronJob{}
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rayCronJob), updatedRayCronJob))
+			assert.Len(t, updatedRayCronJob.Status.Active, len(tc.expectedRayJobRuns))
+			expectedLastScheduleTime := secondRun
+			if tc.concurrencyPolicy == rayv1.ForbidConcurrent {
+				expectedLastScheduleTime = firstRun
+			}
+			assert.True(t, updatedRayCronJob.Status.LastScheduleTime.Time.Equal(expectedLastScheduleTime))
+		})
+	}
+}
+
+func TestReconcileRayCronJobHistoryLimits(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	ctx := context.Background()
+	rayCronJob := newRayCronJob(rayv1.AllowConcurrent)
+	rayCronJob.Spec.SuccessfulJobsHistoryLimit = ptr.To[int32](1)
+	rayCronJob.Spec.FailedJobsHistoryLimit = ptr.To[int32](0)
+	now := rayCronJobCreationTime.Add(time.Hour)
+	rayCronJob.Status.LastScheduleTime = &metav1.Time{Time: now}
+
+	objects := []runtime.Object{rayCronJob}
+	for i := 1; i <= 3; i++ {
+		scheduledTime := rayCronJobCreationTime.Add(time.Duration(5*i) * time.Minute)
+		objects = append(objects, newRayCronJobRayJob(t, newScheme, rayCronJob, scheduledTime, rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusComplete,
+			JobStatus:           rayv1.JobStatusSucceeded,
+			EndTime:             &metav1.Time{Time: scheduledTime.Add(time.Minute)},
+		}))
+	}
+	failedTime := rayCronJobCreationTime.Add(20 * time.Minute)
+	objects = append(objects, newRayCronJobRayJob(t, newScheme, rayCronJob, failedTime, rayv1.RayJobStatus{
+		JobDeploymentStatus: rayv1.JobDeploymentStatusFailed,
+		JobStatus:           rayv1.JobStatusFailed,
+		EndTime:             &metav1.Time{Time: failedTime.Add(time.Minute)},
+	}))
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(objects...).
+		WithStatusSubresource(rayCronJob).Build()
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rayCronJob), rayCronJob))
+	r := &RayCronJobReconciler{Client: fakeClient, Scheme: newScheme, Recorder: record.NewFakeRecorder(10)}
+
+	_, err := r.reconcileRayCronJob(ctx, rayCronJob, now)
+	require.NoError(t, err)
+
+	// Only the RayJob that succeeded last is kept.
+	lastSucceededTime := rayCronJobCreationTime.Add(15 * time.Minute)
+	rayJobs := listRayCronJobRayJobs(t, fakeClient)
+	require.Len(t, rayJobs, 1)
+	assert.Contains(t, rayJobs, fmt.Sprintf("%s-%d", rayCronJob.Name, lastSucceededTime.Unix()/60))
+
+	updatedRayCronJob := &rayv1.RayCronJob{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rayCronJob), updatedRayCronJob))
+	assert.Empty(t, updatedRayCronJob.Status.Active)
+	assert.True(t, updatedRayCronJob.Status.LastSuccessfulTime.Time.Equal(lastSucceededTime.Add(time.Minute)))
+}
+
+func TestReconcileRayCronJobInvalidSchedule(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	rayCronJob := newRayCronJob(rayv1.AllowConcurrent)
+	rayCronJob.Spec.Schedule = "every day"
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayCronJob).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &RayCronJobReconciler{Client: fakeClient, Scheme: newScheme, Recorder: recorder}
+
+	requeueAfter, err := r.reconcileRayCronJob(context.Background(), rayCronJob, rayCronJobCreationTime.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, requeueAfter)
+	assert.Empty(t, listRayCronJobRayJobs(t, fakeClient))
+	assert.Contains(t, <-recorder.Events, string(utils.InvalidRayCronJobSpec))
+}
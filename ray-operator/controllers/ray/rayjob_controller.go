@@ -1,16 +1,24 @@
 package ray
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	errstd "errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
-	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
@@ -19,12 +27,15 @@ import (
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/client/dashboard"
+	"github.com/ray-project/kuberay/ray-operator/pkg/features"
+	"github.com/ray-project/kuberay/ray-operator/pkg/tracing"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
@@ -33,14 +44,22 @@ const (
 	RayJobDefaultRequeueDuration    = 3 * time.Second
 	RayJobDefaultClusterSelectorKey = "ray.io/cluster"
 	PythonUnbufferedEnvVarName      = "PYTHONUNBUFFERED"
+	// RayJobGracefulStopTimeout is how long the RayCluster of a deleted RayJob is kept for its Ray job to stop.
+	RayJobGracefulStopTimeout = 60 * time.Second
 )
 
+// rayJobLogsUploadClient uploads the driver logs of Ray jobs to `spec.logStreaming.sinkURL`.
+var rayJobLogsUploadClient = &http.Client{Timeout: 30 * time.Second}
+
 // RayJobReconciler reconciles a RayJob object
 type RayJobReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
 
+	// apiReader reads the Secrets and ConfigMaps of the runtime environments from the API server, as the operator does
+	// not cache them.
+	apiReader           client.Reader
 	dashboardClientFunc func() utils.RayDashboardClientInterface
 }
 
@@ -51,6 +70,7 @@ func NewRayJobReconciler(_ context.Context, mgr manager.Manager, provider utils.
 		Client:              mgr.GetClient(),
 		Scheme:              mgr.GetScheme(),
 		Recorder:            mgr.GetEventRecorderFor("rayjob-controller"),
+		apiReader:           mgr.GetAPIReader(),
 		dashboardClientFunc: dashboardClientFunc,
 	}
 }
@@ -66,6 +86,7 @@ func NewRayJobReconciler(_ context.Context, mgr manager.Manager, provider utils.
 // +kubebuilder:rbac:groups=core,resources=services/proxy,verbs=get;update;patch;create
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;update
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
@@ -75,46 +96,42 @@ func NewRayJobReconciler(_ context.Context, mgr manager.Manager, provider utils.
 // and what is in the RayJob.Spec
 // Automatically generate RBAC rules to allow the Controller to read and write workloads
 // Reconcile used to bridge the desired state with the current state
-func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
 	logger := ctrl.LoggerFrom(ctx)
+	observer := common.ObserveReconcile(common.KindRayJob, request.NamespacedName)
+	defer observer.Done(&err)
+	ctx, span := tracing.StartReconcile(ctx, common.KindRayJob, request.NamespacedName)
+	defer tracing.End(span, &err)
 
 	// Get RayJob instance
-	var err error
 	rayJobInstance := &rayv1.RayJob{}
 	if err := r.Get(ctx, request.NamespacedName, rayJobInstance); err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request. Stop reconciliation.
 			logger.Info("RayJob resource not found. Ignoring since object must be deleted", "name", request.NamespacedName)
+			observer.Deleted()
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		logger.Error(err, "Failed to get RayJob")
 		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 	}
+	common.SetRayJobGauges(rayJobInstance, time.Now())
 
 	if !rayJobInstance.ObjectMeta.DeletionTimestamp.IsZero() {
 		logger.Info("RayJob is being deleted", "DeletionTimestamp", rayJobInstance.ObjectMeta.DeletionTimestamp)
 		// If the JobStatus is not terminal, it is possible that the Ray job is still running. This includes
 		// the case where JobStatus is JobStatusNew.
-		if !rayv1.IsJobTerminal(rayJobInstance.Status.JobStatus) {
-			rayClusterNamespacedName := common.RayJobRayClusterNamespacedName(rayJobInstance)
-			rayClusterInstance := &rayv1.RayCluster{}
-			if err := r.Get(ctx, rayClusterNamespacedName, rayClusterInstance); err != nil {
-				logger.Error(err, "Failed to get RayCluster")
-			}
-
-			rayDashboardClient := r.dashboardClientFunc()
-			err = rayDashboardClient.InitClient(ctx, rayJobInstance.Status.DashboardURL, rayClusterInstance)
-			if err != nil {
-				logger.Error(err, "Failed to initialize dashboard client")
-			}
-			err = rayDashboardClient.StopJob(ctx, rayJobInstance.Status.JobId)
-			if err != nil {
-				logger.Error(err, "Failed to stop job for RayJob")
-			}
+		// In InteractiveMode, there is no Ray job to stop until users set its submission ID.
+		// The finalizer is kept until the Ray job stopped, so that the RayCluster, which is garbage collected once the
+		// RayJob is deleted, is not deleted under a running driver.
+		if !rayv1.IsJobTerminal(rayJobInstance.Status.JobStatus) && rayJobInstance.Status.JobId != "" &&
+			!r.stopRayJobGracefully(ctx, rayJobInstance) {
+			logger.Info("Wait for the Ray job to stop before removing the finalizer.", "JobId", rayJobInstance.Status.JobId)
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
 		}
 
-		logger.Info("Remove the finalizer no matter StopJob() succeeds or not.", "finalizer", utils.RayJobStopJobFinalizer)
+		logger.Info("Remove the finalizer.", "finalizer", utils.RayJobStopJobFinalizer)
 		controllerutil.RemoveFinalizer(rayJobInstance, utils.RayJobStopJobFinalizer)
 		err := r.Update(ctx, rayJobInstance)
 		if err != nil {
@@ -143,6 +160,21 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 			}
 		}
+		// A RayJob created with the suspend flag, e.g. by Kueue until it is admitted, is suspended without creating
+		// the RayCluster, and without starting the clock of `ActiveDeadlineSeconds`.
+		if rayJobInstance.Spec.Suspend {
+			logger.Info("The suspend flag is true. Transition the status from `New` to `Suspended`.")
+			rayJobInstance.Status.JobStatus = rayv1.JobStatusNew
+			rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusSuspended
+			setRayJobSuspendedCondition(rayJobInstance, metav1.ConditionTrue, rayv1.RayJobSuspendedReason, "The RayJob was created with the suspend flag")
+			break
+		}
+		// A RayJob with a schedule waits for the first run of its schedule. The retries and the resumed runs start now.
+		if rayJobInstance.Spec.Schedule != "" && rayJobInstance.Status.LastScheduleTime == nil {
+			logger.Info("The RayJob has a schedule. Transition the status from `New` to `Scheduled`.", "Schedule", rayJobInstance.Spec.Schedule)
+			rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusScheduled
+			break
+		}
 		// Set `Status.JobDeploymentStatus` to `JobDeploymentStatusInitializing`, and initialize `Status.JobId`
 		// and `Status.RayClusterName` prior to avoid duplicate job submissions and cluster creations.
 		logger.Info("JobDeploymentStatusNew", "RayJob", rayJobInstance.Name)
@@ -166,6 +198,14 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		// Check the current status of RayCluster before submitting.
 		if clientURL := rayJobInstance.Status.DashboardURL; clientURL == "" {
 			if rayClusterInstance.Status.State != rayv1.Ready { //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+				if shouldUpdate := r.checkClusterReadyTimeoutAndUpdateStatusIfNeeded(ctx, rayJobInstance, rayClusterInstance); shouldUpdate {
+					// No Ray job ran on the RayCluster, so it is deleted right away to release the quota held by its
+					// pending Pods, whatever the deletionPolicy is.
+					if _, err := r.deleteClusterResources(ctx, rayJobInstance); err != nil {
+						return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+					}
+					break
+				}
 				logger.Info("Wait for the RayCluster.Status.State to be ready before submitting the job.", "RayCluster", rayClusterInstance.Name, "State", rayClusterInstance.Status.State) //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
 				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 			}
@@ -177,6 +217,13 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			rayJobInstance.Status.DashboardURL = clientURL
 		}
 
+		if rayJobInstance.Spec.SubmissionMode == rayv1.InteractiveMode {
+			logger.Info("The RayCluster is ready. Transition the status from `Initializing` to `Waiting` for users to submit the Ray job.",
+				"RayJob", rayJobInstance.Name, "RayCluster", rayJobInstance.Status.RayClusterName, "DashboardURL", rayJobInstance.Status.DashboardURL)
+			rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusWaiting
+			break
+		}
+
 		if rayJobInstance.Spec.SubmissionMode == rayv1.K8sJobMode {
 			if err := r.createK8sJobIfNeed(ctx, rayJobInstance, rayClusterInstance); err != nil {
 				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
@@ -186,12 +233,41 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		logger.Info("Both RayCluster and the submitter K8s Job are created. Transition the status from `Initializing` to `Running`.",
 			"RayJob", rayJobInstance.Name, "RayCluster", rayJobInstance.Status.RayClusterName)
 		rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusRunning
+	case rayv1.JobDeploymentStatusWaiting:
+		if shouldUpdate := r.updateStatusToSuspendingIfNeeded(ctx, rayJobInstance); shouldUpdate {
+			break
+		}
+
+		if shouldUpdate := r.checkActiveDeadlineAndUpdateStatusIfNeeded(ctx, rayJobInstance); shouldUpdate {
+			break
+		}
+
+		// Users submit the Ray job to the dashboard at `Status.DashboardURL`, e.g. with the Ray Job SDK, and then
+		// set its submission ID in the annotation so that KubeRay tracks its status.
+		// The submission ID of a failed attempt may still be set in the annotation when the Ray job is retried.
+		submissionId := rayJobInstance.Annotations[utils.RayJobSubmissionIdAnnotationKey]
+		if submissionId == "" || isRayJobAttemptSubmissionId(rayJobInstance, submissionId) {
+			logger.Info("Wait for users to submit the Ray job and to set its submission ID in the annotation.",
+				"annotation", utils.RayJobSubmissionIdAnnotationKey, "DashboardURL", rayJobInstance.Status.DashboardURL)
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+		}
+		logger.Info("The submission ID of the Ray job is set. Transition the status from `Waiting` to `Running`.", "JobId", submissionId)
+		rayJobInstance.Status.JobId = submissionId
+		rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusRunning
 	case rayv1.JobDeploymentStatusRunning:
 		if shouldUpdate := r.updateStatusToSuspendingIfNeeded(ctx, rayJobInstance); shouldUpdate {
 			break
 		}
 
 		if shouldUpdate := r.checkActiveDeadlineAndUpdateStatusIfNeeded(ctx, rayJobInstance); shouldUpdate {
+			// The Ray job may still be running. Stop it before the RayJob fails, as the RayCluster is not deleted if
+			// `ShutdownAfterJobFinishes` is false or the RayCluster is selected by `ClusterSelector`. The status is not
+			// updated if the Ray job cannot be stopped, so that stopping it is retried in the next reconciliation.
+			if err := r.stopRayJob(ctx, rayJobInstance); err != nil {
+				logger.Error(err, "Failed to stop the Ray job after the RayJob passed the activeDeadlineSeconds", "JobId", rayJobInstance.Status.JobId)
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			}
+			rayJobInstance.Status.JobStatus = rayv1.JobStatusStopped
 			break
 		}
 
@@ -225,15 +301,54 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 		}
 
-		jobInfo, err := rayDashboardClient.GetJobInfo(ctx, rayJobInstance.Status.JobId)
+		// The steps of the Ray job are submitted by the KubeRay operator, and their statuses are aggregated into the one of
+		// the Ray job of the RayJob.
+		var jobInfo *utils.RayJobInfo
+		if len(rayJobInstance.Spec.Steps) != 0 {
+			if jobInfo, err = r.reconcileRayJobSteps(ctx, rayJobInstance, rayDashboardClient); err != nil {
+				logger.Error(err, "Failed to reconcile the steps of the Ray job", "JobId", rayJobInstance.Status.JobId)
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			}
+		} else {
+			jobInfo, err = rayDashboardClient.GetJobInfo(ctx, rayJobInstance.Status.JobId)
+		}
 		if err != nil {
 			// If the Ray job was not found, GetJobInfo returns a BadRequest error.
 			if rayJobInstance.Spec.SubmissionMode == rayv1.HTTPMode && errors.IsBadRequest(err) {
 				logger.Info("The Ray job was not found. Submit a Ray job via an HTTP request.", "JobId", rayJobInstance.Status.JobId)
-				if _, err := rayDashboardClient.SubmitJob(ctx, rayJobInstance); err != nil {
+				submittedRayJob, err := r.resolveRuntimeEnvFrom(ctx, rayJobInstance)
+				if err != nil {
+					return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+				}
+				if _, err := rayDashboardClient.SubmitJob(ctx, submittedRayJob); err != nil {
 					logger.Error(err, "Failed to submit the Ray job", "JobId", rayJobInstance.Status.JobId)
+					if isRayJobSubmissionRejected(err) {
+						// Submitting the same request again would be rejected again, so the RayJob fails like when the
+						// submitter Kubernetes Job fails in K8sJobMode.
+						r.Recorder.Eventf(rayJobInstance, corev1.EventTypeWarning, string(utils.FailedToSubmitRayJob),
+							"The Ray job %s was rejected by the dashboard: %v", rayJobInstance.Status.JobId, err)
+						rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusFailed
+						rayJobInstance.Status.Reason = rayv1.SubmissionFailed
+						rayJobInstance.Status.Message = fmt.Sprintf("Job submission has failed. Message: %v", err)
+						break
+					}
 					return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 				}
+				r.Recorder.Eventf(rayJobInstance, corev1.EventTypeNormal, string(utils.SubmittedRayJob),
+					"Submitted the Ray job %s to the RayCluster %s", rayJobInstance.Status.JobId, rayClusterInstance.Name)
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+			}
+			// The submitter container in the head Pod waits for the dashboard before it submits the Ray job.
+			if rayJobInstance.Spec.SubmissionMode == rayv1.SidecarMode && errors.IsBadRequest(err) {
+				if shouldUpdate := r.checkSidecarSubmitterAndUpdateStatusIfNeeded(ctx, rayJobInstance, rayClusterInstance); shouldUpdate {
+					break
+				}
+				logger.Info("The Ray job was not found. Wait for the submitter container in the head Pod to submit it.", "JobId", rayJobInstance.Status.JobId)
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+			}
+			// Users may set the submission ID before the Ray job is submitted.
+			if rayJobInstance.Spec.SubmissionMode == rayv1.InteractiveMode && errors.IsBadRequest(err) {
+				logger.Info("The Ray job was not found. Wait for users to submit it.", "JobId", rayJobInstance.Status.JobId)
 				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
 			}
 			logger.Error(err, "Failed to get job info", "JobId", rayJobInstance.Status.JobId)
@@ -260,6 +375,36 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 				jobDeploymentStatus = rayv1.JobDeploymentStatusFailed
 				reason = rayv1.AppFailed
 			}
+			if jobInfo.JobStatus == rayv1.JobStatusSucceeded {
+				rayJobInstance.Status.LastSuccessfulTime = &metav1.Time{Time: time.Now()}
+			}
+			// Keep the end of the driver logs in the status, so that failures can be debugged from the RayJob alone,
+			// even after the RayCluster is deleted. Failing to fetch them does not block the status transition.
+			// The logs of a RayJob with steps are the ones of the step that failed, or else of the last step.
+			rayJobInstance.Status.DriverExitCode = jobInfo.DriverExitCode
+			logsJobId := rayJobInstance.Status.JobId
+			if jobInfo.SubmissionId != "" {
+				logsJobId = jobInfo.SubmissionId
+			}
+			if logs, err := rayDashboardClient.GetJobLog(ctx, logsJobId); err != nil {
+				logger.Error(err, "Failed to get the logs of the Ray job", "JobId", logsJobId)
+			} else if logs != nil {
+				rayJobInstance.Status.LogsTail = truncateToTail(*logs, utils.RayJobLogsTailMaxBytes)
+				if result, err := getRayJobResult(*logs); err != nil {
+					r.Recorder.Eventf(rayJobInstance, corev1.EventTypeWarning, string(utils.InvalidRayJobResult),
+						"The result of the Ray job %s is ignored: %v", logsJobId, err)
+				} else {
+					rayJobInstance.Status.JobResult = result
+				}
+				if rayJobInstance.Spec.LogStreaming != nil && rayJobInstance.Spec.LogStreaming.SinkURL != "" {
+					r.uploadRayJobLogs(ctx, rayJobInstance, *logs)
+				}
+			}
+		} else if common.IsRayJobLogsFollowed(rayJobInstance) {
+			// The Ray job has been submitted, so the Kubernetes Job can follow its driver logs.
+			if err := r.createK8sJobIfNeed(ctx, rayJobInstance, rayClusterInstance); err != nil {
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			}
 		}
 
 		// Always update RayClusterStatus along with JobStatus and JobDeploymentStatus updates.
@@ -267,19 +412,28 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		rayJobInstance.Status.JobStatus = jobInfo.JobStatus
 		rayJobInstance.Status.JobDeploymentStatus = jobDeploymentStatus
 		rayJobInstance.Status.Reason = reason
-		rayJobInstance.Status.Message = jobInfo.Message
+		rayJobInstance.Status.Message = truncateToHead(jobInfo.Message, utils.RayJobStatusMessageMaxBytes)
 	case rayv1.JobDeploymentStatusSuspending, rayv1.JobDeploymentStatusRetrying:
 		// The `suspend` operation should be atomic. In other words, if users set the `suspend` flag to true and then immediately
 		// set it back to false, either all of the RayJob's associated resources should be cleaned up, or no resources should be
 		// cleaned up at all. To keep the atomicity, if a RayJob is in the `Suspending` status, we should delete all of its
 		// associated resources and then transition the status to `Suspended` no matter the value of the `suspend` flag.
 
-		// TODO (kevin85421): Currently, Ray doesn't have a best practice to stop a Ray job gracefully. At this moment,
-		// KubeRay doesn't stop the Ray job before suspending the RayJob. If users want to stop the Ray job by SIGTERM,
-		// users need to set the Pod's preStop hook by themselves.
-		isClusterDeleted, err := r.deleteClusterResources(ctx, rayJobInstance)
-		if err != nil {
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		// The Ray job is stopped before its RayCluster is deleted, so that it exits through the same path as when users
+		// stop it. Suspending is not blocked if the Ray job cannot be stopped, e.g. because the dashboard is unreachable,
+		// as the compute resources must be released, e.g. for Kueue to preempt the RayJob.
+		if rayJobInstance.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusSuspending &&
+			rayJobInstance.Status.JobId != "" && !rayv1.IsJobTerminal(rayJobInstance.Status.JobStatus) {
+			if err := r.stopRayJob(ctx, rayJobInstance); err != nil {
+				logger.Error(err, "Failed to stop the Ray job before suspending the RayJob", "JobId", rayJobInstance.Status.JobId)
+			}
+		}
+		reuseRayCluster := rayJobInstance.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusRetrying && rayJobInstance.Spec.ReuseRayClusterOnRetry
+		isClusterDeleted := true
+		if !reuseRayCluster {
+			if isClusterDeleted, err = r.deleteClusterResources(ctx, rayJobInstance); err != nil {
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			}
 		}
 		isJobDeleted, err := r.deleteSubmitterJob(ctx, rayJobInstance)
 		if err != nil {
@@ -291,18 +445,25 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
 		}
 
-		// Reset the RayCluster and Ray job related status.
-		rayJobInstance.Status.RayClusterStatus = rayv1.RayClusterStatus{}
-		rayJobInstance.Status.RayClusterName = ""
-		rayJobInstance.Status.DashboardURL = ""
+		// Reset the RayCluster and Ray job related status. The RayCluster related status is kept if the retry reuses it.
+		if !reuseRayCluster {
+			rayJobInstance.Status.RayClusterStatus = rayv1.RayClusterStatus{}
+			rayJobInstance.Status.RayClusterName = ""
+			rayJobInstance.Status.DashboardURL = ""
+		}
 		rayJobInstance.Status.JobId = ""
 		rayJobInstance.Status.Message = ""
+		rayJobInstance.Status.LogsTail = ""
+		rayJobInstance.Status.JobResult = ""
+		rayJobInstance.Status.Steps = nil
+		rayJobInstance.Status.DriverExitCode = nil
 		rayJobInstance.Status.Reason = ""
 		// Reset the JobStatus to JobStatusNew and transition the JobDeploymentStatus to `Suspended`.
 		rayJobInstance.Status.JobStatus = rayv1.JobStatusNew
 
 		if rayJobInstance.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusSuspending {
 			rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusSuspended
+			setRayJobSuspendedCondition(rayJobInstance, metav1.ConditionTrue, rayv1.RayJobSuspendedReason, "The RayCluster and the submitter Job were deleted")
 		}
 		if rayJobInstance.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusRetrying {
 			rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusNew
@@ -312,20 +473,87 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			logger.Info("The status is 'Suspended', but the suspend flag is false. Transition the status to 'New'.")
 			rayJobInstance.Status.JobStatus = rayv1.JobStatusNew
 			rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusNew
+			setRayJobSuspendedCondition(rayJobInstance, metav1.ConditionFalse, rayv1.RayJobResumedReason, "The suspend flag was set to false")
 			break
 		}
 		// TODO (kevin85421): We may not need to requeue the RayJob if it has already been suspended.
 		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+	case rayv1.JobDeploymentStatusScheduled:
+		schedule, err := parseCronSchedule(rayJobInstance.Spec.Schedule, nil)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		}
+		earliestTime := rayJobInstance.CreationTimestamp.Time
+		if rayJobInstance.Status.LastScheduleTime != nil {
+			earliestTime = rayJobInstance.Status.LastScheduleTime.Time
+		}
+		// The runs missed while the previous run was running are merged into a single run.
+		now := time.Now()
+		scheduledTime, nextTime := getScheduleTimesSince(schedule, earliestTime, now)
+		if scheduledTime == nil || rayJobInstance.Spec.Suspend {
+			logger.Info("Wait for the next run of the schedule", "Schedule", rayJobInstance.Spec.Schedule, "NextTime", nextTime, "Suspend", rayJobInstance.Spec.Suspend)
+			return ctrl.Result{RequeueAfter: nextTime.Sub(now)}, nil
+		}
+
+		// The submitter Job of the previous run is kept until the next run so that users can still access the driver
+		// logs, and the RayCluster of the previous run is reused unless the deletionPolicy deleted it. In SidecarMode,
+		// the head Pod submits the jobId of the previous run, so the RayCluster is never reused.
+		reuseRayCluster := getDeletionPolicy(rayJobInstance) == rayv1.DeleteNone && rayJobInstance.Spec.SubmissionMode != rayv1.SidecarMode
+		if len(rayJobInstance.Status.Attempts) != 0 {
+			isClusterDeleted := true
+			if !reuseRayCluster {
+				if isClusterDeleted, err = r.deleteClusterResources(ctx, rayJobInstance); err != nil {
+					return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+				}
+			}
+			isJobDeleted, err := r.deleteSubmitterJob(ctx, rayJobInstance)
+			if err != nil {
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			}
+			if !isClusterDeleted || !isJobDeleted {
+				logger.Info("Wait for the resources of the previous run to be deleted before the next run of the schedule.")
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+			}
+		}
+
+		// Reset the status of the previous run, so that the run submits the Ray job with a new jobId.
+		if !reuseRayCluster {
+			rayJobInstance.Status.RayClusterStatus = rayv1.RayClusterStatus{}
+			rayJobInstance.Status.RayClusterName = ""
+			rayJobInstance.Status.DashboardURL = ""
+		}
+		rayJobInstance.Status.JobId = ""
+		rayJobInstance.Status.Message = ""
+		rayJobInstance.Status.LogsTail = ""
+		rayJobInstance.Status.JobResult = ""
+		rayJobInstance.Status.Steps = nil
+		rayJobInstance.Status.DriverExitCode = nil
+		rayJobInstance.Status.Reason = ""
+		rayJobInstance.Status.EndTime = nil
+		rayJobInstance.Status.Attempts = nil
+		rayJobInstance.Status.Succeeded = nil
+		rayJobInstance.Status.Failed = nil
+		rayJobInstance.Status.JobStatus = rayv1.JobStatusNew
+		rayJobInstance.Status.LastScheduleTime = &metav1.Time{Time: *scheduledTime}
+		logger.Info("Run the schedule of the RayJob. Transition the status from `Scheduled` to `New`.", "ScheduledTime", *scheduledTime)
+		rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusNew
 	case rayv1.JobDeploymentStatusComplete, rayv1.JobDeploymentStatusFailed:
-		// If this RayJob uses an existing RayCluster (i.e., ClusterSelector is set), we should not delete the RayCluster.
-		logger.Info(string(rayJobInstance.Status.JobDeploymentStatus), "RayJob", rayJobInstance.Name, "ShutdownAfterJobFinishes", rayJobInstance.Spec.ShutdownAfterJobFinishes, "ClusterSelector", rayJobInstance.Spec.ClusterSelector)
-		if rayJobInstance.Spec.ShutdownAfterJobFinishes && len(rayJobInstance.Spec.ClusterSelector) == 0 {
+		deletionPolicy := getDeletionPolicy(rayJobInstance)
+		logger.Info(string(rayJobInstance.Status.JobDeploymentStatus), "RayJob", rayJobInstance.Name, "DeletionPolicy", deletionPolicy, "ClusterSelector", rayJobInstance.Spec.ClusterSelector)
+		if deletionPolicy != rayv1.DeleteNone {
 			ttlSeconds := rayJobInstance.Spec.TTLSecondsAfterFinished
 			nowTime := time.Now()
+			// The EndTime may be unset if the RayJob finished before it was recorded, in which case the TTL starts now.
+			if rayJobInstance.Status.EndTime == nil {
+				rayJobInstance.Status.EndTime = &metav1.Time{Time: nowTime}
+				if err := utils.PatchStatus(ctx, r.Client, rayJobInstance); err != nil {
+					return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+				}
+			}
 			shutdownTime := rayJobInstance.Status.EndTime.Add(time.Duration(ttlSeconds) * time.Second)
 			logger.Info(
 				fmt.Sprintf("RayJob is %s", rayJobInstance.Status.JobDeploymentStatus),
-				"shutdownAfterJobFinishes", rayJobInstance.Spec.ShutdownAfterJobFinishes,
+				"deletionPolicy", deletionPolicy,
 				"ttlSecondsAfterFinished", ttlSeconds,
 				"Status.endTime", rayJobInstance.Status.EndTime,
 				"Now", nowTime,
@@ -335,10 +563,13 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 				logger.Info(fmt.Sprintf("shutdownTime not reached, requeue this RayJob for %d seconds", delta))
 				return ctrl.Result{RequeueAfter: time.Duration(delta) * time.Second}, nil
 			}
-			if s := os.Getenv(utils.DELETE_RAYJOB_CR_AFTER_JOB_FINISHES); strings.ToLower(s) == "true" {
+			switch deletionPolicy {
+			case rayv1.DeleteSelf:
 				err = r.Client.Delete(ctx, rayJobInstance)
 				logger.Info("RayJob is deleted")
-			} else {
+			case rayv1.DeleteWorkers:
+				err = r.deleteWorkers(ctx, rayJobInstance)
+			default:
 				// We only need to delete the RayCluster. We don't need to delete the submitter Kubernetes Job so that users can still access
 				// the driver logs. In addition, a completed Kubernetes Job does not actually use any compute resources.
 				_, err = r.deleteClusterResources(ctx, rayJobInstance)
@@ -348,17 +579,29 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 			}
 		}
+		// A RayJob with a schedule waits for the next run of its schedule once the deletionPolicy was applied.
+		if rayJobInstance.Spec.Schedule != "" {
+			logger.Info(fmt.Sprintf("The RayJob has a schedule. Transition the status from `%s` to `Scheduled`.", rayJobInstance.Status.JobDeploymentStatus))
+			rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusScheduled
+			break
+		}
 		// If the RayJob is completed, we should not requeue it.
 		return ctrl.Result{}, nil
 	default:
 		logger.Info("Unknown JobDeploymentStatus", "JobDeploymentStatus", rayJobInstance.Status.JobDeploymentStatus)
 		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
 	}
+	recordRayJobAttemptIfNeeded(rayJobInstance)
 	checkBackoffLimitAndUpdateStatusIfNeeded(ctx, rayJobInstance)
 
 	// This is the only place where we update the RayJob status. Please do NOT add any code
 	// between `checkBackoffLimitAndUpdateStatusIfNeeded` and the following code.
 	if err = r.updateRayJobStatus(ctx, originalRayJobInstance, rayJobInstance); err != nil {
+		if errors.IsConflict(err) {
+			// The RayJob was modified since it was read, so its status is recomputed from the latest RayJob.
+			logger.Info("The RayJob was modified while reconciling it; requeue it to recompute its status")
+			return ctrl.Result{Requeue: true}, nil
+		}
 		logger.Info("Failed to update RayJob status", "error", err)
 		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 	}
@@ -367,6 +610,106 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 
 // checkBackoffLimitAndUpdateStatusIfNeeded determines if a RayJob is eligible for retry based on the configured backoff limit,
 // the job's success status, and its failure status. If eligible, sets the JobDeploymentStatus to Retrying.
+// recordRayJobAttemptIfNeeded appends the attempt to run the Ray job to `Status.Attempts` if it has just succeeded or
+// failed, before it may be retried.
+func recordRayJobAttemptIfNeeded(rayJob *rayv1.RayJob) {
+	if rayJob.Status.JobDeploymentStatus != rayv1.JobDeploymentStatusComplete && rayJob.Status.JobDeploymentStatus != rayv1.JobDeploymentStatusFailed {
+		return
+	}
+	rayJob.Status.Attempts = append(rayJob.Status.Attempts, rayv1.RayJobAttempt{
+		StartTime:      rayJob.Status.StartTime,
+		EndTime:        &metav1.Time{Time: time.Now()},
+		JobId:          rayJob.Status.JobId,
+		RayClusterName: rayJob.Status.RayClusterName,
+		JobStatus:      rayJob.Status.JobStatus,
+		Reason:         rayJob.Status.Reason,
+		Message:        rayJob.Status.Message,
+	})
+}
+
+// uploadRayJobLogs uploads the driver logs of the Ray job to `spec.logStreaming.sinkURL` with a PUT request. A failed
+// upload is reported in an event but does not block the RayJob. The URL is not logged, as it may hold credentials.
+func (r *RayJobReconciler) uploadRayJobLogs(ctx context.Context, rayJob *rayv1.RayJob, logs string) {
+	logger := ctrl.LoggerFrom(ctx)
+	err := func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, rayJob.Spec.LogStreaming.SinkURL, strings.NewReader(logs))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+		resp, err := rayJobLogsUploadClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("the sink responded with status code %d", resp.StatusCode)
+		}
+		return nil
+	}()
+	if err != nil {
+		logger.Error(err, "Failed to upload the logs of the Ray job", "JobId", rayJob.Status.JobId)
+		r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, string(utils.FailedToUploadRayJobLogs),
+			"Failed to upload the logs of the Ray job %s: %v", rayJob.Status.JobId, err)
+		return
+	}
+	r.Recorder.Eventf(rayJob, corev1.EventTypeNormal, string(utils.UploadedRayJobLogs),
+		"Uploaded the logs of the Ray job %s", rayJob.Status.JobId)
+}
+
+// truncateToHead returns the first maxBytes bytes of s, without splitting a UTF-8 character.
+func truncateToHead(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	end := maxBytes
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
+
+// truncateToTail returns the last maxBytes bytes of s, without splitting a UTF-8 character.
+func truncateToTail(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	start := len(s) - maxBytes
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	return s[start:]
+}
+
+// getRayJobResult returns the compacted JSON result printed by the driver of the Ray job on the last line of its logs
+// starting with RayJobResultLogPrefix, or an empty string if there is none.
+func getRayJobResult(logs string) (string, error) {
+	index := strings.LastIndex(logs, "\n"+utils.RayJobResultLogPrefix)
+	if index == -1 {
+		if !strings.HasPrefix(logs, utils.RayJobResultLogPrefix) {
+			return "", nil
+		}
+	} else {
+		logs = logs[index+1:]
+	}
+	line, _, _ := strings.Cut(strings.TrimPrefix(logs, utils.RayJobResultLogPrefix), "\n")
+	result := &bytes.Buffer{}
+	if err := json.Compact(result, []byte(strings.TrimSpace(line))); err != nil {
+		return "", fmt.Errorf("the result is not a valid JSON document: %w", err)
+	}
+	if result.Len() > utils.RayJobResultMaxBytes {
+		return "", fmt.Errorf("the result is larger than %d bytes", utils.RayJobResultMaxBytes)
+	}
+	return result.String(), nil
+}
+
+// isRayJobAttemptSubmissionId returns whether the submission ID is the one of a finished attempt to run the Ray job.
+func isRayJobAttemptSubmissionId(rayJob *rayv1.RayJob, submissionId string) bool {
+	return slices.ContainsFunc(rayJob.Status.Attempts, func(attempt rayv1.RayJobAttempt) bool {
+		return attempt.JobId == submissionId
+	})
+}
+
 func checkBackoffLimitAndUpdateStatusIfNeeded(ctx context.Context, rayJob *rayv1.RayJob) {
 	logger := ctrl.LoggerFrom(ctx)
 
@@ -418,6 +761,11 @@ func (r *RayJobReconciler) createK8sJobIfNeed(ctx context.Context, rayJobInstanc
 			if err != nil {
 				return err
 			}
+			if !common.IsRayJobLogsFollowed(rayJobInstance) {
+				if err := r.createOrUpdateRuntimeEnvSecretIfNeed(ctx, rayJobInstance); err != nil {
+					return err
+				}
+			}
 			return r.createNewK8sJob(ctx, rayJobInstance, submitterTemplate)
 		}
 		return err
@@ -441,11 +789,15 @@ func (r *RayJobReconciler) getSubmitterTemplate(ctx context.Context, rayJobInsta
 		logger.Info("user-provided submitter template is used; the first container is assumed to be the submitter")
 	}
 
-	// If the command in the submitter pod template isn't set, use the default command.
+	// If the command in the submitter pod template isn't set, use the default command. In HTTPMode and InteractiveMode,
+	// the Kubernetes Job follows the driver logs of the Ray job instead of submitting it.
 	if len(submitterTemplate.Spec.Containers[utils.RayContainerIndex].Command) == 0 {
-		k8sJobCommand, err := common.GetK8sJobCommand(rayJobInstance)
-		if err != nil {
-			return corev1.PodTemplateSpec{}, err
+		k8sJobCommand := common.GetK8sJobLogsCommand(rayJobInstance)
+		if !common.IsRayJobLogsFollowed(rayJobInstance) {
+			var err error
+			if k8sJobCommand, err = common.GetK8sJobCommand(rayJobInstance); err != nil {
+				return corev1.PodTemplateSpec{}, err
+			}
 		}
 		submitterTemplate.Spec.Containers[utils.RayContainerIndex].Command = k8sJobCommand
 		logger.Info("No command is specified in the user-provided template. Default command is used", "command", k8sJobCommand)
@@ -453,6 +805,12 @@ func (r *RayJobReconciler) getSubmitterTemplate(ctx context.Context, rayJobInsta
 		logger.Info("User-provided command is used", "command", submitterTemplate.Spec.Containers[utils.RayContainerIndex].Command)
 	}
 
+	// The Pods of a Kubernetes Job can't be restarted always. Each failed submitter Pod is replaced by a new one until
+	// the backoff limit of the submitter Job is reached.
+	if submitterTemplate.Spec.RestartPolicy == "" {
+		submitterTemplate.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
 	// Set PYTHONUNBUFFERED=1 for real-time logging
 	submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env = append(submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env, corev1.EnvVar{
 		Name:  PythonUnbufferedEnvVarName,
@@ -471,9 +829,129 @@ func (r *RayJobReconciler) getSubmitterTemplate(ctx context.Context, rayJobInsta
 		Value: rayJobInstance.Status.JobId,
 	})
 
+	// The default command submits the runtime environment that references Secrets or ConfigMaps from the Secret of the
+	// RayJob, see createOrUpdateRuntimeEnvSecretIfNeed.
+	if common.HasRuntimeEnvFrom(rayJobInstance) && !common.IsRayJobLogsFollowed(rayJobInstance) {
+		submitterTemplate.Spec.Volumes = append(submitterTemplate.Spec.Volumes, corev1.Volume{
+			Name: utils.RayJobRuntimeEnvVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: common.RayJobRuntimeEnvSecretName(rayJobInstance)},
+			},
+		})
+		submitterTemplate.Spec.Containers[utils.RayContainerIndex].VolumeMounts = append(submitterTemplate.Spec.Containers[utils.RayContainerIndex].VolumeMounts, corev1.VolumeMount{
+			Name:      utils.RayJobRuntimeEnvVolumeName,
+			MountPath: utils.RayJobRuntimeEnvMountPath,
+			ReadOnly:  true,
+		})
+	}
+
 	return submitterTemplate, nil
 }
 
+// resolveRuntimeEnvFrom returns a copy of the RayJob whose runtimeEnv.envVars include the keys of the Secrets and
+// ConfigMaps referenced by runtimeEnv.envFrom, so that their current values are submitted with the Ray job. The RayJob
+// itself is returned if its runtime environment references none.
+func (r *RayJobReconciler) resolveRuntimeEnvFrom(ctx context.Context, rayJob *rayv1.RayJob) (*rayv1.RayJob, error) {
+	if !common.HasRuntimeEnvFrom(rayJob) {
+		return rayJob, nil
+	}
+	envVars := map[string]string{}
+	for _, source := range rayJob.Spec.RuntimeEnv.EnvFrom {
+		data, err := r.readEnvFromSource(ctx, rayJob.Namespace, source)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range data {
+			envVars[source.Prefix+key] = value
+		}
+	}
+	for key, value := range rayJob.Spec.RuntimeEnv.EnvVars {
+		envVars[key] = value
+	}
+	resolvedRayJob := rayJob.DeepCopy()
+	resolvedRayJob.Spec.RuntimeEnv.EnvVars = envVars
+	resolvedRayJob.Spec.RuntimeEnv.EnvFrom = nil
+	return resolvedRayJob, nil
+}
+
+// readEnvFromSource returns the keys and values of the Secret or ConfigMap of the source, or nil if it is optional and
+// does not exist.
+func (r *RayJobReconciler) readEnvFromSource(ctx context.Context, namespace string, source corev1.EnvFromSource) (map[string]string, error) {
+	if source.SecretRef != nil {
+		secret := &corev1.Secret{}
+		if err := r.apiReader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: source.SecretRef.Name}, secret); err != nil {
+			if errors.IsNotFound(err) && ptr.Deref(source.SecretRef.Optional, false) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to read the Secret %s of the runtime environment: %w", source.SecretRef.Name, err)
+		}
+		data := make(map[string]string, len(secret.Data))
+		for key, value := range secret.Data {
+			data[key] = string(value)
+		}
+		return data, nil
+	}
+	if source.ConfigMapRef != nil {
+		configMap := &corev1.ConfigMap{}
+		if err := r.apiReader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: source.ConfigMapRef.Name}, configMap); err != nil {
+			if errors.IsNotFound(err) && ptr.Deref(source.ConfigMapRef.Optional, false) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to read the ConfigMap %s of the runtime environment: %w", source.ConfigMapRef.Name, err)
+		}
+		return configMap.Data, nil
+	}
+	return nil, nil
+}
+
+// createOrUpdateRuntimeEnvSecretIfNeed stores the runtime environment of the Ray job in a Secret owned by the RayJob if
+// it references Secrets or ConfigMaps, so that the submitter Pod submits their values without the submitter Job
+// storing them. The Secret is updated before each submitter Job is created, e.g. for a retry.
+func (r *RayJobReconciler) createOrUpdateRuntimeEnvSecretIfNeed(ctx context.Context, rayJob *rayv1.RayJob) error {
+	if !common.HasRuntimeEnvFrom(rayJob) {
+		return nil
+	}
+	resolvedRayJob, err := r.resolveRuntimeEnvFrom(ctx, rayJob)
+	if err != nil {
+		return err
+	}
+	runtimeEnv, err := utils.GetRuntimeEnv(resolvedRayJob)
+	if err != nil {
+		return err
+	}
+	runtimeEnvJson, err := json.Marshal(runtimeEnv)
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.RayJobRuntimeEnvSecretName(rayJob),
+			Namespace: rayJob.Namespace,
+			Labels: map[string]string{
+				utils.RayOriginatedFromCRNameLabelKey: rayJob.Name,
+				utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayJobCRD),
+				utils.KubernetesCreatedByLabelKey:     utils.ComponentName,
+			},
+		},
+		// A JSON document is a YAML document too.
+		Data: map[string][]byte{utils.RayJobRuntimeEnvSecretKey: runtimeEnvJson},
+	}
+	if err := ctrl.SetControllerReference(rayJob, secret, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, secret); err == nil || !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	// The Secrets are not cached, so the existing Secret is read from the API server.
+	existingSecret := &corev1.Secret{}
+	if err := r.apiReader.Get(ctx, client.ObjectKeyFromObject(secret), existingSecret); err != nil {
+		return err
+	}
+	existingSecret.Data = secret.Data
+	return r.Update(ctx, existingSecret)
+}
+
 // createNewK8sJob creates a new Kubernetes Job. It returns an error.
 func (r *RayJobReconciler) createNewK8sJob(ctx context.Context, rayJobInstance *rayv1.RayJob, submitterTemplate corev1.PodTemplateSpec) error {
 	logger := ctrl.LoggerFrom(ctx)
@@ -518,7 +996,9 @@ func (r *RayJobReconciler) createNewK8sJob(ctx context.Context, rayJobInstance *
 // deleteSubmitterJob deletes the submitter Job associated with the RayJob.
 func (r *RayJobReconciler) deleteSubmitterJob(ctx context.Context, rayJobInstance *rayv1.RayJob) (bool, error) {
 	logger := ctrl.LoggerFrom(ctx)
-	if rayJobInstance.Spec.SubmissionMode == rayv1.HTTPMode {
+	if rayJobInstance.Spec.SubmissionMode == rayv1.SidecarMode ||
+		(rayJobInstance.Spec.SubmissionMode == rayv1.HTTPMode || rayJobInstance.Spec.SubmissionMode == rayv1.InteractiveMode) &&
+			!common.IsRayJobLogsFollowed(rayJobInstance) {
 		return true, nil
 	}
 	var isJobDeleted bool
@@ -554,6 +1034,11 @@ func (r *RayJobReconciler) deleteSubmitterJob(ctx context.Context, rayJobInstanc
 // deleteClusterResources deletes the RayCluster associated with the RayJob to release the compute resources.
 func (r *RayJobReconciler) deleteClusterResources(ctx context.Context, rayJobInstance *rayv1.RayJob) (bool, error) {
 	logger := ctrl.LoggerFrom(ctx)
+	// The RayClusters selected by ClusterSelector are not owned by the RayJob, e.g. when it is retried.
+	if len(rayJobInstance.Spec.ClusterSelector) != 0 {
+		logger.Info("The RayCluster selected by the clusterSelector is not deleted", "RayCluster", rayJobInstance.Status.RayClusterName)
+		return true, nil
+	}
 	clusterIdentifier := common.RayJobRayClusterNamespacedName(rayJobInstance)
 
 	var isClusterDeleted bool
@@ -583,8 +1068,69 @@ func (r *RayJobReconciler) deleteClusterResources(ctx context.Context, rayJobIns
 	return isClusterDeleted, nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *RayJobReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int) error {
+// deleteWorkers scales the worker groups of the RayCluster associated with the RayJob down to zero and deletes their
+// Pods to release the compute resources, while the head Pod is kept so that users can still access the dashboard and
+// the logs of the Ray job.
+func (r *RayJobReconciler) deleteWorkers(ctx context.Context, rayJobInstance *rayv1.RayJob) error {
+	logger := ctrl.LoggerFrom(ctx)
+	cluster := rayv1.RayCluster{}
+	if err := r.Get(ctx, common.RayJobRayClusterNamespacedName(rayJobInstance), &cluster); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("The associated cluster has been already deleted and it can not be found", "RayCluster", rayJobInstance.Status.RayClusterName)
+			return nil
+		}
+		return err
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	scaledDown := true
+	for i := range cluster.Spec.WorkerGroupSpecs {
+		worker := &cluster.Spec.WorkerGroupSpecs[i]
+		if ptr.Deref(worker.Replicas, 0) != 0 || ptr.Deref(worker.MinReplicas, 0) != 0 || ptr.Deref(worker.MaxReplicas, 0) != 0 || len(worker.ScheduledScaling) != 0 {
+			scaledDown = false
+		}
+		// The autoscaler and the scheduled scaling windows cannot scale the worker group up again.
+		worker.Replicas = ptr.To[int32](0)
+		worker.MinReplicas = ptr.To[int32](0)
+		worker.MaxReplicas = ptr.To[int32](0)
+		worker.ScheduledScaling = nil
+	}
+	if scaledDown {
+		return nil
+	}
+	if err := r.Update(ctx, &cluster); err != nil {
+		return err
+	}
+	// The RayCluster controller does not delete the worker Pods of its own choosing if autoscaling is enabled.
+	if err := r.DeleteAllOf(ctx, &corev1.Pod{}, common.RayClusterWorkerPodsAssociationOptions(&cluster).ToDeleteOptions()...); err != nil {
+		return err
+	}
+	logger.Info("The workers of the associated cluster are deleted", "RayCluster", cluster.Name)
+	r.Recorder.Eventf(rayJobInstance, corev1.EventTypeNormal, "Deleted", "Deleted the workers of cluster %s", cluster.Name)
+	return nil
+}
+
+// getDeletionPolicy returns the resources of the RayJob to delete once its Ray job finishes. If `DeletionPolicy` is not
+// set, the RayCluster is deleted if `ShutdownAfterJobFinishes` is true and it is not selected by `ClusterSelector`, or
+// the RayJob is deleted if the DELETE_RAYJOB_CR_AFTER_JOB_FINISHES environment variable is true, unless it has a
+// schedule.
+func getDeletionPolicy(rayJob *rayv1.RayJob) rayv1.DeletionPolicy {
+	if rayJob.Spec.DeletionPolicy != nil && features.Enabled(features.RayJobDeletionPolicy) {
+		return *rayJob.Spec.DeletionPolicy
+	}
+	if !rayJob.Spec.ShutdownAfterJobFinishes || len(rayJob.Spec.ClusterSelector) != 0 {
+		return rayv1.DeleteNone
+	}
+	if strings.ToLower(os.Getenv(utils.DELETE_RAYJOB_CR_AFTER_JOB_FINISHES)) == "true" && rayJob.Spec.Schedule == "" {
+		return rayv1.DeleteSelf
+	}
+	return rayv1.DeleteCluster
+}
+
+// SetupWithManager sets up the controller with the Manager. A nil rateLimiter uses the default one of controller-runtime.
+func (r *RayJobReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int, rateLimiter ratelimiter.RateLimiter) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&rayv1.RayJob{}).
 		Owns(&rayv1.RayCluster{}).
@@ -592,15 +1138,10 @@ func (r *RayJobReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurren
 		Owns(&batchv1.Job{}).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: reconcileConcurrency,
-			LogConstructor: func(request *reconcile.Request) logr.Logger {
-				logger := ctrl.Log.WithName("controllers").WithName("RayJob")
-				if request != nil {
-					logger = logger.WithValues("RayJob", request.NamespacedName)
-				}
-				return logger
-			},
+			RateLimiter:             rateLimiter,
+			LogConstructor:          utils.LogConstructor(utils.RayJobCRD),
 		}).
-		Complete(r)
+		Complete(utils.WithoutShutdownCancel(r))
 }
 
 // This function is the sole place where `JobDeploymentStatusInitializing` is defined. It initializes `Status.JobId` and `Status.RayClusterName`
@@ -615,24 +1156,29 @@ func (r *RayJobReconciler) initRayJobStatusIfNeed(ctx context.Context, rayJob *r
 		return nil
 	}
 
-	if rayJob.Status.JobId == "" {
+	// The attempts of the Ray job that failed and are retried have been recorded. A suspended RayJob is not a new attempt.
+	rayJob.Status.Attempt = int32(len(rayJob.Status.Attempts)) + 1
+
+	// In InteractiveMode, the submission ID is set by users once they submit the Ray job.
+	if rayJob.Status.JobId == "" && rayJob.Spec.SubmissionMode != rayv1.InteractiveMode {
 		if rayJob.Spec.JobId != "" {
 			rayJob.Status.JobId = rayJob.Spec.JobId
+			// The jobId of a failed attempt is taken if the retry runs on the same RayCluster.
+			if rayJob.Status.Attempt > 1 {
+				rayJob.Status.JobId = fmt.Sprintf("%s-%d", rayJob.Spec.JobId, rayJob.Status.Attempt)
+			}
 		} else {
 			rayJob.Status.JobId = utils.GenerateRayJobId(rayJob.Name)
 		}
 	}
 
 	if rayJob.Status.RayClusterName == "" {
-		// if the clusterSelector is not empty, default use this cluster name
-		// we assume the length of clusterSelector is one
 		if len(rayJob.Spec.ClusterSelector) != 0 {
-			var useValue string
-			var ok bool
-			if useValue, ok = rayJob.Spec.ClusterSelector[RayJobDefaultClusterSelectorKey]; !ok {
-				return fmt.Errorf("failed to get cluster name in ClusterSelector map, the default key is %v", RayJobDefaultClusterSelectorKey)
+			rayClusterName, err := r.selectRayClusterName(ctx, rayJob)
+			if err != nil {
+				return err
 			}
-			rayJob.Status.RayClusterName = useValue
+			rayJob.Status.RayClusterName = rayClusterName
 		} else {
 			rayJob.Status.RayClusterName = utils.GenerateRayClusterName(rayJob.Name)
 		}
@@ -646,6 +1192,29 @@ func (r *RayJobReconciler) initRayJobStatusIfNeed(ctx context.Context, rayJob *r
 	return nil
 }
 
+// selectRayClusterName returns the name of the existing RayCluster that the RayJob with a ClusterSelector runs on. The
+// RayCluster is selected by its name with the `ray.io/cluster` key, or else by its labels, in which case the first ready
+// RayCluster by name is selected.
+func (r *RayJobReconciler) selectRayClusterName(ctx context.Context, rayJob *rayv1.RayJob) (string, error) {
+	if rayClusterName, ok := rayJob.Spec.ClusterSelector[RayJobDefaultClusterSelectorKey]; ok {
+		return rayClusterName, nil
+	}
+
+	rayClusters := rayv1.RayClusterList{}
+	if err := r.List(ctx, &rayClusters, client.InNamespace(rayJob.Namespace), client.MatchingLabels(rayJob.Spec.ClusterSelector)); err != nil {
+		return "", err
+	}
+	slices.SortFunc(rayClusters.Items, func(a, b rayv1.RayCluster) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, rayCluster := range rayClusters.Items {
+		if rayCluster.DeletionTimestamp.IsZero() && rayCluster.Status.State == rayv1.Ready { //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+			return rayCluster.Name, nil
+		}
+	}
+	return "", fmt.Errorf("none of the %d RayClusters matching the clusterSelector %v is ready", len(rayClusters.Items), rayJob.Spec.ClusterSelector)
+}
+
 func (r *RayJobReconciler) updateRayJobStatus(ctx context.Context, oldRayJob *rayv1.RayJob, newRayJob *rayv1.RayJob) error {
 	logger := ctrl.LoggerFrom(ctx)
 	oldRayJobStatus := oldRayJob.Status
@@ -653,16 +1222,19 @@ func (r *RayJobReconciler) updateRayJobStatus(ctx context.Context, oldRayJob *ra
 	logger.Info("updateRayJobStatus", "oldRayJobStatus", oldRayJobStatus, "newRayJobStatus", newRayJobStatus)
 	// If a status field is crucial for the RayJob state machine, it MUST be
 	// updated with a distinct JobStatus or JobDeploymentStatus value.
+	// The statuses of the steps change while the Ray job of the RayJob is running, so they are compared too.
 	if oldRayJobStatus.JobStatus != newRayJobStatus.JobStatus ||
-		oldRayJobStatus.JobDeploymentStatus != newRayJobStatus.JobDeploymentStatus {
+		oldRayJobStatus.JobDeploymentStatus != newRayJobStatus.JobDeploymentStatus ||
+		!equality.Semantic.DeepEqual(oldRayJobStatus.Steps, newRayJobStatus.Steps) {
 
 		if newRayJobStatus.JobDeploymentStatus == rayv1.JobDeploymentStatusComplete || newRayJobStatus.JobDeploymentStatus == rayv1.JobDeploymentStatusFailed {
 			newRayJob.Status.EndTime = &metav1.Time{Time: time.Now()}
 		}
+		setRayJobStageConditions(newRayJob)
 
 		logger.Info("updateRayJobStatus", "old JobStatus", oldRayJobStatus.JobStatus, "new JobStatus", newRayJobStatus.JobStatus,
 			"old JobDeploymentStatus", oldRayJobStatus.JobDeploymentStatus, "new JobDeploymentStatus", newRayJobStatus.JobDeploymentStatus)
-		if err := r.Status().Update(ctx, newRayJob); err != nil {
+		if err := utils.PatchStatus(ctx, r.Client, newRayJob); err != nil {
 			return err
 		}
 	}
@@ -688,6 +1260,12 @@ func (r *RayJobReconciler) getOrCreateRayClusterInstance(ctx context.Context, ra
 			if err != nil {
 				return nil, err
 			}
+			// The submitter container in the head Pod mounts the Secret of the runtime environment in SidecarMode.
+			if rayJobInstance.Spec.SubmissionMode == rayv1.SidecarMode {
+				if err := r.createOrUpdateRuntimeEnvSecretIfNeed(ctx, rayJobInstance); err != nil {
+					return nil, err
+				}
+			}
 			if err := r.Create(ctx, rayClusterInstance); err != nil {
 				return nil, err
 			}
@@ -708,22 +1286,55 @@ func (r *RayJobReconciler) getOrCreateRayClusterInstance(ctx context.Context, ra
 }
 
 func (r *RayJobReconciler) constructRayClusterForRayJob(rayJobInstance *rayv1.RayJob, rayClusterName string) (*rayv1.RayCluster, error) {
-	labels := make(map[string]string, len(rayJobInstance.Labels))
+	// The labels and annotations of the RayJob are merged with the ones of rayClusterMetadata, e.g. for cost attribution.
+	// The labels associating the RayCluster with the RayJob are set last, so that they cannot be overridden.
+	labels := make(map[string]string, len(rayJobInstance.Labels)+3)
+	annotations := make(map[string]string, len(rayJobInstance.Annotations))
 	for key, value := range rayJobInstance.Labels {
 		labels[key] = value
 	}
+	for key, value := range rayJobInstance.Annotations {
+		annotations[key] = value
+	}
+	if rayClusterMetadata := rayJobInstance.Spec.RayClusterMetadata; rayClusterMetadata != nil {
+		for key, value := range rayClusterMetadata.Labels {
+			labels[key] = value
+		}
+		for key, value := range rayClusterMetadata.Annotations {
+			annotations[key] = value
+		}
+	}
 	labels[utils.RayOriginatedFromCRNameLabelKey] = rayJobInstance.Name
 	labels[utils.RayOriginatedFromCRDLabelKey] = utils.RayOriginatedFromCRDLabelValue(utils.RayJobCRD)
+	labels[utils.KubernetesCreatedByLabelKey] = utils.ComponentName
 	rayCluster := &rayv1.RayCluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Labels:      labels,
-			Annotations: rayJobInstance.Annotations,
+			Annotations: annotations,
 			Name:        rayClusterName,
 			Namespace:   rayJobInstance.Namespace,
 		},
 		Spec: *rayJobInstance.Spec.RayClusterSpec.DeepCopy(),
 	}
 
+	// In SidecarMode, the Ray job is submitted by a container in the head Pod.
+	if rayJobInstance.Spec.SubmissionMode == rayv1.SidecarMode {
+		submitterContainer, err := common.GetSidecarSubmitterContainer(rayJobInstance)
+		if err != nil {
+			return nil, err
+		}
+		headPodSpec := &rayCluster.Spec.HeadGroupSpec.Template.Spec
+		headPodSpec.Containers = append(headPodSpec.Containers, submitterContainer)
+		if common.HasRuntimeEnvFrom(rayJobInstance) {
+			headPodSpec.Volumes = append(headPodSpec.Volumes, corev1.Volume{
+				Name: utils.RayJobRuntimeEnvVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: common.RayJobRuntimeEnvSecretName(rayJobInstance)},
+				},
+			})
+		}
+	}
+
 	// Set the ownership in order to do the garbage collection by k8s.
 	if err := ctrl.SetControllerReference(rayJobInstance, rayCluster, r.Scheme); err != nil {
 		return nil, err
@@ -737,10 +1348,11 @@ func (r *RayJobReconciler) updateStatusToSuspendingIfNeeded(ctx context.Context,
 	if !rayJob.Spec.Suspend {
 		return false
 	}
-	// In KubeRay, only `Running` and `Initializing` are allowed to transition to `Suspending`.
+	// In KubeRay, only `Running`, `Initializing` and `Waiting` are allowed to transition to `Suspending`.
 	validTransitions := map[rayv1.JobDeploymentStatus]struct{}{
 		rayv1.JobDeploymentStatusRunning:      {},
 		rayv1.JobDeploymentStatusInitializing: {},
+		rayv1.JobDeploymentStatusWaiting:      {},
 	}
 	if _, ok := validTransitions[rayJob.Status.JobDeploymentStatus]; !ok {
 		logger.Info("The current status is not allowed to transition to `Suspending`", "RayJob", rayJob.Name, "JobDeploymentStatus", rayJob.Status.JobDeploymentStatus)
@@ -751,6 +1363,67 @@ func (r *RayJobReconciler) updateStatusToSuspendingIfNeeded(ctx context.Context,
 	return true
 }
 
+// setRayJobSuspendedCondition sets the Suspended condition, which is persisted along with the transition of
+// `Status.JobDeploymentStatus` to or from `Suspended`.
+func setRayJobSuspendedCondition(rayJob *rayv1.RayJob, status metav1.ConditionStatus, reason, message string) {
+	setRayJobCondition(rayJob, rayv1.RayJobSuspended, status, reason, message)
+}
+
+func setRayJobCondition(rayJob *rayv1.RayJob, conditionType rayv1.RayJobConditionType, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&rayJob.Status.Conditions, metav1.Condition{
+		Type:               string(conditionType),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: rayJob.Generation,
+	})
+}
+
+// setRayJobStageConditions sets the conditions of the stages of the current attempt to run the Ray job from
+// `Status.JobDeploymentStatus` and `Status.JobStatus`, so that pipelines can wait for a stage with e.g.
+// `kubectl wait --for=condition=JobSubmitted`. The conditions of the stages are reset when a new attempt starts, and
+// are kept while the RayJob is `Suspending` or `Retrying`.
+func setRayJobStageConditions(rayJob *rayv1.RayJob) {
+	deploymentStatus := rayJob.Status.JobDeploymentStatus
+	reason := string(deploymentStatus)
+	switch deploymentStatus {
+	case rayv1.JobDeploymentStatusNew, rayv1.JobDeploymentStatusScheduled, rayv1.JobDeploymentStatusSuspended:
+		for _, conditionType := range []rayv1.RayJobConditionType{
+			rayv1.RayJobInitialized, rayv1.RayJobClusterProvisioned, rayv1.RayJobSubmitted,
+			rayv1.RayJobRunning, rayv1.RayJobComplete, rayv1.RayJobFailed,
+		} {
+			setRayJobCondition(rayJob, conditionType, metav1.ConditionFalse, reason, "")
+		}
+		return
+	case rayv1.JobDeploymentStatusInitializing:
+		setRayJobCondition(rayJob, rayv1.RayJobInitialized, metav1.ConditionTrue, reason, "The RayCluster is being created or selected")
+	case rayv1.JobDeploymentStatusWaiting, rayv1.JobDeploymentStatusRunning:
+		setRayJobCondition(rayJob, rayv1.RayJobInitialized, metav1.ConditionTrue, reason, "The RayCluster is being created or selected")
+		setRayJobCondition(rayJob, rayv1.RayJobClusterProvisioned, metav1.ConditionTrue, reason, "The RayCluster is ready")
+	case rayv1.JobDeploymentStatusComplete:
+		setRayJobCondition(rayJob, rayv1.RayJobComplete, metav1.ConditionTrue, reason, rayJob.Status.Message)
+	case rayv1.JobDeploymentStatusFailed:
+		if rayJob.Status.Reason != "" {
+			reason = string(rayJob.Status.Reason)
+		}
+		setRayJobCondition(rayJob, rayv1.RayJobFailed, metav1.ConditionTrue, reason, rayJob.Status.Message)
+	}
+
+	// The dashboard knows the Ray job once it was submitted, whatever its status is.
+	jobStatus := rayJob.Status.JobStatus
+	if jobStatus != rayv1.JobStatusNew {
+		setRayJobCondition(rayJob, rayv1.RayJobSubmitted, metav1.ConditionTrue, string(jobStatus), "The Ray job was submitted to the RayCluster")
+	}
+	if jobStatus == rayv1.JobStatusRunning {
+		setRayJobCondition(rayJob, rayv1.RayJobRunning, metav1.ConditionTrue, string(jobStatus), "The Ray job is running")
+	} else if meta.IsStatusConditionTrue(rayJob.Status.Conditions, string(rayv1.RayJobRunning)) {
+		if jobStatus != rayv1.JobStatusNew {
+			reason = string(jobStatus)
+		}
+		setRayJobCondition(rayJob, rayv1.RayJobRunning, metav1.ConditionFalse, reason, "The Ray job is no longer running")
+	}
+}
+
 func (r *RayJobReconciler) checkK8sJobAndUpdateStatusIfNeeded(ctx context.Context, rayJob *rayv1.RayJob, job *batchv1.Job) bool {
 	logger := ctrl.LoggerFrom(ctx)
 	for _, cond := range job.Status.Conditions {
@@ -785,12 +1458,278 @@ func (r *RayJobReconciler) checkActiveDeadlineAndUpdateStatusIfNeeded(ctx contex
 	return true
 }
 
+func (r *RayJobReconciler) checkClusterReadyTimeoutAndUpdateStatusIfNeeded(ctx context.Context, rayJob *rayv1.RayJob, rayCluster *rayv1.RayCluster) bool {
+	logger := ctrl.LoggerFrom(ctx)
+	if rayJob.Spec.ClusterReadyTimeoutSeconds == nil || time.Now().Before(rayJob.Status.StartTime.Add(time.Duration(*rayJob.Spec.ClusterReadyTimeoutSeconds)*time.Second)) {
+		return false
+	}
+
+	logger.Info("The RayCluster is not ready within the clusterReadyTimeoutSeconds. Transition the status to `Failed`.",
+		"RayCluster", rayCluster.Name, "State", rayCluster.Status.State, "StartTime", rayJob.Status.StartTime, "ClusterReadyTimeoutSeconds", *rayJob.Spec.ClusterReadyTimeoutSeconds) //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusFailed
+	rayJob.Status.Reason = rayv1.ClusterReadyTimeoutExceeded
+	rayJob.Status.Message = fmt.Sprintf("The RayCluster %s was not ready within the clusterReadyTimeoutSeconds. StartTime: %v. ClusterReadyTimeoutSeconds: %d. State: %q. Reason: %q",
+		rayCluster.Name, rayJob.Status.StartTime, *rayJob.Spec.ClusterReadyTimeoutSeconds, rayCluster.Status.State, rayCluster.Status.Reason) //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+	return true
+}
+
+// checkSidecarSubmitterAndUpdateStatusIfNeeded fails the RayJob if the submitter container in the head Pod exited with an
+// error in SidecarMode, as it only does so if the Ray job cannot be submitted.
+func (r *RayJobReconciler) checkSidecarSubmitterAndUpdateStatusIfNeeded(ctx context.Context, rayJob *rayv1.RayJob, rayCluster *rayv1.RayCluster) bool {
+	logger := ctrl.LoggerFrom(ctx)
+	headPod, err := common.GetRayClusterHeadPod(ctx, r.Client, rayCluster)
+	if err != nil || headPod == nil {
+		logger.Info("Failed to get the head Pod to check the submitter container", "RayCluster", rayCluster.Name, "error", err)
+		return false
+	}
+	for _, containerStatus := range headPod.Status.ContainerStatuses {
+		if containerStatus.Name != utils.SubmitterContainerName {
+			continue
+		}
+		for _, terminated := range []*corev1.ContainerStateTerminated{containerStatus.State.Terminated, containerStatus.LastTerminationState.Terminated} {
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			logger.Info("The submitter container in the head Pod failed. Attempting to transition the status to `Failed`.",
+				"Pod", headPod.Name, "ExitCode", terminated.ExitCode, "Reason", terminated.Reason)
+			rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusFailed
+			rayJob.Status.Reason = rayv1.SubmissionFailed
+			rayJob.Status.Message = fmt.Sprintf("Job submission has failed. The submitter container in the head Pod %s exited with code %d. Message: %s",
+				headPod.Name, terminated.ExitCode, terminated.Message)
+			return true
+		}
+	}
+	return false
+}
+
+// stopRayJob stops the Ray job of the RayJob via the dashboard of its RayCluster. Ray jobs that already finished or were
+// never submitted are not stopped.
+func (r *RayJobReconciler) stopRayJob(ctx context.Context, rayJob *rayv1.RayJob) error {
+	rayClusterInstance := &rayv1.RayCluster{}
+	if err := r.Get(ctx, common.RayJobRayClusterNamespacedName(rayJob), rayClusterInstance); err != nil {
+		return err
+	}
+
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, rayJob.Status.DashboardURL, rayClusterInstance); err != nil {
+		return err
+	}
+	// If the Ray job was not found, e.g. because it has not been submitted yet in HTTPMode, GetJobInfo returns a
+	// BadRequest error.
+	for _, jobId := range getRayJobIds(rayJob) {
+		if err := rayDashboardClient.StopJob(ctx, jobId); err != nil && !dashboard.IsNotFound(err) && !errors.IsBadRequest(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// stopRayJobGracefully stops the Ray job of the deleted RayJob and returns whether it is no longer running. It gives up
+// once RayJobGracefulStopTimeout passed since the RayJob was deleted, e.g. if the dashboard is unreachable.
+func (r *RayJobReconciler) stopRayJobGracefully(ctx context.Context, rayJob *rayv1.RayJob) bool {
+	logger := ctrl.LoggerFrom(ctx)
+	if time.Since(rayJob.DeletionTimestamp.Time) >= RayJobGracefulStopTimeout {
+		logger.Info("The Ray job did not stop within the timeout. Stop waiting for it.", "JobId", rayJob.Status.JobId, "timeout", RayJobGracefulStopTimeout)
+		return true
+	}
+
+	rayClusterInstance := &rayv1.RayCluster{}
+	if err := r.Get(ctx, common.RayJobRayClusterNamespacedName(rayJob), rayClusterInstance); err != nil {
+		if errors.IsNotFound(err) {
+			return true
+		}
+		logger.Error(err, "Failed to get the RayCluster to stop the Ray job")
+		return false
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, rayJob.Status.DashboardURL, rayClusterInstance); err != nil {
+		logger.Error(err, "Failed to initialize the dashboard client to stop the Ray job")
+		return false
+	}
+	isStopped := true
+	for _, jobId := range getRayJobIds(rayJob) {
+		// If the Ray job was not found, e.g. because it has not been submitted yet in HTTPMode, GetJobInfo returns a
+		// BadRequest error.
+		jobInfo, err := rayDashboardClient.GetJobInfo(ctx, jobId)
+		if err != nil {
+			if dashboard.IsNotFound(err) || errors.IsBadRequest(err) {
+				continue
+			}
+			logger.Error(err, "Failed to get the Ray job to stop it", "JobId", jobId)
+			isStopped = false
+			continue
+		}
+		if rayv1.IsJobTerminal(jobInfo.JobStatus) {
+			logger.Info("The Ray job is stopped.", "JobId", jobId, "JobStatus", jobInfo.JobStatus)
+			continue
+		}
+		// Stopping a Ray job that is already stopping is a no-op, so the request is sent again until the Ray job stopped.
+		if err := rayDashboardClient.StopJob(ctx, jobId); err != nil && !dashboard.IsNotFound(err) && !errors.IsBadRequest(err) {
+			logger.Error(err, "Failed to stop the Ray job", "JobId", jobId)
+		}
+		isStopped = false
+	}
+	return isStopped
+}
+
+// getRayJobIds returns the jobIds of the Ray jobs of the RayJob, i.e. its jobId, or the jobIds of its steps that were
+// submitted and did not finish.
+func getRayJobIds(rayJob *rayv1.RayJob) []string {
+	if len(rayJob.Spec.Steps) == 0 {
+		return []string{rayJob.Status.JobId}
+	}
+	var jobIds []string
+	for _, step := range rayJob.Status.Steps {
+		if step.StartTime != nil && !rayv1.IsJobTerminal(step.JobStatus) {
+			jobIds = append(jobIds, step.JobId)
+		}
+	}
+	return jobIds
+}
+
+// reconcileRayJobSteps submits the steps of the RayJob whose dependencies succeeded, updates the statuses of the steps
+// and returns the status of the Ray job of the RayJob aggregated from them. The steps that depend on a step that did not
+// succeed are skipped with the STOPPED status. The Ray job succeeds once all steps succeeded, and fails once a step
+// did not succeed and no other step is running.
+func (r *RayJobReconciler) reconcileRayJobSteps(ctx context.Context, rayJob *rayv1.RayJob, rayDashboardClient utils.RayDashboardClientInterface) (*utils.RayJobInfo, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	if len(rayJob.Status.Steps) != len(rayJob.Spec.Steps) {
+		rayJob.Status.Steps = make([]rayv1.RayJobStepStatus, len(rayJob.Spec.Steps))
+		for i, step := range rayJob.Spec.Steps {
+			rayJob.Status.Steps[i] = rayv1.RayJobStepStatus{Name: step.Name, JobId: rayJob.Status.JobId + "-" + step.Name}
+		}
+	}
+	stepStatuses := make(map[string]*rayv1.RayJobStepStatus, len(rayJob.Status.Steps))
+	for i := range rayJob.Status.Steps {
+		stepStatuses[rayJob.Status.Steps[i].Name] = &rayJob.Status.Steps[i]
+	}
+	isSequential := !slices.ContainsFunc(rayJob.Spec.Steps, func(step rayv1.RayJobStep) bool { return len(step.DependsOn) != 0 })
+
+	// The steps only depend on previous steps, so the statuses of their dependencies are up to date.
+	for i, step := range rayJob.Spec.Steps {
+		stepStatus := &rayJob.Status.Steps[i]
+		if rayv1.IsJobTerminal(stepStatus.JobStatus) {
+			continue
+		}
+		dependencies := step.DependsOn
+		if isSequential && i > 0 {
+			dependencies = []string{rayJob.Spec.Steps[i-1].Name}
+		}
+		isReady := true
+		for _, dependency := range dependencies {
+			dependencyStatus := stepStatuses[dependency]
+			if dependencyStatus.JobStatus == rayv1.JobStatusSucceeded {
+				continue
+			}
+			isReady = false
+			if rayv1.IsJobTerminal(dependencyStatus.JobStatus) {
+				stepStatus.JobStatus = rayv1.JobStatusStopped
+				stepStatus.Message = fmt.Sprintf("The step was skipped because the step %s did not succeed", dependency)
+				break
+			}
+		}
+		if !isReady {
+			continue
+		}
+
+		// If the Ray job of the step was not found, GetJobInfo returns a BadRequest error.
+		jobInfo, err := rayDashboardClient.GetJobInfo(ctx, stepStatus.JobId)
+		if err != nil {
+			if !errors.IsBadRequest(err) {
+				return nil, err
+			}
+			logger.Info("The Ray job of the step was not found. Submit it via an HTTP request.", "Step", step.Name, "JobId", stepStatus.JobId)
+			submittedRayJob, err := r.resolveRuntimeEnvFrom(ctx, rayJob)
+			if err != nil {
+				return nil, err
+			}
+			submittedRayJob = submittedRayJob.DeepCopy()
+			submittedRayJob.Spec.Entrypoint = step.Entrypoint
+			submittedRayJob.Status.JobId = stepStatus.JobId
+			if _, err := rayDashboardClient.SubmitJob(ctx, submittedRayJob); err != nil {
+				if !isRayJobSubmissionRejected(err) {
+					return nil, err
+				}
+				// Submitting the same request again would be rejected again, so the step fails.
+				r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, string(utils.FailedToSubmitRayJob),
+					"The Ray job %s of the step %s was rejected by the dashboard: %v", stepStatus.JobId, step.Name, err)
+				stepStatus.JobStatus = rayv1.JobStatusFailed
+				stepStatus.Message = truncateToHead(fmt.Sprintf("Job submission has failed. Message: %v", err), utils.RayJobStatusMessageMaxBytes)
+				stepStatus.StartTime = &metav1.Time{Time: time.Now()}
+				stepStatus.EndTime = stepStatus.StartTime
+				continue
+			}
+			r.Recorder.Eventf(rayJob, corev1.EventTypeNormal, string(utils.SubmittedRayJob),
+				"Submitted the Ray job %s of the step %s to the RayCluster %s", stepStatus.JobId, step.Name, rayJob.Status.RayClusterName)
+			stepStatus.StartTime = &metav1.Time{Time: time.Now()}
+			stepStatus.JobStatus = rayv1.JobStatusPending
+			continue
+		}
+		if stepStatus.StartTime == nil {
+			stepStatus.StartTime = &metav1.Time{Time: time.Now()}
+		}
+		stepStatus.JobStatus = jobInfo.JobStatus
+		stepStatus.Message = truncateToHead(jobInfo.Message, utils.RayJobStatusMessageMaxBytes)
+		if rayv1.IsJobTerminal(jobInfo.JobStatus) {
+			stepStatus.EndTime = &metav1.Time{Time: time.Now()}
+		}
+	}
+
+	jobInfo := &utils.RayJobInfo{JobStatus: rayv1.JobStatusSucceeded}
+	isSubmitted := false
+	for _, stepStatus := range rayJob.Status.Steps {
+		isSubmitted = isSubmitted || stepStatus.StartTime != nil
+		if !rayv1.IsJobTerminal(stepStatus.JobStatus) {
+			jobInfo.JobStatus = rayv1.JobStatusRunning
+			continue
+		}
+		// The skipped steps were not submitted, so the failure is reported with the first step that was.
+		if jobInfo.JobStatus == rayv1.JobStatusSucceeded && stepStatus.JobStatus != rayv1.JobStatusSucceeded && stepStatus.StartTime != nil {
+			jobInfo.JobStatus = rayv1.JobStatusFailed
+			jobInfo.SubmissionId = stepStatus.JobId
+			jobInfo.Message = fmt.Sprintf("The step %s did not succeed. Message: %s", stepStatus.Name, stepStatus.Message)
+		}
+	}
+	switch jobInfo.JobStatus {
+	case rayv1.JobStatusRunning:
+		jobInfo.SubmissionId = ""
+		jobInfo.Message = ""
+		if !isSubmitted {
+			jobInfo.JobStatus = rayv1.JobStatusNew
+		}
+	case rayv1.JobStatusSucceeded:
+		jobInfo.SubmissionId = rayJob.Status.Steps[len(rayJob.Status.Steps)-1].JobId
+	}
+	return jobInfo, nil
+}
+
+// isRayJobSubmissionRejected returns whether the dashboard rejected the submission of a Ray job as invalid, e.g. because
+// of an invalid runtime environment. The submissions of Ray jobs that already exist are rejected too, but they are not
+// invalid.
+func isRayJobSubmissionRejected(err error) bool {
+	var apiErr *dashboard.APIError
+	return errstd.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest && !strings.Contains(apiErr.Body, "already exists")
+}
+
 func validateRayJobSpec(rayJob *rayv1.RayJob) error {
 	// KubeRay has some limitations for the suspend operation. The limitations are a subset of the limitations of
 	// Kueue (https://kueue.sigs.k8s.io/docs/tasks/run_rayjobs/#c-limitations). For example, KubeRay allows users
 	// to suspend a RayJob with autoscaling enabled, but Kueue doesn't.
-	if rayJob.Spec.Suspend && !rayJob.Spec.ShutdownAfterJobFinishes {
-		return fmt.Errorf("a RayJob with shutdownAfterJobFinishes set to false is not allowed to be suspended")
+	if rayJob.Spec.DeletionPolicy != nil {
+		if !features.Enabled(features.RayJobDeletionPolicy) {
+			return fmt.Errorf("deletionPolicy requires the RayJobDeletionPolicy feature gate to be enabled")
+		}
+		if rayJob.Spec.ShutdownAfterJobFinishes {
+			return fmt.Errorf("shutdownAfterJobFinishes and deletionPolicy cannot be set at the same time")
+		}
+		if len(rayJob.Spec.ClusterSelector) != 0 && (*rayJob.Spec.DeletionPolicy == rayv1.DeleteCluster || *rayJob.Spec.DeletionPolicy == rayv1.DeleteWorkers) {
+			return fmt.Errorf("the ClusterSelector mode doesn't support the %s deletionPolicy", *rayJob.Spec.DeletionPolicy)
+		}
+	}
+	deletesRayCluster := rayJob.Spec.ShutdownAfterJobFinishes ||
+		(rayJob.Spec.DeletionPolicy != nil && (*rayJob.Spec.DeletionPolicy == rayv1.DeleteCluster || *rayJob.Spec.DeletionPolicy == rayv1.DeleteSelf))
+	if rayJob.Spec.Suspend && !deletesRayCluster {
+		return fmt.Errorf("a RayJob with shutdownAfterJobFinishes set to false is not allowed to be suspended, unless its deletionPolicy deletes the RayCluster")
 	}
 	if rayJob.Spec.Suspend && len(rayJob.Spec.ClusterSelector) != 0 {
 		return fmt.Errorf("the ClusterSelector mode doesn't support the suspend operation")
@@ -798,16 +1737,147 @@ func validateRayJobSpec(rayJob *rayv1.RayJob) error {
 	if rayJob.Spec.RayClusterSpec == nil && len(rayJob.Spec.ClusterSelector) == 0 {
 		return fmt.Errorf("one of RayClusterSpec or ClusterSelector must be set")
 	}
+	if rayJob.Spec.RuntimeEnv != nil && rayJob.Spec.RuntimeEnvYAML != "" {
+		return fmt.Errorf("runtimeEnv and runtimeEnvYAML cannot be set at the same time")
+	}
+	if rayJob.Spec.RuntimeEnv != nil {
+		for _, source := range rayJob.Spec.RuntimeEnv.EnvFrom {
+			if (source.SecretRef == nil) == (source.ConfigMapRef == nil) {
+				return fmt.Errorf("each source of runtimeEnv.envFrom must reference either a Secret or a ConfigMap")
+			}
+		}
+	}
 	// Validate whether RuntimeEnvYAML is a valid YAML string. Note that this only checks its validity
 	// as a YAML string, not its adherence to the runtime environment schema.
 	if _, err := utils.UnmarshalRuntimeEnvYAML(rayJob.Spec.RuntimeEnvYAML); err != nil {
 		return err
 	}
+	if rayJob.Spec.EntrypointResources != "" {
+		var resources map[string]float32
+		if err := json.Unmarshal([]byte(rayJob.Spec.EntrypointResources), &resources); err != nil {
+			return fmt.Errorf("entrypointResources must be a JSON object mapping resource names to quantities: %w", err)
+		}
+	}
+	// The Ray job submission CLI and API reject negative quantities, which would fail every submission of the Ray job.
+	if rayJob.Spec.EntrypointNumCpus < 0 || rayJob.Spec.EntrypointNumGpus < 0 {
+		return fmt.Errorf("entrypointNumCpus and entrypointNumGpus must be non-negative")
+	}
 	if rayJob.Spec.ActiveDeadlineSeconds != nil && *rayJob.Spec.ActiveDeadlineSeconds <= 0 {
 		return fmt.Errorf("activeDeadlineSeconds must be a positive integer")
 	}
+	if rayJob.Spec.ClusterReadyTimeoutSeconds != nil && *rayJob.Spec.ClusterReadyTimeoutSeconds <= 0 {
+		return fmt.Errorf("clusterReadyTimeoutSeconds must be a positive integer")
+	}
+	if rayJob.Spec.TTLSecondsAfterFinished < 0 {
+		return fmt.Errorf("ttlSecondsAfterFinished must be a non-negative integer")
+	}
+	if rayJob.Spec.TTLSecondsAfterFinished > 0 && !rayJob.Spec.ShutdownAfterJobFinishes &&
+		(rayJob.Spec.DeletionPolicy == nil || *rayJob.Spec.DeletionPolicy == rayv1.DeleteNone) {
+		return fmt.Errorf("ttlSecondsAfterFinished is only supported when shutdownAfterJobFinishes is set to true or deletionPolicy deletes resources")
+	}
 	if rayJob.Spec.BackoffLimit != nil && *rayJob.Spec.BackoffLimit < 0 {
 		return fmt.Errorf("backoffLimit must be a positive integer")
 	}
+	switch rayJob.Spec.SubmissionMode {
+	case "", rayv1.K8sJobMode, rayv1.HTTPMode, rayv1.InteractiveMode, rayv1.SidecarMode:
+	default:
+		return fmt.Errorf("submissionMode must be %s, %s, %s or %s", rayv1.K8sJobMode, rayv1.HTTPMode, rayv1.InteractiveMode, rayv1.SidecarMode)
+	}
+	if (rayJob.Spec.SubmissionMode == rayv1.HTTPMode || rayJob.Spec.SubmissionMode == rayv1.InteractiveMode || rayJob.Spec.SubmissionMode == rayv1.SidecarMode) &&
+		(rayJob.Spec.SubmitterPodTemplate != nil || rayJob.Spec.SubmitterConfig != nil) && !common.IsRayJobLogsFollowed(rayJob) {
+		return fmt.Errorf("submitterPodTemplate and submitterConfig are not supported in %s, as no submitter Kubernetes Job is created", rayJob.Spec.SubmissionMode)
+	}
+	// The submitter container is added to the head Pod of the RayCluster created for the jobId of each attempt.
+	if rayJob.Spec.SubmissionMode == rayv1.SidecarMode {
+		if rayJob.Spec.RayClusterSpec == nil || len(rayJob.Spec.ClusterSelector) != 0 {
+			return fmt.Errorf("the ClusterSelector mode is not supported in %s, as the submitter container is added to the head Pod of the RayCluster", rayv1.SidecarMode)
+		}
+		if rayJob.Spec.ReuseRayClusterOnRetry {
+			return fmt.Errorf("reuseRayClusterOnRetry is not supported in %s, as the head Pod submits the jobId of the failed attempt", rayv1.SidecarMode)
+		}
+		if len(rayJob.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers) == 0 {
+			return fmt.Errorf("the head Pod template must have at least one container in %s", rayv1.SidecarMode)
+		}
+	}
+	if len(rayJob.Spec.Steps) != 0 {
+		if rayJob.Spec.SubmissionMode != rayv1.HTTPMode {
+			return fmt.Errorf("steps are only supported in %s, as they are submitted by the KubeRay operator", rayv1.HTTPMode)
+		}
+		if rayJob.Spec.Entrypoint != "" {
+			return fmt.Errorf("entrypoint and steps cannot be set at the same time")
+		}
+		if rayJob.Spec.LogStreaming != nil && rayJob.Spec.LogStreaming.Follow {
+			return fmt.Errorf("logStreaming.follow is not supported with steps, as each step is a separate Ray job")
+		}
+		stepNames := make(map[string]struct{}, len(rayJob.Spec.Steps))
+		for _, step := range rayJob.Spec.Steps {
+			if step.Name == "" || step.Entrypoint == "" {
+				return fmt.Errorf("the name and the entrypoint of each step must be set")
+			}
+			if _, ok := stepNames[step.Name]; ok {
+				return fmt.Errorf("the name of the step %s is not unique", step.Name)
+			}
+			// Depending only on previous steps prevents cycles.
+			for _, dependency := range step.DependsOn {
+				if _, ok := stepNames[dependency]; !ok {
+					return fmt.Errorf("the step %s can only depend on previous steps, but it depends on %s", step.Name, dependency)
+				}
+			}
+			stepNames[step.Name] = struct{}{}
+		}
+	}
+	if rayJob.Spec.RayClusterMetadata != nil {
+		if len(rayJob.Spec.ClusterSelector) != 0 {
+			return fmt.Errorf("rayClusterMetadata is not supported in the ClusterSelector mode, as the RayCluster is not created by the RayJob")
+		}
+		for _, key := range []string{utils.RayOriginatedFromCRNameLabelKey, utils.RayOriginatedFromCRDLabelKey, utils.KubernetesCreatedByLabelKey} {
+			if _, ok := rayJob.Spec.RayClusterMetadata.Labels[key]; ok {
+				return fmt.Errorf("the %s label of rayClusterMetadata is reserved for KubeRay", key)
+			}
+		}
+	}
+	if rayJob.Spec.LogStreaming != nil {
+		// The submitter Job already prints the driver logs of the Ray job in K8sJobMode.
+		if rayJob.Spec.LogStreaming.Follow && !common.IsRayJobLogsFollowed(rayJob) {
+			return fmt.Errorf("logStreaming.follow is only supported in %s and %s", rayv1.HTTPMode, rayv1.InteractiveMode)
+		}
+		if sinkURL := rayJob.Spec.LogStreaming.SinkURL; sinkURL != "" {
+			if u, err := url.Parse(sinkURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("logStreaming.sinkURL must be an HTTP(S) URL")
+			}
+		}
+	}
+	// The first container of the submitter Pod template runs `ray job submit`.
+	if rayJob.Spec.SubmitterPodTemplate != nil && len(rayJob.Spec.SubmitterPodTemplate.Spec.Containers) == 0 {
+		return fmt.Errorf("submitterPodTemplate must have at least one container")
+	}
+	if rayJob.Spec.SubmitterPodTemplate != nil {
+		switch rayJob.Spec.SubmitterPodTemplate.Spec.RestartPolicy {
+		case "", corev1.RestartPolicyNever, corev1.RestartPolicyOnFailure:
+		default:
+			return fmt.Errorf("the restartPolicy of submitterPodTemplate must be %s or %s", corev1.RestartPolicyNever, corev1.RestartPolicyOnFailure)
+		}
+	}
+	if rayJob.Spec.SubmitterConfig != nil && rayJob.Spec.SubmitterConfig.BackoffLimit != nil && *rayJob.Spec.SubmitterConfig.BackoffLimit < 0 {
+		return fmt.Errorf("submitterConfig.backoffLimit must be a non-negative integer")
+	}
+	if rayJob.Spec.Schedule != "" {
+		if !features.Enabled(features.RayJobSchedule) {
+			return fmt.Errorf("schedule requires the RayJobSchedule feature gate to be enabled")
+		}
+		if _, err := parseCronSchedule(rayJob.Spec.Schedule, nil); err != nil {
+			return err
+		}
+		// Each run of the schedule submits the Ray job with a new jobId.
+		if rayJob.Spec.JobId != "" {
+			return fmt.Errorf("jobId and schedule cannot be set at the same time")
+		}
+		if rayJob.Spec.SubmissionMode == rayv1.InteractiveMode {
+			return fmt.Errorf("the %s doesn't support the schedule, as users submit the Ray job", rayv1.InteractiveMode)
+		}
+		if rayJob.Spec.DeletionPolicy != nil && (*rayJob.Spec.DeletionPolicy == rayv1.DeleteSelf || *rayJob.Spec.DeletionPolicy == rayv1.DeleteWorkers) {
+			return fmt.Errorf("the %s deletionPolicy doesn't support the schedule, as the next runs need the RayJob and the workers of its RayCluster", *rayJob.Spec.DeletionPolicy)
+		}
+	}
 	return nil
 }
@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/gomega"
 	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/util/retry"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
@@ -46,6 +47,7 @@ var _ = Context("RayJob with suspend operation", func() {
 			Eventually(
 				getRayJobDeploymentStatus(ctx, rayJob),
 				time.Second*5, time.Millisecond*500).Should(Equal(rayv1.JobDeploymentStatusSuspended))
+			Expect(meta.IsStatusConditionTrue(rayJob.Status.Conditions, string(rayv1.RayJobSuspended))).To(BeTrue())
 		})
 
 		It("should NOT create a raycluster object", func() {
@@ -64,6 +66,7 @@ var _ = Context("RayJob with suspend operation", func() {
 			Eventually(
 				getRayClusterNameForRayJob(ctx, rayJob),
 				time.Second*15, time.Millisecond*500).Should(Not(BeEmpty()))
+			Expect(meta.IsStatusConditionFalse(rayJob.Status.Conditions, string(rayv1.RayJobSuspended))).To(BeTrue())
 			// The actual cluster instance and underlying resources SHOULD be created when suspend == false
 			Eventually(
 				// k8sClient client does not throw error if cluster IS found
@@ -2,20 +2,31 @@ package ray
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
-	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	utils "github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/client/dashboard"
+	"github.com/ray-project/kuberay/ray-operator/pkg/features"
 )
 
 func TestCreateK8sJobIfNeed(t *testing.T) {
@@ -59,7 +70,7 @@ func TestCreateK8sJobIfNeed(t *testing.T) {
 	}
 
 	// Test 1: Return the existing k8s job if it already exists
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(k8sJob, rayCluster, rayJob).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(k8sJob, rayCluster, rayJob).Build()
 	ctx := context.TODO()
 
 	rayJobReconciler := &RayJobReconciler{
@@ -72,7 +83,7 @@ func TestCreateK8sJobIfNeed(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Test 2: Create a new k8s job if it does not already exist
-	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayCluster, rayJob).Build()
+	fakeClient = newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayCluster, rayJob).Build()
 	rayJobReconciler.Client = fakeClient
 
 	err = rayJobReconciler.createK8sJobIfNeed(ctx, rayJob, rayCluster)
@@ -189,6 +200,13 @@ func TestGetSubmitterTemplate(t *testing.T) {
 	envVar, found = utils.EnvVarByName(utils.RAY_JOB_SUBMISSION_ID, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env)
 	assert.True(t, found)
 	assert.Equal(t, "test-job-id", envVar.Value)
+
+	// Test 7: The restart policy of the user provided template defaults to Never, as Jobs reject Always
+	assert.Equal(t, corev1.RestartPolicyNever, submitterTemplate.Spec.RestartPolicy)
+	rayJobInstanceWithTemplate.Spec.SubmitterPodTemplate.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	submitterTemplate, err = r.getSubmitterTemplate(ctx, rayJobInstanceWithTemplate, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.RestartPolicyOnFailure, submitterTemplate.Spec.RestartPolicy)
 }
 
 func TestUpdateStatusToSuspendingIfNeeded(t *testing.T) {
@@ -215,6 +233,11 @@ func TestUpdateStatusToSuspendingIfNeeded(t *testing.T) {
 			status:               rayv1.JobDeploymentStatusInitializing,
 			expectedShouldUpdate: true,
 		},
+		"Suspend is true, and the RayJob is waiting for users to submit the Ray job": {
+			suspend:              true,
+			status:               rayv1.JobDeploymentStatusWaiting,
+			expectedShouldUpdate: true,
+		},
 	}
 
 	for name, tc := range tests {
@@ -235,7 +258,7 @@ func TestUpdateStatusToSuspendingIfNeeded(t *testing.T) {
 			}
 
 			// Initialize a fake client with newScheme and runtimeObjects.
-			fakeClient := clientFake.NewClientBuilder().
+			fakeClient := newFakeClientBuilder().
 				WithScheme(newScheme).
 				WithRuntimeObjects(rayJob).
 				WithStatusSubresource(rayJob).Build()
@@ -292,7 +315,7 @@ func TestUpdateRayJobStatus(t *testing.T) {
 			oldRayJob := rayJobTemplate.DeepCopy()
 
 			// Initialize a fake client with newScheme and runtimeObjects.
-			fakeClient := clientFake.NewClientBuilder().
+			fakeClient := newFakeClientBuilder().
 				WithScheme(newScheme).
 				WithRuntimeObjects(oldRayJob).
 				WithStatusSubresource(oldRayJob).Build()
@@ -369,4 +392,1368 @@ func TestValidateRayJobSpec(t *testing.T) {
 		},
 	})
 	assert.Error(t, err, "The RayJob is invalid because the backoffLimit must be a positive integer.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: "InvalidMode",
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the submissionMode is unknown.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.HTTPMode,
+			SubmitterConfig: &rayv1.SubmitterConfig{
+				BackoffLimit: ptr.To[int32](1),
+			},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because no submitter Job is created in HTTPMode.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:       &rayv1.RayClusterSpec{},
+			SubmitterPodTemplate: &corev1.PodTemplateSpec{},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the submitterPodTemplate has no container.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmitterPodTemplate: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers:    []corev1.Container{{Name: "ray-job-submitter"}},
+					RestartPolicy: corev1.RestartPolicyAlways,
+				},
+			},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the submitter Pods can't be restarted always.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmitterConfig: &rayv1.SubmitterConfig{
+				BackoffLimit: ptr.To[int32](-1),
+			},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the submitterConfig.backoffLimit is negative.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:      &rayv1.RayClusterSpec{},
+			EntrypointResources: `{"Custom_1": 1, "Custom_2": 5.5}`,
+		},
+	})
+	assert.NoError(t, err, "The RayJob is valid.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:      &rayv1.RayClusterSpec{},
+			EntrypointResources: `{"Custom_1": "one"}`,
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the entrypointResources are not quantities.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			RuntimeEnvYAML: "working_dir: ./",
+			RuntimeEnv:     &rayv1.RuntimeEnv{WorkingDir: "./"},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because both runtimeEnv and runtimeEnvYAML are set.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			RuntimeEnv:     &rayv1.RuntimeEnv{EnvFrom: []corev1.EnvFromSource{{Prefix: "TEST_"}}},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the envFrom source references neither a Secret nor a ConfigMap.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:    &rayv1.RayClusterSpec{},
+			EntrypointNumCpus: 0.5,
+			EntrypointNumGpus: -1,
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the entrypointNumGpus is negative.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:           &rayv1.RayClusterSpec{},
+			ShutdownAfterJobFinishes: true,
+			TTLSecondsAfterFinished:  -1,
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the ttlSecondsAfterFinished is negative.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:          &rayv1.RayClusterSpec{},
+			TTLSecondsAfterFinished: 60,
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the RayCluster is never deleted without shutdownAfterJobFinishes.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			DeletionPolicy: ptr.To(rayv1.DeleteWorkers),
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the RayJobDeletionPolicy feature gate is disabled.")
+
+	defer features.SetFeatureGateDuringTest(t, features.RayJobDeletionPolicy, true)()
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:          &rayv1.RayClusterSpec{},
+			DeletionPolicy:          ptr.To(rayv1.DeleteWorkers),
+			TTLSecondsAfterFinished: 60,
+		},
+	})
+	assert.NoError(t, err, "The RayJob is valid because the workers are deleted after the TTL.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:           &rayv1.RayClusterSpec{},
+			DeletionPolicy:           ptr.To(rayv1.DeleteCluster),
+			ShutdownAfterJobFinishes: true,
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because both shutdownAfterJobFinishes and deletionPolicy are set.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			ClusterSelector: map[string]string{RayJobDefaultClusterSelectorKey: "raycluster"},
+			DeletionPolicy:  ptr.To(rayv1.DeleteCluster),
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the selected RayCluster cannot be deleted.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			DeletionPolicy: ptr.To(rayv1.DeleteWorkers),
+			Suspend:        true,
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the RayCluster is not deleted once the Ray job finishes.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			Schedule:       "0 * * * *",
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the RayJobSchedule feature gate is disabled.")
+
+	defer features.SetFeatureGateDuringTest(t, features.RayJobSchedule, true)()
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			Schedule:       "0 * * * *",
+			DeletionPolicy: ptr.To(rayv1.DeleteCluster),
+		},
+	})
+	assert.NoError(t, err, "The RayJob is valid because each run of the schedule creates a new RayCluster.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			Schedule:       "every hour",
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the schedule is invalid.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			Schedule:       "0 * * * *",
+			JobId:          "test-job",
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because each run of the schedule needs a new jobId.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			Schedule:       "0 * * * *",
+			DeletionPolicy: ptr.To(rayv1.DeleteSelf),
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the next runs of the schedule need the RayJob.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.K8sJobMode,
+			LogStreaming:   &rayv1.LogStreamingOptions{Follow: true},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the submitter Job already prints the driver logs in K8sJobMode.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:  &rayv1.RayClusterSpec{},
+			SubmissionMode:  rayv1.HTTPMode,
+			LogStreaming:    &rayv1.LogStreamingOptions{Follow: true},
+			SubmitterConfig: &rayv1.SubmitterConfig{BackoffLimit: ptr.To[int32](1)},
+		},
+	})
+	assert.NoError(t, err, "The RayJob is valid because the Kubernetes Job following the driver logs uses the submitterConfig.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			LogStreaming:   &rayv1.LogStreamingOptions{SinkURL: "s3://bucket/logs"},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because logStreaming.sinkURL is not an HTTP(S) URL.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:             &rayv1.RayClusterSpec{},
+			ClusterReadyTimeoutSeconds: ptr.To[int32](0),
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because clusterReadyTimeoutSeconds is not positive.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			ClusterSelector: map[string]string{"key": "value"},
+			SubmissionMode:  rayv1.SidecarMode,
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the submitter container cannot be added to a selected RayCluster.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			RayClusterMetadata: &rayv1.RayClusterMetadata{
+				Labels: map[string]string{utils.RayOriginatedFromCRNameLabelKey: "other"},
+			},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because rayClusterMetadata overrides a label reserved for KubeRay.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.HTTPMode,
+			Steps: []rayv1.RayJobStep{
+				{Name: "train", Entrypoint: "python train.py"},
+				{Name: "evaluate", Entrypoint: "python evaluate.py", DependsOn: []string{"train"}},
+			},
+		},
+	})
+	assert.NoError(t, err, "The RayJob is valid because the steps only depend on previous steps.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.HTTPMode,
+			Steps: []rayv1.RayJobStep{
+				{Name: "train", Entrypoint: "python train.py", DependsOn: []string{"evaluate"}},
+				{Name: "evaluate", Entrypoint: "python evaluate.py"},
+			},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because a step depends on a next step.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.K8sJobMode,
+			Steps:          []rayv1.RayJobStep{{Name: "train", Entrypoint: "python train.py"}},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the steps are submitted via HTTP requests.")
+}
+
+func TestIsRayJobSubmissionRejected(t *testing.T) {
+	assert.True(t, isRayJobSubmissionRejected(&dashboard.APIError{StatusCode: http.StatusBadRequest, Body: "Failed to parse runtime_env"}))
+	assert.False(t, isRayJobSubmissionRejected(&dashboard.APIError{StatusCode: http.StatusBadRequest, Body: "Job with submission_id raysubmit_1 already exists"}))
+	assert.False(t, isRayJobSubmissionRejected(&dashboard.APIError{StatusCode: http.StatusServiceUnavailable}))
+	assert.False(t, isRayJobSubmissionRejected(errors.New("connection refused")))
+}
+
+func TestReconcileInteractiveModeRayJob(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.InteractiveMode,
+		},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusWaiting,
+			RayClusterName:      "test-raycluster",
+			DashboardURL:        "test-raycluster-head-svc.default.svc.cluster.local:8265",
+		},
+	}
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(rayJob).
+		WithStatusSubresource(rayJob).Build()
+	ctx := context.Background()
+	r := &RayJobReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: rayJob.Namespace, Name: rayJob.Name}}
+
+	// The RayJob keeps waiting until users set the submission ID of the Ray job they submitted.
+	_, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusWaiting, rayJob.Status.JobDeploymentStatus)
+	assert.Empty(t, rayJob.Status.JobId)
+
+	rayJob.Annotations = map[string]string{utils.RayJobSubmissionIdAnnotationKey: "raysubmit_interactive"}
+	assert.NoError(t, fakeClient.Update(ctx, rayJob))
+	_, err = r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusRunning, rayJob.Status.JobDeploymentStatus)
+	assert.Equal(t, "raysubmit_interactive", rayJob.Status.JobId)
+}
+
+func TestReconcileRayJobPassingActiveDeadline(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-raycluster",
+			Namespace: "default",
+		},
+	}
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:        &rayv1.RayClusterSpec{},
+			SubmissionMode:        rayv1.HTTPMode,
+			ActiveDeadlineSeconds: ptr.To[int32](60),
+		},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+			JobStatus:           rayv1.JobStatusRunning,
+			JobId:               "test-rayjob-abcde",
+			RayClusterName:      rayCluster.Name,
+			DashboardURL:        "test-raycluster-head-svc.default.svc.cluster.local:8265",
+			StartTime:           &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(rayJob, rayCluster).
+		WithStatusSubresource(rayJob).Build()
+	ctx := context.Background()
+	r := &RayJobReconciler{
+		Client:              fakeClient,
+		Recorder:            &record.FakeRecorder{},
+		Scheme:              newScheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface { return &utils.FakeRayDashboardClient{} },
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: rayJob.Namespace, Name: rayJob.Name}}
+
+	// The Ray job is stopped and the RayJob fails.
+	_, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusFailed, rayJob.Status.JobDeploymentStatus)
+	assert.Equal(t, rayv1.DeadlineExceeded, rayJob.Status.Reason)
+	assert.Equal(t, rayv1.JobStatusStopped, rayJob.Status.JobStatus)
+	assert.NotNil(t, rayJob.Status.EndTime)
+}
+
+func TestReconcileRayJobPassingClusterReadyTimeout(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-raycluster",
+			Namespace: "default",
+		},
+		Status: rayv1.RayClusterStatus{
+			Reason: "0/3 nodes are available: 3 Insufficient nvidia.com/gpu.",
+		},
+	}
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:             &rayv1.RayClusterSpec{},
+			ClusterReadyTimeoutSeconds: ptr.To[int32](60),
+		},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusInitializing,
+			JobId:               "test-rayjob-abcde",
+			RayClusterName:      rayCluster.Name,
+			StartTime:           &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(rayJob, rayCluster).
+		WithStatusSubresource(rayJob).Build()
+	ctx := context.Background()
+	r := &RayJobReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: rayJob.Namespace, Name: rayJob.Name}}
+
+	// The RayJob fails and the RayCluster that was never ready is deleted.
+	_, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusFailed, rayJob.Status.JobDeploymentStatus)
+	assert.Equal(t, rayv1.ClusterReadyTimeoutExceeded, rayJob.Status.Reason)
+	assert.Contains(t, rayJob.Status.Message, "Insufficient nvidia.com/gpu")
+	err = fakeClient.Get(ctx, types.NamespacedName{Namespace: rayCluster.Namespace, Name: rayCluster.Name}, rayCluster)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileFailedRayJobKeepsLogsTail(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-raycluster",
+			Namespace: "default",
+		},
+	}
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.HTTPMode,
+		},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+			JobStatus:           rayv1.JobStatusRunning,
+			JobId:               "test-rayjob-abcde",
+			RayClusterName:      rayCluster.Name,
+			DashboardURL:        "test-raycluster-head-svc.default.svc.cluster.local:8265",
+			StartTime:           &metav1.Time{Time: time.Now().Add(-time.Minute)},
+		},
+	}
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(rayJob, rayCluster).
+		WithStatusSubresource(rayJob).Build()
+	ctx := context.Background()
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	getJobInfo := func(context.Context, string) (*utils.RayJobInfo, error) {
+		return &utils.RayJobInfo{
+			JobStatus:      rayv1.JobStatusFailed,
+			Message:        "Job entrypoint command failed with exit code 1" + strings.Repeat(".", 2*utils.RayJobStatusMessageMaxBytes),
+			DriverExitCode: ptr.To[int32](1),
+		}, nil
+	}
+	dashboardClient.GetJobInfoMock.Store(&getJobInfo)
+	r := &RayJobReconciler{
+		Client:              fakeClient,
+		Recorder:            &record.FakeRecorder{},
+		Scheme:              newScheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface { return dashboardClient },
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: rayJob.Namespace, Name: rayJob.Name}}
+
+	// The end of the driver logs and the exit code of the driver are kept in the status of the failed RayJob.
+	_, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusFailed, rayJob.Status.JobDeploymentStatus)
+	assert.Equal(t, rayv1.AppFailed, rayJob.Status.Reason)
+	assert.Equal(t, "log", rayJob.Status.LogsTail)
+	assert.Equal(t, ptr.To[int32](1), rayJob.Status.DriverExitCode)
+	assert.Len(t, rayJob.Status.Message, utils.RayJobStatusMessageMaxBytes)
+	assert.True(t, strings.HasPrefix(rayJob.Status.Message, "Job entrypoint command failed with exit code 1"))
+}
+
+func TestReconcileRayJobFollowingLogs(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = batchv1.AddToScheme(newScheme)
+	uploadedLogs := make(chan string, 1)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		uploadedLogs <- string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer sink.Close()
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-raycluster",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.9.0"}}},
+				},
+			},
+		},
+	}
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.HTTPMode,
+			LogStreaming:   &rayv1.LogStreamingOptions{Follow: true, SinkURL: sink.URL},
+		},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+			JobStatus:           rayv1.JobStatusRunning,
+			JobId:               "test-rayjob-abcde",
+			RayClusterName:      rayCluster.Name,
+			DashboardURL:        "test-raycluster-head-svc.default.svc.cluster.local:8265",
+			StartTime:           &metav1.Time{Time: time.Now().Add(-time.Minute)},
+		},
+	}
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(rayJob, rayCluster).
+		WithStatusSubresource(rayJob).Build()
+	ctx := context.Background()
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	r := &RayJobReconciler{
+		Client:              fakeClient,
+		Recorder:            &record.FakeRecorder{},
+		Scheme:              newScheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface { return dashboardClient },
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: rayJob.Namespace, Name: rayJob.Name}}
+
+	// A Kubernetes Job follows the driver logs of the running Ray job.
+	_, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	job := &batchv1.Job{}
+	assert.NoError(t, fakeClient.Get(ctx, common.RayJobK8sJobNamespacedName(rayJob), job))
+	assert.Equal(t, []string{"ray", "job", "logs", "--address", "http://" + rayJob.Status.DashboardURL, "--follow", rayJob.Status.JobId},
+		job.Spec.Template.Spec.Containers[utils.RayContainerIndex].Command)
+
+	// The driver logs are uploaded to the sink once the Ray job finished.
+	getJobInfo := func(context.Context, string) (*utils.RayJobInfo, error) {
+		return &utils.RayJobInfo{JobStatus: rayv1.JobStatusSucceeded}, nil
+	}
+	dashboardClient.GetJobInfoMock.Store(&getJobInfo)
+	_, err = r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusComplete, rayJob.Status.JobDeploymentStatus)
+	assert.Equal(t, "log", <-uploadedLogs)
+}
+
+func TestReconcileSidecarModeRayJob(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayJobSpec{
+			SubmissionMode: rayv1.SidecarMode,
+			Entrypoint:     "python test.py",
+			RayClusterSpec: &rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.9.0"}}},
+					},
+				},
+			},
+		},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+			JobStatus:           rayv1.JobStatusNew,
+			JobId:               "test-rayjob-abcde",
+			RayClusterName:      "test-raycluster",
+			DashboardURL:        "test-raycluster-head-svc.default.svc.cluster.local:8265",
+			StartTime:           &metav1.Time{Time: time.Now().Add(-time.Minute)},
+		},
+	}
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(rayJob).
+		WithStatusSubresource(rayJob).Build()
+	ctx := context.Background()
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	getJobInfo := func(context.Context, string) (*utils.RayJobInfo, error) {
+		return nil, apierrors.NewBadRequest("Job does not exist on the cluster")
+	}
+	dashboardClient.GetJobInfoMock.Store(&getJobInfo)
+	r := &RayJobReconciler{
+		Client:              fakeClient,
+		Recorder:            &record.FakeRecorder{},
+		Scheme:              newScheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface { return dashboardClient },
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: rayJob.Namespace, Name: rayJob.Name}}
+
+	// The RayCluster is created with the submitter container in its head Pod, and the RayJob waits for it to submit
+	// the Ray job.
+	_, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	rayCluster := &rayv1.RayCluster{}
+	assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: rayJob.Namespace, Name: "test-raycluster"}, rayCluster))
+	headContainers := rayCluster.Spec.HeadGroupSpec.Template.Spec.Containers
+	assert.Len(t, headContainers, 2)
+	assert.Equal(t, utils.SubmitterContainerName, headContainers[1].Name)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusRunning, rayJob.Status.JobDeploymentStatus)
+
+	// The RayJob fails once the submitter container failed to submit the Ray job.
+	headPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-raycluster-head",
+			Namespace: rayJob.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:  rayCluster.Name,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  utils.SubmitterContainerName,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+			}},
+		},
+	}
+	assert.NoError(t, fakeClient.Create(ctx, headPod))
+	_, err = r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusFailed, rayJob.Status.JobDeploymentStatus)
+	assert.Equal(t, rayv1.SubmissionFailed, rayJob.Status.Reason)
+}
+
+func TestCheckSidecarSubmitterAndUpdateStatusIfNeeded(t *testing.T) {
+	rayCluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-raycluster", Namespace: "default"}}
+
+	tests := map[string]struct {
+		submitterStatus corev1.ContainerStatus
+		expectFailed    bool
+	}{
+		"The submitter container is running": {
+			submitterStatus: corev1.ContainerStatus{
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			},
+			expectFailed: false,
+		},
+		"The submitter container exited with an error": {
+			submitterStatus: corev1.ContainerStatus{
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+			},
+			expectFailed: true,
+		},
+		"The submitter container was restarted after exiting with an error": {
+			submitterStatus: corev1.ContainerStatus{
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+				RestartCount:         1,
+			},
+			expectFailed: true,
+		},
+		"The submitter container succeeded": {
+			submitterStatus: corev1.ContainerStatus{
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+			},
+			expectFailed: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.submitterStatus.Name = utils.SubmitterContainerName
+			headPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-raycluster-head",
+					Namespace: rayCluster.Namespace,
+					Labels: map[string]string{
+						utils.RayClusterLabelKey:  rayCluster.Name,
+						utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+					},
+				},
+				Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{tc.submitterStatus}},
+			}
+			// The head Pod is read from the informer cache, whose transform strips the fields KubeRay does not read.
+			cachedHeadPod, err := utils.StripUnusedFields(headPod)
+			assert.NoError(t, err)
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(cachedHeadPod.(*corev1.Pod)).Build()
+			r := &RayJobReconciler{Client: fakeClient, Recorder: &record.FakeRecorder{}}
+
+			rayJob := &rayv1.RayJob{Status: rayv1.RayJobStatus{JobDeploymentStatus: rayv1.JobDeploymentStatusRunning}}
+			failed := r.checkSidecarSubmitterAndUpdateStatusIfNeeded(context.Background(), rayJob, rayCluster)
+			assert.Equal(t, tc.expectFailed, failed)
+			if tc.expectFailed {
+				assert.Equal(t, rayv1.JobDeploymentStatusFailed, rayJob.Status.JobDeploymentStatus)
+				assert.Equal(t, rayv1.SubmissionFailed, rayJob.Status.Reason)
+			}
+		})
+	}
+}
+
+func TestConstructRayClusterForRayJobMetadata(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-rayjob",
+			Namespace:   "default",
+			Labels:      map[string]string{"team": "ml", "cost-center": "1234"},
+			Annotations: map[string]string{"owner": "alice"},
+		},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			RayClusterMetadata: &rayv1.RayClusterMetadata{
+				Labels:      map[string]string{"cost-center": "5678", "env": "prod"},
+				Annotations: map[string]string{"owner": "bob"},
+			},
+		},
+	}
+	r := &RayJobReconciler{Scheme: newScheme}
+
+	rayCluster, err := r.constructRayClusterForRayJob(rayJob, "test-raycluster")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"team":                                "ml",
+		"cost-center":                         "5678",
+		"env":                                 "prod",
+		utils.RayOriginatedFromCRNameLabelKey: rayJob.Name,
+		utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayJobCRD),
+		utils.KubernetesCreatedByLabelKey:     utils.ComponentName,
+	}, rayCluster.Labels)
+	assert.Equal(t, map[string]string{"owner": "bob"}, rayCluster.Annotations)
+	// The annotations of the RayJob are not modified.
+	assert.Equal(t, map[string]string{"owner": "alice"}, rayJob.Annotations)
+	assert.True(t, metav1.IsControlledBy(rayCluster, rayJob))
+}
+
+func TestReconcileRayJobSteps(t *testing.T) {
+	ctx := context.Background()
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayJobSpec{
+			SubmissionMode: rayv1.HTTPMode,
+			Steps: []rayv1.RayJobStep{
+				{Name: "prepare", Entrypoint: "python prepare.py"},
+				{Name: "train", Entrypoint: "python train.py", DependsOn: []string{"prepare"}},
+				{Name: "evaluate", Entrypoint: "python evaluate.py", DependsOn: []string{"prepare"}},
+				{Name: "report", Entrypoint: "python report.py", DependsOn: []string{"train", "evaluate"}},
+			},
+		},
+		Status: rayv1.RayJobStatus{
+			JobId:               "test-job",
+			JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+		},
+	}
+	// The Ray jobs that were not submitted are not found.
+	jobStatuses := map[string]rayv1.JobStatus{}
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	getJobInfo := func(_ context.Context, jobId string) (*utils.RayJobInfo, error) {
+		jobStatus, ok := jobStatuses[jobId]
+		if !ok {
+			return nil, apierrors.NewBadRequest("Job does not exist on the cluster")
+		}
+		return &utils.RayJobInfo{JobStatus: jobStatus, SubmissionId: jobId}, nil
+	}
+	dashboardClient.GetJobInfoMock.Store(&getJobInfo)
+	r := &RayJobReconciler{Recorder: &record.FakeRecorder{}}
+
+	// The first step is submitted.
+	jobInfo, err := r.reconcileRayJobSteps(ctx, rayJob, dashboardClient)
+	assert.NoError(t, err)
+	assert.Equal(t, rayv1.JobStatusRunning, jobInfo.JobStatus)
+	assert.Len(t, rayJob.Status.Steps, 4)
+	assert.Equal(t, "test-job-prepare", rayJob.Status.Steps[0].JobId)
+	assert.Equal(t, rayv1.JobStatusPending, rayJob.Status.Steps[0].JobStatus)
+	assert.NotNil(t, rayJob.Status.Steps[0].StartTime)
+	assert.Nil(t, rayJob.Status.Steps[1].StartTime)
+
+	// The steps depending on the first step are submitted once it succeeded.
+	jobStatuses["test-job-prepare"] = rayv1.JobStatusSucceeded
+	jobInfo, err = r.reconcileRayJobSteps(ctx, rayJob, dashboardClient)
+	assert.NoError(t, err)
+	assert.Equal(t, rayv1.JobStatusRunning, jobInfo.JobStatus)
+	assert.Equal(t, rayv1.JobStatusSucceeded, rayJob.Status.Steps[0].JobStatus)
+	assert.NotNil(t, rayJob.Status.Steps[0].EndTime)
+	assert.Equal(t, rayv1.JobStatusPending, rayJob.Status.Steps[1].JobStatus)
+	assert.Equal(t, rayv1.JobStatusPending, rayJob.Status.Steps[2].JobStatus)
+	assert.Nil(t, rayJob.Status.Steps[3].StartTime)
+
+	// The last step is skipped, and the Ray job fails with the step that failed.
+	jobStatuses["test-job-train"] = rayv1.JobStatusFailed
+	jobStatuses["test-job-evaluate"] = rayv1.JobStatusSucceeded
+	jobInfo, err = r.reconcileRayJobSteps(ctx, rayJob, dashboardClient)
+	assert.NoError(t, err)
+	assert.Equal(t, rayv1.JobStatusFailed, jobInfo.JobStatus)
+	assert.Equal(t, "test-job-train", jobInfo.SubmissionId)
+	assert.Contains(t, jobInfo.Message, "train")
+	assert.Equal(t, rayv1.JobStatusStopped, rayJob.Status.Steps[3].JobStatus)
+	assert.Nil(t, rayJob.Status.Steps[3].StartTime)
+	assert.Empty(t, getRayJobIds(rayJob))
+
+	// Without dependencies, the steps run sequentially.
+	rayJob.Spec.Steps = []rayv1.RayJobStep{
+		{Name: "first", Entrypoint: "python first.py"},
+		{Name: "second", Entrypoint: "python second.py"},
+	}
+	rayJob.Status.Steps = nil
+	jobStatuses["test-job-first"] = rayv1.JobStatusRunning
+	jobInfo, err = r.reconcileRayJobSteps(ctx, rayJob, dashboardClient)
+	assert.NoError(t, err)
+	assert.Equal(t, rayv1.JobStatusRunning, jobInfo.JobStatus)
+	assert.Nil(t, rayJob.Status.Steps[1].StartTime)
+	assert.Equal(t, []string{"test-job-first"}, getRayJobIds(rayJob))
+
+	jobStatuses["test-job-first"] = rayv1.JobStatusSucceeded
+	jobStatuses["test-job-second"] = rayv1.JobStatusSucceeded
+	jobInfo, err = r.reconcileRayJobSteps(ctx, rayJob, dashboardClient)
+	assert.NoError(t, err)
+	assert.Equal(t, rayv1.JobStatusSucceeded, jobInfo.JobStatus)
+	assert.Equal(t, "test-job-second", jobInfo.SubmissionId)
+}
+
+func TestTruncateToHeadAndTail(t *testing.T) {
+	assert.Equal(t, "log", truncateToTail("log", 4))
+	assert.Equal(t, "cdef", truncateToTail("abcdef", 4))
+	// A multi-byte character is not split.
+	assert.Equal(t, "a", truncateToTail("éa", 2))
+	assert.Equal(t, "ab", truncateToHead("abcdef", 2))
+	assert.Equal(t, "a", truncateToHead("aé", 2))
+}
+
+func TestGetRayJobResult(t *testing.T) {
+	tests := map[string]struct {
+		logs           string
+		expectedResult string
+		expectError    bool
+	}{
+		"No result": {
+			logs:           "training\ndone\n",
+			expectedResult: "",
+		},
+		"Result on the first line": {
+			logs:           "KUBERAY_JOB_RESULT: {\"accuracy\": 0.9}",
+			expectedResult: `{"accuracy":0.9}`,
+		},
+		"The last result is used": {
+			logs:           "KUBERAY_JOB_RESULT: {\"epoch\": 1}\ntraining\nKUBERAY_JOB_RESULT: {\"epoch\": 2}\ndone\n",
+			expectedResult: `{"epoch":2}`,
+		},
+		"Invalid JSON": {
+			logs:        "KUBERAY_JOB_RESULT: {\"accuracy\":\n",
+			expectError: true,
+		},
+		"Result too large": {
+			logs:        "KUBERAY_JOB_RESULT: \"" + strings.Repeat("a", utils.RayJobResultMaxBytes) + "\"\n",
+			expectError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := getRayJobResult(tc.logs)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestReconcileDeletedRayJobStopsRayJobGracefully(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-raycluster",
+			Namespace: "default",
+		},
+	}
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-rayjob",
+			Namespace:         "default",
+			Finalizers:        []string{utils.RayJobStopJobFinalizer},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+			JobStatus:           rayv1.JobStatusRunning,
+			JobId:               "test-rayjob-abcde",
+			RayClusterName:      rayCluster.Name,
+			DashboardURL:        "test-raycluster-head-svc.default.svc.cluster.local:8265",
+		},
+	}
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(rayJob, rayCluster).
+		WithStatusSubresource(rayJob).Build()
+	ctx := context.Background()
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	r := &RayJobReconciler{
+		Client:              fakeClient,
+		Recorder:            &record.FakeRecorder{},
+		Scheme:              newScheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface { return dashboardClient },
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: rayJob.Namespace, Name: rayJob.Name}}
+
+	// The finalizer is kept while the Ray job is stopping.
+	_, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Contains(t, rayJob.Finalizers, utils.RayJobStopJobFinalizer)
+
+	// The finalizer is removed once the Ray job stopped.
+	getJobInfo := func(context.Context, string) (*utils.RayJobInfo, error) {
+		return &utils.RayJobInfo{JobStatus: rayv1.JobStatusStopped}, nil
+	}
+	dashboardClient.GetJobInfoMock.Store(&getJobInfo)
+	_, err = r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.True(t, apierrors.IsNotFound(fakeClient.Get(ctx, request.NamespacedName, rayJob)))
+}
+
+func TestStopRayJobGracefullyTimeout(t *testing.T) {
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-rayjob",
+			Namespace:         "default",
+			DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-RayJobGracefulStopTimeout)},
+		},
+		Status: rayv1.RayJobStatus{
+			JobStatus: rayv1.JobStatusRunning,
+			JobId:     "test-rayjob-abcde",
+		},
+	}
+	r := &RayJobReconciler{}
+
+	// The RayJob stops waiting for the Ray job to stop after the timeout, without reaching the dashboard.
+	assert.True(t, r.stopRayJobGracefully(context.Background(), rayJob))
+}
+
+func TestRetryRayJobOnSameRayCluster(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = batchv1.AddToScheme(newScheme)
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-raycluster",
+			Namespace: "default",
+		},
+	}
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:         &rayv1.RayClusterSpec{},
+			JobId:                  "test-job",
+			BackoffLimit:           ptr.To[int32](1),
+			ReuseRayClusterOnRetry: true,
+		},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusFailed,
+			JobStatus:           rayv1.JobStatusFailed,
+			Reason:              rayv1.AppFailed,
+			JobId:               "test-job",
+			Attempt:             1,
+			RayClusterName:      rayCluster.Name,
+			DashboardURL:        "test-raycluster-head-svc.default.svc.cluster.local:8265",
+			StartTime:           &metav1.Time{Time: time.Now().Add(-time.Minute)},
+		},
+	}
+	ctx := context.Background()
+
+	// The failed attempt is recorded before the Ray job is retried.
+	recordRayJobAttemptIfNeeded(rayJob)
+	checkBackoffLimitAndUpdateStatusIfNeeded(ctx, rayJob)
+	assert.Equal(t, rayv1.JobDeploymentStatusRetrying, rayJob.Status.JobDeploymentStatus)
+	assert.Len(t, rayJob.Status.Attempts, 1)
+	assert.Equal(t, "test-job", rayJob.Status.Attempts[0].JobId)
+	assert.Equal(t, rayv1.AppFailed, rayJob.Status.Attempts[0].Reason)
+	assert.NotNil(t, rayJob.Status.Attempts[0].EndTime)
+
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(rayJob, rayCluster).
+		WithStatusSubresource(rayJob).Build()
+	r := &RayJobReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: rayJob.Namespace, Name: rayJob.Name}}
+
+	// The RayCluster of the failed attempt is kept for the retry.
+	_, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusNew, rayJob.Status.JobDeploymentStatus)
+	assert.Equal(t, rayCluster.Name, rayJob.Status.RayClusterName)
+	assert.Empty(t, rayJob.Status.JobId)
+	assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: rayCluster.Namespace, Name: rayCluster.Name}, rayCluster))
+
+	// The retry is submitted with a new jobId.
+	assert.NoError(t, r.initRayJobStatusIfNeed(ctx, rayJob))
+	assert.Equal(t, int32(2), rayJob.Status.Attempt)
+	assert.Equal(t, "test-job-2", rayJob.Status.JobId)
+	assert.Equal(t, rayCluster.Name, rayJob.Status.RayClusterName)
+}
+
+func TestSetRayJobStageConditions(t *testing.T) {
+	rayJob := &rayv1.RayJob{}
+	isTrue := func(conditionType rayv1.RayJobConditionType) bool {
+		return meta.IsStatusConditionTrue(rayJob.Status.Conditions, string(conditionType))
+	}
+
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusNew
+	setRayJobStageConditions(rayJob)
+	assert.Len(t, rayJob.Status.Conditions, 6)
+	assert.False(t, isTrue(rayv1.RayJobInitialized))
+
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusInitializing
+	setRayJobStageConditions(rayJob)
+	assert.True(t, isTrue(rayv1.RayJobInitialized))
+	assert.False(t, isTrue(rayv1.RayJobClusterProvisioned))
+
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusRunning
+	setRayJobStageConditions(rayJob)
+	assert.True(t, isTrue(rayv1.RayJobClusterProvisioned))
+	assert.False(t, isTrue(rayv1.RayJobSubmitted))
+
+	rayJob.Status.JobStatus = rayv1.JobStatusRunning
+	setRayJobStageConditions(rayJob)
+	assert.True(t, isTrue(rayv1.RayJobSubmitted))
+	assert.True(t, isTrue(rayv1.RayJobRunning))
+
+	rayJob.Status.JobStatus = rayv1.JobStatusFailed
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusFailed
+	rayJob.Status.Reason = rayv1.AppFailed
+	setRayJobStageConditions(rayJob)
+	assert.False(t, isTrue(rayv1.RayJobRunning))
+	assert.True(t, isTrue(rayv1.RayJobFailed))
+	assert.Equal(t, string(rayv1.AppFailed), meta.FindStatusCondition(rayJob.Status.Conditions, string(rayv1.RayJobFailed)).Reason)
+	assert.False(t, isTrue(rayv1.RayJobComplete))
+
+	// The conditions are kept while the RayJob is retrying, and are reset when the retry starts.
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusRetrying
+	setRayJobStageConditions(rayJob)
+	assert.True(t, isTrue(rayv1.RayJobFailed))
+	rayJob.Status.JobStatus = rayv1.JobStatusNew
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusNew
+	setRayJobStageConditions(rayJob)
+	for _, condition := range rayJob.Status.Conditions {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status, condition.Type)
+	}
+}
+
+func TestGetDeletionPolicy(t *testing.T) {
+	rayJob := &rayv1.RayJob{}
+	assert.Equal(t, rayv1.DeleteNone, getDeletionPolicy(rayJob))
+
+	rayJob.Spec.ShutdownAfterJobFinishes = true
+	assert.Equal(t, rayv1.DeleteCluster, getDeletionPolicy(rayJob))
+
+	t.Setenv(utils.DELETE_RAYJOB_CR_AFTER_JOB_FINISHES, "true")
+	assert.Equal(t, rayv1.DeleteSelf, getDeletionPolicy(rayJob))
+
+	// The next runs of the schedule need the RayJob.
+	rayJob.Spec.Schedule = "0 * * * *"
+	assert.Equal(t, rayv1.DeleteCluster, getDeletionPolicy(rayJob))
+	rayJob.Spec.Schedule = ""
+
+	// The selected RayCluster is not owned by the RayJob.
+	rayJob.Spec.ClusterSelector = map[string]string{RayJobDefaultClusterSelectorKey: "raycluster"}
+	assert.Equal(t, rayv1.DeleteNone, getDeletionPolicy(rayJob))
+
+	// The deletionPolicy is ignored unless the feature gate is enabled.
+	rayJob = &rayv1.RayJob{Spec: rayv1.RayJobSpec{DeletionPolicy: ptr.To(rayv1.DeleteWorkers)}}
+	assert.Equal(t, rayv1.DeleteNone, getDeletionPolicy(rayJob))
+	defer features.SetFeatureGateDuringTest(t, features.RayJobDeletionPolicy, true)()
+	assert.Equal(t, rayv1.DeleteWorkers, getDeletionPolicy(rayJob))
+}
+
+func TestDeleteWorkers(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-raycluster",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayClusterSpec{
+			EnableInTreeAutoscaling: ptr.To(true),
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{
+					GroupName:   "small-group",
+					Replicas:    ptr.To[int32](2),
+					MinReplicas: ptr.To[int32](1),
+					MaxReplicas: ptr.To[int32](5),
+				},
+			},
+		},
+	}
+	newPod := func(name string, nodeType rayv1.RayNodeType) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					utils.RayClusterLabelKey:  rayCluster.Name,
+					utils.RayNodeTypeLabelKey: string(nodeType),
+				},
+			},
+		}
+	}
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(rayCluster, newPod("head", rayv1.HeadNode), newPod("worker-1", rayv1.WorkerNode), newPod("worker-2", rayv1.WorkerNode)).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &RayJobReconciler{Client: fakeClient, Recorder: recorder, Scheme: newScheme}
+	ctx := context.Background()
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rayjob", Namespace: "default"},
+		Status:     rayv1.RayJobStatus{RayClusterName: rayCluster.Name},
+	}
+
+	assert.NoError(t, r.deleteWorkers(ctx, rayJob))
+	assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: rayCluster.Namespace, Name: rayCluster.Name}, rayCluster))
+	worker := rayCluster.Spec.WorkerGroupSpecs[0]
+	assert.Equal(t, int32(0), *worker.Replicas)
+	assert.Equal(t, int32(0), *worker.MinReplicas)
+	assert.Equal(t, int32(0), *worker.MaxReplicas)
+	pods := corev1.PodList{}
+	assert.NoError(t, fakeClient.List(ctx, &pods))
+	assert.Len(t, pods.Items, 1)
+	assert.Equal(t, "head", pods.Items[0].Name)
+	assert.Len(t, recorder.Events, 1)
+
+	// The workers are deleted once.
+	assert.NoError(t, r.deleteWorkers(ctx, rayJob))
+	assert.Len(t, recorder.Events, 1)
+}
+
+func TestSelectRayClusterName(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	newRayCluster := func(name string, state rayv1.ClusterState) *rayv1.RayCluster {
+		return &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{"team": "ml"},
+			},
+			Status: rayv1.RayClusterStatus{State: state},
+		}
+	}
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(newRayCluster("raycluster-c", rayv1.Ready), newRayCluster("raycluster-b", rayv1.Ready), newRayCluster("raycluster-a", rayv1.Suspended)).
+		Build()
+	r := &RayJobReconciler{Client: fakeClient, Scheme: newScheme}
+	ctx := context.Background()
+	rayJob := &rayv1.RayJob{ObjectMeta: metav1.ObjectMeta{Name: "rayjob", Namespace: "default"}}
+
+	// The RayCluster is selected by its name.
+	rayJob.Spec.ClusterSelector = map[string]string{RayJobDefaultClusterSelectorKey: "raycluster-a"}
+	name, err := r.selectRayClusterName(ctx, rayJob)
+	assert.NoError(t, err)
+	assert.Equal(t, "raycluster-a", name)
+
+	// The first ready RayCluster matching the labels is selected.
+	rayJob.Spec.ClusterSelector = map[string]string{"team": "ml"}
+	name, err = r.selectRayClusterName(ctx, rayJob)
+	assert.NoError(t, err)
+	assert.Equal(t, "raycluster-b", name)
+
+	rayJob.Spec.ClusterSelector = map[string]string{"team": "infra"}
+	_, err = r.selectRayClusterName(ctx, rayJob)
+	assert.Error(t, err)
+}
+
+func TestReconcileScheduledRayJob(t *testing.T) {
+	defer features.SetFeatureGateDuringTest(t, features.RayJobSchedule, true)()
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = batchv1.AddToScheme(newScheme)
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-rayjob",
+			Namespace:         "default",
+			CreationTimestamp: metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+		},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.HTTPMode,
+			Schedule:       "0 * * * *",
+		},
+	}
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(rayJob).
+		WithStatusSubresource(rayJob).Build()
+	ctx := context.Background()
+	r := &RayJobReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: rayJob.Namespace, Name: rayJob.Name}}
+
+	// The RayJob waits for the first run of its schedule.
+	_, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusScheduled, rayJob.Status.JobDeploymentStatus)
+
+	// The runs missed since the RayJob was created are merged into a single run.
+	_, err = r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusNew, rayJob.Status.JobDeploymentStatus)
+	assert.NotNil(t, rayJob.Status.LastScheduleTime)
+	assert.WithinDuration(t, time.Now(), rayJob.Status.LastScheduleTime.Time, time.Hour)
+
+	// The RayJob waits for the next run once the Ray job finished.
+	rayJob.Status = rayv1.RayJobStatus{
+		JobDeploymentStatus: rayv1.JobDeploymentStatusComplete,
+		JobStatus:           rayv1.JobStatusSucceeded,
+		JobId:               "test-rayjob-abcde",
+		RayClusterName:      "test-raycluster",
+		DashboardURL:        "test-raycluster-head-svc.default.svc.cluster.local:8265",
+		Succeeded:           ptr.To[int32](1),
+		Attempts:            []rayv1.RayJobAttempt{{JobId: "test-rayjob-abcde", JobStatus: rayv1.JobStatusSucceeded}},
+		LastScheduleTime:    rayJob.Status.LastScheduleTime,
+	}
+	assert.NoError(t, fakeClient.Status().Update(ctx, rayJob))
+	_, err = r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	result, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusScheduled, rayJob.Status.JobDeploymentStatus)
+	assert.Positive(t, result.RequeueAfter)
+	assert.LessOrEqual(t, result.RequeueAfter, time.Hour)
+
+	// The next run reuses the RayCluster, which the deletionPolicy keeps, and submits the Ray job with a new jobId.
+	rayJob.Status.LastScheduleTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	assert.NoError(t, fakeClient.Status().Update(ctx, rayJob))
+	_, err = r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusNew, rayJob.Status.JobDeploymentStatus)
+	assert.Equal(t, "test-raycluster", rayJob.Status.RayClusterName)
+	assert.Empty(t, rayJob.Status.JobId)
+	assert.Empty(t, rayJob.Status.Attempts)
+	assert.Equal(t, int32(0), *rayJob.Status.Succeeded)
+}
+
+func TestResolveRuntimeEnvFrom(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: "default"},
+		Data:       map[string][]byte{"TOKEN": []byte("secret-token"), "USER": []byte("secret-user")},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "default"},
+		Data:       map[string]string{"USER": "configmap-user", "LEVEL": "debug"},
+	}
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			RuntimeEnv: &rayv1.RuntimeEnv{
+				WorkingDir: "./",
+				EnvVars:    map[string]string{"LEVEL": "info"},
+				EnvFrom: []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}}},
+					{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name}}},
+					{
+						Prefix:    "OPTIONAL_",
+						SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "missing-secret"}, Optional: ptr.To(true)},
+					},
+				},
+			},
+		},
+	}
+	fakeClient := newFakeClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(rayJob, secret, configMap).Build()
+	ctx := context.Background()
+	r := &RayJobReconciler{
+		Client:    fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    newScheme,
+		apiReader: fakeClient,
+	}
+
+	// The later sources take precedence, and the envVars take precedence over all of them.
+	resolvedRayJob, err := r.resolveRuntimeEnvFrom(ctx, rayJob)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"TOKEN": "secret-token", "USER": "configmap-user", "LEVEL": "info"}, resolvedRayJob.Spec.RuntimeEnv.EnvVars)
+	assert.Empty(t, resolvedRayJob.Spec.RuntimeEnv.EnvFrom)
+	assert.Equal(t, map[string]string{"LEVEL": "info"}, rayJob.Spec.RuntimeEnv.EnvVars)
+
+	// The resolved runtime environment is stored in a Secret for the submitter Pod, and updated before each submission.
+	assert.NoError(t, r.createOrUpdateRuntimeEnvSecretIfNeed(ctx, rayJob))
+	runtimeEnvSecret := &corev1.Secret{}
+	assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: rayJob.Namespace, Name: "test-rayjob-runtime-env"}, runtimeEnvSecret))
+	assert.Equal(t, `{"env_vars":{"LEVEL":"info","TOKEN":"secret-token","USER":"configmap-user"},"working_dir":"./"}`,
+		string(runtimeEnvSecret.Data[utils.RayJobRuntimeEnvSecretKey]))
+
+	secret.Data["TOKEN"] = []byte("rotated-token")
+	assert.NoError(t, fakeClient.Update(ctx, secret))
+	assert.NoError(t, r.createOrUpdateRuntimeEnvSecretIfNeed(ctx, rayJob))
+	assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: rayJob.Namespace, Name: "test-rayjob-runtime-env"}, runtimeEnvSecret))
+	assert.Contains(t, string(runtimeEnvSecret.Data[utils.RayJobRuntimeEnvSecretKey]), "rotated-token")
+
+	// The Ray job cannot be submitted without its required Secrets.
+	rayJob.Spec.RuntimeEnv.EnvFrom[0].SecretRef.Name = "missing-secret"
+	_, err = r.resolveRuntimeEnvFrom(ctx, rayJob)
+	assert.Error(t, err)
 }
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/json"
@@ -16,15 +17,18 @@ import (
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
+	"github.com/ray-project/kuberay/ray-operator/pkg/tracing"
 
 	cmap "github.com/orcaman/concurrent-map/v2"
 
-	"github.com/go-logr/logr"
 	fmtErrors "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 
@@ -33,7 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -46,6 +50,13 @@ const (
 	ServiceDefaultRequeueDuration   = 2 * time.Second
 	RayClusterDeletionDelayDuration = 60 * time.Second
 	ENABLE_ZERO_DOWNTIME            = "ENABLE_ZERO_DOWNTIME"
+
+	// The defaults of the ClusterUpgradeOptions of the NewClusterWithIncrementalUpgrade upgrade strategy.
+	DefaultTrafficStepSizePercent   = 10
+	DefaultTrafficMigrationInterval = 30 * time.Second
+
+	// ServeProxyHealthCheckConcurrency is the number of Serve proxies of the worker Pods checked at the same time.
+	ServeProxyHealthCheckConcurrency = 10
 )
 
 // RayServiceReconciler reconciles a RayService object
@@ -57,6 +68,9 @@ type RayServiceReconciler struct {
 	// To avoid reapplying the same config repeatedly, cache the config in this map.
 	ServeConfigs                 cmap.ConcurrentMap[string, string]
 	RayClusterDeletionTimestamps cmap.ConcurrentMap[string, time.Time]
+	// ServeUnhealthyTimestamps stores, for each active RayCluster, since when KubeRay has failed to get its Serve
+	// application statuses. It is used to enforce the serviceUnhealthySecondThreshold of the RayService.
+	ServeUnhealthyTimestamps cmap.ConcurrentMap[string, time.Time]
 
 	dashboardClientFunc func() utils.RayDashboardClientInterface
 	httpProxyClientFunc func() utils.RayHttpProxyClientInterface
@@ -72,6 +86,7 @@ func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, provider ut
 		Recorder:                     mgr.GetEventRecorderFor("rayservice-controller"),
 		ServeConfigs:                 cmap.New[string](),
 		RayClusterDeletionTimestamps: cmap.New[time.Time](),
+		ServeUnhealthyTimestamps:     cmap.New[time.Time](),
 
 		dashboardClientFunc: dashboardClientFunc,
 		httpProxyClientFunc: httpProxyClientFunc,
@@ -94,6 +109,7 @@ func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, provider ut
 // +kubebuilder:rbac:groups=core,resources=services/proxy,verbs=get;update;patch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
 // +kubebuilder:rbac:groups=extensions,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
@@ -108,20 +124,32 @@ func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, provider ut
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.2/pkg/reconcile
-func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
 	logger := ctrl.LoggerFrom(ctx)
+	observer := common.ObserveReconcile(common.KindRayService, request.NamespacedName)
+	defer observer.Done(&err)
+	ctx, span := tracing.StartReconcile(ctx, common.KindRayService, request.NamespacedName)
+	defer tracing.End(span, &err)
 
 	isReady := false
 
 	var rayServiceInstance *rayv1.RayService
-	var err error
 	var ctrlResult ctrl.Result
 
 	// Resolve the CR from request.
 	if rayServiceInstance, err = r.getRayServiceInstance(ctx, request); err != nil {
+		if errors.IsNotFound(err) {
+			observer.Deleted()
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 	originalRayServiceInstance := rayServiceInstance.DeepCopy()
+
+	if err := validateRayServiceSpec(rayServiceInstance); err != nil {
+		logger.Error(err, "The RayService spec is invalid")
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.InvalidRayServiceSpec), "%s", err)
+		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+	}
 	r.cleanUpServeConfigCache(ctx, rayServiceInstance)
 
 	// TODO (kevin85421): ObservedGeneration should be used to determine whether to update this CR or not.
@@ -135,10 +163,26 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, client.IgnoreNotFound(err)
 	}
 
+	// Delete the pending RayCluster if its Serve applications are not ready in time, so that a new one is prepared.
+	if pendingRayClusterInstance != nil && isPendingRayClusterReadyTimeoutExceeded(rayServiceInstance, pendingRayClusterInstance) {
+		logger.Info("The pending RayCluster is not ready within pendingClusterReadyTimeoutSeconds, deleting it", "rayCluster", pendingRayClusterInstance.Name)
+		if err := r.Delete(ctx, pendingRayClusterInstance, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+		}
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.PendingRayClusterReadyTimeoutExceeded),
+			"Deleted the pending RayCluster %s because its Serve applications are not ready within %d seconds",
+			pendingRayClusterInstance.Name, *rayServiceInstance.Spec.UpgradeStrategy.PendingClusterReadyTimeoutSeconds)
+		rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{}
+		if errStatus := utils.PatchStatus(ctx, r.Client, rayServiceInstance); errStatus != nil {
+			logger.Error(errStatus, "Fail to update status of RayService after deleting the pending RayCluster", "rayServiceInstance", rayServiceInstance)
+		}
+		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+	}
+
 	// Check if we need to create pending RayCluster.
 	if rayServiceInstance.Status.PendingServiceStatus.RayClusterName != "" && pendingRayClusterInstance == nil {
 		// Update RayService Status since reconcileRayCluster may mark RayCluster restart.
-		if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
+		if errStatus := utils.PatchStatus(ctx, r.Client, rayServiceInstance); errStatus != nil {
 			logger.Error(errStatus, "Fail to update status of RayService after RayCluster changes", "rayServiceInstance", rayServiceInstance)
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
 		}
@@ -155,7 +199,18 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	if activeRayClusterInstance != nil && pendingRayClusterInstance == nil {
 		logger.Info("Reconciling the Serve component. Only the active Ray cluster exists.")
 		rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{}
-		if ctrlResult, isReady, err = r.reconcileServe(ctx, rayServiceInstance, activeRayClusterInstance, true); err != nil {
+		ctrlResult, isReady, err = r.reconcileServe(ctx, rayServiceInstance, activeRayClusterInstance, true)
+		if !isRollbackRequested(rayServiceInstance) && r.shouldReplaceUnhealthyRayCluster(ctx, rayServiceInstance, activeRayClusterInstance.Name) {
+			r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.UnhealthyRayCluster),
+				"The Serve applications of RayCluster %s have been unhealthy for longer than the RayService thresholds, preparing a new RayCluster", activeRayClusterInstance.Name)
+			r.ServeUnhealthyTimestamps.Remove(activeRayClusterInstance.Name)
+			r.markRestartAndAddPendingClusterName(ctx, rayServiceInstance)
+			if errStatus := utils.PatchStatus(ctx, r.Client, rayServiceInstance); errStatus != nil {
+				logger.Error(errStatus, "Fail to update status of RayService after marking the active RayCluster unhealthy", "rayServiceInstance", rayServiceInstance)
+			}
+			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+		}
+		if err != nil {
 			logger.Error(err, "Fail to reconcileServe.")
 			return ctrlResult, nil
 		}
@@ -189,8 +244,9 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	}
 
 	// Get the ready Ray cluster instance for service and ingress update.
+	// During an incremental upgrade, the pending RayCluster is only promoted once it receives all the traffic.
 	var rayClusterInstance *rayv1.RayCluster
-	if pendingRayClusterInstance != nil {
+	if pendingRayClusterInstance != nil && rayServiceInstance.Status.PendingServiceStatus.RayClusterName == "" {
 		rayClusterInstance = pendingRayClusterInstance
 		logger.Info("Reconciling the ingress and service resources " +
 			"on the pending Ray cluster.")
@@ -208,7 +264,11 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 			err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToUpdateService, err)
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
 		}
-		if err := r.labelHeadPodForServeStatus(ctx, rayClusterInstance); err != nil {
+		if err := r.labelHeadPodForServeStatus(ctx, rayClusterInstance, rayServiceInstance.Spec.ExcludeHeadPodFromServeSvc); err != nil {
+			err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToUpdateServingPodLabel, err)
+			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+		}
+		if err := r.labelWorkerPodsForServeStatus(ctx, rayClusterInstance); err != nil {
 			err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToUpdateServingPodLabel, err)
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
 		}
@@ -218,20 +278,26 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 		}
 	}
 
+	if err := r.reconcileHTTPRoute(ctx, rayServiceInstance, activeRayClusterInstance, pendingRayClusterInstance); err != nil {
+		err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToUpdateHTTPRoute, err)
+		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+	}
+
 	if err := r.calculateStatus(ctx, rayServiceInstance); err != nil {
 		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
 	}
+	common.SetRayServiceGauges(rayServiceInstance)
 
 	// Final status update for any CR modification.
 	if r.inconsistentRayServiceStatuses(ctx, originalRayServiceInstance.Status, rayServiceInstance.Status) {
 		rayServiceInstance.Status.LastUpdateTime = &metav1.Time{Time: time.Now()}
-		if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
+		if errStatus := utils.PatchStatus(ctx, r.Client, rayServiceInstance); errStatus != nil {
 			logger.Error(errStatus, "Failed to update RayService status", "rayServiceInstance", rayServiceInstance)
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, errStatus
 		}
 	}
 
-	return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+	return ctrl.Result{RequeueAfter: getHealthCheckPeriod(rayServiceInstance)}, nil
 }
 
 func (r *RayServiceReconciler) calculateStatus(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
@@ -261,6 +327,11 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatus(ctx context.Context,
 		return true
 	}
 
+	if !reflect.DeepEqual(oldStatus.TrafficRoutedPercent, newStatus.TrafficRoutedPercent) {
+		logger.Info("inconsistentRayServiceStatus RayService TrafficRoutedPercent changed")
+		return true
+	}
+
 	if len(oldStatus.Applications) != len(newStatus.Applications) {
 		return true
 	}
@@ -328,11 +399,16 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatuses(ctx context.Contex
 		return true
 	}
 
+	if oldStatus.PreviousRayClusterName != newStatus.PreviousRayClusterName {
+		logger.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService PreviousRayClusterName changed from %s to %s", oldStatus.PreviousRayClusterName, newStatus.PreviousRayClusterName))
+		return true
+	}
+
 	return false
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int) error {
+// SetupWithManager sets up the controller with the Manager. A nil rateLimiter uses the default one of controller-runtime.
+func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int, rateLimiter ratelimiter.RateLimiter) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&rayv1.RayService{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
@@ -344,15 +420,10 @@ func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 		Owns(&networkingv1.Ingress{}).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: reconcileConcurrency,
-			LogConstructor: func(request *reconcile.Request) logr.Logger {
-				logger := ctrl.Log.WithName("controllers").WithName("RayService")
-				if request != nil {
-					logger = logger.WithValues("RayService", request.NamespacedName)
-				}
-				return logger
-			},
+			RateLimiter:             rateLimiter,
+			LogConstructor:          utils.LogConstructor(utils.RayServiceCRD),
 		}).
-		Complete(r)
+		Complete(utils.WithoutShutdownCancel(r))
 }
 
 func (r *RayServiceReconciler) getRayServiceInstance(ctx context.Context, request ctrl.Request) (*rayv1.RayService, error) {
@@ -371,7 +442,7 @@ func (r *RayServiceReconciler) getRayServiceInstance(ctx context.Context, reques
 
 func (r *RayServiceReconciler) updateState(ctx context.Context, rayServiceInstance *rayv1.RayService, status rayv1.ServiceStatus, err error) error {
 	rayServiceInstance.Status.ServiceStatus = status
-	if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
+	if errStatus := utils.PatchStatus(ctx, r.Client, rayServiceInstance); errStatus != nil {
 		return fmtErrors.Errorf("combined error: %v %v", err, errStatus)
 	}
 	r.Recorder.Event(rayServiceInstance, "Normal", string(status), err.Error())
@@ -400,20 +471,35 @@ func (r *RayServiceReconciler) reconcileRayCluster(ctx context.Context, rayServi
 		return nil, nil, err
 	}
 
+	if isRollbackRequested(rayServiceInstance) {
+		if rayServiceInstance.Status.PreviousRayClusterName != "" {
+			previousRayCluster := &rayv1.RayCluster{}
+			if err := r.Get(ctx, common.RayServicePreviousRayClusterNamespacedName(rayServiceInstance), previousRayCluster); err == nil {
+				if err := r.rollbackToPreviousRayCluster(ctx, rayServiceInstance); err != nil {
+					return nil, nil, err
+				}
+				activeRayCluster = previousRayCluster
+			} else if !errors.IsNotFound(err) {
+				return nil, nil, err
+			}
+		}
+		// The pending RayCluster, if any, is deleted as a dangling RayCluster.
+		rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{}
+		logger.Info("A rollback to the previous RayCluster is requested. Skip preparing a new RayCluster.")
+		return activeRayCluster, nil, nil
+	}
+
 	clusterAction := r.shouldPrepareNewRayCluster(ctx, rayServiceInstance, activeRayCluster)
 	if clusterAction == RolloutNew {
 		// For LLM serving, some users might not have sufficient GPU resources to run two RayClusters simultaneously.
-		// Therefore, KubeRay offers ENABLE_ZERO_DOWNTIME as a feature flag for zero-downtime upgrades.
-		enableZeroDowntime := true
-		if s := os.Getenv(ENABLE_ZERO_DOWNTIME); strings.ToLower(s) == "false" {
-			enableZeroDowntime = false
-		}
-		if enableZeroDowntime || !enableZeroDowntime && activeRayCluster == nil {
+		// Therefore, KubeRay offers the None upgrade strategy and ENABLE_ZERO_DOWNTIME as a feature flag to disable
+		// zero-downtime upgrades.
+		if isZeroDowntimeUpgradeEnabled(rayServiceInstance) || activeRayCluster == nil {
 			// Add a pending cluster name. In the next reconcile loop, shouldPrepareNewRayCluster will return DoNothing and we will
 			// actually create the pending RayCluster instance.
 			r.markRestartAndAddPendingClusterName(ctx, rayServiceInstance)
 		} else {
-			logger.Info("Zero-downtime upgrade is disabled (ENABLE_ZERO_DOWNTIME: false). Skip preparing a new RayCluster.")
+			logger.Info("Zero-downtime upgrade is disabled by the None upgrade strategy or ENABLE_ZERO_DOWNTIME: false. Skip preparing a new RayCluster.")
 		}
 		return activeRayCluster, nil, nil
 	} else if clusterAction == Update {
@@ -446,12 +532,22 @@ func (r *RayServiceReconciler) cleanUpRayClusterInstance(ctx context.Context, ra
 	}
 
 	// Clean up RayCluster instances. Each instance is deleted 60 seconds
-	// after becoming inactive to give the ingress time to update.
+	// after becoming inactive to give the ingress time to update. The previous
+	// RayCluster is kept for spec.rollback.keepPreviousClusterSeconds instead.
+	previousRayClusterExists := false
 	for _, rayClusterInstance := range rayClusterList.Items {
+		isPreviousRayCluster := rayClusterInstance.Name == rayServiceInstance.Status.PreviousRayClusterName
+		if isPreviousRayCluster {
+			previousRayClusterExists = true
+		}
 		if rayClusterInstance.Name != rayServiceInstance.Status.ActiveServiceStatus.RayClusterName && rayClusterInstance.Name != rayServiceInstance.Status.PendingServiceStatus.RayClusterName {
 			cachedTimestamp, exists := r.RayClusterDeletionTimestamps.Get(rayClusterInstance.Name)
 			if !exists {
-				deletionTimestamp := metav1.Now().Add(RayClusterDeletionDelayDuration)
+				deletionDelay := RayClusterDeletionDelayDuration
+				if isPreviousRayCluster {
+					deletionDelay = getKeepPreviousClusterDuration(rayServiceInstance)
+				}
+				deletionTimestamp := metav1.Now().Add(deletionDelay)
 				r.RayClusterDeletionTimestamps.Set(rayClusterInstance.Name, deletionTimestamp)
 				logger.Info(fmt.Sprintf("Scheduled dangling RayCluster "+
 					"%s for deletion at %s", rayClusterInstance.Name, deletionTimestamp))
@@ -468,10 +564,17 @@ func (r *RayServiceReconciler) cleanUpRayClusterInstance(ctx context.Context, ra
 					if err := r.Delete(ctx, &rayClusterInstance, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
 						return err
 					}
+					r.ServeUnhealthyTimestamps.Remove(rayClusterInstance.Name)
+					if isPreviousRayCluster {
+						previousRayClusterExists = false
+					}
 				}
 			}
 		}
 	}
+	if !previousRayClusterExists {
+		rayServiceInstance.Status.PreviousRayClusterName = ""
+	}
 
 	return nil
 }
@@ -495,11 +598,13 @@ func (r *RayServiceReconciler) cleanUpServeConfigCache(ctx context.Context, rayS
 	logger := ctrl.LoggerFrom(ctx)
 	activeConfigKey := r.generateConfigKey(rayServiceInstance, rayServiceInstance.Status.ActiveServiceStatus.RayClusterName)
 	pendingConfigKey := r.generateConfigKey(rayServiceInstance, rayServiceInstance.Status.PendingServiceStatus.RayClusterName)
+	// The Serve config of the previous RayCluster is kept so that a rollback does not update its Serve applications.
+	previousConfigKey := r.generateConfigKey(rayServiceInstance, rayServiceInstance.Status.PreviousRayClusterName)
 	configPrefix := r.generateConfigKeyPrefix(rayServiceInstance)
 
 	// Clean up RayCluster serve deployment configs.
 	for key := range r.ServeConfigs.Items() {
-		if key == activeConfigKey || key == pendingConfigKey {
+		if key == activeConfigKey || key == pendingConfigKey || key == previousConfigKey {
 			continue
 		}
 		if !strings.HasPrefix(key, configPrefix) {
@@ -761,6 +866,111 @@ func (r *RayServiceReconciler) constructRayClusterForRayService(ctx context.Cont
 	return rayCluster, nil
 }
 
+// serveConfigV2 is the part of the Serve config V2 that KubeRay validates. The whole config is sent to Ray Serve as is.
+type serveConfigV2 struct {
+	Applications []struct {
+		Name        *string `json:"name"`
+		RoutePrefix *string `json:"route_prefix"`
+		ImportPath  string  `json:"import_path"`
+	} `json:"applications"`
+}
+
+// validateRayServiceSpec checks the Serve applications of serveConfigV2, so that an invalid config is reported on the
+// RayService instead of being rejected by Ray Serve on every RayCluster.
+func validateRayServiceSpec(rayService *rayv1.RayService) error {
+	if threshold := rayService.Spec.ServiceUnhealthySecondThreshold; threshold != nil && *threshold <= 0 {
+		return fmt.Errorf("serviceUnhealthySecondThreshold must be a positive integer, got %d", *threshold)
+	}
+	if threshold := rayService.Spec.DeploymentUnhealthySecondThreshold; threshold != nil && *threshold <= 0 {
+		return fmt.Errorf("deploymentUnhealthySecondThreshold must be a positive integer, got %d", *threshold)
+	}
+	if period := rayService.Spec.HealthCheckPeriodSeconds; period != nil && *period <= 0 {
+		return fmt.Errorf("healthCheckPeriodSeconds must be a positive integer, got %d", *period)
+	}
+	if rollback := rayService.Spec.Rollback; rollback != nil && rollback.KeepPreviousClusterSeconds != nil && *rollback.KeepPreviousClusterSeconds <= 0 {
+		return fmt.Errorf("keepPreviousClusterSeconds must be a positive integer, got %d", *rollback.KeepPreviousClusterSeconds)
+	}
+	if upgradeStrategy := rayService.Spec.UpgradeStrategy; upgradeStrategy != nil {
+		switch upgradeStrategy.Type {
+		case "", rayv1.NewClusterUpgradeType, rayv1.NewClusterWithIncrementalUpgradeType, rayv1.NoneUpgradeType:
+		default:
+			return fmt.Errorf("the upgrade strategy type %s is invalid, valid options are %s, %s and %s",
+				upgradeStrategy.Type, rayv1.NewClusterUpgradeType, rayv1.NewClusterWithIncrementalUpgradeType, rayv1.NoneUpgradeType)
+		}
+		if timeout := upgradeStrategy.PendingClusterReadyTimeoutSeconds; timeout != nil && *timeout <= 0 {
+			return fmt.Errorf("pendingClusterReadyTimeoutSeconds must be a positive integer, got %d", *timeout)
+		}
+		if options := upgradeStrategy.ClusterUpgradeOptions; options != nil {
+			if options.StepSizePercent != nil && (*options.StepSizePercent < 1 || *options.StepSizePercent > 100) {
+				return fmt.Errorf("stepSizePercent must be between 1 and 100, got %d", *options.StepSizePercent)
+			}
+			if options.IntervalSeconds != nil && *options.IntervalSeconds <= 0 {
+				return fmt.Errorf("intervalSeconds must be a positive integer, got %d", *options.IntervalSeconds)
+			}
+		}
+		if upgradeStrategy.Type == rayv1.NewClusterWithIncrementalUpgradeType &&
+			(upgradeStrategy.ClusterUpgradeOptions == nil || upgradeStrategy.ClusterUpgradeOptions.GatewayName == "") {
+			return fmt.Errorf("the %s upgrade strategy requires clusterUpgradeOptions.gatewayName", upgradeStrategy.Type)
+		}
+	}
+
+	if rayService.Spec.ServeConfigV2 == "" {
+		return nil
+	}
+	var serveConfig serveConfigV2
+	if err := yaml.Unmarshal([]byte(rayService.Spec.ServeConfigV2), &serveConfig); err != nil {
+		return fmt.Errorf("serveConfigV2 is not a valid Serve config: %w", err)
+	}
+
+	names := make(map[string]bool)
+	routePrefixes := make(map[string]string)
+	for i, app := range serveConfig.Applications {
+		// Ray Serve names an application without a name "default".
+		name := "default"
+		if app.Name != nil {
+			name = *app.Name
+		}
+		if name == "" {
+			return fmt.Errorf("the name of the application %d in serveConfigV2 must not be empty", i)
+		}
+		if names[name] {
+			return fmt.Errorf("the application name %s in serveConfigV2 is not unique", name)
+		}
+		names[name] = true
+		if app.ImportPath == "" {
+			return fmt.Errorf("the application %s in serveConfigV2 must set import_path", name)
+		}
+		if app.RoutePrefix == nil {
+			continue
+		}
+		if !strings.HasPrefix(*app.RoutePrefix, "/") {
+			return fmt.Errorf("the route_prefix %s of the application %s in serveConfigV2 must start with /", *app.RoutePrefix, name)
+		}
+		if other, ok := routePrefixes[*app.RoutePrefix]; ok {
+			return fmt.Errorf("the applications %s and %s in serveConfigV2 have the same route_prefix %s", other, name, *app.RoutePrefix)
+		}
+		routePrefixes[*app.RoutePrefix] = name
+	}
+	return nil
+}
+
+// isServeConfigV2Equal returns whether two Serve configs V2 are the same once parsed, so that changes to the formatting,
+// comments or key order of serveConfigV2 do not update the Serve applications.
+func isServeConfigV2Equal(a, b string) bool {
+	if a == b {
+		return true
+	}
+	configA := make(map[string]interface{})
+	configB := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(a), &configA); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(b), &configB); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(configA, configB)
+}
+
 func (r *RayServiceReconciler) checkIfNeedSubmitServeDeployment(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, serveStatus *rayv1.RayServiceStatus) bool {
 	logger := ctrl.LoggerFrom(ctx)
 
@@ -799,7 +1009,7 @@ func (r *RayServiceReconciler) checkIfNeedSubmitServeDeployment(ctx context.Cont
 	reason := fmt.Sprintf("Current Serve config matches cached Serve config, "+
 		"and some deployments have been deployed for cluster %s", rayClusterInstance.Name)
 
-	if cachedServeConfigV2 != rayServiceInstance.Spec.ServeConfigV2 {
+	if !isServeConfigV2Equal(cachedServeConfigV2, rayServiceInstance.Spec.ServeConfigV2) {
 		shouldUpdate = true
 		reason = fmt.Sprintf("Current V2 Serve config doesn't match cached Serve config for cluster %s with key %s", rayClusterInstance.Name, cacheKey)
 	}
@@ -931,6 +1141,179 @@ func (r *RayServiceReconciler) generateConfigKeyPrefix(rayServiceInstance *rayv1
 	return rayServiceInstance.Namespace + "/" + rayServiceInstance.Name + "/"
 }
 
+// shouldReplaceUnhealthyRayCluster returns whether the Serve applications of the active RayCluster have been unhealthy
+// for longer than the serviceUnhealthySecondThreshold or deploymentUnhealthySecondThreshold of the RayService.
+func (r *RayServiceReconciler) shouldReplaceUnhealthyRayCluster(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterName string) bool {
+	logger := ctrl.LoggerFrom(ctx)
+
+	if threshold := rayServiceInstance.Spec.ServiceUnhealthySecondThreshold; threshold != nil {
+		if unhealthySince, exists := r.ServeUnhealthyTimestamps.Get(rayClusterName); exists && isUnhealthyForLongerThan(unhealthySince, *threshold) {
+			logger.Info("Failed to get the Serve application statuses for longer than serviceUnhealthySecondThreshold",
+				"rayCluster", rayClusterName, "unhealthySince", unhealthySince, "serviceUnhealthySecondThreshold", *threshold)
+			return true
+		}
+	}
+
+	if threshold := rayServiceInstance.Spec.DeploymentUnhealthySecondThreshold; threshold != nil {
+		for appName, app := range rayServiceInstance.Status.ActiveServiceStatus.Applications {
+			if isServeAppUnhealthyOrDeployedFailed(app.Status) && app.HealthLastUpdateTime != nil &&
+				isUnhealthyForLongerThan(app.HealthLastUpdateTime.Time, *threshold) {
+				logger.Info("The Serve application has been unhealthy for longer than deploymentUnhealthySecondThreshold",
+					"rayCluster", rayClusterName, "appName", appName, "status", app.Status, "deploymentUnhealthySecondThreshold", *threshold)
+				return true
+			}
+			for deploymentName, deployment := range app.Deployments {
+				if deployment.Status == rayv1.DeploymentStatusEnum.UNHEALTHY && deployment.HealthLastUpdateTime != nil &&
+					isUnhealthyForLongerThan(deployment.HealthLastUpdateTime.Time, *threshold) {
+					logger.Info("The Serve deployment has been unhealthy for longer than deploymentUnhealthySecondThreshold",
+						"rayCluster", rayClusterName, "appName", appName, "deploymentName", deploymentName, "deploymentUnhealthySecondThreshold", *threshold)
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// migrateTraffic shifts StepSizePercent of the traffic from the active RayCluster to the ready pending RayCluster
+// every IntervalSeconds, and promotes the pending RayCluster once it receives all the traffic. The HTTPRoute of the
+// RayService is updated from TrafficRoutedPercent by reconcileHTTPRoute.
+func (r *RayServiceReconciler) migrateTraffic(ctx context.Context, rayServiceInstance *rayv1.RayService) {
+	logger := ctrl.LoggerFrom(ctx)
+	activeServiceStatus := &rayServiceInstance.Status.ActiveServiceStatus
+	pendingServiceStatus := &rayServiceInstance.Status.PendingServiceStatus
+	stepSizePercent, interval := getClusterUpgradeOptions(rayServiceInstance)
+
+	if lastMigratedTime := pendingServiceStatus.LastTrafficMigratedTime; lastMigratedTime != nil && time.Since(lastMigratedTime.Time) < interval {
+		return
+	}
+
+	pendingPercent := min(ptr.Deref(pendingServiceStatus.TrafficRoutedPercent, 0)+stepSizePercent, 100)
+	now := metav1.Now()
+	pendingServiceStatus.TrafficRoutedPercent = ptr.To(pendingPercent)
+	pendingServiceStatus.LastTrafficMigratedTime = &now
+	activeServiceStatus.TrafficRoutedPercent = ptr.To(100 - pendingPercent)
+	activeServiceStatus.LastTrafficMigratedTime = &now
+	logger.Info("Shifted traffic to the pending RayCluster", "activeRayCluster", activeServiceStatus.RayClusterName,
+		"pendingRayCluster", pendingServiceStatus.RayClusterName, "pendingTrafficRoutedPercent", pendingPercent)
+	r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.MigratedTraffic),
+		"Routed %d%% of the traffic to the pending RayCluster %s", pendingPercent, pendingServiceStatus.RayClusterName)
+
+	if pendingPercent == 100 {
+		r.updateRayClusterInfo(ctx, rayServiceInstance, pendingServiceStatus.RayClusterName)
+	}
+}
+
+// reconcileHTTPRoute creates the Serve services of the active and pending RayClusters, and the HTTPRoute splitting the
+// traffic of the RayService between them according to their TrafficRoutedPercent, for the
+// NewClusterWithIncrementalUpgrade upgrade strategy. The Serve services are deleted with their RayClusters.
+func (r *RayServiceReconciler) reconcileHTTPRoute(ctx context.Context, rayServiceInstance *rayv1.RayService, activeRayCluster, pendingRayCluster *rayv1.RayCluster) error {
+	if !isIncrementalUpgradeEnabled(rayServiceInstance) {
+		return nil
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	// The pending RayCluster may have been promoted in this reconciliation, so the RayClusters are looked up by the
+	// names in the status.
+	rayClusters := map[string]*rayv1.RayCluster{}
+	for _, rayCluster := range []*rayv1.RayCluster{activeRayCluster, pendingRayCluster} {
+		if rayCluster != nil {
+			rayClusters[rayCluster.Name] = rayCluster
+		}
+	}
+	pendingPercent := int32(0)
+	if rayServiceInstance.Status.PendingServiceStatus.RayClusterName != "" {
+		pendingPercent = ptr.Deref(rayServiceInstance.Status.PendingServiceStatus.TrafficRoutedPercent, 0)
+	}
+
+	backends := []common.HTTPRouteBackend{}
+	for _, backend := range []struct {
+		rayClusterName string
+		weight         int32
+	}{
+		{rayServiceInstance.Status.ActiveServiceStatus.RayClusterName, 100 - pendingPercent},
+		{rayServiceInstance.Status.PendingServiceStatus.RayClusterName, pendingPercent},
+	} {
+		rayCluster, exists := rayClusters[backend.rayClusterName]
+		if !exists {
+			continue
+		}
+		serveService, err := r.createServeServiceForRayCluster(ctx, rayServiceInstance, rayCluster)
+		if err != nil {
+			return err
+		}
+		if backend.rayClusterName == rayServiceInstance.Status.PendingServiceStatus.RayClusterName {
+			// Only the head Pod of the RayCluster serving the Serve service of the RayService is labeled otherwise.
+			if err := r.labelHeadPodForServeStatus(ctx, rayCluster, rayServiceInstance.Spec.ExcludeHeadPodFromServeSvc); err != nil {
+				return err
+			}
+			if err := r.labelWorkerPodsForServeStatus(ctx, rayCluster); err != nil {
+				return err
+			}
+		}
+		backends = append(backends, common.HTTPRouteBackend{
+			ServiceName: serveService.Name,
+			Port:        serveService.Spec.Ports[0].Port,
+			Weight:      backend.weight,
+		})
+	}
+	if len(backends) == 0 {
+		return nil
+	}
+
+	route := common.BuildHTTPRouteForRayService(rayServiceInstance, backends)
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(common.HTTPRouteGVK)
+	err := r.Get(ctx, client.ObjectKey{Namespace: route.GetNamespace(), Name: route.GetName()}, existing)
+	if errors.IsNotFound(err) {
+		if err := ctrl.SetControllerReference(rayServiceInstance, route, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, route); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+		logger.Info("Created the HTTPRoute of the RayService", "HTTPRoute", route.GetName())
+		return nil
+	} else if err != nil {
+		return err
+	}
+	// The API server defaults fields of the spec that KubeRay does not set, so only the fields set by KubeRay are compared.
+	existingGatewayName, existingBackends := common.HTTPRouteOwnedFields(existing)
+	desiredGatewayName, desiredBackends := common.HTTPRouteOwnedFields(route)
+	if existingGatewayName == desiredGatewayName && equality.Semantic.DeepEqual(existingBackends, desiredBackends) {
+		return nil
+	}
+	existing.Object["spec"] = route.Object["spec"]
+	if err := r.Update(ctx, existing); err != nil {
+		return err
+	}
+	logger.Info("Updated the HTTPRoute of the RayService", "HTTPRoute", route.GetName(), "backends", backends)
+	return nil
+}
+
+// createServeServiceForRayCluster creates the Serve service of the RayCluster that the HTTPRoute of the RayService
+// routes to, if it does not exist yet.
+func (r *RayServiceReconciler) createServeServiceForRayCluster(ctx context.Context, rayServiceInstance *rayv1.RayService, rayCluster *rayv1.RayCluster) (*corev1.Service, error) {
+	serveService, err := common.BuildServeServiceForRayServiceCluster(ctx, *rayServiceInstance, *rayCluster)
+	if err != nil {
+		return nil, err
+	}
+	err = r.Get(ctx, client.ObjectKeyFromObject(serveService), &corev1.Service{})
+	if err == nil {
+		return serveService, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err := ctrl.SetControllerReference(rayCluster, serveService, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, serveService); err != nil && !errors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	ctrl.LoggerFrom(ctx).Info("Created the Serve service of the RayCluster", "Service", serveService.Name, "RayCluster", rayCluster.Name)
+	return serveService, nil
+}
+
 func (r *RayServiceReconciler) markRestartAndAddPendingClusterName(ctx context.Context, rayServiceInstance *rayv1.RayService) {
 	logger := ctrl.LoggerFrom(ctx)
 
@@ -946,11 +1329,31 @@ func (r *RayServiceReconciler) updateRayClusterInfo(ctx context.Context, rayServ
 	logger := ctrl.LoggerFrom(ctx)
 	logger.Info("updateRayClusterInfo", "ActiveRayClusterName", rayServiceInstance.Status.ActiveServiceStatus.RayClusterName, "healthyClusterName", healthyClusterName)
 	if rayServiceInstance.Status.ActiveServiceStatus.RayClusterName != healthyClusterName {
+		// The RayCluster that was active is kept for spec.rollback.keepPreviousClusterSeconds.
+		rayServiceInstance.Status.PreviousRayClusterName = rayServiceInstance.Status.ActiveServiceStatus.RayClusterName
 		rayServiceInstance.Status.ActiveServiceStatus = rayServiceInstance.Status.PendingServiceStatus
 		rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{}
 	}
 }
 
+// rollbackToPreviousRayCluster makes the previous RayCluster active again. The RayCluster rolled back from is not kept
+// as the previous RayCluster, so that the rollback is not rolled back in the next reconciliation.
+func (r *RayServiceReconciler) rollbackToPreviousRayCluster(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
+	logger := ctrl.LoggerFrom(ctx)
+	previousRayClusterName := rayServiceInstance.Status.PreviousRayClusterName
+	activeRayClusterName := rayServiceInstance.Status.ActiveServiceStatus.RayClusterName
+
+	// The deletion of the previous RayCluster was scheduled once it became inactive.
+	r.RayClusterDeletionTimestamps.Remove(previousRayClusterName)
+	rayServiceInstance.Status.ActiveServiceStatus = rayv1.RayServiceStatus{RayClusterName: previousRayClusterName}
+	rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{}
+	rayServiceInstance.Status.PreviousRayClusterName = ""
+	logger.Info("Rolled back to the previous RayCluster", "fromRayCluster", activeRayClusterName, "toRayCluster", previousRayClusterName)
+	r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.RolledBackRayCluster),
+		"Rolled back from RayCluster %s to the previous RayCluster %s", activeRayClusterName, previousRayClusterName)
+	return utils.PatchStatus(ctx, r.Client, rayServiceInstance)
+}
+
 func (r *RayServiceReconciler) reconcileServices(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, serviceType utils.ServiceType) error {
 	logger := ctrl.LoggerFrom(ctx)
 	logger.Info(
@@ -1055,6 +1458,8 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 	// Pick up service status to be updated.
 	if isActive {
 		rayServiceStatus = &rayServiceInstance.Status.ActiveServiceStatus
+		// The timestamp is removed below once the Serve application statuses are fetched from the dashboard.
+		r.ServeUnhealthyTimestamps.SetIfAbsent(rayClusterInstance.Name, time.Now())
 	} else {
 		rayServiceStatus = &rayServiceInstance.Status.PendingServiceStatus
 	}
@@ -1096,6 +1501,10 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 	}
 
 	shouldUpdate := r.checkIfNeedSubmitServeDeployment(ctx, rayServiceInstance, rayClusterInstance, rayServiceStatus)
+	if shouldUpdate && isRollbackRequested(rayServiceInstance) && r.ServeConfigs.Has(r.generateConfigKey(rayServiceInstance, rayClusterInstance.Name)) {
+		logger.Info("Skip updating the Serve applications because a rollback to the previous RayCluster is requested", "RayCluster name", rayClusterInstance.Name)
+		shouldUpdate = false
+	}
 	if shouldUpdate {
 		if err = r.updateServeDeployment(ctx, rayServiceInstance, rayDashboardClient, rayClusterInstance.Name); err != nil {
 			err = r.updateState(ctx, rayServiceInstance, rayv1.WaitForServeDeploymentReady, err)
@@ -1111,16 +1520,21 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 		err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToGetServeDeploymentStatus, err)
 		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, err
 	}
+	r.ServeUnhealthyTimestamps.Remove(rayClusterInstance.Name)
 
 	logger.Info("Check serve health", "isReady", isReady, "isActive", isActive)
 
 	if isReady {
 		rayServiceInstance.Status.ServiceStatus = rayv1.Running
-		r.updateRayClusterInfo(ctx, rayServiceInstance, rayClusterInstance.Name)
+		if isActive || rayServiceInstance.Status.ActiveServiceStatus.RayClusterName == "" || !isIncrementalUpgradeEnabled(rayServiceInstance) {
+			r.updateRayClusterInfo(ctx, rayServiceInstance, rayClusterInstance.Name)
+		} else {
+			r.migrateTraffic(ctx, rayServiceInstance)
+		}
 		r.Recorder.Event(rayServiceInstance, "Normal", "Running", "The Serve application is now running and healthy.")
 	} else {
 		rayServiceInstance.Status.ServiceStatus = rayv1.WaitForServeDeploymentReady
-		if err := r.Status().Update(ctx, rayServiceInstance); err != nil {
+		if err := utils.PatchStatus(ctx, r.Client, rayServiceInstance); err != nil {
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, err
 		}
 		logger.Info("Mark cluster as waiting for Serve deployments", "rayCluster", rayClusterInstance)
@@ -1129,7 +1543,9 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 	return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, isReady, nil
 }
 
-func (r *RayServiceReconciler) labelHeadPodForServeStatus(ctx context.Context, rayClusterInstance *rayv1.RayCluster) error {
+// labelHeadPodForServeStatus sets the `ray.io/serve` label of the head Pod, which the Serve service selects, from the
+// health of its Serve proxy. The label is always false if the head Pod is excluded from the Serve service.
+func (r *RayServiceReconciler) labelHeadPodForServeStatus(ctx context.Context, rayClusterInstance *rayv1.RayCluster, excludeHeadPodFromServeSvc bool) error {
 	headPod, err := common.GetRayClusterHeadPod(ctx, r, rayClusterInstance)
 	if err != nil {
 		return err
@@ -1145,26 +1561,93 @@ func (r *RayServiceReconciler) labelHeadPodForServeStatus(ctx context.Context, r
 	servingPort := utils.FindContainerPort(&rayContainer, utils.ServingPortName, utils.DefaultServingPort)
 	httpProxyClient.SetHostIp(headPod.Status.PodIP, headPod.Namespace, headPod.Name, servingPort)
 
+	// Keep the original Pod to compare its labels later, and to patch only the label, as the cached Pod is stripped
+	// of the fields that KubeRay does not read.
+	originalHeadPod := headPod.DeepCopy()
 	if headPod.Labels == nil {
 		headPod.Labels = make(map[string]string)
 	}
 
-	// Make a copy of the labels for comparison later, to decide whether we need to push an update.
-	originalLabels := make(map[string]string, len(headPod.Labels))
-	for key, value := range headPod.Labels {
-		originalLabels[key] = value
-	}
-
-	if err = httpProxyClient.CheckProxyActorHealth(ctx); err == nil {
+	if excludeHeadPodFromServeSvc {
+		headPod.Labels[utils.RayClusterServingServiceLabelKey] = utils.EnableRayClusterServingServiceFalse
+	} else if err = httpProxyClient.CheckProxyActorHealth(ctx); err == nil {
 		headPod.Labels[utils.RayClusterServingServiceLabelKey] = utils.EnableRayClusterServingServiceTrue
 	} else {
 		headPod.Labels[utils.RayClusterServingServiceLabelKey] = utils.EnableRayClusterServingServiceFalse
 	}
 
-	if !reflect.DeepEqual(originalLabels, headPod.Labels) {
-		if updateErr := r.Update(ctx, headPod); updateErr != nil {
-			return updateErr
+	if !reflect.DeepEqual(originalHeadPod.Labels, headPod.Labels) {
+		if patchErr := r.Patch(ctx, headPod, client.MergeFrom(originalHeadPod)); patchErr != nil {
+			return patchErr
+		}
+	}
+
+	return nil
+}
+
+// labelWorkerPodsForServeStatus sets the `ray.io/serve` label of the worker Pods, which the Serve service selects, from
+// the health of their Serve proxy. The worker Pods are created with the label set to true, as their readiness probe
+// already checks their Serve proxy, and the label is only set to false for the Pods whose Serve proxy is unhealthy, so
+// that a worker Pod keeps serving the traffic until a check says otherwise. The worker Pods that are being drained or
+// deleted are removed from the Serve service before their Serve proxy stops. The Serve proxies are checked
+// concurrently, as each check may wait for the timeout of the client.
+func (r *RayServiceReconciler) labelWorkerPodsForServeStatus(ctx context.Context, rayClusterInstance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	workerPods := corev1.PodList{}
+	filterLabels := common.RayClusterWorkerPodsAssociationOptions(rayClusterInstance)
+	if err := r.List(ctx, &workerPods, filterLabels.ToCachedPodListOptions()...); err != nil {
+		return err
+	}
+
+	serveLabels := make([]string, len(workerPods.Items))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, ServeProxyHealthCheckConcurrency)
+	for i := range workerPods.Items {
+		workerPod := &workerPods.Items[i]
+		serveLabels[i] = workerPod.Labels[utils.RayClusterServingServiceLabelKey]
+		if workerPod.DeletionTimestamp != nil || workerPod.Annotations[utils.RayDrainDeadlineAnnotationKey] != "" {
+			serveLabels[i] = utils.EnableRayClusterServingServiceFalse
+			continue
+		}
+		// The Pods without an IP are not endpoints of the Serve service regardless of the label.
+		if workerPod.Status.PodIP == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			rayContainer := workerPod.Spec.Containers[utils.RayContainerIndex]
+			servingPort := utils.FindContainerPort(&rayContainer, utils.ServingPortName, utils.DefaultServingPort)
+			httpProxyClient := r.httpProxyClientFunc()
+			httpProxyClient.InitClient()
+			httpProxyClient.SetHostIp(workerPod.Status.PodIP, workerPod.Namespace, workerPod.Name, servingPort)
+			serveLabels[i] = utils.EnableRayClusterServingServiceTrue
+			if err := httpProxyClient.CheckProxyActorHealth(ctx); err != nil {
+				serveLabels[i] = utils.EnableRayClusterServingServiceFalse
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range workerPods.Items {
+		workerPod := &workerPods.Items[i]
+		if serveLabels[i] == "" || workerPod.Labels[utils.RayClusterServingServiceLabelKey] == serveLabels[i] {
+			continue
+		}
+
+		originalWorkerPod := workerPod.DeepCopy()
+		if workerPod.Labels == nil {
+			workerPod.Labels = make(map[string]string)
+		}
+		workerPod.Labels[utils.RayClusterServingServiceLabelKey] = serveLabels[i]
+		if err := r.Patch(ctx, workerPod, client.MergeFrom(originalWorkerPod)); err != nil {
+			return err
 		}
+		logger.Info("Updated the serve label of the worker Pod", "Pod", workerPod.Name, "serveLabel", serveLabels[i])
 	}
 
 	return nil
@@ -1246,3 +1729,81 @@ func (r *RayServiceReconciler) isHeadPodRunningAndReady(ctx context.Context, ins
 func isServeAppUnhealthyOrDeployedFailed(appStatus string) bool {
 	return appStatus == rayv1.ApplicationStatusEnum.UNHEALTHY || appStatus == rayv1.ApplicationStatusEnum.DEPLOY_FAILED
 }
+
+func isUnhealthyForLongerThan(unhealthySince time.Time, thresholdSeconds int32) bool {
+	return time.Since(unhealthySince) > time.Duration(thresholdSeconds)*time.Second
+}
+
+// getHealthCheckPeriod returns how often the Serve applications of the RayService are checked once they are running.
+func getHealthCheckPeriod(rayServiceInstance *rayv1.RayService) time.Duration {
+	if rayServiceInstance.Spec.HealthCheckPeriodSeconds != nil {
+		return time.Duration(*rayServiceInstance.Spec.HealthCheckPeriodSeconds) * time.Second
+	}
+	return ServiceDefaultRequeueDuration
+}
+
+// isZeroDowntimeUpgradeEnabled returns whether a new RayCluster is prepared when a change of the RayCluster spec of
+// the RayService cannot be applied in place. The upgrade strategy of the RayService takes precedence over
+// ENABLE_ZERO_DOWNTIME.
+func isZeroDowntimeUpgradeEnabled(rayServiceInstance *rayv1.RayService) bool {
+	if upgradeStrategy := rayServiceInstance.Spec.UpgradeStrategy; upgradeStrategy != nil && upgradeStrategy.Type != "" {
+		return upgradeStrategy.Type != rayv1.NoneUpgradeType
+	}
+	return strings.ToLower(os.Getenv(ENABLE_ZERO_DOWNTIME)) != "false"
+}
+
+// isPendingRayClusterReadyTimeoutExceeded returns whether the pending RayCluster was created longer ago than the
+// pendingClusterReadyTimeoutSeconds of the RayService. A pending RayCluster that already receives a part of the
+// traffic of an incremental upgrade has been ready, so it does not time out.
+func isPendingRayClusterReadyTimeoutExceeded(rayServiceInstance *rayv1.RayService, pendingRayCluster *rayv1.RayCluster) bool {
+	upgradeStrategy := rayServiceInstance.Spec.UpgradeStrategy
+	if upgradeStrategy == nil || upgradeStrategy.PendingClusterReadyTimeoutSeconds == nil || pendingRayCluster.CreationTimestamp.IsZero() {
+		return false
+	}
+	if rayServiceInstance.Status.PendingServiceStatus.TrafficRoutedPercent != nil {
+		return false
+	}
+	timeout := time.Duration(*upgradeStrategy.PendingClusterReadyTimeoutSeconds) * time.Second
+	return time.Since(pendingRayCluster.CreationTimestamp.Time) > timeout
+}
+
+// isIncrementalUpgradeEnabled returns whether the traffic of the RayService is shifted gradually to its pending
+// RayCluster through a Gateway API HTTPRoute.
+func isIncrementalUpgradeEnabled(rayServiceInstance *rayv1.RayService) bool {
+	upgradeStrategy := rayServiceInstance.Spec.UpgradeStrategy
+	return upgradeStrategy != nil && upgradeStrategy.Type == rayv1.NewClusterWithIncrementalUpgradeType
+}
+
+// getClusterUpgradeOptions returns the percentage of the traffic shifted at each step of an incremental upgrade of
+// the RayService, and the duration between two steps.
+func getClusterUpgradeOptions(rayServiceInstance *rayv1.RayService) (int32, time.Duration) {
+	stepSizePercent := int32(DefaultTrafficStepSizePercent)
+	interval := DefaultTrafficMigrationInterval
+	if upgradeStrategy := rayServiceInstance.Spec.UpgradeStrategy; upgradeStrategy != nil && upgradeStrategy.ClusterUpgradeOptions != nil {
+		if options := upgradeStrategy.ClusterUpgradeOptions; options.StepSizePercent != nil {
+			stepSizePercent = *options.StepSizePercent
+		}
+		if options := upgradeStrategy.ClusterUpgradeOptions; options.IntervalSeconds != nil {
+			interval = time.Duration(*options.IntervalSeconds) * time.Second
+		}
+	}
+	return stepSizePercent, interval
+}
+
+// isRollbackRequested returns whether the RayService is rolled back to its previous RayCluster, with
+// spec.rollback.toPreviousCluster or the ray.io/rollback annotation.
+func isRollbackRequested(rayServiceInstance *rayv1.RayService) bool {
+	if rollback := rayServiceInstance.Spec.Rollback; rollback != nil && rollback.ToPreviousCluster {
+		return true
+	}
+	return strings.ToLower(rayServiceInstance.Annotations[utils.RayServiceRollbackAnnotationKey]) == "true"
+}
+
+// getKeepPreviousClusterDuration returns how long the previous RayCluster of the RayService is kept after the
+// promotion of the active RayCluster.
+func getKeepPreviousClusterDuration(rayServiceInstance *rayv1.RayService) time.Duration {
+	if rollback := rayServiceInstance.Spec.Rollback; rollback != nil && rollback.KeepPreviousClusterSeconds != nil {
+		return time.Duration(*rollback.KeepPreviousClusterSeconds) * time.Second
+	}
+	return RayClusterDeletionDelayDuration
+}
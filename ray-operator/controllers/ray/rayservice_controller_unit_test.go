@@ -13,13 +13,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/scheme"
 )
@@ -290,7 +291,7 @@ func TestIsHeadPodRunningAndReady(t *testing.T) {
 
 	// Initialize a fake client with newScheme and runtimeObjects.
 	runtimeObjects := []runtime.Object{}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	ctx := context.TODO()
 
 	// Initialize RayService reconciler.
@@ -309,7 +310,7 @@ func TestIsHeadPodRunningAndReady(t *testing.T) {
 	// Test 2: There is one head pod, but the pod is not running and ready.
 	// `isHeadPodRunningAndReady` should return false, and no error should be returned.
 	runtimeObjects = []runtime.Object{headPod}
-	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient = newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	r.Client = fakeClient
 	isReady, err = r.isHeadPodRunningAndReady(ctx, &cluster)
 	assert.Nil(t, err)
@@ -328,7 +329,7 @@ func TestIsHeadPodRunningAndReady(t *testing.T) {
 		},
 	}
 	runtimeObjects = []runtime.Object{runningHeadPod}
-	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient = newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	r.Client = fakeClient
 	isReady, err = r.isHeadPodRunningAndReady(ctx, &cluster)
 	assert.Nil(t, err)
@@ -372,7 +373,7 @@ func TestReconcileServices_UpdateService(t *testing.T) {
 
 	// Initialize a fake client with newScheme and runtimeObjects.
 	runtimeObjects := []runtime.Object{}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 
 	// Initialize RayCluster reconciler.
 	r := &RayServiceReconciler{
@@ -455,7 +456,7 @@ func TestFetchHeadServiceURL(t *testing.T) {
 
 	// Initialize a fake client with newScheme and runtimeObjects.
 	runtimeObjects := []runtime.Object{&headSvc}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 
 	// Initialize RayService reconciler.
 	ctx := context.TODO()
@@ -478,7 +479,7 @@ func TestGetAndCheckServeStatus(t *testing.T) {
 
 	// Initialize a fake client with newScheme and runtimeObjects.
 	runtimeObjects := []runtime.Object{}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 
 	// Initialize RayService reconciler.
 	ctx := context.TODO()
@@ -630,7 +631,7 @@ func TestCheckIfNeedSubmitServeDeployment(t *testing.T) {
 
 	// Initialize a fake client with newScheme and runtimeObjects.
 	runtimeObjects := []runtime.Object{}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 
 	// Initialize RayService reconciler.
 	r := RayServiceReconciler{
@@ -707,6 +708,665 @@ applications:
   import_path: fruit.deployment_graph`
 	shouldCreate = r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &serveStatus)
 	assert.True(t, shouldCreate)
+
+	// Test 5: The Serve config has been reformatted, but the Serve applications are the same.
+	// Therefore, the Serve in-place update should not be triggered.
+	r.ServeConfigs.Set(cacheKey, rayService.Spec.ServeConfigV2)
+	rayService.Spec.ServeConfigV2 = `
+# The applications of the RayService.
+applications:
+  - import_path: "fruit.deployment_graph"
+    name: new_app_name
+`
+	shouldCreate = r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &serveStatus)
+	assert.False(t, shouldCreate)
+}
+
+func TestValidateRayServiceSpec(t *testing.T) {
+	tests := map[string]struct {
+		serviceUnhealthySecondThreshold *int32
+		healthCheckPeriodSeconds        *int32
+		upgradeStrategy                 *rayv1.RayServiceUpgradeStrategy
+		rollback                        *rayv1.RayServiceRollback
+		serveConfigV2                   string
+		expectError                     bool
+	}{
+		"valid rollback": {
+			rollback:    &rayv1.RayServiceRollback{KeepPreviousClusterSeconds: ptr.To[int32](3600), ToPreviousCluster: true},
+			expectError: false,
+		},
+		"zero keepPreviousClusterSeconds": {
+			rollback:    &rayv1.RayServiceRollback{KeepPreviousClusterSeconds: ptr.To[int32](0)},
+			expectError: true,
+		},
+		"valid upgrade strategy": {
+			upgradeStrategy: &rayv1.RayServiceUpgradeStrategy{
+				Type:                              rayv1.NewClusterUpgradeType,
+				PendingClusterReadyTimeoutSeconds: ptr.To[int32](600),
+			},
+			expectError: false,
+		},
+		"invalid upgrade strategy type": {
+			upgradeStrategy: &rayv1.RayServiceUpgradeStrategy{
+				Type: rayv1.RayServiceUpgradeType("InPlace"),
+			},
+			expectError: true,
+		},
+		"zero pendingClusterReadyTimeoutSeconds": {
+			upgradeStrategy: &rayv1.RayServiceUpgradeStrategy{
+				PendingClusterReadyTimeoutSeconds: ptr.To[int32](0),
+			},
+			expectError: true,
+		},
+		"positive health check thresholds and period": {
+			serviceUnhealthySecondThreshold: ptr.To[int32](300),
+			healthCheckPeriodSeconds:        ptr.To[int32](10),
+			expectError:                     false,
+		},
+		"zero serviceUnhealthySecondThreshold": {
+			serviceUnhealthySecondThreshold: ptr.To[int32](0),
+			expectError:                     true,
+		},
+		"negative healthCheckPeriodSeconds": {
+			healthCheckPeriodSeconds: ptr.To[int32](-1),
+			expectError:              true,
+		},
+		"empty serveConfigV2": {
+			serveConfigV2: "",
+			expectError:   false,
+		},
+		"valid applications": {
+			serveConfigV2: `
+applications:
+- name: fruit
+  import_path: fruit.deployment_graph
+  route_prefix: /fruit
+- name: math
+  import_path: conditional_dag.serve_dag
+  route_prefix: /calc`,
+			expectError: false,
+		},
+		"invalid YAML": {
+			serveConfigV2: "applications: [",
+			expectError:   true,
+		},
+		"empty application name": {
+			serveConfigV2: `
+applications:
+- name: ""
+  import_path: fruit.deployment_graph`,
+			expectError: true,
+		},
+		"duplicate application names": {
+			serveConfigV2: `
+applications:
+- name: fruit
+  import_path: fruit.deployment_graph
+  route_prefix: /fruit
+- name: fruit
+  import_path: conditional_dag.serve_dag
+  route_prefix: /calc`,
+			expectError: true,
+		},
+		"duplicate default application names": {
+			serveConfigV2: `
+applications:
+- import_path: fruit.deployment_graph
+  route_prefix: /fruit
+- import_path: conditional_dag.serve_dag
+  route_prefix: /calc`,
+			expectError: true,
+		},
+		"missing import_path": {
+			serveConfigV2: `
+applications:
+- name: fruit`,
+			expectError: true,
+		},
+		"duplicate route prefixes": {
+			serveConfigV2: `
+applications:
+- name: fruit
+  import_path: fruit.deployment_graph
+  route_prefix: /
+- name: math
+  import_path: conditional_dag.serve_dag
+  route_prefix: /`,
+			expectError: true,
+		},
+		"route prefix without leading slash": {
+			serveConfigV2: `
+applications:
+- name: fruit
+  import_path: fruit.deployment_graph
+  route_prefix: fruit`,
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rayService := &rayv1.RayService{
+				Spec: rayv1.RayServiceSpec{
+					ServiceUnhealthySecondThreshold: tc.serviceUnhealthySecondThreshold,
+					HealthCheckPeriodSeconds:        tc.healthCheckPeriodSeconds,
+					UpgradeStrategy:                 tc.upgradeStrategy,
+					Rollback:                        tc.rollback,
+					ServeConfigV2:                   tc.serveConfigV2,
+				},
+			}
+			err := validateRayServiceSpec(rayService)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIsZeroDowntimeUpgradeEnabled(t *testing.T) {
+	tests := map[string]struct {
+		upgradeStrategy    *rayv1.RayServiceUpgradeStrategy
+		enableZeroDowntime string
+		expected           bool
+	}{
+		"no upgrade strategy and no environment variable": {
+			expected: true,
+		},
+		"no upgrade strategy and zero-downtime upgrades disabled by the environment variable": {
+			enableZeroDowntime: "false",
+			expected:           false,
+		},
+		"upgrade strategy without type and zero-downtime upgrades disabled by the environment variable": {
+			upgradeStrategy:    &rayv1.RayServiceUpgradeStrategy{PendingClusterReadyTimeoutSeconds: ptr.To[int32](600)},
+			enableZeroDowntime: "false",
+			expected:           false,
+		},
+		"NewCluster upgrade strategy overrides the environment variable": {
+			upgradeStrategy:    &rayv1.RayServiceUpgradeStrategy{Type: rayv1.NewClusterUpgradeType},
+			enableZeroDowntime: "false",
+			expected:           true,
+		},
+		"None upgrade strategy overrides the environment variable": {
+			upgradeStrategy:    &rayv1.RayServiceUpgradeStrategy{Type: rayv1.NoneUpgradeType},
+			enableZeroDowntime: "true",
+			expected:           false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(ENABLE_ZERO_DOWNTIME, tc.enableZeroDowntime)
+			rayService := &rayv1.RayService{
+				Spec: rayv1.RayServiceSpec{
+					UpgradeStrategy: tc.upgradeStrategy,
+				},
+			}
+			assert.Equal(t, tc.expected, isZeroDowntimeUpgradeEnabled(rayService))
+		})
+	}
+}
+
+func TestIsPendingRayClusterReadyTimeoutExceeded(t *testing.T) {
+	tests := map[string]struct {
+		upgradeStrategy   *rayv1.RayServiceUpgradeStrategy
+		creationTimestamp time.Time
+		expected          bool
+	}{
+		"no upgrade strategy": {
+			creationTimestamp: time.Now().Add(-time.Hour),
+			expected:          false,
+		},
+		"no timeout": {
+			upgradeStrategy:   &rayv1.RayServiceUpgradeStrategy{Type: rayv1.NewClusterUpgradeType},
+			creationTimestamp: time.Now().Add(-time.Hour),
+			expected:          false,
+		},
+		"pending RayCluster created within the timeout": {
+			upgradeStrategy:   &rayv1.RayServiceUpgradeStrategy{PendingClusterReadyTimeoutSeconds: ptr.To[int32](600)},
+			creationTimestamp: time.Now().Add(-time.Minute),
+			expected:          false,
+		},
+		"pending RayCluster created before the timeout": {
+			upgradeStrategy:   &rayv1.RayServiceUpgradeStrategy{PendingClusterReadyTimeoutSeconds: ptr.To[int32](600)},
+			creationTimestamp: time.Now().Add(-time.Hour),
+			expected:          true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rayService := &rayv1.RayService{
+				Spec: rayv1.RayServiceSpec{
+					UpgradeStrategy: tc.upgradeStrategy,
+				},
+			}
+			pendingRayCluster := &rayv1.RayCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pending-cluster",
+					CreationTimestamp: metav1.NewTime(tc.creationTimestamp),
+				},
+			}
+			assert.Equal(t, tc.expected, isPendingRayClusterReadyTimeoutExceeded(rayService, pendingRayCluster))
+		})
+	}
+}
+
+func TestLabelHeadPodForServeStatus(t *testing.T) {
+	namespace := "ray"
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: namespace},
+	}
+
+	tests := map[string]struct {
+		excludeHeadPodFromServeSvc bool
+		expectedServeLabel         string
+	}{
+		"The head Pod with a healthy Serve proxy serves the traffic": {
+			excludeHeadPodFromServeSvc: false,
+			expectedServeLabel:         utils.EnableRayClusterServingServiceTrue,
+		},
+		"The head Pod excluded from the Serve service never serves the traffic": {
+			excludeHeadPodFromServeSvc: true,
+			expectedServeLabel:         utils.EnableRayClusterServingServiceFalse,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			headPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "head-pod",
+					Namespace: namespace,
+					Labels: map[string]string{
+						utils.RayClusterLabelKey:               rayCluster.Name,
+						utils.RayNodeTypeLabelKey:              string(rayv1.HeadNode),
+						utils.RayClusterServingServiceLabelKey: utils.EnableRayClusterServingServiceFalse,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "ray-head"}},
+				},
+			}
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(headPod).Build()
+			r := &RayServiceReconciler{
+				Client:              fakeClient,
+				httpProxyClientFunc: func() utils.RayHttpProxyClientInterface { return &utils.FakeRayHttpProxyClient{} },
+			}
+
+			ctx := context.Background()
+			err := r.labelHeadPodForServeStatus(ctx, rayCluster, tc.excludeHeadPodFromServeSvc)
+			assert.Nil(t, err)
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(headPod), headPod)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedServeLabel, headPod.Labels[utils.RayClusterServingServiceLabelKey])
+		})
+	}
+}
+
+func TestLabelWorkerPodsForServeStatus(t *testing.T) {
+	namespace := "ray"
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: namespace},
+	}
+
+	tests := map[string]struct {
+		podIP              string
+		annotations        map[string]string
+		serveLabel         string
+		expectedServeLabel string
+		unhealthyProxy     bool
+	}{
+		"The worker Pod whose Serve proxy recovered serves the traffic again": {
+			podIP:              "10.0.0.2",
+			serveLabel:         utils.EnableRayClusterServingServiceFalse,
+			expectedServeLabel: utils.EnableRayClusterServingServiceTrue,
+		},
+		"The worker Pod with an unhealthy Serve proxy stops serving the traffic": {
+			podIP:              "10.0.0.2",
+			serveLabel:         utils.EnableRayClusterServingServiceTrue,
+			expectedServeLabel: utils.EnableRayClusterServingServiceFalse,
+			unhealthyProxy:     true,
+		},
+		"The worker Pod without an IP keeps its label": {
+			podIP:              "",
+			serveLabel:         utils.EnableRayClusterServingServiceTrue,
+			expectedServeLabel: utils.EnableRayClusterServingServiceTrue,
+		},
+		"The worker Pod being drained stops serving the traffic": {
+			podIP:              "10.0.0.2",
+			annotations:        map[string]string{utils.RayDrainDeadlineAnnotationKey: time.Now().Add(time.Minute).Format(time.RFC3339)},
+			serveLabel:         utils.EnableRayClusterServingServiceTrue,
+			expectedServeLabel: utils.EnableRayClusterServingServiceFalse,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			workerPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "worker-pod",
+					Namespace: namespace,
+					Labels: map[string]string{
+						utils.RayClusterLabelKey:               rayCluster.Name,
+						utils.RayNodeTypeLabelKey:              string(rayv1.WorkerNode),
+						utils.RayClusterServingServiceLabelKey: tc.serveLabel,
+					},
+					Annotations: tc.annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "ray-worker"}},
+				},
+				Status: corev1.PodStatus{PodIP: tc.podIP},
+			}
+			fakeClient := newFakeClientBuilder().WithRuntimeObjects(workerPod).Build()
+			r := &RayServiceReconciler{
+				Client: fakeClient,
+				httpProxyClientFunc: func() utils.RayHttpProxyClientInterface {
+					if tc.unhealthyProxy {
+						return &unhealthyHttpProxyClient{}
+					}
+					return &utils.FakeRayHttpProxyClient{}
+				},
+			}
+
+			ctx := context.Background()
+			err := r.labelWorkerPodsForServeStatus(ctx, rayCluster)
+			assert.Nil(t, err)
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(workerPod), workerPod)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedServeLabel, workerPod.Labels[utils.RayClusterServingServiceLabelKey])
+		})
+	}
+}
+
+// unhealthyHttpProxyClient is a fake client of the Serve proxies that are all unhealthy.
+type unhealthyHttpProxyClient struct {
+	utils.FakeRayHttpProxyClient
+}
+
+func (r *unhealthyHttpProxyClient) CheckProxyActorHealth(_ context.Context) error {
+	return fmt.Errorf("the Serve proxy is unhealthy")
+}
+
+func TestMigrateTraffic(t *testing.T) {
+	recently := metav1.NewTime(time.Now().Add(-10 * time.Second))
+	longAgo := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+
+	tests := map[string]struct {
+		clusterUpgradeOptions       *rayv1.ClusterUpgradeOptions
+		pendingTrafficRoutedPercent *int32
+		lastTrafficMigratedTime     *metav1.Time
+		expectedPendingPercent      *int32
+		expectedActivePercent       *int32
+		expectPromoted              bool
+	}{
+		"first step with the default step size": {
+			clusterUpgradeOptions:  &rayv1.ClusterUpgradeOptions{GatewayName: "gateway"},
+			expectedPendingPercent: ptr.To[int32](10),
+			expectedActivePercent:  ptr.To[int32](90),
+			expectPromoted:         false,
+		},
+		"step within the interval": {
+			clusterUpgradeOptions:       &rayv1.ClusterUpgradeOptions{GatewayName: "gateway"},
+			pendingTrafficRoutedPercent: ptr.To[int32](10),
+			lastTrafficMigratedTime:     &recently,
+			expectedPendingPercent:      ptr.To[int32](10),
+			expectPromoted:              false,
+		},
+		"step after the interval with a custom step size": {
+			clusterUpgradeOptions:       &rayv1.ClusterUpgradeOptions{GatewayName: "gateway", StepSizePercent: ptr.To[int32](25)},
+			pendingTrafficRoutedPercent: ptr.To[int32](25),
+			lastTrafficMigratedTime:     &longAgo,
+			expectedPendingPercent:      ptr.To[int32](50),
+			expectedActivePercent:       ptr.To[int32](50),
+			expectPromoted:              false,
+		},
+		"last step promotes the pending RayCluster": {
+			clusterUpgradeOptions:       &rayv1.ClusterUpgradeOptions{GatewayName: "gateway", StepSizePercent: ptr.To[int32](30)},
+			pendingTrafficRoutedPercent: ptr.To[int32](90),
+			lastTrafficMigratedTime:     &longAgo,
+			expectedPendingPercent:      ptr.To[int32](100),
+			expectPromoted:              true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &RayServiceReconciler{Recorder: &record.FakeRecorder{}}
+			rayService := &rayv1.RayService{
+				Spec: rayv1.RayServiceSpec{
+					UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{
+						Type:                  rayv1.NewClusterWithIncrementalUpgradeType,
+						ClusterUpgradeOptions: tc.clusterUpgradeOptions,
+					},
+				},
+				Status: rayv1.RayServiceStatuses{
+					ActiveServiceStatus: rayv1.RayServiceStatus{RayClusterName: "active-cluster"},
+					PendingServiceStatus: rayv1.RayServiceStatus{
+						RayClusterName:          "pending-cluster",
+						TrafficRoutedPercent:    tc.pendingTrafficRoutedPercent,
+						LastTrafficMigratedTime: tc.lastTrafficMigratedTime,
+					},
+				},
+			}
+
+			r.migrateTraffic(context.Background(), rayService)
+			if tc.expectPromoted {
+				assert.Equal(t, "pending-cluster", rayService.Status.ActiveServiceStatus.RayClusterName)
+				assert.Equal(t, tc.expectedPendingPercent, rayService.Status.ActiveServiceStatus.TrafficRoutedPercent)
+				assert.Empty(t, rayService.Status.PendingServiceStatus.RayClusterName)
+				return
+			}
+			assert.Equal(t, "active-cluster", rayService.Status.ActiveServiceStatus.RayClusterName)
+			assert.Equal(t, tc.expectedPendingPercent, rayService.Status.PendingServiceStatus.TrafficRoutedPercent)
+			assert.Equal(t, tc.expectedActivePercent, rayService.Status.ActiveServiceStatus.TrafficRoutedPercent)
+		})
+	}
+}
+
+func TestReconcileHTTPRoute(t *testing.T) {
+	namespace := "ray"
+	newRayCluster := func(name string) *rayv1.RayCluster {
+		return &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:  "ray-head",
+								Ports: []corev1.ContainerPort{{Name: utils.ServingPortName, ContainerPort: 8000}},
+							}},
+						},
+					},
+				},
+			},
+		}
+	}
+	activeRayCluster := newRayCluster("test-service-raycluster-active")
+	pendingRayCluster := newRayCluster("test-service-raycluster-pending")
+	pendingHeadPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pending-head",
+			Namespace: namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:  pendingRayCluster.Name,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			},
+		},
+		Spec: pendingRayCluster.Spec.HeadGroupSpec.Template.Spec,
+	}
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: namespace},
+		Spec: rayv1.RayServiceSpec{
+			UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{
+				Type:                  rayv1.NewClusterWithIncrementalUpgradeType,
+				ClusterUpgradeOptions: &rayv1.ClusterUpgradeOptions{GatewayName: "gateway"},
+			},
+		},
+		Status: rayv1.RayServiceStatuses{
+			ActiveServiceStatus:  rayv1.RayServiceStatus{RayClusterName: activeRayCluster.Name, TrafficRoutedPercent: ptr.To[int32](70)},
+			PendingServiceStatus: rayv1.RayServiceStatus{RayClusterName: pendingRayCluster.Name, TrafficRoutedPercent: ptr.To[int32](30)},
+		},
+	}
+
+	fakeClient := newFakeClientBuilder().WithRuntimeObjects(pendingHeadPod).Build()
+	r := &RayServiceReconciler{
+		Client:              fakeClient,
+		Recorder:            &record.FakeRecorder{},
+		Scheme:              scheme.Scheme,
+		httpProxyClientFunc: func() utils.RayHttpProxyClientInterface { return &utils.FakeRayHttpProxyClient{} },
+	}
+	ctx := context.Background()
+	getBackendWeights := func() map[string]int64 {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(common.HTTPRouteGVK)
+		err := fakeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: common.HTTPRouteName(rayService)}, route)
+		assert.Nil(t, err)
+		rules, _, err := unstructured.NestedSlice(route.Object, "spec", "rules")
+		assert.Nil(t, err)
+		weights := map[string]int64{}
+		for _, backendRef := range rules[0].(map[string]interface{})["backendRefs"].([]interface{}) {
+			backend := backendRef.(map[string]interface{})
+			weights[backend["name"].(string)] = backend["weight"].(int64)
+		}
+		return weights
+	}
+
+	// During an incremental upgrade, the traffic is split between the Serve services of both RayClusters, and the head
+	// Pod of the pending RayCluster is labeled to serve the traffic.
+	err := r.reconcileHTTPRoute(ctx, rayService, activeRayCluster, pendingRayCluster)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{
+		utils.GenerateServeServiceName(activeRayCluster.Name):  70,
+		utils.GenerateServeServiceName(pendingRayCluster.Name): 30,
+	}, getBackendWeights())
+	services := corev1.ServiceList{}
+	err = fakeClient.List(ctx, &services, client.InNamespace(namespace))
+	assert.Nil(t, err)
+	assert.Len(t, services.Items, 2)
+	headPod := &corev1.Pod{}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(pendingHeadPod), headPod)
+	assert.Nil(t, err)
+	assert.Equal(t, utils.EnableRayClusterServingServiceTrue, headPod.Labels[utils.RayClusterServingServiceLabelKey])
+
+	// The HTTPRoute is not updated for the fields that the API server defaults.
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(common.HTTPRouteGVK)
+	err = fakeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: common.HTTPRouteName(rayService)}, route)
+	assert.Nil(t, err)
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	parentRefs[0].(map[string]interface{})["kind"] = "Gateway"
+	err = unstructured.SetNestedSlice(route.Object, parentRefs, "spec", "parentRefs")
+	assert.Nil(t, err)
+	err = fakeClient.Update(ctx, route)
+	assert.Nil(t, err)
+	resourceVersion := route.GetResourceVersion()
+	err = r.reconcileHTTPRoute(ctx, rayService, activeRayCluster, pendingRayCluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: common.HTTPRouteName(rayService)}, route)
+	assert.Nil(t, err)
+	assert.Equal(t, resourceVersion, route.GetResourceVersion())
+
+	// Once the pending RayCluster is promoted, it receives all the traffic.
+	r.updateRayClusterInfo(ctx, rayService, pendingRayCluster.Name)
+	err = r.reconcileHTTPRoute(ctx, rayService, nil, pendingRayCluster)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{
+		utils.GenerateServeServiceName(pendingRayCluster.Name): 100,
+	}, getBackendWeights())
+}
+
+func TestShouldReplaceUnhealthyRayCluster(t *testing.T) {
+	clusterName := "test-cluster"
+	recently := metav1.NewTime(time.Now().Add(-10 * time.Second))
+	longAgo := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+
+	tests := map[string]struct {
+		serviceUnhealthySecondThreshold    *int32
+		deploymentUnhealthySecondThreshold *int32
+		serveUnhealthySince                *time.Time
+		applications                       map[string]rayv1.AppStatus
+		expectReplace                      bool
+	}{
+		"no thresholds": {
+			serveUnhealthySince: &longAgo.Time,
+			applications: map[string]rayv1.AppStatus{
+				"myapp": {Status: rayv1.ApplicationStatusEnum.UNHEALTHY, HealthLastUpdateTime: &longAgo},
+			},
+			expectReplace: false,
+		},
+		"Serve statuses unavailable within serviceUnhealthySecondThreshold": {
+			serviceUnhealthySecondThreshold: ptr.To[int32](60),
+			serveUnhealthySince:             &recently.Time,
+			expectReplace:                   false,
+		},
+		"Serve statuses unavailable for longer than serviceUnhealthySecondThreshold": {
+			serviceUnhealthySecondThreshold: ptr.To[int32](60),
+			serveUnhealthySince:             &longAgo.Time,
+			expectReplace:                   true,
+		},
+		"application unhealthy within deploymentUnhealthySecondThreshold": {
+			deploymentUnhealthySecondThreshold: ptr.To[int32](60),
+			applications: map[string]rayv1.AppStatus{
+				"myapp": {Status: rayv1.ApplicationStatusEnum.UNHEALTHY, HealthLastUpdateTime: &recently},
+			},
+			expectReplace: false,
+		},
+		"application deploy failed for longer than deploymentUnhealthySecondThreshold": {
+			deploymentUnhealthySecondThreshold: ptr.To[int32](60),
+			applications: map[string]rayv1.AppStatus{
+				"myapp": {Status: rayv1.ApplicationStatusEnum.DEPLOY_FAILED, HealthLastUpdateTime: &longAgo},
+			},
+			expectReplace: true,
+		},
+		"running application for longer than deploymentUnhealthySecondThreshold": {
+			deploymentUnhealthySecondThreshold: ptr.To[int32](60),
+			applications: map[string]rayv1.AppStatus{
+				"myapp": {Status: rayv1.ApplicationStatusEnum.RUNNING, HealthLastUpdateTime: &longAgo},
+			},
+			expectReplace: false,
+		},
+		"deployment unhealthy for longer than deploymentUnhealthySecondThreshold": {
+			deploymentUnhealthySecondThreshold: ptr.To[int32](60),
+			applications: map[string]rayv1.AppStatus{
+				"myapp": {
+					Status:               rayv1.ApplicationStatusEnum.DEPLOYING,
+					HealthLastUpdateTime: &recently,
+					Deployments: map[string]rayv1.ServeDeploymentStatus{
+						"MangoStand": {Status: rayv1.DeploymentStatusEnum.UNHEALTHY, HealthLastUpdateTime: &longAgo},
+					},
+				},
+			},
+			expectReplace: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &RayServiceReconciler{
+				ServeUnhealthyTimestamps: cmap.New[time.Time](),
+			}
+			if tc.serveUnhealthySince != nil {
+				r.ServeUnhealthyTimestamps.Set(clusterName, *tc.serveUnhealthySince)
+			}
+			rayService := &rayv1.RayService{
+				Spec: rayv1.RayServiceSpec{
+					ServiceUnhealthySecondThreshold:    tc.serviceUnhealthySecondThreshold,
+					DeploymentUnhealthySecondThreshold: tc.deploymentUnhealthySecondThreshold,
+				},
+				Status: rayv1.RayServiceStatuses{
+					ActiveServiceStatus: rayv1.RayServiceStatus{
+						RayClusterName: clusterName,
+						Applications:   tc.applications,
+					},
+				},
+			}
+			assert.Equal(t, tc.expectReplace, r.shouldReplaceUnhealthyRayCluster(context.Background(), rayService, clusterName))
+		})
+	}
 }
 
 func TestReconcileRayCluster(t *testing.T) {
@@ -810,7 +1470,7 @@ func TestReconcileRayCluster(t *testing.T) {
 				}
 				runtimeObjects = append(runtimeObjects, tc.activeCluster.DeepCopy())
 			}
-			fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+			fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 			r := RayServiceReconciler{
 				Client: fakeClient,
 				Scheme: newScheme,
@@ -841,6 +1501,124 @@ func TestReconcileRayCluster(t *testing.T) {
 	}
 }
 
+func TestReconcileRayCluster_Rollback(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	ctx := context.TODO()
+	namespace := "ray"
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: namespace,
+		},
+		Spec: rayv1.RayServiceSpec{
+			Rollback: &rayv1.RayServiceRollback{KeepPreviousClusterSeconds: ptr.To[int32](3600)},
+			RayClusterSpec: rayv1.RayClusterSpec{
+				RayVersion: "new-version",
+			},
+		},
+		Status: rayv1.RayServiceStatuses{
+			ActiveServiceStatus:    rayv1.RayServiceStatus{RayClusterName: "active-cluster"},
+			PreviousRayClusterName: "previous-cluster",
+		},
+	}
+
+	newRayCluster := func(name string, rayClusterSpec rayv1.RayClusterSpec) *rayv1.RayCluster {
+		hash, err := generateHashWithoutReplicasAndWorkersToDelete(rayClusterSpec)
+		assert.Nil(t, err)
+		return &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					utils.RayOriginatedFromCRNameLabelKey: rayService.Name,
+					utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD),
+				},
+				Annotations: map[string]string{
+					utils.HashWithoutReplicasAndWorkersToDeleteKey: hash,
+					utils.NumWorkerGroupsKey:                       strconv.Itoa(len(rayClusterSpec.WorkerGroupSpecs)),
+					utils.KubeRayVersion:                           utils.KUBERAY_VERSION,
+				},
+			},
+			Spec: rayClusterSpec,
+		}
+	}
+	activeCluster := newRayCluster("active-cluster", rayService.Spec.RayClusterSpec)
+	previousCluster := newRayCluster("previous-cluster", rayv1.RayClusterSpec{RayVersion: "old-version"})
+
+	tests := map[string]struct {
+		toPreviousCluster          bool
+		annotations                map[string]string
+		previousClusterExists      bool
+		expectedActiveClusterName  string
+		expectedPreviousRayCluster string
+	}{
+		"No rollback is requested. The previous RayCluster is kept.": {
+			previousClusterExists:      true,
+			expectedActiveClusterName:  "active-cluster",
+			expectedPreviousRayCluster: "previous-cluster",
+		},
+		"spec.rollback.toPreviousCluster rolls back to the previous RayCluster.": {
+			toPreviousCluster:          true,
+			previousClusterExists:      true,
+			expectedActiveClusterName:  "previous-cluster",
+			expectedPreviousRayCluster: "",
+		},
+		"The ray.io/rollback annotation rolls back to the previous RayCluster.": {
+			annotations:                map[string]string{utils.RayServiceRollbackAnnotationKey: "true"},
+			previousClusterExists:      true,
+			expectedActiveClusterName:  "previous-cluster",
+			expectedPreviousRayCluster: "",
+		},
+		"The previous RayCluster has been deleted. The rollback is not possible.": {
+			toPreviousCluster:          true,
+			previousClusterExists:      false,
+			expectedActiveClusterName:  "active-cluster",
+			expectedPreviousRayCluster: "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := rayService.DeepCopy()
+			service.Annotations = tc.annotations
+			service.Spec.Rollback.ToPreviousCluster = tc.toPreviousCluster
+			runtimeObjects := []runtime.Object{service, activeCluster.DeepCopy()}
+			if tc.previousClusterExists {
+				runtimeObjects = append(runtimeObjects, previousCluster.DeepCopy())
+			}
+			fakeClient := newFakeClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).WithStatusSubresource(service).Build()
+			r := RayServiceReconciler{
+				Client:                       fakeClient,
+				Scheme:                       newScheme,
+				Recorder:                     &record.FakeRecorder{},
+				RayClusterDeletionTimestamps: cmap.New[time.Time](),
+				ServeUnhealthyTimestamps:     cmap.New[time.Time](),
+			}
+			err := fakeClient.Get(ctx, client.ObjectKeyFromObject(service), service)
+			assert.Nil(t, err)
+
+			activeRayCluster, pendingRayCluster, err := r.reconcileRayCluster(ctx, service)
+			assert.Nil(t, err)
+			assert.Nil(t, pendingRayCluster)
+			assert.Equal(t, tc.expectedActiveClusterName, activeRayCluster.Name)
+			assert.Equal(t, tc.expectedActiveClusterName, service.Status.ActiveServiceStatus.RayClusterName)
+			assert.Equal(t, tc.expectedPreviousRayCluster, service.Status.PreviousRayClusterName)
+			// The spec of the previous RayCluster differs from the RayService spec, but no new RayCluster is prepared
+			// during a rollback.
+			assert.Equal(t, "", service.Status.PendingServiceStatus.RayClusterName)
+
+			if tc.expectedPreviousRayCluster != "" {
+				// The previous RayCluster is kept for keepPreviousClusterSeconds instead of 60 seconds.
+				deletionTimestamp, exists := r.RayClusterDeletionTimestamps.Get(tc.expectedPreviousRayCluster)
+				assert.True(t, exists)
+				assert.Greater(t, time.Until(deletionTimestamp), RayClusterDeletionDelayDuration)
+			}
+		})
+	}
+}
+
 func initFakeDashboardClient(appName string, deploymentStatus string, appStatus string) utils.RayDashboardClientInterface {
 	fakeDashboardClient := utils.FakeRayDashboardClient{}
 	status := generateServeStatus(deploymentStatus, appStatus)
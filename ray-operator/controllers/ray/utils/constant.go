@@ -26,10 +26,31 @@ const (
 	HashWithoutReplicasAndWorkersToDeleteKey = "ray.io/hash-without-replicas-and-workers-to-delete"
 	NumWorkerGroupsKey                       = "ray.io/num-worker-groups"
 	KubeRayVersion                           = "ray.io/kuberay-version"
+	// RayWorkerReplicaIndexKey is the index of the replica that a worker Pod belongs to within its worker group.
+	// Indices are reused: a new Pod takes the smallest index that is not used by any other Pod in the group.
+	RayWorkerReplicaIndexKey = "ray.io/worker-group-replica-index"
+	// RayReplicaIndexKey and RayHostIndexKey are set on the worker Pods of multi-host worker groups (numOfHosts > 1).
+	// All hosts of a replica share the same replica index, and each host has a unique host index within its replica.
+	RayReplicaIndexKey = "ray.io/replica-index"
+	RayHostIndexKey    = "ray.io/host-index"
+	// RayPodTemplateHashLabelKey is the hash of the Pod template of the group that a Pod was created from. The update
+	// strategies of the groups replace the Pods whose hash differs from the hash of the current Pod template.
+	RayPodTemplateHashLabelKey = "ray.io/pod-template-hash"
+	// RayEnvironmentTierLabelKey selects the environment tier, e.g. dev, staging or prod, of a RayCluster. The operator
+	// enforces the defaults configured for the tier in its configuration.
+	RayEnvironmentTierLabelKey = "ray.io/environment-tier"
+	// RayWarmPoolLabelKey is the name of the RayCluster that a standby Pod of the warm pool of a worker group belongs
+	// to, see `WarmPoolSize` of the worker group specs. Standby Pods carry it instead of RayClusterLabelKey so that they
+	// are never counted as Ray Pods.
+	RayWarmPoolLabelKey = "ray.io/warm-pool"
 
 	// In KubeRay, the Ray container must be the first application container in a head or worker Pod.
 	RayContainerIndex = 0
 
+	// SubmitterContainerName is the name of the container that submits the Ray job of a RayJob, in the submitter Pod or
+	// in the head Pod in SidecarMode.
+	SubmitterContainerName = "ray-job-submitter"
+
 	// Batch scheduling labels
 	// TODO(tgaddair): consider making these part of the CRD
 	RaySchedulerName     = "ray.io/scheduler-name"
@@ -44,6 +65,52 @@ const (
 	// `KUBERAY_GEN_RAY_START_CMD`.
 	RayOverwriteContainerCmdAnnotationKey = "ray.io/overwrite-container-cmd"
 
+	// RayServiceMeshModeAnnotationKey is set on Ray Pods when the RayCluster enables `spec.serviceMeshMode`
+	// so that BuildPod can generate a mesh-aware container command.
+	RayServiceMeshModeAnnotationKey = "ray.io/service-mesh-mode"
+
+	// Istio annotations used by the Istio service mesh mode.
+	IstioProxyConfigAnnotationKey          = "proxy.istio.io/config"
+	IstioIncludeInboundPortsAnnotationKey  = "traffic.sidecar.istio.io/includeInboundPorts"
+	IstioExcludeInboundPortsAnnotationKey  = "traffic.sidecar.istio.io/excludeInboundPorts"
+	IstioExcludeOutboundPortsAnnotationKey = "traffic.sidecar.istio.io/excludeOutboundPorts"
+
+	// The Kubernetes Cluster Autoscaler does not evict Pods with this annotation set to "false" when it scales down nodes.
+	SafeToEvictAnnotationKey = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
+	// If this annotation is set to "false" on a RayCluster, the image digest pinning webhook leaves its images unchanged.
+	PinImageDigestsAnnotationKey = "ray.io/pin-image-digests"
+
+	// RayDefaultsMaterializedAnnotationKey is set by the defaulting webhook on the RayClusters whose computed defaults it
+	// wrote into the spec. RayClusters created before the webhook was enabled are left unchanged on updates, as new
+	// fields in their Pod templates would replace their Pods.
+	RayDefaultsMaterializedAnnotationKey = "ray.io/defaults-materialized"
+
+	// RayAcceleratorResourcesAnnotationKey maps extended resources of a group's Ray container to Ray custom resources,
+	// e.g. "google.com/tpu=TPU,habana.ai/gaudi=HPU". It overrides the operator's accelerator resource mapping per key.
+	RayAcceleratorResourcesAnnotationKey = "ray.io/accelerator-resources"
+
+	// RayShmSizeAnnotationKey and RayDisableShmVolumeAnnotationKey carry the /dev/shm settings of a group from
+	// the Pod template to the Pod, see `ShmSize` and `DisableShmVolume` of the group specs.
+	RayShmSizeAnnotationKey          = "ray.io/shm-size"
+	RayDisableShmVolumeAnnotationKey = "ray.io/disable-shm-volume"
+
+	// RayStartParamsHashAnnotationKey is the hash of the ConfigMap keys merged into the RayStartParams of a Pod, see
+	// `RayStartParamsFrom` of the group specs. Pods with an outdated hash are replaced.
+	RayStartParamsHashAnnotationKey = "ray.io/ray-start-params-hash"
+
+	// RayDrainDeadlineAnnotationKey is set on a worker Pod whose Ray node KubeRay drains before deleting the Pod. The Pod
+	// is deleted once the deadline, in RFC 3339 format, has passed or the Pod is no longer ready.
+	RayDrainDeadlineAnnotationKey = "ray.io/drain-deadline"
+
+	// RayJobSubmissionIdAnnotationKey is set by users on a RayJob in InteractiveMode to the submission ID of the Ray job
+	// they submitted to its RayCluster, so that KubeRay tracks the status of the Ray job.
+	RayJobSubmissionIdAnnotationKey = "ray.io/rayjob-submission-id"
+
+	// RayServiceRollbackAnnotationKey is set by users to "true" on a RayService to roll it back to its previous
+	// RayCluster, like `spec.rollback.toPreviousCluster`.
+	RayServiceRollbackAnnotationKey = "ray.io/rollback"
+
 	// Finalizers for GCS fault tolerance
 	GCSFaultToleranceRedisCleanupFinalizer = "ray.io/gcs-ft-redis-cleanup-finalizer"
 
@@ -69,15 +136,29 @@ const (
 	DefaultRedisPort                = 6379
 	DefaultDashboardPort            = 8265
 	DefaultMetricsPort              = 8080
+	DefaultAutoscalerMetricsPort    = 44217
+	DefaultDashboardMetricsPort     = 44227
 	DefaultDashboardAgentListenPort = 52365
 	DefaultServingPort              = 8000
 
+	// Ray picks random ports for the object manager, the node manager, and the dashboard agent's gRPC
+	// server by default. When a service mesh is enabled, KubeRay pins them so that they can be excluded
+	// from the sidecar's traffic interception.
+	DefaultObjectManagerPort       = 8076
+	DefaultNodeManagerPort         = 8077
+	DefaultDashboardAgentGrpcPort  = 8078
+	DefaultIstioProxyReadinessPort = 15021
+
 	ClientPortName    = "client"
 	RedisPortName     = "redis"
 	DashboardPortName = "dashboard"
 	MetricsPortName   = "metrics"
 	ServingPortName   = "serve"
 
+	// The names of the ports of the autoscaler and dashboard metrics of the head Pod, as in the Ray documentation.
+	AutoscalerMetricsPortName = "as-metrics"
+	DashboardMetricsPortName  = "dash-metrics"
+
 	// The default AppProtocol for Kubernetes service
 	DefaultServiceAppProtocol = "tcp"
 
@@ -109,13 +190,35 @@ const (
 	RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV  = "RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV"
 	RAYCLUSTER_DEFAULT_REQUEUE_SECONDS      = 300
 	KUBERAY_GEN_RAY_START_CMD               = "KUBERAY_GEN_RAY_START_CMD"
+	RAY_LOG_OUTPUT                          = "RAY_LOG_OUTPUT"
+	RAY_ROTATION_MAX_BYTES                  = "RAY_ROTATION_MAX_BYTES"
+	RAY_ROTATION_BACKUP_COUNT               = "RAY_ROTATION_BACKUP_COUNT"
+	POD_NAME                                = "POD_NAME"
 
 	// Environment variables for RayJob submitter Kubernetes Job.
 	// Example: ray job submit --address=http://$RAY_DASHBOARD_ADDRESS --submission-id=$RAY_JOB_SUBMISSION_ID ...
 	RAY_DASHBOARD_ADDRESS = "RAY_DASHBOARD_ADDRESS"
 	RAY_JOB_SUBMISSION_ID = "RAY_JOB_SUBMISSION_ID"
 
+	// The runtime environment of a Ray job that references Secrets or ConfigMaps is stored in a Secret mounted in the
+	// submitter Pod, and submitted with `ray job submit --runtime-env`.
+	RayJobRuntimeEnvVolumeName = "ray-job-runtime-env"
+	RayJobRuntimeEnvMountPath  = "/tmp/ray-job-runtime-env"
+	RayJobRuntimeEnvSecretKey  = "runtime_env.yaml"
+
+	// The message and the driver logs of a finished Ray job are truncated in the RayJob status, so that the RayJob
+	// stays well below the size limit of Kubernetes objects.
+	RayJobStatusMessageMaxBytes = 1024
+	RayJobLogsTailMaxBytes      = 4 * 1024
+
+	// The entrypoint of a Ray job reports its result to KubeRay by printing a line starting with RayJobResultLogPrefix
+	// followed by a JSON document of at most RayJobResultMaxBytes, e.g. `KUBERAY_JOB_RESULT: {"accuracy": 0.9}`.
+	RayJobResultLogPrefix = "KUBERAY_JOB_RESULT:"
+	RayJobResultMaxBytes  = 4 * 1024
+
 	// Environment variables for Ray Autoscaler V2.
+	// RAY_ENABLE_AUTOSCALER_V2 makes the GCS server and the autoscaler run the autoscaler V2.
+	RAY_ENABLE_AUTOSCALER_V2 = "RAY_enable_autoscaler_v2"
 	// The value of RAY_CLOUD_INSTANCE_ID is the Pod name for Autoscaler V2 alpha. This may change in the future.
 	RAY_CLOUD_INSTANCE_ID = "RAY_CLOUD_INSTANCE_ID"
 	// The value of RAY_NODE_TYPE_NAME is the name of the node group (i.e., the value of the "ray.io/group" label).
@@ -137,6 +240,23 @@ const (
 	// flag for v1.1.0 and will be removed if the behavior proves to be stable enough.
 	ENABLE_PROBES_INJECTION = "ENABLE_PROBES_INJECTION"
 
+	// If set to true, the init container injected into Ray worker Pods only receives the environment variables of the
+	// Ray container it needs to connect to the GCS server, i.e. the TLS-related ones, instead of all of them. This keeps
+	// Pods with large environments below the object size limit of etcd.
+	ENABLE_MINIMAL_INIT_CONTAINER_ENV = "ENABLE_MINIMAL_INIT_CONTAINER_ENV"
+
+	// A comma-separated list of additional environment variables of the Ray container that are passed to the init
+	// container if ENABLE_MINIMAL_INIT_CONTAINER_ENV is true.
+	INIT_CONTAINER_ENV_ALLOWLIST = "INIT_CONTAINER_ENV_ALLOWLIST"
+
+	// If set to true and webhooks are enabled, a mutating webhook resolves the image tags of RayClusters to digests at
+	// admission and pins them in the Pod templates, so Pods created later, e.g. by scale-ups, run the same images.
+	ENABLE_IMAGE_DIGEST_PINNING = "ENABLE_IMAGE_DIGEST_PINNING"
+
+	// If set to true and webhooks are enabled, a mutating webhook writes the defaults computed by KubeRay, e.g. the
+	// ports of the Ray containers and the rayStartParams, into the spec of RayClusters at admission.
+	ENABLE_SPEC_DEFAULTING = "ENABLE_SPEC_DEFAULTING"
+
 	// If set to true, kuberay creates a normal ClusterIP service for a Ray Head instead of a Headless service.
 	ENABLE_RAY_HEAD_CLUSTER_IP_SERVICE = "ENABLE_RAY_HEAD_CLUSTER_IP_SERVICE"
 
@@ -162,6 +282,16 @@ const (
 	DefaultLivenessProbeSuccessThreshold    = 1
 	DefaultLivenessProbeFailureThreshold    = 120
 
+	// Autoscaler default liveness probe values. The autoscaler logs its status in every iteration of its update
+	// loop, so the probe fails if its log file has not been written for AutoscalerHeartbeatTimeoutSeconds.
+	DefaultAutoscalerLivenessProbeInitialDelaySeconds = 30
+	DefaultAutoscalerLivenessProbeTimeoutSeconds      = 5
+	DefaultAutoscalerLivenessProbePeriodSeconds       = 10
+	DefaultAutoscalerLivenessProbeFailureThreshold    = 3
+	AutoscalerHeartbeatTimeoutSeconds                 = 60
+	// RayAutoscalerLogPath is the log file of the autoscaler, relative to the Ray log directory.
+	RayAutoscalerLogPath = "session_latest/logs/monitor.log"
+
 	// Ray health check related configurations
 	// Note: Since the Raylet process and the dashboard agent process are fate-sharing,
 	// only one of them needs to be checked. So, RayAgentRayletHealthPath accesses the dashboard agent's API endpoint
@@ -170,11 +300,17 @@ const (
 	RayAgentRayletHealthPath  = "api/local_raylet_healthz"
 	RayDashboardGCSHealthPath = "api/gcs_healthz"
 	RayServeProxyHealthPath   = "-/healthz"
-	BaseWgetHealthCommand     = "wget -T %d -q -O- http://localhost:%d/%s | grep success"
+	// Deprecated: The probes injected by KubeRay run Python instead of wget, which distroless Ray images do not ship.
+	BaseWgetHealthCommand   = "wget -T %d -q -O- http://localhost:%d/%s | grep success"
+	IstioProxyReadinessPath = "healthz/ready"
 
 	// Finalizers for RayJob
 	RayJobStopJobFinalizer = "ray.io/rayjob-finalizer"
 
+	// RayCronJobScheduledTimestampAnnotationKey is the time, in the RFC 3339 format, of the run of a RayCronJob that a
+	// RayJob was created for.
+	RayCronJobScheduledTimestampAnnotationKey = "ray.io/cronjob-scheduled-timestamp"
+
 	// RayNodeHeadGroupLabelValue is the value for the RayNodeGroupLabelKey label on a head node
 	RayNodeHeadGroupLabelValue = "headgroup"
 
@@ -206,6 +342,11 @@ func (e *errRayClusterReplicaFailure) Error() string {
 	return e.reason
 }
 
+// Reason is the reason of the reconciliation errors wrapping the marker, e.g. in the reconcile error metrics.
+func (e *errRayClusterReplicaFailure) Reason() string {
+	return e.reason
+}
+
 // These are markers used by the calculateStatus() for setting the RayClusterReplicaFailure condition.
 var (
 	ErrFailedDeleteAllPods   = &errRayClusterReplicaFailure{reason: "FailedDeleteAllPods"}
@@ -238,10 +379,45 @@ const (
 	FailedToCreateWorkerPod K8sEventType = "FailedToCreateWorkerPod"
 	DeletedWorkerPod        K8sEventType = "DeletedWorkerPod"
 	FailedToDeleteWorkerPod K8sEventType = "FailedToDeleteWorkerPod"
+	InterruptedWorkerPod    K8sEventType = "InterruptedWorkerPod"
+	RecycledWorkerPod       K8sEventType = "RecycledWorkerPod"
+	DrainingWorkerPod       K8sEventType = "DrainingWorkerPod"
+	ForceDeletedWorkerPod   K8sEventType = "ForceDeletedWorkerPod"
+	UnschedulablePods       K8sEventType = "UnschedulablePods"
+
+	// RayStartParams event list
+	ReplacedOutdatedPod       K8sEventType = "ReplacedOutdatedPod"
+	FailedToGetRayStartParams K8sEventType = "FailedToGetRayStartParams"
+
+	// Placement group event list
+	CreatedPlacementGroups        K8sEventType = "CreatedPlacementGroups"
+	FailedToCreatePlacementGroups K8sEventType = "FailedToCreatePlacementGroups"
+
+	// Autoscaler event list
+	ScaledWorkerGroup K8sEventType = "ScaledWorkerGroup"
+	AutoscalerFailed  K8sEventType = "AutoscalerFailed"
+
+	// Scheduled scaling event list
+	AppliedScheduledScaling       K8sEventType = "AppliedScheduledScaling"
+	FailedToApplyScheduledScaling K8sEventType = "FailedToApplyScheduledScaling"
+
+	// Idle timeout event list
+	IdleTimeoutExpired K8sEventType = "IdleTimeoutExpired"
+
+	// Worker group event list
+	GroupAdded   K8sEventType = "GroupAdded"
+	GroupRemoved K8sEventType = "GroupRemoved"
+
+	// Warm pool event list
+	CreatedWarmPoolPod        K8sEventType = "CreatedWarmPoolPod"
+	FailedToCreateWarmPoolPod K8sEventType = "FailedToCreateWarmPoolPod"
+	DeletedWarmPoolPod        K8sEventType = "DeletedWarmPoolPod"
+	FailedToDeleteWarmPoolPod K8sEventType = "FailedToDeleteWarmPoolPod"
 
 	// Generic Pod event list
 	DeletedPod        K8sEventType = "DeletedPod"
 	FailedToDeletePod K8sEventType = "FailedToDeletePod"
+	LargePod          K8sEventType = "LargePod"
 
 	// Ingress event list
 	CreatedIngress        K8sEventType = "CreatedIngress"
@@ -266,4 +442,43 @@ const (
 	// RoleBinding list
 	CreatedRoleBinding        K8sEventType = "CreatedRoleBinding"
 	FailedToCreateRoleBinding K8sEventType = "FailedToCreateRoleBinding"
+
+	// PersistentVolumeClaim event list
+	CreatedPersistentVolumeClaim        K8sEventType = "CreatedPersistentVolumeClaim"
+	FailedToCreatePersistentVolumeClaim K8sEventType = "FailedToCreatePersistentVolumeClaim"
+	DeletedPersistentVolumeClaim        K8sEventType = "DeletedPersistentVolumeClaim"
+	FailedToDeletePersistentVolumeClaim K8sEventType = "FailedToDeletePersistentVolumeClaim"
+
+	// PodDisruptionBudget event list
+	CreatedPodDisruptionBudget        K8sEventType = "CreatedPodDisruptionBudget"
+	FailedToCreatePodDisruptionBudget K8sEventType = "FailedToCreatePodDisruptionBudget"
+	DeletedPodDisruptionBudget        K8sEventType = "DeletedPodDisruptionBudget"
+
+	// PodMonitor event list
+	CreatedPodMonitor        K8sEventType = "CreatedPodMonitor"
+	FailedToCreatePodMonitor K8sEventType = "FailedToCreatePodMonitor"
+	UpdatedPodMonitor        K8sEventType = "UpdatedPodMonitor"
+	FailedToUpdatePodMonitor K8sEventType = "FailedToUpdatePodMonitor"
+
+	// Ray job event list
+	SubmittedRayJob          K8sEventType = "SubmittedRayJob"
+	FailedToSubmitRayJob     K8sEventType = "FailedToSubmitRayJob"
+	UploadedRayJobLogs       K8sEventType = "UploadedRayJobLogs"
+	FailedToUploadRayJobLogs K8sEventType = "FailedToUploadRayJobLogs"
+	InvalidRayJobResult      K8sEventType = "InvalidRayJobResult"
+
+	// RayService event list
+	InvalidRayServiceSpec                 K8sEventType = "InvalidRayServiceSpec"
+	UnhealthyRayCluster                   K8sEventType = "UnhealthyRayCluster"
+	PendingRayClusterReadyTimeoutExceeded K8sEventType = "PendingRayClusterReadyTimeoutExceeded"
+	MigratedTraffic                       K8sEventType = "MigratedTraffic"
+	RolledBackRayCluster                  K8sEventType = "RolledBackRayCluster"
+
+	// RayCronJob event list
+	CreatedRayJob         K8sEventType = "CreatedRayJob"
+	FailedToCreateRayJob  K8sEventType = "FailedToCreateRayJob"
+	DeletedRayJob         K8sEventType = "DeletedRayJob"
+	FailedToDeleteRayJob  K8sEventType = "FailedToDeleteRayJob"
+	InvalidRayCronJobSpec K8sEventType = "InvalidRayCronJobSpec"
+	SkippedRayCronJobRun  K8sEventType = "SkippedRayCronJobRun"
 )
@@ -1,16 +1,17 @@
 package utils
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"net/http"
-	"time"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/yaml"
 
 	fmtErrors "github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -20,14 +21,18 @@ import (
 	"k8s.io/apimachinery/pkg/util/json"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/pkg/client/dashboard"
+	"github.com/ray-project/kuberay/ray-operator/pkg/tracing"
 )
 
 var (
 	// Multi-application URL paths
-	ServeDetailsPath = "/api/serve/applications/"
-	DeployPathV2     = "/api/serve/applications/"
+	ServeDetailsPath = dashboard.ServeApplicationsPath
+	DeployPathV2     = dashboard.ServeApplicationsPath
 	// Job URL paths
-	JobPath = "/api/jobs/"
+	JobPath = dashboard.JobPath
+	// Cluster status URL path, which reports the state of the autoscaler
+	ClusterStatusPath = "/api/cluster_status"
 )
 
 type RayDashboardClientInterface interface {
@@ -43,6 +48,7 @@ type RayDashboardClientInterface interface {
 	GetJobLog(ctx context.Context, jobName string) (*string, error)
 	StopJob(ctx context.Context, jobName string) error
 	DeleteJob(ctx context.Context, jobName string) error
+	GetClusterStatus(ctx context.Context) (*ClusterStatus, error)
 }
 
 type BaseDashboardClient struct {
@@ -50,19 +56,71 @@ type BaseDashboardClient struct {
 	dashboardURL string
 }
 
-func GetRayDashboardClientFunc(mgr ctrl.Manager, useKubernetesProxy bool) func() RayDashboardClientInterface {
+// GetRayDashboardClientFunc returns a function creating the dashboard clients. The clients share the connections to
+// the Ray dashboards, including the HTTPS ones verified with the same CA certificates, and their circuit breakers, and
+// their requests are made according to options. The requests of the clients to the Ray dashboard of a RayCluster are
+// made through the RoundTripper returned by instrumentTransport, if not nil, e.g. to record their metrics.
+func GetRayDashboardClientFunc(mgr ctrl.Manager, useKubernetesProxy bool, options DashboardClientOptions, instrumentTransport func(*rayv1.RayCluster, http.RoundTripper) http.RoundTripper) func() RayDashboardClientInterface {
+	baseTransport := newDashboardTransport()
+	tlsTransports := &dashboardTLSTransports{transports: map[[sha256.Size]byte]http.RoundTripper{}}
+	var breaker *dashboardCircuitBreaker
+	if options.CircuitBreakerThreshold > 0 {
+		breaker = newDashboardCircuitBreaker(options.CircuitBreakerThreshold, options.CircuitBreakerCooldown)
+	}
 	return func() RayDashboardClientInterface {
 		return &RayDashboardClient{
-			mgr:                mgr,
-			useKubernetesProxy: useKubernetesProxy,
+			mgr:                 mgr,
+			useKubernetesProxy:  useKubernetesProxy,
+			options:             options,
+			secretReader:        mgr.GetAPIReader(),
+			baseTransport:       baseTransport,
+			tlsTransports:       tlsTransports,
+			breaker:             breaker,
+			instrumentTransport: instrumentTransport,
 		}
 	}
 }
 
 type RayDashboardClient struct {
-	mgr ctrl.Manager
+	mgr          ctrl.Manager
+	secretReader client.Reader
 	BaseDashboardClient
-	useKubernetesProxy bool
+	baseTransport       http.RoundTripper
+	tlsTransports       *dashboardTLSTransports
+	breaker             *dashboardCircuitBreaker
+	instrumentTransport func(*rayv1.RayCluster, http.RoundTripper) http.RoundTripper
+	options             DashboardClientOptions
+	useKubernetesProxy  bool
+}
+
+// transport returns the RoundTripper of the requests to the Ray dashboard of the RayCluster made through next.
+func (r *RayDashboardClient) transport(rayCluster *rayv1.RayCluster, next http.RoundTripper) http.RoundTripper {
+	if r.instrumentTransport != nil {
+		next = r.instrumentTransport(rayCluster, next)
+	}
+	return tracing.NewTransport(next)
+}
+
+// newHTTPClient returns the client of the requests to the Ray dashboard at r.dashboardURL made through next. The
+// requests fail fast while the circuit breaker of the dashboard is open, and each of their attempts is traced and
+// instrumented.
+func (r *RayDashboardClient) newHTTPClient(rayCluster *rayv1.RayCluster, next http.RoundTripper) *http.Client {
+	transport := r.transport(rayCluster, next)
+	if r.options.MaxRetries > 0 {
+		transport = &retryTransport{next: transport, maxRetries: r.options.MaxRetries}
+	}
+	if r.breaker != nil {
+		transport = &circuitBreakerTransport{next: transport, breaker: r.breaker, dashboardURL: r.dashboardURL}
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   r.options.timeout(),
+	}
+}
+
+// dashboardClient returns the client of the REST APIs of the dashboard.
+func (r *RayDashboardClient) dashboardClient() *dashboard.Client {
+	return dashboard.NewClient(r.dashboardURL, r.client)
 }
 
 // FetchHeadServiceURL fetches the URL that consists of the FQDN for the RayCluster's head service
@@ -111,8 +169,12 @@ func FetchHeadServiceURL(ctx context.Context, cli client.Client, rayCluster *ray
 func (r *RayDashboardClient) InitClient(ctx context.Context, url string, rayCluster *rayv1.RayCluster) error {
 	log := ctrl.LoggerFrom(ctx)
 
+	connection, err := r.readDashboardConnection(ctx, rayCluster)
+	if err != nil {
+		return err
+	}
+
 	if r.useKubernetesProxy {
-		var err error
 		headSvcName := rayCluster.Status.Head.ServiceName
 		if headSvcName == "" {
 			log.Info("RayCluster is missing .status.head.serviceName, calling GenerateHeadServiceName instead...", "RayCluster name", rayCluster.Name, "namespace", rayCluster.Namespace)
@@ -122,196 +184,166 @@ func (r *RayDashboardClient) InitClient(ctx context.Context, url string, rayClus
 			}
 		}
 
-		r.client = r.mgr.GetHTTPClient()
-		r.dashboardURL = fmt.Sprintf("%s/api/v1/namespaces/%s/services/%s:dashboard/proxy", r.mgr.GetConfig().Host, rayCluster.Namespace, headSvcName)
+		// The API server connects to the dashboard over HTTPS if the Service name is prefixed with the scheme.
+		scheme := ""
+		if connection.tls {
+			scheme = "https:"
+		}
+		// The API server authenticates the operator with the Authorization header, so it is not overridden.
+		connection.header.Del("Authorization")
+		r.dashboardURL = fmt.Sprintf("%s/api/v1/namespaces/%s/services/%s%s:dashboard/proxy", r.mgr.GetConfig().Host, rayCluster.Namespace, scheme, headSvcName)
+		r.client = r.newHTTPClient(rayCluster, newHeaderTransport(r.mgr.GetHTTPClient().Transport, connection.header))
 		return nil
 	}
 
-	r.client = &http.Client{
-		Timeout: 2 * time.Second,
-	}
-
+	transport := r.baseTransport
 	r.dashboardURL = "http://" + url
-	return nil
-}
-
-// UpdateDeployments update the deployments in the Ray cluster.
-func (r *RayDashboardClient) UpdateDeployments(ctx context.Context, configJson []byte) error {
-	var req *http.Request
-	var err error
-	if req, err = http.NewRequestWithContext(ctx, http.MethodPut, r.dashboardURL+DeployPathV2, bytes.NewBuffer(configJson)); err != nil {
-		return err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := r.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return fmt.Errorf("UpdateDeployments fail: %s %s", resp.Status, string(body))
+	if connection.tls {
+		r.dashboardURL = "https://" + url
+		if connection.caPEM != nil {
+			if transport, err = r.tlsTransports.get(connection.caPEM); err != nil {
+				return err
+			}
+		}
 	}
-
+	r.client = r.newHTTPClient(rayCluster, newHeaderTransport(transport, connection.header))
 	return nil
 }
 
-func (r *RayDashboardClient) GetMultiApplicationStatus(ctx context.Context) (map[string]*ServeApplicationStatus, error) {
-	serveDetails, err := r.GetServeDetails(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to get serve details: %w", err)
-	}
-
-	return r.ConvertServeDetailsToApplicationStatuses(serveDetails)
+// dashboardConnection is how to connect to the Ray dashboard of a RayCluster according to its DashboardClient.
+type dashboardConnection struct {
+	// header holds the headers set on all the requests, e.g. to authenticate them.
+	header http.Header
+	// caPEM holds the CA certificates that the certificate of the dashboard is verified with, if not the system ones.
+	caPEM []byte
+	tls   bool
 }
 
-// GetServeDetails gets details on all live applications on the Ray cluster.
-func (r *RayDashboardClient) GetServeDetails(ctx context.Context) (*ServeDetails, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", r.dashboardURL+ServeDetailsPath, nil)
-	if err != nil {
-		return nil, err
+// readDashboardConnection reads the Secrets referenced by the DashboardClient of the RayCluster.
+func (r *RayDashboardClient) readDashboardConnection(ctx context.Context, rayCluster *rayv1.RayCluster) (dashboardConnection, error) {
+	connection := dashboardConnection{header: http.Header{}}
+	if rayCluster == nil || rayCluster.Spec.DashboardClient == nil {
+		return connection, nil
 	}
 
-	resp, err := r.client.Do(req)
-	if err != nil {
-		return nil, err
+	config := rayCluster.Spec.DashboardClient
+	if config.TLS != nil {
+		connection.tls = true
+		if config.TLS.CASecretRef != nil {
+			caPEM, err := r.readSecretKey(ctx, rayCluster.Namespace, config.TLS.CASecretRef)
+			if err != nil {
+				return connection, err
+			}
+			connection.caPEM = caPEM
+		}
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, fmt.Errorf("GetServeDetails fail: %s %s", resp.Status, string(body))
+	if config.BearerTokenSecretRef != nil {
+		token, err := r.readSecretKey(ctx, rayCluster.Namespace, config.BearerTokenSecretRef)
+		if err != nil {
+			return connection, err
+		}
+		if token != nil {
+			connection.header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+		}
 	}
-
-	var serveDetails ServeDetails
-	if err = json.Unmarshal(body, &serveDetails); err != nil {
-		return nil, fmt.Errorf("GetServeDetails failed. Failed to unmarshal bytes: %s", string(body))
+	if config.CookieSecretRef != nil {
+		cookie, err := r.readSecretKey(ctx, rayCluster.Namespace, config.CookieSecretRef)
+		if err != nil {
+			return connection, err
+		}
+		if cookie != nil {
+			connection.header.Set("Cookie", strings.TrimSpace(string(cookie)))
+		}
 	}
-
-	return &serveDetails, nil
+	return connection, nil
 }
 
-func (r *RayDashboardClient) ConvertServeDetailsToApplicationStatuses(serveDetails *ServeDetails) (map[string]*ServeApplicationStatus, error) {
-	detailsJson, err := json.Marshal(serveDetails.Applications)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to marshal serve details: %v", serveDetails.Applications)
+// readSecretKey returns the value of the key of the Secret, or nil if the selector is optional and the Secret or its
+// key does not exist. The Secrets are read from the API server, as they are not cached by the operator.
+func (r *RayDashboardClient) readSecretKey(ctx context.Context, namespace string, selector *corev1.SecretKeySelector) ([]byte, error) {
+	optional := selector.Optional != nil && *selector.Optional
+	secret := &corev1.Secret{}
+	if err := r.secretReader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: selector.Name}, secret); err != nil {
+		if errors.IsNotFound(err) && optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the Secret %s of the dashboard client: %w", selector.Name, err)
 	}
-
-	applicationStatuses := map[string]*ServeApplicationStatus{}
-	if err = json.Unmarshal(detailsJson, &applicationStatuses); err != nil {
-		return nil, fmt.Errorf("Failed to unmarshal serve details bytes into map of application statuses: %w. Bytes: %s", err, string(detailsJson))
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		if optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("the Secret %s of the dashboard client has no key %s", selector.Name, selector.Key)
 	}
+	return value, nil
+}
 
-	return applicationStatuses, nil
+// UpdateDeployments update the deployments in the Ray cluster.
+func (r *RayDashboardClient) UpdateDeployments(ctx context.Context, configJson []byte) (err error) {
+	ctx, span := tracing.Start(ctx, "DeployServe", attribute.String("server.address", r.dashboardURL))
+	defer tracing.End(span, &err)
+
+	return r.dashboardClient().DeployApplications(ctx, configJson)
 }
 
-type RuntimeEnvType map[string]interface{}
-
-// RayJobInfo is the response of "ray job status" api.
-// Reference to https://docs.ray.io/en/latest/cluster/running-applications/job-submission/rest.html#ray-job-rest-api-spec
-// Reference to https://github.com/ray-project/ray/blob/cfbf98c315cfb2710c56039a3c96477d196de049/dashboard/modules/job/pydantic_models.py#L38-L107
-type RayJobInfo struct {
-	ErrorType    *string           `json:"error_type,omitempty"`
-	Metadata     map[string]string `json:"metadata,omitempty"`
-	RuntimeEnv   RuntimeEnvType    `json:"runtime_env,omitempty"`
-	JobStatus    rayv1.JobStatus   `json:"status,omitempty"`
-	Entrypoint   string            `json:"entrypoint,omitempty"`
-	JobId        string            `json:"job_id,omitempty"`
-	SubmissionId string            `json:"submission_id,omitempty"`
-	Message      string            `json:"message,omitempty"`
-	StartTime    uint64            `json:"start_time,omitempty"`
-	EndTime      uint64            `json:"end_time,omitempty"`
+func (r *RayDashboardClient) GetMultiApplicationStatus(ctx context.Context) (map[string]*ServeApplicationStatus, error) {
+	return r.dashboardClient().GetApplicationStatuses(ctx)
 }
 
-// RayJobRequest is the request body to submit.
-// Reference to https://docs.ray.io/en/latest/cluster/running-applications/job-submission/rest.html#ray-job-rest-api-spec
-// Reference to https://github.com/ray-project/ray/blob/cfbf98c315cfb2710c56039a3c96477d196de049/dashboard/modules/job/common.py#L325-L353
-type RayJobRequest struct {
-	RuntimeEnv   RuntimeEnvType     `json:"runtime_env,omitempty"`
-	Metadata     map[string]string  `json:"metadata,omitempty"`
-	Resources    map[string]float32 `json:"entrypoint_resources,omitempty"`
-	Entrypoint   string             `json:"entrypoint"`
-	SubmissionId string             `json:"submission_id,omitempty"`
-	NumCpus      float32            `json:"entrypoint_num_cpus,omitempty"`
-	NumGpus      float32            `json:"entrypoint_num_gpus,omitempty"`
+// GetServeDetails gets details on all live applications on the Ray cluster.
+func (r *RayDashboardClient) GetServeDetails(ctx context.Context) (*ServeDetails, error) {
+	return r.dashboardClient().GetServeDetails(ctx)
 }
 
-type RayJobResponse struct {
-	JobId string `json:"job_id"`
+func (r *RayDashboardClient) ConvertServeDetailsToApplicationStatuses(serveDetails *ServeDetails) (map[string]*ServeApplicationStatus, error) {
+	return dashboard.ConvertServeDetailsToApplicationStatuses(serveDetails)
 }
 
-type RayJobStopResponse struct {
-	Stopped bool `json:"stopped"`
+// The types of the Ray Job Submission REST API are defined in the dashboard package, which the operator shares with
+// the apiserver and external Go programs.
+type (
+	RuntimeEnvType     = dashboard.RuntimeEnvType
+	RayJobInfo         = dashboard.RayJobInfo
+	RayJobRequest      = dashboard.RayJobRequest
+	RayJobResponse     = dashboard.RayJobResponse
+	RayJobStopResponse = dashboard.RayJobStopResponse
+	RayJobLogsResponse = dashboard.RayJobLogsResponse
+)
+
+// ClusterStatusResponse is the response of the cluster status api of the Ray dashboard.
+// Reference to https://github.com/ray-project/ray/blob/master/python/ray/dashboard/modules/reporter/reporter_head.py
+type ClusterStatusResponse struct {
+	Message string        `json:"msg,omitempty"`
+	Data    ClusterStatus `json:"data,omitempty"`
+	Result  bool          `json:"result"`
 }
 
-type RayJobLogsResponse struct {
-	Logs string `json:"logs,omitempty"`
+// ClusterStatus is the state of the Ray autoscaler reported by the cluster status api.
+type ClusterStatus struct {
+	// AutoscalingError is the last error of the autoscaler, e.g. a failure to launch a worker node.
+	AutoscalingError string `json:"autoscalingError,omitempty"`
 }
 
 // Note that RayJobInfo and error can't be nil at the same time.
 // Please make sure if the Ray job with JobId can't be found. Return a BadRequest error.
 func (r *RayDashboardClient) GetJobInfo(ctx context.Context, jobId string) (*RayJobInfo, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", r.dashboardURL+JobPath+jobId, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := r.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusNotFound {
+	jobInfo, err := r.dashboardClient().GetJobInfo(ctx, jobId)
+	if dashboard.IsNotFound(err) {
 		return nil, errors.NewBadRequest("Job " + jobId + " does not exist on the cluster")
 	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var jobInfo RayJobInfo
-	if err = json.Unmarshal(body, &jobInfo); err != nil {
-		// Maybe body is not valid json, raise an error with the body.
-		return nil, fmt.Errorf("GetJobInfo fail: %s", string(body))
-	}
-
-	return &jobInfo, nil
+	return jobInfo, err
 }
 
 func (r *RayDashboardClient) ListJobs(ctx context.Context) (*[]RayJobInfo, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", r.dashboardURL+JobPath, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := r.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil
-	}
-
-	body, err := io.ReadAll(resp.Body)
+	jobInfos, err := r.dashboardClient().ListJobs(ctx)
 	if err != nil {
+		if dashboard.IsNotFound(err) {
+			return nil, nil
+		}
 		return nil, err
 	}
-
-	var jobInfo []RayJobInfo
-	if err = json.Unmarshal(body, &jobInfo); err != nil {
-		// Maybe body is not valid json, raise an error with the body.
-		return nil, fmt.Errorf("GetJobInfo fail: %s", string(body))
-	}
-
-	return &jobInfo, nil
+	return &jobInfos, nil
 }
 
 func (r *RayDashboardClient) SubmitJob(ctx context.Context, rayJob *rayv1.RayJob) (jobId string, err error) {
@@ -323,101 +355,39 @@ func (r *RayDashboardClient) SubmitJob(ctx context.Context, rayJob *rayv1.RayJob
 }
 
 func (r *RayDashboardClient) SubmitJobReq(ctx context.Context, request *RayJobRequest, name *string) (jobId string, err error) {
+	ctx, span := tracing.Start(ctx, "SubmitJob", attribute.String("server.address", r.dashboardURL))
+	defer tracing.End(span, &err)
 	log := ctrl.LoggerFrom(ctx)
-	rayJobJson, err := json.Marshal(request)
-	if err != nil {
-		return
-	}
 	if name != nil {
-		log.Info("Submit a ray job", "rayJob", name, "jobInfo", string(rayJobJson))
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.dashboardURL+JobPath, bytes.NewBuffer(rayJobJson))
-	if err != nil {
-		return
+		log.Info("Submit a ray job", "rayJob", name, "entrypoint", request.Entrypoint, "submissionId", request.SubmissionId)
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := r.client.Do(req)
-	if err != nil {
-		return
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return "", fmt.Errorf("SubmitJob fail: %s %s", resp.Status, string(body))
-	}
-
-	var jobResp RayJobResponse
-	if err = json.Unmarshal(body, &jobResp); err != nil {
-		// Maybe body is not valid json, raise an error with the body.
-		return "", fmt.Errorf("SubmitJob fail: %s", string(body))
-	}
-
-	return jobResp.JobId, nil
+	return r.dashboardClient().SubmitJob(ctx, request)
 }
 
 // Get Job Log
 func (r *RayDashboardClient) GetJobLog(ctx context.Context, jobName string) (*string, error) {
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Get ray job log", "rayJob", jobName)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.dashboardURL+JobPath+jobName+"/logs", nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := r.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusNotFound {
-		// This does the right thing, but breaks E2E test
-		//		return nil, errors.NewBadRequest("Job " + jobId + " does not exist on the cluster")
-		return nil, nil
-	}
-
-	body, err := io.ReadAll(resp.Body)
+	logs, err := r.dashboardClient().GetJobLogs(ctx, jobName)
 	if err != nil {
+		if dashboard.IsNotFound(err) {
+			// This does the right thing, but breaks E2E test
+			//		return nil, errors.NewBadRequest("Job " + jobId + " does not exist on the cluster")
+			return nil, nil
+		}
 		return nil, err
 	}
-
-	var jobLog RayJobLogsResponse
-	if err = json.Unmarshal(body, &jobLog); err != nil {
-		// Maybe body is not valid json, raise an error with the body.
-		return nil, fmt.Errorf("GetJobLog fail: %s", string(body))
-	}
-
-	return &jobLog.Logs, nil
+	return &logs, nil
 }
 
 func (r *RayDashboardClient) StopJob(ctx context.Context, jobName string) (err error) {
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Stop a ray job", "rayJob", jobName)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.dashboardURL+JobPath+jobName+"/stop", nil)
-	if err != nil {
-		return err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := r.client.Do(req)
+	stopped, err := r.dashboardClient().StopJob(ctx, jobName)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	var jobStopResp RayJobStopResponse
-	if err = json.Unmarshal(body, &jobStopResp); err != nil {
-		return err
-	}
-
-	if !jobStopResp.Stopped {
+	if !stopped {
 		jobInfo, err := r.GetJobInfo(ctx, jobName)
 		if err != nil {
 			return err
@@ -433,20 +403,42 @@ func (r *RayDashboardClient) StopJob(ctx context.Context, jobName string) (err e
 func (r *RayDashboardClient) DeleteJob(ctx context.Context, jobName string) error {
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Delete a ray job", "rayJob", jobName)
+	if err := r.dashboardClient().DeleteJob(ctx, jobName); err != nil && !dashboard.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, r.dashboardURL+JobPath+jobName, nil)
+// GetClusterStatus returns the state of the autoscaler reported by the cluster status api.
+func (r *RayDashboardClient) GetClusterStatus(ctx context.Context) (*ClusterStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.dashboardURL+ClusterStatusPath, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
 	resp, err := r.client.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	return nil
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GetClusterStatus fail: %s %s", resp.Status, string(body))
+	}
+
+	var clusterStatusResp ClusterStatusResponse
+	if err = json.Unmarshal(body, &clusterStatusResp); err != nil {
+		return nil, fmt.Errorf("GetClusterStatus fail: %s", string(body))
+	}
+	if !clusterStatusResp.Result {
+		return nil, fmt.Errorf("GetClusterStatus fail: %s", clusterStatusResp.Message)
+	}
+
+	return &clusterStatusResp.Data, nil
 }
 
 func ConvertRayJobToReq(rayJob *rayv1.RayJob) (*RayJobRequest, error) {
@@ -455,13 +447,11 @@ func ConvertRayJobToReq(rayJob *rayv1.RayJob) (*RayJobRequest, error) {
 		SubmissionId: rayJob.Status.JobId,
 		Metadata:     rayJob.Spec.Metadata,
 	}
-	if len(rayJob.Spec.RuntimeEnvYAML) != 0 {
-		runtimeEnv, err := UnmarshalRuntimeEnvYAML(rayJob.Spec.RuntimeEnvYAML)
-		if err != nil {
-			return nil, err
-		}
-		req.RuntimeEnv = runtimeEnv
+	runtimeEnv, err := GetRuntimeEnv(rayJob)
+	if err != nil {
+		return nil, err
 	}
+	req.RuntimeEnv = runtimeEnv
 	req.NumCpus = rayJob.Spec.EntrypointNumCpus
 	req.NumGpus = rayJob.Spec.EntrypointNumGpus
 	if rayJob.Spec.EntrypointResources != "" {
@@ -472,6 +462,29 @@ func ConvertRayJobToReq(rayJob *rayv1.RayJob) (*RayJobRequest, error) {
 	return req, nil
 }
 
+// GetRuntimeEnv returns the runtime environment of the Ray job from runtimeEnv or runtimeEnvYAML, or nil if it has none.
+// The keys of the Secrets and ConfigMaps referenced by runtimeEnv.envFrom are not included.
+func GetRuntimeEnv(rayJob *rayv1.RayJob) (RuntimeEnvType, error) {
+	spec := rayJob.Spec.RuntimeEnv
+	if spec == nil {
+		if len(rayJob.Spec.RuntimeEnvYAML) == 0 {
+			return nil, nil
+		}
+		return UnmarshalRuntimeEnvYAML(rayJob.Spec.RuntimeEnvYAML)
+	}
+	runtimeEnv := RuntimeEnvType{}
+	if spec.WorkingDir != "" {
+		runtimeEnv["working_dir"] = spec.WorkingDir
+	}
+	if len(spec.Pip) != 0 {
+		runtimeEnv["pip"] = spec.Pip
+	}
+	if len(spec.EnvVars) != 0 {
+		runtimeEnv["env_vars"] = spec.EnvVars
+	}
+	return runtimeEnv, nil
+}
+
 func UnmarshalRuntimeEnvYAML(runtimeEnvYAML string) (RuntimeEnvType, error) {
 	var runtimeEnv RuntimeEnvType
 	err := yaml.Unmarshal([]byte(runtimeEnvYAML), &runtimeEnv)
@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
@@ -64,6 +68,24 @@ var _ = Describe("RayFrameworkGenerator", func() {
 		Expect(rayJobRequest.RuntimeEnv["working_dir"]).To(Equal("./"))
 	})
 
+	It("Test ConvertRayJobToReq with RuntimeEnv", func() {
+		rayJobRequest, err := ConvertRayJobToReq(&rayv1.RayJob{
+			Spec: rayv1.RayJobSpec{
+				RuntimeEnv: &rayv1.RuntimeEnv{
+					WorkingDir: "./",
+					Pip:        []string{"requests==2.26.0"},
+					EnvVars:    map[string]string{"counter_name": "test_counter"},
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rayJobRequest.RuntimeEnv).To(Equal(RuntimeEnvType{
+			"working_dir": "./",
+			"pip":         []string{"requests==2.26.0"},
+			"env_vars":    map[string]string{"counter_name": "test_counter"},
+		}))
+	})
+
 	It("Test ConvertRayJobToReq with EntrypointResources", func() {
 		rayJobRequest, err := ConvertRayJobToReq(&rayv1.RayJob{
 			Spec: rayv1.RayJobSpec{
@@ -171,4 +193,150 @@ var _ = Describe("RayFrameworkGenerator", func() {
 		err := rayDashboardClient.StopJob(context.TODO(), "stop-job-1")
 		Expect(err).ToNot(HaveOccurred())
 	})
+
+	It("Test getting the cluster status", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ClusterStatusPath,
+			func(_ *http.Request) (*http.Response, error) {
+				body := &ClusterStatusResponse{
+					Result: true,
+					Data: ClusterStatus{
+						AutoscalingError: "Failed to launch 1 node(s) of type gpu-group",
+					},
+				}
+				bodyBytes, _ := json.Marshal(body)
+				return httpmock.NewBytesResponse(200, bodyBytes), nil
+			})
+
+		clusterStatus, err := rayDashboardClient.GetClusterStatus(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clusterStatus.AutoscalingError).To(Equal("Failed to launch 1 node(s) of type gpu-group"))
+	})
+
+	It("Test getting the cluster status when the dashboard fails", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ClusterStatusPath,
+			func(_ *http.Request) (*http.Response, error) {
+				body := &ClusterStatusResponse{
+					Result:  false,
+					Message: "Failed to get cluster status",
+				}
+				bodyBytes, _ := json.Marshal(body)
+				return httpmock.NewBytesResponse(200, bodyBytes), nil
+			})
+
+		_, err := rayDashboardClient.GetClusterStatus(context.TODO())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Failed to get cluster status"))
+	})
+
+	It("Test retrying the requests to the dashboard", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		rayDashboardClient = &RayDashboardClient{options: DashboardClientOptions{MaxRetries: 2}}
+		Expect(rayDashboardClient.InitClient(context.Background(), "127.0.0.1:8090", nil)).To(Succeed())
+		attempts := 0
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ClusterStatusPath,
+			func(_ *http.Request) (*http.Response, error) {
+				attempts++
+				if attempts < 3 {
+					return httpmock.NewStringResponse(http.StatusServiceUnavailable, "dashboard is starting"), nil
+				}
+				bodyBytes, _ := json.Marshal(&ClusterStatusResponse{Result: true})
+				return httpmock.NewBytesResponse(200, bodyBytes), nil
+			})
+
+		_, err := rayDashboardClient.GetClusterStatus(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(attempts).To(Equal(3))
+	})
+
+	It("Test failing fast once the circuit breaker of the dashboard opens", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		breaker := newDashboardCircuitBreaker(2, time.Minute)
+		rayDashboardClient = &RayDashboardClient{breaker: breaker}
+		Expect(rayDashboardClient.InitClient(context.Background(), "127.0.0.1:8090", nil)).To(Succeed())
+		attempts := 0
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ClusterStatusPath,
+			func(_ *http.Request) (*http.Response, error) {
+				attempts++
+				return httpmock.NewStringResponse(http.StatusInternalServerError, "internal error"), nil
+			})
+
+		for range 2 {
+			_, err := rayDashboardClient.GetClusterStatus(context.TODO())
+			Expect(err).To(HaveOccurred())
+		}
+		_, err := rayDashboardClient.GetClusterStatus(context.TODO())
+		Expect(err).To(MatchError(ErrDashboardCircuitOpen))
+		Expect(attempts).To(Equal(2))
+
+		// The requests are sent again once the cooldown is over.
+		Expect(breaker.allow(rayDashboardClient.dashboardURL, time.Now().Add(time.Minute))).To(BeTrue())
+	})
+	It("Test authenticating the requests to the dashboard over HTTPS", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "dashboard-auth", Namespace: "default"},
+			Data: map[string][]byte{
+				"token":  []byte("my-token\n"),
+				"cookie": []byte("session=my-session"),
+			},
+		}
+		rayCluster := &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "raycluster-sample", Namespace: "default"},
+			Spec: rayv1.RayClusterSpec{
+				DashboardClient: &rayv1.DashboardClientConfig{
+					TLS: &rayv1.DashboardTLSConfig{},
+					BearerTokenSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "dashboard-auth"},
+						Key:                  "token",
+					},
+					CookieSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "dashboard-auth"},
+						Key:                  "cookie",
+					},
+				},
+			},
+		}
+		rayDashboardClient = &RayDashboardClient{secretReader: clientFake.NewClientBuilder().WithObjects(secret).Build()}
+		Expect(rayDashboardClient.InitClient(context.Background(), "127.0.0.1:8090", rayCluster)).To(Succeed())
+		Expect(rayDashboardClient.dashboardURL).To(Equal("https://127.0.0.1:8090"))
+
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ClusterStatusPath,
+			func(req *http.Request) (*http.Response, error) {
+				if req.Header.Get("Authorization") != "Bearer my-token" || req.Header.Get("Cookie") != "session=my-session" {
+					return httpmock.NewStringResponse(http.StatusUnauthorized, "unauthorized"), nil
+				}
+				bodyBytes, _ := json.Marshal(&ClusterStatusResponse{Result: true})
+				return httpmock.NewBytesResponse(200, bodyBytes), nil
+			})
+		_, err := rayDashboardClient.GetClusterStatus(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Test failing to read a missing Secret of the dashboard client", func() {
+		rayCluster := &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "raycluster-sample", Namespace: "default"},
+			Spec: rayv1.RayClusterSpec{
+				DashboardClient: &rayv1.DashboardClientConfig{
+					BearerTokenSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+						Key:                  "token",
+					},
+				},
+			},
+		}
+		rayDashboardClient = &RayDashboardClient{secretReader: clientFake.NewClientBuilder().Build()}
+		Expect(rayDashboardClient.InitClient(context.Background(), "127.0.0.1:8090", rayCluster)).ToNot(Succeed())
+
+		// The Secret can be optional.
+		rayCluster.Spec.DashboardClient.BearerTokenSecretRef.Optional = ptr.To(true)
+		Expect(rayDashboardClient.InitClient(context.Background(), "127.0.0.1:8090", rayCluster)).To(Succeed())
+		Expect(rayDashboardClient.dashboardURL).To(Equal("http://127.0.0.1:8090"))
+	})
 })
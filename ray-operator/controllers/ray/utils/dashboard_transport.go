@@ -0,0 +1,281 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDashboardClientTimeout bounds each request to a Ray dashboard, including its retries.
+	DefaultDashboardClientTimeout = 2 * time.Second
+	// DefaultDashboardClientMaxRetries is the number of times an idempotent request to a Ray dashboard is retried.
+	DefaultDashboardClientMaxRetries = 2
+	// DefaultDashboardClientCircuitBreakerThreshold is the number of consecutive failed requests to a Ray dashboard
+	// after which the requests to it fail fast.
+	DefaultDashboardClientCircuitBreakerThreshold = 5
+	// DefaultDashboardClientCircuitBreakerCooldown is how long the requests to a Ray dashboard fail fast.
+	DefaultDashboardClientCircuitBreakerCooldown = 30 * time.Second
+
+	// dashboardRetryBaseDelay is the delay before the first retry of a request, doubled on each retry up to
+	// dashboardRetryMaxDelay.
+	dashboardRetryBaseDelay = 100 * time.Millisecond
+	dashboardRetryMaxDelay  = time.Second
+	// dashboardMaxIdleConnsPerHost is the number of idle connections kept open to each Ray dashboard, so that the
+	// periodic requests of the controllers reuse them.
+	dashboardMaxIdleConnsPerHost = 4
+)
+
+// ErrDashboardCircuitOpen is returned by the requests to a Ray dashboard whose previous requests kept failing, until
+// the cooldown of its circuit breaker is over.
+var ErrDashboardCircuitOpen = errors.New("the requests to the Ray dashboard are failing fast after consecutive failures")
+
+// DashboardClientOptions configures the requests of the dashboard clients to the Ray dashboards.
+type DashboardClientOptions struct {
+	// Timeout bounds each request, including its retries. Defaults to DefaultDashboardClientTimeout if zero.
+	Timeout time.Duration
+	// MaxRetries is the number of times an idempotent request is retried with an exponential backoff on connection
+	// errors and 502, 503 and 504 responses. Disabled if zero.
+	MaxRetries int
+	// CircuitBreakerThreshold is the number of consecutive failed requests to a Ray dashboard after which the requests
+	// to it fail fast with ErrDashboardCircuitOpen for CircuitBreakerCooldown, so that an unreachable head Pod does not
+	// slow down the reconciliations. Disabled if zero.
+	CircuitBreakerThreshold int
+	// CircuitBreakerCooldown defaults to DefaultDashboardClientCircuitBreakerCooldown if zero.
+	CircuitBreakerCooldown time.Duration
+}
+
+// DefaultDashboardClientOptions returns the options of the dashboard clients of the operator by default.
+func DefaultDashboardClientOptions() DashboardClientOptions {
+	return DashboardClientOptions{
+		Timeout:                 DefaultDashboardClientTimeout,
+		MaxRetries:              DefaultDashboardClientMaxRetries,
+		CircuitBreakerThreshold: DefaultDashboardClientCircuitBreakerThreshold,
+		CircuitBreakerCooldown:  DefaultDashboardClientCircuitBreakerCooldown,
+	}
+}
+
+func (options DashboardClientOptions) timeout() time.Duration {
+	if options.Timeout <= 0 {
+		return DefaultDashboardClientTimeout
+	}
+	return options.Timeout
+}
+
+// newDashboardTransport returns the transport shared by the dashboard clients connecting to the head Services
+// directly, so that they reuse the connections to each Ray dashboard.
+func newDashboardTransport() http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = dashboardMaxIdleConnsPerHost
+	return transport
+}
+
+// dashboardTLSTransports holds a transport for each set of CA certificates that the certificates of Ray dashboards are
+// verified with, so that the dashboard clients of the same RayCluster reuse their connections.
+type dashboardTLSTransports struct {
+	transports map[[sha256.Size]byte]http.RoundTripper
+	mu         sync.Mutex
+}
+
+// get returns the transport verifying the certificates of the dashboards with the given PEM-encoded CA certificates.
+// A new transport is returned each time if t is nil.
+func (t *dashboardTLSTransports) get(caPEM []byte) (http.RoundTripper, error) {
+	var key [sha256.Size]byte
+	if t != nil {
+		key = sha256.Sum256(caPEM)
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if transport, ok := t.transports[key]; ok {
+			return transport, nil
+		}
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("no valid PEM-encoded CA certificate found for the dashboard client")
+	}
+	transport := newDashboardTransport().(*http.Transport)
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	if t != nil {
+		t.transports[key] = transport
+	}
+	return transport, nil
+}
+
+// headerTransport sets headers on the requests, e.g. to authenticate them.
+type headerTransport struct {
+	next   http.RoundTripper
+	header http.Header
+}
+
+// newHeaderTransport returns next if there is no header to set.
+func newHeaderTransport(next http.RoundTripper, header http.Header) http.RoundTripper {
+	if len(header) == 0 {
+		return next
+	}
+	return &headerTransport{next: next, header: header}
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	// A RoundTripper must not modify the request.
+	req = req.Clone(req.Context())
+	for key, values := range t.header {
+		req.Header[key] = values
+	}
+	return next.RoundTrip(req)
+}
+
+// retryTransport retries the idempotent requests that failed with a connection error or a 502, 503 or 504 response,
+// e.g. while the dashboard of a head Pod restarts.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The body of a request can only be resent if it can be recreated.
+	if t.maxRetries <= 0 || !isIdempotentMethod(req.Method) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt == t.maxRetries || !isRetriableResponse(resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(min(dashboardRetryBaseDelay<<attempt, dashboardRetryMaxDelay))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		attemptReq = req.Clone(ctx)
+		if req.GetBody != nil {
+			if attemptReq.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isRetriableResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// dashboardCircuitBreaker tracks the consecutive failed requests to each Ray dashboard. It is shared by all the
+// dashboard clients of the operator, as they are created for each reconciliation.
+type dashboardCircuitBreaker struct {
+	states    map[string]*dashboardCircuitState
+	threshold int
+	cooldown  time.Duration
+	mu        sync.Mutex
+}
+
+type dashboardCircuitState struct {
+	lastFailure time.Time
+	openUntil   time.Time
+	failures    int
+}
+
+func newDashboardCircuitBreaker(threshold int, cooldown time.Duration) *dashboardCircuitBreaker {
+	if cooldown <= 0 {
+		cooldown = DefaultDashboardClientCircuitBreakerCooldown
+	}
+	return &dashboardCircuitBreaker{
+		states:    map[string]*dashboardCircuitState{},
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow returns whether a request to the dashboard can be sent. Once the cooldown is over, the requests are sent
+// again, and the circuit opens again on the first failure.
+func (b *dashboardCircuitBreaker) allow(dashboardURL string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.states[dashboardURL]
+	return !ok || !now.Before(state.openUntil)
+}
+
+func (b *dashboardCircuitBreaker) record(dashboardURL string, failed bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed {
+		delete(b.states, dashboardURL)
+		return
+	}
+
+	// Forget the dashboards that stopped failing without a successful request, e.g. of deleted RayClusters.
+	for url, state := range b.states {
+		if now.Sub(state.lastFailure) > b.cooldown && !now.Before(state.openUntil) {
+			delete(b.states, url)
+		}
+	}
+	state, ok := b.states[dashboardURL]
+	if !ok {
+		state = &dashboardCircuitState{}
+		b.states[dashboardURL] = state
+	}
+	state.failures++
+	state.lastFailure = now
+	if state.failures >= b.threshold {
+		state.openUntil = now.Add(b.cooldown)
+	}
+}
+
+// circuitBreakerTransport fails the requests to a Ray dashboard fast while its circuit breaker is open.
+type circuitBreakerTransport struct {
+	next         http.RoundTripper
+	breaker      *dashboardCircuitBreaker
+	dashboardURL string
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.breaker.allow(t.dashboardURL, time.Now()) {
+		return nil, fmt.Errorf("%w: %s", ErrDashboardCircuitOpen, t.dashboardURL)
+	}
+	resp, err := t.next.RoundTrip(req)
+	// The requests canceled by the caller do not tell anything about the dashboard, unlike the timed out ones.
+	if err == nil || !errors.Is(req.Context().Err(), context.Canceled) {
+		t.breaker.record(t.dashboardURL, err != nil || resp.StatusCode >= http.StatusInternalServerError, time.Now())
+	}
+	return resp, err
+}
@@ -13,7 +13,8 @@ type FakeRayDashboardClient struct {
 	multiAppStatuses map[string]*ServeApplicationStatus
 	GetJobInfoMock   atomic.Pointer[func(context.Context, string) (*RayJobInfo, error)]
 	BaseDashboardClient
-	serveDetails ServeDetails
+	serveDetails  ServeDetails
+	clusterStatus ClusterStatus
 }
 
 var _ RayDashboardClientInterface = (*FakeRayDashboardClient)(nil)
@@ -79,3 +80,11 @@ func (r *FakeRayDashboardClient) StopJob(_ context.Context, _ string) (err error
 func (r *FakeRayDashboardClient) DeleteJob(_ context.Context, _ string) error {
 	return nil
 }
+
+func (r *FakeRayDashboardClient) GetClusterStatus(_ context.Context) (*ClusterStatus, error) {
+	return &r.clusterStatus, nil
+}
+
+func (r *FakeRayDashboardClient) SetClusterStatus(clusterStatus ClusterStatus) {
+	r.clusterStatus = clusterStatus
+}
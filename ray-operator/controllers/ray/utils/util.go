@@ -8,11 +8,13 @@ import (
 	"math"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
+	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -22,7 +24,11 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
@@ -41,12 +47,68 @@ const (
 	RayClusterCRD CRDType = "RayCluster"
 	RayJobCRD     CRDType = "RayJob"
 	RayServiceCRD CRDType = "RayService"
+	RayCronJobCRD CRDType = "RayCronJob"
 )
 
 var crdMap = map[string]CRDType{
 	"RayCluster": RayClusterCRD,
 	"RayJob":     RayJobCRD,
 	"RayService": RayServiceCRD,
+	"RayCronJob": RayCronJobCRD,
+}
+
+// LogConstructor returns the constructor of the loggers of the controller of the custom resources of the given kind.
+// The log lines of a reconciliation carry the namespace and name of the custom resource, and controller-runtime adds the
+// reconcileID of the reconciliation, so that they can be correlated.
+func LogConstructor(kind CRDType) func(*reconcile.Request) logr.Logger {
+	return func(request *reconcile.Request) logr.Logger {
+		logger := ctrl.Log.WithName("controllers").WithName(string(kind))
+		if request != nil {
+			logger = logger.WithValues(string(kind), request.NamespacedName, "namespace", request.Namespace, "name", request.Name)
+		}
+		return logger
+	}
+}
+
+// PatchStatus writes the status of obj with a JSON patch replacing its whole status. A JSON merge patch is not used, as
+// it would not remove the entries deleted from the maps of the status, e.g. the Serve applications of a RayService. The
+// patch carries the resourceVersion of obj, so that it fails with a conflict if the object was modified since it was
+// read, like Status().Update(), instead of overwriting the status with one computed from an outdated object. The
+// conflict is not retried, as the status has to be recomputed from the latest object: callers requeue the object to
+// read it again.
+func PatchStatus(ctx context.Context, c client.Client, obj client.Object) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	status := fields["status"]
+	if status == nil {
+		status = map[string]interface{}{}
+	}
+	patch, err := json.Marshal([]map[string]interface{}{
+		// The API server rejects the patch with a conflict if the resourceVersion is not the latest one.
+		{"op": "replace", "path": "/metadata/resourceVersion", "value": obj.GetResourceVersion()},
+		// The "add" operation replaces the status if it exists, while "replace" would fail if it does not.
+		{"op": "add", "path": "/status", "value": status},
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status().Patch(ctx, obj, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// WithoutShutdownCancel returns a reconciler running the reconciliations of r with a context which is not canceled
+// when the manager stops. The manager stops dequeuing requests on shutdown and waits for the in-flight reconciliations
+// to finish within its graceful shutdown timeout, so that they are not interrupted half-way, e.g. between scaling a
+// RayCluster and updating its status.
+func WithoutShutdownCancel(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+		return r.Reconcile(context.WithoutCancel(ctx), request)
+	})
 }
 
 func GetCRDType(key string) CRDType {
@@ -103,6 +165,158 @@ func FindHeadPodReadyCondition(headPod *corev1.Pod) metav1.Condition {
 	return headPodReadyCondition
 }
 
+// FindAutoscalerUnhealthyCondition returns the AutoscalerUnhealthy condition of a RayCluster based on the status of the
+// autoscaler container, with the given name, of the head Pod. The autoscaler container is an init container if it runs
+// as a native sidecar container.
+func FindAutoscalerUnhealthyCondition(headPod *corev1.Pod, containerName string) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    string(rayv1.RayClusterAutoscalerUnhealthy),
+		Status:  metav1.ConditionUnknown,
+		Reason:  rayv1.UnknownReason,
+		Message: "The status of the autoscaler container is unknown",
+	}
+	containerStatuses := append(slices.Clone(headPod.Status.InitContainerStatuses), headPod.Status.ContainerStatuses...)
+	for _, containerStatus := range containerStatuses {
+		if containerStatus.Name != containerName {
+			continue
+		}
+		state := containerStatus.State
+		switch {
+		case state.Running != nil:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = rayv1.AutoscalerRunning
+			condition.Message = "The autoscaler is running"
+			if containerStatus.RestartCount > 0 {
+				condition.Message = fmt.Sprintf("The autoscaler is running after %d restarts", containerStatus.RestartCount)
+			}
+		case state.Waiting != nil:
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = rayv1.AutoscalerNotRunning
+			if state.Waiting.Reason != "" {
+				condition.Reason = state.Waiting.Reason
+			}
+			condition.Message = fmt.Sprintf("The autoscaler is waiting: %s", state.Waiting.Message)
+		case state.Terminated != nil:
+			// The autoscaler is not restarted if the restart policy of the head Pod is Never.
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = rayv1.AutoscalerNotRunning
+			if state.Terminated.Reason != "" {
+				condition.Reason = state.Terminated.Reason
+			}
+			condition.Message = fmt.Sprintf("The autoscaler exited with code %d: %s", state.Terminated.ExitCode, state.Terminated.Message)
+		}
+		break
+	}
+	return condition
+}
+
+// FindPodsUnschedulableCondition returns the PodsUnschedulable condition of a RayCluster with the given Pods. The
+// message aggregates the reasons why the scheduler cannot place the Pending Pods, e.g. "2 Pods are unschedulable:
+// Insufficient nvidia.com/gpu (2 Pods)", and the reason is the category of the most common one.
+func FindPodsUnschedulableCondition(pods []corev1.Pod) metav1.Condition {
+	numPods := 0
+	podsPerReason := make(map[string]int)
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type != corev1.PodScheduled || cond.Status != corev1.ConditionFalse || cond.Reason != corev1.PodReasonUnschedulable {
+				continue
+			}
+			numPods++
+			for _, reason := range parseUnschedulableReasons(cond.Message) {
+				podsPerReason[reason]++
+			}
+		}
+	}
+	if numPods == 0 {
+		return metav1.Condition{
+			Type:    string(rayv1.RayClusterPodsUnschedulable),
+			Status:  metav1.ConditionFalse,
+			Reason:  rayv1.AllPodsSchedulable,
+			Message: "All Pods are schedulable",
+		}
+	}
+
+	reasons := make([]string, 0, len(podsPerReason))
+	for reason := range podsPerReason {
+		reasons = append(reasons, reason)
+	}
+	// The most common reasons first.
+	slices.SortFunc(reasons, func(a, b string) int {
+		if podsPerReason[a] != podsPerReason[b] {
+			return podsPerReason[b] - podsPerReason[a]
+		}
+		return strings.Compare(a, b)
+	})
+	details := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		details = append(details, fmt.Sprintf("%s (%s)", reason, pluralizePods(podsPerReason[reason])))
+	}
+	condition := metav1.Condition{
+		Type:    string(rayv1.RayClusterPodsUnschedulable),
+		Status:  metav1.ConditionTrue,
+		Reason:  rayv1.Unschedulable,
+		Message: fmt.Sprintf("%d Pods are unschedulable", numPods),
+	}
+	if numPods == 1 {
+		condition.Message = "1 Pod is unschedulable"
+	}
+	if len(details) > 0 {
+		condition.Reason = unschedulableReasonCategory(reasons[0])
+		condition.Message += ": " + strings.Join(details, "; ")
+	}
+	return condition
+}
+
+// parseUnschedulableReasons extracts the reasons from the message of the PodScheduled condition set by the scheduler,
+// e.g. "0/3 nodes are available: 1 Insufficient nvidia.com/gpu, 2 node(s) had untolerated taint {key: value}.
+// preemption: ...".
+func parseUnschedulableReasons(message string) []string {
+	_, message, found := strings.Cut(message, "nodes are available: ")
+	if !found {
+		return nil
+	}
+	message, _, _ = strings.Cut(message, " preemption: ")
+	message = strings.TrimSuffix(strings.TrimSpace(message), ".")
+	var reasons []string
+	for _, item := range strings.Split(message, ", ") {
+		// Remove the number of nodes.
+		if count, reason, found := strings.Cut(strings.TrimSpace(item), " "); found {
+			if _, err := strconv.Atoi(count); err == nil {
+				item = reason
+			}
+		}
+		if item = strings.TrimSpace(item); item != "" && !slices.Contains(reasons, item) {
+			reasons = append(reasons, item)
+		}
+	}
+	return reasons
+}
+
+// unschedulableReasonCategory returns the reason of the PodsUnschedulable condition for a reason reported by the
+// scheduler.
+func unschedulableReasonCategory(reason string) string {
+	switch {
+	case strings.HasPrefix(reason, "Insufficient "), strings.Contains(reason, "Too many pods"):
+		return rayv1.InsufficientResources
+	case strings.Contains(reason, "taint"):
+		return rayv1.UntoleratedTaints
+	case strings.Contains(reason, "node affinity/selector"):
+		return rayv1.NodeSelectorMismatch
+	default:
+		return rayv1.Unschedulable
+	}
+}
+
+func pluralizePods(n int) string {
+	if n == 1 {
+		return "1 Pod"
+	}
+	return fmt.Sprintf("%d Pods", n)
+}
+
 // IsRunningAndReady returns true if pod is in the PodRunning Phase, if it has a condition of PodReady.
 func IsRunningAndReady(pod *corev1.Pod) bool {
 	if pod.Status.Phase != corev1.PodRunning {
@@ -159,7 +373,7 @@ func CheckName(s string) string {
 	if len(s) > maxLength {
 		// shorten the name
 		offset := int(math.Abs(float64(maxLength) - float64(len(s))))
-		fmt.Printf("pod name is too long: len = %v, we will shorten it by offset = %v", len(s), offset)
+		ctrl.Log.V(1).Info("Shortening the name", "name", s, "length", len(s), "offset", offset)
 		s = s[offset:]
 	}
 
@@ -170,7 +384,6 @@ func CheckName(s string) string {
 
 	// cannot start with a punctuation
 	if unicode.IsPunct(rune(s[0])) {
-		fmt.Println(s)
 		s = "r" + s[1:]
 	}
 
@@ -184,13 +397,12 @@ func CheckLabel(s string) string {
 	if len(s) > maxLenght {
 		// shorten the name
 		offset := int(math.Abs(float64(maxLenght) - float64(len(s))))
-		fmt.Printf("label value is too long: len = %v, we will shorten it by offset = %v\n", len(s), offset)
+		ctrl.Log.V(1).Info("Shortening the label value", "value", s, "length", len(s), "offset", offset)
 		s = s[offset:]
 	}
 
 	// cannot start with a punctuation
 	if unicode.IsPunct(rune(s[0])) {
-		fmt.Println(s)
 		s = "r" + s[1:]
 	}
 
@@ -372,13 +584,80 @@ func CalculateAvailableReplicas(pods corev1.PodList) int32 {
 	return count
 }
 
+// CalculatePodStartupDuration summarizes how long the given Pods took from creation until their Ray containers
+// started running. Pods whose Ray containers are not running or have restarted are skipped because the time of
+// their first start is unknown. It returns nil if none of the Pods has started yet.
+func CalculatePodStartupDuration(pods []corev1.Pod) *rayv1.PodStartupDuration {
+	durations := []time.Duration{}
+	for _, pod := range pods {
+		if len(pod.Spec.Containers) == 0 {
+			continue
+		}
+		rayContainerName := pod.Spec.Containers[RayContainerIndex].Name
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == rayContainerName && status.RestartCount == 0 && status.State.Running != nil {
+				durations = append(durations, status.State.Running.StartedAt.Sub(pod.CreationTimestamp.Time))
+			}
+		}
+	}
+	if len(durations) == 0 {
+		return nil
+	}
+
+	slices.Sort(durations)
+	// Use the nearest-rank method so that each percentile is one of the observed durations.
+	percentile := func(p int) metav1.Duration {
+		rank := (len(durations)*p + 99) / 100
+		return metav1.Duration{Duration: durations[rank-1]}
+	}
+	return &rayv1.PodStartupDuration{
+		P50: percentile(50),
+		P90: percentile(90),
+		Max: percentile(100),
+	}
+}
+
+// CalculateGroupStatuses calculates the status of the head group and each worker group of the RayCluster.
+func CalculateGroupStatuses(ctx context.Context, cluster *rayv1.RayCluster, pods corev1.PodList) []rayv1.GroupStatus {
+	podsByGroup := map[string][]corev1.Pod{}
+	for _, pod := range pods.Items {
+		// Skip Pods that are not Ray nodes, e.g. the Pod of the Redis cleanup Job.
+		nodeType := pod.Labels[RayNodeTypeLabelKey]
+		if nodeType != string(rayv1.HeadNode) && nodeType != string(rayv1.WorkerNode) {
+			continue
+		}
+		groupName := pod.Labels[RayNodeGroupLabelKey]
+		podsByGroup[groupName] = append(podsByGroup[groupName], pod)
+	}
+
+	// Interruptions are counted by the reconciler when it replaces Pods, so they are carried over.
+	interruptions := map[string]int32{}
+	for _, groupStatus := range cluster.Status.GroupStatuses {
+		interruptions[groupStatus.GroupName] = groupStatus.Interruptions
+	}
+
+	groupStatuses := []rayv1.GroupStatus{{
+		GroupName:          RayNodeHeadGroupLabelValue,
+		PodStartupDuration: CalculatePodStartupDuration(podsByGroup[RayNodeHeadGroupLabelValue]),
+	}}
+	for _, workerGroup := range cluster.Spec.WorkerGroupSpecs {
+		groupStatuses = append(groupStatuses, rayv1.GroupStatus{
+			GroupName:          workerGroup.GroupName,
+			PodStartupDuration: CalculatePodStartupDuration(podsByGroup[workerGroup.GroupName]),
+			Interruptions:      interruptions[workerGroup.GroupName],
+			DesiredReplicas:    ptr.To(GetWorkerGroupDesiredReplicas(ctx, workerGroup)),
+		})
+	}
+	return groupStatuses
+}
+
 func CalculateDesiredResources(cluster *rayv1.RayCluster) corev1.ResourceList {
 	desiredResourcesList := []corev1.ResourceList{{}}
 	headPodResource := calculatePodResource(cluster.Spec.HeadGroupSpec.Template.Spec)
 	desiredResourcesList = append(desiredResourcesList, headPodResource)
 	for _, nodeGroup := range cluster.Spec.WorkerGroupSpecs {
 		podResource := calculatePodResource(nodeGroup.Template.Spec)
-		for i := int32(0); i < *nodeGroup.Replicas; i++ {
+		for i := int32(0); i < ptr.Deref(nodeGroup.Replicas, 0); i++ {
 			desiredResourcesList = append(desiredResourcesList, podResource)
 		}
 	}
@@ -448,6 +727,18 @@ func Contains(elems []string, searchTerm string) bool {
 	return false
 }
 
+// IsAutoscalingEnabled returns whether in-tree autoscaling is enabled.
+func IsAutoscalingEnabled(spec *rayv1.RayClusterSpec) bool {
+	return spec.EnableInTreeAutoscaling != nil && *spec.EnableInTreeAutoscaling
+}
+
+// IsAutoscalingV2Enabled returns whether in-tree autoscaling is enabled with the Ray autoscaler v2.
+func IsAutoscalingV2Enabled(spec *rayv1.RayClusterSpec) bool {
+	return IsAutoscalingEnabled(spec) &&
+		spec.AutoscalerOptions != nil && spec.AutoscalerOptions.Version != nil &&
+		*spec.AutoscalerOptions.Version == rayv1.AutoscalerVersionV2
+}
+
 // GetHeadGroupServiceAccountName returns the head group service account if it exists.
 // Otherwise, it returns the name of the cluster itself.
 func GetHeadGroupServiceAccountName(cluster *rayv1.RayCluster) string {
@@ -525,6 +816,15 @@ func GenerateJsonHash(obj interface{}) (string, error) {
 	return hashStr, nil
 }
 
+// GeneratePodTemplateHash returns the hash of the Pod template, which is a valid label value.
+func GeneratePodTemplateHash(template corev1.PodTemplateSpec) string {
+	hash, err := GenerateJsonHash(template)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(hash)
+}
+
 // FindContainerPort searches for a specific port $portName in the container.
 // If the port is found in the container, the corresponding port is returned.
 // If the port is not found, the $defaultPort is returned instead.
@@ -573,3 +873,41 @@ type ClientProvider interface {
 	GetDashboardClient(mgr manager.Manager) func() RayDashboardClientInterface
 	GetHttpProxyClient(mgr manager.Manager) func() RayHttpProxyClientInterface
 }
+
+// StripUnusedFields is the transform function of the informer cache. It drops the managed fields of all objects, and
+// the last applied configuration annotation and the container status details that KubeRay does not read from Pods.
+// The annotations of the other objects are kept, as KubeRay updates some of them, e.g. RayClusters, with the whole
+// cached object, whereas Pods are only patched.
+func StripUnusedFields(obj interface{}) (interface{}, error) {
+	if accessor, ok := obj.(metav1.Object); ok {
+		accessor.SetManagedFields(nil)
+	}
+	if pod, ok := obj.(*corev1.Pod); ok {
+		delete(pod.Annotations, corev1.LastAppliedConfigAnnotation)
+		pod.Status.InitContainerStatuses = stripContainerStatuses(pod.Status.InitContainerStatuses)
+		pod.Status.ContainerStatuses = stripContainerStatuses(pod.Status.ContainerStatuses)
+		pod.Status.EphemeralContainerStatuses = nil
+	}
+	return obj, nil
+}
+
+// stripContainerStatuses keeps the state, last termination state, readiness and restart count of the containers, which
+// KubeRay reads to check the Ray, autoscaler and submitter containers. The last termination state keeps the exit code
+// of a container that was restarted, e.g. the submitter container of a RayJob in SidecarMode.
+func stripContainerStatuses(statuses []corev1.ContainerStatus) []corev1.ContainerStatus {
+	if statuses == nil {
+		return nil
+	}
+	stripped := make([]corev1.ContainerStatus, 0, len(statuses))
+	for _, status := range statuses {
+		stripped = append(stripped, corev1.ContainerStatus{
+			Name:                 status.Name,
+			State:                status.State,
+			LastTerminationState: status.LastTerminationState,
+			Ready:                status.Ready,
+			RestartCount:         status.RestartCount,
+			Started:              status.Started,
+		})
+	}
+	return stripped
+}
@@ -4,10 +4,16 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -290,6 +296,34 @@ func createRayHeadPodWithPhaseAndCondition(phase corev1.PodPhase, typ corev1.Pod
 	}
 }
 
+func TestIsAutoscalingV2Enabled(t *testing.T) {
+	v1, v2 := rayv1.AutoscalerVersionV1, rayv1.AutoscalerVersionV2
+	tests := map[string]struct {
+		spec rayv1.RayClusterSpec
+		want bool
+	}{
+		"autoscaling disabled": {
+			spec: rayv1.RayClusterSpec{AutoscalerOptions: &rayv1.AutoscalerOptions{Version: &v2}},
+		},
+		"autoscaling enabled without a version": {
+			spec: rayv1.RayClusterSpec{EnableInTreeAutoscaling: ptr.To(true)},
+		},
+		"autoscaler v1": {
+			spec: rayv1.RayClusterSpec{EnableInTreeAutoscaling: ptr.To(true), AutoscalerOptions: &rayv1.AutoscalerOptions{Version: &v1}},
+		},
+		"autoscaler v2": {
+			spec: rayv1.RayClusterSpec{EnableInTreeAutoscaling: ptr.To(true), AutoscalerOptions: &rayv1.AutoscalerOptions{Version: &v2}},
+			want: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsAutoscalingV2Enabled(&tc.spec))
+		})
+	}
+}
+
 func TestGetHeadGroupServiceAccountName(t *testing.T) {
 	tests := map[string]struct {
 		input *rayv1.RayCluster
@@ -410,6 +444,76 @@ func TestCalculateAvailableReplicas(t *testing.T) {
 	assert.Equal(t, readyCount, int32(1), "expect 1 ready replica")
 }
 
+func TestCalculateGroupStatuses(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newPod := func(nodeType rayv1.RayNodeType, groupName string, startupSeconds int, restartCount int32) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(createdAt),
+				Labels: map[string]string{
+					RayNodeTypeLabelKey:  string(nodeType),
+					RayNodeGroupLabelKey: groupName,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "ray"}},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:         "ray",
+					RestartCount: restartCount,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{
+							StartedAt: metav1.NewTime(createdAt.Add(time.Duration(startupSeconds) * time.Second)),
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	cluster := &rayv1.RayCluster{
+		Spec: rayv1.RayClusterSpec{
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{GroupName: "cpu", Replicas: ptr.To[int32](10), MinReplicas: ptr.To[int32](0), MaxReplicas: ptr.To[int32](20)},
+				{GroupName: "gpu", Replicas: ptr.To[int32](0), MinReplicas: ptr.To[int32](1), MaxReplicas: ptr.To[int32](4)},
+			},
+		},
+	}
+	pods := corev1.PodList{Items: []corev1.Pod{
+		newPod(rayv1.HeadNode, RayNodeHeadGroupLabelValue, 30, 0),
+		newPod(rayv1.RedisCleanupNode, RayNodeHeadGroupLabelValue, 1, 0),
+	}}
+	for i := 1; i <= 10; i++ {
+		pods.Items = append(pods.Items, newPod(rayv1.WorkerNode, "cpu", i*10, 0))
+	}
+	// The first start of a restarted container is unknown, so the Pod is skipped.
+	pods.Items = append(pods.Items, newPod(rayv1.WorkerNode, "cpu", 1000, 1))
+
+	groupStatuses := CalculateGroupStatuses(context.Background(), cluster, pods)
+	assert.Equal(t, []rayv1.GroupStatus{
+		{
+			GroupName: RayNodeHeadGroupLabelValue,
+			PodStartupDuration: &rayv1.PodStartupDuration{
+				P50: metav1.Duration{Duration: 30 * time.Second},
+				P90: metav1.Duration{Duration: 30 * time.Second},
+				Max: metav1.Duration{Duration: 30 * time.Second},
+			},
+		},
+		{
+			GroupName: "cpu",
+			PodStartupDuration: &rayv1.PodStartupDuration{
+				P50: metav1.Duration{Duration: 50 * time.Second},
+				P90: metav1.Duration{Duration: 90 * time.Second},
+				Max: metav1.Duration{Duration: 100 * time.Second},
+			},
+			DesiredReplicas: ptr.To[int32](10),
+		},
+		// The desired replicas are clamped to the minReplicas of the worker group.
+		{GroupName: "gpu", DesiredReplicas: ptr.To[int32](1)},
+	}, groupStatuses)
+}
+
 func TestFindContainerPort(t *testing.T) {
 	container := corev1.Container{
 		Name: "ray-head",
@@ -649,6 +753,143 @@ func TestFindHeadPodReadyCondition(t *testing.T) {
 	}
 }
 
+func TestFindAutoscalerUnhealthyCondition(t *testing.T) {
+	headPodWithAutoscaler := func(containerStatus corev1.ContainerStatus) *corev1.Pod {
+		containerStatus.Name = "autoscaler"
+		return &corev1.Pod{
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "ray-head"}, containerStatus},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		pod            *corev1.Pod
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		"condition false if the autoscaler is running": {
+			pod: headPodWithAutoscaler(corev1.ContainerStatus{
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}),
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: rayv1.AutoscalerRunning,
+		},
+		"condition true if the autoscaler keeps crashing": {
+			pod: headPodWithAutoscaler(corev1.ContainerStatus{
+				State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				RestartCount: 5,
+			}),
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "CrashLoopBackOff",
+		},
+		"condition true if the autoscaler exited": {
+			pod: headPodWithAutoscaler(corev1.ContainerStatus{
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137}},
+			}),
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: rayv1.AutoscalerNotRunning,
+		},
+		"condition true if the autoscaler native sidecar keeps crashing": {
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					InitContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "autoscaler",
+						State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					}},
+					ContainerStatuses: []corev1.ContainerStatus{{Name: "ray-head"}},
+				},
+			},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "CrashLoopBackOff",
+		},
+		"condition unknown if the autoscaler has no status": {
+			pod:            &corev1.Pod{},
+			expectedStatus: metav1.ConditionUnknown,
+			expectedReason: rayv1.UnknownReason,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			condition := FindAutoscalerUnhealthyCondition(tc.pod, "autoscaler")
+			assert.Equal(t, string(rayv1.RayClusterAutoscalerUnhealthy), condition.Type)
+			assert.Equal(t, tc.expectedStatus, condition.Status)
+			assert.Equal(t, tc.expectedReason, condition.Reason)
+		})
+	}
+}
+
+func TestFindPodsUnschedulableCondition(t *testing.T) {
+	unschedulablePod := func(message string) corev1.Pod {
+		return corev1.Pod{
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: message,
+				}},
+			},
+		}
+	}
+	insufficientGPU := "0/3 nodes are available: 1 node(s) had untolerated taint {node-role.kubernetes.io/control-plane: }, " +
+		"2 Insufficient nvidia.com/gpu. preemption: 0/3 nodes are available: 1 Preemption is not helpful for scheduling, " +
+		"2 No preemption victims found for incoming pod."
+	untoleratedTaint := "0/1 nodes are available: 1 node(s) had untolerated taint {dedicated: ray}. preemption: 0/1 nodes are available: " +
+		"1 Preemption is not helpful for scheduling."
+
+	tests := map[string]struct {
+		expected metav1.Condition
+		pods     []corev1.Pod
+	}{
+		"all Pods are schedulable": {
+			pods: []corev1.Pod{*createRayHeadPodWithPhaseAndCondition(corev1.PodRunning, corev1.PodReady, corev1.ConditionTrue)},
+			expected: metav1.Condition{
+				Type:    string(rayv1.RayClusterPodsUnschedulable),
+				Status:  metav1.ConditionFalse,
+				Reason:  rayv1.AllPodsSchedulable,
+				Message: "All Pods are schedulable",
+			},
+		},
+		"aggregate the reasons of the unschedulable Pods": {
+			pods: []corev1.Pod{unschedulablePod(insufficientGPU), unschedulablePod(insufficientGPU), unschedulablePod(untoleratedTaint)},
+			expected: metav1.Condition{
+				Type:   string(rayv1.RayClusterPodsUnschedulable),
+				Status: metav1.ConditionTrue,
+				Reason: rayv1.InsufficientResources,
+				Message: "3 Pods are unschedulable: Insufficient nvidia.com/gpu (2 Pods); " +
+					"node(s) had untolerated taint {node-role.kubernetes.io/control-plane: } (2 Pods); node(s) had untolerated taint {dedicated: ray} (1 Pod)",
+			},
+		},
+		"untolerated taints": {
+			pods: []corev1.Pod{unschedulablePod(untoleratedTaint)},
+			expected: metav1.Condition{
+				Type:    string(rayv1.RayClusterPodsUnschedulable),
+				Status:  metav1.ConditionTrue,
+				Reason:  rayv1.UntoleratedTaints,
+				Message: "1 Pod is unschedulable: node(s) had untolerated taint {dedicated: ray} (1 Pod)",
+			},
+		},
+		"unknown scheduler message": {
+			pods: []corev1.Pod{unschedulablePod("pod has unbound immediate PersistentVolumeClaims")},
+			expected: metav1.Condition{
+				Type:    string(rayv1.RayClusterPodsUnschedulable),
+				Status:  metav1.ConditionTrue,
+				Reason:  rayv1.Unschedulable,
+				Message: "1 Pod is unschedulable",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FindPodsUnschedulableCondition(tc.pods))
+		})
+	}
+}
+
 func TestErrRayClusterReplicaFailureReason(t *testing.T) {
 	assert.Equal(t, RayClusterReplicaFailureReason(ErrFailedDeleteAllPods), "FailedDeleteAllPods")
 	assert.Equal(t, RayClusterReplicaFailureReason(ErrFailedDeleteHeadPod), "FailedDeleteHeadPod")
@@ -662,3 +903,118 @@ func TestErrRayClusterReplicaFailureReason(t *testing.T) {
 	assert.Equal(t, RayClusterReplicaFailureReason(errors.Join(ErrFailedCreateWorkerPod, errors.New("other error"))), "FailedCreateWorkerPod")
 	assert.Equal(t, RayClusterReplicaFailureReason(errors.New("other error")), "")
 }
+
+func TestWithoutShutdownCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var reconcileErr error
+	reconciler := WithoutShutdownCancel(reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+		reconcileErr = ctx.Err()
+		return reconcile.Result{}, nil
+	}))
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{})
+	assert.Nil(t, err)
+	// The in-flight reconciliation is not interrupted by the shutdown of the manager.
+	assert.Nil(t, reconcileErr)
+}
+
+func TestPatchStatus(t *testing.T) {
+	ctx := context.Background()
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Status: rayv1.RayServiceStatuses{
+			ServiceStatus: rayv1.WaitForServeDeploymentReady,
+			ActiveServiceStatus: rayv1.RayServiceStatus{
+				Applications: map[string]rayv1.AppStatus{
+					"app1": {Status: rayv1.ApplicationStatusEnum.RUNNING},
+					"app2": {Status: rayv1.ApplicationStatusEnum.RUNNING},
+				},
+			},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithObjects(rayService).WithStatusSubresource(rayService).Build()
+
+	// Modify the RayService after it was read, so that its resourceVersion is stale.
+	stale := &rayv1.RayService{}
+	err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rayService), stale)
+	assert.Nil(t, err)
+	latest := stale.DeepCopy()
+	latest.Labels = map[string]string{"team": "ml"}
+	err = fakeClient.Update(ctx, latest)
+	assert.Nil(t, err)
+
+	// The status computed from the outdated RayService is not written.
+	stale.Status.ServiceStatus = rayv1.Running
+	delete(stale.Status.ActiveServiceStatus.Applications, "app2")
+	err = PatchStatus(ctx, fakeClient, stale)
+	assert.True(t, apierrors.IsConflict(err))
+
+	// The status recomputed from the latest RayService is written.
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(rayService), latest)
+	assert.Nil(t, err)
+	latest.Status.ServiceStatus = rayv1.Running
+	delete(latest.Status.ActiveServiceStatus.Applications, "app2")
+	err = PatchStatus(ctx, fakeClient, latest)
+	assert.Nil(t, err)
+
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(rayService), latest)
+	assert.Nil(t, err)
+	assert.Equal(t, rayv1.Running, latest.Status.ServiceStatus)
+	// The deleted Serve application is removed, and the labels updated concurrently are kept.
+	assert.Len(t, latest.Status.ActiveServiceStatus.Applications, 1)
+	assert.Contains(t, latest.Status.ActiveServiceStatus.Applications, "app1")
+	assert.Equal(t, "ml", latest.Labels["team"])
+}
+
+func TestStripUnusedFields(t *testing.T) {
+	lastTerminationState := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "raycluster-head",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kuberay-operator"}},
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: "{}",
+				"ray.io/ft-enabled":                "false",
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:                 "ray-head",
+				State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				LastTerminationState: lastTerminationState,
+				Ready:                true,
+				RestartCount:         1,
+				Image:                "rayproject/ray:2.9.0",
+				ImageID:              "docker.io/rayproject/ray@sha256:abc",
+				ContainerID:          "containerd://abc",
+			}},
+		},
+	}
+	obj, err := StripUnusedFields(pod)
+	assert.Nil(t, err)
+	stripped := obj.(*corev1.Pod)
+	assert.Nil(t, stripped.ManagedFields)
+	// Only the last applied configuration annotation is stripped.
+	assert.Equal(t, map[string]string{"ray.io/ft-enabled": "false"}, stripped.Annotations)
+	assert.Equal(t, []corev1.ContainerStatus{{
+		Name:                 "ray-head",
+		State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		LastTerminationState: lastTerminationState,
+		Ready:                true,
+		RestartCount:         1,
+	}}, stripped.Status.ContainerStatuses)
+
+	// The annotations of the other objects are kept.
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{
+		ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		Annotations:   map[string]string{corev1.LastAppliedConfigAnnotation: "{}"},
+	}}
+	_, err = StripUnusedFields(cluster)
+	assert.Nil(t, err)
+	assert.Nil(t, cluster.ManagedFields)
+	assert.Contains(t, cluster.Annotations, corev1.LastAppliedConfigAnnotation)
+}
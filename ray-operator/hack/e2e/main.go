@@ -0,0 +1,286 @@
+// Command e2e runs the end-to-end test suites of KubeRay against a kind cluster, with the CRDs and the operator built
+// from the working tree, so that patches can be validated reproducibly.
+//
+// Run it from the ray-operator directory:
+//
+//	go run ./hack/e2e [flags] [suite...]
+//
+// All the suites are run if none is given. Use -list to print the available suites.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	operatorNamespace  = "ray-system"
+	operatorDeployment = "kuberay-operator"
+	// kustomizeImage is the operator image set by config/default, which is replaced with the image built from the
+	// working tree.
+	kustomizeImage = "quay.io/kuberay/operator:nightly"
+)
+
+// suite is a scenario suite, i.e. a set of Go e2e tests.
+type suite struct {
+	name        string
+	description string
+	pkg         string
+	run         string
+}
+
+var suites = []suite{
+	{
+		name:        "scale-up",
+		description: "The autoscaler scales the worker groups up and down with the resource demands.",
+		pkg:         "./test/e2eautoscaler",
+		run:         "^TestRayClusterAutoscaler",
+	},
+	{
+		name:        "head-failover",
+		description: "KubeRay recreates a failed head Pod and the workers reconnect to it.",
+		pkg:         "./test/e2e",
+		run:         "^TestRayClusterHeadFailover$",
+	},
+	{
+		name:        "rayjob",
+		description: "The lifecycle of RayJobs: submission, retries, suspension and cluster selectors.",
+		pkg:         "./test/e2e",
+		run:         "^TestRayJob",
+	},
+}
+
+type options struct {
+	clusterName string
+	nodeImage   string
+	image       string
+	engine      string
+	testTimeout time.Duration
+	skipBuild   bool
+	keepCluster bool
+	setupOnly   bool
+	teardown    bool
+	list        bool
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("e2e: ")
+
+	var opts options
+	flag.StringVar(&opts.clusterName, "cluster-name", "kuberay-e2e", "Name of the kind cluster.")
+	flag.StringVar(&opts.nodeImage, "node-image", "kindest/node:v1.29.2", "Node image of the kind cluster, which selects the Kubernetes version.")
+	flag.StringVar(&opts.image, "image", "kuberay/operator:e2e", "Image of the operator built from the working tree.")
+	flag.StringVar(&opts.engine, "engine", "docker", "Container engine used to build the operator image.")
+	flag.DurationVar(&opts.testTimeout, "timeout", 30*time.Minute, "Timeout of each suite.")
+	flag.BoolVar(&opts.skipBuild, "skip-build", false, "Reuse the operator image built by an earlier run.")
+	flag.BoolVar(&opts.keepCluster, "keep-cluster", false, "Keep the kind cluster created by this run, e.g. to debug failures.")
+	flag.BoolVar(&opts.setupOnly, "setup-only", false, "Create the kind cluster and deploy the operator without running any suite.")
+	flag.BoolVar(&opts.teardown, "teardown", false, "Delete the kind cluster and exit.")
+	flag.BoolVar(&opts.list, "list", false, "List the suites and exit.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: go run ./hack/e2e [flags] [suite...]\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if opts.list {
+		for _, s := range suites {
+			fmt.Printf("%-15s %s\n", s.name, s.description)
+		}
+		return
+	}
+	if err := run(opts, flag.Args()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(opts options, names []string) error {
+	if _, err := os.Stat(filepath.Join("config", "default")); err != nil {
+		return errors.New("run the harness from the ray-operator directory")
+	}
+	selected, err := selectSuites(names)
+	if err != nil {
+		return err
+	}
+	for _, tool := range []string{"kind", "kubectl", opts.engine} {
+		if _, err := exec.LookPath(tool); err != nil {
+			return fmt.Errorf("%s is required: %w", tool, err)
+		}
+	}
+
+	if opts.teardown {
+		return command("kind", "delete", "cluster", "--name", opts.clusterName).Run()
+	}
+
+	created, err := createCluster(opts)
+	if err != nil {
+		return err
+	}
+	if created && !opts.keepCluster && !opts.setupOnly {
+		defer func() {
+			if err := command("kind", "delete", "cluster", "--name", opts.clusterName).Run(); err != nil {
+				log.Printf("failed to delete the kind cluster %s: %v", opts.clusterName, err)
+			}
+		}()
+	}
+
+	kubeconfig, err := writeKubeconfig(opts.clusterName)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(kubeconfig)
+	env := []string{"KUBECONFIG=" + kubeconfig}
+
+	if err := deployOperator(opts, env); err != nil {
+		return err
+	}
+	if opts.setupOnly {
+		log.Printf("the operator is running in the kind cluster %s; run `kind export kubeconfig --name %s` to use it", opts.clusterName, opts.clusterName)
+		return nil
+	}
+
+	var failed []string
+	for _, s := range selected {
+		log.Printf("running suite %s", s.name)
+		cmd := command("go", "test", "-count=1", "-v", "-timeout", opts.testTimeout.String(), "-run", s.run, s.pkg)
+		cmd.Env = append(cmd.Env, env...)
+		if err := cmd.Run(); err != nil {
+			log.Printf("suite %s failed: %v", s.name, err)
+			failed = append(failed, s.name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d suites failed: %s", len(failed), len(selected), strings.Join(failed, ", "))
+	}
+	log.Printf("%d suites passed", len(selected))
+	return nil
+}
+
+// selectSuites returns the suites with the given names, or all the suites if no name is given.
+func selectSuites(names []string) ([]suite, error) {
+	if len(names) == 0 {
+		return suites, nil
+	}
+	var selected []suite
+	for _, name := range names {
+		found := false
+		for _, s := range suites {
+			if s.name == name {
+				selected = append(selected, s)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown suite %q; run with -list to print the available suites", name)
+		}
+	}
+	return selected, nil
+}
+
+// createCluster creates the kind cluster unless it already exists, and reports whether it was created.
+func createCluster(opts options) (bool, error) {
+	out, err := output(command("kind", "get", "clusters"))
+	if err != nil {
+		return false, err
+	}
+	for _, name := range strings.Fields(string(out)) {
+		if name == opts.clusterName {
+			log.Printf("reusing the kind cluster %s", opts.clusterName)
+			return false, nil
+		}
+	}
+	if err := command("kind", "create", "cluster", "--name", opts.clusterName, "--image", opts.nodeImage, "--wait", "5m").Run(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// writeKubeconfig writes the kubeconfig of the kind cluster to a temporary file, so that the harness does not change
+// the current context of the user.
+func writeKubeconfig(clusterName string) (string, error) {
+	out, err := output(command("kind", "get", "kubeconfig", "--name", clusterName))
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp("", "kuberay-e2e-kubeconfig-")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.Write(out); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// deployOperator builds the operator image from the working tree, loads it into the kind cluster, and installs the
+// CRDs and the operator from config/default.
+func deployOperator(opts options, env []string) error {
+	if !opts.skipBuild {
+		if err := command(opts.engine, "build", "-t", opts.image, ".").Run(); err != nil {
+			return err
+		}
+	}
+	if err := command("kind", "load", "docker-image", opts.image, "--name", opts.clusterName).Run(); err != nil {
+		return err
+	}
+
+	kustomize := command("kubectl", "kustomize", filepath.Join("config", "default"))
+	kustomize.Env = append(kustomize.Env, env...)
+	manifests, err := output(kustomize)
+	if err != nil {
+		return err
+	}
+	if !bytes.Contains(manifests, []byte(kustomizeImage)) {
+		return fmt.Errorf("the manifests of config/default do not use the image %s", kustomizeImage)
+	}
+	manifests = bytes.ReplaceAll(manifests, []byte(kustomizeImage), []byte(opts.image))
+
+	steps := []struct {
+		stdin io.Reader
+		args  []string
+	}{
+		// Server-side apply avoids the size limit of the last-applied-configuration annotation of the large CRDs.
+		{bytes.NewReader(manifests), []string{"apply", "--server-side", "--force-conflicts", "-f", "-"}},
+		{nil, []string{"wait", "--for=condition=Established", "--timeout=1m", "crd", "--all"}},
+		// The image tag does not change between runs, so restart the operator to pick up a rebuilt image.
+		{nil, []string{"rollout", "restart", "-n", operatorNamespace, "deployment/" + operatorDeployment}},
+		{nil, []string{"rollout", "status", "-n", operatorNamespace, "deployment/" + operatorDeployment, "--timeout=5m"}},
+	}
+	for _, step := range steps {
+		cmd := command("kubectl", step.args...)
+		cmd.Env = append(cmd.Env, env...)
+		cmd.Stdin = step.stdin
+		if err := cmd.Run(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// command returns a command that inherits the environment and prints its output.
+func command(name string, args ...string) *exec.Cmd {
+	log.Printf("+ %s %s", name, strings.Join(args, " "))
+	cmd := exec.Command(name, args...)
+	cmd.Env = os.Environ()
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// output runs the command and returns its standard output instead of printing it.
+func output(cmd *exec.Cmd) ([]byte, error) {
+	cmd.Stdout = nil
+	return cmd.Output()
+}
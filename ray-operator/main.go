@@ -1,26 +1,35 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-logr/zapr"
 	routev1 "github.com/openshift/api/route/v1"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"golang.org/x/time/rate"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/selection"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -28,16 +37,34 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	k8szap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 
 	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
+	"github.com/ray-project/kuberay/ray-operator/pkg/tracing"
+	"github.com/ray-project/kuberay/ray-operator/pkg/webhooks"
 	// +kubebuilder:scaffold:imports
 )
 
+const (
+	// logLevelReloadPeriod is how often the log level is reloaded from the config file.
+	logLevelReloadPeriod = 10 * time.Second
+	// defaultRateLimiterBaseDelay and defaultRateLimiterMaxDelay are the delays of the default rate limiter of
+	// controller-runtime.
+	defaultRateLimiterBaseDelay = 5 * time.Millisecond
+	defaultRateLimiterMaxDelay  = 1000 * time.Second
+	// tracingShutdownTimeout bounds the flush of the pending spans when the operator exits.
+	tracingShutdownTimeout = 5 * time.Second
+	// cacheSyncCheckTimeout bounds how long the readiness probe waits for the informer caches to be synced.
+	cacheSyncCheckTimeout = time.Second
+)
+
 var (
 	scheme    = runtime.NewScheme()
 	setupLog  = ctrl.Log.WithName("setup")
@@ -47,6 +74,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(rayv1.AddToScheme(scheme))
+	utilruntime.Must(rayv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(routev1.Install(scheme))
 	utilruntime.Must(batchv1.AddToScheme(scheme))
 	utilruntime.Must(configapi.AddToScheme(scheme))
@@ -58,30 +86,78 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var leaderElectionNamespace string
+	var leaderElectionID string
+	var leaderElectionLeaseDuration time.Duration
+	var leaderElectionRenewDeadline time.Duration
+	var leaderElectionRetryPeriod time.Duration
 	var probeAddr string
+	var pprofBindAddress string
 	var reconcileConcurrency int
+	var rayClusterMaxConcurrentReconciles int
+	var rayJobMaxConcurrentReconciles int
+	var rayServiceMaxConcurrentReconciles int
+	var rateLimiterBaseDelay time.Duration
+	var rateLimiterMaxDelay time.Duration
+	var syncPeriod time.Duration
+	var gracefulShutdownTimeout time.Duration
 	var watchNamespace string
+	var watchLabelSelector string
 	var forcedClusterUpgrade bool
 	var logFile string
 	var logFileEncoder string
 	var logStdoutEncoder string
 	var useKubernetesProxy bool
+	var enableAcceleratorTolerations bool
+	var acceleratorResourceMapping string
+	var enablePrometheusMonitors bool
+	var prometheusMonitorLabels string
 	var configFile string
 	var featureGates string
+	var stuckPodTimeout time.Duration
+	var dashboardClientTimeout time.Duration
+	var dashboardClientMaxRetries int
+	var dashboardClientCircuitBreakerThreshold int
+	var dashboardClientCircuitBreakerCooldown time.Duration
 
 	// TODO: remove flag-based config once Configuration API graduates to v1.
 	flag.StringVar(&metricsAddr, "metrics-addr", configapi.DefaultMetricsAddr, "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", configapi.DefaultProbeAddr, "The address the probe endpoint binds to.")
+	flag.StringVar(&pprofBindAddress, "pprof-bind-address", "",
+		"The address the pprof endpoints bind to, e.g. :6060. The pprof endpoints are disabled if empty.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", configapi.DefaultEnableLeaderElection,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", "",
 		"Namespace where the leader election resource lives. Defaults to the pod namespace if not set.")
+	flag.StringVar(&leaderElectionID, "leader-election-id", configapi.DefaultLeaderElectionID,
+		"Name of the Lease used for leader election. The replicas of an operator must share the same ID.")
+	flag.DurationVar(&leaderElectionLeaseDuration, "leader-election-lease-duration", 0,
+		"How long the replicas wait before acquiring the leadership once the leader stopped renewing it. Defaults to 15s.")
+	flag.DurationVar(&leaderElectionRenewDeadline, "leader-election-renew-deadline", 0,
+		"How long the leader retries renewing the leadership before giving it up. Defaults to 10s.")
+	flag.DurationVar(&leaderElectionRetryPeriod, "leader-election-retry-period", 0,
+		"How long the replicas wait between the attempts to acquire or renew the leadership. Defaults to 2s.")
 	flag.IntVar(&reconcileConcurrency, "reconcile-concurrency", configapi.DefaultReconcileConcurrency, "max concurrency for reconciling")
+	flag.IntVar(&rayClusterMaxConcurrentReconciles, "raycluster-max-concurrent-reconciles", 0,
+		"Max concurrency for reconciling RayClusters. Defaults to the value of reconcile-concurrency.")
+	flag.IntVar(&rayJobMaxConcurrentReconciles, "rayjob-max-concurrent-reconciles", 0,
+		"Max concurrency for reconciling RayJobs. Defaults to the value of reconcile-concurrency.")
+	flag.IntVar(&rayServiceMaxConcurrentReconciles, "rayservice-max-concurrent-reconciles", 0,
+		"Max concurrency for reconciling RayServices. Defaults to the value of reconcile-concurrency.")
+	flag.DurationVar(&rateLimiterBaseDelay, "rate-limiter-base-delay", 0,
+		"Delay before retrying a custom resource whose reconciliation failed, doubled on each consecutive failure. Defaults to 5ms.")
+	flag.DurationVar(&rateLimiterMaxDelay, "rate-limiter-max-delay", 0,
+		"Maximum delay before retrying a custom resource whose reconciliation failed. Defaults to 1000s.")
+	flag.DurationVar(&syncPeriod, "sync-period", 0,
+		"Period after which all the watched resources are reconciled again, even if they did not change. Defaults to 10h.")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 0,
+		"How long the operator waits for the in-flight reconciliations to finish when it stops. Defaults to 30s.")
 	flag.StringVar(
 		&watchNamespace,
 		"watch-namespace",
 		"",
 		"Specify a list of namespaces to watch for custom resources, separated by commas. If left empty, all namespaces will be watched.")
+	flag.StringVar(&watchLabelSelector, "watch-label-selector", "",
+		"Only watch the custom resources matching this label selector, e.g. team=ml. If left empty, all custom resources will be watched.")
 	flag.BoolVar(&forcedClusterUpgrade, "forced-cluster-upgrade", false,
 		"(Deprecated) Forced cluster upgrade flag")
 	flag.StringVar(&logFile, "log-file-path", "",
@@ -95,6 +171,24 @@ func main() {
 	flag.StringVar(&configFile, "config", "", "Path to structured config file. Flags are ignored if config file is set.")
 	flag.BoolVar(&useKubernetesProxy, "use-kubernetes-proxy", false,
 		"Use Kubernetes proxy subresource when connecting to the Ray Head node.")
+	flag.BoolVar(&enableAcceleratorTolerations, "enable-accelerator-tolerations", false,
+		"Add tolerations for the taints of well-known accelerator nodes to the Ray Pods requesting the corresponding resources.")
+	flag.StringVar(&acceleratorResourceMapping, "accelerator-resource-mapping", "",
+		"A set of extended resource=Ray resource pairs that map the extended resources of Ray containers to Ray custom resources. E.g. google.com/tpu=TPU,habana.ai/gaudi=HPU")
+	flag.BoolVar(&enablePrometheusMonitors, "enable-prometheus-monitors", false,
+		"Create Prometheus Operator PodMonitors scraping the Ray metrics of the RayClusters. Requires the PodMonitor CRD.")
+	flag.StringVar(&prometheusMonitorLabels, "prometheus-monitor-labels", "",
+		"A set of key=value pairs added to the labels of the PodMonitors, e.g. to match the podMonitorSelector of the Prometheus. E.g. release=prometheus")
+	flag.DurationVar(&stuckPodTimeout, "stuck-pod-timeout", 0,
+		"Force-delete the worker Pods that stay terminating, or in the Unknown phase, for longer than this duration, e.g. after their node is gone. Disabled if zero.")
+	flag.DurationVar(&dashboardClientTimeout, "dashboard-client-timeout", 0,
+		"Timeout of each request to the Ray dashboards, including its retries. Defaults to 2s.")
+	flag.IntVar(&dashboardClientMaxRetries, "dashboard-client-max-retries", utils.DefaultDashboardClientMaxRetries,
+		"Number of times an idempotent request to a Ray dashboard is retried on connection errors and 502, 503 and 504 responses. Disabled if zero.")
+	flag.IntVar(&dashboardClientCircuitBreakerThreshold, "dashboard-client-circuit-breaker-threshold", utils.DefaultDashboardClientCircuitBreakerThreshold,
+		"Number of consecutive failed requests to a Ray dashboard after which the requests to it fail fast. Disabled if zero.")
+	flag.DurationVar(&dashboardClientCircuitBreakerCooldown, "dashboard-client-circuit-breaker-cooldown", 0,
+		"How long the requests to a Ray dashboard fail fast once its circuit breaker opens. Defaults to 30s.")
 	flag.StringVar(&featureGates, "feature-gates", "", "A set of key=value pairs that describe feature gates. E.g. FeatureOne=true,FeatureTwo=false,...")
 
 	opts := k8szap.Options{
@@ -117,22 +211,66 @@ func main() {
 	} else {
 		config.MetricsAddr = metricsAddr
 		config.ProbeAddr = probeAddr
+		config.PprofBindAddress = pprofBindAddress
 		config.EnableLeaderElection = &enableLeaderElection
 		config.LeaderElectionNamespace = leaderElectionNamespace
+		config.LeaderElectionID = leaderElectionID
+		config.LeaderElectionLeaseDuration = metav1.Duration{Duration: leaderElectionLeaseDuration}
+		config.LeaderElectionRenewDeadline = metav1.Duration{Duration: leaderElectionRenewDeadline}
+		config.LeaderElectionRetryPeriod = metav1.Duration{Duration: leaderElectionRetryPeriod}
 		config.ReconcileConcurrency = reconcileConcurrency
+		config.RayClusterMaxConcurrentReconciles = rayClusterMaxConcurrentReconciles
+		config.RayJobMaxConcurrentReconciles = rayJobMaxConcurrentReconciles
+		config.RayServiceMaxConcurrentReconciles = rayServiceMaxConcurrentReconciles
+		config.RateLimiterBaseDelay = metav1.Duration{Duration: rateLimiterBaseDelay}
+		config.RateLimiterMaxDelay = metav1.Duration{Duration: rateLimiterMaxDelay}
+		config.SyncPeriod = metav1.Duration{Duration: syncPeriod}
+		config.GracefulShutdownTimeout = metav1.Duration{Duration: gracefulShutdownTimeout}
 		config.WatchNamespace = watchNamespace
+		config.WatchLabelSelector = watchLabelSelector
 		config.LogFile = logFile
 		config.LogFileEncoder = logFileEncoder
 		config.LogStdoutEncoder = logStdoutEncoder
 		config.EnableBatchScheduler = ray.EnableBatchScheduler
 		config.UseKubernetesProxy = useKubernetesProxy
+		config.DashboardClientTimeout = metav1.Duration{Duration: dashboardClientTimeout}
+		config.DashboardClientMaxRetries = &dashboardClientMaxRetries
+		config.DashboardClientCircuitBreakerThreshold = &dashboardClientCircuitBreakerThreshold
+		config.DashboardClientCircuitBreakerCooldown = metav1.Duration{Duration: dashboardClientCircuitBreakerCooldown}
+		config.EnableAcceleratorTolerations = enableAcceleratorTolerations
+		mapping, err := common.ParseAcceleratorResourceMapping(acceleratorResourceMapping)
+		exitOnError(err, "failed to parse the accelerator resource mapping")
+		config.AcceleratorResourceMapping = mapping
+		config.StuckPodTimeout = metav1.Duration{Duration: stuckPodTimeout}
+		config.EnablePrometheusMonitors = enablePrometheusMonitors
+		monitorLabels, err := labels.ConvertSelectorToLabelsMap(prometheusMonitorLabels)
+		exitOnError(err, "failed to parse the Prometheus monitor labels")
+		config.PrometheusMonitorLabels = monitorLabels
 		config.DeleteRayJobAfterJobFinishes = os.Getenv(utils.DELETE_RAYJOB_CR_AFTER_JOB_FINISHES) == "true"
+		configapi.SetDefaults_Configuration(&config)
 	}
 
-	stdoutEncoder, err := newLogEncoder(logStdoutEncoder)
+	stdoutEncoder, err := newLogEncoder(config.LogStdoutEncoder)
 	exitOnError(err, "failed to create log encoder for stdout")
 	opts.Encoder = stdoutEncoder
 
+	// The log level of the config file is atomic, so that it can be reloaded without restarting the operator.
+	logLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
+	var fileLogLevel zapcore.LevelEnabler = zap.InfoLevel
+	if config.LogLevel != "" {
+		level, err := zapcore.ParseLevel(config.LogLevel)
+		exitOnError(err, "failed to parse the log level")
+		logLevel.SetLevel(level)
+		opts.Level = logLevel
+		fileLogLevel = logLevel
+	}
+
+	if config.LogStacktraceLevel != "" {
+		level, err := zapcore.ParseLevel(config.LogStacktraceLevel)
+		exitOnError(err, "failed to parse the stacktrace level")
+		opts.StacktraceLevel = level
+	}
+
 	if config.LogFile != "" {
 		fileWriter := &lumberjack.Logger{
 			Filename:   config.LogFile,
@@ -141,14 +279,18 @@ func main() {
 			MaxAge:     30,  // days
 		}
 
-		fileEncoder, err := newLogEncoder(logFileEncoder)
+		fileEncoder, err := newLogEncoder(config.LogFileEncoder)
 		exitOnError(err, "failed to create log encoder for file")
 
 		k8sLogger := k8szap.NewRaw(k8szap.UseFlagOptions(&opts))
 		zapOpts := append(opts.ZapOpts, zap.AddCallerSkip(1))
+		// The stacktrace level is an option of the logger rather than of its core, so it is not inherited from k8sLogger.
+		if opts.StacktraceLevel != nil {
+			zapOpts = append(zapOpts, zap.AddStacktrace(opts.StacktraceLevel))
+		}
 		combineLogger := zap.New(zapcore.NewTee(
 			k8sLogger.Core(),
-			zapcore.NewCore(fileEncoder, zapcore.AddSync(fileWriter), zap.InfoLevel),
+			zapcore.NewCore(fileEncoder, zapcore.AddSync(fileWriter), fileLogLevel),
 		)).WithOptions(zapOpts...)
 		combineLoggerR := zapr.NewLogger(combineLogger)
 
@@ -167,6 +309,9 @@ func main() {
 	if err := utilfeature.DefaultMutableFeatureGate.Set(featureGates); err != nil {
 		exitOnError(err, "Unable to set flag gates for known features")
 	}
+	if err := utilfeature.DefaultMutableFeatureGate.SetFromMap(config.FeatureGates); err != nil {
+		exitOnError(err, "Unable to set the feature gates of the config file")
+	}
 	features.LogFeatureGates(setupLog)
 
 	// Manager options
@@ -179,9 +324,27 @@ func main() {
 			BindAddress: config.MetricsAddr,
 		},
 		HealthProbeBindAddress:  config.ProbeAddr,
+		PprofBindAddress:        config.PprofBindAddress,
 		LeaderElection:          *config.EnableLeaderElection,
-		LeaderElectionID:        "ray-operator-leader",
+		LeaderElectionID:        config.LeaderElectionID,
 		LeaderElectionNamespace: config.LeaderElectionNamespace,
+		// The operator exits once the manager stops, so the next leader does not need to wait for the lease to expire.
+		LeaderElectionReleaseOnCancel: true,
+	}
+	if config.LeaderElectionLeaseDuration.Duration > 0 {
+		options.LeaseDuration = &config.LeaderElectionLeaseDuration.Duration
+	}
+	if config.LeaderElectionRenewDeadline.Duration > 0 {
+		options.RenewDeadline = &config.LeaderElectionRenewDeadline.Duration
+	}
+	if config.LeaderElectionRetryPeriod.Duration > 0 {
+		options.RetryPeriod = &config.LeaderElectionRetryPeriod.Duration
+	}
+	if config.SyncPeriod.Duration > 0 {
+		options.Cache.SyncPeriod = &config.SyncPeriod.Duration
+	}
+	if config.GracefulShutdownTimeout.Duration > 0 {
+		options.GracefulShutdownTimeout = &config.GracefulShutdownTimeout.Duration
 	}
 
 	// Manager Cache
@@ -191,9 +354,19 @@ func main() {
 	// For example, KubeRay is only interested in the batch Jobs it creates when reconciling RayJobs,
 	// so the controller sets the app.kubernetes.io/created-by=kuberay-operator label on any Job it creates,
 	// and that label is provided to the manager cache as a selector for Job resources.
-	selectorsByObject, err := cacheSelectors()
+	// The custom resources can also be restricted to the ones matching the watch label selector, so that multiple
+	// operator instances can shard them.
+	watchSelector, err := labels.Parse(config.WatchLabelSelector)
+	exitOnError(err, "unable to parse the watch label selector")
+	if !watchSelector.Empty() {
+		setupLog.Info("Only watch custom resources matching the label selector", "watchLabelSelector", watchSelector.String())
+	}
+	selectorsByObject, err := cacheSelectors(watchSelector)
 	exitOnError(err, "unable to create cache selectors")
 	options.Cache.ByObject = selectorsByObject
+	// Strip the fields that KubeRay never reads from the objects before they enter the informer cache, as the Pods of
+	// large RayClusters make up most of the memory of the operator.
+	options.Cache.DefaultTransform = utils.StripUnusedFields
 
 	if watchNamespaces := strings.Split(config.WatchNamespace, ","); len(watchNamespaces) == 1 { // It is not possible for len(watchNamespaces) == 0 to be true. The length of `strings.Split("", ",")` is still 1.
 		if watchNamespaces[0] == "" {
@@ -216,40 +389,139 @@ func main() {
 	exitOnError(err, "unable to start manager")
 
 	rayClusterOptions := ray.RayClusterReconcilerOptions{
-		HeadSidecarContainers:   config.HeadSidecarContainers,
-		WorkerSidecarContainers: config.WorkerSidecarContainers,
+		HeadSidecarContainers:        config.HeadSidecarContainers,
+		WorkerSidecarContainers:      config.WorkerSidecarContainers,
+		EnableAcceleratorTolerations: config.EnableAcceleratorTolerations,
+		AcceleratorResourceMapping:   config.AcceleratorResourceMapping,
+		EnvironmentTiers:             config.EnvironmentTiers,
+		DashboardClientFunc:          config.GetDashboardClient(mgr),
+		StuckPodTimeout:              config.StuckPodTimeout.Duration,
+		EnablePrometheusMonitors:     config.EnablePrometheusMonitors,
+		PrometheusMonitorLabels:      config.PrometheusMonitorLabels,
 	}
 	ctx := ctrl.SetupSignalHandler()
-	exitOnError(ray.NewReconciler(ctx, mgr, rayClusterOptions).SetupWithManager(mgr, config.ReconcileConcurrency),
+	if configFile != "" && config.LogLevel != "" {
+		go wait.UntilWithContext(ctx, func(context.Context) {
+			reloadLogLevel(configFile, logLevel)
+		}, logLevelReloadPeriod)
+	}
+	shutdownTracing, err := tracing.Setup(ctx)
+	exitOnError(err, "unable to set up tracing")
+	defer func() {
+		// The signal context is done at this point, so flush the pending spans with a fresh one.
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			setupLog.Error(err, "unable to shut down tracing")
+		}
+	}()
+	// Each controller has its own rate limiter, as the requests of different kinds can have the same key.
+	exitOnError(ray.NewReconciler(ctx, mgr, rayClusterOptions).SetupWithManager(mgr, config.RayClusterMaxConcurrentReconciles, newRateLimiter(config)),
 		"unable to create controller", "controller", "RayCluster")
-	exitOnError(ray.NewRayServiceReconciler(ctx, mgr, config).SetupWithManager(mgr, config.ReconcileConcurrency),
+	exitOnError(ray.NewRayServiceReconciler(ctx, mgr, config).SetupWithManager(mgr, config.RayServiceMaxConcurrentReconciles, newRateLimiter(config)),
 		"unable to create controller", "controller", "RayService")
-	exitOnError(ray.NewRayJobReconciler(ctx, mgr, config).SetupWithManager(mgr, config.ReconcileConcurrency),
+	exitOnError(ray.NewRayJobReconciler(ctx, mgr, config).SetupWithManager(mgr, config.RayJobMaxConcurrentReconciles, newRateLimiter(config)),
 		"unable to create controller", "controller", "RayJob")
+	// The RayCronJob controller is disabled by default, as its CRD may not be installed, e.g. by Helm on upgrades.
+	if features.Enabled(features.RayCronJob) {
+		exitOnError(ray.NewRayCronJobReconciler(mgr).SetupWithManager(mgr, config.RayJobMaxConcurrentReconciles, newRateLimiter(config)),
+			"unable to create controller", "controller", "RayCronJob")
+	}
 
-	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+	enableWebhooks := os.Getenv("ENABLE_WEBHOOKS") == "true"
+	if enableWebhooks {
+		// This also registers the conversion webhook, which converts all the KubeRay CRDs between v1alpha1 and v1.
 		exitOnError((&rayv1.RayCluster{}).SetupWebhookWithManager(mgr),
 			"unable to create webhook", "webhook", "RayCluster")
+		// The image digest and spec defaulting webhooks are always registered, because their configurations are always
+		// installed, and they admit RayClusters unchanged unless they are enabled.
+		enableImageDigestPinning := strings.ToLower(os.Getenv(utils.ENABLE_IMAGE_DIGEST_PINNING)) == "true"
+		if enableImageDigestPinning {
+			setupLog.Info("Pinning the image digests of RayClusters at admission")
+		}
+		mgr.GetWebhookServer().Register(webhooks.ImageDigestWebhookPath,
+			webhooks.NewImageDigestWebhook(mgr.GetScheme(), mgr.GetAPIReader(), enableImageDigestPinning))
+		enableSpecDefaulting := strings.ToLower(os.Getenv(utils.ENABLE_SPEC_DEFAULTING)) == "true"
+		if enableSpecDefaulting {
+			setupLog.Info("Writing the computed defaults into the spec of RayClusters at admission")
+		}
+		mgr.GetWebhookServer().Register(webhooks.DefaultingWebhookPath,
+			webhooks.NewDefaultingWebhook(mgr.GetScheme(), config.AcceleratorResourceMapping, enableSpecDefaulting))
 	}
 	// +kubebuilder:scaffold:builder
 
-	exitOnError(mgr.AddHealthzCheck("healthz", healthz.Ping), "unable to set up health check")
-	exitOnError(mgr.AddReadyzCheck("readyz", healthz.Ping), "unable to set up ready check")
+	exitOnError(addHealthChecks(ctx, mgr, enableWebhooks), "unable to set up health checks")
 
 	setupLog.Info("starting manager")
 	exitOnError(mgr.Start(ctx), "problem running manager")
 }
 
-func cacheSelectors() (map[client.Object]cache.ByObject, error) {
+func cacheSelectors(watchSelector labels.Selector) (map[client.Object]cache.ByObject, error) {
 	label, err := labels.NewRequirement(utils.KubernetesCreatedByLabelKey, selection.Equals, []string{utils.ComponentName})
 	if err != nil {
 		return nil, err
 	}
 	selector := labels.NewSelector().Add(*label)
 
-	return map[client.Object]cache.ByObject{
-		&batchv1.Job{}: {Label: selector},
-	}, nil
+	// Only the ConfigMaps that RayStartParams are sourced from are cached.
+	rayStartParamsSelector := labels.SelectorFromSet(labels.Set{rayv1.RayStartParamsConfigMapLabelKey: "true"})
+
+	selectorsByObject := map[client.Object]cache.ByObject{
+		&batchv1.Job{}:      {Label: selector},
+		&corev1.ConfigMap{}: {Label: rayStartParamsSelector},
+	}
+	if !watchSelector.Empty() {
+		selectorsByObject[&rayv1.RayCluster{}] = cache.ByObject{Label: watchSelector}
+		selectorsByObject[&rayv1.RayJob{}] = cache.ByObject{Label: watchSelector}
+		selectorsByObject[&rayv1.RayService{}] = cache.ByObject{Label: watchSelector}
+	}
+	return selectorsByObject, nil
+}
+
+// addHealthChecks adds the liveness and readiness checks of the manager. The operator is live as long as it serves the
+// probes, and ready once the informer caches are synced, as it would otherwise act on a partial view of the cluster,
+// and once the webhook server serves its certificate if the webhooks are enabled. It is not ready anymore once it is
+// shutting down, so that the webhook requests are routed to the other replicas while it drains.
+func addHealthChecks(ctx context.Context, mgr ctrl.Manager, enableWebhooks bool) error {
+	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("shutdown", shutdownChecker(ctx)); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("informers", cacheSyncedChecker(mgr.GetCache())); err != nil {
+		return err
+	}
+	if enableWebhooks {
+		// The checker fails until the certificate is loaded and the TLS handshake succeeds.
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cacheSyncedChecker returns a checker failing until the informer caches are synced. It does not block the probe for
+// longer than cacheSyncCheckTimeout.
+func cacheSyncedChecker(informers cache.Informers) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), cacheSyncCheckTimeout)
+		defer cancel()
+		if !informers.WaitForCacheSync(ctx) {
+			return errors.New("informer caches are not synced")
+		}
+		return nil
+	}
+}
+
+// shutdownChecker returns a checker failing once ctx is done, i.e. once the operator received a termination signal.
+func shutdownChecker(ctx context.Context) healthz.Checker {
+	return func(_ *http.Request) error {
+		if ctx.Err() != nil {
+			return errors.New("the operator is shutting down")
+		}
+		return nil
+	}
 }
 
 func exitOnError(err error, msg string, keysAndValues ...interface{}) {
@@ -272,6 +544,53 @@ func decodeConfig(configData []byte, scheme *runtime.Scheme) (configapi.Configur
 	return cfg, nil
 }
 
+// newRateLimiter returns the rate limiter of the controllers, which is the default one of controller-runtime with the
+// delays of the config, or nil to use the default one if no delay is set.
+func newRateLimiter(config configapi.Configuration) ratelimiter.RateLimiter {
+	baseDelay, maxDelay := config.RateLimiterBaseDelay.Duration, config.RateLimiterMaxDelay.Duration
+	if baseDelay == 0 && maxDelay == 0 {
+		return nil
+	}
+	if baseDelay == 0 {
+		baseDelay = defaultRateLimiterBaseDelay
+	}
+	if maxDelay == 0 {
+		maxDelay = defaultRateLimiterMaxDelay
+	}
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay),
+		// The overall rate limit of the default rate limiter, which is not per custom resource.
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
+
+// reloadLogLevel sets the log level to the one in the config file, e.g. after the ConfigMap mounted as the config
+// file is updated. Invalid config files are logged and ignored.
+func reloadLogLevel(configFile string, logLevel zap.AtomicLevel) {
+	configData, err := os.ReadFile(configFile)
+	if err != nil {
+		setupLog.Error(err, "failed to read config file")
+		return
+	}
+	config, err := decodeConfig(configData, scheme)
+	if err != nil {
+		setupLog.Error(err, "failed to decode config file")
+		return
+	}
+	if config.LogLevel == "" {
+		return
+	}
+	level, err := zapcore.ParseLevel(config.LogLevel)
+	if err != nil {
+		setupLog.Error(err, "failed to parse the log level")
+		return
+	}
+	if level != logLevel.Level() {
+		setupLog.Info("Changing the log level", "logLevel", level.String())
+		logLevel.SetLevel(level)
+	}
+}
+
 // newLogEncoder returns a zapcore.Encoder based on the encoder type ('json' or 'console')
 func newLogEncoder(encoderType string) (zapcore.Encoder, error) {
 	pe := zap.NewProductionEncoderConfig()
@@ -1,15 +1,26 @@
 package main
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 
 	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
 
 func Test_decodeConfig(t *testing.T) {
@@ -30,10 +41,14 @@ kind: Configuration
 					Kind:       "Configuration",
 					APIVersion: "config.ray.io/v1alpha1",
 				},
-				MetricsAddr:          ":8080",
-				ProbeAddr:            ":8082",
-				EnableLeaderElection: ptr.To(true),
-				ReconcileConcurrency: 1,
+				MetricsAddr:                       ":8080",
+				ProbeAddr:                         ":8082",
+				EnableLeaderElection:              ptr.To(true),
+				LeaderElectionID:                  "ray-operator-leader",
+				ReconcileConcurrency:              1,
+				RayClusterMaxConcurrentReconciles: 1,
+				RayJobMaxConcurrentReconciles:     1,
+				RayServiceMaxConcurrentReconciles: 1,
 			},
 			expectErr: false,
 		},
@@ -51,10 +66,14 @@ reconcileConcurrency: 1
 					Kind:       "Configuration",
 					APIVersion: "config.ray.io/v1alpha1",
 				},
-				MetricsAddr:          ":8080",
-				ProbeAddr:            ":8082",
-				EnableLeaderElection: ptr.To(true),
-				ReconcileConcurrency: 1,
+				MetricsAddr:                       ":8080",
+				ProbeAddr:                         ":8082",
+				EnableLeaderElection:              ptr.To(true),
+				LeaderElectionID:                  "ray-operator-leader",
+				ReconcileConcurrency:              1,
+				RayClusterMaxConcurrentReconciles: 1,
+				RayJobMaxConcurrentReconciles:     1,
+				RayServiceMaxConcurrentReconciles: 1,
 			},
 			expectErr: false,
 		},
@@ -78,10 +97,14 @@ workerSidecarContainers:
 					Kind:       "Configuration",
 					APIVersion: "config.ray.io/v1alpha1",
 				},
-				MetricsAddr:          ":8080",
-				ProbeAddr:            ":8082",
-				EnableLeaderElection: ptr.To(true),
-				ReconcileConcurrency: 1,
+				MetricsAddr:                       ":8080",
+				ProbeAddr:                         ":8082",
+				EnableLeaderElection:              ptr.To(true),
+				LeaderElectionID:                  "ray-operator-leader",
+				ReconcileConcurrency:              1,
+				RayClusterMaxConcurrentReconciles: 1,
+				RayJobMaxConcurrentReconciles:     1,
+				RayServiceMaxConcurrentReconciles: 1,
 				HeadSidecarContainers: []corev1.Container{
 					{
 						Name:  "fluentbit",
@@ -97,6 +120,85 @@ workerSidecarContainers:
 			},
 			expectErr: false,
 		},
+		{
+			name: "config with log level and feature gates",
+			configData: `apiVersion: config.ray.io/v1alpha1
+kind: Configuration
+logLevel: debug
+logStacktraceLevel: panic
+featureGates:
+  RayClusterStatusConditions: true
+`,
+			expectedConfig: configapi.Configuration{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Configuration",
+					APIVersion: "config.ray.io/v1alpha1",
+				},
+				MetricsAddr:                       ":8080",
+				ProbeAddr:                         ":8082",
+				EnableLeaderElection:              ptr.To(true),
+				LeaderElectionID:                  "ray-operator-leader",
+				ReconcileConcurrency:              1,
+				RayClusterMaxConcurrentReconciles: 1,
+				RayJobMaxConcurrentReconciles:     1,
+				RayServiceMaxConcurrentReconciles: 1,
+				LogLevel:                          "debug",
+				LogStacktraceLevel:                "panic",
+				FeatureGates:                      map[string]bool{"RayClusterStatusConditions": true},
+			},
+			expectErr: false,
+		},
+		{
+			name: "config with leader election",
+			configData: `apiVersion: config.ray.io/v1alpha1
+kind: Configuration
+leaderElectionID: kuberay-ha
+leaderElectionLeaseDuration: 30s
+leaderElectionRenewDeadline: 20s
+leaderElectionRetryPeriod: 5s
+`,
+			expectedConfig: configapi.Configuration{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Configuration",
+					APIVersion: "config.ray.io/v1alpha1",
+				},
+				MetricsAddr:                       ":8080",
+				ProbeAddr:                         ":8082",
+				EnableLeaderElection:              ptr.To(true),
+				LeaderElectionID:                  "kuberay-ha",
+				LeaderElectionLeaseDuration:       metav1.Duration{Duration: 30 * time.Second},
+				LeaderElectionRenewDeadline:       metav1.Duration{Duration: 20 * time.Second},
+				LeaderElectionRetryPeriod:         metav1.Duration{Duration: 5 * time.Second},
+				ReconcileConcurrency:              1,
+				RayClusterMaxConcurrentReconciles: 1,
+				RayJobMaxConcurrentReconciles:     1,
+				RayServiceMaxConcurrentReconciles: 1,
+			},
+			expectErr: false,
+		},
+		{
+			name: "config with max concurrent reconciles",
+			configData: `apiVersion: config.ray.io/v1alpha1
+kind: Configuration
+reconcileConcurrency: 2
+rayJobMaxConcurrentReconciles: 4
+`,
+			expectedConfig: configapi.Configuration{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Configuration",
+					APIVersion: "config.ray.io/v1alpha1",
+				},
+				MetricsAddr:                       ":8080",
+				ProbeAddr:                         ":8082",
+				EnableLeaderElection:              ptr.To(true),
+				LeaderElectionID:                  "ray-operator-leader",
+				ReconcileConcurrency:              2,
+				RayClusterMaxConcurrentReconciles: 2,
+				RayJobMaxConcurrentReconciles:     4,
+				RayServiceMaxConcurrentReconciles: 2,
+			},
+			expectErr: false,
+		},
 		{
 			name: "unknown filed ignored",
 			configData: `apiVersion: config.ray.io/v1alpha1
@@ -112,10 +214,14 @@ unknownfield: 1
 					Kind:       "Configuration",
 					APIVersion: "config.ray.io/v1alpha1",
 				},
-				MetricsAddr:          ":8080",
-				ProbeAddr:            ":8082",
-				EnableLeaderElection: ptr.To(true),
-				ReconcileConcurrency: 1,
+				MetricsAddr:                       ":8080",
+				ProbeAddr:                         ":8082",
+				EnableLeaderElection:              ptr.To(true),
+				LeaderElectionID:                  "ray-operator-leader",
+				ReconcileConcurrency:              1,
+				RayClusterMaxConcurrentReconciles: 1,
+				RayJobMaxConcurrentReconciles:     1,
+				RayServiceMaxConcurrentReconciles: 1,
 			},
 			expectErr: false,
 		},
@@ -189,3 +295,118 @@ reconcileConcurrency: true
 		})
 	}
 }
+
+func Test_reloadLogLevel(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(logLevel string) {
+		configData := "apiVersion: config.ray.io/v1alpha1\nkind: Configuration\nlogLevel: " + logLevel + "\n"
+		if err := os.WriteFile(configFile, []byte(configData), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	logLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
+
+	writeConfig("debug")
+	reloadLogLevel(configFile, logLevel)
+	if logLevel.Level() != zap.DebugLevel {
+		t.Errorf("expected log level %v but got %v", zap.DebugLevel, logLevel.Level())
+	}
+
+	// Invalid log levels are ignored.
+	writeConfig("verbose")
+	reloadLogLevel(configFile, logLevel)
+	if logLevel.Level() != zap.DebugLevel {
+		t.Errorf("expected log level %v but got %v", zap.DebugLevel, logLevel.Level())
+	}
+}
+
+func Test_cacheSelectors(t *testing.T) {
+	selectorsByObject, err := cacheSelectors(labels.Everything())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selectorsByObject) != 2 {
+		t.Errorf("expected only the batch Jobs and ConfigMaps to be selected but got %v", selectorsByObject)
+	}
+
+	watchSelector, err := labels.Parse("team=ml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	selectorsByObject, err = cacheSelectors(watchSelector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for obj, byObject := range selectorsByObject {
+		if _, ok := obj.(*batchv1.Job); ok {
+			continue
+		}
+		if _, ok := obj.(*corev1.ConfigMap); ok {
+			if byObject.Label.String() != rayv1.RayStartParamsConfigMapLabelKey+"=true" {
+				t.Errorf("expected the label selector of the RayStartParams ConfigMaps but got %v", byObject.Label)
+			}
+			continue
+		}
+		if byObject.Label.String() != "team=ml" {
+			t.Errorf("expected the label selector team=ml for %T but got %v", obj, byObject.Label)
+		}
+	}
+	if len(selectorsByObject) != 5 {
+		t.Errorf("expected the batch Jobs, ConfigMaps and the custom resources to be selected but got %v", selectorsByObject)
+	}
+}
+
+func Test_newRateLimiter(t *testing.T) {
+	if rateLimiter := newRateLimiter(configapi.Configuration{}); rateLimiter != nil {
+		t.Errorf("expected the default rate limiter but got %v", rateLimiter)
+	}
+
+	rateLimiter := newRateLimiter(configapi.Configuration{
+		RateLimiterBaseDelay: metav1.Duration{Duration: time.Second},
+		RateLimiterMaxDelay:  metav1.Duration{Duration: 3 * time.Second},
+	})
+	for i, expected := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		if delay := rateLimiter.When("raycluster"); delay != expected {
+			t.Errorf("expected the delay %v of failure %d but got %v", expected, i+1, delay)
+		}
+	}
+	rateLimiter.Forget("raycluster")
+	if delay := rateLimiter.When("raycluster"); delay != time.Second {
+		t.Errorf("expected the base delay after forgetting the failures but got %v", delay)
+	}
+}
+
+type fakeInformers struct {
+	cache.Informers
+	synced bool
+}
+
+func (f fakeInformers) WaitForCacheSync(ctx context.Context) bool {
+	if !f.synced {
+		<-ctx.Done()
+	}
+	return f.synced
+}
+
+func Test_cacheSyncedChecker(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/readyz/informers", nil)
+	if err := cacheSyncedChecker(fakeInformers{synced: true})(req); err != nil {
+		t.Errorf("expected the check to succeed once the caches are synced but got %v", err)
+	}
+	if err := cacheSyncedChecker(fakeInformers{synced: false})(req); err == nil {
+		t.Errorf("expected the check to fail until the caches are synced")
+	}
+}
+
+func Test_shutdownChecker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	checker := shutdownChecker(ctx)
+	req := httptest.NewRequest(http.MethodGet, "/readyz/shutdown", nil)
+	if err := checker(req); err != nil {
+		t.Errorf("expected the check to succeed until the operator shuts down but got %v", err)
+	}
+	cancel()
+	if err := checker(req); err == nil {
+		t.Errorf("expected the check to fail once the operator shuts down")
+	}
+}
@@ -13,9 +13,13 @@ type AutoscalerOptionsApplyConfiguration struct {
 	Resources          *v1.ResourceRequirements `json:"resources,omitempty"`
 	Image              *string                  `json:"image,omitempty"`
 	ImagePullPolicy    *v1.PullPolicy           `json:"imagePullPolicy,omitempty"`
+	Command            []string                 `json:"command,omitempty"`
+	Args               []string                 `json:"args,omitempty"`
 	SecurityContext    *v1.SecurityContext      `json:"securityContext,omitempty"`
+	ServiceAccountName *string                  `json:"serviceAccountName,omitempty"`
 	IdleTimeoutSeconds *int32                   `json:"idleTimeoutSeconds,omitempty"`
 	UpscalingMode      *rayv1.UpscalingMode     `json:"upscalingMode,omitempty"`
+	Version            *rayv1.AutoscalerVersion `json:"version,omitempty"`
 	Env                []v1.EnvVar              `json:"env,omitempty"`
 	EnvFrom            []v1.EnvFromSource       `json:"envFrom,omitempty"`
 	VolumeMounts       []v1.VolumeMount         `json:"volumeMounts,omitempty"`
@@ -51,6 +55,26 @@ func (b *AutoscalerOptionsApplyConfiguration) WithImagePullPolicy(value v1.PullP
 	return b
 }
 
+// WithCommand adds the given value to the Command field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Command field.
+func (b *AutoscalerOptionsApplyConfiguration) WithCommand(values ...string) *AutoscalerOptionsApplyConfiguration {
+	for i := range values {
+		b.Command = append(b.Command, values[i])
+	}
+	return b
+}
+
+// WithArgs adds the given value to the Args field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Args field.
+func (b *AutoscalerOptionsApplyConfiguration) WithArgs(values ...string) *AutoscalerOptionsApplyConfiguration {
+	for i := range values {
+		b.Args = append(b.Args, values[i])
+	}
+	return b
+}
+
 // WithSecurityContext sets the SecurityContext field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the SecurityContext field is set to the value of the last call.
@@ -59,6 +83,14 @@ func (b *AutoscalerOptionsApplyConfiguration) WithSecurityContext(value v1.Secur
 	return b
 }
 
+// WithServiceAccountName sets the ServiceAccountName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccountName field is set to the value of the last call.
+func (b *AutoscalerOptionsApplyConfiguration) WithServiceAccountName(value string) *AutoscalerOptionsApplyConfiguration {
+	b.ServiceAccountName = &value
+	return b
+}
+
 // WithIdleTimeoutSeconds sets the IdleTimeoutSeconds field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the IdleTimeoutSeconds field is set to the value of the last call.
@@ -75,6 +107,14 @@ func (b *AutoscalerOptionsApplyConfiguration) WithUpscalingMode(value rayv1.Upsc
 	return b
 }
 
+// WithVersion sets the Version field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Version field is set to the value of the last call.
+func (b *AutoscalerOptionsApplyConfiguration) WithVersion(value rayv1.AutoscalerVersion) *AutoscalerOptionsApplyConfiguration {
+	b.Version = &value
+	return b
+}
+
 // WithEnv adds the given value to the Env field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Env field.
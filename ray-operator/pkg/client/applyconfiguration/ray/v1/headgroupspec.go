@@ -4,17 +4,23 @@ package v1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	corev1 "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
 // HeadGroupSpecApplyConfiguration represents an declarative configuration of the HeadGroupSpec type for use
 // with apply.
 type HeadGroupSpecApplyConfiguration struct {
-	ServiceType    *v1.ServiceType                           `json:"serviceType,omitempty"`
-	HeadService    *v1.Service                               `json:"headService,omitempty"`
-	EnableIngress  *bool                                     `json:"enableIngress,omitempty"`
-	RayStartParams map[string]string                         `json:"rayStartParams,omitempty"`
-	Template       *corev1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
+	ServiceType        *v1.ServiceType                           `json:"serviceType,omitempty"`
+	HeadService        *v1.Service                               `json:"headService,omitempty"`
+	EnableIngress      *bool                                     `json:"enableIngress,omitempty"`
+	SafeToEvict        *bool                                     `json:"safeToEvict,omitempty"`
+	ShmSize            *resource.Quantity                        `json:"shmSize,omitempty"`
+	DisableShmVolume   *bool                                     `json:"disableShmVolume,omitempty"`
+	RayStartParams     map[string]string                         `json:"rayStartParams,omitempty"`
+	RayStartParamsFrom *RayStartParamsSourceApplyConfiguration   `json:"rayStartParamsFrom,omitempty"`
+	Template           *corev1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
+	UpdateStrategy     *HeadUpdateStrategyApplyConfiguration     `json:"updateStrategy,omitempty"`
 }
 
 // HeadGroupSpecApplyConfiguration constructs an declarative configuration of the HeadGroupSpec type for use with
@@ -47,6 +53,30 @@ func (b *HeadGroupSpecApplyConfiguration) WithEnableIngress(value bool) *HeadGro
 	return b
 }
 
+// WithSafeToEvict sets the SafeToEvict field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SafeToEvict field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithSafeToEvict(value bool) *HeadGroupSpecApplyConfiguration {
+	b.SafeToEvict = &value
+	return b
+}
+
+// WithShmSize sets the ShmSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ShmSize field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithShmSize(value resource.Quantity) *HeadGroupSpecApplyConfiguration {
+	b.ShmSize = &value
+	return b
+}
+
+// WithDisableShmVolume sets the DisableShmVolume field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisableShmVolume field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithDisableShmVolume(value bool) *HeadGroupSpecApplyConfiguration {
+	b.DisableShmVolume = &value
+	return b
+}
+
 // WithRayStartParams puts the entries into the RayStartParams field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the RayStartParams field,
@@ -61,6 +91,14 @@ func (b *HeadGroupSpecApplyConfiguration) WithRayStartParams(entries map[string]
 	return b
 }
 
+// WithRayStartParamsFrom sets the RayStartParamsFrom field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RayStartParamsFrom field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithRayStartParamsFrom(value *RayStartParamsSourceApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.RayStartParamsFrom = value
+	return b
+}
+
 // WithTemplate sets the Template field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Template field is set to the value of the last call.
@@ -68,3 +106,11 @@ func (b *HeadGroupSpecApplyConfiguration) WithTemplate(value *corev1.PodTemplate
 	b.Template = value
 	return b
 }
+
+// WithUpdateStrategy sets the UpdateStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdateStrategy field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithUpdateStrategy(value *HeadUpdateStrategyApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.UpdateStrategy = value
+	return b
+}
@@ -2,16 +2,26 @@
 
 package v1
 
+import (
+	v1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
 // RayClusterSpecApplyConfiguration represents an declarative configuration of the RayClusterSpec type for use
 // with apply.
 type RayClusterSpecApplyConfiguration struct {
-	Suspend                 *bool                                `json:"suspend,omitempty"`
-	AutoscalerOptions       *AutoscalerOptionsApplyConfiguration `json:"autoscalerOptions,omitempty"`
-	HeadServiceAnnotations  map[string]string                    `json:"headServiceAnnotations,omitempty"`
-	EnableInTreeAutoscaling *bool                                `json:"enableInTreeAutoscaling,omitempty"`
-	HeadGroupSpec           *HeadGroupSpecApplyConfiguration     `json:"headGroupSpec,omitempty"`
-	RayVersion              *string                              `json:"rayVersion,omitempty"`
-	WorkerGroupSpecs        []WorkerGroupSpecApplyConfiguration  `json:"workerGroupSpecs,omitempty"`
+	Suspend                 *bool                                    `json:"suspend,omitempty"`
+	IdleTimeoutSeconds      *int32                                   `json:"idleTimeoutSeconds,omitempty"`
+	IdleTimeoutPolicy       *v1.IdleTimeoutPolicy                    `json:"idleTimeoutPolicy,omitempty"`
+	AutoscalerOptions       *AutoscalerOptionsApplyConfiguration     `json:"autoscalerOptions,omitempty"`
+	HeadServiceAnnotations  map[string]string                        `json:"headServiceAnnotations,omitempty"`
+	EnableInTreeAutoscaling *bool                                    `json:"enableInTreeAutoscaling,omitempty"`
+	ServiceMeshMode         *v1.ServiceMeshMode                      `json:"serviceMeshMode,omitempty"`
+	Logging                 *LoggingOptionsApplyConfiguration        `json:"logging,omitempty"`
+	ServiceAccountTokens    []ServiceAccountTokenApplyConfiguration  `json:"serviceAccountTokens,omitempty"`
+	DashboardClient         *DashboardClientConfigApplyConfiguration `json:"dashboardClient,omitempty"`
+	HeadGroupSpec           *HeadGroupSpecApplyConfiguration         `json:"headGroupSpec,omitempty"`
+	RayVersion              *string                                  `json:"rayVersion,omitempty"`
+	WorkerGroupSpecs        []WorkerGroupSpecApplyConfiguration      `json:"workerGroupSpecs,omitempty"`
 }
 
 // RayClusterSpecApplyConfiguration constructs an declarative configuration of the RayClusterSpec type for use with
@@ -28,6 +38,22 @@ func (b *RayClusterSpecApplyConfiguration) WithSuspend(value bool) *RayClusterSp
 	return b
 }
 
+// WithIdleTimeoutSeconds sets the IdleTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleTimeoutSeconds field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithIdleTimeoutSeconds(value int32) *RayClusterSpecApplyConfiguration {
+	b.IdleTimeoutSeconds = &value
+	return b
+}
+
+// WithIdleTimeoutPolicy sets the IdleTimeoutPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleTimeoutPolicy field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithIdleTimeoutPolicy(value v1.IdleTimeoutPolicy) *RayClusterSpecApplyConfiguration {
+	b.IdleTimeoutPolicy = &value
+	return b
+}
+
 // WithAutoscalerOptions sets the AutoscalerOptions field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the AutoscalerOptions field is set to the value of the last call.
@@ -58,6 +84,43 @@ func (b *RayClusterSpecApplyConfiguration) WithEnableInTreeAutoscaling(value boo
 	return b
 }
 
+// WithServiceMeshMode sets the ServiceMeshMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceMeshMode field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithServiceMeshMode(value v1.ServiceMeshMode) *RayClusterSpecApplyConfiguration {
+	b.ServiceMeshMode = &value
+	return b
+}
+
+// WithLogging sets the Logging field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Logging field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithLogging(value *LoggingOptionsApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.Logging = value
+	return b
+}
+
+// WithServiceAccountTokens adds the given value to the ServiceAccountTokens field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ServiceAccountTokens field.
+func (b *RayClusterSpecApplyConfiguration) WithServiceAccountTokens(values ...*ServiceAccountTokenApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithServiceAccountTokens")
+		}
+		b.ServiceAccountTokens = append(b.ServiceAccountTokens, *values[i])
+	}
+	return b
+}
+
+// WithDashboardClient sets the DashboardClient field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DashboardClient field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithDashboardClient(value *DashboardClientConfigApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.DashboardClient = value
+	return b
+}
+
 // WithHeadGroupSpec sets the HeadGroupSpec field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the HeadGroupSpec field is set to the value of the last call.
@@ -28,6 +28,10 @@ type RayClusterStatusApplyConfiguration struct {
 	MinWorkerReplicas       *int32                           `json:"minWorkerReplicas,omitempty"`
 	MaxWorkerReplicas       *int32                           `json:"maxWorkerReplicas,omitempty"`
 	ObservedGeneration      *int64                           `json:"observedGeneration,omitempty"`
+	GroupStatuses           []GroupStatusApplyConfiguration  `json:"groupStatuses,omitempty"`
+	PlacementGroups         []string                         `json:"placementGroups,omitempty"`
+	AutoscalerError         *string                          `json:"autoscalerError,omitempty"`
+	LastActivityTime        *metav1.Time                     `json:"lastActivityTime,omitempty"`
 }
 
 // RayClusterStatusApplyConfiguration constructs an declarative configuration of the RayClusterStatus type for use with
@@ -185,3 +189,42 @@ func (b *RayClusterStatusApplyConfiguration) WithObservedGeneration(value int64)
 	b.ObservedGeneration = &value
 	return b
 }
+
+// WithGroupStatuses adds the given value to the GroupStatuses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the GroupStatuses field.
+func (b *RayClusterStatusApplyConfiguration) WithGroupStatuses(values ...*GroupStatusApplyConfiguration) *RayClusterStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithGroupStatuses")
+		}
+		b.GroupStatuses = append(b.GroupStatuses, *values[i])
+	}
+	return b
+}
+
+// WithPlacementGroups adds the given value to the PlacementGroups field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PlacementGroups field.
+func (b *RayClusterStatusApplyConfiguration) WithPlacementGroups(values ...string) *RayClusterStatusApplyConfiguration {
+	for i := range values {
+		b.PlacementGroups = append(b.PlacementGroups, values[i])
+	}
+	return b
+}
+
+// WithAutoscalerError sets the AutoscalerError field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AutoscalerError field is set to the value of the last call.
+func (b *RayClusterStatusApplyConfiguration) WithAutoscalerError(value string) *RayClusterStatusApplyConfiguration {
+	b.AutoscalerError = &value
+	return b
+}
+
+// WithLastActivityTime sets the LastActivityTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastActivityTime field is set to the value of the last call.
+func (b *RayClusterStatusApplyConfiguration) WithLastActivityTime(value metav1.Time) *RayClusterStatusApplyConfiguration {
+	b.LastActivityTime = &value
+	return b
+}
@@ -10,23 +10,31 @@ import (
 // RayJobSpecApplyConfiguration represents an declarative configuration of the RayJobSpec type for use
 // with apply.
 type RayJobSpecApplyConfiguration struct {
-	ActiveDeadlineSeconds    *int32                                    `json:"activeDeadlineSeconds,omitempty"`
-	BackoffLimit             *int32                                    `json:"backoffLimit,omitempty"`
-	RayClusterSpec           *RayClusterSpecApplyConfiguration         `json:"rayClusterSpec,omitempty"`
-	SubmitterPodTemplate     *corev1.PodTemplateSpecApplyConfiguration `json:"submitterPodTemplate,omitempty"`
-	Metadata                 map[string]string                         `json:"metadata,omitempty"`
-	ClusterSelector          map[string]string                         `json:"clusterSelector,omitempty"`
-	SubmitterConfig          *SubmitterConfigApplyConfiguration        `json:"submitterConfig,omitempty"`
-	Entrypoint               *string                                   `json:"entrypoint,omitempty"`
-	RuntimeEnvYAML           *string                                   `json:"runtimeEnvYAML,omitempty"`
-	JobId                    *string                                   `json:"jobId,omitempty"`
-	SubmissionMode           *rayv1.JobSubmissionMode                  `json:"submissionMode,omitempty"`
-	EntrypointResources      *string                                   `json:"entrypointResources,omitempty"`
-	EntrypointNumCpus        *float32                                  `json:"entrypointNumCpus,omitempty"`
-	EntrypointNumGpus        *float32                                  `json:"entrypointNumGpus,omitempty"`
-	TTLSecondsAfterFinished  *int32                                    `json:"ttlSecondsAfterFinished,omitempty"`
-	ShutdownAfterJobFinishes *bool                                     `json:"shutdownAfterJobFinishes,omitempty"`
-	Suspend                  *bool                                     `json:"suspend,omitempty"`
+	ActiveDeadlineSeconds      *int32                                    `json:"activeDeadlineSeconds,omitempty"`
+	ClusterReadyTimeoutSeconds *int32                                    `json:"clusterReadyTimeoutSeconds,omitempty"`
+	BackoffLimit               *int32                                    `json:"backoffLimit,omitempty"`
+	ReuseRayClusterOnRetry     *bool                                     `json:"reuseRayClusterOnRetry,omitempty"`
+	RayClusterSpec             *RayClusterSpecApplyConfiguration         `json:"rayClusterSpec,omitempty"`
+	RayClusterMetadata         *RayClusterMetadataApplyConfiguration     `json:"rayClusterMetadata,omitempty"`
+	SubmitterPodTemplate       *corev1.PodTemplateSpecApplyConfiguration `json:"submitterPodTemplate,omitempty"`
+	Metadata                   map[string]string                         `json:"metadata,omitempty"`
+	ClusterSelector            map[string]string                         `json:"clusterSelector,omitempty"`
+	SubmitterConfig            *SubmitterConfigApplyConfiguration        `json:"submitterConfig,omitempty"`
+	Entrypoint                 *string                                   `json:"entrypoint,omitempty"`
+	Steps                      []RayJobStepApplyConfiguration            `json:"steps,omitempty"`
+	RuntimeEnvYAML             *string                                   `json:"runtimeEnvYAML,omitempty"`
+	RuntimeEnv                 *RuntimeEnvApplyConfiguration             `json:"runtimeEnv,omitempty"`
+	JobId                      *string                                   `json:"jobId,omitempty"`
+	SubmissionMode             *rayv1.JobSubmissionMode                  `json:"submissionMode,omitempty"`
+	LogStreaming               *LogStreamingOptionsApplyConfiguration    `json:"logStreaming,omitempty"`
+	EntrypointResources        *string                                   `json:"entrypointResources,omitempty"`
+	EntrypointNumCpus          *float32                                  `json:"entrypointNumCpus,omitempty"`
+	EntrypointNumGpus          *float32                                  `json:"entrypointNumGpus,omitempty"`
+	TTLSecondsAfterFinished    *int32                                    `json:"ttlSecondsAfterFinished,omitempty"`
+	ShutdownAfterJobFinishes   *bool                                     `json:"shutdownAfterJobFinishes,omitempty"`
+	DeletionPolicy             *rayv1.DeletionPolicy                     `json:"deletionPolicy,omitempty"`
+	Suspend                    *bool                                     `json:"suspend,omitempty"`
+	Schedule                   *string                                   `json:"schedule,omitempty"`
 }
 
 // RayJobSpecApplyConfiguration constructs an declarative configuration of the RayJobSpec type for use with
@@ -43,6 +51,14 @@ func (b *RayJobSpecApplyConfiguration) WithActiveDeadlineSeconds(value int32) *R
 	return b
 }
 
+// WithClusterReadyTimeoutSeconds sets the ClusterReadyTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterReadyTimeoutSeconds field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithClusterReadyTimeoutSeconds(value int32) *RayJobSpecApplyConfiguration {
+	b.ClusterReadyTimeoutSeconds = &value
+	return b
+}
+
 // WithBackoffLimit sets the BackoffLimit field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the BackoffLimit field is set to the value of the last call.
@@ -51,6 +67,14 @@ func (b *RayJobSpecApplyConfiguration) WithBackoffLimit(value int32) *RayJobSpec
 	return b
 }
 
+// WithReuseRayClusterOnRetry sets the ReuseRayClusterOnRetry field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReuseRayClusterOnRetry field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithReuseRayClusterOnRetry(value bool) *RayJobSpecApplyConfiguration {
+	b.ReuseRayClusterOnRetry = &value
+	return b
+}
+
 // WithRayClusterSpec sets the RayClusterSpec field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RayClusterSpec field is set to the value of the last call.
@@ -59,6 +83,14 @@ func (b *RayJobSpecApplyConfiguration) WithRayClusterSpec(value *RayClusterSpecA
 	return b
 }
 
+// WithRayClusterMetadata sets the RayClusterMetadata field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RayClusterMetadata field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithRayClusterMetadata(value *RayClusterMetadataApplyConfiguration) *RayJobSpecApplyConfiguration {
+	b.RayClusterMetadata = value
+	return b
+}
+
 // WithSubmitterPodTemplate sets the SubmitterPodTemplate field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the SubmitterPodTemplate field is set to the value of the last call.
@@ -111,6 +143,19 @@ func (b *RayJobSpecApplyConfiguration) WithEntrypoint(value string) *RayJobSpecA
 	return b
 }
 
+// WithSteps adds the given value to the Steps field in the declarative configuration
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Steps field.
+func (b *RayJobSpecApplyConfiguration) WithSteps(values ...*RayJobStepApplyConfiguration) *RayJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSteps")
+		}
+		b.Steps = append(b.Steps, *values[i])
+	}
+	return b
+}
+
 // WithRuntimeEnvYAML sets the RuntimeEnvYAML field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RuntimeEnvYAML field is set to the value of the last call.
@@ -119,6 +164,14 @@ func (b *RayJobSpecApplyConfiguration) WithRuntimeEnvYAML(value string) *RayJobS
 	return b
 }
 
+// WithRuntimeEnv sets the RuntimeEnv field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RuntimeEnv field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithRuntimeEnv(value *RuntimeEnvApplyConfiguration) *RayJobSpecApplyConfiguration {
+	b.RuntimeEnv = value
+	return b
+}
+
 // WithJobId sets the JobId field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the JobId field is set to the value of the last call.
@@ -135,6 +188,14 @@ func (b *RayJobSpecApplyConfiguration) WithSubmissionMode(value rayv1.JobSubmiss
 	return b
 }
 
+// WithLogStreaming sets the LogStreaming field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LogStreaming field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithLogStreaming(value *LogStreamingOptionsApplyConfiguration) *RayJobSpecApplyConfiguration {
+	b.LogStreaming = value
+	return b
+}
+
 // WithEntrypointResources sets the EntrypointResources field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the EntrypointResources field is set to the value of the last call.
@@ -175,6 +236,14 @@ func (b *RayJobSpecApplyConfiguration) WithShutdownAfterJobFinishes(value bool)
 	return b
 }
 
+// WithDeletionPolicy sets the DeletionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionPolicy field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithDeletionPolicy(value rayv1.DeletionPolicy) *RayJobSpecApplyConfiguration {
+	b.DeletionPolicy = &value
+	return b
+}
+
 // WithSuspend sets the Suspend field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Suspend field is set to the value of the last call.
@@ -182,3 +251,11 @@ func (b *RayJobSpecApplyConfiguration) WithSuspend(value bool) *RayJobSpecApplyC
 	b.Suspend = &value
 	return b
 }
+
+// WithSchedule sets the Schedule field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Schedule field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithSchedule(value string) *RayJobSpecApplyConfiguration {
+	b.Schedule = &value
+	return b
+}
@@ -10,19 +10,28 @@ import (
 // RayJobStatusApplyConfiguration represents an declarative configuration of the RayJobStatus type for use
 // with apply.
 type RayJobStatusApplyConfiguration struct {
-	JobId               *string                             `json:"jobId,omitempty"`
-	RayClusterName      *string                             `json:"rayClusterName,omitempty"`
-	DashboardURL        *string                             `json:"dashboardURL,omitempty"`
-	JobStatus           *v1.JobStatus                       `json:"jobStatus,omitempty"`
-	JobDeploymentStatus *v1.JobDeploymentStatus             `json:"jobDeploymentStatus,omitempty"`
-	Reason              *v1.JobFailedReason                 `json:"reason,omitempty"`
-	Message             *string                             `json:"message,omitempty"`
-	StartTime           *metav1.Time                        `json:"startTime,omitempty"`
-	EndTime             *metav1.Time                        `json:"endTime,omitempty"`
-	Succeeded           *int32                              `json:"succeeded,omitempty"`
-	Failed              *int32                              `json:"failed,omitempty"`
-	RayClusterStatus    *RayClusterStatusApplyConfiguration `json:"rayClusterStatus,omitempty"`
-	ObservedGeneration  *int64                              `json:"observedGeneration,omitempty"`
+	JobId               *string                              `json:"jobId,omitempty"`
+	RayClusterName      *string                              `json:"rayClusterName,omitempty"`
+	DashboardURL        *string                              `json:"dashboardURL,omitempty"`
+	JobStatus           *v1.JobStatus                        `json:"jobStatus,omitempty"`
+	JobDeploymentStatus *v1.JobDeploymentStatus              `json:"jobDeploymentStatus,omitempty"`
+	Reason              *v1.JobFailedReason                  `json:"reason,omitempty"`
+	Message             *string                              `json:"message,omitempty"`
+	LogsTail            *string                              `json:"logsTail,omitempty"`
+	DriverExitCode      *int32                               `json:"driverExitCode,omitempty"`
+	JobResult           *string                              `json:"jobResult,omitempty"`
+	StartTime           *metav1.Time                         `json:"startTime,omitempty"`
+	EndTime             *metav1.Time                         `json:"endTime,omitempty"`
+	LastScheduleTime    *metav1.Time                         `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime  *metav1.Time                         `json:"lastSuccessfulTime,omitempty"`
+	Succeeded           *int32                               `json:"succeeded,omitempty"`
+	Failed              *int32                               `json:"failed,omitempty"`
+	Attempt             *int32                               `json:"attempt,omitempty"`
+	Attempts            []RayJobAttemptApplyConfiguration    `json:"attempts,omitempty"`
+	Steps               []RayJobStepStatusApplyConfiguration `json:"steps,omitempty"`
+	RayClusterStatus    *RayClusterStatusApplyConfiguration  `json:"rayClusterStatus,omitempty"`
+	Conditions          []metav1.Condition                   `json:"conditions,omitempty"`
+	ObservedGeneration  *int64                               `json:"observedGeneration,omitempty"`
 }
 
 // RayJobStatusApplyConfiguration constructs an declarative configuration of the RayJobStatus type for use with
@@ -87,6 +96,30 @@ func (b *RayJobStatusApplyConfiguration) WithMessage(value string) *RayJobStatus
 	return b
 }
 
+// WithLogsTail sets the LogsTail field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LogsTail field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithLogsTail(value string) *RayJobStatusApplyConfiguration {
+	b.LogsTail = &value
+	return b
+}
+
+// WithDriverExitCode sets the DriverExitCode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DriverExitCode field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithDriverExitCode(value int32) *RayJobStatusApplyConfiguration {
+	b.DriverExitCode = &value
+	return b
+}
+
+// WithJobResult sets the JobResult field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobResult field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithJobResult(value string) *RayJobStatusApplyConfiguration {
+	b.JobResult = &value
+	return b
+}
+
 // WithStartTime sets the StartTime field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the StartTime field is set to the value of the last call.
@@ -103,6 +136,22 @@ func (b *RayJobStatusApplyConfiguration) WithEndTime(value metav1.Time) *RayJobS
 	return b
 }
 
+// WithLastScheduleTime sets the LastScheduleTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastScheduleTime field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithLastScheduleTime(value metav1.Time) *RayJobStatusApplyConfiguration {
+	b.LastScheduleTime = &value
+	return b
+}
+
+// WithLastSuccessfulTime sets the LastSuccessfulTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastSuccessfulTime field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithLastSuccessfulTime(value metav1.Time) *RayJobStatusApplyConfiguration {
+	b.LastSuccessfulTime = &value
+	return b
+}
+
 // WithSucceeded sets the Succeeded field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Succeeded field is set to the value of the last call.
@@ -119,6 +168,40 @@ func (b *RayJobStatusApplyConfiguration) WithFailed(value int32) *RayJobStatusAp
 	return b
 }
 
+// WithAttempt sets the Attempt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Attempt field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithAttempt(value int32) *RayJobStatusApplyConfiguration {
+	b.Attempt = &value
+	return b
+}
+
+// WithAttempts adds the given value to the Attempts field in the declarative configuration
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Attempts field.
+func (b *RayJobStatusApplyConfiguration) WithAttempts(values ...*RayJobAttemptApplyConfiguration) *RayJobStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAttempts")
+		}
+		b.Attempts = append(b.Attempts, *values[i])
+	}
+	return b
+}
+
+// WithSteps adds the given value to the Steps field in the declarative configuration
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Steps field.
+func (b *RayJobStatusApplyConfiguration) WithSteps(values ...*RayJobStepStatusApplyConfiguration) *RayJobStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSteps")
+		}
+		b.Steps = append(b.Steps, *values[i])
+	}
+	return b
+}
+
 // WithRayClusterStatus sets the RayClusterStatus field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RayClusterStatus field is set to the value of the last call.
@@ -127,6 +210,16 @@ func (b *RayJobStatusApplyConfiguration) WithRayClusterStatus(value *RayClusterS
 	return b
 }
 
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *RayJobStatusApplyConfiguration) WithConditions(values ...metav1.Condition) *RayJobStatusApplyConfiguration {
+	for i := range values {
+		b.Conditions = append(b.Conditions, values[i])
+	}
+	return b
+}
+
 // WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ObservedGeneration field is set to the value of the last call.
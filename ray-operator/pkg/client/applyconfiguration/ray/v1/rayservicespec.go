@@ -9,11 +9,15 @@ import (
 // RayServiceSpecApplyConfiguration represents an declarative configuration of the RayServiceSpec type for use
 // with apply.
 type RayServiceSpecApplyConfiguration struct {
-	ServiceUnhealthySecondThreshold    *int32                            `json:"serviceUnhealthySecondThreshold,omitempty"`
-	DeploymentUnhealthySecondThreshold *int32                            `json:"deploymentUnhealthySecondThreshold,omitempty"`
-	ServeService                       *v1.Service                       `json:"serveService,omitempty"`
-	ServeConfigV2                      *string                           `json:"serveConfigV2,omitempty"`
-	RayClusterSpec                     *RayClusterSpecApplyConfiguration `json:"rayClusterConfig,omitempty"`
+	ServiceUnhealthySecondThreshold    *int32                                       `json:"serviceUnhealthySecondThreshold,omitempty"`
+	DeploymentUnhealthySecondThreshold *int32                                       `json:"deploymentUnhealthySecondThreshold,omitempty"`
+	HealthCheckPeriodSeconds           *int32                                       `json:"healthCheckPeriodSeconds,omitempty"`
+	UpgradeStrategy                    *RayServiceUpgradeStrategyApplyConfiguration `json:"upgradeStrategy,omitempty"`
+	Rollback                           *RayServiceRollbackApplyConfiguration        `json:"rollback,omitempty"`
+	ServeService                       *v1.Service                                  `json:"serveService,omitempty"`
+	ExcludeHeadPodFromServeSvc         *bool                                        `json:"excludeHeadPodFromServeSvc,omitempty"`
+	ServeConfigV2                      *string                                      `json:"serveConfigV2,omitempty"`
+	RayClusterSpec                     *RayClusterSpecApplyConfiguration            `json:"rayClusterConfig,omitempty"`
 }
 
 // RayServiceSpecApplyConfiguration constructs an declarative configuration of the RayServiceSpec type for use with
@@ -38,6 +42,30 @@ func (b *RayServiceSpecApplyConfiguration) WithDeploymentUnhealthySecondThreshol
 	return b
 }
 
+// WithHealthCheckPeriodSeconds sets the HealthCheckPeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HealthCheckPeriodSeconds field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithHealthCheckPeriodSeconds(value int32) *RayServiceSpecApplyConfiguration {
+	b.HealthCheckPeriodSeconds = &value
+	return b
+}
+
+// WithUpgradeStrategy sets the UpgradeStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpgradeStrategy field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithUpgradeStrategy(value *RayServiceUpgradeStrategyApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.UpgradeStrategy = value
+	return b
+}
+
+// WithRollback sets the Rollback field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rollback field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithRollback(value *RayServiceRollbackApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.Rollback = value
+	return b
+}
+
 // WithServeService sets the ServeService field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ServeService field is set to the value of the last call.
@@ -46,6 +74,14 @@ func (b *RayServiceSpecApplyConfiguration) WithServeService(value v1.Service) *R
 	return b
 }
 
+// WithExcludeHeadPodFromServeSvc sets the ExcludeHeadPodFromServeSvc field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExcludeHeadPodFromServeSvc field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithExcludeHeadPodFromServeSvc(value bool) *RayServiceSpecApplyConfiguration {
+	b.ExcludeHeadPodFromServeSvc = &value
+	return b
+}
+
 // WithServeConfigV2 sets the ServeConfigV2 field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ServeConfigV2 field is set to the value of the last call.
@@ -2,12 +2,18 @@
 
 package v1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // RayServiceStatusApplyConfiguration represents an declarative configuration of the RayServiceStatus type for use
 // with apply.
 type RayServiceStatusApplyConfiguration struct {
-	Applications     map[string]AppStatusApplyConfiguration `json:"applicationStatuses,omitempty"`
-	RayClusterName   *string                                `json:"rayClusterName,omitempty"`
-	RayClusterStatus *RayClusterStatusApplyConfiguration    `json:"rayClusterStatus,omitempty"`
+	Applications            map[string]AppStatusApplyConfiguration `json:"applicationStatuses,omitempty"`
+	RayClusterName          *string                                `json:"rayClusterName,omitempty"`
+	RayClusterStatus        *RayClusterStatusApplyConfiguration    `json:"rayClusterStatus,omitempty"`
+	TrafficRoutedPercent    *int32                                 `json:"trafficRoutedPercent,omitempty"`
+	LastTrafficMigratedTime *v1.Time                               `json:"lastTrafficMigratedTime,omitempty"`
 }
 
 // RayServiceStatusApplyConfiguration constructs an declarative configuration of the RayServiceStatus type for use with
@@ -45,3 +51,19 @@ func (b *RayServiceStatusApplyConfiguration) WithRayClusterStatus(value *RayClus
 	b.RayClusterStatus = value
 	return b
 }
+
+// WithTrafficRoutedPercent sets the TrafficRoutedPercent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TrafficRoutedPercent field is set to the value of the last call.
+func (b *RayServiceStatusApplyConfiguration) WithTrafficRoutedPercent(value int32) *RayServiceStatusApplyConfiguration {
+	b.TrafficRoutedPercent = &value
+	return b
+}
+
+// WithLastTrafficMigratedTime sets the LastTrafficMigratedTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTrafficMigratedTime field is set to the value of the last call.
+func (b *RayServiceStatusApplyConfiguration) WithLastTrafficMigratedTime(value v1.Time) *RayServiceStatusApplyConfiguration {
+	b.LastTrafficMigratedTime = &value
+	return b
+}
@@ -10,12 +10,13 @@ import (
 // RayServiceStatusesApplyConfiguration represents an declarative configuration of the RayServiceStatuses type for use
 // with apply.
 type RayServiceStatusesApplyConfiguration struct {
-	LastUpdateTime       *v1.Time                            `json:"lastUpdateTime,omitempty"`
-	ServiceStatus        *rayv1.ServiceStatus                `json:"serviceStatus,omitempty"`
-	ActiveServiceStatus  *RayServiceStatusApplyConfiguration `json:"activeServiceStatus,omitempty"`
-	PendingServiceStatus *RayServiceStatusApplyConfiguration `json:"pendingServiceStatus,omitempty"`
-	NumServeEndpoints    *int32                              `json:"numServeEndpoints,omitempty"`
-	ObservedGeneration   *int64                              `json:"observedGeneration,omitempty"`
+	LastUpdateTime         *v1.Time                            `json:"lastUpdateTime,omitempty"`
+	ServiceStatus          *rayv1.ServiceStatus                `json:"serviceStatus,omitempty"`
+	ActiveServiceStatus    *RayServiceStatusApplyConfiguration `json:"activeServiceStatus,omitempty"`
+	PendingServiceStatus   *RayServiceStatusApplyConfiguration `json:"pendingServiceStatus,omitempty"`
+	PreviousRayClusterName *string                             `json:"previousRayClusterName,omitempty"`
+	NumServeEndpoints      *int32                              `json:"numServeEndpoints,omitempty"`
+	ObservedGeneration     *int64                              `json:"observedGeneration,omitempty"`
 }
 
 // RayServiceStatusesApplyConfiguration constructs an declarative configuration of the RayServiceStatuses type for use with
@@ -56,6 +57,14 @@ func (b *RayServiceStatusesApplyConfiguration) WithPendingServiceStatus(value *R
 	return b
 }
 
+// WithPreviousRayClusterName sets the PreviousRayClusterName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreviousRayClusterName field is set to the value of the last call.
+func (b *RayServiceStatusesApplyConfiguration) WithPreviousRayClusterName(value string) *RayServiceStatusesApplyConfiguration {
+	b.PreviousRayClusterName = &value
+	return b
+}
+
 // WithNumServeEndpoints sets the NumServeEndpoints field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the NumServeEndpoints field is set to the value of the last call.
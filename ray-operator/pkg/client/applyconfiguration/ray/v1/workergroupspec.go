@@ -3,20 +3,36 @@
 package v1
 
 import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
 // WorkerGroupSpecApplyConfiguration represents an declarative configuration of the WorkerGroupSpec type for use
 // with apply.
 type WorkerGroupSpecApplyConfiguration struct {
-	GroupName      *string                               `json:"groupName,omitempty"`
-	Replicas       *int32                                `json:"replicas,omitempty"`
-	MinReplicas    *int32                                `json:"minReplicas,omitempty"`
-	MaxReplicas    *int32                                `json:"maxReplicas,omitempty"`
-	RayStartParams map[string]string                     `json:"rayStartParams,omitempty"`
-	Template       *v1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
-	ScaleStrategy  *ScaleStrategyApplyConfiguration      `json:"scaleStrategy,omitempty"`
-	NumOfHosts     *int32                                `json:"numOfHosts,omitempty"`
+	GroupName          *string                                      `json:"groupName,omitempty"`
+	Replicas           *int32                                       `json:"replicas,omitempty"`
+	MinReplicas        *int32                                       `json:"minReplicas,omitempty"`
+	MaxReplicas        *int32                                       `json:"maxReplicas,omitempty"`
+	RayStartParams     map[string]string                            `json:"rayStartParams,omitempty"`
+	RayStartParamsFrom *RayStartParamsSourceApplyConfiguration      `json:"rayStartParamsFrom,omitempty"`
+	Template           *v1.PodTemplateSpecApplyConfiguration        `json:"template,omitempty"`
+	ScaleStrategy      *ScaleStrategyApplyConfiguration             `json:"scaleStrategy,omitempty"`
+	ScheduledScaling   []ScheduledScalingWindowApplyConfiguration   `json:"scheduledScaling,omitempty"`
+	ScalePolicy        *ScalePolicyApplyConfiguration               `json:"scalePolicy,omitempty"`
+	WarmPoolSize       *int32                                       `json:"warmPoolSize,omitempty"`
+	IdleTimeoutSeconds *int32                                       `json:"idleTimeoutSeconds,omitempty"`
+	NumOfHosts         *int32                                       `json:"numOfHosts,omitempty"`
+	SafeToEvict        *bool                                        `json:"safeToEvict,omitempty"`
+	Spot               *bool                                        `json:"spot,omitempty"`
+	TopologySpread     *TopologySpreadOptionsApplyConfiguration     `json:"topologySpread,omitempty"`
+	MaxPodAge          *metav1.Duration                             `json:"maxPodAge,omitempty"`
+	GPU                *GPUOptionsApplyConfiguration                `json:"gpu,omitempty"`
+	ShmSize            *resource.Quantity                           `json:"shmSize,omitempty"`
+	DisableShmVolume   *bool                                        `json:"disableShmVolume,omitempty"`
+	PlacementGroup     *PlacementGroupOptionsApplyConfiguration     `json:"placementGroup,omitempty"`
+	UpdateStrategy     *WorkerGroupUpdateStrategyApplyConfiguration `json:"updateStrategy,omitempty"`
 }
 
 // WorkerGroupSpecApplyConfiguration constructs an declarative configuration of the WorkerGroupSpec type for use with
@@ -71,6 +87,14 @@ func (b *WorkerGroupSpecApplyConfiguration) WithRayStartParams(entries map[strin
 	return b
 }
 
+// WithRayStartParamsFrom sets the RayStartParamsFrom field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RayStartParamsFrom field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithRayStartParamsFrom(value *RayStartParamsSourceApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.RayStartParamsFrom = value
+	return b
+}
+
 // WithTemplate sets the Template field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Template field is set to the value of the last call.
@@ -87,6 +111,43 @@ func (b *WorkerGroupSpecApplyConfiguration) WithScaleStrategy(value *ScaleStrate
 	return b
 }
 
+// WithScheduledScaling adds the given value to the ScheduledScaling field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ScheduledScaling field.
+func (b *WorkerGroupSpecApplyConfiguration) WithScheduledScaling(values ...*ScheduledScalingWindowApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithScheduledScaling")
+		}
+		b.ScheduledScaling = append(b.ScheduledScaling, *values[i])
+	}
+	return b
+}
+
+// WithScalePolicy sets the ScalePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScalePolicy field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithScalePolicy(value *ScalePolicyApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.ScalePolicy = value
+	return b
+}
+
+// WithWarmPoolSize sets the WarmPoolSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WarmPoolSize field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithWarmPoolSize(value int32) *WorkerGroupSpecApplyConfiguration {
+	b.WarmPoolSize = &value
+	return b
+}
+
+// WithIdleTimeoutSeconds sets the IdleTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleTimeoutSeconds field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithIdleTimeoutSeconds(value int32) *WorkerGroupSpecApplyConfiguration {
+	b.IdleTimeoutSeconds = &value
+	return b
+}
+
 // WithNumOfHosts sets the NumOfHosts field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the NumOfHosts field is set to the value of the last call.
@@ -94,3 +155,75 @@ func (b *WorkerGroupSpecApplyConfiguration) WithNumOfHosts(value int32) *WorkerG
 	b.NumOfHosts = &value
 	return b
 }
+
+// WithSafeToEvict sets the SafeToEvict field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SafeToEvict field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithSafeToEvict(value bool) *WorkerGroupSpecApplyConfiguration {
+	b.SafeToEvict = &value
+	return b
+}
+
+// WithSpot sets the Spot field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Spot field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithSpot(value bool) *WorkerGroupSpecApplyConfiguration {
+	b.Spot = &value
+	return b
+}
+
+// WithTopologySpread sets the TopologySpread field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TopologySpread field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithTopologySpread(value *TopologySpreadOptionsApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.TopologySpread = value
+	return b
+}
+
+// WithMaxPodAge sets the MaxPodAge field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxPodAge field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithMaxPodAge(value metav1.Duration) *WorkerGroupSpecApplyConfiguration {
+	b.MaxPodAge = &value
+	return b
+}
+
+// WithGPU sets the GPU field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GPU field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithGPU(value *GPUOptionsApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.GPU = value
+	return b
+}
+
+// WithShmSize sets the ShmSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ShmSize field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithShmSize(value resource.Quantity) *WorkerGroupSpecApplyConfiguration {
+	b.ShmSize = &value
+	return b
+}
+
+// WithDisableShmVolume sets the DisableShmVolume field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisableShmVolume field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithDisableShmVolume(value bool) *WorkerGroupSpecApplyConfiguration {
+	b.DisableShmVolume = &value
+	return b
+}
+
+// WithPlacementGroup sets the PlacementGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PlacementGroup field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithPlacementGroup(value *PlacementGroupOptionsApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.PlacementGroup = value
+	return b
+}
+
+// WithUpdateStrategy sets the UpdateStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdateStrategy field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithUpdateStrategy(value *WorkerGroupUpdateStrategyApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.UpdateStrategy = value
+	return b
+}
@@ -17,38 +17,100 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &rayv1.AppStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("AutoscalerOptions"):
 		return &rayv1.AutoscalerOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterUpgradeOptions"):
+		return &rayv1.ClusterUpgradeOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DashboardClientConfig"):
+		return &rayv1.DashboardClientConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DashboardTLSConfig"):
+		return &rayv1.DashboardTLSConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GPUOptions"):
+		return &rayv1.GPUOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GroupStatus"):
+		return &rayv1.GroupStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HeadGroupSpec"):
 		return &rayv1.HeadGroupSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HeadInfo"):
 		return &rayv1.HeadInfoApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HeadUpdateStrategy"):
+		return &rayv1.HeadUpdateStrategyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("LogArchiveOptions"):
+		return &rayv1.LogArchiveOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("LogSidecarOptions"):
+		return &rayv1.LogSidecarOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("LogStreamingOptions"):
+		return &rayv1.LogStreamingOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("LogVolumeOptions"):
+		return &rayv1.LogVolumeOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("LoggingOptions"):
+		return &rayv1.LoggingOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PlacementGroupOptions"):
+		return &rayv1.PlacementGroupOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PodStartupDuration"):
+		return &rayv1.PodStartupDurationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayCluster"):
 		return &rayv1.RayClusterApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayClusterMetadata"):
+		return &rayv1.RayClusterMetadataApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayClusterSpec"):
 		return &rayv1.RayClusterSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayClusterStatus"):
 		return &rayv1.RayClusterStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayCronJob"):
+		return &rayv1.RayCronJobApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayCronJobSpec"):
+		return &rayv1.RayCronJobSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayCronJobStatus"):
+		return &rayv1.RayCronJobStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayJob"):
 		return &rayv1.RayJobApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayJobAttempt"):
+		return &rayv1.RayJobAttemptApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayJobSpec"):
 		return &rayv1.RayJobSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayJobStatus"):
 		return &rayv1.RayJobStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayJobStep"):
+		return &rayv1.RayJobStepApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayJobStepStatus"):
+		return &rayv1.RayJobStepStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayJobTemplateSpec"):
+		return &rayv1.RayJobTemplateSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayService"):
 		return &rayv1.RayServiceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayServiceRollback"):
+		return &rayv1.RayServiceRollbackApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayServiceSpec"):
 		return &rayv1.RayServiceSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayServiceStatus"):
 		return &rayv1.RayServiceStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayServiceStatuses"):
 		return &rayv1.RayServiceStatusesApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayServiceUpgradeStrategy"):
+		return &rayv1.RayServiceUpgradeStrategyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayStartParamsSource"):
+		return &rayv1.RayStartParamsSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RollingUpdateWorkerGroup"):
+		return &rayv1.RollingUpdateWorkerGroupApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RuntimeEnv"):
+		return &rayv1.RuntimeEnvApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ScalePolicy"):
+		return &rayv1.ScalePolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ScaleStrategy"):
 		return &rayv1.ScaleStrategyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ScheduledScalingWindow"):
+		return &rayv1.ScheduledScalingWindowApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ServeDeploymentStatus"):
 		return &rayv1.ServeDeploymentStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServiceAccountToken"):
+		return &rayv1.ServiceAccountTokenApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("SubmitterConfig"):
 		return &rayv1.SubmitterConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TopologySpreadOptions"):
+		return &rayv1.TopologySpreadOptionsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("WorkerGroupSpec"):
 		return &rayv1.WorkerGroupSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WorkerGroupUpdateStrategy"):
+		return &rayv1.WorkerGroupUpdateStrategyApplyConfiguration{}
 
 	}
 	return nil
@@ -16,6 +16,10 @@ func (c *FakeRayV1) RayClusters(namespace string) v1.RayClusterInterface {
 	return &FakeRayClusters{c, namespace}
 }
 
+func (c *FakeRayV1) RayCronJobs(namespace string) v1.RayCronJobInterface {
+	return &FakeRayCronJobs{c, namespace}
+}
+
 func (c *FakeRayV1) RayJobs(namespace string) v1.RayJobInterface {
 	return &FakeRayJobs{c, namespace}
 }
@@ -13,6 +13,7 @@ import (
 type RayV1Interface interface {
 	RESTClient() rest.Interface
 	RayClustersGetter
+	RayCronJobsGetter
 	RayJobsGetter
 	RayServicesGetter
 }
@@ -26,6 +27,10 @@ func (c *RayV1Client) RayClusters(namespace string) RayClusterInterface {
 	return newRayClusters(c, namespace)
 }
 
+func (c *RayV1Client) RayCronJobs(namespace string) RayCronJobInterface {
+	return newRayCronJobs(c, namespace)
+}
+
 func (c *RayV1Client) RayJobs(namespace string) RayJobInterface {
 	return newRayJobs(c, namespace)
 }
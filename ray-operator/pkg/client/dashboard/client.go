@@ -0,0 +1,186 @@
+// Package dashboard is a client of the REST APIs served by the Ray dashboard: the Ray Job Submission API, which submits
+// Ray jobs to a Ray cluster and manages them, and the multi-application Ray Serve API (v2), which deploys the Serve
+// applications of a Ray cluster and reports their status. It is used by the KubeRay operator and apiserver, and can be
+// used by any Go program reaching the dashboard, e.g. through a port-forward to the head Service of a RayCluster.
+//
+// Reference to https://docs.ray.io/en/latest/cluster/running-applications/job-submission/rest.html
+// Reference to https://docs.ray.io/en/latest/serve/api/index.html#serve-rest-api
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// JobPath is the URL path of the Ray Job Submission REST API.
+const JobPath = "/api/jobs/"
+
+// APIError is returned when the Ray dashboard responds to a request with a status code other than 2xx.
+type APIError struct {
+	// Operation is the method of the Client that made the request, e.g. SubmitJob.
+	Operation string
+	// Status is the status of the response, e.g. "404 Not Found".
+	Status string
+	// Body is the body of the response, which usually describes the error.
+	Body       string
+	StatusCode int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s fail: %s %s", e.Operation, e.Status, e.Body)
+}
+
+// IsNotFound returns whether the error is an APIError with the 404 status code, e.g. for a job that does not exist.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Client makes requests to the REST APIs of a Ray dashboard. It is safe for concurrent use.
+type Client struct {
+	httpClient *http.Client
+	address    string
+}
+
+// NewClient returns a client of the Ray dashboard at address, e.g. http://raycluster-head-svc.default.svc:8265. The
+// requests are made with httpClient, or http.DefaultClient if nil, so that their transport, e.g. to authenticate
+// them, and their timeout can be customized.
+func NewClient(address string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		httpClient: httpClient,
+		address:    strings.TrimSuffix(address, "/"),
+	}
+}
+
+// Address returns the address of the Ray dashboard.
+func (c *Client) Address() string {
+	return c.address
+}
+
+// SubmitJob submits a Ray job and returns its submission ID, which is generated by Ray if not set in the request.
+func (c *Client) SubmitJob(ctx context.Context, request *RayJobRequest) (string, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	respBody, err := c.do(ctx, "SubmitJob", http.MethodPost, JobPath, body)
+	if err != nil {
+		return "", err
+	}
+	var jobResp RayJobResponse
+	if err = json.Unmarshal(respBody, &jobResp); err != nil {
+		// Maybe body is not valid json, raise an error with the body.
+		return "", fmt.Errorf("SubmitJob fail: %s", string(respBody))
+	}
+	return jobResp.JobId, nil
+}
+
+// GetJobInfo returns the state of the Ray job with the given submission ID. It returns an APIError for which
+// IsNotFound is true if the job does not exist.
+func (c *Client) GetJobInfo(ctx context.Context, submissionID string) (*RayJobInfo, error) {
+	respBody, err := c.do(ctx, "GetJobInfo", http.MethodGet, JobPath+url.PathEscape(submissionID), nil)
+	if err != nil {
+		return nil, err
+	}
+	var jobInfo RayJobInfo
+	if err = json.Unmarshal(respBody, &jobInfo); err != nil {
+		return nil, fmt.Errorf("GetJobInfo fail: %s", string(respBody))
+	}
+	return &jobInfo, nil
+}
+
+// ListJobs returns the state of all the Ray jobs of the Ray cluster.
+func (c *Client) ListJobs(ctx context.Context) ([]RayJobInfo, error) {
+	respBody, err := c.do(ctx, "ListJobs", http.MethodGet, JobPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var jobInfos []RayJobInfo
+	if err = json.Unmarshal(respBody, &jobInfos); err != nil {
+		return nil, fmt.Errorf("ListJobs fail: %s", string(respBody))
+	}
+	return jobInfos, nil
+}
+
+// GetJobLogs returns the logs of the driver of the Ray job with the given submission ID.
+func (c *Client) GetJobLogs(ctx context.Context, submissionID string) (string, error) {
+	respBody, err := c.do(ctx, "GetJobLogs", http.MethodGet, JobPath+url.PathEscape(submissionID)+"/logs", nil)
+	if err != nil {
+		return "", err
+	}
+	var jobLogs RayJobLogsResponse
+	if err = json.Unmarshal(respBody, &jobLogs); err != nil {
+		return "", fmt.Errorf("GetJobLogs fail: %s", string(respBody))
+	}
+	return jobLogs.Logs, nil
+}
+
+// StopJob requests the Ray job with the given submission ID to stop, and returns false if it was not running anymore,
+// e.g. because it already succeeded.
+func (c *Client) StopJob(ctx context.Context, submissionID string) (bool, error) {
+	respBody, err := c.do(ctx, "StopJob", http.MethodPost, JobPath+url.PathEscape(submissionID)+"/stop", nil)
+	if err != nil {
+		return false, err
+	}
+	var stopResp RayJobStopResponse
+	if err = json.Unmarshal(respBody, &stopResp); err != nil {
+		return false, fmt.Errorf("StopJob fail: %s", string(respBody))
+	}
+	return stopResp.Stopped, nil
+}
+
+// DeleteJob deletes the Ray job with the given submission ID and its logs. Ray only deletes the jobs in terminal
+// states.
+func (c *Client) DeleteJob(ctx context.Context, submissionID string) error {
+	respBody, err := c.do(ctx, "DeleteJob", http.MethodDelete, JobPath+url.PathEscape(submissionID), nil)
+	if err != nil {
+		return err
+	}
+	var deleteResp RayJobDeleteResponse
+	if err = json.Unmarshal(respBody, &deleteResp); err != nil {
+		return fmt.Errorf("DeleteJob fail: %s", string(respBody))
+	}
+	if !deleteResp.Deleted {
+		return fmt.Errorf("DeleteJob fail: job %s was not deleted", submissionID)
+	}
+	return nil
+}
+
+// do sends a request to the path of the Ray dashboard and returns the body of the response, or an APIError if its
+// status code is not 2xx.
+func (c *Client) do(ctx context.Context, operation, method, path string, body []byte) ([]byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.address+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &APIError{Operation: operation, Status: resp.Status, Body: string(respBody), StatusCode: resp.StatusCode}
+	}
+	return respBody, nil
+}
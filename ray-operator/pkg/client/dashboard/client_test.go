@@ -0,0 +1,107 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// newTestServer returns a fake Ray dashboard serving a single job.
+func newTestServer(t *testing.T) *httptest.Server {
+	jobs := map[string]*RayJobInfo{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/jobs/", func(w http.ResponseWriter, r *http.Request) {
+		var request RayJobRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Entrypoint == "" {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		jobs[request.SubmissionId] = &RayJobInfo{SubmissionId: request.SubmissionId, Entrypoint: request.Entrypoint, JobStatus: rayv1.JobStatusRunning}
+		_ = json.NewEncoder(w).Encode(RayJobResponse{JobId: request.SubmissionId})
+	})
+	mux.HandleFunc("GET /api/jobs/", func(w http.ResponseWriter, _ *http.Request) {
+		jobInfos := []RayJobInfo{}
+		for _, jobInfo := range jobs {
+			jobInfos = append(jobInfos, *jobInfo)
+		}
+		_ = json.NewEncoder(w).Encode(jobInfos)
+	})
+	mux.HandleFunc("GET /api/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		jobInfo, ok := jobs[r.PathValue("id")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(jobInfo)
+	})
+	mux.HandleFunc("GET /api/jobs/{id}/logs", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(RayJobLogsResponse{Logs: "hello world\n"})
+	})
+	mux.HandleFunc("POST /api/jobs/{id}/stop", func(w http.ResponseWriter, r *http.Request) {
+		jobInfo := jobs[r.PathValue("id")]
+		stopped := jobInfo.JobStatus == rayv1.JobStatusRunning
+		jobInfo.JobStatus = rayv1.JobStatusStopped
+		_ = json.NewEncoder(w).Encode(RayJobStopResponse{Stopped: stopped})
+	})
+	mux.HandleFunc("DELETE /api/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		delete(jobs, r.PathValue("id"))
+		_ = json.NewEncoder(w).Encode(RayJobDeleteResponse{Deleted: true})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient(t *testing.T) {
+	server := newTestServer(t)
+	client := NewClient(server.URL+"/", server.Client())
+	ctx := context.Background()
+
+	submissionID, err := client.SubmitJob(ctx, &RayJobRequest{Entrypoint: "python sample.py", SubmissionId: "raysubmit_test001"})
+	require.NoError(t, err)
+	assert.Equal(t, "raysubmit_test001", submissionID)
+
+	jobInfo, err := client.GetJobInfo(ctx, submissionID)
+	require.NoError(t, err)
+	assert.Equal(t, "python sample.py", jobInfo.Entrypoint)
+	assert.Equal(t, rayv1.JobStatusRunning, jobInfo.JobStatus)
+
+	jobInfos, err := client.ListJobs(ctx)
+	require.NoError(t, err)
+	assert.Len(t, jobInfos, 1)
+
+	logs, err := client.GetJobLogs(ctx, submissionID)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world\n", logs)
+
+	stopped, err := client.StopJob(ctx, submissionID)
+	require.NoError(t, err)
+	assert.True(t, stopped)
+	stopped, err = client.StopJob(ctx, submissionID)
+	require.NoError(t, err)
+	assert.False(t, stopped)
+
+	require.NoError(t, client.DeleteJob(ctx, submissionID))
+	_, err = client.GetJobInfo(ctx, submissionID)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestClientAPIError(t *testing.T) {
+	server := newTestServer(t)
+	client := NewClient(server.URL, server.Client())
+
+	_, err := client.SubmitJob(context.Background(), &RayJobRequest{})
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "SubmitJob", apiErr.Operation)
+	assert.Contains(t, err.Error(), "invalid request")
+	assert.False(t, IsNotFound(err))
+}
@@ -0,0 +1,63 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ServeApplicationsPath is the URL path of the multi-application Ray Serve REST API (v2).
+const ServeApplicationsPath = "/api/serve/applications/"
+
+// GetServeDetails returns the details of all the live Serve applications of the Ray cluster, including the status of
+// their deployments and replicas, and of the Serve proxies.
+func (c *Client) GetServeDetails(ctx context.Context) (*ServeDetails, error) {
+	respBody, err := c.do(ctx, "GetServeDetails", http.MethodGet, ServeApplicationsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var serveDetails ServeDetails
+	if err = json.Unmarshal(respBody, &serveDetails); err != nil {
+		return nil, fmt.Errorf("GetServeDetails failed. Failed to unmarshal bytes: %s", string(respBody))
+	}
+	return &serveDetails, nil
+}
+
+// GetApplicationStatuses returns the status of the Serve applications of the Ray cluster keyed by their names.
+func (c *Client) GetApplicationStatuses(ctx context.Context) (map[string]*ServeApplicationStatus, error) {
+	serveDetails, err := c.GetServeDetails(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get serve details: %w", err)
+	}
+	return ConvertServeDetailsToApplicationStatuses(serveDetails)
+}
+
+// DeployApplications declaratively deploys the Serve applications of the config, a JSON-encoded
+// ServeDeploySchema. The applications of the Ray cluster missing from the config are deleted.
+func (c *Client) DeployApplications(ctx context.Context, configJson []byte) error {
+	_, err := c.do(ctx, "UpdateDeployments", http.MethodPut, ServeApplicationsPath, configJson)
+	return err
+}
+
+// DeleteApplications shuts Serve down, deleting all the Serve applications of the Ray cluster.
+func (c *Client) DeleteApplications(ctx context.Context) error {
+	_, err := c.do(ctx, "DeleteApplications", http.MethodDelete, ServeApplicationsPath, nil)
+	return err
+}
+
+// ConvertServeDetailsToApplicationStatuses returns the status of the Serve applications of serveDetails keyed by
+// their names.
+func ConvertServeDetailsToApplicationStatuses(serveDetails *ServeDetails) (map[string]*ServeApplicationStatus, error) {
+	detailsJson, err := json.Marshal(serveDetails.Applications)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal serve details: %v", serveDetails.Applications)
+	}
+
+	applicationStatuses := map[string]*ServeApplicationStatus{}
+	if err = json.Unmarshal(detailsJson, &applicationStatuses); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal serve details bytes into map of application statuses: %w. Bytes: %s", err, string(detailsJson))
+	}
+
+	return applicationStatuses, nil
+}
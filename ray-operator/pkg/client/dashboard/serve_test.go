@@ -0,0 +1,92 @@
+package dashboard
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serveDetailsJson = `{
+  "deploy_mode": "MULTI_APP",
+  "proxy_location": "EveryNode",
+  "proxies": {
+    "node-1": {"node_id": "node-1", "node_ip": "10.0.0.1", "status": "HEALTHY"}
+  },
+  "applications": {
+    "fruit_app": {
+      "name": "fruit_app",
+      "route_prefix": "/fruit",
+      "status": "RUNNING",
+      "last_deployed_time_s": 1700000000.5,
+      "deployments": {
+        "MangoStand": {
+          "name": "MangoStand",
+          "status": "UPSCALING",
+          "status_trigger": "AUTOSCALING",
+          "target_num_replicas": 2,
+          "replicas": [
+            {"replica_id": "abc", "state": "RUNNING", "node_id": "node-1", "node_ip": "10.0.0.1", "start_time_s": 1700000001}
+          ]
+        }
+      }
+    }
+  }
+}`
+
+func TestServeClient(t *testing.T) {
+	var deployedConfig string
+	deleted := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/serve/applications/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(serveDetailsJson))
+	})
+	mux.HandleFunc("PUT /api/serve/applications/", func(_ http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		deployedConfig = string(body)
+	})
+	mux.HandleFunc("DELETE /api/serve/applications/", func(_ http.ResponseWriter, _ *http.Request) {
+		deleted = true
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := NewClient(server.URL, server.Client())
+	ctx := context.Background()
+
+	serveDetails, err := client.GetServeDetails(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "HEALTHY", serveDetails.Proxies["node-1"].Status)
+	deployment := serveDetails.Applications["fruit_app"].Deployments["MangoStand"]
+	assert.Equal(t, "UPSCALING", deployment.Status)
+	assert.Equal(t, "AUTOSCALING", deployment.StatusTrigger)
+	assert.Equal(t, int32(2), deployment.TargetNumReplicas)
+	require.Len(t, deployment.Replicas, 1)
+	assert.Equal(t, "RUNNING", deployment.Replicas[0].State)
+
+	statuses, err := client.GetApplicationStatuses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "RUNNING", statuses["fruit_app"].Status)
+	assert.Equal(t, "UPSCALING", statuses["fruit_app"].Deployments["MangoStand"].Status)
+
+	require.NoError(t, client.DeployApplications(ctx, []byte(`{"applications": []}`)))
+	assert.Equal(t, `{"applications": []}`, deployedConfig)
+
+	require.NoError(t, client.DeleteApplications(ctx))
+	assert.True(t, deleted)
+}
+
+func TestServeClientAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid config", http.StatusBadRequest)
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, server.Client())
+
+	err := client.DeployApplications(context.Background(), []byte(`{}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "UpdateDeployments fail: 400 Bad Request")
+}
@@ -0,0 +1,74 @@
+package dashboard
+
+// Please see the Ray Serve docs
+// https://docs.ray.io/en/latest/serve/api/doc/ray.serve.schema.ServeInstanceDetails.html for the
+// multi-application schema.
+
+// ServeDeploymentStatus and ServeApplicationStatus describe the format of status(es) that will
+// be returned by the GetApplicationStatuses method of the client
+// Describes the status of a deployment
+type ServeDeploymentStatus struct {
+	Name    string `json:"name,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Describes the status of an application
+type ServeApplicationStatus struct {
+	Deployments map[string]ServeDeploymentStatus `json:"deployments"`
+	Name        string                           `json:"name,omitempty"`
+	Status      string                           `json:"status"`
+	Message     string                           `json:"message,omitempty"`
+}
+
+// V2 Serve API Response format. These extend the ServeDeploymentStatus and ServeApplicationStatus structs,
+// but contain more information such as route prefix because the V2/multi-app GET API fetchs general metadata,
+// not just statuses.
+type ServeDeploymentDetails struct {
+	ServeDeploymentStatus
+	RoutePrefix string `json:"route_prefix,omitempty"`
+	// StatusTrigger is the reason of the last status change, e.g. HEALTH_CHECK_FAILED or AUTOSCALING.
+	StatusTrigger string `json:"status_trigger,omitempty"`
+	// Replicas are the live replicas of the deployment.
+	Replicas []ServeReplicaDetails `json:"replicas,omitempty"`
+	// TargetNumReplicas is the number of replicas that Serve is scaling the deployment to.
+	TargetNumReplicas int32 `json:"target_num_replicas,omitempty"`
+}
+
+// ServeReplicaDetails describes a replica of a deployment.
+type ServeReplicaDetails struct {
+	ReplicaId string `json:"replica_id,omitempty"`
+	// State is the state of the replica, e.g. STARTING, RUNNING or RECOVERING.
+	State   string `json:"state,omitempty"`
+	NodeId  string `json:"node_id,omitempty"`
+	NodeIp  string `json:"node_ip,omitempty"`
+	ActorId string `json:"actor_id,omitempty"`
+	// StartTimeS is the Unix time in seconds at which the replica was started.
+	StartTimeS float64 `json:"start_time_s,omitempty"`
+}
+
+type ServeApplicationDetails struct {
+	Deployments map[string]ServeDeploymentDetails `json:"deployments"`
+	ServeApplicationStatus
+	RoutePrefix string `json:"route_prefix,omitempty"`
+	DocsPath    string `json:"docs_path,omitempty"`
+	// LastDeployedTimeS is the Unix time in seconds at which the config of the application was last deployed.
+	LastDeployedTimeS float64 `json:"last_deployed_time_s,omitempty"`
+}
+
+// ServeProxyDetails describes the Serve proxy of a Ray node.
+type ServeProxyDetails struct {
+	NodeId  string `json:"node_id,omitempty"`
+	NodeIp  string `json:"node_ip,omitempty"`
+	ActorId string `json:"actor_id,omitempty"`
+	// Status is the status of the proxy, e.g. STARTING, HEALTHY, UNHEALTHY or DRAINING.
+	Status string `json:"status,omitempty"`
+}
+
+type ServeDetails struct {
+	Applications map[string]ServeApplicationDetails `json:"applications"`
+	// Proxies are the Serve proxies of the Ray cluster keyed by the ID of their node.
+	Proxies       map[string]ServeProxyDetails `json:"proxies,omitempty"`
+	DeployMode    string                       `json:"deploy_mode,omitempty"`
+	ProxyLocation string                       `json:"proxy_location,omitempty"`
+}
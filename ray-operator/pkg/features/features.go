@@ -17,6 +17,31 @@ const (
 	//
 	// Enables new conditions in RayCluster status
 	RayClusterStatusConditions featuregate.Feature = "RayClusterStatusConditions"
+
+	// owner: @cchen777
+	// alpha: v1.2
+	//
+	// Runs the autoscaler and the log sidecars as native sidecar containers on Kubernetes 1.28 or later.
+	// Kubernetes 1.28 requires the SidecarContainers feature gate to be enabled on the cluster.
+	NativeSidecarContainers featuregate.Feature = "NativeSidecarContainers"
+
+	// owner: @cchen777
+	// alpha: v1.2
+	//
+	// Enables the deletionPolicy field of RayJobs, which specifies the resources deleted once the Ray job finishes.
+	RayJobDeletionPolicy featuregate.Feature = "RayJobDeletionPolicy"
+
+	// owner: @cchen777
+	// alpha: v1.2
+	//
+	// Runs the controller of RayCronJobs, which create RayJobs on a cron schedule. The RayCronJob CRD must be installed.
+	RayCronJob featuregate.Feature = "RayCronJob"
+
+	// owner: @cchen777
+	// alpha: v1.2
+	//
+	// Enables the schedule field of RayJobs, which re-runs the Ray job on a cron schedule.
+	RayJobSchedule featuregate.Feature = "RayJobSchedule"
 )
 
 func init() {
@@ -25,6 +50,10 @@ func init() {
 
 var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	RayClusterStatusConditions: {Default: false, PreRelease: featuregate.Alpha},
+	NativeSidecarContainers:    {Default: false, PreRelease: featuregate.Alpha},
+	RayJobDeletionPolicy:       {Default: false, PreRelease: featuregate.Alpha},
+	RayCronJob:                 {Default: false, PreRelease: featuregate.Alpha},
+	RayJobSchedule:             {Default: false, PreRelease: featuregate.Alpha},
 }
 
 // SetFeatureGateDuringTest is a helper method to override feature gates in tests.
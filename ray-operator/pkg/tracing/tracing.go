@@ -0,0 +1,129 @@
+// Package tracing instruments the KubeRay operator with OpenTelemetry spans, e.g. of the reconciliations of the custom
+// resources, the creation of Pods and the calls to the Ray dashboard, so that slow reconciliations and the latency of
+// the external calls can be debugged in production.
+//
+// Tracing is configured with the standard OpenTelemetry environment variables, and is disabled unless an OTLP endpoint
+// is set, e.g. OTEL_EXPORTER_OTLP_ENDPOINT=http://otel-collector:4317. The spans are exported with OTLP over gRPC.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// InstrumentationName is the name of the tracer of the operator.
+	InstrumentationName = "github.com/ray-project/kuberay/ray-operator"
+	// ServiceName is the service name of the spans, unless overridden by OTEL_SERVICE_NAME.
+	ServiceName = "kuberay-operator"
+)
+
+// Enabled returns whether an OTLP endpoint is set for the traces.
+func Enabled() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup sets the global tracer provider exporting the spans to the OTLP endpoint if tracing is enabled. The returned
+// function flushes the pending spans and shuts the tracer provider down.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+	// The exporter reads its endpoint, headers, TLS and timeout from the OTEL_EXPORTER_OTLP_* environment variables.
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The attributes from OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the default service name.
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", ServiceName)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// The sampler is read from OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG, and defaults to parentbased_always_on.
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Start starts a span of the operator. The span is a no-op if tracing is disabled.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartReconcile starts the span of a reconciliation of the custom resource.
+func StartReconcile(ctx context.Context, kind string, name types.NamespacedName) (context.Context, trace.Span) {
+	return Start(ctx, kind+".Reconcile",
+		attribute.String("kuberay.kind", kind),
+		attribute.String("kuberay.namespace", name.Namespace),
+		attribute.String("kuberay.name", name.Name),
+	)
+}
+
+// End ends the span, recording *err as its error if it is not nil. It is meant to be deferred with a named error
+// result.
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
+
+// NewTransport returns a RoundTripper recording a client span for each request made through next, or through
+// http.DefaultTransport at the time of the request if next is nil, and propagating the trace context in the headers.
+func NewTransport(next http.RoundTripper) http.RoundTripper {
+	return &transport{next: next}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	ctx, span := otel.Tracer(InstrumentationName).Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	// Do not modify the request of the caller.
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return recorder
+}
+
+func TestEnabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	assert.False(t, Enabled())
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://otel-collector:4317")
+	assert.True(t, Enabled())
+}
+
+func TestEnd(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	var err error
+	_, span := StartReconcile(context.Background(), "RayCluster", types.NamespacedName{Namespace: "default", Name: "raycluster"})
+	End(span, &err)
+	err = errors.New("failed")
+	_, span = StartReconcile(context.Background(), "RayCluster", types.NamespacedName{Namespace: "default", Name: "raycluster"})
+	End(span, &err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "RayCluster.Reconcile", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "failed", spans[1].Status().Description)
+}
+
+func TestNewTransport(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx, parent := Start(context.Background(), "DeployServe")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/serve/applications/", nil)
+	assert.Nil(t, err)
+	resp, err := (&http.Client{Transport: NewTransport(nil)}).Do(req)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	// The request of the caller is not modified.
+	assert.Empty(t, req.Header.Get("traceparent"))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	client := spans[0]
+	assert.Equal(t, "HTTP GET", client.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), client.Parent().SpanID())
+	assert.Contains(t, traceparent, client.SpanContext().SpanID().String())
+	assert.Equal(t, codes.Error, client.Status().Code)
+}
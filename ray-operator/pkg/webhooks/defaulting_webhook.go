@@ -0,0 +1,161 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// DefaultingWebhookPath is the path serving the webhook writing the computed defaults into the spec of RayClusters.
+const DefaultingWebhookPath = "/mutate-ray-io-v1-raycluster"
+
+//+kubebuilder:webhook:path=/mutate-ray-io-v1-raycluster,mutating=true,failurePolicy=ignore,sideEffects=None,groups=ray.io,resources=rayclusters,verbs=create;update,versions=v1,name=mraycluster.kb.io,admissionReviewVersions=v1
+
+// RayClusterDefaulter writes the defaults that KubeRay computes when it creates the Pods of a RayCluster into its
+// spec, so that the stored RayCluster shows its effective configuration and GitOps tools do not report drift. Values
+// set by users are never overwritten, and the Pods are built from the same configuration either way.
+//
+// This also makes the GPUs and accelerators that KubeRay adds to the Pods visible to the Ray Autoscaler, which reads
+// the resources of the groups from the RayCluster instead of from the Pods.
+type RayClusterDefaulter struct {
+	// acceleratorResourceMapping is the operator-level mapping from extended resources to Ray custom resources.
+	acceleratorResourceMapping map[string]string
+	// disabled makes the webhook a no-op, so that the webhook configuration can always be installed.
+	disabled bool
+}
+
+var _ admission.CustomDefaulter = &RayClusterDefaulter{}
+
+// NewDefaultingWebhook returns the mutating webhook that writes the computed defaults into the spec of RayClusters. It
+// admits RayClusters unchanged if it is not enabled.
+func NewDefaultingWebhook(scheme *runtime.Scheme, acceleratorResourceMapping map[string]string, enabled bool) *admission.Webhook {
+	return admission.WithCustomDefaulter(scheme, &rayv1.RayCluster{}, &RayClusterDefaulter{acceleratorResourceMapping: acceleratorResourceMapping, disabled: !enabled})
+}
+
+// Default implements admission.CustomDefaulter.
+func (d *RayClusterDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	logger := ctrl.LoggerFrom(ctx)
+	cluster, ok := obj.(*rayv1.RayCluster)
+	if !ok {
+		return fmt.Errorf("expected a RayCluster but got %T", obj)
+	}
+	if d.disabled {
+		return nil
+	}
+
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+		oldCluster := &rayv1.RayCluster{}
+		if err := json.Unmarshal(req.OldObject.Raw, oldCluster); err != nil {
+			return fmt.Errorf("failed to decode the old RayCluster: %w", err)
+		}
+		if _, ok := oldCluster.Annotations[utils.RayDefaultsMaterializedAnnotationKey]; !ok {
+			return nil
+		}
+	}
+
+	SetRayClusterDefaults(ctx, cluster, d.acceleratorResourceMapping)
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[utils.RayDefaultsMaterializedAnnotationKey] = "true"
+	logger.Info("Wrote the computed defaults into the spec", "RayCluster", cluster.Name, "namespace", cluster.Namespace)
+	return nil
+}
+
+// SetRayClusterDefaults sets the ports of the Ray containers, the rayStartParams, the GPU resources and the replicas of
+// the worker groups of the RayCluster that are unset to the values KubeRay uses for them. The extended resources in
+// acceleratorResourceMapping are advertised as Ray custom resources like KubeRay does when it builds the Pods.
+func SetRayClusterDefaults(ctx context.Context, cluster *rayv1.RayCluster, acceleratorResourceMapping map[string]string) {
+	// The rayStartParams merged from a ConfigMap are not known at admission, and the ones in the spec take precedence
+	// over them, so the rayStartParams of the groups with rayStartParamsFrom are left unset.
+	headSpec := &cluster.Spec.HeadGroupSpec
+	if headSpec.RayStartParamsFrom == nil {
+		headSpec.RayStartParams = setDefaultRayStartParams(headSpec.RayStartParams, rayv1.HeadNode)
+		headSpec.RayStartParams = common.AddAcceleratorResources(ctx, headSpec.Template, headSpec.RayStartParams, acceleratorResourceMapping)
+	}
+	if len(headSpec.Template.Spec.Containers) > 0 {
+		container := &headSpec.Template.Spec.Containers[utils.RayContainerIndex]
+		// The head Service exposes the ports of the Ray container, or these ones if the Ray container has none.
+		if len(container.Ports) == 0 {
+			container.Ports = defaultHeadContainerPorts()
+		}
+		setDefaultMetricsPort(container)
+	}
+
+	for i := range cluster.Spec.WorkerGroupSpecs {
+		worker := &cluster.Spec.WorkerGroupSpecs[i]
+		if len(worker.Template.Spec.Containers) > 0 {
+			setDefaultMetricsPort(&worker.Template.Spec.Containers[utils.RayContainerIndex])
+			if worker.GPU != nil {
+				common.AddGPUResources(&worker.Template, *worker.GPU)
+			}
+		}
+		if worker.RayStartParamsFrom == nil {
+			worker.RayStartParams = setDefaultRayStartParams(worker.RayStartParams, rayv1.WorkerNode)
+			worker.RayStartParams = common.AddGPUNumGPUs(worker.RayStartParams, worker.GPU)
+			worker.RayStartParams = common.AddAcceleratorResources(ctx, worker.Template, worker.RayStartParams, acceleratorResourceMapping)
+		}
+		if worker.MinReplicas == nil {
+			worker.MinReplicas = ptr.To[int32](0)
+		}
+		if worker.MaxReplicas == nil {
+			worker.MaxReplicas = ptr.To[int32](math.MaxInt32)
+		}
+		if worker.Replicas == nil {
+			worker.Replicas = ptr.To(min(*worker.MinReplicas, *worker.MaxReplicas))
+		}
+	}
+}
+
+// setDefaultRayStartParams sets the rayStartParams that KubeRay adds to `ray start` regardless of the environment of
+// the Pod. The address of the head Pod, which depends on its Service, is not set.
+func setDefaultRayStartParams(rayStartParams map[string]string, nodeType rayv1.RayNodeType) map[string]string {
+	if rayStartParams == nil {
+		rayStartParams = map[string]string{}
+	}
+	if nodeType == rayv1.HeadNode {
+		if _, ok := rayStartParams["dashboard-host"]; !ok {
+			rayStartParams["dashboard-host"] = "0.0.0.0"
+		}
+	}
+	if _, ok := rayStartParams["metrics-export-port"]; !ok {
+		rayStartParams["metrics-export-port"] = strconv.Itoa(utils.DefaultMetricsPort)
+	}
+	if _, ok := rayStartParams["dashboard-agent-listen-port"]; !ok {
+		rayStartParams["dashboard-agent-listen-port"] = strconv.Itoa(utils.DefaultDashboardAgentListenPort)
+	}
+	// KubeRay always runs `ray start` with --block.
+	rayStartParams["block"] = "true"
+	return rayStartParams
+}
+
+// defaultHeadContainerPorts returns the default ports of the Ray container of the head Pod.
+func defaultHeadContainerPorts() []corev1.ContainerPort {
+	return []corev1.ContainerPort{
+		{Name: utils.ClientPortName, ContainerPort: utils.DefaultClientPort},
+		{Name: utils.DashboardPortName, ContainerPort: utils.DefaultDashboardPort},
+		{Name: utils.MetricsPortName, ContainerPort: utils.DefaultMetricsPort},
+		{Name: utils.RedisPortName, ContainerPort: utils.DefaultRedisPort},
+		{Name: utils.ServingPortName, ContainerPort: utils.DefaultServingPort},
+	}
+}
+
+// setDefaultMetricsPort adds the metrics port that KubeRay adds to the Ray container if it has none.
+func setDefaultMetricsPort(container *corev1.Container) {
+	if utils.FindContainerPort(container, utils.MetricsPortName, -1) == -1 {
+		container.Ports = append(container.Ports, corev1.ContainerPort{Name: utils.MetricsPortName, ContainerPort: utils.DefaultMetricsPort})
+	}
+}
@@ -0,0 +1,132 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestRayClusterDefaulter(t *testing.T) {
+	defaulter := &RayClusterDefaulter{}
+	newCluster := func() *rayv1.RayCluster {
+		return &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "raycluster-sample", Namespace: "default"},
+			Spec: rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					RayStartParams: map[string]string{"dashboard-host": "127.0.0.1"},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.9.0"}},
+						},
+					},
+				},
+				WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+					{
+						GroupName:   "small-group",
+						MinReplicas: ptr.To[int32](2),
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  "ray-worker",
+										Image: "rayproject/ray:2.9.0",
+										Ports: []corev1.ContainerPort{{Name: utils.MetricsPortName, ContainerPort: 9090}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("write the defaults on creation", func(t *testing.T) {
+		cluster := newCluster()
+		require.NoError(t, defaulter.Default(context.Background(), cluster))
+		assert.Equal(t, "true", cluster.Annotations[utils.RayDefaultsMaterializedAnnotationKey])
+
+		head := cluster.Spec.HeadGroupSpec
+		// Values set by users are left unchanged.
+		assert.Equal(t, "127.0.0.1", head.RayStartParams["dashboard-host"])
+		assert.Equal(t, "true", head.RayStartParams["block"])
+		assert.Equal(t, "8080", head.RayStartParams["metrics-export-port"])
+		assert.Equal(t, "52365", head.RayStartParams["dashboard-agent-listen-port"])
+		assert.Equal(t, defaultHeadContainerPorts(), head.Template.Spec.Containers[0].Ports)
+
+		worker := cluster.Spec.WorkerGroupSpecs[0]
+		assert.Equal(t, "true", worker.RayStartParams["block"])
+		assert.NotContains(t, worker.RayStartParams, "dashboard-host")
+		assert.Equal(t, []corev1.ContainerPort{{Name: utils.MetricsPortName, ContainerPort: 9090}}, worker.Template.Spec.Containers[0].Ports)
+		assert.Equal(t, int32(2), *worker.Replicas)
+		assert.Equal(t, int32(math.MaxInt32), *worker.MaxReplicas)
+	})
+
+	t.Run("do nothing if the webhook is disabled", func(t *testing.T) {
+		defaulter := &RayClusterDefaulter{disabled: true}
+		cluster := newCluster()
+		require.NoError(t, defaulter.Default(context.Background(), cluster))
+		assert.NotContains(t, cluster.Annotations, utils.RayDefaultsMaterializedAnnotationKey)
+		assert.NotContains(t, cluster.Spec.HeadGroupSpec.RayStartParams, "block")
+	})
+
+	t.Run("write the GPUs and accelerators of the groups for the Autoscaler", func(t *testing.T) {
+		defaulter := &RayClusterDefaulter{acceleratorResourceMapping: map[string]string{"google.com/tpu": "TPU"}}
+		cluster := newCluster()
+		cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Resources.Limits = corev1.ResourceList{"google.com/tpu": resource.MustParse("4")}
+		cluster.Spec.WorkerGroupSpecs[0].GPU = &rayv1.GPUOptions{Count: ptr.To[int32](2)}
+		require.NoError(t, defaulter.Default(context.Background(), cluster))
+
+		assert.Equal(t, `'"{\"TPU\":4}"'`, cluster.Spec.HeadGroupSpec.RayStartParams["resources"])
+		worker := cluster.Spec.WorkerGroupSpecs[0]
+		assert.Equal(t, "2", worker.RayStartParams["num-gpus"])
+		gpus := worker.Template.Spec.Containers[0].Resources.Limits["nvidia.com/gpu"]
+		assert.Equal(t, "2", gpus.String())
+	})
+
+	t.Run("leave the rayStartParams merged from a ConfigMap unset", func(t *testing.T) {
+		cluster := newCluster()
+		cluster.Spec.WorkerGroupSpecs[0].RayStartParamsFrom = &rayv1.RayStartParamsSource{}
+		require.NoError(t, defaulter.Default(context.Background(), cluster))
+		assert.Empty(t, cluster.Spec.WorkerGroupSpecs[0].RayStartParams)
+	})
+
+	newUpdateContext := func(oldCluster *rayv1.RayCluster) context.Context {
+		raw, err := json.Marshal(oldCluster)
+		require.NoError(t, err)
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				OldObject: runtime.RawExtension{Raw: raw},
+			},
+		})
+	}
+
+	t.Run("write the defaults on updates of RayClusters created with them", func(t *testing.T) {
+		oldCluster := newCluster()
+		oldCluster.Annotations = map[string]string{utils.RayDefaultsMaterializedAnnotationKey: "true"}
+		cluster := newCluster()
+		require.NoError(t, defaulter.Default(newUpdateContext(oldCluster), cluster))
+		assert.Equal(t, "true", cluster.Spec.HeadGroupSpec.RayStartParams["block"])
+	})
+
+	t.Run("leave the RayClusters created before the webhook unchanged", func(t *testing.T) {
+		cluster := newCluster()
+		require.NoError(t, defaulter.Default(newUpdateContext(newCluster()), cluster))
+		assert.Equal(t, newCluster(), cluster)
+	})
+}
@@ -0,0 +1,209 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	// ImageDigestWebhookPath is the path serving the image digest pinning webhook for RayClusters.
+	ImageDigestWebhookPath = "/mutate-ray-io-v1-raycluster-image-digest"
+
+	imageDigestCacheTTL    = 10 * time.Minute
+	registryRequestTimeout = 5 * time.Second
+	// imageDigestPinningTimeout bounds the time spent resolving all the images of a RayCluster, so that the webhook
+	// answers before the default admission timeout of 10 seconds even if the registries are slow.
+	imageDigestPinningTimeout = 8 * time.Second
+)
+
+//+kubebuilder:webhook:path=/mutate-ray-io-v1-raycluster-image-digest,mutating=true,failurePolicy=ignore,sideEffects=None,groups=ray.io,resources=rayclusters,verbs=create;update,versions=v1,name=mraycluster-image-digest.kb.io,admissionReviewVersions=v1
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+
+// ImageDigestPinner resolves the image tags of the Ray Pod templates of a RayCluster to digests and writes the pinned
+// references, e.g. `rayproject/ray:2.9.0@sha256:...`, back into the templates. Pods created weeks later by scale-ups
+// therefore run the same images as the original deployment even if the tags have been moved.
+//
+// Registry credentials are read from the image pull Secrets of the Pod templates and of their service accounts, so the
+// operator needs the permission to get Secrets and ServiceAccounts in the namespaces of the RayClusters, i.e. cluster-wide
+// unless the operator watches a single namespace.
+//
+// Pinning is best-effort: if a registry or a Secret cannot be read, e.g. during a registry outage or when rate-limited,
+// the error is logged and the image is left unpinned instead of rejecting the RayCluster.
+type ImageDigestPinner struct {
+	reader   client.Reader
+	resolver *digestResolver
+	// disabled makes the webhook a no-op, so that the webhook configuration can always be installed.
+	disabled bool
+}
+
+var _ admission.CustomDefaulter = &ImageDigestPinner{}
+
+// NewImageDigestPinner returns an ImageDigestPinner reading image pull Secrets and service accounts with the reader.
+func NewImageDigestPinner(reader client.Reader) *ImageDigestPinner {
+	return &ImageDigestPinner{
+		reader:   reader,
+		resolver: newDigestResolver(&http.Client{Timeout: registryRequestTimeout}, imageDigestCacheTTL),
+	}
+}
+
+// NewImageDigestWebhook returns the mutating webhook that pins the image digests of RayClusters. The webhook admits
+// RayClusters unchanged if it is not enabled.
+func NewImageDigestWebhook(scheme *runtime.Scheme, reader client.Reader, enabled bool) *admission.Webhook {
+	pinner := NewImageDigestPinner(reader)
+	pinner.disabled = !enabled
+	return admission.WithCustomDefaulter(scheme, &rayv1.RayCluster{}, pinner)
+}
+
+// Default implements admission.CustomDefaulter.
+func (p *ImageDigestPinner) Default(ctx context.Context, obj runtime.Object) error {
+	logger := ctrl.LoggerFrom(ctx)
+	cluster, ok := obj.(*rayv1.RayCluster)
+	if !ok {
+		return fmt.Errorf("expected a RayCluster but got %T", obj)
+	}
+	if p.disabled || strings.ToLower(cluster.Annotations[utils.PinImageDigestsAnnotationKey]) == "false" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, imageDigestPinningTimeout)
+	defer cancel()
+
+	namespace := cluster.Namespace
+	// Images pinned when the RayCluster was created are reused on updates, so that re-applying a manifest with tags does
+	// not silently move the RayCluster to newer images.
+	pinned := map[string]string{}
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		if namespace == "" {
+			namespace = req.Namespace
+		}
+		if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+			oldCluster := &rayv1.RayCluster{}
+			if err := json.Unmarshal(req.OldObject.Raw, oldCluster); err != nil {
+				return fmt.Errorf("failed to decode the old RayCluster: %w", err)
+			}
+			for _, template := range podTemplates(oldCluster) {
+				collectPinnedImages(template, pinned)
+			}
+		}
+	}
+
+	for _, template := range podTemplates(cluster) {
+		p.pinPodTemplate(ctx, namespace, template, pinned)
+	}
+	logger.Info("Pinned image digests", "RayCluster", cluster.Name, "namespace", namespace, "images", len(pinned))
+	return nil
+}
+
+// podTemplates returns the Pod templates of the head group and all worker groups.
+func podTemplates(cluster *rayv1.RayCluster) []*corev1.PodTemplateSpec {
+	templates := []*corev1.PodTemplateSpec{&cluster.Spec.HeadGroupSpec.Template}
+	for i := range cluster.Spec.WorkerGroupSpecs {
+		templates = append(templates, &cluster.Spec.WorkerGroupSpecs[i].Template)
+	}
+	return templates
+}
+
+// collectPinnedImages maps the unpinned form of each pinned image in the Pod template to the pinned image.
+func collectPinnedImages(template *corev1.PodTemplateSpec, pinned map[string]string) {
+	for _, containers := range [][]corev1.Container{template.Spec.InitContainers, template.Spec.Containers} {
+		for _, container := range containers {
+			if image, _, found := strings.Cut(container.Image, "@"); found {
+				pinned[image] = container.Image
+			}
+		}
+	}
+}
+
+// pinPodTemplate pins the images of all containers in the Pod template. Images that already have a digest are left
+// unchanged, and so are the images whose digests cannot be resolved.
+func (p *ImageDigestPinner) pinPodTemplate(ctx context.Context, namespace string, template *corev1.PodTemplateSpec, pinned map[string]string) {
+	logger := ctrl.LoggerFrom(ctx)
+	var credentials map[string]registryCredentials
+	for _, containers := range [][]corev1.Container{template.Spec.InitContainers, template.Spec.Containers} {
+		for i := range containers {
+			image := containers[i].Image
+			if pinnedImage, ok := pinned[image]; ok {
+				containers[i].Image = pinnedImage
+				continue
+			}
+			ref, err := parseImageReference(image)
+			if err != nil {
+				logger.Info("Leaving the image unpinned", "image", image, "error", err)
+				continue
+			}
+			if ref.digest != "" {
+				continue
+			}
+			if credentials == nil {
+				if credentials, err = p.getRegistryCredentials(ctx, namespace, template.Spec); err != nil {
+					// Anonymous access may still work for public images.
+					logger.Info("Failed to read the registry credentials of the Pod template", "namespace", namespace, "error", err)
+					credentials = map[string]registryCredentials{}
+				}
+			}
+			var creds *registryCredentials
+			if c, ok := credentials[ref.registry]; ok {
+				creds = &c
+			}
+			digest, err := p.resolver.Resolve(ctx, ref, creds)
+			if err != nil {
+				logger.Info("Leaving the image unpinned", "image", image, "error", err)
+				continue
+			}
+			containers[i].Image = image + "@" + digest
+			pinned[image] = containers[i].Image
+		}
+	}
+}
+
+// getRegistryCredentials returns the registry credentials of the image pull Secrets of the Pod and its service account.
+// Like the kubelet, it ignores image pull Secrets that do not exist.
+func (p *ImageDigestPinner) getRegistryCredentials(ctx context.Context, namespace string, podSpec corev1.PodSpec) (map[string]registryCredentials, error) {
+	pullSecrets := append([]corev1.LocalObjectReference{}, podSpec.ImagePullSecrets...)
+	serviceAccountName := podSpec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = "default"
+	}
+	serviceAccount := &corev1.ServiceAccount{}
+	if err := p.reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: serviceAccountName}, serviceAccount); err == nil {
+		pullSecrets = append(pullSecrets, serviceAccount.ImagePullSecrets...)
+	} else if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	credentials := map[string]registryCredentials{}
+	for _, pullSecret := range pullSecrets {
+		secret := &corev1.Secret{}
+		if err := p.reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: pullSecret.Name}, secret); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		secretCredentials, err := credentialsFromSecret(secret)
+		if err != nil {
+			return nil, err
+		}
+		// The first image pull Secret with credentials for a registry wins.
+		for registry, c := range secretCredentials {
+			if _, ok := credentials[registry]; !ok {
+				credentials[registry] = c
+			}
+		}
+	}
+	return credentials, nil
+}
@@ -0,0 +1,133 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestImageDigestPinner(t *testing.T) {
+	server, registry, _ := newTestRegistry(t)
+	image := registry + "/rayproject/ray:2.9.0"
+	pinnedImage := image + "@" + testDigest
+
+	auth := base64.StdEncoding.EncodeToString([]byte(testUsername + ":" + testPassword))
+	pullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: "default"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(fmt.Sprintf(`{"auths": {%q: {"auth": %q}}}`, registry, auth)),
+		},
+	}
+	// The image pull Secret of the worker group is attached to its service account.
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta:       metav1.ObjectMeta{Name: "ray-worker", Namespace: "default"},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: pullSecret.Name}},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(pullSecret, serviceAccount).Build()
+	pinner := NewImageDigestPinner(fakeClient)
+	pinner.resolver = newDigestResolver(server.Client(), time.Minute)
+
+	newCluster := func() *rayv1.RayCluster {
+		return &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "raycluster-sample", Namespace: "default"},
+			Spec: rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							ImagePullSecrets: []corev1.LocalObjectReference{{Name: pullSecret.Name}},
+							Containers: []corev1.Container{
+								{Name: "ray-head", Image: image},
+								{Name: "sidecar", Image: "busybox@" + testDigest},
+							},
+						},
+					},
+				},
+				WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+					{
+						GroupName: "small-group",
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								ServiceAccountName: serviceAccount.Name,
+								InitContainers:     []corev1.Container{{Name: "init", Image: image}},
+								Containers:         []corev1.Container{{Name: "ray-worker", Image: image}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("pin the images on creation", func(t *testing.T) {
+		cluster := newCluster()
+		require.NoError(t, pinner.Default(context.Background(), cluster))
+		assert.Equal(t, pinnedImage, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Image)
+		// Images that already have a digest are left unchanged.
+		assert.Equal(t, "busybox@"+testDigest, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[1].Image)
+		assert.Equal(t, pinnedImage, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.InitContainers[0].Image)
+		assert.Equal(t, pinnedImage, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image)
+	})
+
+	t.Run("reuse the pinned images on updates", func(t *testing.T) {
+		oldCluster := newCluster()
+		oldPinnedImage := image + "@sha256:" + fmt.Sprintf("%064d", 0)
+		oldCluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Image = oldPinnedImage
+		raw, err := json.Marshal(oldCluster)
+		require.NoError(t, err)
+		ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				OldObject: runtime.RawExtension{Raw: raw},
+			},
+		})
+
+		cluster := newCluster()
+		require.NoError(t, pinner.Default(ctx, cluster))
+		assert.Equal(t, oldPinnedImage, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, oldPinnedImage, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image)
+	})
+
+	t.Run("opt out with the annotation", func(t *testing.T) {
+		cluster := newCluster()
+		cluster.Annotations = map[string]string{utils.PinImageDigestsAnnotationKey: "false"}
+		require.NoError(t, pinner.Default(context.Background(), cluster))
+		assert.Equal(t, image, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Image)
+	})
+
+	t.Run("leave the image unpinned if the credentials are missing", func(t *testing.T) {
+		// Use a new pinner so that the digest is not served from the cache.
+		pinner := NewImageDigestPinner(fakeClient)
+		pinner.resolver = newDigestResolver(server.Client(), time.Minute)
+		cluster := newCluster()
+		cluster.Spec.HeadGroupSpec.Template.Spec.ImagePullSecrets = nil
+		require.NoError(t, pinner.Default(context.Background(), cluster))
+		assert.Equal(t, image, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Image)
+		// The worker group still has the credentials of its service account.
+		assert.Equal(t, pinnedImage, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image)
+	})
+
+	t.Run("do nothing if the webhook is disabled", func(t *testing.T) {
+		pinner := NewImageDigestPinner(fakeClient)
+		pinner.resolver = newDigestResolver(server.Client(), time.Minute)
+		pinner.disabled = true
+		cluster := newCluster()
+		require.NoError(t, pinner.Default(context.Background(), cluster))
+		assert.Equal(t, image, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Image)
+	})
+}
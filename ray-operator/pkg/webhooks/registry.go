@@ -0,0 +1,327 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	dockerHubRegistry = "registry-1.docker.io"
+	defaultImageTag   = "latest"
+)
+
+var (
+	// manifestMediaTypes are the manifest types accepted from registries. Manifest lists and image indexes are preferred
+	// so that the pinned digest refers to the same multi-architecture image as the tag.
+	manifestMediaTypes = []string{
+		"application/vnd.oci.image.index.v1+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+	}
+	tagRegex            = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	digestRegex         = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-fA-F0-9]{32,}$`)
+	challengeParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// imageReference is a parsed container image reference such as `quay.io/kuberay/operator:v1.1.0`.
+type imageReference struct {
+	// registry is the host and optional port of the registry. Images without a registry are pulled from Docker Hub.
+	registry   string
+	repository string
+	tag        string
+	digest     string
+}
+
+// parseImageReference parses a container image reference the same way as the container runtime does.
+func parseImageReference(image string) (imageReference, error) {
+	ref := imageReference{}
+	name := image
+	if before, after, found := strings.Cut(name, "@"); found {
+		if !digestRegex.MatchString(after) {
+			return ref, fmt.Errorf("invalid digest in image %q", image)
+		}
+		name, ref.digest = before, after
+	}
+	// A colon after the last slash separates the tag. Colons before it belong to the port of the registry.
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.tag = name[:i], name[i+1:]
+		if !tagRegex.MatchString(ref.tag) {
+			return ref, fmt.Errorf("invalid tag in image %q", image)
+		}
+	}
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return ref, fmt.Errorf("invalid image %q", image)
+	}
+
+	if domain, repository, found := strings.Cut(name, "/"); found && (strings.ContainsAny(domain, ".:") || domain == "localhost") {
+		ref.registry, ref.repository = normalizeRegistry(domain), repository
+	} else {
+		ref.registry, ref.repository = dockerHubRegistry, name
+	}
+	if ref.registry == dockerHubRegistry && !strings.Contains(ref.repository, "/") {
+		ref.repository = "library/" + ref.repository
+	}
+	if ref.tag == "" && ref.digest == "" {
+		ref.tag = defaultImageTag
+	}
+	return ref, nil
+}
+
+// normalizeRegistry maps the aliases of Docker Hub to the host serving its registry API.
+func normalizeRegistry(registry string) string {
+	switch registry {
+	case "docker.io", "index.docker.io":
+		return dockerHubRegistry
+	}
+	return registry
+}
+
+// registryCredentials are the credentials used to pull images from a registry.
+type registryCredentials struct {
+	username string
+	password string
+}
+
+// dockerConfigEntry is an entry of a Docker config file, as stored in image pull Secrets.
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// credentialsFromSecret returns the registry credentials stored in an image pull Secret, keyed by registry.
+func credentialsFromSecret(secret *corev1.Secret) (map[string]registryCredentials, error) {
+	entries := map[string]dockerConfigEntry{}
+	switch secret.Type {
+	case corev1.SecretTypeDockerConfigJson:
+		config := struct {
+			Auths map[string]dockerConfigEntry `json:"auths"`
+		}{}
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			return nil, fmt.Errorf("failed to parse the image pull Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		entries = config.Auths
+	case corev1.SecretTypeDockercfg:
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse the image pull Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+	default:
+		return nil, nil
+	}
+
+	credentials := make(map[string]registryCredentials, len(entries))
+	for server, entry := range entries {
+		username, password := entry.Username, entry.Password
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode the credentials of %s in the image pull Secret %s/%s: %w", server, secret.Namespace, secret.Name, err)
+			}
+			username, password, _ = strings.Cut(string(decoded), ":")
+		}
+		// Servers may be written as URLs, e.g. `https://index.docker.io/v1/`.
+		host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+		host, _, _ = strings.Cut(host, "/")
+		credentials[normalizeRegistry(host)] = registryCredentials{username: username, password: password}
+	}
+	return credentials, nil
+}
+
+// cachedDigest is a digest resolved from a registry and the time until which it can be reused.
+type cachedDigest struct {
+	digest    string
+	expiresAt time.Time
+}
+
+// digestResolver resolves image tags to manifest digests through the Docker Registry HTTP API V2. Resolved digests are
+// cached, so all the RayClusters created with the same tag within the TTL are pinned to the same digest. Only registries
+// served over HTTPS are supported.
+type digestResolver struct {
+	httpClient *http.Client
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedDigest
+}
+
+func newDigestResolver(httpClient *http.Client, ttl time.Duration) *digestResolver {
+	return &digestResolver{
+		httpClient: httpClient,
+		ttl:        ttl,
+		now:        time.Now,
+		cache:      map[string]cachedDigest{},
+	}
+}
+
+// Resolve returns the digest of the manifest that the tag of the image reference points to.
+func (r *digestResolver) Resolve(ctx context.Context, ref imageReference, credentials *registryCredentials) (string, error) {
+	key := fmt.Sprintf("%s/%s:%s", ref.registry, ref.repository, ref.tag)
+	r.mu.Lock()
+	cached, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok && r.now().Before(cached.expiresAt) {
+		return cached.digest, nil
+	}
+
+	digest, err := r.fetchDigest(ctx, ref, credentials)
+	if err != nil {
+		return "", err
+	}
+	r.mu.Lock()
+	r.cache[key] = cachedDigest{digest: digest, expiresAt: r.now().Add(r.ttl)}
+	r.mu.Unlock()
+	return digest, nil
+}
+
+func (r *digestResolver) fetchDigest(ctx context.Context, ref imageReference, credentials *registryCredentials) (string, error) {
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", ref.registry, ref.repository, ref.tag)
+	resp, err := r.requestManifest(ctx, http.MethodHead, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	authorization := ""
+	if resp.StatusCode == http.StatusUnauthorized {
+		if authorization, err = r.authorize(ctx, ref, resp.Header.Get("WWW-Authenticate"), credentials); err != nil {
+			return "", err
+		}
+		if resp, err = r.requestManifest(ctx, http.MethodHead, manifestURL, authorization); err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get the manifest of %s/%s:%s: %s", ref.registry, ref.repository, ref.tag, resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		// Registries are not required to return the digest for HEAD requests. Fall back to hashing the manifest.
+		req, err := r.newManifestRequest(ctx, http.MethodGet, manifestURL, authorization)
+		if err != nil {
+			return "", err
+		}
+		resp, err := r.httpClient.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("failed to get the manifest of %s/%s:%s: %s", ref.registry, ref.repository, ref.tag, resp.Status)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256(body)
+		digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	if !digestRegex.MatchString(digest) {
+		return "", fmt.Errorf("registry %s returned an invalid digest %q for %s:%s", ref.registry, digest, ref.repository, ref.tag)
+	}
+	return digest, nil
+}
+
+func (r *digestResolver) newManifestRequest(ctx context.Context, method string, manifestURL string, authorization string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return req, nil
+}
+
+// requestManifest sends a request for a manifest and discards the response body.
+func (r *digestResolver) requestManifest(ctx context.Context, method string, manifestURL string, authorization string) (*http.Response, error) {
+	req, err := r.newManifestRequest(ctx, method, manifestURL, authorization)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return resp, nil
+}
+
+// authorize answers the authentication challenge of a registry and returns the value of the Authorization header.
+// See https://distribution.github.io/distribution/spec/auth/token/ for the token authentication flow.
+func (r *digestResolver) authorize(ctx context.Context, ref imageReference, challenge string, credentials *registryCredentials) (string, error) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for _, match := range challengeParamRegex.FindAllStringSubmatch(rest, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if credentials == nil {
+			return "", fmt.Errorf("registry %s requires credentials, but no image pull Secret has credentials for it", ref.registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials.username+":"+credentials.password)), nil
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return "", fmt.Errorf("registry %s returned an invalid authentication challenge %q", ref.registry, challenge)
+		}
+		query := realm.Query()
+		if service := params["service"]; service != "" {
+			query.Set("service", service)
+		}
+		scope := params["scope"]
+		if scope == "" {
+			scope = fmt.Sprintf("repository:%s:pull", ref.repository)
+		}
+		query.Set("scope", scope)
+		realm.RawQuery = query.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		if credentials != nil {
+			req.SetBasicAuth(credentials.username, credentials.password)
+		}
+		resp, err := r.httpClient.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("failed to get a token for %s/%s: %s", ref.registry, ref.repository, resp.Status)
+		}
+		token := struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}{}
+		if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+			return "", fmt.Errorf("failed to parse the token for %s/%s: %w", ref.registry, ref.repository, err)
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		if token.Token == "" {
+			return "", fmt.Errorf("registry %s returned an empty token for %s", ref.registry, ref.repository)
+		}
+		return "Bearer " + token.Token, nil
+	}
+	return "", fmt.Errorf("registry %s requires an unsupported authentication scheme %q", ref.registry, scheme)
+}
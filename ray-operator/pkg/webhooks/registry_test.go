@@ -0,0 +1,167 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	testDigest   = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	testToken    = "test-token"
+	testUsername = "user"
+	testPassword = "password"
+)
+
+// newTestRegistry starts a registry that serves the digest of `ray:2.9.0` and requires a token that is only issued to
+// the test credentials. It returns the host of the registry and a counter of manifest requests.
+func newTestRegistry(t *testing.T) (*httptest.Server, string, *int) {
+	manifestRequests := 0
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			username, password, ok := r.BasicAuth()
+			if !ok || username != testUsername || password != testPassword {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, "repository:rayproject/ray:pull", r.URL.Query().Get("scope"))
+			fmt.Fprintf(w, `{"token": %q}`, testToken)
+		case r.URL.Path == "/v2/rayproject/ray/manifests/2.9.0":
+			manifestRequests++
+			if r.Header.Get("Authorization") != "Bearer "+testToken {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Contains(t, r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json")
+			w.Header().Set("Docker-Content-Digest", testDigest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, strings.TrimPrefix(server.URL, "https://"), &manifestRequests
+}
+
+func TestParseImageReference(t *testing.T) {
+	tests := map[string]struct {
+		image    string
+		expected imageReference
+		hasError bool
+	}{
+		"Docker Hub official image without tag": {
+			image:    "ubuntu",
+			expected: imageReference{registry: dockerHubRegistry, repository: "library/ubuntu", tag: "latest"},
+		},
+		"Docker Hub image with tag": {
+			image:    "rayproject/ray:2.9.0",
+			expected: imageReference{registry: dockerHubRegistry, repository: "rayproject/ray", tag: "2.9.0"},
+		},
+		"Docker Hub alias": {
+			image:    "docker.io/rayproject/ray:2.9.0",
+			expected: imageReference{registry: dockerHubRegistry, repository: "rayproject/ray", tag: "2.9.0"},
+		},
+		"registry with port": {
+			image:    "localhost:5000/ray/ray:nightly",
+			expected: imageReference{registry: "localhost:5000", repository: "ray/ray", tag: "nightly"},
+		},
+		"registry with port and no tag": {
+			image:    "registry.example.com:5000/ray",
+			expected: imageReference{registry: "registry.example.com:5000", repository: "ray", tag: "latest"},
+		},
+		"pinned image": {
+			image:    "quay.io/kuberay/operator:v1.1.0@" + testDigest,
+			expected: imageReference{registry: "quay.io", repository: "kuberay/operator", tag: "v1.1.0", digest: testDigest},
+		},
+		"invalid digest": {
+			image:    "rayproject/ray@sha256:foo",
+			hasError: true,
+		},
+		"empty image": {
+			image:    "",
+			hasError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ref, err := parseImageReference(tc.image)
+			if tc.hasError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func TestCredentialsFromSecret(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte(testUsername + ":" + testPassword))
+	secret := &corev1.Secret{
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths": {
+				"https://index.docker.io/v1/": {"auth": "` + auth + `"},
+				"quay.io": {"username": "robot", "password": "secret"}
+			}}`),
+		},
+	}
+
+	credentials, err := credentialsFromSecret(secret)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]registryCredentials{
+		dockerHubRegistry: {username: testUsername, password: testPassword},
+		"quay.io":         {username: "robot", password: "secret"},
+	}, credentials)
+
+	// Secrets of other types do not contain registry credentials.
+	credentials, err = credentialsFromSecret(&corev1.Secret{Type: corev1.SecretTypeOpaque})
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+}
+
+func TestDigestResolver(t *testing.T) {
+	server, registry, manifestRequests := newTestRegistry(t)
+	resolver := newDigestResolver(server.Client(), time.Minute)
+	now := time.Now()
+	resolver.now = func() time.Time { return now }
+	ctx := context.Background()
+	ref := imageReference{registry: registry, repository: "rayproject/ray", tag: "2.9.0"}
+
+	// The registry does not issue tokens without credentials.
+	_, err := resolver.Resolve(ctx, ref, nil)
+	assert.Error(t, err)
+
+	credentials := &registryCredentials{username: testUsername, password: testPassword}
+	digest, err := resolver.Resolve(ctx, ref, credentials)
+	require.NoError(t, err)
+	assert.Equal(t, testDigest, digest)
+	requests := *manifestRequests
+
+	// The digest is cached until the TTL expires.
+	digest, err = resolver.Resolve(ctx, ref, credentials)
+	require.NoError(t, err)
+	assert.Equal(t, testDigest, digest)
+	assert.Equal(t, requests, *manifestRequests)
+
+	now = now.Add(2 * time.Minute)
+	_, err = resolver.Resolve(ctx, ref, credentials)
+	require.NoError(t, err)
+	assert.Greater(t, *manifestRequests, requests)
+
+	// Unknown tags are not resolved.
+	_, err = resolver.Resolve(ctx, imageReference{registry: registry, repository: "rayproject/ray", tag: "unknown"}, credentials)
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package e2e
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	rayv1ac "github.com/ray-project/kuberay/ray-operator/pkg/client/applyconfiguration/ray/v1"
+	. "github.com/ray-project/kuberay/ray-operator/test/support"
+)
+
+func TestRayClusterHeadFailover(t *testing.T) {
+	test := With(t)
+
+	// Create a namespace
+	namespace := test.NewTestNamespace()
+	test.StreamKubeRayOperatorLogs()
+
+	rayClusterAC := rayv1ac.RayCluster("head-failover", namespace.Name).
+		WithSpec(newRayClusterSpec())
+
+	rayCluster, err := test.Client().Ray().RayV1().RayClusters(namespace.Name).Apply(test.Ctx(), rayClusterAC, TestApplyOptions)
+	test.Expect(err).NotTo(HaveOccurred())
+	test.T().Logf("Created RayCluster %s/%s successfully", rayCluster.Namespace, rayCluster.Name)
+
+	test.T().Logf("Waiting for RayCluster %s/%s to become ready", rayCluster.Namespace, rayCluster.Name)
+	test.Eventually(RayCluster(test, rayCluster.Namespace, rayCluster.Name), TestTimeoutMedium).
+		Should(WithTransform(RayClusterState, Equal(rayv1.Ready)))
+
+	// Simulate a failure of the head Pod by deleting it.
+	headPod := GetHeadPod(test, rayCluster)
+	err = test.Client().Core().CoreV1().Pods(headPod.Namespace).Delete(test.Ctx(), headPod.Name, metav1.DeleteOptions{})
+	test.Expect(err).NotTo(HaveOccurred())
+	test.T().Logf("Deleted head Pod %s/%s successfully", headPod.Namespace, headPod.Name)
+
+	// Assert that KubeRay creates a new head Pod and that it becomes ready.
+	test.Eventually(HeadPod(test, rayCluster), TestTimeoutMedium).
+		Should(WithTransform(func(pod *corev1.Pod) types.UID { return pod.UID }, Not(Equal(headPod.UID))))
+	test.Eventually(HeadPod(test, rayCluster), TestTimeoutMedium).
+		Should(WithTransform(utils.IsRunningAndReady, BeTrue()))
+
+	// Assert that the worker Pods reconnect to the new head Pod.
+	test.Eventually(RayCluster(test, rayCluster.Namespace, rayCluster.Name), TestTimeoutLong).
+		Should(WithTransform(RayClusterAvailableWorkerReplicas, Equal(int32(1))))
+	test.Expect(GetRayCluster(test, rayCluster.Namespace, rayCluster.Name)).
+		To(WithTransform(RayClusterState, Equal(rayv1.Ready)))
+}
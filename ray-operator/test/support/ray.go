@@ -82,6 +82,22 @@ func RayClusterDesiredWorkerReplicas(cluster *rayv1.RayCluster) int32 {
 	return cluster.Status.DesiredWorkerReplicas
 }
 
+func RayClusterAvailableWorkerReplicas(cluster *rayv1.RayCluster) int32 {
+	return cluster.Status.AvailableWorkerReplicas
+}
+
+func HeadPod(t Test, rayCluster *rayv1.RayCluster) func(g gomega.Gomega) *corev1.Pod {
+	return func(g gomega.Gomega) *corev1.Pod {
+		pods, err := t.Client().Core().CoreV1().Pods(rayCluster.Namespace).List(
+			t.Ctx(),
+			common.RayClusterHeadPodsAssociationOptions(rayCluster).ToMetaV1ListOptions(),
+		)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(pods.Items).To(gomega.HaveLen(1))
+		return &pods.Items[0]
+	}
+}
+
 func GetHeadPod(t Test, rayCluster *rayv1.RayCluster) *corev1.Pod {
 	t.T().Helper()
 	pods, err := t.Client().Core().CoreV1().Pods(rayCluster.Namespace).List(